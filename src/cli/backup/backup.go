@@ -3,7 +3,10 @@ package backup
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/alcionai/clues"
 	"github.com/pkg/errors"
@@ -15,9 +18,11 @@ import (
 	"github.com/alcionai/corso/src/internal/common/color"
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/kopia"
 	"github.com/alcionai/corso/src/internal/observe"
 	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/verify"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/errs/core"
 	"github.com/alcionai/corso/src/pkg/logger"
@@ -38,6 +43,9 @@ var subCommandFuncs = []func() *cobra.Command{
 	listCmd,
 	detailsCmd,
 	deleteCmd,
+	membershipCmd,
+	diffCmd,
+	historyCmd,
 }
 
 var serviceCommands = []func(cmd *cobra.Command) *cobra.Command{
@@ -46,6 +54,7 @@ var serviceCommands = []func(cmd *cobra.Command) *cobra.Command{
 	addSharePointCommands,
 	addGroupsCommands,
 	addTeamsChatsCommands,
+	addDirectoryCommands,
 }
 
 // AddCommands attaches all `corso backup * *` commands to the parent.
@@ -59,10 +68,32 @@ func AddCommands(cmd *cobra.Command) {
 
 		for _, addBackupTo := range serviceCommands {
 			sc := addBackupTo(subCommand)
+
+			// not every subcommand is supported by every service.
+			if sc == nil {
+				continue
+			}
+
 			flags.AddAllProviderFlags(sc)
 			flags.AddAllStorageFlags(sc)
 		}
 	}
+
+	// verification works the same for backups of every service.
+	verifyC, _ := utils.AddCommand(backupC, verifyCmd(), utils.MarkPreviewCommand())
+	flags.AddSampleFlag(verifyC)
+	flags.AddAllProviderFlags(verifyC)
+	flags.AddAllStorageFlags(verifyC)
+
+	// navigating the folders of a backup works the same for every service.
+	lsC, _ := utils.AddCommand(backupC, lsCmd(), utils.MarkPreviewCommand())
+	flags.AddAllProviderFlags(lsC)
+	flags.AddAllStorageFlags(lsC)
+
+	treeC, _ := utils.AddCommand(backupC, treeCmd(), utils.MarkPreviewCommand())
+	flags.AddDepthFlag(treeC)
+	flags.AddAllProviderFlags(treeC)
+	flags.AddAllStorageFlags(treeC)
 }
 
 // ---------------------------------------------------------------------------
@@ -163,6 +194,283 @@ func handleDeleteCmd(cmd *cobra.Command, args []string) error {
 	return cmd.Help()
 }
 
+// The backup membership subcommand.
+// `corso backup membership <service> [<flag>...]`
+var membershipCommand = "membership"
+
+func membershipCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   membershipCommand,
+		Short: "Shows the membership recorded by a backup",
+		RunE:  handleMembershipCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// Handler for calls to `corso backup membership`.
+// Produces the same output as `corso backup membership --help`.
+func handleMembershipCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// The backup diff subcommand.
+// `corso backup diff <service> <oldBackupId> <newBackupId> [<flag>...]`
+var diffCommand = "diff"
+
+func diffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   diffCommand,
+		Short: "Shows the changes between two backups",
+		RunE:  handleDiffCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// Handler for calls to `corso backup diff`.
+// Produces the same output as `corso backup diff --help`.
+func handleDiffCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// The backup history subcommand.
+// `corso backup history <service> [<flag>...]`
+var historyCommand = "history"
+
+func historyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   historyCommand,
+		Short: "Lists the backed up versions of items",
+		RunE:  handleHistoryCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// Handler for calls to `corso backup history`.
+// Produces the same output as `corso backup history --help`.
+func handleHistoryCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// The backup verify subcommand.
+// `corso backup verify <backupId> [<flag>...]`
+var verifyCommand = "verify"
+
+const verifyCommandExamples = `# Verify that every item in backup 1234abcd... can be restored
+corso backup verify 1234abcd-12ab-cd34-56de-1234abcd
+
+# Read the data of a random sample of 1000 items, instead of every item
+corso backup verify 1234abcd-12ab-cd34-56de-1234abcd --sample 1000
+
+# Produce the verification report as json
+corso backup verify 1234abcd-12ab-cd34-56de-1234abcd --json`
+
+func verifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   verifyCommand + " <backupId>",
+		Short: "Verifies the integrity of a backup",
+		Long: `Verifies that a backup can be restored.  The backup's details and errors
+are read, every item in the details is matched to data stored in the backup
+and vice versa, and the data of every item is read to check that it
+decrypts and decompresses to the expected size.  For files in OneDrive and
+SharePoint libraries, the size is also checked against the details.
+
+Exits with an error if the backup fails verification.`,
+		Example: verifyCommandExamples,
+		RunE:    handleVerifyCmd,
+		Args:    cobra.ExactArgs(1),
+	}
+}
+
+// Handler for calls to `corso backup verify`.
+func handleVerifyCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	rep, err := verifyCore(ctx, r, args[0], flags.SampleFV)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	rep.Print(ctx)
+
+	if !rep.Passed {
+		return Only(ctx, clues.New("Backup "+args[0]+" failed verification"))
+	}
+
+	return nil
+}
+
+// verifyCore runs the verification of the backup.
+func verifyCore(
+	ctx context.Context,
+	bv repository.BackupVerifier,
+	backupID string,
+	sample int,
+) (*verify.Report, error) {
+	ctx = clues.Add(ctx, "backup_id", backupID)
+
+	rep, err := bv.VerifyBackup(ctx, backupID, max(sample, 0))
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return nil, clues.New("No backup exists with the id " + backupID)
+		}
+
+		return nil, clues.Wrap(err, "Failed to verify backup "+backupID)
+	}
+
+	return rep, nil
+}
+
+// The backup ls subcommand.
+// `corso backup ls <backupId> [<path>] [<flag>...]`
+var lsCommand = "ls"
+
+const lsCommandExamples = `# List the top level folders of backup 1234abcd...
+corso backup ls 1234abcd-12ab-cd34-56de-1234abcd
+
+# List the subfolders and emails in the Inbox/Important folder
+corso backup ls 1234abcd-12ab-cd34-56de-1234abcd Inbox/Important
+
+# Folder names that contain a '/' escape it with a '\'
+corso backup ls 1234abcd-12ab-cd34-56de-1234abcd "Reports/2023\/2024"`
+
+func lsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   lsCommand + " <backupId> [<path>]",
+		Short: "Lists the folders and items in a folder of a backup",
+		Long: `Lists the subfolders and items in a folder of a backup, along with the
+size and number of items held in each subfolder.  The path accepts the same
+syntax as the restore --folder flags, and defaults to the top of the backup.`,
+		Example: lsCommandExamples,
+		RunE:    handleLsCmd,
+		Args:    cobra.RangeArgs(1, 2),
+	}
+}
+
+// Handler for calls to `corso backup ls`.
+func handleLsCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	n, err := backupFolderCore(ctx, r, args[0], folderArg(args))
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	details.PrintListing(ctx, n)
+
+	return nil
+}
+
+// The backup tree subcommand.
+// `corso backup tree <backupId> [<path>] [<flag>...]`
+var treeCommand = "tree"
+
+const treeCommandExamples = `# Show the size and number of items of every folder in backup 1234abcd...
+corso backup tree 1234abcd-12ab-cd34-56de-1234abcd
+
+# Only show the Documents folder and its direct subfolders
+corso backup tree 1234abcd-12ab-cd34-56de-1234abcd Documents --depth 1`
+
+func treeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   treeCommand + " <backupId> [<path>]",
+		Short: "Shows the folder tree of a backup",
+		Long: `Shows a folder of a backup and all of its subfolders, along with the size
+and number of items held in each folder, including the items in its
+subfolders.  The path accepts the same syntax as the restore --folder flags,
+and defaults to the top of the backup.`,
+		Example: treeCommandExamples,
+		RunE:    handleTreeCmd,
+		Args:    cobra.RangeArgs(1, 2),
+	}
+}
+
+// Handler for calls to `corso backup tree`.
+func handleTreeCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	n, err := backupFolderCore(ctx, r, args[0], folderArg(args))
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	details.PrintTree(ctx, n, flags.DepthFV)
+
+	return nil
+}
+
+// folderArg returns the folder path argument, if one was provided.
+func folderArg(args []string) string {
+	if len(args) < 2 {
+		return ""
+	}
+
+	return args[1]
+}
+
+// backupFolderCore arranges the details of the backup into their folder
+// hierarchy, and returns the folder at the path.
+func backupFolderCore(
+	ctx context.Context,
+	bg repository.BackupGetter,
+	backupID, folder string,
+) (*details.FolderNode, error) {
+	ctx = clues.Add(ctx, "backup_id", backupID)
+
+	elems, err := details.SplitFolderPath(folder)
+	if err != nil {
+		return nil, clues.Wrap(err, "Invalid folder path "+folder)
+	}
+
+	d, _, errs := bg.GetBackupDetails(ctx, backupID)
+	if errs.Failure() != nil {
+		if errors.Is(errs.Failure(), data.ErrNotFound) {
+			return nil, clues.New("No backup exists with the id " + backupID)
+		}
+
+		return nil, clues.Wrap(errs.Failure(), "Failed to get details of backup "+backupID)
+	}
+
+	root, err := details.Tree(d)
+	if err != nil {
+		return nil, clues.Wrap(err, "arranging details of backup "+backupID)
+	}
+
+	return root.Find(elems)
+}
+
 // ---------------------------------------------------------------------------
 // common handlers
 // ---------------------------------------------------------------------------
@@ -178,11 +486,24 @@ func genericCreateCommand(
 	ins idname.Cacher,
 ) error {
 	var (
-		bIDs []string
+		bIDs      []string
+		estimates []backup.Estimate
+		total     = backup.Estimate{
+			ProtectedResource: "all",
+			Category:          backup.AllCategories,
+		}
 		errs = []error{}
 	)
 
+	ctx, interrupted, stopInterrupts := handleInterrupts(ctx)
+	defer stopInterrupts()
+
 	for _, discSel := range selectorSet {
+		// don't start any more backups once the user asks to stop.
+		if interrupted() {
+			break
+		}
+
 		discSel.Configure(defaultSelectorConfig)
 
 		var (
@@ -226,6 +547,16 @@ func genericCreateCommand(
 			cerr := clues.Wrap(err, owner)
 			errs = append(errs, cerr)
 
+			if errors.Is(err, core.ErrBackupInterrupted) {
+				Errf(
+					ictx,
+					"Backup of %s interrupted.  Any items backed up before the interruption were saved, "+
+						"and won't be downloaded again by the next backup.",
+					bo.ResourceOwner.Name())
+
+				continue
+			}
+
 			Errf(
 				ictx,
 				"%s\nCause: %s",
@@ -235,6 +566,19 @@ func genericCreateCommand(
 			continue
 		}
 
+		if bo.Options.DryRun {
+			es := backup.NewEstimates(
+				bo.ResourceOwner.Name(),
+				bo.Results.Counts,
+				bo.Results.CategoryCounts,
+				bo.Results.UnsizedCategories)
+
+			estimates = append(estimates, es...)
+			total = total.Add(es[len(es)-1])
+
+			continue
+		}
+
 		bIDs = append(bIDs, string(bo.Results.BackupID))
 
 		if !DisplayJSONFormat() {
@@ -245,6 +589,16 @@ func genericCreateCommand(
 		}
 	}
 
+	if len(estimates) > 0 {
+		if len(selectorSet) > 1 {
+			estimates = append(estimates, total)
+		}
+
+		Info(ctx, "\nBackup Estimates:")
+		backup.PrintEstimates(ctx, estimates)
+		Info(ctx, "Bytes only include files in OneDrive and SharePoint libraries; other item sizes aren't known until the items are fetched.")
+	}
+
 	bups, berrs := r.Backups(ctx, bIDs)
 	if berrs.Failure() != nil {
 		return Only(ctx, clues.Wrap(berrs.Failure(), "Unable to retrieve backup results from storage"))
@@ -269,6 +623,53 @@ func genericCreateCommand(
 	return nil
 }
 
+// handleInterrupts makes the first SIGINT or SIGTERM stop the running backup
+// gracefully, whether it's still enumerating items or already uploading them.
+// Any items uploaded so far get saved before the backup exits.
+// A second signal exits immediately.  Returns a func that reports whether an
+// interrupt was received, and a func that releases the signal handling.
+func handleInterrupts(ctx context.Context) (context.Context, func() bool, func()) {
+	var (
+		sigs      = make(chan os.Signal, 1)
+		interrupt = make(chan struct{})
+		done      = make(chan struct{})
+	)
+
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-sigs:
+			// restores the default handling, so that another signal exits.
+			signal.Stop(sigs)
+			close(interrupt)
+
+			Info(
+				ctx,
+				"\nInterrupted: stopping the backup and saving any items backed up so far.  "+
+					"Interrupt again to exit immediately.")
+
+		case <-done:
+		}
+	}()
+
+	interrupted := func() bool {
+		select {
+		case <-interrupt:
+			return true
+		default:
+			return false
+		}
+	}
+
+	stop := func() {
+		signal.Stop(sigs)
+		close(done)
+	}
+
+	return kopia.BindInterrupt(ctx, interrupt), interrupted, stop
+}
+
 // genericDeleteCommand is a helper function that all services can use
 // for the removal of an entry from the repository
 func genericDeleteCommand(
@@ -414,6 +815,238 @@ func genericDetailsCore(
 	return d, nil
 }
 
+// genericDiffCommand is a helper function that all services can use to
+// display the changes between two backups.
+func genericDiffCommand(
+	cmd *cobra.Command,
+	prevID, currID string,
+	sel selectors.Selector,
+) error {
+	ctx := cmd.Context()
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, sel.PathService())
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	changes, err := genericDiffCore(ctx, r, prevID, currID, sel)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	details.PrintChanges(ctx, changes)
+
+	return nil
+}
+
+// genericDiffCore compares the details of two backups of the same protected
+// resource, after reducing both to the selection.
+func genericDiffCore(
+	ctx context.Context,
+	bg repository.BackupGetter,
+	prevID, currID string,
+	sel selectors.Selector,
+) ([]details.Change, error) {
+	ctx = clues.Add(ctx, "prev_backup_id", prevID, "curr_backup_id", currID)
+
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+
+	prev, prevBup, err := reducedDetails(ctx, bg, prevID, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	curr, currBup, err := reducedDetails(ctx, bg, currID, sel)
+	if err != nil {
+		return nil, err
+	}
+
+	if prevBup.Selector.Service != currBup.Selector.Service ||
+		prevBup.Selector.DiscreteOwner != currBup.Selector.DiscreteOwner {
+		return nil, clues.New("Backups " + prevID + " and " + currID + " are of different protected resources")
+	}
+
+	changes, err := details.Compare(prev, curr)
+	if err != nil {
+		return nil, clues.Wrap(err, "Failed to compare backups")
+	}
+
+	return changes, nil
+}
+
+// reducedDetails retrieves the backup's details, reduced to the entries
+// matching the selector.
+func reducedDetails(
+	ctx context.Context,
+	bg repository.BackupGetter,
+	backupID string,
+	sel selectors.Selector,
+) (*details.Details, *backup.Backup, error) {
+	d, bup, errs := bg.GetBackupDetails(ctx, backupID)
+	if errs.Failure() != nil {
+		if errors.Is(errs.Failure(), data.ErrNotFound) {
+			return nil, nil, clues.New("No backup exists with the id " + backupID)
+		}
+
+		return nil, nil, clues.Wrap(errs.Failure(), "Failed to get details of backup "+backupID)
+	}
+
+	if bup.Selector.Service != sel.Service {
+		return nil, nil, clues.New("Backup " + backupID + " is not a " + sel.PathService().HumanString() + " backup")
+	}
+
+	d, err := sel.Reduce(ctx, d, errs)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "filtering details of backup "+backupID)
+	}
+
+	return d, bup, nil
+}
+
+// itemFlagByCategory maps each category to the restore and export flag
+// that selects its items.  Those flags accept the item's details ID.
+var itemFlagByCategory = map[path.CategoryType]string{
+	path.EmailCategory:             flags.EmailFN,
+	path.ContactsCategory:          flags.ContactFN,
+	path.EventsCategory:            flags.EventFN,
+	path.FilesCategory:             flags.FileFN,
+	path.LibrariesCategory:         flags.FileFN,
+	path.ListsCategory:             flags.ListFN,
+	path.PagesCategory:             flags.PageFN,
+	path.ChannelMessagesCategory:   flags.MessageFN,
+	path.ConversationPostsCategory: flags.PostFN,
+}
+
+// genericHistoryCommand is a helper function that all services can use to
+// display the versions of the selected items across all backups of a
+// protected resource.
+func genericHistoryCommand(
+	cmd *cobra.Command,
+	serviceCommand, resource string,
+	version int,
+	sel selectors.Selector,
+) error {
+	ctx := cmd.Context()
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, sel.PathService())
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	hs, err := genericHistoryCore(ctx, r, resource, sel)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	if version > 0 {
+		hs = historyVersion(hs, version)
+	}
+
+	if len(hs) == 0 {
+		Info(ctx, selectors.ErrorNoMatchingItems)
+		return nil
+	}
+
+	details.PrintHistory(ctx, hs)
+
+	if version > 0 && !DisplayJSONFormat() {
+		printVersionShortcuts(ctx, serviceCommand, hs)
+	}
+
+	return nil
+}
+
+// genericHistoryCore collects the selected items from every backup of the
+// protected resource, and groups them into item histories.
+func genericHistoryCore(
+	ctx context.Context,
+	bg repository.BackupGetter,
+	resource string,
+	sel selectors.Selector,
+) ([]details.ItemHistory, error) {
+	ctx = clues.Add(ctx, "protected_resource", resource)
+
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+
+	bs, err := bg.BackupsByTag(ctx, store.Service(sel.PathService()))
+	if err != nil {
+		return nil, clues.Wrap(err, "Failed to list backups in the repository")
+	}
+
+	bds := []details.BackupDetails{}
+
+	for _, b := range bs {
+		if !strings.EqualFold(b.Selector.DiscreteOwner, resource) &&
+			!strings.EqualFold(b.Selector.DiscreteOwnerName, resource) {
+			continue
+		}
+
+		bid := string(b.ID)
+
+		d, _, err := reducedDetails(ctx, bg, bid, sel)
+		if err != nil {
+			return nil, err
+		}
+
+		bds = append(bds, details.BackupDetails{
+			BackupID:  bid,
+			CreatedAt: b.CreationTime,
+			Details:   d,
+		})
+	}
+
+	if len(bds) == 0 {
+		return nil, clues.New("No " + sel.PathService().HumanString() + " backups exist for " + resource)
+	}
+
+	hs, err := details.History(bds)
+	if err != nil {
+		return nil, clues.Wrap(err, "Failed to compare backups")
+	}
+
+	return hs, nil
+}
+
+// historyVersion reduces each item's history to the given version.  Items
+// with fewer versions are dropped.
+func historyVersion(hs []details.ItemHistory, version int) []details.ItemHistory {
+	res := []details.ItemHistory{}
+
+	for _, h := range hs {
+		if version > len(h.Versions) {
+			continue
+		}
+
+		h.Versions = []details.ItemVersion{h.Versions[version-1]}
+		res = append(res, h)
+	}
+
+	return res
+}
+
+// printVersionShortcuts lists the commands that restore and export each
+// item's version.
+func printVersionShortcuts(ctx context.Context, serviceCommand string, hs []details.ItemHistory) {
+	Info(ctx, "\nRestore or export these versions with:")
+
+	for _, h := range hs {
+		fn, ok := itemFlagByCategory[path.ToCategoryType(h.Category)]
+		if !ok {
+			continue
+		}
+
+		v := h.Latest()
+		sel := fmt.Sprintf("--backup %s --%s %s", v.BackupID, fn, v.ShortRef)
+
+		Infof(ctx, "corso restore %s %s", serviceCommand, sel)
+		Infof(ctx, "corso export %s <destination> %s", serviceCommand, sel)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // helper funcs
 // ---------------------------------------------------------------------------
@@ -1,20 +1,33 @@
 package backup
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/cli/flags"
+	flagsTD "github.com/alcionai/corso/src/cli/flags/testdata"
+	cliTD "github.com/alcionai/corso/src/cli/testdata"
+	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/cli/utils/testdata"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/model"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	dtd "github.com/alcionai/corso/src/pkg/backup/details/testdata"
+	"github.com/alcionai/corso/src/pkg/backup/verify"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
 )
 
 type BackupUnitSuite struct {
@@ -95,3 +108,500 @@ func (suite *BackupUnitSuite) TestGenericDetailsCore_empty() {
 	require.Error(t, err, "has error")
 	assert.ErrorIs(t, err, ErrEmptyBackup, clues.ToCore(err))
 }
+
+type detailsBackupGetter struct {
+	*testdata.MockBackupGetter
+	details map[string]*details.Details
+	backups map[string]*backup.Backup
+}
+
+func (bg detailsBackupGetter) BackupsByTag(
+	context.Context,
+	...store.FilterOption,
+) ([]*backup.Backup, error) {
+	res := make([]*backup.Backup, 0, len(bg.backups))
+	for _, b := range bg.backups {
+		res = append(res, b)
+	}
+
+	return res, nil
+}
+
+func (bg detailsBackupGetter) GetBackupDetails(
+	_ context.Context,
+	backupID string,
+) (*details.Details, *backup.Backup, *fault.Bus) {
+	errs := fault.New(false)
+
+	d, ok := bg.details[backupID]
+	if !ok {
+		errs.Fail(clues.Stack(data.ErrNotFound))
+	}
+
+	return d, bg.backups[backupID], errs
+}
+
+func mailEntry(id, folder string, modified time.Time) details.Entry {
+	return details.Entry{
+		RepoRef:     "tenant/exchange/user/email/" + folder + "/" + id,
+		ShortRef:    id,
+		ParentRef:   "tenant/exchange/user/email/" + folder,
+		LocationRef: folder,
+		ItemRef:     id,
+		ItemInfo: details.ItemInfo{
+			Exchange: &details.ExchangeInfo{
+				ItemType: details.ExchangeMail,
+				Subject:  id,
+				Modified: modified,
+			},
+		},
+	}
+}
+
+func (suite *BackupUnitSuite) TestGenericDiffCore() {
+	var (
+		then  = time.Now().Add(-time.Hour).UTC()
+		now   = time.Now().UTC()
+		esel  = selectors.NewExchangeBackup([]string{"user"})
+		osel  = selectors.NewOneDriveBackup([]string{"user"})
+		other = selectors.NewExchangeBackup([]string{"other"})
+	)
+
+	bg := detailsBackupGetter{
+		details: map[string]*details.Details{
+			"prev": {
+				DetailsModel: details.DetailsModel{
+					Entries: []details.Entry{
+						mailEntry("kept", "Inbox", then),
+						mailEntry("edited", "Inbox", then),
+						mailEntry("moved", "Inbox", then),
+						mailEntry("deleted", "Inbox", then),
+					},
+				},
+			},
+			"curr": {
+				DetailsModel: details.DetailsModel{
+					Entries: []details.Entry{
+						mailEntry("kept", "Inbox", then),
+						mailEntry("edited", "Inbox", now),
+						mailEntry("moved", "Archive", then),
+						mailEntry("added", "Archive", now),
+					},
+				},
+			},
+			"other":    {},
+			"onedrive": {},
+		},
+		backups: map[string]*backup.Backup{
+			"prev":     {Selector: esel.Selector},
+			"curr":     {Selector: esel.Selector},
+			"other":    {Selector: other.Selector},
+			"onedrive": {Selector: osel.Selector},
+		},
+	}
+
+	table := []struct {
+		name          string
+		prevID        string
+		currID        string
+		expectChanges map[string]details.ChangeType
+		expectErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "changes",
+			prevID: "prev",
+			currID: "curr",
+			expectChanges: map[string]details.ChangeType{
+				"added":   details.ItemAdded,
+				"edited":  details.ItemModified,
+				"moved":   details.ItemMoved,
+				"deleted": details.ItemDeleted,
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:          "no changes",
+			prevID:        "prev",
+			currID:        "prev",
+			expectChanges: map[string]details.ChangeType{},
+			expectErr:     assert.NoError,
+		},
+		{
+			name:      "no backup",
+			prevID:    "prev",
+			currID:    "missing",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "different protected resources",
+			prevID:    "prev",
+			currID:    "other",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "different service",
+			prevID:    "prev",
+			currID:    "onedrive",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sel := selectors.NewExchangeRestore(selectors.Any())
+			sel.Include(sel.AllData())
+
+			changes, err := genericDiffCore(ctx, bg, test.prevID, test.currID, sel.Selector)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			result := map[string]details.ChangeType{}
+			for _, c := range changes {
+				result[c.ItemRef] = c.Change
+			}
+
+			assert.Equal(t, test.expectChanges, result)
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestGenericHistoryCore() {
+	var (
+		first  = time.Now().Add(-2 * time.Hour).UTC()
+		second = time.Now().Add(-time.Hour).UTC()
+		esel   = selectors.NewExchangeBackup([]string{"user"})
+		other  = selectors.NewExchangeBackup([]string{"other"})
+	)
+
+	esel.DiscreteOwnerName = "user@example.com"
+
+	bg := detailsBackupGetter{
+		details: map[string]*details.Details{
+			"first": {
+				DetailsModel: details.DetailsModel{
+					Entries: []details.Entry{
+						mailEntry("kept", "Inbox", first),
+						mailEntry("edited", "Inbox", first),
+					},
+				},
+			},
+			"second": {
+				DetailsModel: details.DetailsModel{
+					Entries: []details.Entry{
+						mailEntry("kept", "Archive", first),
+						mailEntry("edited", "Inbox", second),
+					},
+				},
+			},
+			"other": {
+				DetailsModel: details.DetailsModel{
+					Entries: []details.Entry{
+						mailEntry("others", "Inbox", first),
+					},
+				},
+			},
+		},
+		backups: map[string]*backup.Backup{
+			"first": {
+				BaseModel:    model.BaseModel{ID: "first"},
+				CreationTime: first,
+				Selector:     esel.Selector,
+			},
+			"second": {
+				BaseModel:    model.BaseModel{ID: "second"},
+				CreationTime: second,
+				Selector:     esel.Selector,
+			},
+			"other": {
+				BaseModel:    model.BaseModel{ID: "other"},
+				CreationTime: second,
+				Selector:     other.Selector,
+			},
+		},
+	}
+
+	table := []struct {
+		name           string
+		resource       string
+		expectVersions map[string][]string
+		expectErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "by id",
+			resource: "user",
+			expectVersions: map[string][]string{
+				"kept":   {"first"},
+				"edited": {"first", "second"},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:     "by name",
+			resource: "USER@example.com",
+			expectVersions: map[string][]string{
+				"kept":   {"first"},
+				"edited": {"first", "second"},
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "no backups",
+			resource:  "missing",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sel := selectors.NewExchangeRestore(selectors.Any())
+			sel.Include(sel.AllData())
+
+			hs, err := genericHistoryCore(ctx, bg, test.resource, sel.Selector)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			result := map[string][]string{}
+
+			for _, h := range hs {
+				for _, v := range h.Versions {
+					result[h.ItemRef] = append(result[h.ItemRef], v.BackupID)
+				}
+			}
+
+			assert.Equal(t, test.expectVersions, result)
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestHistoryVersion() {
+	t := suite.T()
+
+	hs := []details.ItemHistory{
+		{
+			ItemRef:  "one",
+			Versions: []details.ItemVersion{{Version: 1, BackupID: "a"}},
+		},
+		{
+			ItemRef: "two",
+			Versions: []details.ItemVersion{
+				{Version: 1, BackupID: "a"},
+				{Version: 2, BackupID: "b"},
+			},
+		},
+	}
+
+	result := historyVersion(hs, 2)
+	require.Len(t, result, 1)
+	assert.Equal(t, "two", result[0].ItemRef)
+	assert.Equal(t, []details.ItemVersion{{Version: 2, BackupID: "b"}}, result[0].Versions)
+
+	// the input histories are left unchanged.
+	assert.Len(t, hs[1].Versions, 2)
+}
+
+func (suite *BackupUnitSuite) TestVerifyFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: "backup"},
+		func(parent *cobra.Command) *cobra.Command {
+			c, _ := utils.AddCommand(parent, verifyCmd())
+			flags.AddSampleFlag(c)
+
+			return c
+		},
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			verifyCommand,
+			[]string{
+				flagsTD.BackupInput,
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.SampleFN, "10",
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.Equal(t, 10, flags.SampleFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
+func (suite *BackupUnitSuite) TestTreeFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: "backup"},
+		func(parent *cobra.Command) *cobra.Command {
+			c, _ := utils.AddCommand(parent, treeCmd())
+			flags.AddDepthFlag(c)
+
+			return c
+		},
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			treeCommand,
+			[]string{
+				flagsTD.BackupInput,
+				"Inbox/Important",
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.DepthFN, "2",
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.Equal(t, 2, flags.DepthFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
+func (suite *BackupUnitSuite) TestBackupFolderCore() {
+	now := time.Now().UTC()
+
+	bg := detailsBackupGetter{
+		MockBackupGetter: &testdata.MockBackupGetter{},
+		details: map[string]*details.Details{
+			"bid": {DetailsModel: details.DetailsModel{Entries: []details.Entry{
+				mailEntry("m1", "Inbox", now),
+				mailEntry("m2", "Inbox/Important", now),
+				mailEntry("m3", "Inbox/Important", now),
+			}}},
+		},
+	}
+
+	table := []struct {
+		name        string
+		backupID    string
+		folder      string
+		expectCount int
+		expectErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "top of the backup",
+			backupID:    "bid",
+			expectCount: 3,
+			expectErr:   assert.NoError,
+		},
+		{
+			name:        "folder",
+			backupID:    "bid",
+			folder:      "/Inbox/Important/",
+			expectCount: 2,
+			expectErr:   assert.NoError,
+		},
+		{
+			name:      "missing folder",
+			backupID:  "bid",
+			folder:    "Inbox/Other",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "no backup",
+			backupID:  "other",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			n, err := backupFolderCore(ctx, bg, test.backupID, test.folder)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err == nil {
+				assert.Equal(t, test.expectCount, n.ItemCount)
+			}
+		})
+	}
+}
+
+type mockBackupVerifier struct {
+	report *verify.Report
+	sample int
+	err    error
+}
+
+func (bv *mockBackupVerifier) VerifyBackup(
+	_ context.Context,
+	_ string,
+	sample int,
+) (*verify.Report, error) {
+	bv.sample = sample
+	return bv.report, bv.err
+}
+
+func (suite *BackupUnitSuite) TestVerifyCore() {
+	rep := &verify.Report{BackupID: "bid", Passed: true}
+
+	table := []struct {
+		name         string
+		bv           *mockBackupVerifier
+		sample       int
+		expectSample int
+		expectErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:         "verified",
+			bv:           &mockBackupVerifier{report: rep},
+			sample:       10,
+			expectSample: 10,
+			expectErr:    assert.NoError,
+		},
+		{
+			name:      "negative sample reads everything",
+			bv:        &mockBackupVerifier{report: rep},
+			sample:    -1,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "no backup",
+			bv:        &mockBackupVerifier{err: clues.Stack(data.ErrNotFound)},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "error",
+			bv:        &mockBackupVerifier{err: assert.AnError},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			result, err := verifyCore(ctx, test.bv, "bid", test.sample)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectSample, test.bv.sample)
+
+			if test.bv.err == nil {
+				assert.Equal(t, rep, result)
+			}
+		})
+	}
+}
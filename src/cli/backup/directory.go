@@ -0,0 +1,342 @@
+package backup
+
+import (
+	"fmt"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// ------------------------------------------------------------------------------------------------
+// setup and globals
+// ------------------------------------------------------------------------------------------------
+
+const (
+	directoryServiceCommand                 = "directory"
+	directoryServiceCommandDeleteUseSuffix  = "--backups <backupId>"
+	directoryServiceCommandDetailsUseSuffix = "--backup <backupId>"
+	directoryServiceCommandDiffUseSuffix    = "<oldBackupId> <newBackupId>"
+)
+
+const (
+	directoryServiceCommandCreateExamples = `# Backup all users, groups, app registrations, service principals,
+# conditional access policies and administrative units in the tenant
+corso backup create directory
+
+# Backup only the tenant's users and groups
+corso backup create directory --data users,groups`
+
+	directoryServiceCommandDeleteExamples = `# Delete directory backup with ID 1234abcd-12ab-cd34-56de-1234abcd \
+and 1234abcd-12ab-cd34-56de-1234abce
+corso backup delete directory --backups 1234abcd-12ab-cd34-56de-1234abcd,1234abcd-12ab-cd34-56de-1234abce`
+
+	directoryServiceCommandDetailsExamples = `# Explore the directory objects in the latest backup (1234abcd...)
+corso backup details directory --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Explore the group named "Marketing" in the backup
+corso backup details directory --backup 1234abcd-12ab-cd34-56de-1234abcd --directory-group Marketing
+
+# Explore all users whose principal name contains "contoso.com"
+corso backup details directory --backup 1234abcd-12ab-cd34-56de-1234abcd --user-principal-name contoso.com`
+
+	directoryServiceCommandDiffExamples = `# Show the items added, modified, moved, or deleted between two of the tenant's
+# backups (1234abcd... and 5678efgh...)
+corso backup diff directory 1234abcd-12ab-cd34-56de-1234abcd 5678efgh-56ef-gh78-90ij-5678efgh
+
+# Only compare the group named "Marketing"
+corso backup diff directory 1234abcd-12ab-cd34-56de-1234abcd 5678efgh-56ef-gh78-90ij-5678efgh \
+    --directory-group Marketing`
+)
+
+// called by backup.go to map subcommands to provider-specific handling.
+func addDirectoryCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case createCommand:
+		c, _ = utils.AddCommand(cmd, directoryCreateCmd(), utils.MarkPreReleaseCommand())
+
+		c.Example = directoryServiceCommandCreateExamples
+
+		// Flags addition ordering should follow the order we want them to appear in help and docs:
+		flags.AddDataFlag(
+			c,
+			[]string{
+				flags.DataDirectoryUsers,
+				flags.DataDirectoryGroups,
+				flags.DataApplications,
+				flags.DataServicePrincipals,
+				flags.DataConditionalAccess,
+				flags.DataAdminUnits,
+			},
+			false)
+		flags.AddGenericBackupFlags(c)
+
+	case listCommand:
+		c, _ = utils.AddCommand(cmd, directoryListCmd(), utils.MarkPreReleaseCommand())
+
+		flags.AddBackupIDFlag(c, false)
+		flags.AddAllBackupListFlags(c)
+
+	case detailsCommand:
+		c, _ = utils.AddCommand(cmd, directoryDetailsCmd(), utils.MarkPreReleaseCommand())
+
+		c.Use = c.Use + " " + directoryServiceCommandDetailsUseSuffix
+		c.Example = directoryServiceCommandDetailsExamples
+
+		flags.AddSkipReduceFlag(c)
+
+		// Flags addition ordering should follow the order we want them to appear in help and docs:
+		// More generic (ex: --user) and more frequently used flags take precedence.
+		flags.AddBackupIDFlag(c, true)
+		flags.AddDirectoryDetailsAndRestoreFlags(c)
+
+	case diffCommand:
+		c, _ = utils.AddCommand(cmd, directoryDiffCmd(), utils.MarkPreReleaseCommand())
+
+		c.Use = c.Use + " " + directoryServiceCommandDiffUseSuffix
+		c.Example = directoryServiceCommandDiffExamples
+
+		flags.AddDirectoryDetailsAndRestoreFlags(c)
+
+	case deleteCommand:
+		c, _ = utils.AddCommand(cmd, directoryDeleteCmd(), utils.MarkPreReleaseCommand())
+
+		c.Use = c.Use + " " + directoryServiceCommandDeleteUseSuffix
+		c.Example = directoryServiceCommandDeleteExamples
+
+		flags.AddMultipleBackupIDsFlag(c, false)
+		flags.AddBackupIDFlag(c, false)
+	}
+
+	return c
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup create
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup create directory [<flag>...]`
+func directoryCreateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   directoryServiceCommand,
+		Short: "Backup the M365 tenant directory",
+		RunE:  createDirectoryCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// processes a directory backup.
+func createDirectoryCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	// the directory belongs to the tenant in the account configuration,
+	// so a backup can be created without any flags.
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	if err := validateDirectoryBackupCreateFlags(flags.CategoryDataFV); err != nil {
+		return err
+	}
+
+	if _, err := utils.ParseDownloadRate(); err != nil {
+		return err
+	}
+
+	r, acct, err := utils.AccountConnectAndWriteRepoConfig(
+		ctx,
+		cmd,
+		path.DirectoryService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	creds, err := acct.M365Config()
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to parse m365 account config"))
+	}
+
+	svcCli, err := m365.NewM365Client(ctx, *acct)
+	if err != nil {
+		return Only(ctx, clues.Stack(err))
+	}
+
+	id, name, err := svcCli.AC.Directory().GetIDAndName(ctx, creds.AzureTenantID, api.CallConfig{})
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to retrieve M365 tenant"))
+	}
+
+	ins := idname.NewCache(map[string]string{id: name})
+	sel := utils.AddDirectoryCategories(selectors.NewDirectoryBackup([]string{id}), flags.CategoryDataFV)
+
+	return genericCreateCommand(
+		ctx,
+		r,
+		"Directory",
+		[]selectors.Selector{sel.Selector},
+		ins)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup list
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup list directory [<flag>...]`
+func directoryListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   directoryServiceCommand,
+		Short: "List the history of M365 directory backups",
+		RunE:  listDirectoryCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// lists the history of backup operations
+func listDirectoryCmd(cmd *cobra.Command, args []string) error {
+	return genericListCommand(cmd, flags.BackupIDFV, path.DirectoryService, args)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup details
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup details directory [<flag>...]`
+func directoryDetailsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   directoryServiceCommand,
+		Short: "Shows the details of a M365 directory backup",
+		RunE:  detailsDirectoryCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// processes a directory backup.
+func detailsDirectoryCmd(cmd *cobra.Command, args []string) error {
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	return runDetailsDirectoryCmd(cmd)
+}
+
+func runDetailsDirectoryCmd(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	opts := utils.MakeDirectoryOpts(cmd)
+
+	sel := utils.IncludeDirectoryRestoreDataSelectors(ctx, opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterDirectoryRestoreInfoSelectors(sel, opts)
+
+	ds, err := genericDetailsCommand(cmd, flags.BackupIDFV, sel.Selector)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	if len(ds.Entries) > 0 {
+		ds.PrintEntries(ctx)
+	} else {
+		Info(ctx, selectors.ErrorNoMatchingItems)
+	}
+
+	return nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup diff
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup diff directory <oldBackupId> <newBackupId> [<flag>...]`
+func directoryDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   directoryServiceCommand,
+		Short: "Shows the changes between two M365 directory backups",
+		RunE:  diffDirectoryCmd,
+		Args:  cobra.ExactArgs(2),
+	}
+}
+
+// lists the items that changed between the two backups, running the
+// details of both first through selector reduction as a filtering step.
+func diffDirectoryCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	opts := utils.MakeDirectoryOpts(cmd)
+
+	sel := utils.IncludeDirectoryRestoreDataSelectors(cmd.Context(), opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterDirectoryRestoreInfoSelectors(sel, opts)
+
+	return genericDiffCommand(cmd, args[0], args[1], sel.Selector)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup delete
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup delete directory [<flag>...]`
+func directoryDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   directoryServiceCommand,
+		Short: "Delete backed-up M365 directory data",
+		RunE:  deleteDirectoryCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// deletes a directory backup.
+func deleteDirectoryCmd(cmd *cobra.Command, args []string) error {
+	backupIDValue := []string{}
+
+	if len(flags.BackupIDsFV) > 0 {
+		backupIDValue = flags.BackupIDsFV
+	} else if len(flags.BackupIDFV) > 0 {
+		backupIDValue = append(backupIDValue, flags.BackupIDFV)
+	} else {
+		return clues.New("either --backup or --backups flag is required")
+	}
+
+	return genericDeleteCommand(cmd, path.DirectoryService, "Directory", backupIDValue, args)
+}
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+func validateDirectoryBackupCreateFlags(cats []string) error {
+	msg := fmt.Sprintf(
+		" is an unrecognized data type; only %s, %s, %s, %s, %s and %s are supported",
+		flags.DataDirectoryUsers,
+		flags.DataDirectoryGroups,
+		flags.DataApplications,
+		flags.DataServicePrincipals,
+		flags.DataConditionalAccess,
+		flags.DataAdminUnits)
+
+	allowedCats := utils.DirectoryAllowedCategories()
+
+	for _, d := range cats {
+		if _, ok := allowedCats[d]; !ok {
+			return clues.New(d + msg)
+		}
+	}
+
+	return nil
+}
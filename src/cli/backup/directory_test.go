@@ -0,0 +1,195 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	flagsTD "github.com/alcionai/corso/src/cli/flags/testdata"
+	cliTD "github.com/alcionai/corso/src/cli/testdata"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type DirectoryUnitSuite struct {
+	tester.Suite
+}
+
+func TestDirectoryUnitSuite(t *testing.T) {
+	suite.Run(t, &DirectoryUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *DirectoryUnitSuite) TestAddDirectoryCommands() {
+	expectUse := directoryServiceCommand
+
+	table := []struct {
+		name        string
+		use         string
+		expectUse   string
+		expectShort string
+		expectRunE  func(*cobra.Command, []string) error
+	}{
+		{
+			name:        "create directory",
+			use:         createCommand,
+			expectUse:   expectUse,
+			expectShort: directoryCreateCmd().Short,
+			expectRunE:  createDirectoryCmd,
+		},
+		{
+			name:        "list directory",
+			use:         listCommand,
+			expectUse:   expectUse,
+			expectShort: directoryListCmd().Short,
+			expectRunE:  listDirectoryCmd,
+		},
+		{
+			name:        "details directory",
+			use:         detailsCommand,
+			expectUse:   expectUse + " " + directoryServiceCommandDetailsUseSuffix,
+			expectShort: directoryDetailsCmd().Short,
+			expectRunE:  detailsDirectoryCmd,
+		},
+		{
+			name:        "diff directory",
+			use:         diffCommand,
+			expectUse:   expectUse + " " + directoryServiceCommandDiffUseSuffix,
+			expectShort: directoryDiffCmd().Short,
+			expectRunE:  diffDirectoryCmd,
+		},
+		{
+			name:        "delete directory",
+			use:         deleteCommand,
+			expectUse:   expectUse + " " + directoryServiceCommandDeleteUseSuffix,
+			expectShort: directoryDeleteCmd().Short,
+			expectRunE:  deleteDirectoryCmd,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			cmd := &cobra.Command{Use: test.use}
+
+			c := addDirectoryCommands(cmd)
+			require.NotNil(t, c)
+
+			cmds := cmd.Commands()
+			require.Len(t, cmds, 1)
+
+			child := cmds[0]
+			assert.Equal(t, test.expectUse, child.Use)
+			assert.Equal(t, test.expectShort, child.Short)
+			tester.AreSameFunc(t, test.expectRunE, child.RunE)
+		})
+	}
+}
+
+func (suite *DirectoryUnitSuite) TestValidateDirectoryBackupCreateFlags() {
+	table := []struct {
+		name   string
+		cats   []string
+		expect assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "none",
+			cats:   []string{},
+			expect: assert.NoError,
+		},
+		{
+			name:   "groups",
+			cats:   []string{flags.DataDirectoryGroups},
+			expect: assert.NoError,
+		},
+		{
+			name: "all allowed",
+			cats: []string{
+				flags.DataDirectoryUsers,
+				flags.DataDirectoryGroups,
+				flags.DataApplications,
+				flags.DataServicePrincipals,
+				flags.DataConditionalAccess,
+				flags.DataAdminUnits,
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:   "bad inputs",
+			cats:   []string{"foo"},
+			expect: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := validateDirectoryBackupCreateFlags(test.cats)
+			test.expect(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *DirectoryUnitSuite) TestBackupCreateFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: createCommand},
+		addDirectoryCommands,
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			directoryServiceCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.CategoryDataFN, flagsTD.FlgInputs(flagsTD.DirectoryCategoryDataInput),
+			},
+			flagsTD.PreparedGenericBackupFlags(),
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	backupOpts := utils.ParseBackupOptions()
+
+	assert.Equal(t, control.FailFast, backupOpts.FailureHandling)
+	assert.ElementsMatch(t, flagsTD.DirectoryCategoryDataInput, flags.CategoryDataFV)
+	flagsTD.AssertGenericBackupFlags(t, cmd)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
+func (suite *DirectoryUnitSuite) TestBackupDetailsFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: detailsCommand},
+		addDirectoryCommands,
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			directoryServiceCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.BackupFN, flagsTD.BackupInput,
+				"--" + flags.SkipReduceFN,
+			},
+			flagsTD.PreparedDirectoryFlags(),
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	co := utils.Control()
+
+	assert.Equal(t, flagsTD.BackupInput, flags.BackupIDFV)
+	assert.True(t, co.SkipReduce)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+	flagsTD.AssertDirectoryFlags(t, cmd)
+}
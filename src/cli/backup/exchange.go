@@ -1,12 +1,15 @@
 package backup
 
 import (
+	"slices"
+
 	"github.com/alcionai/clues"
 	"github.com/spf13/cobra"
 
 	"github.com/alcionai/corso/src/cli/flags"
 	. "github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
@@ -27,6 +30,8 @@ const (
 	exchangeServiceCommandCreateUseSuffix  = "--mailbox <email> | '" + flags.Wildcard + "'"
 	exchangeServiceCommandDeleteUseSuffix  = "--backups <backupId>"
 	exchangeServiceCommandDetailsUseSuffix = "--backup <backupId>"
+	exchangeServiceCommandDiffUseSuffix    = "<oldBackupId> <newBackupId>"
+	exchangeServiceCommandHistoryUseSuffix = "--mailbox <email>"
 )
 
 const (
@@ -37,7 +42,17 @@ corso backup create exchange --mailbox alice@example.com
 corso backup create exchange --mailbox alice@example.com,bob@example.com --data contacts
 
 # Backup all Exchange data for all M365 users 
-corso backup create exchange --mailbox '*'`
+corso backup create exchange --mailbox '*'
+
+# Backup Alice's email, including Recoverable Items and leaving out Junk Email
+corso backup create exchange --mailbox alice@example.com --data email \
+    --include-mail-folders recoverable-items --exclude-mail-folders junk
+
+# Backup Alice's email along with the original MIME content of each message
+corso backup create exchange --mailbox alice@example.com --data email --mail-mime include
+
+# Estimate the folders and items in a first backup of Alice's mailbox
+corso backup create exchange --mailbox alice@example.com --dry-run`
 
 	exchangeServiceCommandDeleteExamples = `# Delete Exchange backup with IDs 1234abcd-12ab-cd34-56de-1234abcd \
 and 1234abcd-12ab-cd34-56de-1234abce
@@ -57,6 +72,21 @@ corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
 # Explore contacts named Andy
 corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --contact-name Andy`
+
+	exchangeServiceCommandDiffExamples = `# Show the items added, modified, moved, or deleted between two of Alice's
+# backups (1234abcd... and 5678efgh...)
+corso backup diff exchange 1234abcd-12ab-cd34-56de-1234abcd 5678efgh-56ef-gh78-90ij-5678efgh
+
+# Only compare the emails in the folder "Inbox"
+corso backup diff exchange 1234abcd-12ab-cd34-56de-1234abcd 5678efgh-56ef-gh78-90ij-5678efgh \
+    --email '*' --email-folder Inbox`
+
+	exchangeServiceCommandHistoryExamples = `# List every backed up version of the emails with the subject "Budget" in Alice's mailbox
+corso backup history exchange --mailbox alice@example.com --email '*' --email-subject Budget
+
+# Show the commands that restore or export the second version of those emails
+corso backup history exchange --mailbox alice@example.com --email '*' --email-subject Budget \
+    --item-version 2`
 )
 
 // called by backup.go to map subcommands to provider-specific handling.
@@ -78,7 +108,10 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddDisableDeltaFlag(c)
 		flags.AddEnableImmutableIDFlag(c)
 		flags.AddDeltaPageSizeFlag(c)
+		flags.AddMailFoldersFlags(c)
+		flags.AddMailMIMEFlag(c)
 		flags.AddGenericBackupFlags(c)
+		flags.AddIndexContentFlag(c)
 		flags.AddDisableSlidingWindowLimiterFlag(c)
 
 	case listCommand:
@@ -100,6 +133,24 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddExchangeDetailsAndRestoreFlags(c, false)
 
+	case diffCommand:
+		c, _ = utils.AddCommand(cmd, exchangeDiffCmd())
+
+		c.Use = c.Use + " " + exchangeServiceCommandDiffUseSuffix
+		c.Example = exchangeServiceCommandDiffExamples
+
+		flags.AddExchangeDetailsAndRestoreFlags(c, false)
+
+	case historyCommand:
+		c, _ = utils.AddCommand(cmd, exchangeHistoryCmd())
+
+		c.Use = c.Use + " " + exchangeServiceCommandHistoryUseSuffix
+		c.Example = exchangeServiceCommandHistoryExamples
+
+		flags.AddHistoryResourceFlag(c, flags.MailBoxFN, "mailbox")
+		flags.AddItemVersionFlag(c)
+		flags.AddExchangeDetailsAndRestoreFlags(c, false)
+
 	case deleteCommand:
 		c, _ = utils.AddCommand(cmd, exchangeDeleteCmd())
 
@@ -143,6 +194,21 @@ func createExchangeCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if err := validateMailFoldersFlags(
+		flags.IncludeMailFoldersFV,
+		flags.ExcludeMailFoldersFV,
+		flags.CategoryDataFV); err != nil {
+		return err
+	}
+
+	if err := validateMailMIMEFlag(flags.MailMIMEFV); err != nil {
+		return err
+	}
+
+	if _, err := utils.ParseDownloadRate(); err != nil {
+		return err
+	}
+
 	r, acct, err := utils.AccountConnectAndWriteRepoConfig(
 		ctx,
 		cmd,
@@ -154,6 +220,7 @@ func createExchangeCmd(cmd *cobra.Command, args []string) error {
 	defer utils.CloseRepo(ctx, r)
 
 	sel := exchangeBackupCreateSelectors(flags.UserFV, flags.CategoryDataFV)
+	addMailFoldersSelectors(sel, flags.IncludeMailFoldersFV, flags.ExcludeMailFoldersFV)
 
 	ins, err := utils.UsersMap(
 		ctx,
@@ -202,6 +269,18 @@ func exchangeBackupCreateSelectors(userIDs, cats []string) *selectors.ExchangeBa
 	return sel
 }
 
+// addMailFoldersSelectors adds the well-known mail folders to include in, or
+// exclude from, the email backup.
+func addMailFoldersSelectors(sel *selectors.ExchangeBackup, includes, excludes []string) {
+	for _, f := range includes {
+		sel.Include(sel.MailWellKnownFolder(f))
+	}
+
+	for _, f := range excludes {
+		sel.Exclude(sel.MailWellKnownFolder(f))
+	}
+}
+
 func validateExchangeBackupCreateFlags(userIDs, cats []string) error {
 	if len(userIDs) == 0 {
 		return clues.New("--user/--mailbox requires one or more email addresses or the wildcard '*'")
@@ -217,6 +296,31 @@ func validateExchangeBackupCreateFlags(userIDs, cats []string) error {
 	return nil
 }
 
+func validateMailFoldersFlags(includes, excludes, cats []string) error {
+	folders := append(slices.Clone(includes), excludes...)
+
+	if len(folders) > 0 && len(cats) > 0 && !slices.Contains(cats, dataEmail) {
+		return clues.New("--" + flags.IncludeMailFoldersFN + " and --" + flags.ExcludeMailFoldersFN +
+			" require the " + dataEmail + " data type")
+	}
+
+	for _, f := range folders {
+		if !slices.Contains(control.WellKnownMailFolders, control.WellKnownMailFolder(f)) {
+			return clues.New(f + " is an unrecognized mail folder; see --" + flags.IncludeMailFoldersFN + " for accepted values")
+		}
+	}
+
+	return nil
+}
+
+func validateMailMIMEFlag(mode string) error {
+	if len(mode) == 0 || slices.Contains(control.MailMIMEModes, control.MailMIMEMode(mode)) {
+		return nil
+	}
+
+	return clues.New(mode + " is an unrecognized mail mime mode; see --" + flags.MailMIMEFN + " for accepted values")
+}
+
 // ------------------------------------------------------------------------------------------------
 // backup list
 // ------------------------------------------------------------------------------------------------
@@ -286,6 +390,71 @@ func runDetailsExchangeCmd(cmd *cobra.Command) error {
 	return nil
 }
 
+// ------------------------------------------------------------------------------------------------
+// backup diff
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup diff exchange <oldBackupId> <newBackupId> [<flag>...]`
+func exchangeDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   exchangeServiceCommand,
+		Short: "Shows the changes between two M365 Exchange service backups",
+		RunE:  diffExchangeCmd,
+		Args:  cobra.ExactArgs(2),
+	}
+}
+
+// lists the items that changed between the two backups, running the
+// details of both first through selector reduction as a filtering step.
+func diffExchangeCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	opts := utils.MakeExchangeOpts(cmd)
+
+	sel := utils.IncludeExchangeRestoreDataSelectors(opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterExchangeRestoreInfoSelectors(sel, opts)
+
+	return genericDiffCommand(cmd, args[0], args[1], sel.Selector)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup history
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup history exchange --mailbox <mailbox> [<flag>...]`
+func exchangeHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   exchangeServiceCommand,
+		Short: "Lists the backed up versions of M365 Exchange items",
+		RunE:  historyExchangeCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// lists the versions of the selected items across all backups of the
+// mailbox, using selector reduction on the details of each backup.
+func historyExchangeCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	opts := utils.MakeExchangeOpts(cmd)
+
+	sel := utils.IncludeExchangeRestoreDataSelectors(opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterExchangeRestoreInfoSelectors(sel, opts)
+
+	return genericHistoryCommand(
+		cmd,
+		exchangeServiceCommand,
+		flags.HistoryResourceFV,
+		flags.ItemVersionFV,
+		sel.Selector)
+}
+
 // ------------------------------------------------------------------------------------------------
 // backup delete
 // ------------------------------------------------------------------------------------------------
@@ -57,6 +57,20 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 			expectShort: exchangeDetailsCmd().Short,
 			expectRunE:  detailsExchangeCmd,
 		},
+		{
+			name:        "diff exchange",
+			use:         diffCommand,
+			expectUse:   expectUse + " " + exchangeServiceCommandDiffUseSuffix,
+			expectShort: exchangeDiffCmd().Short,
+			expectRunE:  diffExchangeCmd,
+		},
+		{
+			name:        "history exchange",
+			use:         historyCommand,
+			expectUse:   expectUse + " " + exchangeServiceCommandHistoryUseSuffix,
+			expectShort: exchangeHistoryCmd().Short,
+			expectRunE:  historyExchangeCmd,
+		},
 		{
 			name:        "delete exchange",
 			use:         deleteCommand,
@@ -104,6 +118,9 @@ func (suite *ExchangeUnitSuite) TestBackupCreateFlags() {
 				"--" + flags.CategoryDataFN, flagsTD.FlgInputs(flagsTD.ExchangeCategoryDataInput),
 				"--" + flags.FetchParallelismFN, flagsTD.FetchParallelism,
 				"--" + flags.DeltaPageSizeFN, flagsTD.DeltaPageSize,
+				"--" + flags.IncludeMailFoldersFN, string(control.RecoverableItems),
+				"--" + flags.ExcludeMailFoldersFN, string(control.JunkEmail),
+				"--" + flags.MailMIMEFN, string(control.MailMIMEInclude),
 
 				// bool flags
 				"--" + flags.DisableDeltaFN,
@@ -129,6 +146,9 @@ func (suite *ExchangeUnitSuite) TestBackupCreateFlags() {
 	assert.True(t, backupOpts.M365.DisableDeltaEndpoint)
 	assert.True(t, backupOpts.M365.ExchangeImmutableIDs)
 	assert.True(t, backupOpts.ServiceRateLimiter.DisableSlidingWindowLimiter)
+	assert.ElementsMatch(t, []string{string(control.RecoverableItems)}, flags.IncludeMailFoldersFV)
+	assert.ElementsMatch(t, []string{string(control.JunkEmail)}, flags.ExcludeMailFoldersFV)
+	assert.Equal(t, control.MailMIMEInclude, backupOpts.M365.MailMIME)
 
 	assert.Equal(t, flagsTD.FetchParallelism, strconv.Itoa(co.Parallelism.ItemFetch))
 	assert.Equal(t, flagsTD.DeltaPageSize, strconv.Itoa(int(co.DeltaPageSize)))
@@ -201,6 +221,35 @@ func (suite *ExchangeUnitSuite) TestBackupDetailsFlags() {
 	flagsTD.AssertStorageFlags(t, cmd)
 }
 
+func (suite *ExchangeUnitSuite) TestBackupDiffFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: diffCommand},
+		addExchangeCommands,
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			exchangeServiceCommand,
+			[]string{
+				"old-backup-id",
+				flagsTD.BackupInput,
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.EmailFolderFN, flagsTD.FlgInputs(flagsTD.EmailFldInput),
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	opts := utils.MakeExchangeOpts(cmd)
+
+	assert.ElementsMatch(t, flagsTD.EmailFldInput, opts.EmailFolder)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
 func (suite *ExchangeUnitSuite) TestBackupDeleteFlags() {
 	t := suite.T()
 
@@ -263,6 +312,110 @@ func (suite *ExchangeUnitSuite) TestValidateBackupCreateFlags() {
 	}
 }
 
+func (suite *ExchangeUnitSuite) TestValidateMailFoldersFlags() {
+	table := []struct {
+		name               string
+		includes, excludes []string
+		cats               []string
+		expect             assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "none",
+			expect: assert.NoError,
+		},
+		{
+			name:     "known folders",
+			includes: []string{string(control.RecoverableItems)},
+			excludes: []string{string(control.JunkEmail), string(control.Drafts)},
+			expect:   assert.NoError,
+		},
+		{
+			name:     "unknown include",
+			includes: []string{"smurfs"},
+			expect:   assert.Error,
+		},
+		{
+			name:     "unknown exclude",
+			excludes: []string{"inbox"},
+			expect:   assert.Error,
+		},
+		{
+			name:     "with email data",
+			includes: []string{string(control.RecoverableItems)},
+			cats:     []string{dataEmail, dataEvents},
+			expect:   assert.NoError,
+		},
+		{
+			name:     "without email data",
+			excludes: []string{string(control.JunkEmail)},
+			cats:     []string{dataContacts},
+			expect:   assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			err := validateMailFoldersFlags(test.includes, test.excludes, test.cats)
+			test.expect(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *ExchangeUnitSuite) TestValidateMailMIMEFlag() {
+	table := []struct {
+		name   string
+		mode   string
+		expect assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "none",
+			expect: assert.NoError,
+		},
+		{
+			name:   "include",
+			mode:   string(control.MailMIMEInclude),
+			expect: assert.NoError,
+		},
+		{
+			name:   "only",
+			mode:   string(control.MailMIMEOnly),
+			expect: assert.NoError,
+		},
+		{
+			name:   "unknown",
+			mode:   "smurfs",
+			expect: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			err := validateMailMIMEFlag(test.mode)
+			test.expect(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *ExchangeUnitSuite) TestAddMailFoldersSelectors() {
+	t := suite.T()
+
+	sel := exchangeBackupCreateSelectors([]string{"user"}, []string{dataEmail})
+	addMailFoldersSelectors(
+		sel,
+		[]string{string(control.RecoverableItems)},
+		[]string{string(control.JunkEmail), string(control.RecoverableItemsPurges)})
+
+	cfg := sel.MailFoldersConfig()
+
+	assert.True(t, cfg.IsIncluded(control.RecoverableItemsDeletions))
+	assert.True(t, cfg.IsIncluded(control.RecoverableItemsVersions))
+	assert.False(t, cfg.IsIncluded(control.RecoverableItemsPurges))
+	assert.False(t, cfg.IsIncluded(control.JunkEmail))
+	assert.True(t, cfg.IsIncluded(control.Drafts))
+}
+
 func (suite *ExchangeUnitSuite) TestExchangeBackupCreateSelectors() {
 	table := []struct {
 		name             string
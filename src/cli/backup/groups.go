@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/slices"
 
@@ -12,9 +13,12 @@ import (
 	. "github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/pkg/backup/membership"
+	"github.com/alcionai/corso/src/pkg/errs/core"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/filters"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/repository"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365"
 )
@@ -29,6 +33,9 @@ const (
 	groupsServiceCommandCreateUseSuffix  = "--group <groupName> | '" + flags.Wildcard + "'"
 	groupsServiceCommandDeleteUseSuffix  = "--backups <backupId>"
 	groupsServiceCommandDetailsUseSuffix = "--backup <backupId>"
+	groupsServiceCommandDiffUseSuffix    = "<oldBackupId> <newBackupId>"
+	groupsServiceCommandHistoryUseSuffix = "--group <groupName>"
+	groupsServiceCommandMembershipSuffix = "--backup <backupId>"
 )
 
 const (
@@ -41,6 +48,15 @@ corso backup create groups --group Marketing --data messages
 # Backup only group mailbox posts
 corso backup create groups --group Marketing --data conversations
 
+# Backup only the group calendar events
+corso backup create groups --group Marketing --data events
+
+# Backup only Planner plans and tasks
+corso backup create groups --group Marketing --data plans
+
+# Backup only the team's settings, channels, tabs, and installed apps
+corso backup create groups --group Marketing --data team-structure
+
 # Backup all Groups and Teams data for all groups
 corso backup create groups --group '*'`
 
@@ -56,7 +72,34 @@ corso backup details groups --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --last-message-reply-after 2022-01-01T00:00:00
 
 # Explore group mailbox posts with conversation subject "hello world"
-corso backup details groups --backup 1234abcd-12ab-cd34-56de-1234abcd --conversation "hello world"`
+corso backup details groups --backup 1234abcd-12ab-cd34-56de-1234abcd --conversation "hello world"
+
+# Explore Marketing's group calendar events starting after the start of 2022
+corso backup details groups --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --event-starts-after 2022-01-01T00:00:00
+
+# Explore the tasks in Marketing's "Launch" plan
+corso backup details groups --backup 1234abcd-12ab-cd34-56de-1234abcd --plan Launch`
+
+	groupsServiceCommandDiffExamples = `# Show the items added, modified, moved, or deleted between two of Marketing's
+# backups (1234abcd... and 5678efgh...)
+corso backup diff groups 1234abcd-12ab-cd34-56de-1234abcd 5678efgh-56ef-gh78-90ij-5678efgh
+
+# Only compare the tasks in Marketing's "Launch" plan
+corso backup diff groups 1234abcd-12ab-cd34-56de-1234abcd 5678efgh-56ef-gh78-90ij-5678efgh --plan Launch`
+
+	groupsServiceCommandHistoryExamples = `# List every backed up version of the file "Fiscal 22" in Marketing's library
+corso backup history groups --group Marketing --file "Fiscal 22"
+
+# Show the commands that restore or export the second version of that file
+corso backup history groups --group Marketing --file "Fiscal 22" --item-version 2`
+
+	groupsServiceCommandMembershipExamples = `# List the owners and members recorded in Marketing's latest backup (1234abcd...)
+corso backup membership groups --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Show who joined or left Marketing between an earlier backup (1234abce...) and the latest one
+corso backup membership groups --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --compare-backup 1234abce-12ab-cd34-56de-1234abce`
 )
 
 // called by backup.go to map subcommands to provider-specific handling.
@@ -72,10 +115,23 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 
 		// Flags addition ordering should follow the order we want them to appear in help and docs:
 		flags.AddGroupFlag(c)
-		flags.AddDataFlag(c, []string{flags.DataLibraries, flags.DataMessages, flags.DataConversations}, false)
+		flags.AddDataFlag(
+			c,
+			[]string{
+				flags.DataLibraries,
+				flags.DataMessages,
+				flags.DataConversations,
+				flags.DataEvents,
+				flags.DataPlans,
+				flags.DataTeamStructure,
+			},
+			false)
 		flags.AddFetchParallelismFlag(c)
 		flags.AddDisableDeltaFlag(c)
 		flags.AddGenericBackupFlags(c)
+		flags.AddDriveVersionsFlags(c)
+		flags.AddIndexContentFlag(c)
+		flags.AddFileFilterFlags(c)
 		flags.AddDisableLazyItemReader(c)
 
 	case listCommand:
@@ -98,6 +154,26 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddGroupDetailsAndRestoreFlags(c)
 		flags.AddSharePointDetailsAndRestoreFlags(c)
 
+	case diffCommand:
+		c, _ = utils.AddCommand(cmd, groupsDiffCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + groupsServiceCommandDiffUseSuffix
+		c.Example = groupsServiceCommandDiffExamples
+
+		flags.AddGroupDetailsAndRestoreFlags(c)
+		flags.AddSharePointDetailsAndRestoreFlags(c)
+
+	case historyCommand:
+		c, _ = utils.AddCommand(cmd, groupsHistoryCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + groupsServiceCommandHistoryUseSuffix
+		c.Example = groupsServiceCommandHistoryExamples
+
+		flags.AddHistoryResourceFlag(c, flags.GroupFN, "group")
+		flags.AddItemVersionFlag(c)
+		flags.AddGroupDetailsAndRestoreFlags(c)
+		flags.AddSharePointDetailsAndRestoreFlags(c)
+
 	case deleteCommand:
 		c, _ = utils.AddCommand(cmd, groupsDeleteCmd(), utils.MarkPreviewCommand())
 
@@ -106,6 +182,15 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddMultipleBackupIDsFlag(c, false)
 		flags.AddBackupIDFlag(c, false)
+
+	case membershipCommand:
+		c, _ = utils.AddCommand(cmd, groupsMembershipCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + groupsServiceCommandMembershipSuffix
+		c.Example = groupsServiceCommandMembershipExamples
+
+		flags.AddBackupIDFlag(c, true)
+		flags.AddCompareBackupFlag(c)
 	}
 
 	return c
@@ -142,6 +227,15 @@ func createGroupsCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	ff, err := utils.MakeFileFilter()
+	if err != nil {
+		return err
+	}
+
+	if _, err := utils.ParseDownloadRate(); err != nil {
+		return err
+	}
+
 	r, acct, err := utils.AccountConnectAndWriteRepoConfig(
 		ctx,
 		cmd,
@@ -166,6 +260,8 @@ func createGroupsCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	sel := groupsBackupCreateSelectors(ctx, ins, flags.GroupFV, flags.CategoryDataFV)
+	sel.FilterFiles(ff)
+
 	selectorSet := []selectors.Selector{}
 
 	for _, discSel := range sel.SplitByResourceOwner(ins.IDs()) {
@@ -248,6 +344,71 @@ func runDetailsGroupsCmd(cmd *cobra.Command) error {
 	return nil
 }
 
+// ------------------------------------------------------------------------------------------------
+// backup diff
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup diff groups <oldBackupId> <newBackupId> [<flag>...]`
+func groupsDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   groupsServiceCommand,
+		Short: "Shows the changes between two M365 Groups service backups",
+		RunE:  diffGroupsCmd,
+		Args:  cobra.ExactArgs(2),
+	}
+}
+
+// lists the items that changed between the two backups, running the
+// details of both first through selector reduction as a filtering step.
+func diffGroupsCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	opts := utils.MakeGroupsOpts(cmd)
+
+	sel := utils.IncludeGroupsRestoreDataSelectors(cmd.Context(), opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterGroupsRestoreInfoSelectors(sel, opts)
+
+	return genericDiffCommand(cmd, args[0], args[1], sel.Selector)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup history
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup history groups --group <group> [<flag>...]`
+func groupsHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   groupsServiceCommand,
+		Short: "Lists the backed up versions of M365 Groups items",
+		RunE:  historyGroupsCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// lists the versions of the selected items across all backups of the
+// group, using selector reduction on the details of each backup.
+func historyGroupsCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	opts := utils.MakeGroupsOpts(cmd)
+
+	sel := utils.IncludeGroupsRestoreDataSelectors(cmd.Context(), opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterGroupsRestoreInfoSelectors(sel, opts)
+
+	return genericHistoryCommand(
+		cmd,
+		groupsServiceCommand,
+		flags.HistoryResourceFV,
+		flags.ItemVersionFV,
+		sel.Selector)
+}
+
 // ------------------------------------------------------------------------------------------------
 // backup delete
 // ------------------------------------------------------------------------------------------------
@@ -277,6 +438,87 @@ func deleteGroupsCmd(cmd *cobra.Command, args []string) error {
 	return genericDeleteCommand(cmd, path.GroupsService, "Groups", backupIDValue, args)
 }
 
+// ------------------------------------------------------------------------------------------------
+// backup membership
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup membership groups [<flag>...]`
+func groupsMembershipCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   groupsServiceCommand,
+		Short: "Shows the owners and members recorded by a Groups backup",
+		RunE:  membershipGroupsCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// displays the membership recorded in a groups backup, or the
+// membership changes since an earlier backup.
+func membershipGroupsCmd(cmd *cobra.Command, args []string) error {
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	ctx := cmd.Context()
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.GroupsService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	ms, changes, err := groupsMembershipCore(ctx, r, flags.BackupIDFV, flags.CompareBackupFV)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	if len(flags.CompareBackupFV) > 0 {
+		membership.PrintChanges(ctx, changes)
+	} else {
+		membership.PrintAll(ctx, ms)
+	}
+
+	return nil
+}
+
+// groupsMembershipCore retrieves the membership recorded by the backup.
+// If a comparison backup is provided, the changes between it and the
+// backup are produced as well.
+func groupsMembershipCore(
+	ctx context.Context,
+	mg repository.MembershipGetter,
+	backupID, compareID string,
+) ([]membership.Member, []membership.Change, error) {
+	curr, _, err := mg.GetGroupMembership(ctx, backupID)
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return nil, nil, clues.New("No membership recorded in backup " + backupID)
+		}
+
+		return nil, nil, clues.Wrap(err, "Failed to get membership for backup "+backupID)
+	}
+
+	if len(compareID) == 0 {
+		return curr.All(), nil, nil
+	}
+
+	prev, _, err := mg.GetGroupMembership(ctx, compareID)
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return nil, nil, clues.New("No membership recorded in backup " + compareID)
+		}
+
+		return nil, nil, clues.Wrap(err, "Failed to get membership for backup "+compareID)
+	}
+
+	return curr.All(), membership.Diff(*prev, *curr), nil
+}
+
 // ---------------------------------------------------------------------------
 // helpers
 // ---------------------------------------------------------------------------
@@ -292,8 +534,8 @@ func validateGroupsBackupCreateFlags(groups, cats []string) error {
 	// TODO(keepers): release conversations support
 
 	msg := fmt.Sprintf(
-		" is an unrecognized data type; only %s and %s are supported",
-		flags.DataLibraries, flags.DataMessages)
+		" is an unrecognized data type; only %s, %s, %s, %s and %s are supported",
+		flags.DataLibraries, flags.DataMessages, flags.DataEvents, flags.DataPlans, flags.DataTeamStructure)
 
 	// msg := fmt.Sprintf(
 	// 	" is an unrecognized data type; only %s, %s and %s are supported",
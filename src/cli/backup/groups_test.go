@@ -1,6 +1,7 @@
 package backup
 
 import (
+	"context"
 	"strconv"
 	"testing"
 
@@ -15,7 +16,10 @@ import (
 	cliTD "github.com/alcionai/corso/src/cli/testdata"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/membership"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/errs/core"
 )
 
 type GroupsUnitSuite struct {
@@ -57,6 +61,20 @@ func (suite *GroupsUnitSuite) TestAddGroupsCommands() {
 			expectShort: groupsDetailsCmd().Short,
 			expectRunE:  detailsGroupsCmd,
 		},
+		{
+			name:        "diff groups",
+			use:         diffCommand,
+			expectUse:   expectUse + " " + groupsServiceCommandDiffUseSuffix,
+			expectShort: groupsDiffCmd().Short,
+			expectRunE:  diffGroupsCmd,
+		},
+		{
+			name:        "history groups",
+			use:         historyCommand,
+			expectUse:   expectUse + " " + groupsServiceCommandHistoryUseSuffix,
+			expectShort: groupsHistoryCmd().Short,
+			expectRunE:  historyGroupsCmd,
+		},
 		{
 			name:        "delete groups",
 			use:         deleteCommand,
@@ -64,6 +82,13 @@ func (suite *GroupsUnitSuite) TestAddGroupsCommands() {
 			expectShort: groupsDeleteCmd().Short,
 			expectRunE:  deleteGroupsCmd,
 		},
+		{
+			name:        "membership groups",
+			use:         membershipCommand,
+			expectUse:   expectUse + " " + groupsServiceCommandMembershipSuffix,
+			expectShort: groupsMembershipCmd().Short,
+			expectRunE:  membershipGroupsCmd,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -111,12 +136,24 @@ func (suite *GroupsUnitSuite) TestValidateGroupsBackupCreateFlags() {
 			cats:   []string{flags.DataConversations},
 			expect: assert.NoError,
 		},
+		{
+			name:   "plans",
+			cats:   []string{flags.DataPlans},
+			expect: assert.NoError,
+		},
+		{
+			name:   "team structure",
+			cats:   []string{flags.DataTeamStructure},
+			expect: assert.NoError,
+		},
 		{
 			name: "all allowed",
 			cats: []string{
 				flags.DataLibraries,
 				flags.DataMessages,
 				flags.DataConversations,
+				flags.DataPlans,
+				flags.DataTeamStructure,
 			},
 			expect: assert.NoError,
 		},
@@ -232,6 +269,7 @@ func (suite *GroupsUnitSuite) TestBackupDetailsFlags() {
 			},
 			flagsTD.PreparedChannelFlags(),
 			flagsTD.PreparedConversationFlags(),
+			flagsTD.PreparedPlanAndTeamStructureFlags(),
 			flagsTD.PreparedProviderFlags(),
 			flagsTD.PreparedStorageFlags(),
 			flagsTD.PreparedLibraryFlags()))
@@ -244,6 +282,7 @@ func (suite *GroupsUnitSuite) TestBackupDetailsFlags() {
 	flagsTD.AssertStorageFlags(t, cmd)
 	flagsTD.AssertChannelFlags(t, cmd)
 	flagsTD.AssertConversationFlags(t, cmd)
+	flagsTD.AssertPlanAndTeamStructureFlags(t, cmd)
 	flagsTD.AssertLibraryFlags(t, cmd)
 }
 
@@ -271,3 +310,103 @@ func (suite *GroupsUnitSuite) TestBackupDeleteFlags() {
 	flagsTD.AssertProviderFlags(t, cmd)
 	flagsTD.AssertStorageFlags(t, cmd)
 }
+
+func (suite *GroupsUnitSuite) TestBackupMembershipFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: membershipCommand},
+		addGroupsCommands,
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			groupsServiceCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.BackupFN, flagsTD.BackupInput,
+				"--" + flags.CompareBackupFN, flagsTD.CompareBackupInput,
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.Equal(t, flagsTD.BackupInput, flags.BackupIDFV)
+	assert.Equal(t, flagsTD.CompareBackupInput, flags.CompareBackupFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
+type mockMembershipGetter map[string]*membership.Membership
+
+func (mg mockMembershipGetter) GetGroupMembership(
+	_ context.Context,
+	backupID string,
+) (*membership.Membership, *backup.Backup, error) {
+	m, ok := mg[backupID]
+	if !ok {
+		return nil, nil, clues.Stack(core.ErrNotFound)
+	}
+
+	return m, &backup.Backup{}, nil
+}
+
+func (suite *GroupsUnitSuite) TestGroupsMembershipCore() {
+	var (
+		owner  = membership.Member{ID: "o", Role: membership.OwnerRole}
+		member = membership.Member{ID: "m", Role: membership.MemberRole}
+		mg     = mockMembershipGetter{
+			"old": {Owners: []membership.Member{owner}},
+			"new": {Owners: []membership.Member{owner}, Members: []membership.Member{member}},
+		}
+	)
+
+	table := []struct {
+		name          string
+		backupID      string
+		compareID     string
+		expectMembers []membership.Member
+		expectChanges []membership.Change
+		expectErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "membership",
+			backupID:      "new",
+			expectMembers: []membership.Member{owner, member},
+			expectErr:     assert.NoError,
+		},
+		{
+			name:          "compared",
+			backupID:      "new",
+			compareID:     "old",
+			expectMembers: []membership.Member{owner, member},
+			expectChanges: []membership.Change{{Member: member, Change: membership.Added}},
+			expectErr:     assert.NoError,
+		},
+		{
+			name:      "no membership recorded",
+			backupID:  "none",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "no membership recorded in comparison",
+			backupID:  "new",
+			compareID: "none",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ms, changes, err := groupsMembershipCore(ctx, mg, test.backupID, test.compareID)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectMembers, ms)
+			assert.Equal(t, test.expectChanges, changes)
+		})
+	}
+}
@@ -22,6 +22,8 @@ const (
 	oneDriveServiceCommandCreateUseSuffix  = "--user <email> | '" + flags.Wildcard + "'"
 	oneDriveServiceCommandDeleteUseSuffix  = "--backups <backupId>"
 	oneDriveServiceCommandDetailsUseSuffix = "--backup <backupId>"
+	oneDriveServiceCommandDiffUseSuffix    = "<oldBackupId> <newBackupId>"
+	oneDriveServiceCommandHistoryUseSuffix = "--user <userEmail>"
 )
 
 const (
@@ -32,7 +34,10 @@ corso backup create onedrive --user alice@example.com
 corso backup create onedrive --user alice@example.com,bob@example.com
 
 # Backup all OneDrive data for all M365 users 
-corso backup create onedrive --user '*'`
+corso backup create onedrive --user '*'
+
+# Estimate the number of files, and their size, in a first backup of all users
+corso backup create onedrive --user '*' --dry-run`
 
 	oneDriveServiceCommandDeleteExamples = `# Delete OneDrive backup with ID 1234abcd-12ab-cd34-56de-1234abcd \
 and 1234abcd-12ab-cd34-56de-1234abce
@@ -48,6 +53,21 @@ corso backup details onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd \
 # Explore files created before the end of 2015
 corso backup details onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --file-created-before 2015-01-01T00:00:00`
+
+	oneDriveServiceCommandDiffExamples = `# Show the items added, modified, moved, or deleted between two of Bob's
+# backups (1234abcd... and 5678efgh...)
+corso backup diff onedrive 1234abcd-12ab-cd34-56de-1234abcd 5678efgh-56ef-gh78-90ij-5678efgh
+
+# Only compare the files in the folder "Reports"
+corso backup diff onedrive 1234abcd-12ab-cd34-56de-1234abcd 5678efgh-56ef-gh78-90ij-5678efgh \
+    --folder "Reports"`
+
+	oneDriveServiceCommandHistoryExamples = `# List every backed up version of the file "Fiscal 22" in Bob's OneDrive
+corso backup history onedrive --user bob@example.com --file "Fiscal 22" --folder "Reports"
+
+# Show the commands that restore or export the second version of that file
+corso backup history onedrive --user bob@example.com --file "Fiscal 22" --folder "Reports" \
+    --item-version 2`
 )
 
 // called by backup.go to map subcommands to provider-specific handling.
@@ -66,6 +86,9 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddUserFlag(c)
 		flags.AddGenericBackupFlags(c)
+		flags.AddDriveVersionsFlags(c)
+		flags.AddIndexContentFlag(c)
+		flags.AddFileFilterFlags(c)
 		fs.BoolVar(
 			&flags.UseOldDeltaProcessFV,
 			flags.UseOldDeltaProcessFN,
@@ -89,6 +112,24 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddOneDriveDetailsAndRestoreFlags(c)
 
+	case diffCommand:
+		c, _ = utils.AddCommand(cmd, oneDriveDiffCmd())
+
+		c.Use = c.Use + " " + oneDriveServiceCommandDiffUseSuffix
+		c.Example = oneDriveServiceCommandDiffExamples
+
+		flags.AddOneDriveDetailsAndRestoreFlags(c)
+
+	case historyCommand:
+		c, _ = utils.AddCommand(cmd, oneDriveHistoryCmd())
+
+		c.Use = c.Use + " " + oneDriveServiceCommandHistoryUseSuffix
+		c.Example = oneDriveServiceCommandHistoryExamples
+
+		flags.AddHistoryResourceFlag(c, flags.UserFN, "user")
+		flags.AddItemVersionFlag(c)
+		flags.AddOneDriveDetailsAndRestoreFlags(c)
+
 	case deleteCommand:
 		c, _ = utils.AddCommand(cmd, oneDriveDeleteCmd())
 
@@ -133,6 +174,15 @@ func createOneDriveCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	ff, err := utils.MakeFileFilter()
+	if err != nil {
+		return err
+	}
+
+	if _, err := utils.ParseDownloadRate(); err != nil {
+		return err
+	}
+
 	r, acct, err := utils.AccountConnectAndWriteRepoConfig(
 		ctx,
 		cmd,
@@ -145,6 +195,8 @@ func createOneDriveCmd(cmd *cobra.Command, args []string) error {
 
 	sel := oneDriveBackupCreateSelectors(flags.UserFV)
 
+	sel.FilterFiles(ff)
+
 	ins, err := utils.UsersMap(
 		ctx,
 		*acct,
@@ -253,6 +305,71 @@ func runDetailsOneDriveCmd(cmd *cobra.Command) error {
 	return nil
 }
 
+// ------------------------------------------------------------------------------------------------
+// backup diff
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup diff onedrive <oldBackupId> <newBackupId> [<flag>...]`
+func oneDriveDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   oneDriveServiceCommand,
+		Short: "Shows the changes between two M365 OneDrive service backups",
+		RunE:  diffOneDriveCmd,
+		Args:  cobra.ExactArgs(2),
+	}
+}
+
+// lists the items that changed between the two backups, running the
+// details of both first through selector reduction as a filtering step.
+func diffOneDriveCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	opts := utils.MakeOneDriveOpts(cmd)
+
+	sel := utils.IncludeOneDriveRestoreDataSelectors(opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterOneDriveRestoreInfoSelectors(sel, opts)
+
+	return genericDiffCommand(cmd, args[0], args[1], sel.Selector)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup history
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup history onedrive --user <user> [<flag>...]`
+func oneDriveHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   oneDriveServiceCommand,
+		Short: "Lists the backed up versions of M365 OneDrive files",
+		RunE:  historyOneDriveCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// lists the versions of the selected items across all backups of the
+// user, using selector reduction on the details of each backup.
+func historyOneDriveCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	opts := utils.MakeOneDriveOpts(cmd)
+
+	sel := utils.IncludeOneDriveRestoreDataSelectors(opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterOneDriveRestoreInfoSelectors(sel, opts)
+
+	return genericHistoryCommand(
+		cmd,
+		oneDriveServiceCommand,
+		flags.HistoryResourceFV,
+		flags.ItemVersionFV,
+		sel.Selector)
+}
+
 // `corso backup delete onedrive [<flag>...]`
 func oneDriveDeleteCmd() *cobra.Command {
 	return &cobra.Command{
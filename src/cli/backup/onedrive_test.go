@@ -2,6 +2,7 @@ package backup
 
 import (
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/spf13/cobra"
@@ -56,6 +57,20 @@ func (suite *OneDriveUnitSuite) TestAddOneDriveCommands() {
 			expectShort: oneDriveDetailsCmd().Short,
 			expectRunE:  detailsOneDriveCmd,
 		},
+		{
+			name:        "diff onedrive",
+			use:         diffCommand,
+			expectUse:   expectUse + " " + oneDriveServiceCommandDiffUseSuffix,
+			expectShort: oneDriveDiffCmd().Short,
+			expectRunE:  diffOneDriveCmd,
+		},
+		{
+			name:        "history onedrive",
+			use:         historyCommand,
+			expectUse:   expectUse + " " + oneDriveServiceCommandHistoryUseSuffix,
+			expectShort: oneDriveHistoryCmd().Short,
+			expectRunE:  historyOneDriveCmd,
+		},
 		{
 			name:        "delete onedrive",
 			use:         deleteCommand,
@@ -127,6 +142,43 @@ func (suite *OneDriveUnitSuite) TestBackupCreateFlags() {
 	flagsTD.AssertStorageFlags(t, cmd)
 }
 
+func (suite *OneDriveUnitSuite) TestBackupCreateFlags_fileFilter() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: createCommand},
+		addOneDriveCommands,
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			oneDriveServiceCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.UserFN, flagsTD.FlgInputs(flagsTD.UsersInput),
+				"--" + flags.FileExtensionFN, "docx,.PDF",
+				"--" + flags.ExcludeMIMETypeFN, "video/*",
+				"--" + flags.MaxFileSizeFN, "2MB",
+				"--" + flags.FileModifiedAfterFN, "2024-01-02T03:04:05Z",
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+
+	ff, err := utils.MakeFileFilter()
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, []string{"docx", ".PDF"}, ff.Extensions)
+	assert.Equal(t, []string{"video/*"}, ff.ExcludeMIMETypes)
+	assert.Equal(t, int64(2_000_000), ff.MaxSize)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ff.ModifiedAfter.UTC())
+	assert.True(t, ff.ModifiedBefore.IsZero())
+}
+
 func (suite *OneDriveUnitSuite) TestBackupListFlags() {
 	t := suite.T()
 
@@ -183,6 +235,37 @@ func (suite *OneDriveUnitSuite) TestBackupDetailsFlags() {
 	flagsTD.AssertStorageFlags(t, cmd)
 }
 
+func (suite *OneDriveUnitSuite) TestBackupHistoryFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: historyCommand},
+		addOneDriveCommands,
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			oneDriveServiceCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.UserFN, "user-id",
+				"--" + flags.ItemVersionFN, "2",
+				"--" + flags.FileFN, flagsTD.FlgInputs(flagsTD.FileNameInput),
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	opts := utils.MakeOneDriveOpts(cmd)
+
+	assert.Equal(t, "user-id", flags.HistoryResourceFV)
+	assert.Equal(t, 2, flags.ItemVersionFV)
+	assert.ElementsMatch(t, flagsTD.FileNameInput, opts.FileName)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
 func (suite *OneDriveUnitSuite) TestBackupDeleteFlags() {
 	t := suite.T()
 
@@ -27,6 +27,8 @@ const (
 	sharePointServiceCommandCreateUseSuffix  = "--site <siteURL> | '" + flags.Wildcard + "'"
 	sharePointServiceCommandDeleteUseSuffix  = "--backups <backupId>"
 	sharePointServiceCommandDetailsUseSuffix = "--backup <backupId>"
+	sharePointServiceCommandDiffUseSuffix    = "<oldBackupId> <newBackupId>"
+	sharePointServiceCommandHistoryUseSuffix = "--site <siteURL>"
 )
 
 const (
@@ -41,6 +43,9 @@ corso backup create sharepoint --site '*'
 
 # Backup all SharePoint list data for a Site
 corso backup create sharepoint --site https://example.com/hr --data lists
+
+# Backup the columns, content types, permissions and settings of a Site
+corso backup create sharepoint --site https://example.com/hr --data site-config
 `
 
 	sharePointServiceCommandDeleteExamples = `# Delete SharePoint backup with ID 1234abcd-12ab-cd34-56de-1234abcd \
@@ -81,6 +86,21 @@ corso backup details sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd \
 # Explore lists modified after a given time
 corso backup details sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --list-modified-after 2024-01-01T12:23:34`
+
+	sharePointServiceCommandDiffExamples = `# Show the items added, modified, moved, or deleted between two of the HR site's
+# backups (1234abcd... and 5678efgh...)
+corso backup diff sharepoint 1234abcd-12ab-cd34-56de-1234abcd 5678efgh-56ef-gh78-90ij-5678efgh
+
+# Only compare the files in the document library "Work Documents"
+corso backup diff sharepoint 1234abcd-12ab-cd34-56de-1234abcd 5678efgh-56ef-gh78-90ij-5678efgh \
+    --library "Work Documents"`
+
+	sharePointServiceCommandHistoryExamples = `# List every backed up version of the file "Fiscal 22" in the HR site
+corso backup history sharepoint --site https://example.com/hr --file "Fiscal 22" --folder "Reports"
+
+# Show the commands that restore or export the second version of that file
+corso backup history sharepoint --site https://example.com/hr --file "Fiscal 22" --folder "Reports" \
+    --item-version 2`
 )
 
 // called by backup.go to map subcommands to provider-specific handling.
@@ -100,6 +120,10 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		// when explicit invoke is not required anymore
 		flags.AddDataFlag(c, []string{flags.DataLibraries}, true)
 		flags.AddGenericBackupFlags(c)
+		flags.AddDriveVersionsFlags(c)
+		flags.AddIndexContentFlag(c)
+		flags.AddFileFilterFlags(c)
+		flags.AddListVersionsFlag(c)
 
 	case listCommand:
 		c, _ = utils.AddCommand(cmd, sharePointListCmd())
@@ -117,6 +141,24 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddSharePointDetailsAndRestoreFlags(c)
 
+	case diffCommand:
+		c, _ = utils.AddCommand(cmd, sharePointDiffCmd())
+
+		c.Use = c.Use + " " + sharePointServiceCommandDiffUseSuffix
+		c.Example = sharePointServiceCommandDiffExamples
+
+		flags.AddSharePointDetailsAndRestoreFlags(c)
+
+	case historyCommand:
+		c, _ = utils.AddCommand(cmd, sharePointHistoryCmd())
+
+		c.Use = c.Use + " " + sharePointServiceCommandHistoryUseSuffix
+		c.Example = sharePointServiceCommandHistoryExamples
+
+		flags.AddHistoryResourceFlag(c, flags.SiteFN, "site")
+		flags.AddItemVersionFlag(c)
+		flags.AddSharePointDetailsAndRestoreFlags(c)
+
 	case deleteCommand:
 		c, _ = utils.AddCommand(cmd, sharePointDeleteCmd())
 
@@ -161,6 +203,15 @@ func createSharePointCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	ff, err := utils.MakeFileFilter()
+	if err != nil {
+		return err
+	}
+
+	if _, err := utils.ParseDownloadRate(); err != nil {
+		return err
+	}
+
 	r, acct, err := utils.AccountConnectAndWriteRepoConfig(
 		ctx,
 		cmd,
@@ -189,6 +240,8 @@ func createSharePointCmd(cmd *cobra.Command, args []string) error {
 		return Only(ctx, clues.Wrap(err, "Retrieving up sharepoint sites by ID and URL"))
 	}
 
+	sel.FilterFiles(ff)
+
 	selectorSet := []selectors.Selector{}
 
 	for _, discSel := range sel.SplitByResourceOwner(ins.IDs()) {
@@ -216,7 +269,8 @@ func validateSharePointBackupCreateFlags(sites, weburls, cats []string) error {
 	for _, d := range cats {
 		if _, ok := allowedCats[d]; !ok {
 			return clues.New(
-				d + " is an unrecognized data type; only  " + flags.DataLibraries + " supported")
+				d + " is an unrecognized data type; only " + flags.DataLibraries + ", " +
+					flags.DataLists + ", and " + flags.DataSiteConfiguration + " supported")
 		}
 	}
 
@@ -269,6 +323,71 @@ func listSharePointCmd(cmd *cobra.Command, args []string) error {
 	return genericListCommand(cmd, flags.BackupIDFV, path.SharePointService, args)
 }
 
+// ------------------------------------------------------------------------------------------------
+// backup diff
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup diff sharepoint <oldBackupId> <newBackupId> [<flag>...]`
+func sharePointDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   sharePointServiceCommand,
+		Short: "Shows the changes between two M365 SharePoint service backups",
+		RunE:  diffSharePointCmd,
+		Args:  cobra.ExactArgs(2),
+	}
+}
+
+// lists the items that changed between the two backups, running the
+// details of both first through selector reduction as a filtering step.
+func diffSharePointCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	opts := utils.MakeSharePointOpts(cmd)
+
+	sel := utils.IncludeSharePointRestoreDataSelectors(cmd.Context(), opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterSharePointRestoreInfoSelectors(sel, opts)
+
+	return genericDiffCommand(cmd, args[0], args[1], sel.Selector)
+}
+
+// ------------------------------------------------------------------------------------------------
+// backup history
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup history sharepoint --site <site> [<flag>...]`
+func sharePointHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   sharePointServiceCommand,
+		Short: "Lists the backed up versions of M365 SharePoint items",
+		RunE:  historySharePointCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// lists the versions of the selected items across all backups of the
+// site, using selector reduction on the details of each backup.
+func historySharePointCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	opts := utils.MakeSharePointOpts(cmd)
+
+	sel := utils.IncludeSharePointRestoreDataSelectors(cmd.Context(), opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterSharePointRestoreInfoSelectors(sel, opts)
+
+	return genericHistoryCommand(
+		cmd,
+		sharePointServiceCommand,
+		flags.HistoryResourceFV,
+		flags.ItemVersionFV,
+		sel.Selector)
+}
+
 // ------------------------------------------------------------------------------------------------
 // backup delete
 // ------------------------------------------------------------------------------------------------
@@ -59,6 +59,20 @@ func (suite *SharePointUnitSuite) TestAddSharePointCommands() {
 			expectShort: sharePointDetailsCmd().Short,
 			expectRunE:  detailsSharePointCmd,
 		},
+		{
+			name:        "diff sharepoint",
+			use:         diffCommand,
+			expectUse:   expectUse + " " + sharePointServiceCommandDiffUseSuffix,
+			expectShort: sharePointDiffCmd().Short,
+			expectRunE:  diffSharePointCmd,
+		},
+		{
+			name:        "history sharepoint",
+			use:         historyCommand,
+			expectUse:   expectUse + " " + sharePointServiceCommandHistoryUseSuffix,
+			expectShort: sharePointHistoryCmd().Short,
+			expectRunE:  historySharePointCmd,
+		},
 		{
 			name:        "delete sharepoint",
 			use:         deleteCommand,
@@ -259,6 +273,12 @@ func (suite *SharePointUnitSuite) TestValidateSharePointBackupCreateFlags() {
 			cats:   []string{flags.DataLists},
 			expect: assert.NoError,
 		},
+		{
+			name:   "site with site configuration category",
+			site:   []string{"smarf"},
+			cats:   []string{flags.DataSiteConfiguration},
+			expect: assert.NoError,
+		},
 
 		// [TODO]: Uncomment when pages are enabled
 
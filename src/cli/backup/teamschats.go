@@ -28,6 +28,7 @@ const (
 	teamschatsServiceCommandCreateUseSuffix  = "--user <userEmail> | '" + flags.Wildcard + "'"
 	teamschatsServiceCommandDeleteUseSuffix  = "--backups <backupId>"
 	teamschatsServiceCommandDetailsUseSuffix = "--backup <backupId>"
+	teamschatsServiceCommandDiffUseSuffix    = "<oldBackupId> <newBackupId>"
 )
 
 const (
@@ -43,6 +44,10 @@ corso backup delete chats --backups 1234abcd-12ab-cd34-56de-1234abcd,1234abcd-12
 
 	teamschatsServiceCommandDetailsExamples = `# Explore chats in Bob's latest backup (1234abcd...)
 corso backup details chats --backup 1234abcd-12ab-cd34-56de-1234abcd`
+
+	teamschatsServiceCommandDiffExamples = `# Show the items added, modified, moved, or deleted between two of Bob's
+# backups (1234abcd... and 5678efgh...)
+corso backup diff chats 1234abcd-12ab-cd34-56de-1234abcd 5678efgh-56ef-gh78-90ij-5678efgh`
 )
 
 // called by backup.go to map subcommands to provider-specific handling.
@@ -60,6 +65,7 @@ func addTeamsChatsCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddUserFlag(c)
 		flags.AddDataFlag(c, []string{flags.DataChats}, false)
 		flags.AddGenericBackupFlags(c)
+		flags.AddIndexContentFlag(c)
 
 	case listCommand:
 		c, _ = utils.AddCommand(cmd, teamschatsListCmd(), utils.MarkPreReleaseCommand())
@@ -80,6 +86,14 @@ func addTeamsChatsCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddTeamsChatsDetailsAndRestoreFlags(c)
 
+	case diffCommand:
+		c, _ = utils.AddCommand(cmd, teamschatsDiffCmd(), utils.MarkPreReleaseCommand())
+
+		c.Use = c.Use + " " + teamschatsServiceCommandDiffUseSuffix
+		c.Example = teamschatsServiceCommandDiffExamples
+
+		flags.AddTeamsChatsDetailsAndRestoreFlags(c)
+
 	case deleteCommand:
 		c, _ = utils.AddCommand(cmd, teamschatsDeleteCmd(), utils.MarkPreReleaseCommand())
 
@@ -124,6 +138,10 @@ func createTeamsChatsCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if _, err := utils.ParseDownloadRate(); err != nil {
+		return err
+	}
+
 	r, acct, err := utils.AccountConnectAndWriteRepoConfig(
 		ctx,
 		cmd,
@@ -230,6 +248,36 @@ func runDetailsTeamsChatsCmd(cmd *cobra.Command) error {
 	return nil
 }
 
+// ------------------------------------------------------------------------------------------------
+// backup diff
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup diff chats <oldBackupId> <newBackupId> [<flag>...]`
+func teamschatsDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   teamschatsServiceCommand,
+		Short: "Shows the changes between two M365 Chats backups",
+		RunE:  diffTeamsChatsCmd,
+		Args:  cobra.ExactArgs(2),
+	}
+}
+
+// lists the items that changed between the two backups, running the
+// details of both first through selector reduction as a filtering step.
+func diffTeamsChatsCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	opts := utils.MakeTeamsChatsOpts(cmd)
+
+	sel := utils.IncludeTeamsChatsRestoreDataSelectors(cmd.Context(), opts)
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+	utils.FilterTeamsChatsRestoreInfoSelectors(sel, opts)
+
+	return genericDiffCommand(cmd, args[0], args[1], sel.Selector)
+}
+
 // ------------------------------------------------------------------------------------------------
 // backup delete
 // ------------------------------------------------------------------------------------------------
@@ -56,6 +56,13 @@ func (suite *TeamsChatsUnitSuite) TestAddTeamsChatsCommands() {
 			expectShort: teamschatsDetailsCmd().Short,
 			expectRunE:  detailsTeamsChatsCmd,
 		},
+		{
+			name:        "diff teamschats",
+			use:         diffCommand,
+			expectUse:   expectUse + " " + teamschatsServiceCommandDiffUseSuffix,
+			expectShort: teamschatsDiffCmd().Short,
+			expectRunE:  diffTeamsChatsCmd,
+		},
 		{
 			name:        "delete teamschats",
 			use:         deleteCommand,
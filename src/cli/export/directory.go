@@ -0,0 +1,98 @@
+package export
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+// called by export.go to map subcommands to provider-specific handling.
+func addDirectoryCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case exportCommand:
+		c, _ = utils.AddCommand(cmd, directoryExportCmd(), utils.MarkPreReleaseCommand())
+
+		c.Use = c.Use + " " + directoryServiceCommandUseSuffix
+
+		flags.AddBackupIDFlag(c, true)
+		flags.AddDirectoryDetailsAndRestoreFlags(c)
+		flags.AddExportConfigFlags(c)
+		flags.AddFailFastFlag(c)
+	}
+
+	return c
+}
+
+const (
+	directoryServiceCommand          = "directory"
+	directoryServiceCommandUseSuffix = "<destination> --backup <backupId>"
+
+	//nolint:lll
+	directoryServiceCommandExportExamples = `# Export all directory objects in the backup (1234abcd...) to /my-exports
+corso export directory my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Export the conditional access policies in the backup to the current directory
+corso export directory . --backup 1234abcd-12ab-cd34-56de-1234abcd --conditional-access-policy '*'
+
+# Export the group named "Marketing" to /my-exports
+corso export directory my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd --directory-group Marketing`
+)
+
+// `corso export directory [<flag>...] <destination>`
+func directoryExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   directoryServiceCommand,
+		Short: "Export M365 directory data",
+		RunE:  exportDirectoryCmd,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing export destination")
+			}
+
+			return nil
+		},
+		Example: directoryServiceCommandExportExamples,
+	}
+}
+
+// processes a directory export.
+func exportDirectoryCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := utils.MakeDirectoryOpts(cmd)
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	if err := utils.ValidateDirectoryRestoreFlags(flags.BackupIDFV, opts, false); err != nil {
+		return err
+	}
+
+	sel := utils.IncludeDirectoryRestoreDataSelectors(ctx, opts)
+	utils.FilterDirectoryRestoreInfoSelectors(sel, opts)
+
+	acceptedDirectoryFormatTypes := []string{
+		string(control.DefaultFormat),
+		string(control.JSONFormat),
+	}
+
+	return runExport(
+		ctx,
+		cmd,
+		args,
+		opts.ExportCfg,
+		sel.Selector,
+		flags.BackupIDFV,
+		"Directory",
+		acceptedDirectoryFormatTypes)
+}
@@ -25,6 +25,7 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddSharePointDetailsAndRestoreFlags(c)
 		flags.AddGroupDetailsAndRestoreFlags(c)
 		flags.AddExportConfigFlags(c)
+		flags.AddFileVersionFlag(c)
 		flags.AddFailFastFlag(c)
 	}
 
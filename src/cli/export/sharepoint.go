@@ -21,6 +21,7 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddSharePointDetailsAndRestoreFlags(c)
 		flags.AddExportConfigFlags(c)
+		flags.AddFileVersionFlag(c)
 		flags.AddFailFastFlag(c)
 	}
 
@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
@@ -8,4 +10,62 @@ func AddGenericBackupFlags(cmd *cobra.Command) {
 	AddFailFastFlag(cmd)
 	AddDisableIncrementalsFlag(cmd)
 	AddForceItemDataDownloadFlag(cmd)
+	AddBackupDryRunFlag(cmd)
+	AddDownloadRateFlags(cmd)
+	AddCheckpointIntervalFlag(cmd)
+}
+
+// BackupDryRunFV shares its flag name with the restore DryRunFV, but not its
+// value, so that only backup create produces estimates.
+var BackupDryRunFV bool
+
+// AddBackupDryRunFlag adds the --dry-run flag, which estimates the size of
+// a backup without running it.
+func AddBackupDryRunFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&BackupDryRunFV,
+		DryRunFN, false,
+		"Estimate the backup by enumerating its containers and items, without fetching item data or "+
+			"writing to the repository.")
+}
+
+const (
+	MaxDownloadRateFN      = "max-download-rate"
+	DownloadRateScheduleFN = "download-rate-schedule"
+)
+
+var (
+	MaxDownloadRateFV      string
+	DownloadRateScheduleFV []string
+)
+
+// AddDownloadRateFlags adds the flags that cap the rate at which backups
+// download item content.
+func AddDownloadRateFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringVar(
+		&MaxDownloadRateFV,
+		MaxDownloadRateFN, "",
+		"Limit item downloads to this many bytes per second (ex: 10MB); unlimited by default.")
+
+	fs.StringSliceVar(
+		&DownloadRateScheduleFV,
+		DownloadRateScheduleFN, nil,
+		"Use a different download limit during a daily window of local time, as start-end=rate "+
+			"(ex: 09:00-17:00=1MB); a rate of 0 removes the limit during the window.")
+}
+
+const CheckpointIntervalFN = "checkpoint-interval"
+
+var CheckpointIntervalFV time.Duration
+
+// AddCheckpointIntervalFlag adds the flag that controls how often backups
+// save their upload progress to the repository.
+func AddCheckpointIntervalFlag(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(
+		&CheckpointIntervalFV,
+		CheckpointIntervalFN, 0,
+		"Save upload progress to the repository this often (ex: 10m), so that a crashed backup "+
+			"doesn't need to upload that data again; at most, and by default, 45m.")
 }
@@ -0,0 +1,33 @@
+package flags
+
+import "github.com/spf13/cobra"
+
+const ItemVersionFN = "item-version"
+
+var (
+	ItemVersionFV int
+	// HistoryResourceFV holds the id or name of the protected resource whose
+	// backups get searched.
+	HistoryResourceFV string
+)
+
+// AddHistoryResourceFlag adds the required flag that names the protected
+// resource whose backups get searched, such as --user or --site.
+func AddHistoryResourceFlag(cmd *cobra.Command, name, resource string) {
+	cmd.Flags().StringVar(
+		&HistoryResourceFV,
+		name,
+		"",
+		"ID or name of the "+resource+" whose backups get searched.")
+	cobra.CheckErr(cmd.MarkFlagRequired(name))
+}
+
+// AddItemVersionFlag adds the --item-version flag, which picks a single
+// version of each item from the history.
+func AddItemVersionFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&ItemVersionFV,
+		ItemVersionFN,
+		0,
+		"Only show this version of each item, along with the commands that restore or export it.")
+}
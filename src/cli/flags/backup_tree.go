@@ -0,0 +1,17 @@
+package flags
+
+import "github.com/spf13/cobra"
+
+const DepthFN = "depth"
+
+var DepthFV int
+
+// AddDepthFlag adds the --depth flag, which limits how many levels of
+// subfolders `corso backup tree` shows.
+func AddDepthFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&DepthFV,
+		DepthFN,
+		-1,
+		"Only show folders this many levels below the starting folder.  By default, every folder is shown.")
+}
@@ -0,0 +1,17 @@
+package flags
+
+import "github.com/spf13/cobra"
+
+const SampleFN = "sample"
+
+var SampleFV int
+
+// AddSampleFlag adds the --sample flag, which limits backup verification
+// to reading the data of a random selection of items.
+func AddSampleFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&SampleFV,
+		SampleFN,
+		0,
+		"Only read the data of this many randomly chosen items.  By default, the data of every item is read.")
+}
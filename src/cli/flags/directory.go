@@ -0,0 +1,84 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	DataDirectoryUsers    = "users"
+	DataDirectoryGroups   = "groups"
+	DataApplications      = "applications"
+	DataServicePrincipals = "service-principals"
+	DataConditionalAccess = "conditional-access"
+	DataAdminUnits        = "admin-units"
+)
+
+const (
+	AdminUnitFN               = "admin-unit"
+	ApplicationFN             = "application"
+	ConditionalAccessPolicyFN = "conditional-access-policy"
+	DirectoryGroupFN          = "directory-group"
+	DirectoryUserFN           = "directory-user"
+	ServicePrincipalFN        = "service-principal"
+
+	GroupMailNicknameFN = "group-mail-nickname"
+	UserPrincipalNameFN = "user-principal-name"
+)
+
+var (
+	AdminUnitFV               []string
+	ApplicationFV             []string
+	ConditionalAccessPolicyFV []string
+	DirectoryGroupFV          []string
+	DirectoryUserFV           []string
+	ServicePrincipalFV        []string
+
+	GroupMailNicknameFV string
+	UserPrincipalNameFV string
+)
+
+// AddDirectoryDetailsAndRestoreFlags adds flags that are common to both the
+// details and restore commands.
+func AddDirectoryDetailsAndRestoreFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringSliceVar(
+		&DirectoryUserFV,
+		DirectoryUserFN, nil,
+		"Select directory users by ID or display name; accepts '"+Wildcard+"' to select all users.")
+
+	fs.StringSliceVar(
+		&DirectoryGroupFV,
+		DirectoryGroupFN, nil,
+		"Select directory groups by ID or display name; accepts '"+Wildcard+"' to select all groups.")
+
+	fs.StringSliceVar(
+		&ApplicationFV,
+		ApplicationFN, nil,
+		"Select app registrations by ID or display name; accepts '"+Wildcard+"' to select all applications.")
+
+	fs.StringSliceVar(
+		&ServicePrincipalFV,
+		ServicePrincipalFN, nil,
+		"Select service principals by ID or display name; accepts '"+Wildcard+"' to select all service principals.")
+
+	fs.StringSliceVar(
+		&ConditionalAccessPolicyFV,
+		ConditionalAccessPolicyFN, nil,
+		"Select conditional access policies by ID or display name; accepts '"+Wildcard+"' to select all policies.")
+
+	fs.StringSliceVar(
+		&AdminUnitFV,
+		AdminUnitFN, nil,
+		"Select administrative units by ID or display name; accepts '"+Wildcard+"' to select all units.")
+
+	fs.StringVar(
+		&UserPrincipalNameFV,
+		UserPrincipalNameFN, "",
+		"Select directory users whose principal name contains this value.")
+
+	fs.StringVar(
+		&GroupMailNicknameFV,
+		GroupMailNicknameFN, "",
+		"Select directory groups whose mail nickname contains this value.")
+}
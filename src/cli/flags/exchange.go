@@ -1,7 +1,11 @@
 package flags
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/pkg/control"
 )
 
 const (
@@ -9,12 +13,13 @@ const (
 	ContactFolderFN = "contact-folder"
 	ContactNameFN   = "contact-name"
 
-	EmailFN               = "email"
-	EmailFolderFN         = "email-folder"
-	EmailReceivedAfterFN  = "email-received-after"
-	EmailReceivedBeforeFN = "email-received-before"
-	EmailSenderFN         = "email-sender"
-	EmailSubjectFN        = "email-subject"
+	EmailFN                = "email"
+	EmailFolderFN          = "email-folder"
+	EmailReceivedAfterFN   = "email-received-after"
+	EmailReceivedBeforeFN  = "email-received-before"
+	EmailSenderFN          = "email-sender"
+	EmailSubjectFN         = "email-subject"
+	EmailWellKnownFolderFN = "email-well-known-folder"
 
 	EventFN             = "event"
 	EventCalendarFN     = "event-calendar"
@@ -23,6 +28,11 @@ const (
 	EventStartsAfterFN  = "event-starts-after"
 	EventStartsBeforeFN = "event-starts-before"
 	EventSubjectFN      = "event-subject"
+
+	IncludeMailFoldersFN = "include-mail-folders"
+	ExcludeMailFoldersFN = "exclude-mail-folders"
+
+	MailMIMEFN = "mail-mime"
 )
 
 // flag values (ie: FV)
@@ -31,12 +41,13 @@ var (
 	ContactFolderFV []string
 	ContactNameFV   string
 
-	EmailFV               []string
-	EmailFolderFV         []string
-	EmailReceivedAfterFV  string
-	EmailReceivedBeforeFV string
-	EmailSenderFV         string
-	EmailSubjectFV        string
+	EmailFV                []string
+	EmailFolderFV          []string
+	EmailReceivedAfterFV   string
+	EmailReceivedBeforeFV  string
+	EmailSenderFV          string
+	EmailSubjectFV         string
+	EmailWellKnownFolderFV string
 
 	EventFV             []string
 	EventCalendarFV     []string
@@ -45,6 +56,11 @@ var (
 	EventStartsAfterFV  string
 	EventStartsBeforeFV string
 	EventSubjectFV      string
+
+	IncludeMailFoldersFV []string
+	ExcludeMailFoldersFV []string
+
+	MailMIMEFV string
 )
 
 // AddExchangeDetailsAndRestoreFlags adds flags that are common to both the
@@ -77,6 +93,11 @@ func AddExchangeDetailsAndRestoreFlags(cmd *cobra.Command, emailOnly bool) {
 		&EmailReceivedBeforeFV,
 		EmailReceivedBeforeFN, "",
 		"Select emails received before this datetime.")
+	fs.StringVar(
+		&EmailWellKnownFolderFV,
+		EmailWellKnownFolderFN, "",
+		"Select emails held within a well-known mail folder, such as Recoverable Items; accepts: "+
+			strings.Join(wellKnownMailFolderNames(), ", ")+".")
 
 	// NOTE: Only temporary until we add support for exporting the
 	// others as well in exchange.
@@ -128,3 +149,51 @@ func AddExchangeDetailsAndRestoreFlags(cmd *cobra.Command, emailOnly bool) {
 		ContactNameFN, "",
 		"Select contacts whose contact name contains this value.")
 }
+
+// AddMailFoldersFlags adds the flags that include or exclude well-known
+// mail folders from email backups.
+func AddMailFoldersFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	wellKnown := wellKnownMailFolderNames()
+
+	fs.StringSliceVar(
+		&IncludeMailFoldersFV,
+		IncludeMailFoldersFN, nil,
+		"Include well-known mail folders that are skipped by default, such as Recoverable Items; accepts: "+
+			strings.Join(wellKnown, ", ")+".")
+	fs.StringSliceVar(
+		&ExcludeMailFoldersFV,
+		ExcludeMailFoldersFN, nil,
+		"Exclude well-known mail folders, and their subfolders, from the backup; accepts: "+
+			strings.Join(wellKnown, ", ")+".")
+}
+
+// AddMailMIMEFlag adds the flag that captures the raw MIME content of each
+// message in email backups.
+func AddMailMIMEFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	modes := make([]string, 0, len(control.MailMIMEModes))
+	for _, m := range control.MailMIMEModes {
+		modes = append(modes, string(m))
+	}
+
+	fs.StringVar(
+		&MailMIMEFV,
+		MailMIMEFN, "",
+		"Capture the raw MIME content of each email for byte-exact exports; accepts: "+
+			strings.Join(modes, ", ")+". "+
+			"'include' stores the MIME content in addition to the message, 'only' stores it in place "+
+			"of the message body and attachments.")
+}
+
+func wellKnownMailFolderNames() []string {
+	names := make([]string, 0, len(control.WellKnownMailFolders))
+
+	for _, f := range control.WellKnownMailFolders {
+		names = append(names, string(f))
+	}
+
+	return names
+}
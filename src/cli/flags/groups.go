@@ -7,14 +7,22 @@ import (
 const (
 	DataMessages      = "messages"
 	DataConversations = "conversations"
+	DataEvents        = "events"
+	DataPlans         = "plans"
+	DataTeamStructure = "team-structure"
 )
 
 const (
-	ChannelFN      = "channel"
-	ConversationFN = "conversation"
-	GroupFN        = "group"
-	MessageFN      = "message"
-	PostFN         = "post"
+	ChannelFN       = "channel"
+	CompareBackupFN = "compare-backup"
+	ConversationFN  = "conversation"
+	DryRunFN        = "dry-run"
+	GroupFN         = "group"
+	MembershipFN    = "membership"
+	MessageFN       = "message"
+	PlanFN          = "plan"
+	PostFN          = "post"
+	TeamStructureFN = "team-structure"
 
 	MessageCreatedAfterFN    = "message-created-after"
 	MessageCreatedBeforeFN   = "message-created-before"
@@ -23,11 +31,16 @@ const (
 )
 
 var (
-	ChannelFV      []string
-	ConversationFV []string
-	GroupFV        []string
-	MessageFV      []string
-	PostFV         []string
+	ChannelFV       []string
+	CompareBackupFV string
+	ConversationFV  []string
+	DryRunFV        bool
+	GroupFV         []string
+	MembershipFV    bool
+	MessageFV       []string
+	PlanFV          []string
+	PostFV          []string
+	TeamStructureFV bool
 
 	MessageCreatedAfterFV    string
 	MessageCreatedBeforeFV   string
@@ -77,6 +90,87 @@ func AddGroupDetailsAndRestoreFlags(cmd *cobra.Command) {
 		&PostFV,
 		PostFN, nil,
 		"Select Conversation Posts by reference.")
+
+	AddGroupEventFlags(cmd)
+	AddPlanAndTeamStructureFlags(cmd)
+}
+
+// AddGroupEventFlags adds the flags for selecting events in the group's
+// calendar.  The flags are shared with exchange, since group events carry
+// the same properties as user events.
+func AddGroupEventFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringSliceVar(
+		&EventFV,
+		EventFN, nil,
+		"Select group calendar events by event ID; accepts '"+Wildcard+"' to select all events.")
+
+	fs.StringVar(
+		&EventSubjectFV,
+		EventSubjectFN, "",
+		"Select group calendar events with a subject containing this value.")
+
+	fs.StringVar(
+		&EventOrganizerFV,
+		EventOrganizerFN, "",
+		"Select group calendar events from a specific organizer.")
+
+	fs.StringVar(
+		&EventRecursFV,
+		EventRecursFN, "",
+		"Select recurring group calendar events. Use `--event-recurs false` to select non-recurring events.")
+
+	fs.StringVar(
+		&EventStartsAfterFV,
+		EventStartsAfterFN, "",
+		"Select group calendar events starting after this datetime.")
+
+	fs.StringVar(
+		&EventStartsBeforeFV,
+		EventStartsBeforeFN, "",
+		"Select group calendar events starting before this datetime.")
+}
+
+// AddPlanAndTeamStructureFlags adds the flags for selecting the group data
+// that gets restored into the group itself, rather than its site.
+func AddPlanAndTeamStructureFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringSliceVar(
+		&PlanFV,
+		PlanFN, nil,
+		"Select Planner plans by title or ID.")
+
+	fs.BoolVar(
+		&TeamStructureFV,
+		TeamStructureFN, false,
+		"Select the team's settings, channels, tabs, and installed apps.")
+}
+
+// AddMembershipRestoreFlags adds the flags for restoring the owners and
+// members recorded in a group's backup.
+func AddMembershipRestoreFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.BoolVar(
+		&MembershipFV,
+		MembershipFN, false,
+		"Re-add the owners and members recorded in the backup who are missing from the group.")
+
+	fs.BoolVar(
+		&DryRunFV,
+		DryRunFN, false,
+		"Report the owners and members that would be re-added, without changing the group.")
+}
+
+// AddCompareBackupFlag adds the --compare-backup flag, which selects an
+// earlier backup to compare against.
+func AddCompareBackupFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&CompareBackupFV,
+		CompareBackupFN, "",
+		"ID of an earlier backup to compare against.")
 }
 
 // AddGroupFlag adds the --group flag, which accepts either the id,
@@ -1,7 +1,11 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/pkg/control"
 )
 
 const (
@@ -13,7 +17,18 @@ const (
 	FileModifiedAfterFN  = "file-modified-after"
 	FileModifiedBeforeFN = "file-modified-before"
 
+	FileExtensionFN        = "file-extension"
+	ExcludeFileExtensionFN = "exclude-file-extension"
+	MIMETypeFN             = "mime-type"
+	ExcludeMIMETypeFN      = "exclude-mime-type"
+	MaxFileSizeFN          = "max-file-size"
+
 	UseOldDeltaProcessFN = "use-old-delta-process"
+
+	IncludeVersionsFN = "include-versions"
+	MaxVersionsFN     = "max-versions"
+	MaxVersionAgeFN   = "max-version-age"
+	FileVersionFN     = "file-version"
 )
 
 var (
@@ -25,7 +40,18 @@ var (
 	FileModifiedAfterFV  string
 	FileModifiedBeforeFV string
 
+	FileExtensionFV        []string
+	ExcludeFileExtensionFV []string
+	MIMETypeFV             []string
+	ExcludeMIMETypeFV      []string
+	MaxFileSizeFV          string
+
 	UseOldDeltaProcessFV bool
+
+	IncludeVersionsFV bool
+	MaxVersionsFV     int
+	MaxVersionAgeFV   time.Duration
+	FileVersionFV     string
 )
 
 // AddOneDriveDetailsAndRestoreFlags adds flags that are common to both the
@@ -63,3 +89,77 @@ func AddOneDriveDetailsAndRestoreFlags(cmd *cobra.Command) {
 		FileModifiedBeforeFN, "",
 		"Select files modified before this datetime.")
 }
+
+// AddFileFilterFlags adds the flags that limit which drive files get
+// backed up.
+func AddFileFilterFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringSliceVar(
+		&FileExtensionFV,
+		FileExtensionFN, nil,
+		"Only back up files with these extensions (ex: docx,pdf).")
+
+	fs.StringSliceVar(
+		&ExcludeFileExtensionFV,
+		ExcludeFileExtensionFN, nil,
+		"Skip files with these extensions.")
+
+	fs.StringSliceVar(
+		&MIMETypeFV,
+		MIMETypeFN, nil,
+		"Only back up files with these MIME types; 'type/*' matches every subtype (ex: image/*).")
+
+	fs.StringSliceVar(
+		&ExcludeMIMETypeFV,
+		ExcludeMIMETypeFN, nil,
+		"Skip files with these MIME types; 'type/*' matches every subtype.")
+
+	fs.StringVar(
+		&MaxFileSizeFV,
+		MaxFileSizeFN, "",
+		"Skip files larger than this size (ex: 500MB).")
+
+	fs.StringVar(
+		&FileModifiedAfterFV,
+		FileModifiedAfterFN, "",
+		"Only back up files modified after this datetime.")
+
+	fs.StringVar(
+		&FileModifiedBeforeFV,
+		FileModifiedBeforeFN, "",
+		"Only back up files modified before this datetime.")
+}
+
+// AddDriveVersionsFlags adds the flags that opt drive backups into
+// including prior versions of each file.
+func AddDriveVersionsFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.BoolVar(
+		&IncludeVersionsFV,
+		IncludeVersionsFN, false,
+		"Include prior versions of each file in the backup.")
+
+	fs.IntVar(
+		&MaxVersionsFV,
+		MaxVersionsFN, 0,
+		"Keep at most this many prior versions of each file; requires --"+IncludeVersionsFN+".")
+
+	fs.DurationVar(
+		&MaxVersionAgeFV,
+		MaxVersionAgeFN, 0,
+		"Skip prior versions older than this duration (ex: 720h); requires --"+IncludeVersionsFN+".")
+}
+
+// AddFileVersionFlag adds the flag for picking which backed up version of
+// each file gets restored or exported.
+func AddFileVersionFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringVar(
+		&FileVersionFV,
+		FileVersionFN, "",
+		"Select a prior version of each file by its version ID, or '"+control.AllVersions+
+			"' to include the full version history; defaults to the current version.")
+}
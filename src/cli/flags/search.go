@@ -0,0 +1,66 @@
+package flags
+
+import "github.com/spf13/cobra"
+
+const (
+	SearchSubjectFN        = "subject"
+	SearchSenderFN         = "sender"
+	SearchFileNameFN       = "file-name"
+	SearchContentFN        = "content"
+	SearchModifiedAfterFN  = "modified-after"
+	SearchModifiedBeforeFN = "modified-before"
+
+	IndexContentFN = "index-content"
+)
+
+var (
+	SearchSubjectFV        string
+	SearchSenderFV         string
+	SearchFileNameFV       string
+	SearchContentFV        string
+	SearchModifiedAfterFV  string
+	SearchModifiedBeforeFV string
+
+	IndexContentFV bool
+)
+
+// AddSearchFlags adds the flags that describe the items to search for.
+func AddSearchFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringVar(
+		&SearchSubjectFV,
+		SearchSubjectFN, "",
+		"Select emails, events, and messages whose subject has words starting with this value's words.")
+	fs.StringVar(
+		&SearchSenderFV,
+		SearchSenderFN, "",
+		"Select emails and messages whose sender has words starting with this value's words.")
+	fs.StringVar(
+		&SearchFileNameFV,
+		SearchFileNameFN, "",
+		"Select OneDrive and library files whose name has words starting with this value's words.")
+	fs.StringVar(
+		&SearchContentFV,
+		SearchContentFN, "",
+		"Select items whose text contains this value.  Only finds items backed up with --"+IndexContentFN+".")
+	fs.StringVar(
+		&SearchModifiedAfterFV,
+		SearchModifiedAfterFN, "",
+		"Select items modified after this datetime.")
+	fs.StringVar(
+		&SearchModifiedBeforeFV,
+		SearchModifiedBeforeFN, "",
+		"Select items modified before this datetime.")
+}
+
+// AddIndexContentFlag adds the flag that opts backups into indexing the text
+// of each item, for use by `corso search --content`.
+func AddIndexContentFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.BoolVar(
+		&IndexContentFV,
+		IndexContentFN, false,
+		"Index the text of messages, and of text, html, office, and pdf files, so that corso search --content can find them.")
+}
@@ -5,9 +5,10 @@ import (
 )
 
 const (
-	DataLibraries = "libraries"
-	DataPages     = "pages"
-	DataLists     = "lists"
+	DataLibraries         = "libraries"
+	DataPages             = "pages"
+	DataLists             = "lists"
+	DataSiteConfiguration = "site-config"
 )
 
 const (
@@ -22,6 +23,9 @@ const (
 	PageFolderFN = "page-folder"
 	PageFN       = "page"
 
+	IncludeListVersionsFN = "include-list-versions"
+	CreateMissingTermsFN  = "create-missing-terms"
+
 	SiteFN   = "site"    // site only accepts WebURL values
 	SiteIDFN = "site-id" // site-id accepts actual site ids
 )
@@ -38,6 +42,9 @@ var (
 	PageFolderFV []string
 	PageFV       []string
 
+	IncludeListVersionsFV bool
+	CreateMissingTermsFV  bool
+
 	SiteIDFV []string
 	WebURLFV []string
 )
@@ -142,3 +149,21 @@ func AddSiteFlag(cmd *cobra.Command, multiple bool) {
 
 	cmd.Flags().StringSliceVar(&WebURLFV, SiteFN, nil, message)
 }
+
+// AddListVersionsFlag adds the flag that opts list backups into including
+// the version history of each list item.
+func AddListVersionsFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&IncludeListVersionsFV,
+		IncludeListVersionsFN, false,
+		"Include the version history of each list item in the backup.")
+}
+
+// AddCreateMissingTermsFlag adds the flag that allows restores to recreate
+// the managed metadata terms missing from the destination's term store.
+func AddCreateMissingTermsFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&CreateMissingTermsFV,
+		CreateMissingTermsFN, false,
+		"Recreate managed metadata terms, term sets, and term groups missing from the site's term store.")
+}
@@ -0,0 +1,34 @@
+package testdata
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/alcionai/corso/src/cli/flags"
+)
+
+func PreparedDirectoryFlags() []string {
+	return []string{
+		"--" + flags.DirectoryUserFN, FlgInputs(DirectoryUserInput),
+		"--" + flags.DirectoryGroupFN, FlgInputs(DirectoryGroupInput),
+		"--" + flags.ApplicationFN, FlgInputs(ApplicationInput),
+		"--" + flags.ServicePrincipalFN, FlgInputs(ServicePrincipalInput),
+		"--" + flags.ConditionalAccessPolicyFN, FlgInputs(ConditionalAccessPolicyInput),
+		"--" + flags.AdminUnitFN, FlgInputs(AdminUnitInput),
+		"--" + flags.UserPrincipalNameFN, UserPrincipalNameInput,
+		"--" + flags.GroupMailNicknameFN, GroupMailNicknameInput,
+	}
+}
+
+func AssertDirectoryFlags(t *testing.T, cmd *cobra.Command) {
+	assert.ElementsMatch(t, DirectoryUserInput, flags.DirectoryUserFV)
+	assert.ElementsMatch(t, DirectoryGroupInput, flags.DirectoryGroupFV)
+	assert.ElementsMatch(t, ApplicationInput, flags.ApplicationFV)
+	assert.ElementsMatch(t, ServicePrincipalInput, flags.ServicePrincipalFV)
+	assert.ElementsMatch(t, ConditionalAccessPolicyInput, flags.ConditionalAccessPolicyFV)
+	assert.ElementsMatch(t, AdminUnitInput, flags.AdminUnitFV)
+	assert.Equal(t, UserPrincipalNameInput, flags.UserPrincipalNameFV)
+	assert.Equal(t, GroupMailNicknameInput, flags.GroupMailNicknameFV)
+}
@@ -9,8 +9,9 @@ import (
 func FlgInputs(in []string) string { return strings.Join(in, ",") }
 
 var (
-	BackupInput = "backup-id"
-	SiteInput   = "site-id"
+	BackupInput        = "backup-id"
+	CompareBackupInput = "compare-backup-id"
+	SiteInput          = "site-id"
 
 	GroupsInput  = []string{"team1", "group2"}
 	MailboxInput = []string{"mailbox1", "mailbox2"}
@@ -22,6 +23,16 @@ var (
 	SharepointCategoryDataInput = []string{"files", "lists", "pages"}
 	GroupsCategoryDataInput     = []string{"files", "lists", "pages", "messages"}
 	TeamsChatsCategoryDataInput = []string{"chats"}
+	DirectoryCategoryDataInput  = []string{"users", "groups", "applications"}
+
+	DirectoryUserInput           = []string{"dirUser1", "dirUser2"}
+	DirectoryGroupInput          = []string{"dirGroup1", "dirGroup2"}
+	ApplicationInput             = []string{"app1", "app2"}
+	ServicePrincipalInput        = []string{"sp1", "sp2"}
+	ConditionalAccessPolicyInput = []string{"policy1", "policy2"}
+	AdminUnitInput               = []string{"adminUnit1", "adminUnit2"}
+	UserPrincipalNameInput       = "userPrincipalName"
+	GroupMailNicknameInput       = "groupMailNickname"
 
 	ChannelInput                = []string{"channel1", "channel2"}
 	MessageInput                = []string{"message1", "message2"}
@@ -37,12 +48,15 @@ var (
 	ConversationInput = []string{"conversation1", "conversation2"}
 	PostInput         = []string{"post1", "post2"}
 
+	PlanInput = []string{"plan1", "plan2"}
+
 	EmailInput               = []string{"mail1", "mail2"}
 	EmailFldInput            = []string{"mailFld1", "mailFld2"}
 	EmailReceivedAfterInput  = "mailReceivedAfter"
 	EmailReceivedBeforeInput = "mailReceivedBefore"
 	EmailSenderInput         = "mailSender"
 	EmailSubjectInput        = "mailSubject"
+	EmailWellKnownFldInput   = "recoverable-items"
 
 	EventInput             = []string{"event1", "event2"}
 	EventCalInput          = []string{"eventCal1", "eventCal2"}
@@ -93,6 +107,10 @@ var (
 
 	FetchParallelism = "3"
 
+	MaxDownloadRateInput      = "10MB"
+	DownloadRateScheduleInput = []string{"09:00-17:00=1MB", "22:00-06:00=0"}
+	CheckpointIntervalInput   = "10m"
+
 	FailFast              = true
 	DisableIncrementals   = true
 	ForceItemDataDownload = true
@@ -40,3 +40,27 @@ func AssertConversationFlags(t *testing.T, cmd *cobra.Command) {
 	assert.Equal(t, ConversationInput, flags.ConversationFV)
 	assert.Equal(t, PostInput, flags.PostFV)
 }
+
+func PreparedPlanAndTeamStructureFlags() []string {
+	return []string{
+		"--" + flags.PlanFN, FlgInputs(PlanInput),
+		"--" + flags.TeamStructureFN,
+	}
+}
+
+func AssertPlanAndTeamStructureFlags(t *testing.T, cmd *cobra.Command) {
+	assert.Equal(t, PlanInput, flags.PlanFV)
+	assert.True(t, flags.TeamStructureFV)
+}
+
+func PreparedMembershipRestoreFlags() []string {
+	return []string{
+		"--" + flags.MembershipFN,
+		"--" + flags.DryRunFN,
+	}
+}
+
+func AssertMembershipRestoreFlags(t *testing.T, cmd *cobra.Command) {
+	assert.True(t, flags.MembershipFV)
+	assert.True(t, flags.DryRunFV)
+}
@@ -2,6 +2,7 @@ package testdata
 
 import (
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -46,6 +47,10 @@ func PreparedGenericBackupFlags() []string {
 		"--" + flags.FailFastFN,
 		"--" + flags.DisableIncrementalsFN,
 		"--" + flags.ForceItemDataDownloadFN,
+		"--" + flags.DryRunFN,
+		"--" + flags.MaxDownloadRateFN, MaxDownloadRateInput,
+		"--" + flags.DownloadRateScheduleFN, FlgInputs(DownloadRateScheduleInput),
+		"--" + flags.CheckpointIntervalFN, CheckpointIntervalInput,
 	}
 }
 
@@ -53,4 +58,8 @@ func AssertGenericBackupFlags(t *testing.T, cmd *cobra.Command) {
 	assert.True(t, flags.FailFastFV, "fail fast flag")
 	assert.True(t, flags.DisableIncrementalsFV, "disable incrementals flag")
 	assert.True(t, flags.ForceItemDataDownloadFV, "force item data download flag")
+	assert.True(t, flags.BackupDryRunFV, "dry run flag")
+	assert.Equal(t, MaxDownloadRateInput, flags.MaxDownloadRateFV, "max download rate flag")
+	assert.Equal(t, DownloadRateScheduleInput, flags.DownloadRateScheduleFV, "download rate schedule flag")
+	assert.Equal(t, 10*time.Minute, flags.CheckpointIntervalFV, "checkpoint interval flag")
 }
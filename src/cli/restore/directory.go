@@ -0,0 +1,90 @@
+package restore
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+// called by restore.go to map subcommands to provider-specific handling.
+func addDirectoryCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case restoreCommand:
+		c, _ = utils.AddCommand(cmd, directoryRestoreCmd(), utils.MarkPreReleaseCommand())
+
+		c.Use = c.Use + " " + directoryServiceCommandUseSuffix
+
+		flags.AddBackupIDFlag(c, true)
+		flags.AddDirectoryDetailsAndRestoreFlags(c)
+		flags.AddRestoreConfigFlags(c, false)
+		flags.AddFailFastFlag(c)
+	}
+
+	return c
+}
+
+const (
+	directoryServiceCommand          = "directory"
+	directoryServiceCommandUseSuffix = "--backup <backupId>"
+
+	directoryServiceCommandRestoreExamples = `# Recreate the deleted groups from the backup (1234abcd...)
+corso restore directory --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Recreate the group named "Marketing" if it was deleted
+corso restore directory --backup 1234abcd-12ab-cd34-56de-1234abcd --directory-group Marketing
+
+# Reset the attributes of existing groups to their backed up values
+corso restore directory --backup 1234abcd-12ab-cd34-56de-1234abcd --directory-group '*' --collisions replace`
+)
+
+// `corso restore directory [<flag>...]`
+func directoryRestoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     directoryServiceCommand,
+		Short:   "Restore M365 directory groups",
+		RunE:    restoreDirectoryCmd,
+		Args:    cobra.NoArgs,
+		Example: directoryServiceCommandRestoreExamples,
+	}
+}
+
+// processes a directory restore.
+func restoreDirectoryCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := utils.MakeDirectoryOpts(cmd)
+	opts.RestoreCfg.DTTMFormat = dttm.HumanReadable
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	if err := utils.ValidateDirectoryRestoreFlags(flags.BackupIDFV, opts, true); err != nil {
+		return err
+	}
+
+	// only groups can be restored at this time.
+	if len(opts.Groups) == 0 {
+		opts.Groups = selectors.Any()
+	}
+
+	sel := utils.IncludeDirectoryRestoreDataSelectors(ctx, opts)
+	utils.FilterDirectoryRestoreInfoSelectors(sel, opts)
+
+	return runRestore(
+		ctx,
+		cmd,
+		opts.RestoreCfg,
+		sel.Selector,
+		flags.BackupIDFV,
+		"Directory")
+}
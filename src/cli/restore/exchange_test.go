@@ -61,6 +61,7 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 						"--" + flags.EmailReceivedBeforeFN, flagsTD.EmailReceivedBeforeInput,
 						"--" + flags.EmailSenderFN, flagsTD.EmailSenderInput,
 						"--" + flags.EmailSubjectFN, flagsTD.EmailSubjectInput,
+						"--" + flags.EmailWellKnownFolderFN, flagsTD.EmailWellKnownFldInput,
 						"--" + flags.EventFN, flagsTD.FlgInputs(flagsTD.EventInput),
 						"--" + flags.EventCalendarFN, flagsTD.FlgInputs(flagsTD.EventCalInput),
 						"--" + flags.EventOrganizerFN, flagsTD.EventOrganizerInput,
@@ -95,6 +96,7 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 			assert.Equal(t, flagsTD.EmailReceivedBeforeInput, opts.EmailReceivedBefore)
 			assert.Equal(t, flagsTD.EmailSenderInput, opts.EmailSender)
 			assert.Equal(t, flagsTD.EmailSubjectInput, opts.EmailSubject)
+			assert.Equal(t, flagsTD.EmailWellKnownFldInput, opts.EmailWellKnownFolder)
 			assert.ElementsMatch(t, flagsTD.EventInput, opts.Event)
 			assert.ElementsMatch(t, flagsTD.EventCalInput, opts.EventCalendar)
 			assert.Equal(t, flagsTD.EventOrganizerInput, opts.EventOrganizer)
@@ -1,12 +1,22 @@
 package restore
 
 import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/backup/membership"
 	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365"
 )
 
 // called by restore.go to map subcommands to provider-specific handling.
@@ -23,8 +33,12 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddSiteFlag(c, false)
 		flags.AddSiteIDFlag(c, false)
 		flags.AddNoPermissionsFlag(c)
+		flags.AddFileVersionFlag(c)
 		flags.AddSharePointDetailsAndRestoreFlags(c)
-		flags.AddRestoreConfigFlags(c, false)
+		flags.AddGroupEventFlags(c)
+		flags.AddPlanAndTeamStructureFlags(c)
+		flags.AddMembershipRestoreFlags(c)
+		flags.AddRestoreConfigFlags(c, true)
 		flags.AddFailFastFlag(c)
 	}
 
@@ -47,7 +61,22 @@ corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd --file "FY2021 Pl
 
 # Restore all files and folders in folder "Documents/Finance Reports" that were created before 2020
 corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd \
-    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00`
+    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00
+
+# Restore the group calendar events with the subject "Offsite" into the Marketing group
+corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd --event-subject Offsite
+
+# Restore the plan named "Launch" into the Marketing group
+corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd --plan Launch
+
+# Recreate the team's channels, tabs, and installed apps in the Sales team
+corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd --team-structure --to-resource Sales
+
+# List the owners and members from the backup who are no longer in the group
+corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd --membership --dry-run
+
+# Re-add the owners and members from the backup who are no longer in the group
+corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd --membership`
 )
 
 // `corso restore groups [<flag>...]`
@@ -81,6 +110,10 @@ func restoreGroupsCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if opts.Membership {
+		return runGroupsMembershipRestore(ctx, cmd, opts, flags.BackupIDFV)
+	}
+
 	sel := utils.IncludeGroupsRestoreDataSelectors(ctx, opts)
 	utils.FilterGroupsRestoreInfoSelectors(sel, opts)
 
@@ -96,3 +129,75 @@ func restoreGroupsCmd(cmd *cobra.Command, args []string) error {
 		flags.BackupIDFV,
 		"Groups")
 }
+
+// runGroupsMembershipRestore re-adds the owners and members recorded in
+// the backup who are missing from the group.  The group defaults to the
+// backup's protected resource.
+func runGroupsMembershipRestore(
+	ctx context.Context,
+	cmd *cobra.Command,
+	opts utils.GroupsOpts,
+	backupID string,
+) error {
+	r, rdao, err := utils.GetAccountAndConnect(ctx, cmd, path.GroupsService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	backedUp, bup, err := r.GetGroupMembership(ctx, backupID)
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return Only(ctx, clues.New("No membership recorded in backup "+backupID))
+		}
+
+		return Only(ctx, clues.Wrap(err, "Failed to get membership for backup "+backupID))
+	}
+
+	svcCli, err := m365.NewM365Client(ctx, rdao.Repo.Account)
+	if err != nil {
+		return Only(ctx, clues.Stack(err))
+	}
+
+	groupID := bup.ResourceOwnerID
+
+	if len(opts.RestoreCfg.ProtectedResource) > 0 {
+		g, err := svcCli.GroupByID(ctx, opts.RestoreCfg.ProtectedResource)
+		if err != nil {
+			return Only(ctx, clues.Wrap(err, "Failed to find group "+opts.RestoreCfg.ProtectedResource))
+		}
+
+		groupID = g.ID
+	}
+
+	errs := fault.New(false)
+
+	added, err := svcCli.RestoreGroupMembership(ctx, groupID, *backedUp, opts.DryRun, errs)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to restore group membership"))
+	}
+
+	if opts.DryRun {
+		Outf(ctx, "Dry run: %d owners and members would be re-added", len(added))
+	} else {
+		Info(ctx, "Restore Complete")
+		Outf(ctx, "Re-added %d owners and members", len(added))
+	}
+
+	if len(added) > 0 {
+		membership.PrintAll(ctx, added)
+	}
+
+	if len(errs.Recovered()) > 0 {
+		Infof(ctx, "\nRestore failures")
+
+		for _, e := range errs.Recovered() {
+			Err(ctx, e.Error())
+		}
+
+		return Only(ctx, clues.New("Incomplete restore of group membership"))
+	}
+
+	return nil
+}
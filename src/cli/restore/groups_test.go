@@ -65,9 +65,11 @@ func (suite *GroupsUnitSuite) TestAddGroupsCommands() {
 						"--" + flags.PageFolderFN, flagsTD.FlgInputs(flagsTD.PageFolderInput),
 						"--" + flags.CollisionsFN, flagsTD.Collisions,
 						"--" + flags.DestinationFN, flagsTD.Destination,
-						// "--" + flags.ToResourceFN, flagsTD.ToResource,
+						"--" + flags.ToResourceFN, flagsTD.ToResource,
 						"--" + flags.NoPermissionsFN,
 					},
+					flagsTD.PreparedPlanAndTeamStructureFlags(),
+					flagsTD.PreparedMembershipRestoreFlags(),
 					flagsTD.PreparedProviderFlags(),
 					flagsTD.PreparedStorageFlags()))
 
@@ -92,8 +94,10 @@ func (suite *GroupsUnitSuite) TestAddGroupsCommands() {
 			assert.Equal(t, flagsTD.Collisions, opts.RestoreCfg.Collisions)
 			assert.Equal(t, flagsTD.Destination, opts.RestoreCfg.Destination)
 			assert.ElementsMatch(t, flagsTD.ListsInput, opts.Lists)
-			// assert.Equal(t, flagsTD.ToResource, opts.RestoreCfg.ProtectedResource)
+			assert.Equal(t, flagsTD.ToResource, opts.RestoreCfg.ProtectedResource)
 			assert.True(t, flags.NoPermissionsFV)
+			flagsTD.AssertPlanAndTeamStructureFlags(t, cmd)
+			flagsTD.AssertMembershipRestoreFlags(t, cmd)
 			flagsTD.AssertProviderFlags(t, cmd)
 			flagsTD.AssertStorageFlags(t, cmd)
 		})
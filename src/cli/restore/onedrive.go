@@ -21,6 +21,7 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddOneDriveDetailsAndRestoreFlags(c)
 		flags.AddNoPermissionsFlag(c)
+		flags.AddFileVersionFlag(c)
 		flags.AddRestoreConfigFlags(c, true)
 		flags.AddFailFastFlag(c)
 	}
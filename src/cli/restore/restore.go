@@ -20,6 +20,7 @@ var restoreCommands = []func(cmd *cobra.Command) *cobra.Command{
 	addOneDriveCommands,
 	addSharePointCommands,
 	addGroupsCommands,
+	addDirectoryCommands,
 }
 
 // AddCommands attaches all `corso restore * *` commands to the parent.
@@ -21,6 +21,8 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddSharePointDetailsAndRestoreFlags(c)
 		flags.AddNoPermissionsFlag(c)
+		flags.AddFileVersionFlag(c)
+		flags.AddCreateMissingTermsFlag(c)
 		flags.AddRestoreConfigFlags(c, true)
 		flags.AddFailFastFlag(c)
 	}
@@ -70,7 +72,11 @@ corso restore sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd \
 
 # Restore lists modified after a given time
 corso restore sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd \
-    --list-modified-after 2024-01-01T12:23:34`
+    --list-modified-after 2024-01-01T12:23:34
+
+# Restore lists, recreating the managed metadata terms they use that are missing
+corso restore sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --list "list-name-1" --create-missing-terms`
 )
 
 // `corso restore sharepoint [<flag>...]`
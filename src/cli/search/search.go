@@ -0,0 +1,88 @@
+package search
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/backup/search"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+const searchCommand = "search"
+
+const searchCommandExamples = `# Find every backed up email with "Budget" in its subject
+corso search --subject Budget
+
+# Find the emails and messages sent by Alice since the start of 2024
+corso search --sender alice@example.com --modified-after 2024-01-01
+
+# Find every backed up version of files named "Fiscal 22"
+corso search --file-name "Fiscal 22"
+
+# Find the emails, messages, and files that mention "quarterly forecast" in
+# their text, in backups created with --index-content
+corso search --content "quarterly forecast"`
+
+// AddCommands attaches the `corso search` command to the parent.
+func AddCommands(cmd *cobra.Command) {
+	c, _ := utils.AddCommand(cmd, searchCmd(), utils.MarkPreviewCommand())
+
+	flags.AddSearchFlags(c)
+	flags.AddAllProviderFlags(c)
+	flags.AddAllStorageFlags(c)
+}
+
+// The search command.
+// `corso search [<flag>...]`
+func searchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   searchCommand,
+		Short: "Search for items across all backups",
+		Long: `Search for backed up items across every backup in the repository.
+Each backup stores an index of its items in the repository, so searches only
+read the details of the backups that hold matching items.  Subject, sender,
+and file name values match the items with words starting with each of the
+value's words.`,
+		RunE:    handleSearchCmd,
+		Args:    cobra.NoArgs,
+		Example: searchCommandExamples,
+	}
+}
+
+// Handler for calls to `corso search`.
+func handleSearchCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	ctx := cmd.Context()
+
+	q, err := utils.MakeSearchQuery(cmd)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.UnknownService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	items, err := search.Search(ctx, r, q)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	if len(items) == 0 {
+		Info(ctx, selectors.ErrorNoMatchingItems)
+		return nil
+	}
+
+	search.PrintItems(ctx, items)
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	flagsTD "github.com/alcionai/corso/src/cli/flags/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type SearchUnitSuite struct {
+	tester.Suite
+}
+
+func TestSearchUnitSuite(t *testing.T) {
+	suite.Run(t, &SearchUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SearchUnitSuite) TestAddSearchCommands() {
+	t := suite.T()
+	cmd := &cobra.Command{Use: "root"}
+
+	AddCommands(cmd)
+
+	cmds := cmd.Commands()
+	require.Len(t, cmds, 1)
+
+	c := cmds[0]
+	assert.Equal(t, searchCommand, c.Use)
+	assert.Equal(t, searchCmd().Short, c.Short)
+	tester.AreSameFunc(t, handleSearchCmd, c.RunE)
+}
+
+func (suite *SearchUnitSuite) TestSearchFlags() {
+	t := suite.T()
+	cmd := &cobra.Command{Use: "root"}
+
+	flags.AddRunModeFlag(cmd, true)
+	AddCommands(cmd)
+
+	flagsTD.WithFlags(
+		searchCommand,
+		[]string{
+			"--" + flags.RunModeFN, flags.RunModeFlagTest,
+			"--" + flags.SearchSubjectFN, "budget",
+			"--" + flags.SearchSenderFN, "bob",
+			"--" + flags.SearchFileNameFN, "fiscal",
+			"--" + flags.SearchModifiedAfterFN, "2024-01-01",
+			"--" + flags.SearchModifiedBeforeFN, "2024-02-01",
+		},
+		flagsTD.PreparedProviderFlags(),
+		flagsTD.PreparedStorageFlags())(cmd)
+
+	cmd.SetOut(new(bytes.Buffer)) // drop output
+	cmd.SetErr(new(bytes.Buffer)) // drop output
+
+	err := cmd.Execute()
+	require.NoError(t, err, clues.ToCore(err))
+
+	c, _, err := cmd.Find([]string{searchCommand})
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "budget", flags.SearchSubjectFV)
+	assert.Equal(t, "bob", flags.SearchSenderFV)
+	assert.Equal(t, "fiscal", flags.SearchFileNameFV)
+	assert.Equal(t, "2024-01-01", flags.SearchModifiedAfterFV)
+	assert.Equal(t, "2024-02-01", flags.SearchModifiedBeforeFV)
+	flagsTD.AssertProviderFlags(t, c)
+	flagsTD.AssertStorageFlags(t, c)
+}
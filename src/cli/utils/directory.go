@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type DirectoryOpts struct {
+	Users                     []string
+	Groups                    []string
+	Applications              []string
+	ServicePrincipals         []string
+	ConditionalAccessPolicies []string
+	AdminUnits                []string
+
+	UserPrincipalName string
+	GroupMailNickname string
+
+	RestoreCfg RestoreCfgOpts
+	ExportCfg  ExportCfgOpts
+
+	Populated flags.PopulatedFlags
+}
+
+func DirectoryAllowedCategories() map[string]struct{} {
+	return map[string]struct{}{
+		flags.DataDirectoryUsers:    {},
+		flags.DataDirectoryGroups:   {},
+		flags.DataApplications:      {},
+		flags.DataServicePrincipals: {},
+		flags.DataConditionalAccess: {},
+		flags.DataAdminUnits:        {},
+	}
+}
+
+func AddDirectoryCategories(sel *selectors.DirectoryBackup, cats []string) *selectors.DirectoryBackup {
+	if len(cats) == 0 {
+		sel.Include(sel.AllData())
+	}
+
+	for _, d := range cats {
+		switch d {
+		case flags.DataDirectoryUsers:
+			sel.Include(sel.Users(selectors.Any()))
+		case flags.DataDirectoryGroups:
+			sel.Include(sel.Groups(selectors.Any()))
+		case flags.DataApplications:
+			sel.Include(sel.Applications(selectors.Any()))
+		case flags.DataServicePrincipals:
+			sel.Include(sel.ServicePrincipals(selectors.Any()))
+		case flags.DataConditionalAccess:
+			sel.Include(sel.ConditionalAccessPolicies(selectors.Any()))
+		case flags.DataAdminUnits:
+			sel.Include(sel.AdministrativeUnits(selectors.Any()))
+		}
+	}
+
+	return sel
+}
+
+func MakeDirectoryOpts(cmd *cobra.Command) DirectoryOpts {
+	return DirectoryOpts{
+		Users:                     flags.DirectoryUserFV,
+		Groups:                    flags.DirectoryGroupFV,
+		Applications:              flags.ApplicationFV,
+		ServicePrincipals:         flags.ServicePrincipalFV,
+		ConditionalAccessPolicies: flags.ConditionalAccessPolicyFV,
+		AdminUnits:                flags.AdminUnitFV,
+
+		UserPrincipalName: flags.UserPrincipalNameFV,
+		GroupMailNickname: flags.GroupMailNicknameFV,
+
+		RestoreCfg: makeRestoreCfgOpts(cmd),
+		ExportCfg:  makeExportCfgOpts(cmd),
+
+		// populated contains the list of flags that appear in the
+		// command, according to pflags.  Use this to differentiate
+		// between an "empty" and a "missing" value.
+		Populated: flags.GetPopulatedFlags(cmd),
+	}
+}
+
+// ValidateDirectoryRestoreFlags checks common flags for correctness and interdependencies
+func ValidateDirectoryRestoreFlags(backupID string, opts DirectoryOpts, isRestore bool) error {
+	if len(backupID) == 0 {
+		return clues.New("a backup ID is required")
+	}
+
+	if !isRestore {
+		return nil
+	}
+
+	// only groups can be restored at this time.
+	if len(opts.Users)+
+		len(opts.Applications)+
+		len(opts.ServicePrincipals)+
+		len(opts.ConditionalAccessPolicies)+
+		len(opts.AdminUnits) > 0 ||
+		len(opts.UserPrincipalName) > 0 {
+		return clues.New("only directory groups can be restored")
+	}
+
+	if len(opts.RestoreCfg.ProtectedResource) > 0 {
+		return clues.New("--" + flags.ToResourceFN + " is not supported when restoring directory data")
+	}
+
+	return nil
+}
+
+// AddDirectoryFilter adds the scope of the provided values to the selector's
+// filter set
+func AddDirectoryFilter(
+	sel *selectors.DirectoryRestore,
+	v string,
+	f func(string) []selectors.DirectoryScope,
+) {
+	if len(v) == 0 {
+		return
+	}
+
+	sel.Filter(f(v))
+}
+
+// IncludeDirectoryRestoreDataSelectors builds the common data-selector
+// inclusions for directory commands.
+func IncludeDirectoryRestoreDataSelectors(ctx context.Context, opts DirectoryOpts) *selectors.DirectoryRestore {
+	var (
+		users, groups  = len(opts.Users), len(opts.Groups)
+		apps, sps      = len(opts.Applications), len(opts.ServicePrincipals)
+		cas, adminUnit = len(opts.ConditionalAccessPolicies), len(opts.AdminUnits)
+		sel            = selectors.NewDirectoryRestore(selectors.Any())
+	)
+
+	if users+groups+apps+sps+cas+adminUnit == 0 {
+		sel.Include(sel.AllData())
+		return sel
+	}
+
+	if users > 0 {
+		sel.Include(sel.Users(opts.Users))
+	}
+
+	if groups > 0 {
+		sel.Include(sel.Groups(opts.Groups))
+	}
+
+	if apps > 0 {
+		sel.Include(sel.Applications(opts.Applications))
+	}
+
+	if sps > 0 {
+		sel.Include(sel.ServicePrincipals(opts.ServicePrincipals))
+	}
+
+	if cas > 0 {
+		sel.Include(sel.ConditionalAccessPolicies(opts.ConditionalAccessPolicies))
+	}
+
+	if adminUnit > 0 {
+		sel.Include(sel.AdministrativeUnits(opts.AdminUnits))
+	}
+
+	return sel
+}
+
+// FilterDirectoryRestoreInfoSelectors builds the common info-selector filters.
+func FilterDirectoryRestoreInfoSelectors(
+	sel *selectors.DirectoryRestore,
+	opts DirectoryOpts,
+) {
+	AddDirectoryFilter(sel, opts.UserPrincipalName, sel.UserPrincipalName)
+	AddDirectoryFilter(sel, opts.GroupMailNickname, sel.GroupMailNickname)
+}
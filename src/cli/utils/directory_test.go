@@ -0,0 +1,179 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type DirectoryUtilsSuite struct {
+	tester.Suite
+}
+
+func TestDirectoryUtilsSuite(t *testing.T) {
+	suite.Run(t, &DirectoryUtilsSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *DirectoryUtilsSuite) TestIncludeDirectoryRestoreDataSelectors() {
+	var (
+		single = []string{"single"}
+		multi  = []string{"more", "than", "one"}
+	)
+
+	table := []struct {
+		name             string
+		opts             utils.DirectoryOpts
+		expectIncludeLen int
+	}{
+		{
+			name:             "no inputs",
+			opts:             utils.DirectoryOpts{},
+			expectIncludeLen: 6,
+		},
+		{
+			name: "users",
+			opts: utils.DirectoryOpts{
+				Users: multi,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "groups and applications",
+			opts: utils.DirectoryOpts{
+				Groups:       single,
+				Applications: multi,
+			},
+			expectIncludeLen: 2,
+		},
+		{
+			name: "everything",
+			opts: utils.DirectoryOpts{
+				Users:                     single,
+				Groups:                    single,
+				Applications:              single,
+				ServicePrincipals:         single,
+				ConditionalAccessPolicies: single,
+				AdminUnits:                single,
+			},
+			expectIncludeLen: 6,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sel := utils.IncludeDirectoryRestoreDataSelectors(ctx, test.opts)
+			assert.Len(t, sel.Includes, test.expectIncludeLen)
+		})
+	}
+}
+
+func (suite *DirectoryUtilsSuite) TestValidateDirectoryRestoreFlags() {
+	table := []struct {
+		name      string
+		backupID  string
+		opts      utils.DirectoryOpts
+		isRestore bool
+		expect    assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "no backup id",
+			opts:      utils.DirectoryOpts{},
+			isRestore: true,
+			expect:    assert.Error,
+		},
+		{
+			name:      "groups",
+			backupID:  "id",
+			opts:      utils.DirectoryOpts{Groups: []string{"group"}},
+			isRestore: true,
+			expect:    assert.NoError,
+		},
+		{
+			name:      "users on restore",
+			backupID:  "id",
+			opts:      utils.DirectoryOpts{Users: []string{"user"}},
+			isRestore: true,
+			expect:    assert.Error,
+		},
+		{
+			name:     "users on export",
+			backupID: "id",
+			opts:     utils.DirectoryOpts{Users: []string{"user"}},
+			expect:   assert.NoError,
+		},
+		{
+			name:     "restore to resource",
+			backupID: "id",
+			opts: utils.DirectoryOpts{
+				RestoreCfg: utils.RestoreCfgOpts{ProtectedResource: "tenant"},
+			},
+			isRestore: true,
+			expect:    assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := utils.ValidateDirectoryRestoreFlags(test.backupID, test.opts, test.isRestore)
+			test.expect(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *DirectoryUtilsSuite) TestAddDirectoryCategories() {
+	table := []struct {
+		name           string
+		cats           []string
+		expectScopeLen int
+	}{
+		{
+			name:           "none",
+			cats:           []string{},
+			expectScopeLen: 6,
+		},
+		{
+			name:           "users",
+			cats:           []string{flags.DataDirectoryUsers},
+			expectScopeLen: 1,
+		},
+		{
+			name:           "conditional access",
+			cats:           []string{flags.DataConditionalAccess},
+			expectScopeLen: 1,
+		},
+		{
+			name: "all allowed",
+			cats: []string{
+				flags.DataDirectoryUsers,
+				flags.DataDirectoryGroups,
+				flags.DataApplications,
+				flags.DataServicePrincipals,
+				flags.DataConditionalAccess,
+				flags.DataAdminUnits,
+			},
+			expectScopeLen: 6,
+		},
+		{
+			name:           "bad inputs",
+			cats:           []string{"foo"},
+			expectScopeLen: 0,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			sel := utils.AddDirectoryCategories(selectors.NewDirectoryBackup(selectors.Any()), test.cats)
+			scopes := sel.Scopes()
+			assert.Len(suite.T(), scopes, test.expectScopeLen)
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package utils
 
 import (
+	"slices"
+
 	"github.com/alcionai/clues"
 	"github.com/spf13/cobra"
 
 	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
 
@@ -15,12 +18,13 @@ type ExchangeOpts struct {
 	ContactFolder []string
 	ContactName   string
 
-	Email               []string
-	EmailFolder         []string
-	EmailReceivedAfter  string
-	EmailReceivedBefore string
-	EmailSender         string
-	EmailSubject        string
+	Email                []string
+	EmailFolder          []string
+	EmailReceivedAfter   string
+	EmailReceivedBefore  string
+	EmailSender          string
+	EmailSubject         string
+	EmailWellKnownFolder string
 
 	Event             []string
 	EventCalendar     []string
@@ -45,12 +49,13 @@ func MakeExchangeOpts(cmd *cobra.Command) ExchangeOpts {
 		ContactFolder: flags.ContactFolderFV,
 		ContactName:   flags.ContactNameFV,
 
-		Email:               flags.EmailFV,
-		EmailFolder:         flags.EmailFolderFV,
-		EmailReceivedAfter:  flags.EmailReceivedAfterFV,
-		EmailReceivedBefore: flags.EmailReceivedBeforeFV,
-		EmailSender:         flags.EmailSenderFV,
-		EmailSubject:        flags.EmailSubjectFV,
+		Email:                flags.EmailFV,
+		EmailFolder:          flags.EmailFolderFV,
+		EmailReceivedAfter:   flags.EmailReceivedAfterFV,
+		EmailReceivedBefore:  flags.EmailReceivedBeforeFV,
+		EmailSender:          flags.EmailSenderFV,
+		EmailSubject:         flags.EmailSubjectFV,
+		EmailWellKnownFolder: flags.EmailWellKnownFolderFV,
 
 		Event:             flags.EventFV,
 		EventCalendar:     flags.EventCalendarFV,
@@ -129,6 +134,11 @@ func ValidateExchangeRestoreFlags(backupID string, opts ExchangeOpts) error {
 		return clues.New("invalid time format for email-received-before")
 	}
 
+	if _, ok := opts.Populated[flags.EmailWellKnownFolderFN]; ok &&
+		!slices.Contains(control.WellKnownMailFolders, control.WellKnownMailFolder(opts.EmailWellKnownFolder)) {
+		return clues.New("invalid value for email-well-known-folder")
+	}
+
 	if _, ok := opts.Populated[flags.EventStartsAfterFN]; ok && !IsValidTimeFormat(opts.EventStartsAfter) {
 		return clues.New("invalid time format for event-starts-after")
 	}
@@ -183,6 +193,7 @@ func FilterExchangeRestoreInfoSelectors(
 	AddExchangeInfo(sel, opts.EmailReceivedBefore, sel.MailReceivedBefore)
 	AddExchangeInfo(sel, opts.EmailSender, sel.MailSender)
 	AddExchangeInfo(sel, opts.EmailSubject, sel.MailSubject)
+	AddExchangeInfo(sel, opts.EmailWellKnownFolder, sel.MailWellKnownFolder)
 	AddExchangeInfo(sel, opts.EventOrganizer, sel.EventOrganizer)
 	AddExchangeInfo(sel, opts.EventRecurs, sel.EventRecurs)
 	AddExchangeInfo(sel, opts.EventStartsAfter, sel.EventStartsAfter)
@@ -10,6 +10,7 @@ import (
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
@@ -51,6 +52,24 @@ func (suite *ExchangeUtilsSuite) TestValidateRestoreFlags() {
 			opts:   utils.ExchangeOpts{EmailReceivedAfter: "fnords"},
 			expect: assert.Error,
 		},
+		{
+			name:     "valid well-known folder",
+			backupID: "bid",
+			opts: utils.ExchangeOpts{
+				EmailWellKnownFolder: string(control.RecoverableItemsDeletions),
+				Populated:            flags.PopulatedFlags{flags.EmailWellKnownFolderFN: {}},
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:     "invalid well-known folder",
+			backupID: "bid",
+			opts: utils.ExchangeOpts{
+				EmailWellKnownFolder: "inbox",
+				Populated:            flags.PopulatedFlags{flags.EmailWellKnownFolderFN: {}},
+			},
+			expect: assert.Error,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -466,18 +485,19 @@ func (suite *ExchangeUtilsSuite) TestFilterExchangeRestoreInfoSelectors() {
 		{
 			name: "one of each",
 			opts: utils.ExchangeOpts{
-				ContactName:         stub,
-				EmailReceivedAfter:  stub,
-				EmailReceivedBefore: stub,
-				EmailSender:         stub,
-				EmailSubject:        stub,
-				EventOrganizer:      stub,
-				EventRecurs:         stub,
-				EventStartsAfter:    stub,
-				EventStartsBefore:   stub,
-				EventSubject:        stub,
-			},
-			expectFilterLen: 10,
+				ContactName:          stub,
+				EmailReceivedAfter:   stub,
+				EmailReceivedBefore:  stub,
+				EmailSender:          stub,
+				EmailSubject:         stub,
+				EmailWellKnownFolder: stub,
+				EventOrganizer:       stub,
+				EventRecurs:          stub,
+				EventStartsAfter:     stub,
+				EventStartsBefore:    stub,
+				EventSubject:         stub,
+			},
+			expectFilterLen: 11,
 		},
 	}
 	for _, test := range table {
@@ -13,16 +13,18 @@ import (
 )
 
 type ExportCfgOpts struct {
-	Archive bool
-	Format  string
+	Archive     bool
+	Format      string
+	FileVersion string
 
 	Populated flags.PopulatedFlags
 }
 
 func makeExportCfgOpts(cmd *cobra.Command) ExportCfgOpts {
 	return ExportCfgOpts{
-		Archive: flags.ArchiveFV,
-		Format:  flags.FormatFV,
+		Archive:     flags.ArchiveFV,
+		Format:      flags.FormatFV,
+		FileVersion: flags.FileVersionFV,
 
 		// populated contains the list of flags that appear in the
 		// command, according to pflags.  Use this to differentiate
@@ -39,6 +41,7 @@ func MakeExportConfig(
 
 	exportCfg.Archive = opts.Archive
 	exportCfg.Format = control.FormatType(opts.Format)
+	exportCfg.Version = opts.FileVersion
 
 	return exportCfg
 }
@@ -16,12 +16,23 @@ type GroupsOpts struct {
 	Messages      []string
 	Conversations []string
 	Posts         []string
+	Events        []string
+	Plans         []string
+	TeamStructure bool
+	Membership    bool
+	DryRun        bool
 
 	MessageCreatedAfter    string
 	MessageCreatedBefore   string
 	MessageLastReplyAfter  string
 	MessageLastReplyBefore string
 
+	EventOrganizer    string
+	EventRecurs       string
+	EventStartsAfter  string
+	EventStartsBefore string
+	EventSubject      string
+
 	SiteID             []string
 	WebURL             []string
 	Library            string
@@ -63,6 +74,9 @@ func GroupsAllowedCategories() map[string]struct{} {
 		flags.DataLibraries:     {},
 		flags.DataMessages:      {},
 		flags.DataConversations: {},
+		flags.DataEvents:        {},
+		flags.DataPlans:         {},
+		flags.DataTeamStructure: {},
 	}
 }
 
@@ -79,6 +93,12 @@ func AddGroupsCategories(sel *selectors.GroupsBackup, cats []string) *selectors.
 			sel.Include(sel.ChannelMessages(selectors.Any(), selectors.Any()))
 		case flags.DataConversations:
 			sel.Include(sel.ConversationPosts(selectors.Any(), selectors.Any()))
+		case flags.DataEvents:
+			sel.Include(sel.EventCalendars(selectors.Any()))
+		case flags.DataPlans:
+			sel.Include(sel.Plans(selectors.Any()))
+		case flags.DataTeamStructure:
+			sel.Include(sel.TeamStructure(selectors.Any()))
 		}
 	}
 
@@ -92,6 +112,11 @@ func MakeGroupsOpts(cmd *cobra.Command) GroupsOpts {
 		Messages:      flags.MessageFV,
 		Conversations: flags.ConversationFV,
 		Posts:         flags.PostFV,
+		Events:        flags.EventFV,
+		Plans:         flags.PlanFV,
+		TeamStructure: flags.TeamStructureFV,
+		Membership:    flags.MembershipFV,
+		DryRun:        flags.DryRunFV,
 		WebURL:        flags.WebURLFV,
 		SiteID:        flags.SiteIDFV,
 
@@ -106,6 +131,11 @@ func MakeGroupsOpts(cmd *cobra.Command) GroupsOpts {
 		MessageCreatedBefore:   flags.MessageCreatedBeforeFV,
 		MessageLastReplyAfter:  flags.MessageLastReplyAfterFV,
 		MessageLastReplyBefore: flags.MessageLastReplyBeforeFV,
+		EventOrganizer:         flags.EventOrganizerFV,
+		EventRecurs:            flags.EventRecursFV,
+		EventStartsAfter:       flags.EventStartsAfterFV,
+		EventStartsBefore:      flags.EventStartsBeforeFV,
+		EventSubject:           flags.EventSubjectFV,
 
 		Lists: flags.ListFV,
 
@@ -128,15 +158,33 @@ func ValidateGroupsRestoreFlags(backupID string, opts GroupsOpts, isRestore bool
 		return clues.New("a backup ID is required")
 	}
 
+	if opts.DryRun && !opts.Membership {
+		return clues.New("--" + flags.DryRunFN + " is only supported when restoring --" + flags.MembershipFN)
+	}
+
+	// Membership is restored on its own, directly into the group.
+	if isRestore && opts.Membership {
+		if siteDataSelected(opts) || eventDataSelected(opts) || len(opts.Plans) > 0 || opts.TeamStructure {
+			return clues.New("--" + flags.MembershipFN + " can't be combined with other data selections")
+		}
+
+		return nil
+	}
+
 	// The user has to explicitly specify which resource to restore. In
 	// this case, since we can only restore sites, the user is supposed
-	// to specify which site to restore.
-	if isRestore {
+	// to specify which site to restore.  Events, plans and the team structure
+	// are restored into the group itself, and don't need a site.
+	if isRestore && !onlyGroupDataSelected(opts) {
 		if len(opts.WebURL)+len(opts.SiteID) == 0 {
 			return clues.New("web URL of the site to restore is required. Use --" + flags.SiteFN + " to provide one.")
 		} else if len(opts.WebURL)+len(opts.SiteID) > 1 {
 			return clues.New("only a single site can be selected for restore")
 		}
+
+		if len(opts.RestoreCfg.ProtectedResource) > 0 {
+			return clues.New("--" + flags.ToResourceFN + " is only supported when restoring events, plans or the team structure")
+		}
 	}
 
 	if _, ok := opts.Populated[flags.MessageCreatedAfterFN]; ok && !IsValidTimeFormat(opts.MessageCreatedAfter) {
@@ -155,6 +203,18 @@ func ValidateGroupsRestoreFlags(backupID string, opts GroupsOpts, isRestore bool
 		return clues.New("invalid time format for " + flags.MessageLastReplyBeforeFN)
 	}
 
+	if _, ok := opts.Populated[flags.EventStartsAfterFN]; ok && !IsValidTimeFormat(opts.EventStartsAfter) {
+		return clues.New("invalid time format for " + flags.EventStartsAfterFN)
+	}
+
+	if _, ok := opts.Populated[flags.EventStartsBeforeFN]; ok && !IsValidTimeFormat(opts.EventStartsBefore) {
+		return clues.New("invalid time format for " + flags.EventStartsBeforeFN)
+	}
+
+	if _, ok := opts.Populated[flags.EventRecursFN]; ok && !IsValidBool(opts.EventRecurs) {
+		return clues.New("invalid format for " + flags.EventRecursFN)
+	}
+
 	return validateCommonTimeFlags(opts)
 }
 
@@ -182,8 +242,19 @@ func IncludeGroupsRestoreDataSelectors(ctx context.Context, opts GroupsOpts) *se
 		pageFolders, pageItems = len(opts.PageFolder), len(opts.Page)
 		chans, chanMsgs        = len(opts.Channels), len(opts.Messages)
 		convs, convPosts       = len(opts.Conversations), len(opts.Posts)
+		plans                  = len(opts.Plans)
+		events                 = 0
+		teamStructure          = 0
 	)
 
+	if eventDataSelected(opts) {
+		events = 1
+	}
+
+	if opts.TeamStructure {
+		teamStructure = 1
+	}
+
 	if len(opts.Groups) == 0 {
 		groups = selectors.Any()
 	}
@@ -194,8 +265,9 @@ func IncludeGroupsRestoreDataSelectors(ctx context.Context, opts GroupsOpts) *se
 		lists+
 		pageFolders+pageItems+
 		chans+chanMsgs+
-		convs+convPosts == 0 {
-		sel.Include(sel.AllData())
+		convs+convPosts+
+		events+plans+teamStructure == 0 {
+		sel.Include(sel.AllData(), sel.OptInData())
 		return sel
 	}
 
@@ -280,9 +352,60 @@ func IncludeGroupsRestoreDataSelectors(ctx context.Context, opts GroupsOpts) *se
 		}
 	}
 
+	// group calendar selectors
+
+	if events > 0 {
+		// if no event is specified, select the whole calendar and
+		// leave the narrowing to the event filters.
+		if len(opts.Events) == 0 {
+			sel.Include(sel.EventCalendars(selectors.Any()))
+		} else {
+			sel.Include(sel.Events(selectors.Any(), opts.Events))
+		}
+	}
+
+	// planner selectors
+
+	if plans > 0 {
+		sel.Include(sel.Plans(opts.Plans))
+	}
+
+	// team structure selectors
+
+	if teamStructure > 0 {
+		sel.Include(sel.TeamStructure(selectors.Any()))
+	}
+
 	return sel
 }
 
+// onlyGroupDataSelected is true if the opts select events, plans or the
+// team structure, and no other data.
+func onlyGroupDataSelected(opts GroupsOpts) bool {
+	return (eventDataSelected(opts) || len(opts.Plans) > 0 || opts.TeamStructure) &&
+		!siteDataSelected(opts)
+}
+
+// eventDataSelected is true if the opts select or filter events in the
+// group's calendar.
+func eventDataSelected(opts GroupsOpts) bool {
+	return len(opts.Events) > 0 ||
+		len(opts.EventOrganizer)+len(opts.EventRecurs)+
+			len(opts.EventStartsAfter)+len(opts.EventStartsBefore)+
+			len(opts.EventSubject) > 0
+}
+
+// siteDataSelected is true if the opts select any data that gets
+// restored into one of the group's sites.
+func siteDataSelected(opts GroupsOpts) bool {
+	return len(opts.SiteID)+len(opts.WebURL)+
+		len(opts.FolderPath)+len(opts.FileName)+
+		len(opts.Lists)+
+		len(opts.PageFolder)+len(opts.Page)+
+		len(opts.Channels)+len(opts.Messages)+
+		len(opts.Conversations)+len(opts.Posts) > 0
+}
+
 // FilterGroupsRestoreInfoSelectors builds the common info-selector filters.
 func FilterGroupsRestoreInfoSelectors(
 	sel *selectors.GroupsRestore,
@@ -314,4 +437,9 @@ func FilterGroupsRestoreInfoSelectors(
 	AddGroupsFilter(sel, opts.MessageCreatedBefore, sel.MessageCreatedBefore)
 	AddGroupsFilter(sel, opts.MessageLastReplyAfter, sel.MessageLastReplyAfter)
 	AddGroupsFilter(sel, opts.MessageLastReplyBefore, sel.MessageLastReplyBefore)
+	AddGroupsFilter(sel, opts.EventOrganizer, sel.EventOrganizer)
+	AddGroupsFilter(sel, opts.EventRecurs, sel.EventRecurs)
+	AddGroupsFilter(sel, opts.EventStartsAfter, sel.EventStartsAfter)
+	AddGroupsFilter(sel, opts.EventStartsBefore, sel.EventStartsBefore)
+	AddGroupsFilter(sel, opts.EventSubject, sel.EventSubject)
 }
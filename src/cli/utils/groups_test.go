@@ -43,28 +43,28 @@ func (suite *GroupsUtilsSuite) TestIncludeGroupsRestoreDataSelectors() {
 		{
 			name:             "no inputs",
 			opts:             utils.GroupsOpts{},
-			expectIncludeLen: 3,
+			expectIncludeLen: 6,
 		},
 		{
 			name: "empty",
 			opts: utils.GroupsOpts{
 				Groups: empty,
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 6,
 		},
 		{
 			name: "single inputs",
 			opts: utils.GroupsOpts{
 				Groups: single,
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 6,
 		},
 		{
 			name: "multi inputs",
 			opts: utils.GroupsOpts{
 				Groups: multi,
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 6,
 		},
 		// sharepoint
 		{
@@ -215,6 +215,41 @@ func (suite *GroupsUtilsSuite) TestIncludeGroupsRestoreDataSelectors() {
 			},
 			expectIncludeLen: 1,
 		},
+		// events
+		{
+			name: "multiple events",
+			opts: utils.GroupsOpts{
+				Groups: single,
+				Events: multi,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "event filter only",
+			opts: utils.GroupsOpts{
+				Groups:       single,
+				EventSubject: "subject",
+			},
+			expectIncludeLen: 1,
+		},
+		// plans
+		{
+			name: "multiple plans",
+			opts: utils.GroupsOpts{
+				Groups: single,
+				Plans:  multi,
+			},
+			expectIncludeLen: 1,
+		},
+		// team structure
+		{
+			name: "team structure",
+			opts: utils.GroupsOpts{
+				Groups:        single,
+				TeamStructure: true,
+			},
+			expectIncludeLen: 1,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -260,6 +295,100 @@ func (suite *GroupsUtilsSuite) TestValidateGroupsRestoreFlags() {
 			opts:     utils.GroupsOpts{SiteID: []string{"site-id"}, WebURL: []string{"site"}},
 			expect:   assert.Error,
 		},
+		{
+			name:     "just plans",
+			backupID: "id",
+			opts:     utils.GroupsOpts{Plans: []string{"plan"}}, // plans restore into the group
+			expect:   assert.NoError,
+		},
+		{
+			name:     "plans and libraries without site",
+			backupID: "id",
+			opts:     utils.GroupsOpts{Plans: []string{"plan"}, FolderPath: []string{"folder"}},
+			expect:   assert.Error,
+		},
+		{
+			name:     "just events",
+			backupID: "id",
+			opts:     utils.GroupsOpts{Events: []string{"event"}}, // events restore into the group calendar
+			expect:   assert.NoError,
+		},
+		{
+			name:     "event filter without site",
+			backupID: "id",
+			opts:     utils.GroupsOpts{EventOrganizer: "organizer"},
+			expect:   assert.NoError,
+		},
+		{
+			name:     "events and libraries without site",
+			backupID: "id",
+			opts:     utils.GroupsOpts{Events: []string{"event"}, FolderPath: []string{"folder"}},
+			expect:   assert.Error,
+		},
+		{
+			name:     "membership and events",
+			backupID: "id",
+			opts:     utils.GroupsOpts{Membership: true, Events: []string{"event"}},
+			expect:   assert.Error,
+		},
+		{
+			name:     "just team structure",
+			backupID: "id",
+			opts:     utils.GroupsOpts{TeamStructure: true},
+			expect:   assert.NoError,
+		},
+		{
+			name:     "team structure to another group",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				TeamStructure: true,
+				RestoreCfg:    utils.RestoreCfgOpts{ProtectedResource: "sales"},
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:     "just membership",
+			backupID: "id",
+			opts:     utils.GroupsOpts{Membership: true},
+			expect:   assert.NoError,
+		},
+		{
+			name:     "membership dry run to another group",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				Membership: true,
+				DryRun:     true,
+				RestoreCfg: utils.RestoreCfgOpts{ProtectedResource: "sales"},
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:     "membership and plans",
+			backupID: "id",
+			opts:     utils.GroupsOpts{Membership: true, Plans: []string{"plan"}},
+			expect:   assert.Error,
+		},
+		{
+			name:     "membership and site",
+			backupID: "id",
+			opts:     utils.GroupsOpts{Membership: true, WebURL: []string{"site"}},
+			expect:   assert.Error,
+		},
+		{
+			name:     "dry run without membership",
+			backupID: "id",
+			opts:     utils.GroupsOpts{WebURL: []string{"site"}, DryRun: true},
+			expect:   assert.Error,
+		},
+		{
+			name:     "libraries to another group",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				WebURL:     []string{"site"},
+				RestoreCfg: utils.RestoreCfgOpts{ProtectedResource: "sales"},
+			},
+			expect: assert.Error,
+		},
 		{
 			name:     "no backupID",
 			backupID: "",
@@ -418,14 +547,32 @@ func (suite *GroupsUtilsSuite) TestAddGroupsCategories() {
 			cats:           []string{flags.DataConversations},
 			expectScopeLen: 1,
 		},
+		{
+			name:           "events",
+			cats:           []string{flags.DataEvents},
+			expectScopeLen: 1,
+		},
+		{
+			name:           "plans",
+			cats:           []string{flags.DataPlans},
+			expectScopeLen: 1,
+		},
+		{
+			name:           "team structure",
+			cats:           []string{flags.DataTeamStructure},
+			expectScopeLen: 1,
+		},
 		{
 			name: "all allowed",
 			cats: []string{
 				flags.DataLibraries,
 				flags.DataMessages,
 				flags.DataConversations,
+				flags.DataEvents,
+				flags.DataPlans,
+				flags.DataTeamStructure,
 			},
-			expectScopeLen: 3,
+			expectScopeLen: 6,
 		},
 		{
 			name:           "bad inputs",
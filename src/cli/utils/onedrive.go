@@ -2,9 +2,11 @@ package utils
 
 import (
 	"github.com/alcionai/clues"
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 
 	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
 
@@ -132,3 +134,42 @@ func FilterOneDriveRestoreInfoSelectors(
 	AddOneDriveFilter(sel, opts.FileModifiedAfter, sel.ModifiedAfter)
 	AddOneDriveFilter(sel, opts.FileModifiedBefore, sel.ModifiedBefore)
 }
+
+// MakeFileFilter builds the backup file filter from the user's flags.
+func MakeFileFilter() (selectors.FileFilter, error) {
+	ff := selectors.FileFilter{
+		Extensions:        flags.FileExtensionFV,
+		ExcludeExtensions: flags.ExcludeFileExtensionFV,
+		MIMETypes:         flags.MIMETypeFV,
+		ExcludeMIMETypes:  flags.ExcludeMIMETypeFV,
+	}
+
+	if len(flags.MaxFileSizeFV) > 0 {
+		size, err := humanize.ParseBytes(flags.MaxFileSizeFV)
+		if err != nil || size == 0 {
+			return ff, clues.New("invalid size for " + flags.MaxFileSizeFN)
+		}
+
+		ff.MaxSize = int64(size)
+	}
+
+	if len(flags.FileModifiedAfterFV) > 0 {
+		t, err := dttm.ParseTime(flags.FileModifiedAfterFV)
+		if err != nil {
+			return ff, clues.New("invalid time format for " + flags.FileModifiedAfterFN)
+		}
+
+		ff.ModifiedAfter = t
+	}
+
+	if len(flags.FileModifiedBeforeFV) > 0 {
+		t, err := dttm.ParseTime(flags.FileModifiedBeforeFV)
+		if err != nil {
+			return ff, clues.New("invalid time format for " + flags.FileModifiedBeforeFN)
+		}
+
+		ff.ModifiedBefore = t
+	}
+
+	return ff, clues.Stack(ff.Validate()).OrNil()
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -106,3 +107,48 @@ func (suite *OneDriveUtilsSuite) TestIncludeOneDriveRestoreDataSelectors() {
 		})
 	}
 }
+
+func (suite *OneDriveUtilsSuite) TestMakeFileFilter_invalid() {
+	table := []struct {
+		name           string
+		maxSize        string
+		modifiedAfter  string
+		modifiedBefore string
+	}{
+		{
+			name:    "unparsable size",
+			maxSize: "lots",
+		},
+		{
+			name:    "zero size",
+			maxSize: "0B",
+		},
+		{
+			name:          "unparsable time",
+			modifiedAfter: "yesterday-ish",
+		},
+		{
+			name:           "empty modified window",
+			modifiedAfter:  "2024-02-01T00:00:00Z",
+			modifiedBefore: "2024-01-01T00:00:00Z",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			flags.MaxFileSizeFV = test.maxSize
+			flags.FileModifiedAfterFV = test.modifiedAfter
+			flags.FileModifiedBeforeFV = test.modifiedBefore
+
+			defer func() {
+				flags.MaxFileSizeFV = ""
+				flags.FileModifiedAfterFV = ""
+				flags.FileModifiedBeforeFV = ""
+			}()
+
+			_, err := utils.MakeFileFilter()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package utils
 
 import (
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/dustin/go-humanize"
+
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/pkg/config"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/extensions"
 )
 
 // Control produces the control options based on the user's flags.
@@ -30,6 +37,13 @@ func Control() control.Options {
 	opt.ToggleFeatures.ExchangeImmutableIDs = flags.EnableImmutableIDFV
 	opt.ToggleFeatures.UseOldDeltaProcess = flags.UseOldDeltaProcessFV
 	opt.Parallelism.ItemFetch = flags.FetchParallelismFV
+	opt.DriveVersions = driveVersionsConfig()
+	opt.ListItemVersions = flags.IncludeListVersionsFV
+	opt.MailMIME = control.MailMIMEMode(flags.MailMIMEFV)
+	opt.DryRun = flags.BackupDryRunFV
+	opt.DownloadRate = downloadRateConfig()
+	opt.CheckpointInterval = max(flags.CheckpointIntervalFV, 0)
+	opt.ItemExtensionFactory = itemExtensionFactories()
 
 	return opt
 }
@@ -63,6 +77,115 @@ func ParseBackupOptions() control.BackupConfig {
 	opt.Parallelism.ItemFetch = flags.FetchParallelismFV
 	opt.Incrementals.ForceFullEnumeration = flags.DisableIncrementalsFV
 	opt.Incrementals.ForceItemDataRefresh = flags.ForceItemDataDownloadFV
+	opt.M365.ListItemVersions = flags.IncludeListVersionsFV
+	opt.M365.MailMIME = control.MailMIMEMode(flags.MailMIMEFV)
+	opt.DryRun = flags.BackupDryRunFV
+	opt.DownloadRate = downloadRateConfig()
+	opt.CheckpointInterval = max(flags.CheckpointIntervalFV, 0)
+	opt.ItemExtensionFactory = itemExtensionFactories()
 
 	return opt
 }
+
+// itemExtensionFactories returns the extensions that get applied to the
+// items streamed through a backup.
+func itemExtensionFactories() []extensions.CreateItemExtensioner {
+	if !flags.IndexContentFV {
+		return nil
+	}
+
+	return []extensions.CreateItemExtensioner{&extensions.ContentIndexFactory{}}
+}
+
+func driveVersionsConfig() control.DriveVersionsConfig {
+	if !flags.IncludeVersionsFV {
+		return control.DriveVersionsConfig{}
+	}
+
+	return control.DriveVersionsConfig{
+		Enabled:  true,
+		MaxCount: max(flags.MaxVersionsFV, 0),
+		MaxAge:   max(flags.MaxVersionAgeFV, 0),
+	}
+}
+
+func downloadRateConfig() control.DownloadRateConfig {
+	// invalid values are rejected when validating the backup create flags.
+	cfg, _ := ParseDownloadRate()
+	return cfg
+}
+
+// ParseDownloadRate produces the download rate config from the user's
+// max-download-rate and download-rate-schedule flags.
+func ParseDownloadRate() (control.DownloadRateConfig, error) {
+	var cfg control.DownloadRateConfig
+
+	if len(flags.MaxDownloadRateFV) > 0 {
+		bps, err := humanize.ParseBytes(flags.MaxDownloadRateFV)
+		if err != nil {
+			return cfg, clues.New("invalid rate for " + flags.MaxDownloadRateFN)
+		}
+
+		cfg.BytesPerSecond = int64(bps)
+	}
+
+	for _, in := range flags.DownloadRateScheduleFV {
+		w, err := parseDownloadRateWindow(in)
+		if err != nil {
+			return cfg, clues.Wrap(err, "invalid window for "+flags.DownloadRateScheduleFN).
+				With("window", in)
+		}
+
+		cfg.Schedule = append(cfg.Schedule, w)
+	}
+
+	return cfg, nil
+}
+
+// parseDownloadRateWindow parses a window formatted as HH:MM-HH:MM=rate.
+func parseDownloadRateWindow(in string) (control.DownloadRateWindow, error) {
+	var w control.DownloadRateWindow
+
+	span, rate, ok := strings.Cut(in, "=")
+	if !ok {
+		return w, clues.New("missing rate")
+	}
+
+	start, end, ok := strings.Cut(span, "-")
+	if !ok {
+		return w, clues.New("missing end time")
+	}
+
+	var err error
+
+	if w.Start, err = parseTimeOfDay(start); err != nil {
+		return w, err
+	}
+
+	if w.End, err = parseTimeOfDay(end); err != nil {
+		return w, err
+	}
+
+	if w.Start == w.End {
+		return w, clues.New("window is empty")
+	}
+
+	bps, err := humanize.ParseBytes(strings.TrimSpace(rate))
+	if err != nil {
+		return w, clues.Wrap(err, "parsing rate")
+	}
+
+	w.BytesPerSecond = int64(bps)
+
+	return w, nil
+}
+
+// parseTimeOfDay turns HH:MM into its offset from midnight.
+func parseTimeOfDay(in string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(in))
+	if err != nil {
+		return 0, clues.Wrap(err, "parsing time of day")
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/spf13/cobra"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/extensions"
 )
 
 type OptionsUnitSuite struct {
@@ -37,6 +40,8 @@ func (suite *OptionsUnitSuite) TestAddExchangeCommands() {
 			assert.Equal(t, 2, flags.FetchParallelismFV, flags.FetchParallelismFN)
 			assert.Equal(t, 499, flags.DeltaPageSizeFV, flags.DeltaPageSizeFN)
 			assert.True(t, flags.DisableSlidingWindowLimiterFV, flags.DisableSlidingWindowLimiterFN)
+			assert.True(t, flags.BackupDryRunFV, flags.DryRunFN)
+			assert.True(t, Control().DryRun, "control option")
 		},
 	}
 
@@ -52,6 +57,7 @@ func (suite *OptionsUnitSuite) TestAddExchangeCommands() {
 	flags.AddFetchParallelismFlag(cmd)
 	flags.AddDeltaPageSizeFlag(cmd)
 	flags.AddDisableSlidingWindowLimiterFlag(cmd)
+	flags.AddBackupDryRunFlag(cmd)
 
 	// Test arg parsing for few args
 	cmd.SetArgs([]string{
@@ -66,8 +72,205 @@ func (suite *OptionsUnitSuite) TestAddExchangeCommands() {
 		"--" + flags.FetchParallelismFN, "2",
 		"--" + flags.DeltaPageSizeFN, "499",
 		"--" + flags.DisableSlidingWindowLimiterFN,
+		"--" + flags.DryRunFN,
 	})
 
 	err := cmd.Execute()
 	require.NoError(t, err, clues.ToCore(err))
 }
+
+func (suite *OptionsUnitSuite) TestDriveVersionsConfig() {
+	t := suite.T()
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(cmd *cobra.Command, args []string) {
+			expect := control.DriveVersionsConfig{
+				Enabled:  true,
+				MaxCount: 5,
+				MaxAge:   720 * time.Hour,
+			}
+
+			assert.Equal(t, expect, Control().DriveVersions)
+		},
+	}
+
+	flags.AddDriveVersionsFlags(cmd)
+
+	cmd.SetArgs([]string{
+		"test",
+		"--" + flags.IncludeVersionsFN,
+		"--" + flags.MaxVersionsFN, "5",
+		"--" + flags.MaxVersionAgeFN, "720h",
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err, clues.ToCore(err))
+}
+
+func (suite *OptionsUnitSuite) TestListItemVersions() {
+	t := suite.T()
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(cmd *cobra.Command, args []string) {
+			assert.True(t, Control().ListItemVersions)
+			assert.True(t, ParseBackupOptions().M365.ListItemVersions)
+		},
+	}
+
+	flags.AddListVersionsFlag(cmd)
+
+	cmd.SetArgs([]string{
+		"test",
+		"--" + flags.IncludeListVersionsFN,
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err, clues.ToCore(err))
+}
+
+func (suite *OptionsUnitSuite) TestIndexContent() {
+	t := suite.T()
+
+	defer func() { flags.IndexContentFV = false }()
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(cmd *cobra.Command, args []string) {
+			expect := []extensions.CreateItemExtensioner{&extensions.ContentIndexFactory{}}
+
+			assert.Equal(t, expect, Control().ItemExtensionFactory)
+			assert.Equal(t, expect, ParseBackupOptions().ItemExtensionFactory)
+		},
+	}
+
+	flags.AddIndexContentFlag(cmd)
+
+	cmd.SetArgs([]string{
+		"test",
+		"--" + flags.IndexContentFN,
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err, clues.ToCore(err))
+
+	flags.IndexContentFV = false
+
+	assert.Empty(t, Control().ItemExtensionFactory, "content isn't indexed by default")
+}
+
+func (suite *OptionsUnitSuite) TestRestoreDryRunIsNotABackupEstimate() {
+	t := suite.T()
+
+	flags.BackupDryRunFV = false
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(cmd *cobra.Command, args []string) {
+			assert.True(t, flags.DryRunFV, flags.DryRunFN)
+			assert.False(t, Control().DryRun, "control option")
+		},
+	}
+
+	flags.AddMembershipRestoreFlags(cmd)
+
+	cmd.SetArgs([]string{
+		"test",
+		"--" + flags.DryRunFN,
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err, clues.ToCore(err))
+}
+
+func (suite *OptionsUnitSuite) TestDownloadRateConfig() {
+	t := suite.T()
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(cmd *cobra.Command, args []string) {
+			expect := control.DownloadRateConfig{
+				BytesPerSecond: 10_000_000,
+				Schedule: []control.DownloadRateWindow{
+					{
+						Start:          9 * time.Hour,
+						End:            17*time.Hour + 30*time.Minute,
+						BytesPerSecond: 1_048_576,
+					},
+					{
+						Start: 22 * time.Hour,
+						End:   6 * time.Hour,
+					},
+				},
+			}
+
+			cfg, err := ParseDownloadRate()
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, expect, cfg)
+			assert.Equal(t, expect, Control().DownloadRate)
+			assert.Equal(t, expect, ParseBackupOptions().DownloadRate)
+		},
+	}
+
+	flags.AddDownloadRateFlags(cmd)
+
+	cmd.SetArgs([]string{
+		"test",
+		"--" + flags.MaxDownloadRateFN, "10MB",
+		"--" + flags.DownloadRateScheduleFN, "09:00-17:30=1MiB",
+		"--" + flags.DownloadRateScheduleFN, "22:00-06:00=0",
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err, clues.ToCore(err))
+}
+
+func (suite *OptionsUnitSuite) TestParseDownloadRate_invalid() {
+	table := []struct {
+		name     string
+		rate     string
+		schedule []string
+	}{
+		{
+			name: "bad rate",
+			rate: "fast",
+		},
+		{
+			name:     "missing rate",
+			schedule: []string{"09:00-17:00"},
+		},
+		{
+			name:     "missing end",
+			schedule: []string{"09:00=1MB"},
+		},
+		{
+			name:     "bad time",
+			schedule: []string{"9am-5pm=1MB"},
+		},
+		{
+			name:     "empty window",
+			schedule: []string{"09:00-09:00=1MB"},
+		},
+		{
+			name:     "bad window rate",
+			schedule: []string{"09:00-17:00=slow"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			flags.MaxDownloadRateFV = test.rate
+			flags.DownloadRateScheduleFV = test.schedule
+
+			defer func() {
+				flags.MaxDownloadRateFV = ""
+				flags.DownloadRateScheduleFV = nil
+			}()
+
+			_, err := ParseDownloadRate()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -22,17 +22,22 @@ type RestoreCfgOpts struct {
 	DTTMFormat        dttm.TimeFormat
 	ProtectedResource string
 	SkipPermissions   bool
+	FileVersion       string
+	// CreateMissingTerms is only used by SharePoint restores.
+	CreateMissingTerms bool
 
 	Populated flags.PopulatedFlags
 }
 
 func makeRestoreCfgOpts(cmd *cobra.Command) RestoreCfgOpts {
 	return RestoreCfgOpts{
-		Collisions:        flags.CollisionsFV,
-		Destination:       flags.DestinationFV,
-		DTTMFormat:        dttm.HumanReadable,
-		ProtectedResource: flags.ToResourceFV,
-		SkipPermissions:   flags.NoPermissionsFV,
+		Collisions:         flags.CollisionsFV,
+		Destination:        flags.DestinationFV,
+		DTTMFormat:         dttm.HumanReadable,
+		ProtectedResource:  flags.ToResourceFV,
+		SkipPermissions:    flags.NoPermissionsFV,
+		FileVersion:        flags.FileVersionFV,
+		CreateMissingTerms: flags.CreateMissingTermsFV,
 
 		// populated contains the list of flags that appear in the
 		// command, according to pflags.  Use this to differentiate
@@ -73,6 +78,8 @@ func MakeRestoreConfig(
 
 	restoreCfg.ProtectedResource = opts.ProtectedResource
 	restoreCfg.IncludePermissions = !opts.SkipPermissions
+	restoreCfg.Version = opts.FileVersion
+	restoreCfg.CreateMissingTerms = opts.CreateMissingTerms
 
 	Infof(ctx, "Restoring to folder %s", restoreCfg.Location)
 
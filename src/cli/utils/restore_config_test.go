@@ -142,6 +142,20 @@ func (suite *RestoreCfgUnitSuite) TestMakeRestoreConfig() {
 				IncludePermissions: false,
 			},
 		},
+		{
+			name: "create missing terms",
+			rco: &RestoreCfgOpts{
+				Collisions:         "collisions",
+				Destination:        "destination",
+				CreateMissingTerms: true,
+			},
+			populated: flags.PopulatedFlags{},
+			expect: control.RestoreConfig{
+				OnCollision:        control.Skip,
+				Location:           "Corso_Restore_",
+				CreateMissingTerms: true,
+			},
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -156,6 +170,7 @@ func (suite *RestoreCfgUnitSuite) TestMakeRestoreConfig() {
 			result := MakeRestoreConfig(ctx, opts)
 			assert.Equal(t, test.expect.OnCollision, result.OnCollision)
 			assert.Contains(t, result.Location, test.expect.Location)
+			assert.Equal(t, test.expect.CreateMissingTerms, result.CreateMissingTerms)
 		})
 	}
 }
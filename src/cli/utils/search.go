@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/pkg/backup/search"
+	"github.com/alcionai/corso/src/pkg/dttm"
+)
+
+// MakeSearchQuery builds the search query from the search flags.
+func MakeSearchQuery(cmd *cobra.Command) (search.Query, error) {
+	q := search.Query{
+		Subject:  flags.SearchSubjectFV,
+		Sender:   flags.SearchSenderFV,
+		FileName: flags.SearchFileNameFV,
+		Content:  flags.SearchContentFV,
+	}
+
+	fs := cmd.Flags()
+
+	if fs.Changed(flags.SearchModifiedAfterFN) {
+		t, err := dttm.ParseTime(flags.SearchModifiedAfterFV)
+		if err != nil {
+			return search.Query{}, clues.New("invalid time format for " + flags.SearchModifiedAfterFN)
+		}
+
+		q.ModifiedAfter = t
+	}
+
+	if fs.Changed(flags.SearchModifiedBeforeFN) {
+		t, err := dttm.ParseTime(flags.SearchModifiedBeforeFV)
+		if err != nil {
+			return search.Query{}, clues.New("invalid time format for " + flags.SearchModifiedBeforeFN)
+		}
+
+		q.ModifiedBefore = t
+	}
+
+	if len(q.Subject) == 0 &&
+		len(q.Sender) == 0 &&
+		len(q.FileName) == 0 &&
+		len(q.Content) == 0 &&
+		q.ModifiedAfter.IsZero() &&
+		q.ModifiedBefore.IsZero() {
+		return search.Query{}, clues.New("at least one search flag must be provided")
+	}
+
+	return q, nil
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/search"
+	"github.com/alcionai/corso/src/pkg/dttm"
+)
+
+type SearchUtilsSuite struct {
+	tester.Suite
+}
+
+func TestSearchUtilsSuite(t *testing.T) {
+	suite.Run(t, &SearchUtilsSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SearchUtilsSuite) TestMakeSearchQuery() {
+	after, err := dttm.ParseTime("2024-01-01")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name      string
+		args      []string
+		expect    search.Query
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "no flags",
+			args:      []string{},
+			expectErr: assert.Error,
+		},
+		{
+			name: "text flags",
+			args: []string{
+				"--" + flags.SearchSubjectFN, "budget",
+				"--" + flags.SearchSenderFN, "bob",
+				"--" + flags.SearchFileNameFN, "fiscal",
+				"--" + flags.SearchContentFN, "forecast",
+			},
+			expect: search.Query{
+				Subject:  "budget",
+				Sender:   "bob",
+				FileName: "fiscal",
+				Content:  "forecast",
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "modified after",
+			args:      []string{"--" + flags.SearchModifiedAfterFN, "2024-01-01"},
+			expect:    search.Query{ModifiedAfter: after},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "bad time",
+			args:      []string{"--" + flags.SearchModifiedBeforeFN, "yesterday"},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			cmd := &cobra.Command{Use: "search"}
+			flags.AddSearchFlags(cmd)
+
+			err := cmd.ParseFlags(test.args)
+			require.NoError(t, err, clues.ToCore(err))
+
+			q, err := MakeSearchQuery(cmd)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, q)
+		})
+	}
+}
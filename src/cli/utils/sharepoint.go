@@ -97,8 +97,9 @@ func MakeSharePointOpts(cmd *cobra.Command) SharePointOpts {
 
 func SharePointAllowedCategories() map[string]struct{} {
 	return map[string]struct{}{
-		flags.DataLibraries: {},
-		flags.DataLists:     {},
+		flags.DataLibraries:         {},
+		flags.DataLists:             {},
+		flags.DataSiteConfiguration: {},
 	}
 }
 
@@ -115,6 +116,8 @@ func AddCategories(sel *selectors.SharePointBackup, cats []string) *selectors.Sh
 			sel.Include(sel.Lists(selectors.Any()))
 		case flags.DataLibraries:
 			sel.Include(sel.LibraryFolders(selectors.Any()))
+		case flags.DataSiteConfiguration:
+			sel.Include(sel.SiteConfiguration(selectors.Any()))
 		}
 	}
 
@@ -170,7 +173,7 @@ func IncludeSharePointRestoreDataSelectors(ctx context.Context, opts SharePointO
 	sel := selectors.NewSharePointRestore(sites)
 
 	if folderPaths+fileNames+webUrls+lists+pageFolders+pageItems == 0 {
-		sel.Include(sel.AllData())
+		sel.Include(sel.AllData(), sel.OptInData())
 		return sel
 	}
 
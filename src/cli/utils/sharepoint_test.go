@@ -44,7 +44,7 @@ func (suite *SharePointUtilsSuite) TestIncludeSharePointRestoreDataSelectors() {
 		{
 			name:             "no inputs",
 			opts:             utils.SharePointOpts{},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name: "single inputs",
@@ -54,7 +54,7 @@ func (suite *SharePointUtilsSuite) TestIncludeSharePointRestoreDataSelectors() {
 				SiteID:     single,
 				WebURL:     single,
 			},
-			expectIncludeLen: 4,
+			expectIncludeLen: 5,
 		},
 		{
 			name: "single extended",
@@ -65,7 +65,7 @@ func (suite *SharePointUtilsSuite) TestIncludeSharePointRestoreDataSelectors() {
 				SiteID:     single,
 				WebURL:     single,
 			},
-			expectIncludeLen: 5,
+			expectIncludeLen: 6,
 		},
 		{
 			name: "multi inputs",
@@ -75,7 +75,7 @@ func (suite *SharePointUtilsSuite) TestIncludeSharePointRestoreDataSelectors() {
 				SiteID:     multi,
 				WebURL:     multi,
 			},
-			expectIncludeLen: 4,
+			expectIncludeLen: 5,
 		},
 		{
 			name: "library folder contains",
@@ -122,7 +122,7 @@ func (suite *SharePointUtilsSuite) TestIncludeSharePointRestoreDataSelectors() {
 				SiteID:     empty,
 				WebURL:     containsOnly,
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name: "library folder suffixes",
@@ -132,7 +132,7 @@ func (suite *SharePointUtilsSuite) TestIncludeSharePointRestoreDataSelectors() {
 				SiteID:     empty,
 				WebURL:     prefixOnly, // prefix pattern matches suffix pattern
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name: "library folder suffixes and contains",
@@ -142,7 +142,7 @@ func (suite *SharePointUtilsSuite) TestIncludeSharePointRestoreDataSelectors() {
 				SiteID:     empty,
 				WebURL:     containsAndPrefix, // prefix pattern matches suffix pattern
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name: "Page Folder",
@@ -432,13 +432,19 @@ func (suite *SharePointUtilsSuite) TestAddSharepointCategories() {
 			cats:           []string{flags.DataLists},
 			expectScopeLen: 1,
 		},
+		{
+			name:           "site configuration",
+			cats:           []string{flags.DataSiteConfiguration},
+			expectScopeLen: 1,
+		},
 		{
 			name: "all allowed",
 			cats: []string{
 				flags.DataLibraries,
 				flags.DataLists,
+				flags.DataSiteConfiguration,
 			},
-			expectScopeLen: 2,
+			expectScopeLen: 3,
 		},
 		{
 			name:           "bad inputs",
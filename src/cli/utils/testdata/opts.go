@@ -12,6 +12,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/backup/details/testdata"
+	"github.com/alcionai/corso/src/pkg/backup/search"
 	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/fault"
 	ftd "github.com/alcionai/corso/src/pkg/fault/testdata"
@@ -1027,6 +1028,20 @@ func (bg *MockBackupGetter) GetBackupErrors(
 	return nil, nil, fault.New(false).Fail(clues.New("unexpected call to mock"))
 }
 
+func (bg *MockBackupGetter) GetBackupSearchIndex(
+	ctx context.Context,
+	backupID string,
+) (*search.BackupIndex, *backup.Backup, *fault.Bus) {
+	return nil, nil, fault.New(false).Fail(clues.New("unexpected call to mock"))
+}
+
+func (bg *MockBackupGetter) GetBackupContents(
+	ctx context.Context,
+	backupID string,
+) (*search.Contents, *backup.Backup, *fault.Bus) {
+	return nil, nil, fault.New(false).Fail(clues.New("unexpected call to mock"))
+}
+
 type VersionedBackupGetter struct {
 	*MockBackupGetter
 	Details *details.Details
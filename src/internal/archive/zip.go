@@ -79,6 +79,10 @@ func ZipExportCollection(
 					return
 				}
 
+				if item.Skip != nil {
+					continue
+				}
+
 				name := item.Name
 
 				// We assume folder and name to not contain any path separators.
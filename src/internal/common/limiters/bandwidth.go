@@ -0,0 +1,158 @@
+package limiters
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+	"golang.org/x/time/rate"
+)
+
+var _ Limiter = &Bandwidth{}
+
+// RateFunc returns the number of bytes per second allowed at the given
+// time.  Zero or negative values mean no limit.
+type RateFunc func(t time.Time) int64
+
+// Bandwidth is a token bucket limiter where each token is a byte.  The rate
+// is looked up on every wait, which allows it to change over the day, such
+// as when the user schedules a lower rate during business hours.  The burst
+// is one second's worth of bytes.
+type Bandwidth struct {
+	mu      sync.Mutex
+	rateAt  RateFunc
+	limiter *rate.Limiter
+	bps     int64
+}
+
+// NewBandwidthLimiter produces a limiter that paces bytes at the rate
+// returned by rateAt.  A nil rateAt doesn't limit anything.
+func NewBandwidthLimiter(rateAt RateFunc) *Bandwidth {
+	return &Bandwidth{rateAt: rateAt}
+}
+
+// SetRate replaces the limiter's rate lookup.  Waits that are already in
+// progress finish at the prior rate.
+func (b *Bandwidth) SetRate(rateAt RateFunc) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rateAt = rateAt
+	b.limiter = nil
+	b.bps = 0
+}
+
+// current returns the token bucket for the rate at time t, or false if
+// there is no limit at that time.
+func (b *Bandwidth) current(t time.Time) (*rate.Limiter, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rateAt == nil {
+		return nil, false
+	}
+
+	bps := b.rateAt(t)
+	if bps <= 0 {
+		return nil, false
+	}
+
+	if b.limiter != nil && bps == b.bps {
+		return b.limiter, true
+	}
+
+	burst := int(min(bps, math.MaxInt32))
+
+	if b.limiter == nil {
+		b.limiter = rate.NewLimiter(rate.Limit(bps), burst)
+	} else {
+		b.limiter.SetLimitAt(t, rate.Limit(bps))
+		b.limiter.SetBurstAt(t, burst)
+	}
+
+	b.bps = bps
+
+	return b.limiter, true
+}
+
+func (b *Bandwidth) Wait(ctx context.Context) error {
+	return b.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n bytes can be transferred.  Counts larger than the
+// burst are waited on in burst-sized chunks, picking up any change in the
+// scheduled rate between chunks.
+func (b *Bandwidth) WaitN(ctx context.Context, n int) error {
+	for n > 0 {
+		lim, ok := b.current(time.Now())
+		if !ok {
+			return nil
+		}
+
+		chunk := min(n, lim.Burst())
+
+		if err := lim.WaitN(ctx, chunk); err != nil {
+			return clues.Stack(err)
+		}
+
+		n -= chunk
+	}
+
+	return nil
+}
+
+// Reset drops the accumulated token state.
+func (b *Bandwidth) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.limiter = nil
+	b.bps = 0
+}
+
+// Shutdown is a no-op for the bandwidth limiter.
+func (b *Bandwidth) Shutdown() {}
+
+// ---------------------------------------------------------------------------
+// reader
+// ---------------------------------------------------------------------------
+
+var _ io.ReadCloser = &limitedReader{}
+
+type limitedReader struct {
+	ctx     context.Context
+	rc      io.ReadCloser
+	limiter Limiter
+}
+
+// NewLimitedReader wraps rc so that every read waits on the limiter for the
+// number of bytes that were read.
+func NewLimitedReader(
+	ctx context.Context,
+	rc io.ReadCloser,
+	limiter Limiter,
+) io.ReadCloser {
+	return &limitedReader{
+		ctx:     ctx,
+		rc:      rc,
+		limiter: limiter,
+	}
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	n, err := lr.rc.Read(p)
+	if n > 0 {
+		if werr := lr.limiter.WaitN(lr.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+
+	return n, err
+}
+
+func (lr *limitedReader) Close() error {
+	return lr.rc.Close()
+}
@@ -0,0 +1,148 @@
+package limiters
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type BandwidthUnitSuite struct {
+	tester.Suite
+}
+
+func TestBandwidthUnitSuite(t *testing.T) {
+	suite.Run(t, &BandwidthUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func fixedRate(bps int64) RateFunc {
+	return func(time.Time) int64 { return bps }
+}
+
+func (suite *BandwidthUnitSuite) TestWaitN_unlimited() {
+	table := []struct {
+		name   string
+		rateAt RateFunc
+	}{
+		{
+			name: "nil rate",
+		},
+		{
+			name:   "zero rate",
+			rateAt: fixedRate(0),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			b := NewBandwidthLimiter(test.rateAt)
+			start := time.Now()
+
+			err := b.WaitN(ctx, 1<<30)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Less(t, time.Since(start), 100*time.Millisecond)
+		})
+	}
+}
+
+func (suite *BandwidthUnitSuite) TestWaitN_paced() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	// the first second's worth of bytes is available immediately, so 1500
+	// bytes at 1000 bytes per second should take about half a second.
+	b := NewBandwidthLimiter(fixedRate(1000))
+	start := time.Now()
+
+	err := b.WaitN(ctx, 1500)
+	require.NoError(t, err, clues.ToCore(err))
+
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 400*time.Millisecond)
+	assert.Less(t, elapsed, 2*time.Second)
+}
+
+func (suite *BandwidthUnitSuite) TestWaitN_rateChanges() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var bps int64 = 1000
+
+	b := NewBandwidthLimiter(func(time.Time) int64 { return bps })
+
+	// drain the burst at the initial rate.
+	err := b.WaitN(ctx, 1000)
+	require.NoError(t, err, clues.ToCore(err))
+
+	// lifting the limit no longer waits on the drained bucket.
+	bps = 0
+	start := time.Now()
+
+	err = b.WaitN(ctx, 5000)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	// replacing the rate lookup starts with a fresh bucket.
+	b.SetRate(fixedRate(2000))
+	start = time.Now()
+
+	err = b.WaitN(ctx, 2000)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func (suite *BandwidthUnitSuite) TestWaitN_cancelled() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	b := NewBandwidthLimiter(fixedRate(10))
+
+	err := b.WaitN(ctx, 10)
+	require.NoError(t, err, clues.ToCore(err))
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	err = b.WaitN(cctx, 10)
+	assert.Error(t, err)
+}
+
+func (suite *BandwidthUnitSuite) TestLimitedReader() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		content = bytes.Repeat([]byte("a"), 1500)
+		b       = NewBandwidthLimiter(fixedRate(1000))
+		rc      = NewLimitedReader(ctx, io.NopCloser(bytes.NewReader(content)), b)
+		start   = time.Now()
+	)
+
+	bs, err := io.ReadAll(rc)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, content, bs)
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	err = rc.Close()
+	assert.NoError(t, err, clues.ToCore(err))
+}
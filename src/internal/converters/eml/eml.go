@@ -23,6 +23,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/common/str"
 	"github.com/alcionai/corso/src/internal/converters/ics"
+	"github.com/alcionai/corso/src/internal/converters/vcf"
 	"github.com/alcionai/corso/src/internal/m365/collection/groups/metadata"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -201,11 +202,16 @@ func getFileAttachment(ctx context.Context, attachment models.Attachmentable) (*
 	}, nil
 }
 
-func getItemAttachment(ctx context.Context, attachment models.Attachmentable) (*mail.File, error) {
+func getItemAttachment(
+	ctx context.Context,
+	attachment models.Attachmentable,
+	depth int,
+) (*mail.File, error) {
+	ctx = clues.Add(ctx, "attachment_id", ptr.Val(attachment.GetId()))
+
 	it, err := attachment.GetBackingStore().Get("item")
 	if err != nil {
-		return nil, clues.WrapWC(ctx, err, "getting item for attachment").
-			With("attachment_id", ptr.Val(attachment.GetId()))
+		return nil, clues.WrapWC(ctx, err, "getting item for attachment")
 	}
 
 	name := ptr.Val(attachment.GetName())
@@ -218,11 +224,10 @@ func getItemAttachment(ctx context.Context, attachment models.Attachmentable) (*
 	}
 
 	switch it := it.(type) {
-	case *models.Message:
-		cb, err := FromMessageable(ctx, it)
+	case models.Messageable:
+		cb, err := fromMessageable(ctx, it, depth)
 		if err != nil {
-			return nil, clues.WrapWC(ctx, err, "converting item attachment to eml").
-				With("attachment_id", ptr.Val(attachment.GetId()))
+			return nil, clues.WrapWC(ctx, err, "converting item attachment to eml")
 		}
 
 		return &mail.File{
@@ -230,24 +235,61 @@ func getItemAttachment(ctx context.Context, attachment models.Attachmentable) (*
 			MimeType: "message/rfc822",
 			Data:     []byte(cb),
 		}, nil
+	case models.Eventable:
+		cb, err := ics.FromEventable(ctx, it)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "converting item attachment to ics")
+		}
+
+		return &mail.File{
+			Name:     name + ".ics",
+			MimeType: "text/calendar",
+			Data:     []byte(cb),
+		}, nil
+	case models.Contactable:
+		cb, err := vcf.FromContactable(ctx, it)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "converting item attachment to vcf")
+		}
+
+		return &mail.File{
+			Name:     name + ".vcf",
+			MimeType: "text/vcard",
+			Data:     []byte(cb),
+		}, nil
 	default:
 		logger.Ctx(ctx).
-			With("attachment_id", ptr.Val(attachment.GetId()),
-				"attachment_type", ptr.Val(attachment.GetOdataType())).
+			With("attachment_type", ptr.Val(attachment.GetOdataType())).
 			Info("unknown item attachment type")
 	}
 
 	return nil, nil
 }
 
-func getMailAttachment(ctx context.Context, att models.Attachmentable) (*mail.File, error) {
+// getMailAttachment converts an attachment into a file within the eml.
+// The depth counts the item attachments that enclose this one.  Item
+// attachments nested deeper than api.MaxNestedAttachmentDepth are dropped.
+func getMailAttachment(
+	ctx context.Context,
+	att models.Attachmentable,
+	depth int,
+) (*mail.File, error) {
 	otyp := ptr.Val(att.GetOdataType())
 
 	switch otyp {
 	case "#microsoft.graph.fileAttachment":
 		return getFileAttachment(ctx, att)
 	case "#microsoft.graph.itemAttachment":
-		return getItemAttachment(ctx, att)
+		if depth > api.MaxNestedAttachmentDepth {
+			logger.Ctx(ctx).
+				With("attachment_id", ptr.Val(att.GetId()),
+					"attachment_depth", depth).
+				Info("item attachment nested too deeply")
+
+			return nil, nil
+		}
+
+		return getItemAttachment(ctx, att, depth)
 	default:
 		logger.Ctx(ctx).
 			With("attachment_id", ptr.Val(att.GetId()),
@@ -267,11 +309,32 @@ func FromJSON(ctx context.Context, body []byte) (string, error) {
 		return "", clues.WrapWC(ctx, err, "converting to messageble")
 	}
 
+	// Prefer the original MIME content when the backup captured it, since
+	// that's an exact copy of the message.
+	mime, ok, err := api.MailMIMEContent(data)
+	if err != nil {
+		return "", clues.WrapWC(ctx, err, "reading mime content")
+	}
+
+	if ok {
+		return string(mime), nil
+	}
+
 	return FromMessageable(ctx, data)
 }
 
 // Converts a Messageable to .eml format
 func FromMessageable(ctx context.Context, data models.Messageable) (string, error) {
+	return fromMessageable(ctx, data, 0)
+}
+
+// fromMessageable converts a Messageable to .eml format.  The depth counts
+// the item attachments that enclose the message.
+func fromMessageable(
+	ctx context.Context,
+	data models.Messageable,
+	depth int,
+) (string, error) {
 	ctx = clues.Add(ctx, "item_id", ptr.Val(data.GetId()))
 
 	email := mail.NewMSG()
@@ -349,7 +412,7 @@ func FromMessageable(ctx context.Context, data models.Messageable) (string, erro
 
 	if data.GetAttachments() != nil {
 		for _, attachment := range data.GetAttachments() {
-			att, err := getMailAttachment(ctx, attachment)
+			att, err := getMailAttachment(ctx, attachment, depth+1)
 			if err != nil {
 				return "", clues.WrapWC(ctx, err, "getting mail attachment")
 			}
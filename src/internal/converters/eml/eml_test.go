@@ -2,6 +2,7 @@ package eml
 
 import (
 	"bytes"
+	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -136,6 +137,27 @@ func (suite *EMLUnitSuite) TestConvert_messageble_to_eml() {
 	assert.Equal(t, source, target)
 }
 
+func (suite *EMLUnitSuite) TestConvert_mime_to_eml() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	mime := "Subject: original\r\nX-Custom-Header: kept\r\nContent-Type: text/plain\r\n\r\nbody\r\n"
+
+	msg, err := api.BytesToMessageable([]byte(testdata.EmailWithAttachments))
+	require.NoError(t, err, "creating message")
+
+	api.SetMailMIMEContent(msg, []byte(mime))
+
+	body, err := api.Mail{}.Serialize(ctx, msg, "user", "id")
+	require.NoError(t, err, "serializing message")
+
+	out, err := FromJSON(ctx, body)
+	require.NoError(t, err, "converting to eml")
+	assert.Equal(t, mime, out)
+}
+
 func (suite *EMLUnitSuite) TestConvert_edge_cases() {
 	bodies := []string{
 		testdata.EmailWithAttachments,
@@ -488,3 +510,103 @@ func (suite *EMLUnitSuite) TestConvert_message_in_messageble_to_eml() {
 	// Known from testdata
 	assert.Contains(t, string(iattachments[0].Content), "X-LIC-LOCATION:Africa/Abidjan")
 }
+
+func nestedItemAttachment(name string, item models.OutlookItemable) models.Attachmentable {
+	ia := models.NewItemAttachment()
+	ia.SetName(ptr.To(name))
+	ia.SetItem(item)
+
+	return ia
+}
+
+func (suite *EMLUnitSuite) TestConvert_item_attachments_to_eml() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	body := []byte(testdata.EmailWithAttachments)
+
+	msg, err := api.BytesToMessageable(body)
+	require.NoError(t, err, "creating message")
+
+	event := models.NewEvent()
+	event.SetSubject(ptr.To("Nested Event"))
+
+	start := models.NewDateTimeTimeZone()
+	start.SetDateTime(ptr.To("2024-01-01T10:00:00.0000000"))
+	start.SetTimeZone(ptr.To("UTC"))
+	event.SetStart(start)
+
+	end := models.NewDateTimeTimeZone()
+	end.SetDateTime(ptr.To("2024-01-01T11:00:00.0000000"))
+	end.SetTimeZone(ptr.To("UTC"))
+	event.SetEnd(end)
+
+	contact := models.NewContact()
+	contact.SetGivenName(ptr.To("Nested"))
+	contact.SetSurname(ptr.To("Contact"))
+
+	msg.SetAttachments([]models.Attachmentable{
+		nestedItemAttachment("event", event),
+		nestedItemAttachment("contact", contact),
+	})
+
+	out, err := FromMessageable(ctx, msg)
+	require.NoError(t, err, "converting to eml")
+
+	eml, err := enmime.ReadEnvelope(strings.NewReader(out))
+	require.NoError(t, err, "reading created eml")
+	require.Len(t, eml.Attachments, 2)
+
+	assert.Equal(t, "event.ics", eml.Attachments[0].FileName)
+	assert.Contains(t, string(eml.Attachments[0].Content), "SUMMARY:Nested Event")
+
+	assert.Equal(t, "contact.vcf", eml.Attachments[1].FileName)
+	assert.Contains(t, string(eml.Attachments[1].Content), "N:Contact;Nested")
+}
+
+func (suite *EMLUnitSuite) TestConvert_nested_item_attachment_depth() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	depth := api.MaxNestedAttachmentDepth + 2
+
+	// build a chain of messages, each attached to the one above it.
+	var inner models.Messageable
+
+	for i := depth; i >= 0; i-- {
+		msg := models.NewMessage()
+		msg.SetSubject(ptr.To(fmt.Sprintf("level %d", i)))
+
+		if inner != nil {
+			msg.SetAttachments([]models.Attachmentable{
+				nestedItemAttachment(fmt.Sprintf("level %d", i+1), inner),
+			})
+		}
+
+		inner = msg
+	}
+
+	out, err := FromMessageable(ctx, inner)
+	require.NoError(t, err, "converting to eml")
+
+	levels := 0
+
+	for {
+		eml, err := enmime.ReadEnvelope(strings.NewReader(out))
+		require.NoError(t, err, "reading created eml")
+		assert.Equal(t, fmt.Sprintf("level %d", levels), eml.GetHeader("Subject"))
+
+		if len(eml.Attachments) == 0 {
+			break
+		}
+
+		levels++
+		out = string(eml.Attachments[0].Content)
+	}
+
+	assert.Equal(t, api.MaxNestedAttachmentDepth, levels)
+}
@@ -81,15 +81,20 @@ func addEmails(emails []models.EmailAddressable, vc *vcard.Card) {
 }
 
 func FromJSON(ctx context.Context, body []byte) (string, error) {
-	vc := vcard.Card{}
-	vcard.ToV4(vc)
-
 	data, err := api.BytesToContactable(body)
 	if err != nil {
 		return "", clues.WrapWC(ctx, err, "converting to contactable").
 			With("body_length", len(body))
 	}
 
+	return FromContactable(ctx, data)
+}
+
+// FromContactable converts a Contactable to .vcf format.
+func FromContactable(ctx context.Context, data models.Contactable) (string, error) {
+	vc := vcard.Card{}
+	vcard.ToV4(vc)
+
 	name := vcard.Name{
 		GivenName:       ptr.Val(data.GetGivenName()),
 		FamilyName:      ptr.Val(data.GetSurname()),
@@ -198,8 +203,7 @@ func FromJSON(ctx context.Context, body []byte) (string, error) {
 	out := bytes.NewBuffer(nil)
 	enc := vcard.NewEncoder(out)
 
-	err = enc.Encode(vc)
-	if err != nil {
+	if err := enc.Encode(vc); err != nil {
 		return "", clues.Wrap(err, "encoding vcard")
 	}
 
@@ -54,6 +54,16 @@ type BackupCollection interface {
 	DoNotMergeItems() bool
 }
 
+// ItemEstimator is implemented by backup collections that can describe the
+// items they would produce without fetching any item data.  Used by dry run
+// backups to estimate the size of a backup.
+type ItemEstimator interface {
+	// EstimateItems returns the number of items the collection would produce
+	// and their combined size in bytes.  Sized is false when enumeration
+	// doesn't report item sizes, in which case bytes is always zero.
+	EstimateItems() (items, bytes int64, sized bool)
+}
+
 // RestoreCollection is an extension of Collection that is used during restores.
 type RestoreCollection interface {
 	Collection
@@ -294,7 +294,8 @@ func fixupMinRequirements(
 		if base.ItemDataSnapshot != nil {
 			snapID = base.ItemDataSnapshot.ID
 
-			snapIncomplete = len(base.ItemDataSnapshot.IncompleteReason) > 0
+			snapIncomplete = len(base.ItemDataSnapshot.IncompleteReason) > 0 &&
+				!isPartialAssist(base.Backup, base.ItemDataSnapshot)
 		}
 
 		ictx := clues.Add(
@@ -8,6 +8,7 @@ import (
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/repo/manifest"
 	"github.com/kopia/kopia/snapshot"
+	"github.com/kopia/kopia/snapshot/snapshotfs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -759,6 +760,52 @@ func (suite *BackupBasesUnitSuite) TestFixupAndVerify() {
 				return res
 			}(),
 		},
+		{
+			name: "MergeAndAssistBase InterruptedSnapshot",
+			bb: func() *backupBases {
+				res := validMail1()
+				res.mergeBases[0].ItemDataSnapshot.IncompleteReason = snapshotfs.IncompleteReasonCanceled
+				res.assistBases[0].ItemDataSnapshot.IncompleteReason = snapshotfs.IncompleteReasonCanceled
+				res.assistBases[0].Backup.Tags = map[string]string{
+					model.BackupTypeTag: model.AssistBackup,
+				}
+
+				return res
+			}(),
+			expect: func() *backupBases {
+				res := validMail1()
+				res.mergeBases = nil
+				res.assistBases[0].ItemDataSnapshot.IncompleteReason = snapshotfs.IncompleteReasonCanceled
+				res.assistBases[0].Backup.Tags = map[string]string{
+					model.BackupTypeTag: model.AssistBackup,
+				}
+
+				return res
+			}(),
+		},
+		{
+			name: "MergeAndAssistBase CheckpointSnapshot",
+			bb: func() *backupBases {
+				res := validMail1()
+				res.mergeBases[0].ItemDataSnapshot.IncompleteReason = snapshotfs.IncompleteReasonCheckpoint
+				res.assistBases[0].ItemDataSnapshot.IncompleteReason = snapshotfs.IncompleteReasonCheckpoint
+				res.assistBases[0].Backup.Tags = map[string]string{
+					model.BackupTypeTag: model.AssistBackup,
+				}
+
+				return res
+			}(),
+			expect: func() *backupBases {
+				res := validMail1()
+				res.mergeBases = nil
+				res.assistBases[0].ItemDataSnapshot.IncompleteReason = snapshotfs.IncompleteReasonCheckpoint
+				res.assistBases[0].Backup.Tags = map[string]string{
+					model.BackupTypeTag: model.AssistBackup,
+				}
+
+				return res
+			}(),
+		},
 		{
 			name: "MergeAndAssistBase DuplicateReasonInBase",
 			bb: func() *backupBases {
@@ -7,6 +7,7 @@ import (
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/repo/manifest"
 	"github.com/kopia/kopia/snapshot"
+	"github.com/kopia/kopia/snapshot/snapshotfs"
 	"golang.org/x/exp/maps"
 
 	"github.com/alcionai/corso/src/internal/model"
@@ -55,6 +56,24 @@ type BackupBase struct {
 	Reasons []identity.Reasoner
 }
 
+// isPartialAssist returns true if the backup is an assist backup for a
+// snapshot that only holds part of the backup's item data, either because
+// the upload was interrupted, or because the snapshot is one of the upload's
+// checkpoints.  The incomplete snapshot only holds items that were completely
+// uploaded, and the backup's details cover all of them, so kopia-assisted
+// incrementals can still source data from it.
+func isPartialAssist(bup *backup.Backup, man *snapshot.Manifest) bool {
+	return bup != nil &&
+		man != nil &&
+		isPartialSnapshot(man) &&
+		bup.Type() == model.AssistBackup
+}
+
+func isPartialSnapshot(man *snapshot.Manifest) bool {
+	return man.IncompleteReason == snapshotfs.IncompleteReasonCanceled ||
+		man.IncompleteReason == snapshotfs.IncompleteReasonCheckpoint
+}
+
 func (bb BackupBase) GetReasons() []identity.Reasoner {
 	return bb.Reasons
 }
@@ -173,7 +192,10 @@ func (b *baseFinder) findBasesInSet(
 			continue
 		}
 
-		if len(man.IncompleteReason) > 0 {
+		// Snapshots from interrupted uploads, and upload checkpoints, may still
+		// be assist bases, which depends on the type of their backup model.
+		// Other incomplete snapshots can be skipped right away.
+		if len(man.IncompleteReason) > 0 && !isPartialSnapshot(man) {
 			// Skip here since this snapshot cannot be considered an assist base.
 			logger.Ctx(ictx).Debugw(
 				"Incomplete snapshot",
@@ -182,7 +204,7 @@ func (b *baseFinder) findBasesInSet(
 			continue
 		}
 
-		// This is a complete snapshot so see if we have a backup model for it.
+		// See if we have a backup model for the snapshot.
 		bup, err := b.getBackupModel(ictx, man)
 		if err != nil {
 			// Safe to continue here as we'll just end up attempting to use an older
@@ -213,6 +235,15 @@ func (b *baseFinder) findBasesInSet(
 			continue
 		}
 
+		if len(man.IncompleteReason) > 0 && !isPartialAssist(bup, man) {
+			logger.Ctx(ictx).Debugw(
+				"Incomplete snapshot without an assist backup",
+				"incomplete_reason", man.IncompleteReason,
+				"backup_type", bup.Type())
+
+			continue
+		}
+
 		// If we've made it to this point then we're considering the backup
 		// complete as it has both an item data snapshot and a backup details
 		// snapshot.
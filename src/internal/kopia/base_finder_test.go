@@ -8,6 +8,7 @@ import (
 
 	"github.com/kopia/kopia/repo/manifest"
 	"github.com/kopia/kopia/snapshot"
+	"github.com/kopia/kopia/snapshot/snapshotfs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/exp/maps"
@@ -583,6 +584,72 @@ func (suite *BaseFinderUnitSuite) TestGetBases() {
 				0: testUser1Mail,
 			},
 		},
+		{
+			name:  "Newer Interrupted Assist Snapshot",
+			input: testUser1Mail,
+			data: []baseInfo{
+				newBaseInfoBuilder(1, testT1, testUser1Mail...).
+					build(),
+				newBaseInfoBuilder(2, testT2, testUser1Mail...).
+					setSnapshotIncomplete(snapshotfs.IncompleteReasonCanceled).
+					setBackupType(model.AssistBackup).
+					build(),
+			},
+			expectedMergeReasons: map[int][]identity.Reasoner{
+				0: testUser1Mail,
+			},
+			expectedAssistReasons: map[int][]identity.Reasoner{
+				1: testUser1Mail,
+			},
+		},
+		{
+			name:  "Newer Checkpoint Assist Snapshot",
+			input: testUser1Mail,
+			data: []baseInfo{
+				newBaseInfoBuilder(1, testT1, testUser1Mail...).
+					build(),
+				newBaseInfoBuilder(2, testT2, testUser1Mail...).
+					setSnapshotIncomplete(snapshotfs.IncompleteReasonCheckpoint).
+					setBackupType(model.AssistBackup).
+					build(),
+			},
+			expectedMergeReasons: map[int][]identity.Reasoner{
+				0: testUser1Mail,
+			},
+			expectedAssistReasons: map[int][]identity.Reasoner{
+				1: testUser1Mail,
+			},
+		},
+		{
+			name:  "Newer Checkpoint Snapshot Without Backup",
+			input: testUser1Mail,
+			data: []baseInfo{
+				newBaseInfoBuilder(1, testT1, testUser1Mail...).
+					build(),
+				// checkpoints aren't bases until a model gets written for them.
+				newBaseInfoBuilder(2, testT2, testUser1Mail...).
+					setSnapshotIncomplete(snapshotfs.IncompleteReasonCheckpoint).
+					build(),
+			},
+			expectedMergeReasons: map[int][]identity.Reasoner{
+				0: testUser1Mail,
+			},
+		},
+		{
+			name:  "Newer Interrupted Merge Snapshot",
+			input: testUser1Mail,
+			data: []baseInfo{
+				newBaseInfoBuilder(1, testT1, testUser1Mail...).
+					build(),
+				// Only assist backups may use interrupted snapshots.
+				newBaseInfoBuilder(2, testT2, testUser1Mail...).
+					setSnapshotIncomplete(snapshotfs.IncompleteReasonCanceled).
+					build(),
+			},
+			expectedMergeReasons: map[int][]identity.Reasoner{
+				0: testUser1Mail,
+			},
+		},
 		{
 			name:  "Incomplete Older Than Complete",
 			input: testUser1Mail,
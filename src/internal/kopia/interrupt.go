@@ -0,0 +1,198 @@
+package kopia
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kopia/kopia/repo"
+	"github.com/kopia/kopia/snapshot"
+	"github.com/kopia/kopia/snapshot/snapshotfs"
+	"golang.org/x/exp/maps"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// checkpointPolls is the number of times per checkpoint interval that an
+// upload looks for new checkpoints.  Kopia doesn't report when it writes a
+// checkpoint, so polling within the interval keeps the lag behind each one
+// short.
+const checkpointPolls = 10
+
+type (
+	interruptCtxKey          struct{}
+	checkpointIntervalCtxKey struct{}
+	checkpointHandlerCtxKey  struct{}
+)
+
+// CheckpointHandler gets called with each checkpoint that an upload flushes
+// to the repository, along with the details of the items uploaded so far.
+// Every item in the checkpoint has an entry in the details, though the
+// details may also hold items that were uploaded after the checkpoint.
+type CheckpointHandler func(ctx context.Context, snapshotID string, deets *details.Details) error
+
+// BindInterrupt attaches a channel to the context which, once closed, stops
+// any backup running with that context.  Uploads stop reading more items,
+// and the items uploaded up to that point are kept in a snapshot marked as
+// incomplete.
+func BindInterrupt(ctx context.Context, interrupt <-chan struct{}) context.Context {
+	return context.WithValue(ctx, interruptCtxKey{}, interrupt)
+}
+
+// CtxInterrupt returns the channel attached to the context by BindInterrupt,
+// or nil if there isn't one.
+func CtxInterrupt(ctx context.Context) <-chan struct{} {
+	interrupt, _ := ctx.Value(interruptCtxKey{}).(<-chan struct{})
+	return interrupt
+}
+
+// BindCheckpointInterval sets how often uploads running with the context
+// flush a checkpoint of their progress to the repository.  Data that was
+// flushed doesn't need to be uploaded again if the process crashes.  Zero
+// keeps kopia's default, and intervals above kopia's maximum (45 minutes)
+// are lowered to the maximum.
+func BindCheckpointInterval(ctx context.Context, interval time.Duration) context.Context {
+	return context.WithValue(ctx, checkpointIntervalCtxKey{}, interval)
+}
+
+// BindCheckpointHandler sets the handler that uploads running with the
+// context call for each checkpoint they flush to the repository.
+func BindCheckpointHandler(ctx context.Context, h CheckpointHandler) context.Context {
+	return context.WithValue(ctx, checkpointHandlerCtxKey{}, h)
+}
+
+func ctxCheckpointHandler(ctx context.Context) CheckpointHandler {
+	h, _ := ctx.Value(checkpointHandlerCtxKey{}).(CheckpointHandler)
+	return h
+}
+
+func ctxCheckpointInterval(ctx context.Context) time.Duration {
+	interval, _ := ctx.Value(checkpointIntervalCtxKey{}).(time.Duration)
+	if interval <= 0 {
+		return snapshotfs.DefaultCheckpointInterval
+	}
+
+	return min(interval, snapshotfs.DefaultCheckpointInterval)
+}
+
+// cancelOnInterrupt cancels the upload if the context's interrupt channel
+// closes before the returned func gets called.  The upload is never
+// cancelled after the returned func returns.
+func cancelOnInterrupt(ctx context.Context, u *snapshotfs.Uploader) func() {
+	interrupt := CtxInterrupt(ctx)
+	if interrupt == nil {
+		return func() {}
+	}
+
+	var (
+		done    = make(chan struct{})
+		stopped = make(chan struct{})
+	)
+
+	go func() {
+		defer close(stopped)
+
+		select {
+		case <-interrupt:
+			logger.Ctx(ctx).Info("interrupting upload")
+			u.Cancel()
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// watchCheckpoints passes each checkpoint that kopia writes for the upload
+// to the context's checkpoint handler, until the returned func gets called.
+// Checkpoints are identified by the tags kopia labels them with, which are
+// unique to each upload.
+func watchCheckpoints(
+	ctx context.Context,
+	rep repo.Repository,
+	si snapshot.SourceInfo,
+	tags map[string]string,
+	progress *corsoProgress,
+) func() {
+	handler := ctxCheckpointHandler(ctx)
+	if handler == nil {
+		return func() {}
+	}
+
+	var (
+		done   = make(chan struct{})
+		wg     sync.WaitGroup
+		ticker = time.NewTicker(ctxCheckpointInterval(ctx) / checkpointPolls)
+		last   *snapshot.Manifest
+	)
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			man, err := latestCheckpoint(ctx, rep, si, tags)
+			if err != nil {
+				logger.CtxErr(ctx, err).Info("looking up upload checkpoints")
+				continue
+			}
+
+			if man == nil || (last != nil && !man.StartTime.After(last.StartTime)) {
+				continue
+			}
+
+			last = man
+
+			err = handler(ctx, string(man.ID), progress.deets.Details())
+			if err != nil {
+				logger.CtxErr(ctx, err).Info("handling upload checkpoint")
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// latestCheckpoint returns the most recent checkpoint labeled with the tags,
+// or nil if there isn't one.
+func latestCheckpoint(
+	ctx context.Context,
+	rep repo.Repository,
+	si snapshot.SourceInfo,
+	tags map[string]string,
+) (*snapshot.Manifest, error) {
+	mans, err := snapshot.ListSnapshots(ctx, rep, si)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *snapshot.Manifest
+
+	for _, man := range mans {
+		if man.IncompleteReason != snapshotfs.IncompleteReasonCheckpoint ||
+			!maps.Equal(man.Tags, tags) {
+			continue
+		}
+
+		if latest == nil || man.StartTime.After(latest.StartTime) {
+			latest = man
+		}
+	}
+
+	return latest, nil
+}
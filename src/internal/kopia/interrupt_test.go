@@ -0,0 +1,285 @@
+package kopia
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/fs"
+	"github.com/kopia/kopia/repo"
+	"github.com/kopia/kopia/repo/manifest"
+	"github.com/kopia/kopia/snapshot"
+	"github.com/kopia/kopia/snapshot/snapshotfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	storeTD "github.com/alcionai/corso/src/pkg/storage/testdata"
+)
+
+type InterruptUnitSuite struct {
+	tester.Suite
+}
+
+func TestInterruptUnitSuite(t *testing.T) {
+	suite.Run(t, &InterruptUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *InterruptUnitSuite) TestCtxCheckpointInterval() {
+	table := []struct {
+		name   string
+		bind   func(context.Context) context.Context
+		expect time.Duration
+	}{
+		{
+			name:   "unbound",
+			bind:   func(ctx context.Context) context.Context { return ctx },
+			expect: snapshotfs.DefaultCheckpointInterval,
+		},
+		{
+			name: "zero",
+			bind: func(ctx context.Context) context.Context {
+				return BindCheckpointInterval(ctx, 0)
+			},
+			expect: snapshotfs.DefaultCheckpointInterval,
+		},
+		{
+			name: "shorter",
+			bind: func(ctx context.Context) context.Context {
+				return BindCheckpointInterval(ctx, 5*time.Minute)
+			},
+			expect: 5 * time.Minute,
+		},
+		{
+			name: "longer than the max",
+			bind: func(ctx context.Context) context.Context {
+				return BindCheckpointInterval(ctx, 2*time.Hour)
+			},
+			expect: snapshotfs.DefaultCheckpointInterval,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			assert.Equal(t, test.expect, ctxCheckpointInterval(test.bind(ctx)))
+		})
+	}
+}
+
+func (suite *InterruptUnitSuite) TestCancelOnInterrupt() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	// no interrupt bound
+	u := &snapshotfs.Uploader{}
+	stop := cancelOnInterrupt(ctx, u)
+	stop()
+	assert.False(t, u.IsCanceled(), "unbound")
+
+	// interrupted before stopping
+	interrupt := make(chan struct{})
+	u = &snapshotfs.Uploader{}
+	stop = cancelOnInterrupt(BindInterrupt(ctx, interrupt), u)
+
+	close(interrupt)
+
+	assert.Eventually(t, u.IsCanceled, time.Second, 10*time.Millisecond, "interrupted")
+	stop()
+
+	// stopped before interrupting
+	interrupt = make(chan struct{})
+	u = &snapshotfs.Uploader{}
+	stop = cancelOnInterrupt(BindInterrupt(ctx, interrupt), u)
+
+	stop()
+	close(interrupt)
+
+	assert.False(t, u.IsCanceled(), "stopped")
+}
+
+type InterruptIntegrationSuite struct {
+	tester.Suite
+}
+
+func TestInterruptIntegrationSuite(t *testing.T) {
+	suite.Run(t, &InterruptIntegrationSuite{
+		Suite: tester.NewIntegrationSuite(
+			t,
+			[][]string{storeTD.AWSStorageCredEnvs}),
+	})
+}
+
+//revive:disable-next-line:context-as-argument
+func saveSnapshot(
+	t *testing.T,
+	ctx context.Context,
+	rep repo.Repository,
+	man *snapshot.Manifest,
+) manifest.ID {
+	var id manifest.ID
+
+	err := repo.WriteSession(
+		ctx,
+		rep,
+		repo.WriteSessionOptions{Purpose: "InterruptTest"},
+		func(innerCtx context.Context, rw repo.RepositoryWriter) error {
+			var err error
+
+			id, err = snapshot.SaveSnapshot(innerCtx, rw, man)
+
+			return err
+		})
+	require.NoError(t, err, clues.ToCore(err))
+
+	return id
+}
+
+func (suite *InterruptIntegrationSuite) TestLatestCheckpoint() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	k, err := openLocalKopiaRepo(t, ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer k.Close(ctx)
+
+	var (
+		si    = snapshot.SourceInfo{Host: "host", UserName: "user", Path: "path"}
+		tags  = map[string]string{"backup-id": "id1"}
+		start = fs.UTCTimestampFromTime(time.Now())
+	)
+
+	man, err := latestCheckpoint(ctx, k, si, tags)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Nil(t, man, "no snapshots")
+
+	oldest := saveSnapshot(t, ctx, k, &snapshot.Manifest{
+		Source:           si,
+		StartTime:        start,
+		IncompleteReason: snapshotfs.IncompleteReasonCheckpoint,
+		Tags:             tags,
+	})
+
+	man, err = latestCheckpoint(ctx, k, si, tags)
+	require.NoError(t, err, clues.ToCore(err))
+	require.NotNil(t, man)
+	assert.Equal(t, oldest, man.ID)
+
+	latest := saveSnapshot(t, ctx, k, &snapshot.Manifest{
+		Source:           si,
+		StartTime:        start.Add(time.Minute),
+		IncompleteReason: snapshotfs.IncompleteReasonCheckpoint,
+		Tags:             tags,
+	})
+
+	// Newer snapshots that aren't checkpoints of the same upload are ignored.
+	saveSnapshot(t, ctx, k, &snapshot.Manifest{
+		Source:           si,
+		StartTime:        start.Add(2 * time.Minute),
+		IncompleteReason: snapshotfs.IncompleteReasonCheckpoint,
+		Tags:             map[string]string{"backup-id": "id2"},
+	})
+	saveSnapshot(t, ctx, k, &snapshot.Manifest{
+		Source:    si,
+		StartTime: start.Add(3 * time.Minute),
+		Tags:      tags,
+	})
+
+	man, err = latestCheckpoint(ctx, k, si, tags)
+	require.NoError(t, err, clues.ToCore(err))
+	require.NotNil(t, man)
+	assert.Equal(t, latest, man.ID)
+}
+
+func (suite *InterruptIntegrationSuite) TestWatchCheckpoints() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	k, err := openLocalKopiaRepo(t, ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer k.Close(ctx)
+
+	var (
+		si       = snapshot.SourceInfo{Host: "host", UserName: "user", Path: "path"}
+		tags     = map[string]string{"backup-id": "id1"}
+		start    = fs.UTCTimestampFromTime(time.Now())
+		progress = &corsoProgress{deets: &details.Builder{}}
+		mu       sync.Mutex
+		handled  []string
+	)
+
+	handler := func(_ context.Context, snapshotID string, deets *details.Details) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		assert.NotNil(t, deets, "details")
+
+		handled = append(handled, snapshotID)
+
+		return nil
+	}
+
+	getHandled := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string{}, handled...)
+	}
+
+	// no handler bound
+	stop := watchCheckpoints(ctx, k, si, tags, progress)
+	stop()
+
+	wctx := BindCheckpointInterval(ctx, 100*time.Millisecond)
+	wctx = BindCheckpointHandler(wctx, handler)
+
+	stop = watchCheckpoints(wctx, k, si, tags, progress)
+
+	first := saveSnapshot(t, ctx, k, &snapshot.Manifest{
+		Source:           si,
+		StartTime:        start,
+		IncompleteReason: snapshotfs.IncompleteReasonCheckpoint,
+		Tags:             tags,
+	})
+
+	assert.Eventually(
+		t,
+		func() bool { return len(getHandled()) > 0 },
+		5*time.Second,
+		10*time.Millisecond,
+		"first checkpoint")
+
+	second := saveSnapshot(t, ctx, k, &snapshot.Manifest{
+		Source:           si,
+		StartTime:        start.Add(time.Minute),
+		IncompleteReason: snapshotfs.IncompleteReasonCheckpoint,
+		Tags:             tags,
+	})
+
+	assert.Eventually(
+		t,
+		func() bool { return len(getHandled()) > 1 },
+		5*time.Second,
+		10*time.Millisecond,
+		"second checkpoint")
+
+	stop()
+
+	// each checkpoint is only handled once.
+	assert.Equal(t, []string{string(first), string(second)}, getHandled())
+}
@@ -64,6 +64,9 @@ type corsoProgress struct {
 	// which are well known and actually ignorable.  At the end of a run, if the
 	// manifest ignored error count is equal to this count, then everything is good.
 	expectedIgnoredErrors int
+	// streams holds the item channels of the collections that the upload
+	// started reading, keyed by the collection's full path.
+	streams map[string]<-chan data.Item
 }
 
 // mutexted wrapper around expectedIgnoredErrors++
@@ -74,6 +77,30 @@ func (cp *corsoProgress) incExpectedErrs() {
 	cp.expectedIgnoredErrors++
 }
 
+// addStream records the item channel of a collection the upload started
+// reading.
+func (cp *corsoProgress) addStream(fullPath path.Path, items <-chan data.Item) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	if cp.streams == nil {
+		cp.streams = map[string]<-chan data.Item{}
+	}
+
+	cp.streams[fullPath.String()] = items
+}
+
+// stream returns the item channel of the collection, if the upload started
+// reading it.
+func (cp *corsoProgress) stream(fullPath path.Path) (<-chan data.Item, bool) {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	items, ok := cp.streams[fullPath.String()]
+
+	return items, ok
+}
+
 // Kopia interface function used as a callback when kopia finishes processing a
 // file.
 func (cp *corsoProgress) FinishedFile(relativePath string, err error) {
@@ -364,6 +391,7 @@ func (d *corsoDirectoryIterator) Next(ctx context.Context) (fs.Entry, error) {
 			}
 
 			d.streamItemsChan = d.params.collection.Items(d.ctx, d.progress.errs)
+			d.progress.addStream(d.params.collection.FullPath(), d.streamItemsChan)
 			d.seenEnts = map[string]struct{}{}
 			d.currentPhase = streamEntsPhase
 
@@ -1423,3 +1451,33 @@ func inflateDirTree(
 
 	return res, nil
 }
+
+// finishCollections reads out the remaining items of every collection that
+// an upload stopped streaming early.  Producers only report a collection as
+// done once all of its items were read, so anything left unread would keep
+// them waiting forever.  The items are dropped.  Collections are expected to
+// stream with a cancelled ctx by now, so that they can skip fetching data
+// that won't get uploaded.
+func finishCollections(
+	ctx context.Context,
+	collections []data.BackupCollection,
+	progress *corsoProgress,
+) {
+	for _, c := range collections {
+		// Deleted collections are never streamed.
+		if c.State() == data.DeletedState {
+			continue
+		}
+
+		items, ok := progress.stream(c.FullPath())
+		if !ok {
+			// Errors from collections the upload never got to would only be
+			// about the cancellation.
+			items = c.Items(ctx, fault.New(false))
+		}
+
+		for range items {
+			// Drop the item.
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 
 	pmMock "github.com/alcionai/corso/src/internal/common/prefixmatcher/mock"
 	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
 	exchMock "github.com/alcionai/corso/src/internal/m365/service/exchange/mock"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/backup/details"
@@ -586,6 +587,99 @@ func (suite *CorsoProgressUnitSuite) TestFinishedHashingFile() {
 	}
 }
 
+// streamedCollection records the ctx its items were streamed with, and
+// closes finished once all of its items were read.
+type streamedCollection struct {
+	dataMock.Collection
+	ctx      context.Context
+	finished chan struct{}
+}
+
+func (c *streamedCollection) Items(ctx context.Context, errs *fault.Bus) <-chan data.Item {
+	c.ctx = ctx
+
+	var (
+		items = c.Collection.Items(ctx, errs)
+		res   = make(chan data.Item)
+	)
+
+	go func() {
+		defer close(res)
+		defer close(c.finished)
+
+		for item := range items {
+			res <- item
+		}
+	}()
+
+	return res
+}
+
+func (suite *CorsoProgressUnitSuite) TestFinishCollections() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	newColl := func(dir string, state data.CollectionState) *streamedCollection {
+		return &streamedCollection{
+			Collection: dataMock.Collection{
+				Path: makePath(
+					t,
+					[]string{testTenant, service, testUser, category, dir},
+					false),
+				ItemData: []data.Item{
+					&dataMock.Item{ItemID: "a"},
+					&dataMock.Item{ItemID: "b"},
+					&dataMock.Item{ItemID: "c"},
+				},
+				CState: state,
+			},
+			finished: make(chan struct{}),
+		}
+	}
+
+	var (
+		streamCtx, cancel = context.WithCancel(ctx)
+		started           = newColl("started", data.NewState)
+		unstarted         = newColl("unstarted", data.NotMovedState)
+		deleted           = newColl("deleted", data.DeletedState)
+		cp                = corsoProgress{
+			ctx:     ctx,
+			pending: map[string]*itemDetails{},
+			deets:   &details.Builder{},
+			errs:    fault.New(true),
+			counter: count.New(),
+		}
+	)
+
+	items := started.Items(streamCtx, cp.errs)
+	cp.addStream(started.FullPath(), items)
+
+	// the upload stops after the first item.
+	<-items
+
+	cancel()
+
+	finishCollections(
+		streamCtx,
+		[]data.BackupCollection{started, unstarted, deleted},
+		&cp)
+
+	for _, c := range []*streamedCollection{started, unstarted} {
+		select {
+		case <-c.finished:
+		default:
+			assert.Fail(t, "collection not finished", c.FullPath())
+		}
+
+		require.NotNil(t, c.ctx, "collection not streamed", c.FullPath())
+		assert.ErrorIs(t, c.ctx.Err(), context.Canceled, "collection ctx", c.FullPath())
+	}
+
+	assert.Nil(t, deleted.ctx, "deleted collection streamed")
+}
+
 type HierarchyBuilderUnitSuite struct {
 	tester.Suite
 	testStoragePath  path.Path
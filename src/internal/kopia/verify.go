@@ -0,0 +1,153 @@
+package kopia
+
+import (
+	"context"
+	"io"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/fs"
+	"github.com/kopia/kopia/snapshot/snapshotfs"
+
+	"github.com/alcionai/corso/src/internal/common/readers"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// SnapshotFile is a file found while walking a snapshot.
+type SnapshotFile struct {
+	// RepoRef is the file's path within the snapshot, in the same format
+	// as the RepoRef of a details entry.
+	RepoRef string
+	// Size is the size of the file's content as recorded in the snapshot.
+	Size int64
+
+	file fs.File
+}
+
+// FileContent describes the content read from a SnapshotFile.
+type FileContent struct {
+	// Size is the number of content bytes read.
+	Size int64
+	// DelInFlight is true if the item was deleted in flight during the
+	// backup, which leaves the file without content.
+	DelInFlight bool
+}
+
+// ReadAll reads the file's full content and discards it.  Reading makes
+// kopia decrypt and decompress the content, and check it against its
+// hash, so a nil error means the content can be restored.
+func (sf SnapshotFile) ReadAll(ctx context.Context) (FileContent, error) {
+	var fc FileContent
+
+	if sf.file == nil {
+		return fc, clues.NewWC(ctx, "no snapshot file")
+	}
+
+	r, err := sf.file.Open(ctx)
+	if err != nil {
+		return fc, clues.WrapWC(ctx, err, "opening file")
+	}
+
+	defer r.Close()
+
+	rr, err := readers.NewVersionedRestoreReader(r)
+	if err != nil {
+		return fc, clues.StackWC(ctx, err)
+	}
+
+	if rr.Format().Version != readers.DefaultSerializationVersion {
+		return fc, clues.NewWC(ctx, "unexpected data format").
+			With(
+				"read_version", rr.Format().Version,
+				"expected_version", readers.DefaultSerializationVersion)
+	}
+
+	fc.DelInFlight = rr.Format().DelInFlight
+
+	fc.Size, err = io.Copy(io.Discard, rr)
+	if err != nil {
+		return fc, clues.WrapWC(ctx, err, "reading file")
+	}
+
+	return fc, nil
+}
+
+// WalkSnapshotFiles calls fn with every file found beneath prefix in the
+// snapshot.  The prefix starts with the tenant, like a RepoRef does.
+// Walking stops at the first error returned by fn.  A prefix that doesn't
+// exist in the snapshot holds no files.
+func (w Wrapper) WalkSnapshotFiles(
+	ctx context.Context,
+	snapshotID string,
+	prefix *path.Builder,
+	fn func(context.Context, SnapshotFile) error,
+) error {
+	snapshotRoot, err := w.getSnapshotRoot(ctx, snapshotID)
+	if err != nil {
+		return clues.Wrap(err, "loading snapshot root")
+	}
+
+	// the snapshot root holds the tenant's data, so the tenant isn't
+	// part of the path within the snapshot.
+	e, err := snapshotfs.GetNestedEntry(
+		ctx,
+		snapshotRoot,
+		encodeElements(prefix.PopFront().Elements()...))
+	if err != nil {
+		if isErrEntryNotFound(err) {
+			return nil
+		}
+
+		return clues.WrapWC(ctx, err, "getting prefix directory").
+			With("prefix", prefix)
+	}
+
+	dir, ok := e.(fs.Directory)
+	if !ok {
+		return clues.NewWC(ctx, "prefix is not a directory").
+			With("prefix", prefix)
+	}
+
+	var walked int
+
+	err = walkDir(ctx, dir, prefix, func(ictx context.Context, sf SnapshotFile) error {
+		walked++
+		if walked%1000 == 0 {
+			logger.Ctx(ctx).Infow("walking snapshot files", "walked_files", walked)
+		}
+
+		return fn(ictx, sf)
+	})
+
+	return clues.Stack(err).OrNil()
+}
+
+func walkDir(
+	ctx context.Context,
+	dir fs.Directory,
+	dirPath *path.Builder,
+	fn func(context.Context, SnapshotFile) error,
+) error {
+	return fs.IterateEntries(ctx, dir, func(ictx context.Context, e fs.Entry) error {
+		names, err := decodeElements(e.Name())
+		if err != nil {
+			return clues.StackWC(ictx, err).With("dir_path", dirPath)
+		}
+
+		p := dirPath.Append(names...)
+
+		switch ee := e.(type) {
+		case fs.Directory:
+			return walkDir(ictx, ee, p, fn)
+
+		case fs.File:
+			return fn(ictx, SnapshotFile{
+				RepoRef: p.String(),
+				Size:    max(ee.Size()-int64(readers.VersionFormatSize), 0),
+				file:    ee,
+			})
+		}
+
+		return nil
+	})
+}
@@ -187,8 +187,14 @@ func (w Wrapper) ConsumeBackupCollections(
 		assistBase = bases.SnapshotAssistBases()
 	}
 
+	// Collections stream their items with a ctx of their own, which gets
+	// cancelled if the upload stops early so that they don't keep fetching
+	// items nobody will read.
+	streamCtx, cancelStreams := context.WithCancel(ctx)
+	defer cancelStreams()
+
 	dirTree, err := inflateDirTree(
-		ctx,
+		streamCtx,
 		w.c,
 		mergeBase,
 		collections,
@@ -205,6 +211,12 @@ func (w Wrapper) ConsumeBackupCollections(
 		dirTree,
 		additionalTags,
 		progress)
+
+	if err != nil || s.Incomplete {
+		cancelStreams()
+		finishCollections(streamCtx, collections, progress)
+	}
+
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -384,8 +396,16 @@ func (w Wrapper) makeSnapshotWithRoot(
 			progress.UploadProgress = u.Progress
 			u.Progress = progress
 			u.CheckpointLabels = tags
+			u.CheckpointInterval = ctxCheckpointInterval(ctx)
+
+			stopInterrupt := cancelOnInterrupt(ctx, u)
+			stopCheckpoints := watchCheckpoints(ctx, rw, si, tags, progress)
 
 			man, err = u.Upload(innerCtx, root, policyTree, si, prevSnaps...)
+
+			stopCheckpoints()
+			stopInterrupt()
+
 			if err != nil {
 				err = clues.WrapWC(ctx, err, "uploading data")
 				logger.CtxErr(innerCtx, err).Error("uploading kopia backup")
@@ -393,6 +413,12 @@ func (w Wrapper) makeSnapshotWithRoot(
 				return err
 			}
 
+			if len(man.IncompleteReason) > 0 {
+				logger.Ctx(innerCtx).Infow(
+					"saving incomplete snapshot",
+					"incomplete_reason", man.IncompleteReason)
+			}
+
 			man.Tags = tags
 			// Add one pin to keep kopia's retention policy from collecting it if it
 			// ends up enabled for some reason. The value in the pin doesn't matter.
@@ -2341,6 +2341,49 @@ func (suite *KopiaSimpleRepoIntegrationSuite) TestProduceRestoreCollections_Fetc
 	assert.Equal(t, f.data, buf)
 }
 
+func (suite *KopiaSimpleRepoIntegrationSuite) TestWalkSnapshotFiles() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	found := map[string]int64{}
+
+	err := suite.w.WalkSnapshotFiles(
+		ctx,
+		string(suite.snapshotID),
+		path.Builder{}.Append(testTenant, path.ExchangeService.String()),
+		func(ictx context.Context, sf SnapshotFile) error {
+			fc, err := sf.ReadAll(ictx)
+			require.NoError(t, err, "reading file", clues.ToCore(err))
+			assert.False(t, fc.DelInFlight)
+			assert.Equal(t, sf.Size, fc.Size)
+
+			found[sf.RepoRef] = fc.Size
+
+			return nil
+		})
+	require.NoError(t, err, clues.ToCore(err))
+
+	expect := map[string]int64{}
+	for p, f := range suite.filesByPath {
+		expect[p] = int64(len(f.data))
+	}
+
+	assert.Equal(t, expect, found)
+
+	// prefixes missing from the snapshot hold no files.
+	err = suite.w.WalkSnapshotFiles(
+		ctx,
+		string(suite.snapshotID),
+		path.Builder{}.Append(testTenant, path.OneDriveService.String()),
+		func(context.Context, SnapshotFile) error {
+			assert.Fail(t, "unexpected file")
+			return nil
+		})
+	require.NoError(t, err, clues.ToCore(err))
+}
+
 func (suite *KopiaSimpleRepoIntegrationSuite) TestProduceRestoreCollections_Errors() {
 	itemPath, err := suite.testPath1.AppendItem(testFileName)
 	require.NoError(suite.T(), err, clues.ToCore(err))
@@ -10,6 +10,7 @@ import (
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/diagnostics"
 	kinject "github.com/alcionai/corso/src/internal/kopia/inject"
+	"github.com/alcionai/corso/src/internal/m365/service/directory"
 	"github.com/alcionai/corso/src/internal/m365/service/exchange"
 	"github.com/alcionai/corso/src/internal/m365/service/groups"
 	"github.com/alcionai/corso/src/internal/m365/service/onedrive"
@@ -109,6 +110,9 @@ func (ctrl *Controller) ProduceBackupCollections(
 	case path.TeamsChatsService:
 		handler = teamschats.NewBackup()
 
+	case path.DirectoryService:
+		handler = directory.NewBackup()
+
 	default:
 		return nil, nil, false, clues.NewWC(ctx, fmt.Sprintf("service not supported: %s", service.HumanString()))
 	}
@@ -125,6 +129,12 @@ func (ctrl *Controller) ProduceBackupCollections(
 		return nil, nil, false, err
 	}
 
+	// dry runs never stream Items(), and so never call the UpdateStatus
+	// closer.
+	if bpc.Options.DryRun {
+		return colls, excludeItems, canUsePreviousBackup, nil
+	}
+
 	for _, c := range colls {
 		// kopia doesn't stream Items() from deleted collections,
 		// and so they never end up calling the UpdateStatus closer.
@@ -156,6 +166,8 @@ func (ctrl *Controller) IsServiceEnabled(
 		return groups.IsServiceEnabled(ctx, ctrl.AC.Groups(), resourceOwner)
 	case path.TeamsChatsService:
 		return teamschats.IsServiceEnabled(ctx, ctrl.AC.Users(), resourceOwner)
+	case path.DirectoryService:
+		return directory.IsServiceEnabled(ctx, ctrl.AC.Directory(), resourceOwner)
 	}
 
 	return false, clues.Wrap(clues.NewWC(ctx, service.String()), "service not supported")
@@ -169,7 +181,7 @@ func verifyBackupInputs(sel selectors.Selector, cachedIDs []string) error {
 		// Exchange and OneDrive user existence now checked in checkServiceEnabled.
 		return nil
 
-	case selectors.ServiceSharePoint, selectors.ServiceGroups, selectors.ServiceTeamsChats:
+	case selectors.ServiceSharePoint, selectors.ServiceGroups, selectors.ServiceTeamsChats, selectors.ServiceDirectory:
 		ids = cachedIDs
 	}
 
@@ -257,5 +269,7 @@ func (ctrl *Controller) SetRateLimiter(
 			EnableSlidingLimiter: enableSlidingLim,
 		})
 
+	graph.SetDownloadRate(options.DownloadRate.RateAt)
+
 	return ctx
 }
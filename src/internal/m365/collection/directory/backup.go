@@ -0,0 +1,155 @@
+package directory
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/pii"
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// CreateCollections produces the collection holding every object within the
+// scope's category, along with the metadata collection that tracks the
+// category's delta token.  When a previous delta is available only the
+// objects changed since that delta are fetched.
+func CreateCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	bh backupHandler,
+	tenantID string,
+	scope selectors.DirectoryScope,
+	su support.StatusUpdater,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, bool, error) {
+	var (
+		category = scope.Category().PathType()
+		key      = category.String()
+		qp       = graph.QueryParams{
+			Category:          category,
+			ProtectedResource: bpc.ProtectedResource,
+			TenantID:          tenantID,
+		}
+	)
+
+	cdps, canUsePreviousBackup, err := parseMetadataCollections(ctx, bpc.MetadataCollections)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var (
+		cl          = counter.Local()
+		dp          = cdps[category][key]
+		prevDelta   = dp.Delta
+		prevPathStr = dp.Path // do not log: pii; log prevPath instead
+		prevPath    path.Path
+	)
+
+	ctx = clues.Add(
+		ctx,
+		"can_use_previous_backup", canUsePreviousBackup,
+		"previous_delta", pii.SafeURL{
+			URL:           prevDelta,
+			SafePathElems: graph.SafeURLPathParams,
+			SafeQueryKeys: graph.SafeURLQueryParams,
+		})
+	ctx = clues.AddLabelCounter(ctx, cl.PlainAdder())
+
+	if len(prevPathStr) > 0 {
+		if prevPath, err = pathFromPrevString(prevPathStr); err != nil {
+			err = clues.StackWC(ctx, err).Label(count.BadPrevPath)
+			logger.CtxErr(ctx, err).Error("parsing prev path")
+			// if the previous path is unusable, then the delta must be, too.
+			prevDelta = ""
+		}
+	}
+
+	ctx = clues.Add(ctx, "previous_path", prevPath)
+
+	cc := api.CallConfig{
+		CanMakeDeltaQueries: bh.canMakeDeltaQueries(),
+	}
+
+	addAndRem, err := bh.getItemIDs(ctx, prevDelta, cc)
+	if err != nil {
+		return nil, false, clues.Wrap(err, "enumerating directory objects")
+	}
+
+	removed := str.SliceToMap(addAndRem.Removed)
+
+	// Remove any deleted IDs from the set of added IDs because objects that
+	// are deleted and then restored will show up as both.
+	for remove := range removed {
+		delete(addAndRem.Added, remove)
+	}
+
+	cl.Add(count.ItemsAdded, int64(len(addAndRem.Added)))
+	cl.Add(count.ItemsRemoved, int64(len(removed)))
+
+	deltaURLs := map[string]string{}
+
+	if len(addAndRem.DU.URL) > 0 {
+		deltaURLs[key] = addAndRem.DU.URL
+	} else if !addAndRem.DU.Reset {
+		logger.Ctx(ctx).Info("missing delta url")
+	}
+
+	currPath, err := path.BuildPrefix(
+		tenantID,
+		bpc.ProtectedResource.ID(),
+		path.DirectoryService,
+		category)
+	if err != nil {
+		return nil, false, clues.StackWC(ctx, err).Label(count.BadCollPath)
+	}
+
+	coll := NewCollection(
+		data.NewBaseCollection(
+			currPath,
+			prevPath,
+			&path.Builder{},
+			bpc.Options,
+			addAndRem.DU.Reset,
+			cl),
+		bh,
+		addAndRem.Added,
+		removed,
+		su)
+
+	metadataPrefix, err := path.BuildMetadata(
+		qp.TenantID,
+		qp.ProtectedResource.ID(),
+		path.DirectoryService,
+		qp.Category,
+		false)
+	if err != nil {
+		return nil, false, clues.WrapWC(ctx, err, "making metadata path prefix").
+			Label(count.BadPathPrefix)
+	}
+
+	metadataCollection, err := graph.MakeMetadataCollection(
+		metadataPrefix,
+		[]graph.MetadataCollectionEntry{
+			graph.NewMetadataEntry(metadata.PreviousPathFileName, map[string]string{key: currPath.String()}),
+			graph.NewMetadataEntry(metadata.DeltaURLsFileName, deltaURLs),
+		},
+		su,
+		counter.Local())
+	if err != nil {
+		return nil, false, clues.WrapWC(ctx, err, "making metadata collection")
+	}
+
+	return []data.BackupCollection{coll, metadataCollection}, canUsePreviousBackup, nil
+}
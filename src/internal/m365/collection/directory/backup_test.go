@@ -0,0 +1,269 @@
+package directory
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+var _ backupHandler = &mockBackupHandler{}
+
+type mockBackupHandler struct {
+	canDelta  bool
+	added     []string
+	removed   []string
+	deltaURL  string
+	deleted   map[string]struct{}
+	prevDelta string
+}
+
+func (h *mockBackupHandler) canMakeDeltaQueries() bool {
+	return h.canDelta
+}
+
+func (h *mockBackupHandler) getItemIDs(
+	_ context.Context,
+	prevDelta string,
+	cc api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	h.prevDelta = prevDelta
+
+	aar := pagers.AddedAndRemoved{
+		Added:   map[string]time.Time{},
+		Removed: h.removed,
+		DU: pagers.DeltaUpdate{
+			URL:   h.deltaURL,
+			Reset: !cc.CanMakeDeltaQueries || len(prevDelta) == 0,
+		},
+	}
+
+	for _, id := range h.added {
+		aar.Added[id] = time.Now()
+	}
+
+	return aar, nil
+}
+
+func (h *mockBackupHandler) getItem(
+	_ context.Context,
+	itemID string,
+) (serialization.Parsable, *details.DirectoryInfo, error) {
+	if _, ok := h.deleted[itemID]; ok {
+		return nil, nil, clues.Stack(core.ErrNotFound)
+	}
+
+	group := models.NewGroup()
+	group.SetId(ptr.To(itemID))
+	group.SetDisplayName(ptr.To("name-" + itemID))
+
+	return group, api.DirectoryGroupInfo(group), nil
+}
+
+type BackupUnitSuite struct {
+	tester.Suite
+}
+
+func TestBackupUnitSuite(t *testing.T) {
+	suite.Run(t, &BackupUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func metadataCollection(
+	t *testing.T,
+	category path.CategoryType,
+	prevPath, delta string,
+) data.RestoreCollection {
+	pathPrefix, err := path.BuildMetadata("t", "t", path.DirectoryService, category, false)
+	require.NoError(t, err, clues.ToCore(err))
+
+	coll, err := graph.MakeMetadataCollection(
+		pathPrefix,
+		[]graph.MetadataCollectionEntry{
+			graph.NewMetadataEntry(metadata.PreviousPathFileName, map[string]string{category.String(): prevPath}),
+			graph.NewMetadataEntry(metadata.DeltaURLsFileName, map[string]string{category.String(): delta}),
+		},
+		func(*support.ControllerOperationStatus) {},
+		count.New())
+	require.NoError(t, err, clues.ToCore(err))
+
+	return dataMock.NewUnversionedRestoreCollection(t, data.NoFetchRestoreCollection{Collection: coll})
+}
+
+func (suite *BackupUnitSuite) TestCreateCollections() {
+	prevPath, err := path.BuildPrefix("t", "t", path.DirectoryService, path.DirectoryGroupsCategory)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name             string
+		metadata         func(t *testing.T) []data.RestoreCollection
+		canDelta         bool
+		expectPrevDelta  string
+		expectPrevPath   bool
+		expectDoNotMerge bool
+		expectDelta      map[string]string
+	}{
+		{
+			name:             "first backup",
+			metadata:         func(t *testing.T) []data.RestoreCollection { return nil },
+			canDelta:         true,
+			expectDoNotMerge: true,
+			expectDelta:      map[string]string{path.DirectoryGroupsCategory.String(): "delta-next"},
+		},
+		{
+			name: "incremental backup",
+			metadata: func(t *testing.T) []data.RestoreCollection {
+				return []data.RestoreCollection{
+					metadataCollection(t, path.DirectoryGroupsCategory, prevPath.String(), "delta-prev"),
+				}
+			},
+			canDelta:        true,
+			expectPrevDelta: "delta-prev",
+			expectPrevPath:  true,
+			expectDelta:     map[string]string{path.DirectoryGroupsCategory.String(): "delta-next"},
+		},
+		{
+			name: "delta not supported",
+			metadata: func(t *testing.T) []data.RestoreCollection {
+				return []data.RestoreCollection{
+					metadataCollection(t, path.DirectoryGroupsCategory, prevPath.String(), "delta-prev"),
+				}
+			},
+			expectPrevDelta:  "delta-prev",
+			expectPrevPath:   true,
+			expectDoNotMerge: true,
+			expectDelta:      map[string]string{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				sel = selectors.NewDirectoryBackup([]string{"t"})
+				bh  = &mockBackupHandler{
+					canDelta: test.canDelta,
+					added:    []string{"g1", "g2", "gone"},
+					removed:  []string{"g3"},
+					deleted:  map[string]struct{}{"gone": {}},
+				}
+				bpc = inject.BackupProducerConfig{
+					MetadataCollections: test.metadata(t),
+					Options:             control.DefaultOptions(),
+					ProtectedResource:   idname.NewProvider("t", "tenant"),
+					Selector:            sel.Selector,
+				}
+			)
+
+			if test.canDelta {
+				bh.deltaURL = "delta-next"
+			}
+
+			colls, canUsePrev, err := CreateCollections(
+				ctx,
+				bpc,
+				bh,
+				"t",
+				sel.Groups(selectors.Any())[0],
+				func(*support.ControllerOperationStatus) {},
+				count.New(),
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+			require.Len(t, colls, 2)
+			assert.True(t, canUsePrev)
+			assert.Equal(t, test.expectPrevDelta, bh.prevDelta)
+
+			coll := colls[0]
+			assert.Equal(t, prevPath.String(), coll.FullPath().String())
+			assert.Equal(t, test.expectPrevPath, coll.PreviousPath() != nil)
+			assert.Equal(t, test.expectDoNotMerge, coll.DoNotMergeItems())
+
+			var (
+				added   = map[string]string{}
+				removed = []string{}
+			)
+
+			for item := range coll.Items(ctx, fault.New(true)) {
+				if item.Deleted() {
+					removed = append(removed, item.ID())
+					continue
+				}
+
+				info, err := item.(data.ItemInfo).Info()
+				require.NoError(t, err, clues.ToCore(err))
+
+				added[item.ID()] = info.Directory.Object.DisplayName
+			}
+
+			assert.Equal(t, map[string]string{"g1": "name-g1", "g2": "name-g2"}, added)
+			assert.Equal(t, []string{"g3"}, removed)
+
+			md := dataMock.NewUnversionedRestoreCollection(
+				t,
+				data.NoFetchRestoreCollection{Collection: colls[1]})
+
+			for item := range md.Items(ctx, fault.New(true)) {
+				bs, err := io.ReadAll(item.ToReader())
+				require.NoError(t, err, clues.ToCore(err))
+
+				m := map[string]string{}
+				require.NoError(t, json.Unmarshal(bs, &m))
+
+				switch item.ID() {
+				case metadata.PreviousPathFileName:
+					assert.Equal(t, map[string]string{path.DirectoryGroupsCategory.String(): prevPath.String()}, m)
+				case metadata.DeltaURLsFileName:
+					assert.Equal(t, test.expectDelta, m)
+				}
+			}
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestNewBackupHandler() {
+	for _, cat := range []path.CategoryType{
+		path.DirectoryUsersCategory,
+		path.DirectoryGroupsCategory,
+		path.ApplicationsCategory,
+		path.ServicePrincipalsCategory,
+		path.ConditionalAccessCategory,
+		path.AdminUnitsCategory,
+	} {
+		suite.Run(cat.String(), func() {
+			_, err := NewBackupHandler(cat, api.Directory{})
+			assert.NoError(suite.T(), err, clues.ToCore(err))
+		})
+	}
+
+	_, err := NewBackupHandler(path.EmailCategory, api.Directory{})
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,245 @@
+package directory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+var (
+	_ data.BackupCollection = &prefetchCollection{}
+	_ data.ItemEstimator    = &prefetchCollection{}
+)
+
+const collectionChannelBufferSize = 1000
+
+// updateStatus is a utility function used to send the status update through
+// the channel.
+func updateStatus(
+	ctx context.Context,
+	statusUpdater support.StatusUpdater,
+	attempted int,
+	streamedItems int64,
+	totalBytes int64,
+	folderPath string,
+	err error,
+) {
+	status := support.CreateStatus(
+		ctx,
+		support.Backup,
+		1,
+		support.CollectionMetrics{
+			Objects:   attempted,
+			Successes: int(streamedItems),
+			Bytes:     totalBytes,
+		},
+		folderPath)
+
+	logger.Ctx(ctx).Debugw("done streaming items", "status", status.String())
+
+	statusUpdater(status)
+}
+
+// -----------------------------------------------------------------------------
+// prefetchCollection
+// -----------------------------------------------------------------------------
+
+type prefetchCollection struct {
+	data.BaseCollection
+	stream chan data.Item
+
+	// added is a list of existing object IDs that were added to the category
+	added map[string]time.Time
+	// removed is a list of object IDs that were deleted from the directory
+	removed map[string]struct{}
+
+	getter getItemer
+
+	statusUpdater support.StatusUpdater
+}
+
+// NewCollection produces the collection of directory objects within a
+// single category.  State of the collection is set as an observation of
+// the current and previous paths.
+func NewCollection(
+	baseCol data.BaseCollection,
+	getter getItemer,
+	added map[string]time.Time,
+	removed map[string]struct{},
+	statusUpdater support.StatusUpdater,
+) data.BackupCollection {
+	return &prefetchCollection{
+		BaseCollection: baseCol,
+		added:          added,
+		removed:        removed,
+		getter:         getter,
+		statusUpdater:  statusUpdater,
+		stream:         make(chan data.Item, collectionChannelBufferSize),
+	}
+}
+
+// EstimateItems reports the number of directory objects added to the
+// collection.  Object sizes aren't known until the objects are fetched.
+func (col *prefetchCollection) EstimateItems() (int64, int64, bool) {
+	return int64(len(col.added)), 0, false
+}
+
+func (col *prefetchCollection) Items(ctx context.Context, errs *fault.Bus) <-chan data.Item {
+	go col.streamItems(ctx, errs)
+	return col.stream
+}
+
+func (col *prefetchCollection) streamItems(ctx context.Context, errs *fault.Bus) {
+	var (
+		streamedItems   int64
+		totalBytes      int64
+		wg              sync.WaitGroup
+		progressMessage chan<- struct{}
+		el              = errs.Local()
+	)
+
+	ctx = clues.Add(ctx, "category", col.Category().String())
+
+	defer func() {
+		close(col.stream)
+		logger.Ctx(ctx).Infow(
+			"finished stream backup collection items",
+			"stats", col.Counter.Values())
+
+		updateStatus(
+			ctx,
+			col.statusUpdater,
+			len(col.added)+len(col.removed),
+			streamedItems,
+			totalBytes,
+			col.FullPath().Folder(false),
+			errs.Failure())
+	}()
+
+	if len(col.added)+len(col.removed) > 0 {
+		progressMessage = observe.CollectionProgress(
+			ctx,
+			col.Category().HumanString(),
+			col.LocationPath().Elements())
+		defer close(progressMessage)
+	}
+
+	semaphoreCh := make(chan struct{}, col.Opts().Parallelism.ItemFetch)
+	defer close(semaphoreCh)
+
+	// delete all removed items
+	for id := range col.removed {
+		col.stream <- data.NewDeletedItem(id)
+
+		atomic.AddInt64(&streamedItems, 1)
+		col.Counter.Inc(count.StreamItemsRemoved)
+
+		if progressMessage != nil {
+			progressMessage <- struct{}{}
+		}
+	}
+
+	// add any new items
+	for id, modTime := range col.added {
+		if el.Failure() != nil {
+			break
+		}
+
+		wg.Add(1)
+		semaphoreCh <- struct{}{}
+
+		go func(id string, modTime time.Time) {
+			defer wg.Done()
+			defer func() { <-semaphoreCh }()
+
+			ictx := clues.Add(ctx, "item_id", id)
+
+			item, info, err := col.getter.getItem(ictx, id)
+			if err != nil {
+				// objects deleted in flight get picked up as removed by the
+				// next delta query.
+				if clues.HasLabel(err, graph.LabelStatus(http.StatusNotFound)) || errors.Is(err, core.ErrNotFound) {
+					logger.CtxErr(ictx, err).Info("item deleted in flight. skipping")
+					return
+				}
+
+				err = clues.Wrap(err, "getting directory object").Label(fault.LabelForceNoBackupCreation)
+				el.AddRecoverable(ictx, err)
+
+				return
+			}
+
+			itemData, err := serialize(item)
+			if err != nil {
+				el.AddRecoverable(ictx, clues.Stack(err).Label(fault.LabelForceNoBackupCreation))
+				return
+			}
+
+			info.ParentPath = col.LocationPath().String()
+			info.Size = int64(len(itemData))
+			// Use the mod time we already told kopia about.  This is required
+			// for proper details merging.
+			info.Modified = modTime
+
+			dataItem, err := data.NewPrefetchedItemWithInfo(
+				io.NopCloser(bytes.NewReader(itemData)),
+				id,
+				details.ItemInfo{Directory: info})
+			if err != nil {
+				err := clues.StackWC(ictx, err).Label(fault.LabelForceNoBackupCreation)
+				el.AddRecoverable(ictx, err)
+
+				return
+			}
+
+			col.stream <- dataItem
+
+			atomic.AddInt64(&streamedItems, 1)
+			atomic.AddInt64(&totalBytes, info.Size)
+
+			if col.Counter.Inc(count.StreamItemsAdded)%1000 == 0 {
+				logger.Ctx(ictx).Infow("item stream progress", "stats", col.Counter.Values())
+			}
+
+			col.Counter.Add(count.StreamBytesAdded, info.Size)
+
+			if progressMessage != nil {
+				progressMessage <- struct{}{}
+			}
+		}(id, modTime)
+	}
+
+	wg.Wait()
+}
+
+func serialize(item serialization.Parsable) ([]byte, error) {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", item); err != nil {
+		return nil, clues.Wrap(err, "writing directory object to serializer")
+	}
+
+	bs, err := writer.GetSerializedContent()
+
+	return bs, clues.Wrap(err, "serializing directory object").OrNil()
+}
@@ -0,0 +1,67 @@
+package directory
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// backupHandler produces the objects of a single directory category.
+// Directory objects have no containers, so each category is backed up
+// into a single collection at the root of the category.
+type backupHandler interface {
+	getItemIDser
+	getItemer
+
+	// canMakeDeltaQueries reports whether the category supports
+	// incremental enumeration through delta queries.
+	canMakeDeltaQueries() bool
+}
+
+type getItemIDser interface {
+	// getItemIDs returns the ids of the objects that were added and removed
+	// since the previous delta.  If no delta is provided, all objects in the
+	// category are returned as added.
+	getItemIDs(
+		ctx context.Context,
+		prevDelta string,
+		cc api.CallConfig,
+	) (pagers.AddedAndRemoved, error)
+}
+
+type getItemer interface {
+	getItem(
+		ctx context.Context,
+		itemID string,
+	) (serialization.Parsable, *details.DirectoryInfo, error)
+}
+
+// NewBackupHandler returns the handler that produces the objects within
+// the given directory category.
+func NewBackupHandler(
+	category path.CategoryType,
+	ac api.Directory,
+) (backupHandler, error) {
+	switch category {
+	case path.DirectoryUsersCategory:
+		return usersBackupHandler{ac}, nil
+	case path.DirectoryGroupsCategory:
+		return groupsBackupHandler{ac}, nil
+	case path.ApplicationsCategory:
+		return applicationsBackupHandler{ac}, nil
+	case path.ServicePrincipalsCategory:
+		return servicePrincipalsBackupHandler{ac}, nil
+	case path.ConditionalAccessCategory:
+		return conditionalAccessBackupHandler{ac}, nil
+	case path.AdminUnitsCategory:
+		return adminUnitsBackupHandler{ac}, nil
+	}
+
+	return nil, clues.New("unsupported directory category").With("category", category)
+}
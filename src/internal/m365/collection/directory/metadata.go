@@ -0,0 +1,179 @@
+package directory
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// parseMetadataCollections produces a map of structs holding delta
+// and path lookup maps.  Each category stores a single entry, keyed
+// by the category name.
+func parseMetadataCollections(
+	ctx context.Context,
+	colls []data.RestoreCollection,
+) (metadata.CatDeltaPaths, bool, error) {
+	cdp := newCatDeltaPaths()
+
+	// found tracks the metadata we've loaded, to make sure we don't
+	// fetch overlapping copies.
+	found := map[path.CategoryType]map[string]struct{}{}
+
+	for cat := range cdp {
+		found[cat] = map[string]struct{}{}
+	}
+
+	// errors from metadata items should not stop the backup,
+	// but it should prevent us from using previous backups
+	errs := fault.New(true)
+
+	for _, coll := range colls {
+		var (
+			breakLoop bool
+			items     = coll.Items(ctx, errs)
+			category  = coll.FullPath().Category()
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil, false, clues.WrapWC(ctx, ctx.Err(), "parsing collection metadata")
+
+			case item, ok := <-items:
+				if !ok || errs.Failure() != nil {
+					breakLoop = true
+					break
+				}
+
+				var (
+					m                    = map[string]string{}
+					cdps, wantedCategory = cdp[category]
+				)
+
+				if !wantedCategory {
+					continue
+				}
+
+				err := json.NewDecoder(item.ToReader()).Decode(&m)
+				if err != nil {
+					return nil, false, clues.WrapWC(ctx, err, "decoding metadata json")
+				}
+
+				switch item.ID() {
+				case metadata.PreviousPathFileName:
+					if _, ok := found[category][metadata.PathKey]; ok {
+						return nil, false, clues.Wrap(clues.NewWC(ctx, category.String()), "multiple versions of path metadata")
+					}
+
+					for k, p := range m {
+						cdps.AddPath(k, p)
+					}
+
+					found[category][metadata.PathKey] = struct{}{}
+
+				case metadata.DeltaURLsFileName:
+					if _, ok := found[category][metadata.DeltaKey]; ok {
+						return nil, false, clues.Wrap(clues.NewWC(ctx, category.String()), "multiple versions of delta metadata")
+					}
+
+					for k, d := range m {
+						cdps.AddDelta(k, d)
+					}
+
+					found[category][metadata.DeltaKey] = struct{}{}
+				}
+
+				cdp[category] = cdps
+			}
+
+			if breakLoop {
+				break
+			}
+		}
+	}
+
+	if errs.Failure() != nil {
+		logger.CtxErr(ctx, errs.Failure()).Info("reading metadata collection items")
+
+		return newCatDeltaPaths(), false, nil
+	}
+
+	// Remove any entries that contain a delta, but not a path.
+	// That metadata is considered incomplete, and needs to incur a
+	// complete backup on the next run.
+	for _, dps := range cdp {
+		for k, dp := range dps {
+			if len(dp.Path) == 0 {
+				delete(dps, k)
+			}
+		}
+	}
+
+	return cdp, true, nil
+}
+
+func newCatDeltaPaths() metadata.CatDeltaPaths {
+	return metadata.CatDeltaPaths{
+		path.DirectoryUsersCategory:    {},
+		path.DirectoryGroupsCategory:   {},
+		path.ApplicationsCategory:      {},
+		path.ServicePrincipalsCategory: {},
+		path.ConditionalAccessCategory: {},
+		path.AdminUnitsCategory:        {},
+	}
+}
+
+// pathFromPrevString parses the previous collection path.  Directory
+// collections live at the root of their category, so the path is
+// expected to be a prefix.
+func pathFromPrevString(ps string) (path.Path, error) {
+	p, err := path.PrefixOrPathFromDataLayerPath(ps, false)
+	if err != nil {
+		return nil, clues.Wrap(err, "parsing previous path string")
+	}
+
+	return p, nil
+}
+
+func DeserializeMetadataFiles(
+	ctx context.Context,
+	colls []data.RestoreCollection,
+) ([]store.MetadataFile, error) {
+	cdp, _, err := parseMetadataCollections(ctx, colls)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	var (
+		prevs  = map[string]string{}
+		deltas = map[string]string{}
+	)
+
+	for _, dps := range cdp {
+		for k, dp := range dps {
+			prevs[k] = dp.Path
+			deltas[k] = dp.Delta
+		}
+	}
+
+	files := []store.MetadataFile{
+		{
+			Name: metadata.PreviousPathFileName,
+			Data: prevs,
+		},
+		{
+			Name: metadata.DeltaURLsFileName,
+			Data: deltas,
+		},
+	}
+
+	return files, nil
+}
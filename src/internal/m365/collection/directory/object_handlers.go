@@ -0,0 +1,193 @@
+package directory
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// ---------------------------------------------------------------------------
+// users
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = usersBackupHandler{}
+
+type usersBackupHandler struct {
+	ac api.Directory
+}
+
+func (h usersBackupHandler) canMakeDeltaQueries() bool {
+	return true
+}
+
+func (h usersBackupHandler) getItemIDs(
+	ctx context.Context,
+	prevDelta string,
+	cc api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	return h.ac.GetAddedAndRemovedUserIDs(ctx, prevDelta, cc)
+}
+
+func (h usersBackupHandler) getItem(
+	ctx context.Context,
+	itemID string,
+) (serialization.Parsable, *details.DirectoryInfo, error) {
+	item, info, err := h.ac.GetUser(ctx, itemID, api.CallConfig{})
+	return item, info, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// groups
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = groupsBackupHandler{}
+
+type groupsBackupHandler struct {
+	ac api.Directory
+}
+
+func (h groupsBackupHandler) canMakeDeltaQueries() bool {
+	return true
+}
+
+func (h groupsBackupHandler) getItemIDs(
+	ctx context.Context,
+	prevDelta string,
+	cc api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	return h.ac.GetAddedAndRemovedGroupIDs(ctx, prevDelta, cc)
+}
+
+func (h groupsBackupHandler) getItem(
+	ctx context.Context,
+	itemID string,
+) (serialization.Parsable, *details.DirectoryInfo, error) {
+	item, info, err := h.ac.GetGroup(ctx, itemID, api.CallConfig{})
+	return item, info, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// applications
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = applicationsBackupHandler{}
+
+type applicationsBackupHandler struct {
+	ac api.Directory
+}
+
+func (h applicationsBackupHandler) canMakeDeltaQueries() bool {
+	return true
+}
+
+func (h applicationsBackupHandler) getItemIDs(
+	ctx context.Context,
+	prevDelta string,
+	cc api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	return h.ac.GetAddedAndRemovedApplicationIDs(ctx, prevDelta, cc)
+}
+
+func (h applicationsBackupHandler) getItem(
+	ctx context.Context,
+	itemID string,
+) (serialization.Parsable, *details.DirectoryInfo, error) {
+	item, info, err := h.ac.GetApplication(ctx, itemID, api.CallConfig{})
+	return item, info, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// service principals
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = servicePrincipalsBackupHandler{}
+
+type servicePrincipalsBackupHandler struct {
+	ac api.Directory
+}
+
+func (h servicePrincipalsBackupHandler) canMakeDeltaQueries() bool {
+	return true
+}
+
+func (h servicePrincipalsBackupHandler) getItemIDs(
+	ctx context.Context,
+	prevDelta string,
+	cc api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	return h.ac.GetAddedAndRemovedServicePrincipalIDs(ctx, prevDelta, cc)
+}
+
+func (h servicePrincipalsBackupHandler) getItem(
+	ctx context.Context,
+	itemID string,
+) (serialization.Parsable, *details.DirectoryInfo, error) {
+	item, info, err := h.ac.GetServicePrincipal(ctx, itemID, api.CallConfig{})
+	return item, info, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// conditional access policies
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = conditionalAccessBackupHandler{}
+
+type conditionalAccessBackupHandler struct {
+	ac api.Directory
+}
+
+// conditional access policies don't support delta queries.
+func (h conditionalAccessBackupHandler) canMakeDeltaQueries() bool {
+	return false
+}
+
+func (h conditionalAccessBackupHandler) getItemIDs(
+	ctx context.Context,
+	_ string,
+	cc api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	return h.ac.GetConditionalAccessPolicyIDs(ctx, cc)
+}
+
+func (h conditionalAccessBackupHandler) getItem(
+	ctx context.Context,
+	itemID string,
+) (serialization.Parsable, *details.DirectoryInfo, error) {
+	item, info, err := h.ac.GetConditionalAccessPolicy(ctx, itemID, api.CallConfig{})
+	return item, info, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// administrative units
+// ---------------------------------------------------------------------------
+
+var _ backupHandler = adminUnitsBackupHandler{}
+
+type adminUnitsBackupHandler struct {
+	ac api.Directory
+}
+
+func (h adminUnitsBackupHandler) canMakeDeltaQueries() bool {
+	return true
+}
+
+func (h adminUnitsBackupHandler) getItemIDs(
+	ctx context.Context,
+	prevDelta string,
+	cc api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	return h.ac.GetAddedAndRemovedAdministrativeUnitIDs(ctx, prevDelta, cc)
+}
+
+func (h adminUnitsBackupHandler) getItem(
+	ctx context.Context,
+	itemID string,
+) (serialization.Parsable, *details.DirectoryInfo, error) {
+	item, info, err := h.ac.GetAdministrativeUnit(ctx, itemID, api.CallConfig{})
+	return item, info, clues.Stack(err).OrNil()
+}
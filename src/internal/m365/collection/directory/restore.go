@@ -0,0 +1,251 @@
+package directory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type GroupRestorer interface {
+	GetGroup(
+		ctx context.Context,
+		groupID string,
+		cc api.CallConfig,
+	) (models.Groupable, *details.DirectoryInfo, error)
+	RestoreDeletedGroup(ctx context.Context, groupID string) error
+	PostGroup(ctx context.Context, group models.Groupable) (models.Groupable, error)
+	PatchGroup(ctx context.Context, groupID string, group models.Groupable) error
+	PostGroupMember(ctx context.Context, groupID, memberID string) error
+	PostGroupOwner(ctx context.Context, groupID, ownerID string) error
+}
+
+var _ GroupRestorer = api.Directory{}
+
+// RestoreGroups recreates the groups within the collection.  Groups that
+// were deleted are restored from the directory's deleted items when
+// possible, and created anew otherwise.  Groups that still exist collide
+// with the backed up copy; unless the collision policy is to skip them,
+// their attributes are reset to the backed up values.  In all cases,
+// owners and members missing from the group get added back.
+//
+// Groups can't be nested within a folder, so the restore location is
+// not used.
+func RestoreGroups(
+	ctx context.Context,
+	gr GroupRestorer,
+	dc data.RestoreCollection,
+	restoreCfg control.RestoreConfig,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	var (
+		el       = errs.Local()
+		metrics  support.CollectionMetrics
+		items    = dc.Items(ctx, errs)
+		fullPath = dc.FullPath()
+	)
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		fullPath.Category().HumanString(),
+		fullPath.Folder(false))
+	defer close(progressMessage)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return metrics, clues.WrapWC(ctx, ctx.Err(), "context cancelled")
+
+		case itemData, ok := <-items:
+			if !ok || el.Failure() != nil {
+				return metrics, el.Failure()
+			}
+
+			ictx := clues.Add(ctx, "item_id", itemData.ID())
+			metrics.Objects++
+
+			buf := &bytes.Buffer{}
+
+			_, err := buf.ReadFrom(itemData.ToReader())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading item bytes"))
+				continue
+			}
+
+			body := buf.Bytes()
+
+			info, err := restoreGroup(ictx, gr, body, restoreCfg, el, ctr)
+			if err != nil {
+				if !errors.Is(err, core.ErrAlreadyExists) {
+					el.AddRecoverable(ictx, clues.Wrap(err, "restoring group"))
+				}
+
+				continue
+			}
+
+			metrics.Bytes += int64(len(body))
+			metrics.Successes++
+
+			itemPath, err := fullPath.AppendItem(itemData.ID())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "adding item to collection path"))
+				continue
+			}
+
+			err = deets.Add(
+				itemPath,
+				&path.Builder{},
+				details.ItemInfo{Directory: info})
+			if err != nil {
+				// These deets additions are for cli display purposes only.
+				// no need to fail out on error.
+				logger.Ctx(ictx).Infow("accounting for restored item", "error", err)
+			}
+
+			progressMessage <- struct{}{}
+		}
+	}
+}
+
+func restoreGroup(
+	ctx context.Context,
+	gr GroupRestorer,
+	body []byte,
+	restoreCfg control.RestoreConfig,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (*details.DirectoryInfo, error) {
+	group, err := api.BytesToGroupable(body)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "creating group from bytes")
+	}
+
+	groupID := ptr.Val(group.GetId())
+	ctx = clues.Add(ctx, "group_id", groupID)
+
+	existing, _, err := gr.GetGroup(ctx, groupID, api.CallConfig{})
+
+	switch {
+	case errors.Is(err, core.ErrNotFound):
+		existing, err = recreateGroup(ctx, gr, group)
+		if err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		groupID = ptr.Val(existing.GetId())
+		ctx = clues.Add(ctx, "restored_group_id", groupID)
+
+		ctr.Inc(count.NewItemCreated)
+
+	case err != nil:
+		return nil, clues.Wrap(err, "getting group")
+
+	default:
+		log := logger.Ctx(ctx)
+		log.Debug("item collision")
+
+		if restoreCfg.OnCollision == control.Skip {
+			ctr.Inc(count.CollisionSkip)
+			log.Debug("skipping item with collision")
+
+			return nil, core.ErrAlreadyExists
+		}
+
+		if err := gr.PatchGroup(ctx, groupID, group); err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		ctr.Inc(count.CollisionReplace)
+	}
+
+	// membership is restored on a best-effort basis; a user who no longer
+	// exists shouldn't cost the rest of the group.
+	for _, id := range missingObjectIDs(group.GetOwners(), existing.GetOwners()) {
+		if err := gr.PostGroupOwner(ctx, groupID, id); err != nil {
+			errs.AddRecoverable(ctx, clues.Stack(err).With("owner_id", id))
+		}
+	}
+
+	for _, id := range missingObjectIDs(group.GetMembers(), existing.GetMembers()) {
+		if err := gr.PostGroupMember(ctx, groupID, id); err != nil {
+			errs.AddRecoverable(ctx, clues.Stack(err).With("member_id", id))
+		}
+	}
+
+	info := api.DirectoryGroupInfo(group)
+	info.Size = int64(len(body))
+
+	return info, nil
+}
+
+// recreateGroup brings back a group that no longer exists.  Deleted
+// groups are kept in the directory's deleted items for a period of time;
+// restoring from there retains the group's id and most of its state.
+// Once that window passes, a new group gets created in its place.
+func recreateGroup(
+	ctx context.Context,
+	gr GroupRestorer,
+	group models.Groupable,
+) (models.Groupable, error) {
+	groupID := ptr.Val(group.GetId())
+
+	err := gr.RestoreDeletedGroup(ctx, groupID)
+	if err != nil && !errors.Is(err, core.ErrNotFound) {
+		return nil, clues.Stack(err)
+	}
+
+	if err != nil {
+		logger.Ctx(ctx).Info("group not found in deleted items, creating a new group")
+
+		created, err := gr.PostGroup(ctx, group)
+
+		return created, clues.Stack(err).OrNil()
+	}
+
+	if err := gr.PatchGroup(ctx, groupID, group); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	restored, _, err := gr.GetGroup(ctx, groupID, api.CallConfig{})
+
+	return restored, clues.Wrap(err, "getting restored group").OrNil()
+}
+
+// missingObjectIDs returns the ids of the backed up objects that aren't
+// present in the current set.
+func missingObjectIDs(backedUp, current []models.DirectoryObjectable) []string {
+	present := map[string]struct{}{}
+
+	for _, obj := range current {
+		present[ptr.Val(obj.GetId())] = struct{}{}
+	}
+
+	missing := []string{}
+
+	for _, obj := range backedUp {
+		id := ptr.Val(obj.GetId())
+
+		if _, ok := present[id]; !ok && len(id) > 0 {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,242 @@
+package directory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+var _ GroupRestorer = &mockGroupRestorer{}
+
+type mockGroupRestorer struct {
+	existing      models.Groupable
+	getErr        error
+	restoreErr    error
+	restored      bool
+	posted        bool
+	patched       bool
+	postedOwners  []string
+	postedMembers []string
+}
+
+func (m *mockGroupRestorer) GetGroup(
+	context.Context,
+	string,
+	api.CallConfig,
+) (models.Groupable, *details.DirectoryInfo, error) {
+	if m.getErr != nil && !m.restored {
+		return nil, nil, m.getErr
+	}
+
+	return m.existing, nil, nil
+}
+
+func (m *mockGroupRestorer) RestoreDeletedGroup(context.Context, string) error {
+	if m.restoreErr != nil {
+		return m.restoreErr
+	}
+
+	m.restored = true
+
+	return nil
+}
+
+func (m *mockGroupRestorer) PostGroup(_ context.Context, group models.Groupable) (models.Groupable, error) {
+	m.posted = true
+
+	created := models.NewGroup()
+	created.SetId(ptr.To("new-" + ptr.Val(group.GetId())))
+
+	return created, nil
+}
+
+func (m *mockGroupRestorer) PatchGroup(context.Context, string, models.Groupable) error {
+	m.patched = true
+	return nil
+}
+
+func (m *mockGroupRestorer) PostGroupMember(_ context.Context, groupID, memberID string) error {
+	m.postedMembers = append(m.postedMembers, groupID+"/"+memberID)
+	return nil
+}
+
+func (m *mockGroupRestorer) PostGroupOwner(_ context.Context, groupID, ownerID string) error {
+	m.postedOwners = append(m.postedOwners, groupID+"/"+ownerID)
+	return nil
+}
+
+type RestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &RestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func directoryObjects(ids ...string) []models.DirectoryObjectable {
+	dos := []models.DirectoryObjectable{}
+
+	for _, id := range ids {
+		u := models.NewUser()
+		u.SetId(ptr.To(id))
+
+		dos = append(dos, u)
+	}
+
+	return dos
+}
+
+func groupBytes(t *testing.T) []byte {
+	group := models.NewGroup()
+	group.SetId(ptr.To("gid"))
+	group.SetDisplayName(ptr.To("Marketing"))
+	group.SetMailNickname(ptr.To("marketing"))
+	group.SetOwners(directoryObjects("o1"))
+	group.SetMembers(directoryObjects("m1", "m2"))
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", group)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return bs
+}
+
+func (suite *RestoreUnitSuite) TestRestoreGroups() {
+	fullPath, err := path.Build("t", "t", path.DirectoryService, path.DirectoryGroupsCategory, false, "gid")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	existing := models.NewGroup()
+	existing.SetId(ptr.To("gid"))
+	existing.SetOwners(directoryObjects("o1"))
+	existing.SetMembers(directoryObjects("m1"))
+
+	table := []struct {
+		name            string
+		getErr          error
+		restoreErr      error
+		onCollision     control.CollisionPolicy
+		expectRestored  bool
+		expectPosted    bool
+		expectPatched   bool
+		expectOwners    []string
+		expectMembers   []string
+		expectSuccesses int
+		expectCreated   int64
+		expectSkipped   int64
+		expectReplaced  int64
+	}{
+		{
+			name:            "deleted group, restored from deleted items",
+			getErr:          clues.Stack(core.ErrNotFound),
+			onCollision:     control.Skip,
+			expectRestored:  true,
+			expectPatched:   true,
+			expectMembers:   []string{"gid/m2"},
+			expectSuccesses: 1,
+			expectCreated:   1,
+		},
+		{
+			name:            "deleted group, recreated",
+			getErr:          clues.Stack(core.ErrNotFound),
+			restoreErr:      clues.Stack(core.ErrNotFound),
+			onCollision:     control.Skip,
+			expectPosted:    true,
+			expectOwners:    []string{"new-gid/o1"},
+			expectMembers:   []string{"new-gid/m1", "new-gid/m2"},
+			expectSuccesses: 1,
+			expectCreated:   1,
+		},
+		{
+			name:          "existing group, skip",
+			onCollision:   control.Skip,
+			expectSkipped: 1,
+		},
+		{
+			name:            "existing group, replace",
+			onCollision:     control.Replace,
+			expectPatched:   true,
+			expectMembers:   []string{"gid/m2"},
+			expectSuccesses: 1,
+			expectReplaced:  1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				gr = &mockGroupRestorer{
+					existing:   existing,
+					getErr:     test.getErr,
+					restoreErr: test.restoreErr,
+				}
+				ctr   = count.New()
+				deets = &details.Builder{}
+				dc    = dataMock.Collection{
+					Path: fullPath,
+					ItemData: []data.Item{
+						&dataMock.Item{
+							ItemID: "gid",
+							Reader: io.NopCloser(bytes.NewReader(groupBytes(t))),
+						},
+					},
+				}
+			)
+
+			metrics, err := RestoreGroups(
+				ctx,
+				gr,
+				dc,
+				control.RestoreConfig{OnCollision: test.onCollision},
+				deets,
+				fault.New(true),
+				ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectSuccesses, metrics.Successes)
+			assert.Equal(t, test.expectRestored, gr.restored)
+			assert.Equal(t, test.expectPosted, gr.posted)
+			assert.Equal(t, test.expectPatched, gr.patched)
+			assert.Equal(t, test.expectOwners, gr.postedOwners)
+			assert.Equal(t, test.expectMembers, gr.postedMembers)
+
+			assert.Equal(t, test.expectCreated, ctr.Get(count.NewItemCreated))
+			assert.Equal(t, test.expectSkipped, ctr.Get(count.CollisionSkip))
+			assert.Equal(t, test.expectReplaced, ctr.Get(count.CollisionReplace))
+
+			require.Len(t, deets.Details().Items(), test.expectSuccesses)
+
+			if test.expectSuccesses > 0 {
+				assert.Equal(t, "Marketing", deets.Details().Items()[0].Directory.Object.DisplayName)
+			}
+		})
+	}
+}
@@ -39,7 +39,10 @@ const (
 	oneNoteMimeType = "application/msonenote"
 )
 
-var _ data.BackupCollection = &Collection{}
+var (
+	_ data.BackupCollection = &Collection{}
+	_ data.ItemEstimator    = &Collection{}
+)
 
 // Collection represents a set of OneDrive objects retrieved from M365
 type Collection struct {
@@ -235,6 +238,24 @@ func (oc Collection) CountAddedItems() int {
 	return len(oc.driveItems) - 1
 }
 
+// EstimateItems reports the number of files in the collection, and their
+// combined size.  Folders, and the versions and metadata of each file, are
+// not included.
+func (oc *Collection) EstimateItems() (int64, int64, bool) {
+	var items, bytes int64
+
+	for _, item := range oc.driveItems {
+		if item.GetFile() == nil {
+			continue
+		}
+
+		items++
+		bytes += ptr.Val(item.GetSize())
+	}
+
+	return items, bytes, true
+}
+
 // Items() returns the channel containing M365 Exchange objects
 func (oc *Collection) Items(
 	ctx context.Context,
@@ -347,6 +368,30 @@ func (oc *Collection) getDriveItemContent(
 	return itemData, nil
 }
 
+// getOneNoteSectionContent fetches the onenote section represented by the
+// item through the onenote api.
+func (oc *Collection) getOneNoteSectionContent(
+	ctx context.Context,
+	driveID string,
+	item *custom.DriveItem,
+	errs *fault.Bus,
+) (io.ReadCloser, error) {
+	rc, err := getOneNoteSectionContent(ctx, oc.handler, driveID, item, oc.counter, errs)
+	if err != nil {
+		if errors.Is(err, api.ErrOneNoteSectionNotFound) ||
+			clues.HasLabel(err, graph.LabelStatus(http.StatusNotFound)) {
+			logger.CtxErr(ctx, err).Info("onenote section not found, probably deleted in flight")
+			return nil, clues.Wrap(err, "deleted onenote section").Label(graph.LabelsSkippable)
+		}
+
+		return nil, clues.Wrap(err, "downloading onenote section")
+	}
+
+	oc.counter.Inc(count.OneNoteSections)
+
+	return rc, nil
+}
+
 type itemAndAPIGetter interface {
 	GetItemer
 	api.Getter
@@ -438,6 +483,26 @@ func readItemContents(
 	return rc, nil
 }
 
+// getItemVersions retrieves the prior versions of the item that should get
+// backed up alongside its current content.  Failing to retrieve versions
+// doesn't prevent the item itself from getting backed up.
+func (oc *Collection) getItemVersions(
+	ctx context.Context,
+	itemID string,
+	errs *fault.Bus,
+) []driveVersion {
+	vs, err := oc.handler.GetItemVersions(ctx, oc.driveID, itemID)
+	if err != nil {
+		if !errors.Is(err, core.ErrNotFound) {
+			errs.AddRecoverable(ctx, clues.Wrap(err, "getting item versions"))
+		}
+
+		return nil
+	}
+
+	return filterDriveVersions(vs, oc.ctrl.DriveVersions, time.Now())
+}
+
 type driveStats struct {
 	dirsRead   int64
 	dirsFound  int64
@@ -515,7 +580,12 @@ type lazyItemGetter struct {
 	driveID              string
 	suffix               string
 	itemExtensionFactory []extensions.CreateItemExtensioner
-	contentGetter        func(
+	// sizeFromContent replaces the item's size in details with the number
+	// of bytes read from the content, for items whose stored content
+	// differs from the drive file (ex: onenote sections).
+	sizeFromContent bool
+	counter         *count.Bus
+	contentGetter   func(
 		ctx context.Context,
 		driveID string,
 		item *custom.DriveItem,
@@ -531,6 +601,13 @@ func (lig *lazyItemGetter) GetData(
 		return nil, nil, false, clues.Stack(err)
 	}
 
+	if lig.sizeFromContent {
+		rc = newSizeRecordingReader(rc, func(size int64) {
+			setDriveItemSize(lig.info, size)
+			lig.counter.Add(count.StreamBytesAdded, size)
+		})
+	}
+
 	extRc, extData, err := extensions.AddItemExtensions(
 		ctx,
 		rc,
@@ -603,8 +680,10 @@ func (oc *Collection) streamDriveItem(
 		metaSuffix = metadata.DirMetaFileSuffix
 	}
 
+	isOneNote := isOneNoteSection(item, oc.isPackageOrChildOfPackage)
+
 	// Fetch metadata for the item
-	itemMeta, itemMetaSize, err = downloadItemMeta(ctx, oc.handler, oc.driveID, item)
+	itemMeta, itemMetaSize, err = downloadItemMeta(ctx, oc.handler, oc.driveID, item, isOneNote)
 	if err != nil {
 		// Skip deleted items
 		if !clues.HasLabel(err, graph.LabelStatus(http.StatusNotFound)) && !errors.Is(err, core.ErrNotFound) {
@@ -621,6 +700,13 @@ func (oc *Collection) streamDriveItem(
 		itemSize,
 		parentPath)
 
+	var versions []driveVersion
+
+	if isFile && !isOneNote && oc.ctrl.DriveVersions.Enabled {
+		versions = oc.getItemVersions(ctx, itemID, errs)
+		itemInfo = withItemVersions(itemInfo, versions)
+	}
+
 	ctx = clues.Add(ctx, "item_info", itemInfo)
 
 	// Drive content download requests are also rate limited by graph api.
@@ -634,6 +720,13 @@ func (oc *Collection) streamDriveItem(
 
 	if isFile {
 		dataSuffix := metadata.DataFileSuffix
+		contentGetter := oc.getDriveItemContent
+
+		// onenote sections can't be reliably downloaded as files, and
+		// wouldn't be restorable if they could.
+		if isOneNote {
+			contentGetter = oc.getOneNoteSectionContent
+		}
 
 		// Use a LazyItem to feed to the collection consumer.
 		// This ensures that downloads won't be attempted unless that consumer
@@ -646,7 +739,9 @@ func (oc *Collection) streamDriveItem(
 				item:                 item,
 				driveID:              oc.driveID,
 				itemExtensionFactory: itemExtensionFactory,
-				contentGetter:        oc.getDriveItemContent,
+				contentGetter:        contentGetter,
+				sizeFromContent:      isOneNote,
+				counter:              oc.counter,
 				suffix:               dataSuffix,
 			},
 			itemID+dataSuffix,
@@ -655,6 +750,25 @@ func (oc *Collection) streamDriveItem(
 			errs)
 	}
 
+	// prior versions are stored in a single file beside the item's data
+	// and metadata.  They don't appear in details on their own; the
+	// versions get listed in the item's details instead.
+	if len(versions) > 0 {
+		oc.data <- data.NewLazyItem(
+			ctx,
+			&lazyVersionsGetter{
+				getter:   oc.handler,
+				driveID:  oc.driveID,
+				itemID:   itemID,
+				versions: versions,
+				counter:  oc.counter,
+			},
+			itemID+metadata.VersionsFileSuffix,
+			itemInfo.Modified(),
+			oc.counter,
+			errs)
+	}
+
 	metaReader := lazy.NewLazyReadCloser(func() (io.ReadCloser, error) {
 		progReader := observe.ItemProgress(
 			ctx,
@@ -692,6 +806,11 @@ func (oc *Collection) streamDriveItem(
 		atomic.AddInt64(&stats.dirsRead, 1)
 	}
 
+	// onenote section sizes get counted once their content is read.
+	if isOneNote {
+		return
+	}
+
 	oc.counter.Add(count.StreamBytesAdded, itemSize)
 	atomic.AddInt64(&stats.byteCount, itemSize)
 }
@@ -304,6 +304,48 @@ func (suite *CollectionUnitSuite) TestCollection() {
 	}
 }
 
+func (suite *CollectionUnitSuite) TestCollection_EstimateItems() {
+	var (
+		t   = suite.T()
+		now = time.Now()
+	)
+
+	folderPath, err := path.Build(
+		"a-tenant",
+		"a-user",
+		path.OneDriveService,
+		path.FilesCategory,
+		false,
+		path.Split("drive/driveID1/root:/folderPath")...)
+	require.NoError(t, err, clues.ToCore(err))
+
+	mbh := defaultOneDriveBH("a-user")
+
+	coll, err := NewCollection(
+		mbh,
+		mbh.ProtectedResource,
+		folderPath,
+		nil,
+		id(drivePfx),
+		name(drivePfx),
+		nil,
+		control.Options{},
+		false,
+		true,
+		nil,
+		count.New())
+	require.NoError(t, err, clues.ToCore(err))
+
+	coll.Add(custom.ToCustomDriveItem(odTD.NewStubDriveItem("folder", "folderPath", 0, now, now, false, false)))
+	coll.Add(custom.ToCustomDriveItem(odTD.NewStubDriveItem("file1", "file1", 10, now, now, true, false)))
+	coll.Add(custom.ToCustomDriveItem(odTD.NewStubDriveItem("file2", "file2", 32, now, now, true, false)))
+
+	items, bytes, sized := coll.EstimateItems()
+	assert.Equal(t, int64(2), items, "folders aren't counted")
+	assert.Equal(t, int64(42), bytes)
+	assert.True(t, sized, "drive items report their size")
+}
+
 func (suite *CollectionUnitSuite) TestCollectionReadError() {
 	var (
 		t          = suite.T()
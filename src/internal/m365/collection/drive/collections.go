@@ -16,6 +16,7 @@ import (
 	"github.com/alcionai/corso/src/internal/data"
 	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
 	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/operations/inject"
 	bupMD "github.com/alcionai/corso/src/pkg/backup/metadata"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
@@ -23,6 +24,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/filters"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph/metadata"
@@ -52,6 +54,15 @@ type Collections struct {
 
 	ctrl control.Options
 
+	// fileFilter skips files that the backup selector's file filter
+	// excludes.  Nil if the selector doesn't filter files.
+	fileFilter *selectors.FileFilter
+
+	// enumeration holds the drive enumeration progress that an interrupted
+	// backup saved with its checkpoints, and records the progress of this
+	// backup.  Nil if progress isn't tracked.
+	enumeration *inject.EnumerationState
+
 	// collectionMap allows lookup of the data.BackupCollection
 	// for a OneDrive folder.
 	// driveID -> itemID -> collection
@@ -71,6 +82,8 @@ func NewCollections(
 	protectedResource idname.Provider,
 	statusUpdater support.StatusUpdater,
 	ctrlOpts control.Options,
+	fileFilter *selectors.FileFilter,
+	enumeration *inject.EnumerationState,
 	counter *count.Bus,
 ) *Collections {
 	return &Collections{
@@ -80,6 +93,8 @@ func NewCollections(
 		CollectionMap:     map[string]map[string]*Collection{},
 		statusUpdater:     statusUpdater,
 		ctrl:              ctrlOpts,
+		fileFilter:        fileFilter,
+		enumeration:       enumeration,
 		counter:           counter,
 	}
 }
@@ -634,6 +649,7 @@ func (c *Collections) handleDelete(
 
 		excluded[itemID+metadata.DataFileSuffix] = struct{}{}
 		excluded[itemID+metadata.MetaFileSuffix] = struct{}{}
+		excluded[itemID+metadata.VersionsFileSuffix] = struct{}{}
 		// Exchange counts items streamed through it which includes deletions so
 		// add that here too.
 		c.NumFiles++
@@ -1123,6 +1139,30 @@ func (c *Collections) processItem(
 			return clues.NewWC(ctx, "item seen before parent folder").Label(count.ItemBeforeParent)
 		}
 
+		// Files excluded by the selector's file filter are handled like
+		// deletions: drop any copy added earlier in this enumeration, and
+		// exclude the prior backup's copy so it isn't carried forward.
+		if skip := c.fileFilterSkip(ctx, driveID, item); skip != nil {
+			if prevParentContainerID, alreadyAdded := currPrevPaths[itemID]; alreadyAdded {
+				if prevColl, found := c.CollectionMap[driveID][prevParentContainerID]; found {
+					prevColl.Remove(itemID)
+				}
+
+				delete(currPrevPaths, itemID)
+			}
+
+			if !invalidPrevDelta {
+				excludedItemIDs[itemID+metadata.DataFileSuffix] = struct{}{}
+				excludedItemIDs[itemID+metadata.MetaFileSuffix] = struct{}{}
+				excludedItemIDs[itemID+metadata.VersionsFileSuffix] = struct{}{}
+			}
+
+			skipper.AddSkip(ctx, skip)
+			counter.Inc(count.FilteredFiles)
+
+			return nil
+		}
+
 		// Don't move items if the new collection's already reached it's limit. This
 		// helps ensure we don't get some pathological case where we end up dropping
 		// a bunch of items that got moved.
@@ -1179,6 +1219,7 @@ func (c *Collections) processItem(
 			// original one and download a fresh copy.
 			excludedItemIDs[itemID+metadata.DataFileSuffix] = struct{}{}
 			excludedItemIDs[itemID+metadata.MetaFileSuffix] = struct{}{}
+			excludedItemIDs[itemID+metadata.VersionsFileSuffix] = struct{}{}
 		}
 
 	default:
@@ -1244,3 +1285,38 @@ func updatePath(paths map[string]string, id, newPath string) {
 		paths[folderID] = strings.Replace(p, currPath, newPath, 1)
 	}
 }
+
+// fileFilterSkip returns a skip record if the selector's file filter
+// excludes the file, and nil otherwise.
+func (c *Collections) fileFilterSkip(
+	ctx context.Context,
+	driveID string,
+	file *custom.DriveItem,
+) *fault.Skipped {
+	var mimeType string
+
+	if file.GetFile() != nil {
+		mimeType = ptr.Val(file.GetFile().GetMimeType())
+	}
+
+	reason, excluded := c.fileFilter.Excludes(
+		ptr.Val(file.GetName()),
+		mimeType,
+		ptr.Val(file.GetSize()),
+		ptr.Val(file.GetLastModifiedDateTime()))
+	if !excluded {
+		return nil
+	}
+
+	logger.Ctx(ctx).Debugw("file excluded by file filter", "filter_reason", reason)
+
+	addtl := graph.ItemInfo(file)
+	addtl[fault.AddtlFilterReason] = reason
+
+	return fault.FileSkip(
+		fault.SkipFileFilter,
+		driveID,
+		ptr.Val(file.GetId()),
+		ptr.Val(file.GetName()),
+		addtl)
+}
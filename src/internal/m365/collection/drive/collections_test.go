@@ -48,6 +48,7 @@ func (suite *CollectionsUnitSuite) TestPopulateDriveCollections() {
 		previousPaths            map[string]string
 		topLevelPackages         map[string]struct{}
 		scope                    selectors.OneDriveScope
+		fileFilter               *selectors.FileFilter
 		expect                   assert.ErrorAssertionFunc
 		expectedCollectionIDs    map[string]statePath
 		expectedItemCount        int
@@ -853,6 +854,32 @@ func (suite *CollectionsUnitSuite) TestPopulateDriveCollections() {
 			expectedCountPackages: 1,
 			expectedExcludes:      makeExcludeMap(fileID(), fileID("good")),
 		},
+		{
+			name: "1 root file kept, 1 root file excluded by file filter",
+			items: []models.DriveItemable{
+				rootFolder(),
+				driveItem(fileID("keep"), "keep.txt", d.dir(), rootID, isFile),
+				driveItem(fileID("skip"), "skip.pdf", d.dir(), rootID, isFile),
+			},
+			previousPaths:    map[string]string{},
+			scope:            anyFolderScope,
+			fileFilter:       &selectors.FileFilter{Extensions: []string{"txt"}},
+			topLevelPackages: map[string]struct{}{},
+			expect:           assert.NoError,
+			expectedCollectionIDs: map[string]statePath{
+				rootID: asNotMoved(t, d.strPath(t)),
+			},
+			expectedItemCount:      1,
+			expectedFileCount:      1,
+			expectedContainerCount: 1,
+			expectedSkippedCount:   1,
+			expectedPrevPaths: map[string]string{
+				rootID: d.strPath(t),
+			},
+			expectedTopLevelPackages: map[string]struct{}{},
+			// the excluded file's prior copy is dropped from the base as well.
+			expectedExcludes: makeExcludeMap(fileID("keep"), fileID("skip")),
+		},
 	}
 
 	for _, test := range tests {
@@ -885,6 +912,8 @@ func (suite *CollectionsUnitSuite) TestPopulateDriveCollections() {
 				idname.NewProvider(user, user),
 				nil,
 				control.Options{ToggleFeatures: control.Toggles{}},
+				test.fileFilter,
+				nil,
 				count.New())
 
 			c.CollectionMap[drive.id] = map[string]*Collection{}
@@ -2637,6 +2666,8 @@ func (suite *CollectionsUnitSuite) TestGet() {
 				control.Options{ToggleFeatures: control.Toggles{
 					UseOldDeltaProcess: true,
 				}},
+				nil,
+				nil,
 				count.New())
 
 			prevDelta := "prev-delta"
@@ -2803,6 +2834,8 @@ func (suite *CollectionsUnitSuite) TestAddURLCacheToDriveCollections() {
 				idname.NewProvider(user, user),
 				func(*support.ControllerOperationStatus) {},
 				control.Options{ToggleFeatures: control.Toggles{}},
+				nil,
+				nil,
 				count.New())
 
 			errs := fault.New(true)
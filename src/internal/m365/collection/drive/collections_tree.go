@@ -181,17 +181,26 @@ func (c *Collections) makeDriveCollections(
 		return nil, nil, pagers.DeltaUpdate{}, clues.Wrap(err, "generating backup tree prefix")
 	}
 
-	tree := newFolderyMcFolderFace(ppfx)
-
 	counter.Add(count.PrevPaths, int64(len(prevPaths)))
 
 	// --- delta item aggregation
 
+	// an interrupted backup may have already enumerated the drive.  If so,
+	// we continue from where that enumeration left off.
+	tree, resumed := c.resumeEnumeration(ctx, driveID, ppfx, prevDeltaLink)
+
+	currDeltaLink := prevDeltaLink
+	if resumed != nil {
+		currDeltaLink = resumed.DeltaLink
+
+		counter.Inc(count.ResumedEnumerations)
+	}
+
 	du, countPagesInDelta, err := c.populateTree(
 		ctx,
 		tree,
 		drv,
-		prevDeltaLink,
+		currDeltaLink,
 		limiter,
 		counter,
 		errs)
@@ -199,6 +208,18 @@ func (c *Collections) makeDriveCollections(
 		return nil, nil, pagers.DeltaUpdate{}, clues.Stack(err)
 	}
 
+	if resumed != nil {
+		countPagesInDelta += resumed.PageCount
+	}
+
+	// preview backups don't enumerate everything, so later backups
+	// can't continue from them.
+	if !limiter.enabled() {
+		c.enumeration.Record(
+			driveID,
+			newDriveEnumeration(tree, prevDeltaLink, du.URL, countPagesInDelta))
+	}
+
 	// --- prev path incorporation
 
 	err = addPrevPathsToTree(
@@ -242,6 +263,47 @@ func (c *Collections) makeDriveCollections(
 	return collections, newPrevs, du, nil
 }
 
+// resumeEnumeration produces the tree to enumerate the drive into.  If an
+// interrupted backup saved the drive's enumeration progress, and that
+// enumeration started from the same delta link, the tree gets rebuilt from
+// that progress, which is returned along with it.  Otherwise the tree is
+// empty.
+func (c *Collections) resumeEnumeration(
+	ctx context.Context,
+	driveID string,
+	prefix path.Path,
+	prevDeltaLink string,
+) (*folderyMcFolderFace, *driveEnumeration) {
+	bs, ok := c.enumeration.Previous(driveID)
+	if !ok {
+		return newFolderyMcFolderFace(prefix), nil
+	}
+
+	de, err := parseDriveEnumeration(bs)
+	if err != nil {
+		logger.CtxErr(ctx, err).Info("enumerating drive from the start")
+		return newFolderyMcFolderFace(prefix), nil
+	}
+
+	if de.PrevDeltaLink != prevDeltaLink || len(de.DeltaLink) == 0 {
+		logger.Ctx(ctx).Info("saved enumeration doesn't continue the previous backup")
+		return newFolderyMcFolderFace(prefix), nil
+	}
+
+	tree, err := de.toTree(ctx, prefix)
+	if err != nil {
+		logger.CtxErr(ctx, err).Info("enumerating drive from the start")
+		return newFolderyMcFolderFace(prefix), nil
+	}
+
+	logger.Ctx(ctx).Infow(
+		"continuing saved drive enumeration",
+		"enumerated_folders", len(de.Folders),
+		"enumerated_pages", de.PageCount)
+
+	return tree, &de
+}
+
 // populateTree constructs a new tree and populates it with items
 // retrieved by enumerating the delta query for the drive.
 func (c *Collections) populateTree(
@@ -628,6 +690,15 @@ func (c *Collections) addFileToTree(
 		return nil, nil
 	}
 
+	// Files excluded by the file filter are treated as deleted so that
+	// any copy in the prior backup isn't carried forward.
+	if skip := c.fileFilterSkip(ctx, driveID, file); skip != nil {
+		tree.deleteFile(fileID)
+		counter.Inc(count.FilteredFiles)
+
+		return skip, nil
+	}
+
 	alreadySeen := tree.hasFile(fileID)
 	parentNode, parentNotNil := tree.folderIDToNode[parentID]
 
@@ -19,6 +19,7 @@ import (
 	countTD "github.com/alcionai/corso/src/pkg/count/testdata"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
 	"github.com/alcionai/corso/src/pkg/services/m365/custom"
 )
@@ -2618,6 +2619,7 @@ func (suite *CollectionsTreeUnitSuite) TestCollections_AddFileToTree() {
 		name    string
 		tree    func(t *testing.T, d *deltaDrive) *folderyMcFolderFace
 		file    models.DriveItemable
+		filter  *selectors.FileFilter
 		limiter *pagerLimiter
 		expect  expected
 	}{
@@ -2721,6 +2723,61 @@ func (suite *CollectionsTreeUnitSuite) TestCollections_AddFileToTree() {
 				countTotalBytes:               0,
 			},
 		},
+		{
+			name:    "file excluded by filter",
+			tree:    treeWithRoot,
+			file:    d.fileAt(root),
+			filter:  &selectors.FileFilter{MaxSize: 1},
+			limiter: newPagerLimiter(control.DefaultOptions()),
+			expect: expected{
+				counts: countTD.Expected{
+					count.TotalFilesProcessed: 1,
+					count.FilteredFiles:       1,
+				},
+				err:                           require.NoError,
+				skipped:                       assert.NotNil,
+				treeContainsFileIDsWithParent: map[string]string{},
+				countLiveFiles:                0,
+				countTotalBytes:               0,
+			},
+		},
+		{
+			name:    "previously added file excluded by filter",
+			tree:    treeWithFileAtRoot,
+			file:    d.fileAt(root),
+			filter:  &selectors.FileFilter{MaxSize: 1},
+			limiter: newPagerLimiter(control.DefaultOptions()),
+			expect: expected{
+				counts: countTD.Expected{
+					count.TotalFilesProcessed: 1,
+					count.FilteredFiles:       1,
+				},
+				err:                           require.NoError,
+				skipped:                       assert.NotNil,
+				treeContainsFileIDsWithParent: map[string]string{},
+				countLiveFiles:                0,
+				countTotalBytes:               0,
+			},
+		},
+		{
+			name:    "file passes filter",
+			tree:    treeWithRoot,
+			file:    d.fileAt(root),
+			filter:  &selectors.FileFilter{MaxSize: defaultFileSize},
+			limiter: newPagerLimiter(control.DefaultOptions()),
+			expect: expected{
+				counts: countTD.Expected{
+					count.TotalFilesProcessed: 1,
+				},
+				err:     require.NoError,
+				skipped: assert.Nil,
+				treeContainsFileIDsWithParent: map[string]string{
+					fileID(): rootID,
+				},
+				countLiveFiles:  1,
+				countTotalBytes: defaultFileSize,
+			},
+		},
 		{
 			name:    "already at container file limit",
 			tree:    treeWithFileAtRoot,
@@ -2791,6 +2848,8 @@ func (suite *CollectionsTreeUnitSuite) TestCollections_AddFileToTree() {
 				tree    = test.tree(t, d)
 			)
 
+			c.fileFilter = test.filter
+
 			skipped, err := c.addFileToTree(
 				ctx,
 				tree,
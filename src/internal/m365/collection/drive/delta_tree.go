@@ -625,11 +625,13 @@ func (face *folderyMcFolderFace) generateExcludeItemIDs() map[string]struct{} {
 
 		result[iID+metadata.DataFileSuffix] = struct{}{}
 		result[iID+metadata.MetaFileSuffix] = struct{}{}
+		result[iID+metadata.VersionsFileSuffix] = struct{}{}
 	}
 
 	for iID := range face.deletedFileIDs {
 		result[iID+metadata.DataFileSuffix] = struct{}{}
 		result[iID+metadata.MetaFileSuffix] = struct{}{}
+		result[iID+metadata.VersionsFileSuffix] = struct{}{}
 	}
 
 	return result
@@ -0,0 +1,175 @@
+package drive
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/custom"
+)
+
+// driveEnumeration is the enumeration progress of a drive.  It gets saved
+// with the checkpoints of an interrupted backup, so that the next backup
+// can rebuild the tree from it and continue enumerating the drive from
+// DeltaLink, instead of enumerating everything since PrevDeltaLink again.
+type driveEnumeration struct {
+	// PrevDeltaLink is the delta link the enumeration started from.  The
+	// progress only applies to backups that start from the same link.
+	PrevDeltaLink string `json:"prevDeltaLink"`
+	// DeltaLink is the delta link that continues the enumeration.
+	DeltaLink string `json:"deltaLink"`
+	// PageCount is the number of pages with items enumerated so far.
+	PageCount      int                `json:"pageCount"`
+	Reset          bool               `json:"reset,omitempty"`
+	Folders        []enumeratedFolder `json:"folders"`
+	DeletedFileIDs []string           `json:"deletedFileIds,omitempty"`
+}
+
+// enumeratedFolder is a node of the tree, along with its files.
+type enumeratedFolder struct {
+	ID          string              `json:"id"`
+	ParentID    string              `json:"parentId,omitempty"`
+	Folder      *custom.DriveItem   `json:"folder,omitempty"`
+	Root        bool                `json:"root,omitempty"`
+	Tombstone   bool                `json:"tombstone,omitempty"`
+	NotSelected bool                `json:"notSelected,omitempty"`
+	Files       []*custom.DriveItem `json:"files,omitempty"`
+}
+
+// newDriveEnumeration records the state of the tree.  The items get
+// copied, since backups modify the items they stream.
+func newDriveEnumeration(
+	tree *folderyMcFolderFace,
+	prevDeltaLink, deltaLink string,
+	pageCount int,
+) driveEnumeration {
+	de := driveEnumeration{
+		PrevDeltaLink:  prevDeltaLink,
+		DeltaLink:      deltaLink,
+		PageCount:      pageCount,
+		Reset:          tree.hadReset,
+		Folders:        []enumeratedFolder{},
+		DeletedFileIDs: make([]string, 0, len(tree.deletedFileIDs)),
+	}
+
+	nodeIDs := map[*nodeyMcNodeFace]string{}
+
+	for id, nodey := range tree.folderIDToNode {
+		nodeIDs[nodey] = id
+	}
+
+	for id, zombey := range tree.tombstones {
+		nodeIDs[zombey] = id
+	}
+
+	for nodey, id := range nodeIDs {
+		_, isTombstone := tree.tombstones[id]
+
+		ef := enumeratedFolder{
+			ID:          id,
+			Folder:      nodey.folder.Clone(),
+			Root:        nodey == tree.root,
+			Tombstone:   isTombstone,
+			NotSelected: nodey.isNotSelected,
+			Files:       make([]*custom.DriveItem, 0, len(nodey.files)),
+		}
+
+		if nodey.parent != nil {
+			ef.ParentID = nodeIDs[nodey.parent]
+		}
+
+		for _, file := range nodey.files {
+			ef.Files = append(ef.Files, file.Clone())
+		}
+
+		sort.Slice(ef.Files, func(i, j int) bool {
+			return ptr.Val(ef.Files[i].GetId()) < ptr.Val(ef.Files[j].GetId())
+		})
+
+		de.Folders = append(de.Folders, ef)
+	}
+
+	sort.Slice(de.Folders, func(i, j int) bool {
+		return de.Folders[i].ID < de.Folders[j].ID
+	})
+
+	for id := range tree.deletedFileIDs {
+		de.DeletedFileIDs = append(de.DeletedFileIDs, id)
+	}
+
+	sort.Strings(de.DeletedFileIDs)
+
+	return de
+}
+
+// parseDriveEnumeration deserializes the enumeration progress of a drive.
+func parseDriveEnumeration(bs []byte) (driveEnumeration, error) {
+	var de driveEnumeration
+
+	err := json.Unmarshal(bs, &de)
+
+	return de, clues.Wrap(err, "deserializing drive enumeration").OrNil()
+}
+
+// toTree rebuilds the tree recorded by the enumeration.
+func (de driveEnumeration) toTree(
+	ctx context.Context,
+	prefix path.Path,
+) (*folderyMcFolderFace, error) {
+	tree := newFolderyMcFolderFace(prefix)
+	tree.hadReset = de.Reset
+
+	for _, ef := range de.Folders {
+		if len(ef.ID) == 0 {
+			return nil, clues.NewWC(ctx, "enumerated folder missing ID")
+		}
+
+		nodey := newNodeyMcNodeFace(nil, ef.Folder, ef.NotSelected)
+
+		for _, file := range ef.Files {
+			fileID := ptr.Val(file.GetId())
+			if len(fileID) == 0 {
+				return nil, clues.NewWC(ctx, "enumerated file missing ID")
+			}
+
+			nodey.files[fileID] = file
+			tree.fileIDToParentID[fileID] = ef.ID
+		}
+
+		switch {
+		case ef.Tombstone:
+			tree.tombstones[ef.ID] = nodey
+		case ef.Root:
+			tree.root = nodey
+			tree.folderIDToNode[ef.ID] = nodey
+		default:
+			tree.folderIDToNode[ef.ID] = nodey
+		}
+	}
+
+	for _, ef := range de.Folders {
+		if ef.Tombstone || ef.Root {
+			continue
+		}
+
+		parent := tree.getNode(ef.ParentID)
+		if parent == nil {
+			return nil, clues.NewWC(ctx, "enumerated folder missing parent").
+				With("folder_id", ef.ID, "parent_id", ef.ParentID)
+		}
+
+		nodey := tree.folderIDToNode[ef.ID]
+		nodey.parent = parent
+		parent.children[ef.ID] = nodey
+	}
+
+	for _, id := range de.DeletedFileIDs {
+		tree.deletedFileIDs[id] = struct{}{}
+	}
+
+	return tree, nil
+}
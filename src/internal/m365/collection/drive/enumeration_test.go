@@ -0,0 +1,401 @@
+package drive
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/exp/maps"
+
+	"github.com/alcionai/corso/src/internal/common/prefixmatcher"
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+	"github.com/alcionai/corso/src/pkg/services/m365/custom"
+)
+
+type EnumerationUnitSuite struct {
+	tester.Suite
+}
+
+func TestEnumerationUnitSuite(t *testing.T) {
+	suite.Run(t, &EnumerationUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// treeShape describes the structure of a tree, so that two trees
+// can be compared without comparing the nodes' pointers.
+type treeShape struct {
+	rootID           string
+	parents          map[string]string
+	children         map[string][]string
+	files            map[string][]string
+	notSelected      []string
+	tombstones       []string
+	fileIDToParentID map[string]string
+	deletedFileIDs   map[string]struct{}
+	hadReset         bool
+}
+
+func shapeOf(tree *folderyMcFolderFace) treeShape {
+	ts := treeShape{
+		parents:          map[string]string{},
+		children:         map[string][]string{},
+		files:            map[string][]string{},
+		notSelected:      []string{},
+		tombstones:       maps.Keys(tree.tombstones),
+		fileIDToParentID: tree.fileIDToParentID,
+		deletedFileIDs:   tree.deletedFileIDs,
+		hadReset:         tree.hadReset,
+	}
+
+	if tree.root != nil {
+		ts.rootID = ptr.Val(tree.root.folder.GetId())
+	}
+
+	nodes := map[string]*nodeyMcNodeFace{}
+	maps.Copy(nodes, tree.folderIDToNode)
+	maps.Copy(nodes, tree.tombstones)
+
+	for id, nodey := range nodes {
+		if nodey.parent != nil {
+			ts.parents[id] = ptr.Val(nodey.parent.folder.GetId())
+		}
+
+		ts.children[id] = maps.Keys(nodey.children)
+		ts.files[id] = maps.Keys(nodey.files)
+
+		if nodey.isNotSelected {
+			ts.notSelected = append(ts.notSelected, id)
+		}
+	}
+
+	return ts
+}
+
+func assertSameShape(t *testing.T, expect, got *folderyMcFolderFace) {
+	es, gs := shapeOf(expect), shapeOf(got)
+
+	assert.Equal(t, es.rootID, gs.rootID, "root")
+	assert.Equal(t, es.parents, gs.parents, "parents")
+	assert.Equal(t, es.fileIDToParentID, gs.fileIDToParentID, "file parents")
+	assert.Equal(t, es.deletedFileIDs, gs.deletedFileIDs, "deleted files")
+	assert.Equal(t, es.hadReset, gs.hadReset, "reset")
+	assert.ElementsMatch(t, es.tombstones, gs.tombstones, "tombstones")
+	assert.ElementsMatch(t, es.notSelected, gs.notSelected, "not selected")
+	assert.ElementsMatch(t, maps.Keys(es.children), maps.Keys(gs.children), "folders")
+
+	for id := range es.children {
+		assert.ElementsMatch(t, es.children[id], gs.children[id], "children of %s", id)
+		assert.ElementsMatch(t, es.files[id], gs.files[id], "files of %s", id)
+	}
+}
+
+func (suite *EnumerationUnitSuite) TestDriveEnumeration_roundTrip() {
+	d := drive()
+
+	table := []struct {
+		name string
+		tree func(t *testing.T, d *deltaDrive) *folderyMcFolderFace
+	}{
+		{
+			name: "empty tree",
+			tree: newTree,
+		},
+		{
+			name: "full tree",
+			tree: fullTree,
+		},
+		{
+			name: "file in tombstone",
+			tree: treeWithFileInTombstone,
+		},
+		{
+			name: "unselected folders",
+			tree: treeWithUnselectedRootAndFolder,
+		},
+		{
+			name: "tree after reset",
+			tree: treeWithFoldersAfterReset,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			tree := test.tree(t, d)
+
+			de := newDriveEnumeration(tree, deltaURL("prev"), deltaURL("curr"), 3)
+
+			bs, err := json.Marshal(de)
+			require.NoError(t, err, clues.ToCore(err))
+
+			got, err := parseDriveEnumeration(bs)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, deltaURL("prev"), got.PrevDeltaLink)
+			assert.Equal(t, deltaURL("curr"), got.DeltaLink)
+			assert.Equal(t, 3, got.PageCount)
+
+			gotTree, err := got.toTree(ctx, tree.prefix)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assertSameShape(t, tree, gotTree)
+		})
+	}
+}
+
+func (suite *EnumerationUnitSuite) TestDriveEnumeration_copiesItems() {
+	t := suite.T()
+	d := drive()
+	tree := treeWithFileInFolder(t, d)
+
+	de := newDriveEnumeration(tree, "", deltaURL(), 1)
+
+	// backups rename the parent reference of the items they stream.
+	file := tree.folderIDToNode[folderID()].files[fileID()]
+	file.SetParentReference(custom.SetParentName(file.GetParentReference(), "renamed"))
+
+	for _, ef := range de.Folders {
+		for _, f := range ef.Files {
+			assert.NotEqual(t, "renamed", ptr.Val(f.GetParentReference().GetName()))
+		}
+	}
+}
+
+func (suite *EnumerationUnitSuite) TestDriveEnumeration_toTree_errors() {
+	d := drive()
+
+	table := []struct {
+		name string
+		de   driveEnumeration
+	}{
+		{
+			name: "folder missing ID",
+			de: driveEnumeration{
+				Folders: []enumeratedFolder{{Root: true}},
+			},
+		},
+		{
+			name: "folder missing parent",
+			de: driveEnumeration{
+				Folders: []enumeratedFolder{
+					{
+						ID:       folderID(),
+						ParentID: folderID("parent"),
+						Folder:   custom.ToCustomDriveItem(d.folderAt("parent")),
+					},
+				},
+			},
+		},
+		{
+			name: "file missing ID",
+			de: driveEnumeration{
+				Folders: []enumeratedFolder{
+					{
+						ID:     rootID,
+						Root:   true,
+						Folder: custom.ToCustomDriveItem(rootFolder()),
+						Files:  []*custom.DriveItem{custom.ToCustomDriveItem(models.NewDriveItem())},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			_, err := test.de.toTree(ctx, defaultTreePfx(t, d))
+			assert.Error(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+// deltaLinkRecorder records the delta links that enumerations start from.
+type deltaLinkRecorder struct {
+	*mockBackupHandler[models.DriveItemable]
+	links []string
+}
+
+func (r *deltaLinkRecorder) EnumerateDriveItemsDelta(
+	ctx context.Context,
+	driveID, prevDeltaLink string,
+	cc api.CallConfig,
+) pagers.NextPageResulter[models.DriveItemable] {
+	r.links = append(r.links, prevDeltaLink)
+	return r.mockBackupHandler.EnumerateDriveItemsDelta(ctx, driveID, prevDeltaLink, cc)
+}
+
+func (suite *EnumerationUnitSuite) TestCollections_MakeDriveCollections_resume() {
+	d := drive()
+
+	table := []struct {
+		name          string
+		savedPrevLink string
+		savedLink     string
+		expectLink    string
+		expectResumed int64
+		expectFiles   []string
+	}{
+		{
+			name:          "continues saved enumeration",
+			savedPrevLink: deltaURL("prev"),
+			savedLink:     deltaURL("saved"),
+			expectLink:    deltaURL("saved"),
+			expectResumed: 1,
+			expectFiles:   []string{fileID(), fileID("r")},
+		},
+		{
+			name:          "saved enumeration started from another delta",
+			savedPrevLink: deltaURL("other"),
+			savedLink:     deltaURL("saved"),
+			expectLink:    deltaURL("prev"),
+			expectFiles:   []string{fileID("r")},
+		},
+		{
+			name:          "saved enumeration has no delta link",
+			savedPrevLink: deltaURL("prev"),
+			expectLink:    deltaURL("prev"),
+			expectFiles:   []string{fileID("r")},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			saved := newDriveEnumeration(
+				treeWithFileInFolder(t, d),
+				test.savedPrevLink,
+				test.savedLink,
+				2)
+
+			bs, err := json.Marshal(saved)
+			require.NoError(t, err, clues.ToCore(err))
+
+			mbh := defaultOneDriveBH(user)
+			mbh.DriveItemEnumeration = driveEnumerator(
+				d.newEnumer().with(
+					delta(nil).with(
+						aPage(d.fileAt(root, "r")))))
+
+			rec := &deltaLinkRecorder{mockBackupHandler: mbh}
+			es := inject.NewEnumerationState(map[string]json.RawMessage{d.id: bs})
+
+			c := NewCollections(
+				rec,
+				tenant,
+				mbh.ProtectedResource,
+				nil,
+				control.DefaultOptions(),
+				nil,
+				es,
+				count.New())
+
+			colls, _, du, err := c.makeDriveCollections(
+				ctx,
+				d.able,
+				nil,
+				deltaURL("prev"),
+				newPagerLimiter(control.DefaultOptions()),
+				prefixmatcher.NewStringSetBuilder(),
+				c.counter,
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			require.NotEmpty(t, rec.links)
+			assert.Equal(t, test.expectLink, rec.links[0], "first enumeration's delta link")
+			assert.Equal(t, test.expectResumed, c.counter.Get(count.ResumedEnumerations))
+
+			fileIDs := []string{}
+
+			for _, coll := range colls {
+				dc, ok := coll.(*Collection)
+				if !ok {
+					continue
+				}
+
+				for itemID, item := range dc.driveItems {
+					if item.GetFolder() == nil {
+						fileIDs = append(fileIDs, itemID)
+					}
+				}
+			}
+
+			assert.ElementsMatch(t, test.expectFiles, fileIDs)
+
+			// the progress of this enumeration gets recorded for the next backup.
+			rbs, err := es.Marshal()
+			require.NoError(t, err, clues.ToCore(err))
+
+			var recorded map[string]json.RawMessage
+
+			err = json.Unmarshal(rbs, &recorded)
+			require.NoError(t, err, clues.ToCore(err))
+
+			got, err := parseDriveEnumeration(recorded[d.id])
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, deltaURL("prev"), got.PrevDeltaLink)
+			assert.Equal(t, du.URL, got.DeltaLink)
+		})
+	}
+}
+
+func (suite *EnumerationUnitSuite) TestCollections_MakeDriveCollections_previewNotRecorded() {
+	t := suite.T()
+	d := drive()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	mbh := defaultOneDriveBH(user)
+	mbh.DriveItemEnumeration = driveEnumerator(
+		d.newEnumer().with(
+			delta(nil).with(
+				aPage(d.fileAt(root, "r")))))
+
+	es := inject.NewEnumerationState(nil)
+	opts := minimumLimitOpts()
+
+	c := NewCollections(
+		mbh,
+		tenant,
+		mbh.ProtectedResource,
+		nil,
+		opts,
+		nil,
+		es,
+		count.New())
+
+	_, _, _, err := c.makeDriveCollections(
+		ctx,
+		d.able,
+		nil,
+		deltaURL("prev"),
+		newPagerLimiter(opts),
+		prefixmatcher.NewStringSetBuilder(),
+		c.counter,
+		fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Zero(t, es.Recorded())
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/alcionai/clues"
 
 	"github.com/alcionai/corso/src/internal/data"
+	odmetadata "github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	"github.com/alcionai/corso/src/internal/version"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/export"
@@ -45,13 +46,22 @@ func streamItems(
 	errs := fault.New(false)
 
 	for _, rc := range drc {
+		var driveID string
+
+		// the drive ID is only needed to look up file versions.
+		if fp := rc.FullPath(); len(cec.Version) > 0 && fp != nil {
+			if dp, err := path.ToDrivePath(fp); err == nil {
+				driveID = dp.DriveID
+			}
+		}
+
 		for item := range rc.Items(ctx, errs) {
 			itemUUID := item.ID()
 			if isMetadataFile(itemUUID, backupVersion) {
 				continue
 			}
 
-			name, err := getItemName(ctx, itemUUID, backupVersion, rc)
+			meta, err := getItemMeta(ctx, itemUUID, backupVersion, rc)
 			if err != nil {
 				ch <- export.Item{
 					ID:    itemUUID,
@@ -61,8 +71,34 @@ func streamItems(
 				continue
 			}
 
+			if len(cec.Version) > 0 && !meta.OneNoteSection {
+				exportFileVersions(ctx, rc, driveID, itemUUID, meta.FileName, item, cec.Version, ch, stats)
+				continue
+			}
+
+			var (
+				name = meta.FileName
+				rdr  = item.ToReader()
+			)
+
+			// onenote sections are stored as captured by the onenote api,
+			// and get rendered into a browsable html document.
+			if meta.OneNoteSection {
+				name = oneNoteExportName(name)
+
+				rdr, err = exportOneNoteSection(ctx, rdr)
+				if err != nil {
+					ch <- export.Item{
+						ID:    itemUUID,
+						Error: clues.WrapWC(ctx, err, "exporting onenote section"),
+					}
+
+					continue
+				}
+			}
+
 			stats.UpdateResourceCount(path.FilesCategory)
-			body := metrics.ReaderWithStats(item.ToReader(), path.FilesCategory, stats)
+			body := metrics.ReaderWithStats(rdr, path.FilesCategory, stats)
 
 			ch <- export.Item{
 				ID:    itemUUID,
@@ -100,23 +136,25 @@ func isMetadataFile(id string, backupVersion int) bool {
 	}
 
 	return strings.HasSuffix(id, metadata.MetaFileSuffix) ||
-		strings.HasSuffix(id, metadata.DirMetaFileSuffix)
+		strings.HasSuffix(id, metadata.DirMetaFileSuffix) ||
+		strings.HasSuffix(id, metadata.VersionsFileSuffix)
 }
 
-// getItemName is used to get the name of the item.
-// How we get the name depends on the version of the backup.
-func getItemName(
+// getItemMeta is used to get the metadata of the item.  How we get the
+// metadata depends on the version of the backup.  Backups that predate
+// storing the item name in the metadata only produce the FileName.
+func getItemMeta(
 	ctx context.Context,
 	id string,
 	backupVersion int,
 	fin data.FetchItemByNamer,
-) (string, error) {
+) (odmetadata.Metadata, error) {
 	if backupVersion < version.OneDrive1DataAndMetaFiles {
-		return id, nil
+		return odmetadata.Metadata{FileName: id}, nil
 	}
 
 	if backupVersion < version.OneDrive5DirMetaNoName {
-		return strings.TrimSuffix(id, metadata.DataFileSuffix), nil
+		return odmetadata.Metadata{FileName: strings.TrimSuffix(id, metadata.DataFileSuffix)}, nil
 	}
 
 	if strings.HasSuffix(id, metadata.DataFileSuffix) {
@@ -125,11 +163,11 @@ func getItemName(
 
 		meta, err := FetchAndReadMetadata(ctx, fin, metaName)
 		if err != nil {
-			return "", clues.WrapWC(ctx, err, "getting metadata")
+			return odmetadata.Metadata{}, clues.WrapWC(ctx, err, "getting metadata")
 		}
 
-		return meta.FileName, nil
+		return meta, nil
 	}
 
-	return "", clues.NewWC(ctx, "invalid item id")
+	return odmetadata.Metadata{}, clues.NewWC(ctx, "invalid item id")
 }
@@ -49,6 +49,12 @@ func (suite *ExportUnitSuite) TestIsMetadataFile() {
 			id:            "name" + metadata.DirMetaFileSuffix,
 			isMeta:        true,
 		},
+		{
+			name:          "versions file",
+			backupVersion: version.OneDrive3IsMetaMarker,
+			id:            "name" + metadata.VersionsFileSuffix,
+			isMeta:        true,
+		},
 		{
 			name:          "non metadata file",
 			backupVersion: version.OneDrive3IsMetaMarker,
@@ -89,7 +95,7 @@ func (fd finD) FetchItemByName(ctx context.Context, name string) (data.Item, err
 	return nil, assert.AnError
 }
 
-func (suite *ExportUnitSuite) TestGetItemName() {
+func (suite *ExportUnitSuite) TestGetItemMeta() {
 	table := []struct {
 		name          string
 		id            string
@@ -137,14 +143,14 @@ func (suite *ExportUnitSuite) TestGetItemName() {
 			ctx, flush := tester.NewContext(t)
 			defer flush()
 
-			name, err := getItemName(
+			meta, err := getItemMeta(
 				ctx,
 				test.id,
 				test.backupVersion,
 				test.fin)
 			test.expectErr(t, err, clues.ToCore(err))
 
-			assert.Equal(t, test.expectName, name, "name")
+			assert.Equal(t, test.expectName, meta.FileName, "name")
 		})
 	}
 }
@@ -39,6 +39,8 @@ type BackupHandler interface {
 	api.Getter
 	GetItemPermissioner
 	GetItemer
+	GetItemVersionser
+	GetOneNoteSectioner
 	GetRootFolderer
 	NewDrivePagerer
 	EnumerateDriveItemsDeltaer
@@ -85,6 +87,38 @@ type GetItemer interface {
 	) (models.DriveItemable, error)
 }
 
+type GetItemVersionser interface {
+	GetItemVersions(
+		ctx context.Context,
+		driveID, itemID string,
+	) ([]models.DriveItemVersionable, error)
+}
+
+// GetOneNoteSectioner retrieves onenote sections, pages, and page resources
+// belonging to the handler's protected resource.
+type GetOneNoteSectioner interface {
+	GetOneNoteSection(
+		ctx context.Context,
+		sectionID string,
+	) (models.OnenoteSectionable, error)
+	GetOneNoteSectionGroup(
+		ctx context.Context,
+		sectionGroupID string,
+	) (models.SectionGroupable, error)
+	GetOneNoteSectionPages(
+		ctx context.Context,
+		sectionID string,
+	) ([]models.OnenotePageable, error)
+	GetOneNotePageContent(
+		ctx context.Context,
+		pageID string,
+	) ([]byte, error)
+	GetOneNoteResourceContent(
+		ctx context.Context,
+		resourceURL string,
+	) ([]byte, error)
+}
+
 type EnumerateDriveItemsDeltaer interface {
 	EnumerateDriveItemsDelta(
 		ctx context.Context,
@@ -108,6 +142,7 @@ type RestoreHandler interface {
 	NewItemContentUploader
 	PostDriver
 	PostItemInContainerer
+	PostOneNoteSectioner
 	DeleteItemPermissioner
 	UpdateItemPermissioner
 	UpdateItemLinkSharer
@@ -181,6 +216,33 @@ type PostItemInContainerer interface {
 	) (models.DriveItemable, error)
 }
 
+// PostOneNoteSectioner recreates onenote sections and their pages within the
+// notebooks of the given protected resource.
+type PostOneNoteSectioner interface {
+	GetOrPostOneNoteNotebook(
+		ctx context.Context,
+		protectedResourceID, notebookName string,
+	) (models.Notebookable, error)
+	GetOrPostOneNoteSectionGroup(
+		ctx context.Context,
+		protectedResourceID, container, sectionGroupName string,
+	) (models.SectionGroupable, error)
+	GetOneNoteSectionByName(
+		ctx context.Context,
+		protectedResourceID, container, sectionName string,
+	) (models.OnenoteSectionable, error)
+	PostOneNoteSection(
+		ctx context.Context,
+		protectedResourceID, container, sectionName string,
+	) (models.OnenoteSectionable, error)
+	PostOneNotePage(
+		ctx context.Context,
+		protectedResourceID, sectionID string,
+		content []byte,
+		resources []api.OneNotePageResource,
+	) error
+}
+
 type GetFolderByNamer interface {
 	GetFolderByName(
 		ctx context.Context,
@@ -118,6 +118,7 @@ func makeExcludeMap(files ...string) map[string]struct{} {
 	for _, file := range files {
 		delList[file+metadata.DataFileSuffix] = struct{}{}
 		delList[file+metadata.MetaFileSuffix] = struct{}{}
+		delList[file+metadata.VersionsFileSuffix] = struct{}{}
 	}
 
 	return delList
@@ -162,6 +163,8 @@ func collWithMBH(mbh BackupHandler) *Collections {
 		idname.NewProvider(user, user),
 		func(*support.ControllerOperationStatus) {},
 		control.Options{ToggleFeatures: control.Toggles{}},
+		nil,
+		nil,
 		count.New())
 }
 
@@ -175,6 +178,8 @@ func collWithMBHAndOpts(
 		idname.NewProvider(user, user),
 		func(*support.ControllerOperationStatus) {},
 		opts,
+		nil,
+		nil,
 		count.New())
 }
 
@@ -645,6 +650,8 @@ type mockBackupHandler[T any] struct {
 
 	GI  getsItem
 	GIP getsItemPermission
+	GIV getsItemVersions
+	ON  getsOneNoteSection
 
 	PathPrefixFn  pathPrefixer
 	PathPrefixErr error
@@ -828,6 +835,13 @@ func (h mockBackupHandler[T]) GetItem(ctx context.Context, _, _ string) (models.
 	return h.GI.GetItem(ctx, "", "")
 }
 
+func (h mockBackupHandler[T]) GetItemVersions(
+	ctx context.Context,
+	_, _ string,
+) ([]models.DriveItemVersionable, error) {
+	return h.GIV.GetItemVersions(ctx, "", "")
+}
+
 func (h mockBackupHandler[T]) GetItemPermission(
 	ctx context.Context,
 	_, _ string,
@@ -930,6 +944,113 @@ func (h mockBackupHandler[T]) GetRootFolder(context.Context, string) (models.Dri
 	return h.RootFolder, nil
 }
 
+func (h mockBackupHandler[T]) GetOneNoteSection(
+	ctx context.Context,
+	sectionID string,
+) (models.OnenoteSectionable, error) {
+	return h.ON.GetOneNoteSection(ctx, sectionID)
+}
+
+func (h mockBackupHandler[T]) GetOneNoteSectionGroup(
+	ctx context.Context,
+	sectionGroupID string,
+) (models.SectionGroupable, error) {
+	return h.ON.GetOneNoteSectionGroup(ctx, sectionGroupID)
+}
+
+func (h mockBackupHandler[T]) GetOneNoteSectionPages(
+	ctx context.Context,
+	sectionID string,
+) ([]models.OnenotePageable, error) {
+	return h.ON.GetOneNoteSectionPages(ctx, sectionID)
+}
+
+func (h mockBackupHandler[T]) GetOneNotePageContent(
+	ctx context.Context,
+	pageID string,
+) ([]byte, error) {
+	return h.ON.GetOneNotePageContent(ctx, pageID)
+}
+
+func (h mockBackupHandler[T]) GetOneNoteResourceContent(
+	ctx context.Context,
+	resourceURL string,
+) ([]byte, error) {
+	return h.ON.GetOneNoteResourceContent(ctx, resourceURL)
+}
+
+// ---------------------------------------------------------------------------
+// Get OneNote Sectioner
+// ---------------------------------------------------------------------------
+
+var _ GetOneNoteSectioner = getsOneNoteSection{}
+
+type getsOneNoteSection struct {
+	Section models.OnenoteSectionable
+	// sectionGroupID -> section group
+	SectionGroups map[string]models.SectionGroupable
+	Pages         []models.OnenotePageable
+	// pageID -> content
+	Content map[string][]byte
+	// resource url -> content
+	Resources map[string][]byte
+	// pageID -> content retrieval error
+	ContentErrs map[string]error
+	Err         error
+}
+
+func (m getsOneNoteSection) GetOneNoteSection(
+	_ context.Context,
+	sectionID string,
+) (models.OnenoteSectionable, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	if m.Section == nil || ptr.Val(m.Section.GetId()) != sectionID {
+		return nil, api.ErrOneNoteSectionNotFound
+	}
+
+	return m.Section, nil
+}
+
+func (m getsOneNoteSection) GetOneNoteSectionGroup(
+	_ context.Context,
+	sectionGroupID string,
+) (models.SectionGroupable, error) {
+	sg, ok := m.SectionGroups[sectionGroupID]
+	if !ok {
+		return nil, clues.New("section group not found")
+	}
+
+	return sg, nil
+}
+
+func (m getsOneNoteSection) GetOneNoteSectionPages(
+	context.Context,
+	string,
+) ([]models.OnenotePageable, error) {
+	return m.Pages, m.Err
+}
+
+func (m getsOneNoteSection) GetOneNotePageContent(
+	_ context.Context,
+	pageID string,
+) ([]byte, error) {
+	if err := m.ContentErrs[pageID]; err != nil {
+		return nil, err
+	}
+
+	return m.Content[pageID], m.Err
+}
+
+func (m getsOneNoteSection) GetOneNoteResourceContent(
+	_ context.Context,
+	resourceURL string,
+) ([]byte, error) {
+	return m.Resources[resourceURL], m.Err
+}
+
 // ---------------------------------------------------------------------------
 // Get Itemer
 // ---------------------------------------------------------------------------
@@ -946,6 +1067,22 @@ func (m getsItem) GetItem(
 	return m.Item, m.Err
 }
 
+// ---------------------------------------------------------------------------
+// Get Item Versionser
+// ---------------------------------------------------------------------------
+
+type getsItemVersions struct {
+	Versions []models.DriveItemVersionable
+	Err      error
+}
+
+func (m getsItemVersions) GetItemVersions(
+	_ context.Context,
+	_, _ string,
+) ([]models.DriveItemVersionable, error) {
+	return m.Versions, m.Err
+}
+
 // ---------------------------------------------------------------------------
 // Drive Item Enummerator
 // ---------------------------------------------------------------------------
@@ -1263,6 +1400,14 @@ type mockRestoreHandler struct {
 	PostDriveErr  error
 
 	UploadSessionErr error
+
+	OneNoteSection        models.OnenoteSectionable
+	OneNoteNotebook       models.Notebookable
+	PostedOneNoteSections []string
+	// containers of each posted section, in order
+	PostedOneNoteContainers []string
+	PostedOneNotePages      [][]byte
+	PostedOneNoteRes        [][]api.OneNotePageResource
 }
 
 func (h mockRestoreHandler) PostDrive(
@@ -1357,6 +1502,68 @@ func (h *mockRestoreHandler) GetRootFolder(
 	return models.NewDriveItem(), nil
 }
 
+func (h *mockRestoreHandler) GetOneNoteSectionByName(
+	context.Context,
+	string, string, string,
+) (models.OnenoteSectionable, error) {
+	if h.OneNoteSection == nil {
+		return nil, api.ErrOneNoteSectionNotFound
+	}
+
+	return h.OneNoteSection, nil
+}
+
+func (h *mockRestoreHandler) GetOrPostOneNoteNotebook(
+	context.Context,
+	string, string,
+) (models.Notebookable, error) {
+	if h.OneNoteNotebook == nil {
+		nb := models.NewNotebook()
+		nb.SetId(ptr.To("notebook"))
+
+		return nb, nil
+	}
+
+	return h.OneNoteNotebook, nil
+}
+
+func (h *mockRestoreHandler) GetOrPostOneNoteSectionGroup(
+	_ context.Context,
+	_, _, sectionGroupName string,
+) (models.SectionGroupable, error) {
+	sg := models.NewSectionGroup()
+	sg.SetId(ptr.To(sectionGroupName))
+	sg.SetDisplayName(ptr.To(sectionGroupName))
+
+	return sg, nil
+}
+
+func (h *mockRestoreHandler) PostOneNoteSection(
+	_ context.Context,
+	_, container, sectionName string,
+) (models.OnenoteSectionable, error) {
+	h.PostedOneNoteSections = append(h.PostedOneNoteSections, sectionName)
+	h.PostedOneNoteContainers = append(h.PostedOneNoteContainers, container)
+
+	sect := models.NewOnenoteSection()
+	sect.SetId(ptr.To("section"))
+	sect.SetDisplayName(ptr.To(sectionName))
+
+	return sect, nil
+}
+
+func (h *mockRestoreHandler) PostOneNotePage(
+	_ context.Context,
+	_, _ string,
+	content []byte,
+	resources []api.OneNotePageResource,
+) error {
+	h.PostedOneNotePages = append(h.PostedOneNotePages, content)
+	h.PostedOneNoteRes = append(h.PostedOneNoteRes, resources)
+
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // stub drive item factories
 // ---------------------------------------------------------------------------
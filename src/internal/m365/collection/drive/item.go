@@ -115,7 +115,7 @@ func (dg *downloadWithRetries) Get(
 			Label(graph.LabelStatus(resp.StatusCode))
 	}
 
-	return resp.Body, nil
+	return graph.LimitDownload(ctx, resp.Body), nil
 }
 
 func downloadFile(
@@ -159,10 +159,12 @@ func downloadItemMeta(
 	getter GetItemPermissioner,
 	driveID string,
 	item *custom.DriveItem,
+	isOneNoteSection bool,
 ) (io.ReadCloser, int, error) {
 	meta := metadata.Metadata{
-		FileName:    ptr.Val(item.GetName()),
-		SharingMode: metadata.SharingModeInherited,
+		FileName:       ptr.Val(item.GetName()),
+		SharingMode:    metadata.SharingModeInherited,
+		OneNoteSection: isOneNoteSection,
 	}
 
 	if item.GetShared() != nil {
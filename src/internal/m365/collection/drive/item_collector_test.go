@@ -291,6 +291,8 @@ func (suite *OneDriveIntgSuite) TestOneDriveNewCollections() {
 				control.Options{
 					ToggleFeatures: control.Toggles{},
 				},
+				nil,
+				nil,
 				count.New())
 
 			ssmb := prefixmatcher.NewStringSetBuilder()
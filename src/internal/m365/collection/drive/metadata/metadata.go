@@ -44,4 +44,7 @@ type Metadata struct {
 	SharingMode SharingMode  `json:"permissionMode,omitempty"`
 	Permissions []Permission `json:"permissions,omitempty"`
 	LinkShares  []LinkShare  `json:"linkShares,omitempty"`
+	// OneNoteSection is true when the data file holds a onenote section
+	// captured through the onenote api instead of the raw .one file content.
+	OneNoteSection bool `json:"oneNoteSection,omitempty"`
 }
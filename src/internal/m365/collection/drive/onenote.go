@@ -0,0 +1,816 @@
+package drive
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/custom"
+)
+
+const (
+	oneNoteSectionExt = ".one"
+	// exported sections are rendered into a single html document.
+	oneNoteExportExt = ".html"
+)
+
+// oneNoteResourceRE matches the graph urls of images and file attachments
+// referenced by the html content of a onenote page.
+var oneNoteResourceRE = regexp.MustCompile(
+	`(?:src|data)="(https://graph\.microsoft\.com/[^"]+/onenote/resources/[^"]+)"`)
+
+// OneNoteSection is the serialized form of a onenote section as captured
+// through the onenote api.  It's stored in place of the .one file content
+// within the drive hierarchy.
+type OneNoteSection struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Notebook and SectionGroups locate the section within onenote.  The
+	// section groups are ordered from the notebook down to the section.
+	Notebook      string        `json:"notebook,omitempty"`
+	SectionGroups []string      `json:"sectionGroups,omitempty"`
+	Pages         []OneNotePage `json:"pages"`
+}
+
+// OneNotePage holds the html content of a single page, along with every
+// binary resource referenced by that content.
+type OneNotePage struct {
+	ID        string            `json:"id"`
+	Title     string            `json:"title"`
+	Level     int32             `json:"level"`
+	Order     int32             `json:"order"`
+	Created   time.Time         `json:"created"`
+	Modified  time.Time         `json:"modified"`
+	Content   []byte            `json:"content"`
+	Resources []OneNoteResource `json:"resources,omitempty"`
+}
+
+type OneNoteResource struct {
+	URL         string `json:"url"`
+	ContentType string `json:"contentType"`
+	Data        []byte `json:"data"`
+}
+
+// isOneNoteSection returns true if the item is a onenote section file.
+// Sections are only retrievable as files when they're small enough, so
+// they get captured through the onenote api instead.
+func isOneNoteSection(item *custom.DriveItem, inPackage bool) bool {
+	if item == nil || item.GetFile() == nil {
+		return false
+	}
+
+	if strings.EqualFold(ptr.Val(item.GetFile().GetMimeType()), oneNoteMimeType) {
+		return true
+	}
+
+	return inPackage && strings.HasSuffix(strings.ToLower(ptr.Val(item.GetName())), oneNoteSectionExt)
+}
+
+// oneNoteSectionName produces the onenote display name of the section
+// represented by the .one file.
+func oneNoteSectionName(fileName string) string {
+	if strings.HasSuffix(strings.ToLower(fileName), oneNoteSectionExt) {
+		return fileName[:len(fileName)-len(oneNoteSectionExt)]
+	}
+
+	return fileName
+}
+
+// ---------------------------------------------------------------------------
+// backup
+// ---------------------------------------------------------------------------
+
+// getOneNoteSectionContent produces the serialized OneNoteSection matching
+// the item.  The section is addressed by the id onenote derives from the
+// item's sharepoint list item unique id, so that sections sharing a name
+// in other notebooks or section groups can't be picked up by mistake.
+//
+// Pages are retrieved and serialized one at a time as the reader gets
+// consumed, so only a single page and its resources are held in memory.
+// They're stored in page order, which lets restores and exports handle them
+// one at a time as well.  Pages that can't be retrieved get skipped without
+// failing the section.
+func getOneNoteSectionContent(
+	ctx context.Context,
+	gons GetOneNoteSectioner,
+	driveID string,
+	item *custom.DriveItem,
+	counter *count.Bus,
+	errs *fault.Bus,
+) (io.ReadCloser, error) {
+	uniqueID := ptr.Val(item.GetListItemUniqueID())
+	if len(uniqueID) == 0 {
+		return nil, clues.NewWC(ctx, "onenote section item has no list item unique id")
+	}
+
+	sectionID := api.OneNoteSectionID(uniqueID)
+	ctx = clues.Add(ctx, "onenote_section_id", sectionID)
+
+	sect, err := gons.GetOneNoteSection(ctx, sectionID)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting onenote section")
+	}
+
+	sectionGroups, err := getOneNoteSectionGroups(ctx, gons, sect.GetParentSectionGroup())
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	pages, err := gons.GetOneNoteSectionPages(ctx, ptr.Val(sect.GetId()))
+	if err != nil {
+		return nil, clues.Wrap(err, "getting onenote section pages")
+	}
+
+	sort.SliceStable(pages, func(i, j int) bool {
+		return ptr.Val(pages[i].GetOrder()) < ptr.Val(pages[j].GetOrder())
+	})
+
+	section := OneNoteSection{
+		ID:            ptr.Val(sect.GetId()),
+		Name:          ptr.Val(sect.GetDisplayName()),
+		SectionGroups: sectionGroups,
+		Pages:         []OneNotePage{},
+	}
+
+	if sect.GetParentNotebook() != nil {
+		section.Notebook = ptr.Val(sect.GetParentNotebook().GetDisplayName())
+	}
+
+	rc := pipeWrites(ctx, func(w io.Writer) error {
+		return writeOneNoteSection(ctx, w, gons, section, pages, driveID, item, counter, errs)
+	})
+
+	return rc, nil
+}
+
+// pipeWrites produces a reader of everything written by write, which runs
+// in its own goroutine.  The goroutine is stopped once the reader gets
+// closed or the ctx is done, so that a reader which gets abandoned can't
+// leave it blocked on a write.
+func pipeWrites(ctx context.Context, write func(w io.Writer) error) io.ReadCloser {
+	var (
+		pr, pw = io.Pipe()
+		done   = make(chan struct{})
+	)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			pw.CloseWithError(clues.StackWC(ctx, ctx.Err()))
+		case <-done:
+		}
+	}()
+
+	go func() {
+		defer close(done)
+		pw.CloseWithError(write(pw))
+	}()
+
+	return pr
+}
+
+// writeOneNoteSection serializes the section into w, retrieving the content
+// of each page as it gets written.  The output is equivalent to marshaling
+// the section with all of its pages populated.
+func writeOneNoteSection(
+	ctx context.Context,
+	w io.Writer,
+	gons GetOneNoteSectioner,
+	section OneNoteSection,
+	pages []models.OnenotePageable,
+	driveID string,
+	item *custom.DriveItem,
+	counter *count.Bus,
+	errs *fault.Bus,
+) error {
+	// section.Pages is empty, so the serialized section ends with the
+	// closing of the pages array and the section object.  Pages get
+	// written in between those closing brackets.
+	hdr, err := json.Marshal(section)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "serializing onenote section")
+	}
+
+	if _, err := w.Write(bytes.TrimSuffix(hdr, []byte("]}"))); err != nil {
+		return clues.WrapWC(ctx, err, "writing onenote section")
+	}
+
+	var written int
+
+	for _, p := range pages {
+		if err := ctx.Err(); err != nil {
+			return clues.StackWC(ctx, err)
+		}
+
+		page, err := getOneNotePage(ctx, gons, p)
+		if err != nil {
+			logger.CtxErr(ctx, err).
+				With("skipped_reason", fault.SkipOneNotePage).
+				Info("inaccessible onenote page")
+			errs.AddSkip(ctx, fault.FileSkip(
+				fault.SkipOneNotePage,
+				driveID,
+				ptr.Val(p.GetId()),
+				ptr.Val(p.GetTitle()),
+				graph.ItemInfo(item)))
+
+			continue
+		}
+
+		bs, err := json.Marshal(page)
+		if err != nil {
+			return clues.WrapWC(ctx, err, "serializing onenote page")
+		}
+
+		if written > 0 {
+			bs = append([]byte(","), bs...)
+		}
+
+		if _, err := w.Write(bs); err != nil {
+			return clues.WrapWC(ctx, err, "writing onenote page")
+		}
+
+		written++
+
+		counter.Inc(count.OneNotePages)
+	}
+
+	if _, err := w.Write([]byte("]}")); err != nil {
+		return clues.WrapWC(ctx, err, "writing onenote section")
+	}
+
+	return nil
+}
+
+// sizeRecordingReader counts the bytes read from the wrapped reader, and
+// hands the total to onEOF once the content is fully read.
+type sizeRecordingReader struct {
+	io.ReadCloser
+	size  int64
+	onEOF func(size int64)
+}
+
+func newSizeRecordingReader(rc io.ReadCloser, onEOF func(int64)) *sizeRecordingReader {
+	return &sizeRecordingReader{ReadCloser: rc, onEOF: onEOF}
+}
+
+func (r *sizeRecordingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.size += int64(n)
+
+	if errors.Is(err, io.EOF) && r.onEOF != nil {
+		r.onEOF(r.size)
+		r.onEOF = nil
+	}
+
+	return n, err
+}
+
+// setDriveItemSize replaces the size recorded in the item's details.
+func setDriveItemSize(info *details.ItemInfo, size int64) {
+	switch {
+	case info.OneDrive != nil:
+		info.OneDrive.Size = size
+	case info.SharePoint != nil:
+		info.SharePoint.Size = size
+	case info.Groups != nil:
+		info.Groups.Size = size
+	}
+}
+
+// getOneNoteSectionGroups produces the names of the section groups holding
+// a section, ordered from the notebook down to the section's parent.  Only
+// the immediate parent gets expanded alongside a section or section group,
+// so each ancestor is retrieved in turn.
+func getOneNoteSectionGroups(
+	ctx context.Context,
+	gons GetOneNoteSectioner,
+	parent models.SectionGroupable,
+) ([]string, error) {
+	var groups []string
+
+	for parent != nil {
+		groups = append([]string{ptr.Val(parent.GetDisplayName())}, groups...)
+
+		sg, err := gons.GetOneNoteSectionGroup(ctx, ptr.Val(parent.GetId()))
+		if err != nil {
+			return nil, clues.Wrap(err, "getting onenote section group").
+				With("onenote_section_group_id", ptr.Val(parent.GetId()))
+		}
+
+		parent = sg.GetParentSectionGroup()
+	}
+
+	return groups, nil
+}
+
+func getOneNotePage(
+	ctx context.Context,
+	gons GetOneNoteSectioner,
+	p models.OnenotePageable,
+) (OneNotePage, error) {
+	pageID := ptr.Val(p.GetId())
+	ctx = clues.Add(ctx, "onenote_page_id", pageID)
+
+	content, err := gons.GetOneNotePageContent(ctx, pageID)
+	if err != nil {
+		return OneNotePage{}, clues.Wrap(err, "getting onenote page content")
+	}
+
+	page := OneNotePage{
+		ID:       pageID,
+		Title:    ptr.Val(p.GetTitle()),
+		Level:    ptr.Val(p.GetLevel()),
+		Order:    ptr.Val(p.GetOrder()),
+		Created:  ptr.Val(p.GetCreatedDateTime()),
+		Modified: ptr.Val(p.GetLastModifiedDateTime()),
+		Content:  content,
+	}
+
+	for _, url := range oneNoteResourceURLs(content) {
+		bs, err := gons.GetOneNoteResourceContent(ctx, url)
+		if err != nil {
+			return OneNotePage{}, clues.Wrap(err, "getting onenote page resource")
+		}
+
+		page.Resources = append(page.Resources, OneNoteResource{
+			URL:         url,
+			ContentType: http.DetectContentType(bs),
+			Data:        bs,
+		})
+	}
+
+	return page, nil
+}
+
+// oneNoteResourceURLs returns the distinct resource urls referenced in the
+// page content, in order of appearance.
+func oneNoteResourceURLs(content []byte) []string {
+	var (
+		urls = []string{}
+		seen = map[string]struct{}{}
+	)
+
+	for _, match := range oneNoteResourceRE.FindAllSubmatch(content, -1) {
+		url := html.UnescapeString(string(match[1]))
+
+		if _, ok := seen[url]; ok {
+			continue
+		}
+
+		seen[url] = struct{}{}
+		urls = append(urls, url)
+	}
+
+	return urls
+}
+
+// replaceOneNoteResourceURLs rewrites each resource url within the content
+// using the value returned by fn.
+func replaceOneNoteResourceURLs(
+	content []byte,
+	fn func(url string) string,
+) []byte {
+	return oneNoteResourceRE.ReplaceAllFunc(content, func(match []byte) []byte {
+		var (
+			sub  = oneNoteResourceRE.FindSubmatch(match)
+			url  = html.UnescapeString(string(sub[1]))
+			repl = fn(url)
+		)
+
+		if repl == url {
+			return match
+		}
+
+		return bytes.Replace(match, sub[1], []byte(html.EscapeString(repl)), 1)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// restore
+// ---------------------------------------------------------------------------
+
+// oneNoteSectionReader deserializes a section one page at a time, so that
+// only a single page and its resources are held in memory.  Sections are
+// serialized with their pages last, so everything else about the section
+// is read before the first page.
+type oneNoteSectionReader struct {
+	// section holds everything but the pages.
+	section OneNoteSection
+	dec     *json.Decoder
+	inPages bool
+}
+
+func newOneNoteSectionReader(r io.Reader) (*oneNoteSectionReader, error) {
+	var (
+		sr  = &oneNoteSectionReader{dec: json.NewDecoder(r)}
+		hdr = map[string]json.RawMessage{}
+	)
+
+	tok, err := sr.dec.Token()
+	if err != nil {
+		return nil, clues.Wrap(err, "deserializing onenote section")
+	}
+
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, clues.New("deserializing onenote section: not an object")
+	}
+
+	for sr.dec.More() {
+		tok, err := sr.dec.Token()
+		if err != nil {
+			return nil, clues.Wrap(err, "deserializing onenote section")
+		}
+
+		key, _ := tok.(string)
+
+		if key == "pages" {
+			tok, err := sr.dec.Token()
+			if err != nil {
+				return nil, clues.Wrap(err, "deserializing onenote section pages")
+			}
+
+			if d, ok := tok.(json.Delim); ok && d == '[' {
+				sr.inPages = true
+				break
+			}
+
+			if tok != nil {
+				return nil, clues.New("deserializing onenote section pages: not an array")
+			}
+
+			continue
+		}
+
+		var v json.RawMessage
+
+		if err := sr.dec.Decode(&v); err != nil {
+			return nil, clues.Wrap(err, "deserializing onenote section")
+		}
+
+		hdr[key] = v
+	}
+
+	bs, err := json.Marshal(hdr)
+	if err != nil {
+		return nil, clues.Wrap(err, "deserializing onenote section")
+	}
+
+	if err := json.Unmarshal(bs, &sr.section); err != nil {
+		return nil, clues.Wrap(err, "deserializing onenote section")
+	}
+
+	return sr, nil
+}
+
+// next deserializes the next page of the section.  It returns false once
+// all of the pages have been read.
+func (sr *oneNoteSectionReader) next() (OneNotePage, bool, error) {
+	if !sr.inPages {
+		return OneNotePage{}, false, nil
+	}
+
+	if !sr.dec.More() {
+		sr.inPages = false
+
+		// consuming the end of the pages catches truncated sections.
+		if _, err := sr.dec.Token(); err != nil {
+			return OneNotePage{}, false, clues.Wrap(err, "deserializing onenote section pages")
+		}
+
+		return OneNotePage{}, false, nil
+	}
+
+	var page OneNotePage
+
+	if err := sr.dec.Decode(&page); err != nil {
+		return OneNotePage{}, false, clues.Wrap(err, "deserializing onenote page")
+	}
+
+	return page, true, nil
+}
+
+// restoreOneNoteFile restores the onenote section held by the item.
+// Sections captured before their notebook and section groups were recorded
+// are placed into the notebook named after their parent folder.
+func restoreOneNoteFile(
+	ctx context.Context,
+	rh RestoreHandler,
+	rcc inject.RestoreConsumerConfig,
+	drivePath *path.DrivePath,
+	meta metadata.Metadata,
+	itemData data.Item,
+	ctr *count.Bus,
+) (details.ItemInfo, error) {
+	folderNotebook := rcc.RestoreConfig.Location
+
+	if len(drivePath.Folders) > 0 {
+		folderNotebook = drivePath.Folders[len(drivePath.Folders)-1]
+	}
+
+	rc := itemData.ToReader()
+	defer rc.Close()
+
+	sectionID, written, err := restoreOneNoteSection(
+		ctx,
+		rh,
+		rcc.ProtectedResource.ID(),
+		folderNotebook,
+		meta,
+		rc,
+		rcc.RestoreConfig.OnCollision,
+		ctr)
+	if err != nil {
+		return details.ItemInfo{}, err
+	}
+
+	dii := rh.AugmentItemInfo(
+		details.ItemInfo{},
+		rcc.ProtectedResource,
+		custom.NewDriveItem(sectionID, meta.FileName),
+		written,
+		nil)
+
+	return dii, nil
+}
+
+// restoreOneNoteSection recreates the section, and all of its pages, within
+// the notebook and section groups it was backed up from.  OneNote sections
+// can't be restored as drive files, since the .one file contents are not
+// captured during backup.
+func restoreOneNoteSection(
+	ctx context.Context,
+	pons PostOneNoteSectioner,
+	protectedResourceID, folderNotebook string,
+	meta metadata.Metadata,
+	itemData io.Reader,
+	onCollision control.CollisionPolicy,
+	ctr *count.Bus,
+) (string, int64, error) {
+	sr, err := newOneNoteSectionReader(itemData)
+	if err != nil {
+		return "", 0, clues.StackWC(ctx, err)
+	}
+
+	var (
+		section       = sr.section
+		sectionName   = oneNoteSectionName(meta.FileName)
+		notebookName  = section.Notebook
+		sectionGroups = section.SectionGroups
+	)
+
+	if len(notebookName) == 0 {
+		notebookName = folderNotebook
+		sectionGroups = nil
+	}
+
+	ctx = clues.Add(
+		ctx,
+		"onenote_notebook", clues.Hide(notebookName),
+		"onenote_section_groups", clues.Hide(sectionGroups),
+		"onenote_section", clues.Hide(sectionName))
+
+	container, err := getOrPostOneNoteContainer(ctx, pons, protectedResourceID, notebookName, sectionGroups)
+	if err != nil {
+		return "", 0, clues.Stack(err)
+	}
+
+	_, err = pons.GetOneNoteSectionByName(ctx, protectedResourceID, container, sectionName)
+	if err == nil {
+		if onCollision == control.Skip {
+			ctr.Inc(count.CollisionSkip)
+			logger.Ctx(ctx).Debug("skipping onenote section with collision")
+
+			return "", 0, core.ErrAlreadyExists
+		}
+
+		// onenote rejects duplicate section names within a container, and
+		// sections can't be deleted through the api, so both copy and
+		// replace produce a renamed section.
+		sectionName = fmt.Sprintf("%s %s", sectionName, time.Now().UTC().Format("20060102T150405"))
+	} else if !errors.Is(err, api.ErrOneNoteSectionNotFound) {
+		return "", 0, clues.Wrap(err, "looking up onenote section")
+	}
+
+	sect, err := pons.PostOneNoteSection(ctx, protectedResourceID, container, sectionName)
+	if err != nil {
+		return "", 0, clues.Wrap(err, "creating onenote section")
+	}
+
+	// pages are created in the order they were stored, which is how onenote
+	// determines the order of pages within the section.
+	var written int64
+
+	for {
+		page, ok, err := sr.next()
+		if err != nil {
+			return "", 0, clues.StackWC(ctx, err)
+		}
+
+		if !ok {
+			break
+		}
+
+		content, resources := oneNotePageForUpload(page)
+
+		err = pons.PostOneNotePage(
+			ctx,
+			protectedResourceID,
+			ptr.Val(sect.GetId()),
+			content,
+			resources)
+		if err != nil {
+			return "", 0, clues.Wrap(err, "creating onenote page").With("onenote_page_id", page.ID)
+		}
+
+		written += int64(len(content))
+
+		for _, r := range resources {
+			written += int64(len(r.Data))
+		}
+	}
+
+	ctr.Inc(count.NewItemCreated)
+
+	return ptr.Val(sect.GetId()), written, nil
+}
+
+// getOrPostOneNoteContainer produces the container segment addressing the
+// innermost of the section groups within the notebook, creating the
+// notebook and any section groups that don't exist.
+func getOrPostOneNoteContainer(
+	ctx context.Context,
+	pons PostOneNoteSectioner,
+	protectedResourceID, notebookName string,
+	sectionGroups []string,
+) (string, error) {
+	nb, err := pons.GetOrPostOneNoteNotebook(ctx, protectedResourceID, notebookName)
+	if err != nil {
+		return "", clues.Wrap(err, "getting onenote notebook")
+	}
+
+	container := api.OneNoteNotebookContainer(ptr.Val(nb.GetId()))
+
+	for _, name := range sectionGroups {
+		sg, err := pons.GetOrPostOneNoteSectionGroup(ctx, protectedResourceID, container, name)
+		if err != nil {
+			return "", clues.Wrap(err, "getting onenote section group")
+		}
+
+		container = api.OneNoteSectionGroupContainer(ptr.Val(sg.GetId()))
+	}
+
+	return container, nil
+}
+
+// oneNotePageForUpload rewrites resource urls within the page content to
+// reference multipart part names, as required by the page creation api.
+func oneNotePageForUpload(page OneNotePage) ([]byte, []api.OneNotePageResource) {
+	var (
+		resources = make([]api.OneNotePageResource, 0, len(page.Resources))
+		partNames = map[string]string{}
+	)
+
+	for i, r := range page.Resources {
+		name := fmt.Sprintf("resource%d", i)
+		partNames[r.URL] = "name:" + name
+
+		resources = append(resources, api.OneNotePageResource{
+			Name:        name,
+			ContentType: r.ContentType,
+			Data:        r.Data,
+		})
+	}
+
+	content := replaceOneNoteResourceURLs(page.Content, func(url string) string {
+		if pn, ok := partNames[url]; ok {
+			return pn
+		}
+
+		return url
+	})
+
+	return content, resources
+}
+
+// ---------------------------------------------------------------------------
+// export
+// ---------------------------------------------------------------------------
+
+// oneNoteExportName produces the name used for an exported section.
+func oneNoteExportName(fileName string) string {
+	return oneNoteSectionName(fileName) + oneNoteExportExt
+}
+
+// exportOneNoteSection renders the section into a single, self-contained
+// html document.  Each page becomes an article in the document, and all
+// resources get embedded as data urls.  Pages are rendered one at a time
+// as the document gets read.
+func exportOneNoteSection(ctx context.Context, rc io.ReadCloser) (io.ReadCloser, error) {
+	sr, err := newOneNoteSectionReader(rc)
+	if err != nil {
+		rc.Close()
+		return nil, clues.Stack(err)
+	}
+
+	exported := pipeWrites(ctx, func(w io.Writer) error {
+		defer rc.Close()
+		return writeOneNoteExport(w, sr)
+	})
+
+	return exported, nil
+}
+
+func writeOneNoteExport(w io.Writer, sr *oneNoteSectionReader) error {
+	_, err := fmt.Fprintf(
+		w,
+		"<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n",
+		html.EscapeString(sr.section.Name))
+	if err != nil {
+		return clues.Wrap(err, "writing onenote export")
+	}
+
+	for {
+		page, ok, err := sr.next()
+		if err != nil {
+			return clues.Stack(err)
+		}
+
+		if !ok {
+			break
+		}
+
+		dataURLs := map[string]string{}
+
+		for _, r := range page.Resources {
+			dataURLs[r.URL] = "data:" + r.ContentType + ";base64," + base64.StdEncoding.EncodeToString(r.Data)
+		}
+
+		content := replaceOneNoteResourceURLs(page.Content, func(url string) string {
+			if du, ok := dataURLs[url]; ok {
+				return du
+			}
+
+			return url
+		})
+
+		buf := &bytes.Buffer{}
+
+		fmt.Fprintf(
+			buf,
+			"<article data-page-id=\"%s\" data-level=\"%d\">\n<h1>%s</h1>\n",
+			html.EscapeString(page.ID),
+			page.Level,
+			html.EscapeString(page.Title))
+		buf.Write(pageBody(content))
+		buf.WriteString("\n</article>\n")
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return clues.Wrap(err, "writing onenote export")
+		}
+	}
+
+	if _, err := io.WriteString(w, "</body>\n</html>\n"); err != nil {
+		return clues.Wrap(err, "writing onenote export")
+	}
+
+	return nil
+}
+
+var htmlBodyRE = regexp.MustCompile(`(?is)<body[^>]*>(.*)</body>`)
+
+// pageBody extracts the contents of the body element from the page html.
+// Content without a body element is returned as-is.
+func pageBody(content []byte) []byte {
+	if m := htmlBodyRE.FindSubmatch(content); m != nil {
+		return m[1]
+	}
+
+	return content
+}
@@ -0,0 +1,667 @@
+package drive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/custom"
+)
+
+const (
+	oneNoteImgURL = "https://graph.microsoft.com/v1.0/users('u')/onenote/resources/img-id/$value"
+	oneNoteObjURL = "https://graph.microsoft.com/v1.0/users('u')/onenote/resources/obj-id/$value"
+)
+
+var oneNotePageHTML = []byte(`<html><head><title>pg</title></head><body>` +
+	`<p>hello</p>` +
+	`<img src="` + oneNoteImgURL + `" data-src-type="image/png" />` +
+	`<object data-attachment="f.pdf" data="` + oneNoteObjURL + `" type="application/pdf" />` +
+	`<img src="` + oneNoteImgURL + `" />` +
+	`</body></html>`)
+
+type OneNoteUnitSuite struct {
+	tester.Suite
+}
+
+func TestOneNoteUnitSuite(t *testing.T) {
+	suite.Run(t, &OneNoteUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func oneNoteDriveItem(name, mimeType string) *custom.DriveItem {
+	item := models.NewDriveItem()
+	item.SetId(ptr.To("id"))
+	item.SetName(ptr.To(name))
+
+	spIDs := models.NewSharepointIds()
+	spIDs.SetListItemUniqueId(ptr.To("{SECT-UNIQUE-ID}"))
+	item.SetSharepointIds(spIDs)
+
+	file := models.NewFile()
+	file.SetMimeType(ptr.To(mimeType))
+	item.SetFile(file)
+
+	return custom.ToCustomDriveItem(item)
+}
+
+func (suite *OneNoteUnitSuite) TestIsOneNoteSection() {
+	table := []struct {
+		name      string
+		item      *custom.DriveItem
+		inPackage bool
+		expect    assert.BoolAssertionFunc
+	}{
+		{
+			name:   "onenote mime type",
+			item:   oneNoteDriveItem("sect.one", oneNoteMimeType),
+			expect: assert.True,
+		},
+		{
+			name:      "section extension in package",
+			item:      oneNoteDriveItem("Sect.ONE", ""),
+			inPackage: true,
+			expect:    assert.True,
+		},
+		{
+			name:   "section extension outside of package",
+			item:   oneNoteDriveItem("sect.one", ""),
+			expect: assert.False,
+		},
+		{
+			name:      "table of contents in package",
+			item:      oneNoteDriveItem("Open Notebook.onetoc2", ""),
+			inPackage: true,
+			expect:    assert.False,
+		},
+		{
+			name:      "folder",
+			item:      custom.NewDriveItem("id", "sect.one"),
+			inPackage: true,
+			expect:    assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), isOneNoteSection(test.item, test.inPackage))
+		})
+	}
+}
+
+func (suite *OneNoteUnitSuite) TestOneNoteResourceURLs() {
+	t := suite.T()
+
+	urls := oneNoteResourceURLs(oneNotePageHTML)
+	assert.Equal(t, []string{oneNoteImgURL, oneNoteObjURL}, urls)
+
+	replaced := replaceOneNoteResourceURLs(oneNotePageHTML, func(url string) string {
+		if url == oneNoteImgURL {
+			return "name:img"
+		}
+
+		return url
+	})
+
+	assert.Equal(t, 2, bytes.Count(replaced, []byte(`src="name:img"`)))
+	assert.Contains(t, string(replaced), oneNoteObjURL)
+	assert.Empty(t, oneNoteResourceURLs([]byte("<p>no resources</p>")))
+}
+
+func (suite *OneNoteUnitSuite) TestGetOneNoteSectionContent() {
+	nb := models.NewNotebook()
+	nb.SetDisplayName(ptr.To("nb"))
+
+	outer := models.NewSectionGroup()
+	outer.SetId(ptr.To("outer-id"))
+	outer.SetDisplayName(ptr.To("outer"))
+
+	inner := models.NewSectionGroup()
+	inner.SetId(ptr.To("inner-id"))
+	inner.SetDisplayName(ptr.To("inner"))
+	inner.SetParentSectionGroup(outer)
+
+	sect := models.NewOnenoteSection()
+	sect.SetId(ptr.To("1-sect-unique-id"))
+	sect.SetDisplayName(ptr.To("sect"))
+	sect.SetParentNotebook(nb)
+
+	groupedSect := models.NewOnenoteSection()
+	groupedSect.SetId(ptr.To("1-sect-unique-id"))
+	groupedSect.SetDisplayName(ptr.To("sect"))
+	groupedSect.SetParentNotebook(nb)
+	groupedSect.SetParentSectionGroup(inner)
+
+	otherSect := models.NewOnenoteSection()
+	otherSect.SetId(ptr.To("1-other-unique-id"))
+	otherSect.SetDisplayName(ptr.To("sect"))
+
+	page := models.NewOnenotePage()
+	page.SetId(ptr.To("page-id"))
+	page.SetTitle(ptr.To("pg"))
+	page.SetOrder(ptr.To[int32](3))
+
+	earlierPage := models.NewOnenotePage()
+	earlierPage.SetId(ptr.To("earlier-page-id"))
+	earlierPage.SetTitle(ptr.To("earlier"))
+	earlierPage.SetOrder(ptr.To[int32](1))
+
+	brokenPage := models.NewOnenotePage()
+	brokenPage.SetId(ptr.To("broken-page-id"))
+	brokenPage.SetTitle(ptr.To("broken"))
+
+	table := []struct {
+		name        string
+		item        *custom.DriveItem
+		gons        getsOneNoteSection
+		expectErr   assert.ErrorAssertionFunc
+		expect      func(t *testing.T, s OneNoteSection)
+		expectSkips int
+	}{
+		{
+			name: "success",
+			item: oneNoteDriveItem("sect.one", oneNoteMimeType),
+			gons: getsOneNoteSection{
+				Section: sect,
+				Pages:   []models.OnenotePageable{page},
+				Content: map[string][]byte{"page-id": oneNotePageHTML},
+				Resources: map[string][]byte{
+					oneNoteImgURL: []byte("img"),
+					oneNoteObjURL: []byte("%PDF-1.4"),
+				},
+			},
+			expectErr: assert.NoError,
+			expect: func(t *testing.T, s OneNoteSection) {
+				assert.Equal(t, "1-sect-unique-id", s.ID)
+				assert.Equal(t, "sect", s.Name)
+				assert.Equal(t, "nb", s.Notebook)
+				assert.Empty(t, s.SectionGroups)
+				require.Len(t, s.Pages, 1)
+				assert.Equal(t, "pg", s.Pages[0].Title)
+				assert.Equal(t, int32(3), s.Pages[0].Order)
+				assert.Equal(t, oneNotePageHTML, s.Pages[0].Content)
+				require.Len(t, s.Pages[0].Resources, 2)
+				assert.Equal(t, []byte("img"), s.Pages[0].Resources[0].Data)
+				assert.Equal(t, "application/pdf", s.Pages[0].Resources[1].ContentType)
+			},
+		},
+		{
+			name: "inaccessible page skipped",
+			item: oneNoteDriveItem("sect.one", oneNoteMimeType),
+			gons: getsOneNoteSection{
+				Section: sect,
+				Pages:   []models.OnenotePageable{brokenPage, page},
+				Content: map[string][]byte{"page-id": oneNotePageHTML},
+				Resources: map[string][]byte{
+					oneNoteImgURL: []byte("img"),
+					oneNoteObjURL: []byte("%PDF-1.4"),
+				},
+				ContentErrs: map[string]error{"broken-page-id": assert.AnError},
+			},
+			expectErr: assert.NoError,
+			expect: func(t *testing.T, s OneNoteSection) {
+				require.Len(t, s.Pages, 1)
+				assert.Equal(t, "page-id", s.Pages[0].ID)
+			},
+			expectSkips: 1,
+		},
+		{
+			name: "pages stored in order",
+			item: oneNoteDriveItem("sect.one", oneNoteMimeType),
+			gons: getsOneNoteSection{
+				Section: sect,
+				Pages:   []models.OnenotePageable{page, earlierPage},
+				Content: map[string][]byte{
+					"page-id":         []byte("<p>later</p>"),
+					"earlier-page-id": []byte("<p>earlier</p>"),
+				},
+			},
+			expectErr: assert.NoError,
+			expect: func(t *testing.T, s OneNoteSection) {
+				require.Len(t, s.Pages, 2)
+				assert.Equal(t, "earlier-page-id", s.Pages[0].ID)
+				assert.Equal(t, "page-id", s.Pages[1].ID)
+			},
+		},
+		{
+			name: "nested section groups",
+			item: oneNoteDriveItem("sect.one", oneNoteMimeType),
+			gons: getsOneNoteSection{
+				Section: groupedSect,
+				SectionGroups: map[string]models.SectionGroupable{
+					"inner-id": inner,
+					"outer-id": outer,
+				},
+			},
+			expectErr: assert.NoError,
+			expect: func(t *testing.T, s OneNoteSection) {
+				assert.Equal(t, "nb", s.Notebook)
+				assert.Equal(t, []string{"outer", "inner"}, s.SectionGroups)
+			},
+		},
+		{
+			name: "section group lookup failure",
+			item: oneNoteDriveItem("sect.one", oneNoteMimeType),
+			gons: getsOneNoteSection{
+				Section: groupedSect,
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "same name, different section",
+			item: oneNoteDriveItem("sect.one", oneNoteMimeType),
+			gons: getsOneNoteSection{
+				Section: otherSect,
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "no unique id",
+			item:      custom.ToCustomDriveItem(models.NewDriveItem()),
+			gons:      getsOneNoteSection{Section: sect},
+			expectErr: assert.Error,
+		},
+		{
+			name: "api error",
+			item: oneNoteDriveItem("sect.one", oneNoteMimeType),
+			gons: getsOneNoteSection{
+				Section: sect,
+				Err:     assert.AnError,
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			errs := fault.New(true)
+
+			rc, err := getOneNoteSectionContent(ctx, test.gons, "drive-id", test.item, count.New(), errs)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			s, err := readOneNoteSection(rc)
+			require.NoError(t, err, clues.ToCore(err))
+
+			test.expect(t, s)
+			assert.Len(t, errs.Skipped(), test.expectSkips)
+		})
+	}
+}
+
+// readOneNoteSection deserializes the section along with all of its pages.
+func readOneNoteSection(r io.Reader) (OneNoteSection, error) {
+	sr, err := newOneNoteSectionReader(r)
+	if err != nil {
+		return OneNoteSection{}, err
+	}
+
+	section := sr.section
+	section.Pages = []OneNotePage{}
+
+	for {
+		page, ok, err := sr.next()
+		if err != nil {
+			return OneNoteSection{}, err
+		}
+
+		if !ok {
+			return section, nil
+		}
+
+		section.Pages = append(section.Pages, page)
+	}
+}
+
+func (suite *OneNoteUnitSuite) TestOneNoteSectionReader() {
+	full := oneNoteSectionBytes(suite.T(), "nb", "outer")
+
+	table := []struct {
+		name        string
+		input       []byte
+		expectErr   assert.ErrorAssertionFunc
+		expectPages int
+	}{
+		{
+			name:        "pages",
+			input:       full,
+			expectErr:   assert.NoError,
+			expectPages: 2,
+		},
+		{
+			name:      "no pages",
+			input:     []byte(`{"id":"sect-id","name":"sect","notebook":"nb","sectionGroups":["outer"],"pages":null}`),
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "truncated pages",
+			input:     full[:len(full)-2],
+			expectErr: assert.Error,
+		},
+		{
+			name:      "truncated page",
+			input:     full[:len(full)-20],
+			expectErr: assert.Error,
+		},
+		{
+			name:      "not an object",
+			input:     []byte(`["sect"]`),
+			expectErr: assert.Error,
+		},
+		{
+			name:      "not json",
+			input:     []byte("not json"),
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			s, err := readOneNoteSection(bytes.NewReader(test.input))
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, "sect", s.Name)
+			assert.Equal(t, "nb", s.Notebook)
+			assert.Equal(t, []string{"outer"}, s.SectionGroups)
+			assert.Len(t, s.Pages, test.expectPages)
+		})
+	}
+}
+
+func (suite *OneNoteUnitSuite) TestPipeWrites() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	// the writer never finishes on its own, and only stops once its
+	// writes fail.
+	writeForever := func(stopped chan<- error) func(w io.Writer) error {
+		return func(w io.Writer) error {
+			for {
+				if _, err := w.Write([]byte("data")); err != nil {
+					stopped <- err
+					return err
+				}
+			}
+		}
+	}
+
+	// abandoned readers are released by the ctx.
+	cctx, cancel := context.WithCancel(ctx)
+	stopped := make(chan error, 1)
+
+	rc := pipeWrites(cctx, writeForever(stopped))
+
+	_, err := rc.Read(make([]byte, 4))
+	require.NoError(t, err, clues.ToCore(err))
+
+	cancel()
+
+	select {
+	case err := <-stopped:
+		assert.ErrorIs(t, err, io.ErrClosedPipe, clues.ToCore(err))
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "writer not stopped by the ctx")
+	}
+
+	_, err = io.ReadAll(rc)
+	assert.ErrorIs(t, err, context.Canceled, clues.ToCore(err))
+
+	// closing the reader stops the writer.
+	stopped = make(chan error, 1)
+	rc = pipeWrites(ctx, writeForever(stopped))
+
+	err = rc.Close()
+	require.NoError(t, err, clues.ToCore(err))
+
+	select {
+	case err := <-stopped:
+		assert.ErrorIs(t, err, io.ErrClosedPipe, clues.ToCore(err))
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "writer not stopped by closing the reader")
+	}
+}
+
+func (suite *OneNoteUnitSuite) TestSizeRecordingReader() {
+	t := suite.T()
+
+	var (
+		content = []byte("serialized section")
+		info    = details.ItemInfo{OneDrive: &details.OneDriveInfo{Size: 1}}
+		calls   int
+	)
+
+	rc := newSizeRecordingReader(io.NopCloser(bytes.NewReader(content)), func(size int64) {
+		calls++
+
+		setDriveItemSize(&info, size)
+	})
+
+	bs, err := io.ReadAll(rc)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, content, bs)
+
+	// reading past EOF shouldn't report the size again.
+	_, err = rc.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, int64(len(content)), info.OneDrive.Size)
+}
+
+func oneNoteSectionBytes(t *testing.T, notebook string, sectionGroups ...string) []byte {
+	s := OneNoteSection{
+		ID:            "sect-id",
+		Name:          "sect",
+		Notebook:      notebook,
+		SectionGroups: sectionGroups,
+		// backups store pages in page order.
+		Pages: []OneNotePage{
+			{
+				ID:      "first",
+				Title:   "first page",
+				Order:   1,
+				Content: oneNotePageHTML,
+				Resources: []OneNoteResource{
+					{URL: oneNoteImgURL, ContentType: "image/png", Data: []byte("img")},
+					{URL: oneNoteObjURL, ContentType: "application/pdf", Data: []byte("pdf")},
+				},
+			},
+			{
+				ID:      "second",
+				Title:   "second page",
+				Order:   2,
+				Content: []byte("<html><body><p>two</p></body></html>"),
+			},
+		},
+	}
+
+	bs, err := json.Marshal(s)
+	require.NoError(t, err, clues.ToCore(err))
+
+	return bs
+}
+
+func (suite *OneNoteUnitSuite) TestRestoreOneNoteSection() {
+	existing := models.NewOnenoteSection()
+	existing.SetId(ptr.To("existing"))
+
+	table := []struct {
+		name            string
+		notebook        string
+		sectionGroups   []string
+		existing        models.OnenoteSectionable
+		onCollision     control.CollisionPolicy
+		expectErr       assert.ErrorAssertionFunc
+		expectSkip      bool
+		expectRenamed   bool
+		expectContainer string
+	}{
+		{
+			name:            "no collision",
+			notebook:        "nb",
+			onCollision:     control.Skip,
+			expectErr:       assert.NoError,
+			expectContainer: api.OneNoteNotebookContainer("notebook"),
+		},
+		{
+			name:            "section groups",
+			notebook:        "nb",
+			sectionGroups:   []string{"outer", "inner"},
+			onCollision:     control.Skip,
+			expectErr:       assert.NoError,
+			expectContainer: api.OneNoteSectionGroupContainer("inner"),
+		},
+		{
+			name:            "no recorded notebook",
+			sectionGroups:   []string{"outer"},
+			onCollision:     control.Skip,
+			expectErr:       assert.NoError,
+			expectContainer: api.OneNoteNotebookContainer("notebook"),
+		},
+		{
+			name:        "collision skip",
+			existing:    existing,
+			onCollision: control.Skip,
+			expectErr:   assert.Error,
+			expectSkip:  true,
+		},
+		{
+			name:          "collision copy",
+			existing:      existing,
+			onCollision:   control.Copy,
+			expectErr:     assert.NoError,
+			expectRenamed: true,
+		},
+		{
+			name:          "collision replace",
+			existing:      existing,
+			onCollision:   control.Replace,
+			expectErr:     assert.NoError,
+			expectRenamed: true,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				rh  = &mockRestoreHandler{OneNoteSection: test.existing}
+				ctr = count.New()
+			)
+
+			_, written, err := restoreOneNoteSection(
+				ctx,
+				rh,
+				"pr",
+				"notebook",
+				metadata.Metadata{FileName: "sect.one", OneNoteSection: true},
+				bytes.NewReader(oneNoteSectionBytes(t, test.notebook, test.sectionGroups...)),
+				test.onCollision,
+				ctr)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if test.expectSkip {
+				assert.ErrorIs(t, err, core.ErrAlreadyExists, clues.ToCore(err))
+				assert.Empty(t, rh.PostedOneNoteSections)
+				assert.Equal(t, int64(1), ctr.Get(count.CollisionSkip))
+
+				return
+			}
+
+			require.Len(t, rh.PostedOneNoteSections, 1)
+
+			if len(test.expectContainer) > 0 {
+				assert.Equal(t, test.expectContainer, rh.PostedOneNoteContainers[0])
+			}
+
+			if test.expectRenamed {
+				assert.NotEqual(t, "sect", rh.PostedOneNoteSections[0])
+				assert.Contains(t, rh.PostedOneNoteSections[0], "sect ")
+			} else {
+				assert.Equal(t, "sect", rh.PostedOneNoteSections[0])
+			}
+
+			// pages are posted in order, with resources swapped for part names.
+			require.Len(t, rh.PostedOneNotePages, 2)
+			assert.Contains(t, string(rh.PostedOneNotePages[0]), `src="name:resource0"`)
+			assert.Contains(t, string(rh.PostedOneNotePages[0]), `data="name:resource1"`)
+			assert.NotContains(t, string(rh.PostedOneNotePages[0]), oneNoteImgURL)
+			assert.Contains(t, string(rh.PostedOneNotePages[1]), "two")
+
+			require.Len(t, rh.PostedOneNoteRes[0], 2)
+			assert.Equal(t, "resource0", rh.PostedOneNoteRes[0][0].Name)
+			assert.Equal(t, "image/png", rh.PostedOneNoteRes[0][0].ContentType)
+			assert.Empty(t, rh.PostedOneNoteRes[1])
+
+			assert.Positive(t, written)
+			assert.Equal(t, int64(1), ctr.Get(count.NewItemCreated))
+		})
+	}
+}
+
+func (suite *OneNoteUnitSuite) TestExportOneNoteSection() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	rc, err := exportOneNoteSection(ctx, io.NopCloser(bytes.NewReader(oneNoteSectionBytes(t, "nb"))))
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := io.ReadAll(rc)
+	require.NoError(t, err, clues.ToCore(err))
+
+	result := string(bs)
+
+	assert.Contains(t, result, "<title>sect</title>")
+	assert.Less(t, bytes.Index(bs, []byte("first page")), bytes.Index(bs, []byte("second page")))
+	assert.Contains(t, result, `src="data:image/png;base64,aW1n"`)
+	assert.NotContains(t, result, oneNoteImgURL)
+	assert.NotContains(t, result, "<head><title>pg</title>", "page heads should be dropped")
+	assert.Equal(t, "sect.html", oneNoteExportName("sect.one"))
+
+	_, err = exportOneNoteSection(ctx, io.NopCloser(bytes.NewReader([]byte("not json"))))
+	assert.Error(t, err, clues.ToCore(err))
+
+	// damaged pages fail the read of the exported document.
+	full := oneNoteSectionBytes(t, "nb")
+
+	rc, err = exportOneNoteSection(ctx, io.NopCloser(bytes.NewReader(full[:len(full)-20])))
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = io.ReadAll(rc)
+	assert.Error(t, err, clues.ToCore(err))
+}
@@ -302,6 +302,11 @@ func restoreItem(
 		return details.ItemInfo{}, true, nil
 	}
 
+	// versions get restored alongside their data file, when requested.
+	if strings.HasSuffix(itemUUID, metadata.VersionsFileSuffix) {
+		return details.ItemInfo{}, true, nil
+	}
+
 	if strings.HasSuffix(itemUUID, metadata.DirMetaFileSuffix) {
 		// Only the version.OneDrive1DataAndMetaFiles needed to deserialize the
 		// permission for child folders here. Later versions can request
@@ -371,6 +376,10 @@ func restoreItem(
 			return details.ItemInfo{}, true, nil
 		}
 
+		if errors.Is(err, errNoMatchingVersion) {
+			return details.ItemInfo{}, true, nil
+		}
+
 		return details.ItemInfo{}, false, clues.Wrap(err, "v6 restore")
 	}
 
@@ -507,18 +516,54 @@ func restoreV6File(
 		return details.ItemInfo{}, clues.New("item with empty name")
 	}
 
-	itemID, itemInfo, err := restoreFile(
-		ctx,
-		rcc,
-		rh,
-		fibn,
-		meta.FileName,
-		itemData,
-		drivePath.DriveID,
-		restoreFolderID,
-		caches.collisionKeyToItemID,
-		copyBuffer,
-		ctr)
+	// onenote sections get recreated through the onenote api instead of
+	// as drive files.  Drive permissions don't apply to them.
+	if meta.OneNoteSection {
+		return restoreOneNoteFile(ctx, rh, rcc, drivePath, meta, itemData, ctr)
+	}
+
+	var (
+		itemID   string
+		itemInfo details.ItemInfo
+	)
+
+	if len(rcc.RestoreConfig.Version) == 0 {
+		itemID, itemInfo, err = restoreFile(
+			ctx,
+			rcc,
+			rh,
+			fibn,
+			meta.FileName,
+			itemData,
+			drivePath.DriveID,
+			restoreFolderID,
+			caches.collisionKeyToItemID,
+			copyBuffer,
+			ctr)
+	} else {
+		itemID, itemInfo, err = restoreFileVersions(
+			ctx,
+			rcc,
+			rh,
+			fibn,
+			meta.FileName,
+			trimmedName,
+			itemData,
+			drivePath.DriveID,
+			restoreFolderID,
+			caches.collisionKeyToItemID,
+			copyBuffer,
+			ctr)
+		if errors.Is(err, errNoMatchingVersion) {
+			errs.AddSkip(ctx, fault.FileSkip(
+				fault.SkipVersionNotFound,
+				drivePath.DriveID,
+				trimmedName,
+				meta.FileName,
+				map[string]any{"version": rcc.RestoreConfig.Version}))
+		}
+	}
+
 	if err != nil {
 		return details.ItemInfo{}, err
 	}
@@ -786,15 +831,59 @@ func restoreFile(
 		return "", details.ItemInfo{}, err
 	}
 
-	w, uploadURL, err := driveItemWriter(
+	written, err := uploadFileContent(
 		ctx,
 		ir,
+		fibn,
+		name,
+		itemData,
+		ss.Size(),
 		driveID,
 		ptr.Val(newItem.GetId()),
-		ss.Size(),
+		copyBuffer,
+		ctr)
+	if err != nil {
+		return "", details.ItemInfo{}, err
+	}
+
+	dii := ir.AugmentItemInfo(
+		details.ItemInfo{},
+		rcc.ProtectedResource,
+		custom.ToCustomDriveItem(newItem),
+		written,
+		nil)
+
+	if shouldDeleteOriginal {
+		ctr.Inc(count.CollisionReplace)
+	} else {
+		ctr.Inc(count.NewItemCreated)
+	}
+
+	return ptr.Val(newItem.GetId()), dii, nil
+}
+
+// uploadFileContent writes the item data into the drive item, retrying the
+// upload from scratch on failure.
+func uploadFileContent(
+	ctx context.Context,
+	ir NewItemContentUploader,
+	fibn data.FetchItemByNamer,
+	name string,
+	itemData data.Item,
+	size int64,
+	driveID, itemID string,
+	copyBuffer []byte,
+	ctr *count.Bus,
+) (int64, error) {
+	w, uploadURL, err := driveItemWriter(
+		ctx,
+		ir,
+		driveID,
+		itemID,
+		size,
 		ctr)
 	if err != nil {
-		return "", details.ItemInfo{}, clues.Wrap(err, "get item upload session")
+		return 0, clues.Wrap(err, "get item upload session")
 	}
 
 	var (
@@ -820,7 +909,7 @@ func restoreFile(
 			// but we don't have a Seeker available here.
 			itemData, err := fibn.FetchItemByName(ctx, itemData.ID())
 			if err != nil {
-				return "", details.ItemInfo{}, clues.Wrap(err, "get data file")
+				return 0, clues.Wrap(err, "get data file")
 			}
 
 			iReader = itemData.ToReader()
@@ -831,7 +920,7 @@ func restoreFile(
 			iReader,
 			observe.ItemRestoreMsg,
 			clues.Hide(pname),
-			ss.Size())
+			size)
 		defer progressReader.Close()
 
 		// Upload the stream data
@@ -847,30 +936,17 @@ func restoreFile(
 		// refresh the io.Writer to restart the upload
 		// TODO: @vkamra verify if var session is the desired input
 		w = graph.NewLargeItemWriter(
-			ptr.Val(newItem.GetId()),
+			itemID,
 			uploadURL,
-			ss.Size(),
+			size,
 			ctr)
 	}
 
 	if err != nil {
-		return "", details.ItemInfo{}, clues.Wrap(err, "uploading file")
-	}
-
-	dii := ir.AugmentItemInfo(
-		details.ItemInfo{},
-		rcc.ProtectedResource,
-		custom.ToCustomDriveItem(newItem),
-		written,
-		nil)
-
-	if shouldDeleteOriginal {
-		ctr.Inc(count.CollisionReplace)
-	} else {
-		ctr.Inc(count.NewItemCreated)
+		return 0, clues.Wrap(err, "uploading file")
 	}
 
-	return ptr.Val(newItem.GetId()), dii, nil
+	return written, nil
 }
 
 func FetchAndReadMetadata(
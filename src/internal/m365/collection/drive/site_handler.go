@@ -168,6 +168,13 @@ func (h siteBackupHandler) GetItem(
 	return h.ac.GetItem(ctx, driveID, itemID)
 }
 
+func (h siteBackupHandler) GetItemVersions(
+	ctx context.Context,
+	driveID, itemID string,
+) ([]models.DriveItemVersionable, error) {
+	return h.ac.GetItemVersions(ctx, driveID, itemID)
+}
+
 func (h siteBackupHandler) IsAllPass() bool {
 	return h.scope.IsAny(selectors.SharePointLibraryFolder)
 }
@@ -191,6 +198,41 @@ func (h siteBackupHandler) GetRootFolder(
 	return h.ac.Drives().GetRootFolder(ctx, driveID)
 }
 
+func (h siteBackupHandler) GetOneNoteSection(
+	ctx context.Context,
+	sectionID string,
+) (models.OnenoteSectionable, error) {
+	return h.ac.OneNote().GetSection(ctx, api.SiteOneNoteOwner(h.siteID), sectionID)
+}
+
+func (h siteBackupHandler) GetOneNoteSectionGroup(
+	ctx context.Context,
+	sectionGroupID string,
+) (models.SectionGroupable, error) {
+	return h.ac.OneNote().GetSectionGroup(ctx, api.SiteOneNoteOwner(h.siteID), sectionGroupID)
+}
+
+func (h siteBackupHandler) GetOneNoteSectionPages(
+	ctx context.Context,
+	sectionID string,
+) ([]models.OnenotePageable, error) {
+	return h.ac.OneNote().GetSectionPages(ctx, api.SiteOneNoteOwner(h.siteID), sectionID)
+}
+
+func (h siteBackupHandler) GetOneNotePageContent(
+	ctx context.Context,
+	pageID string,
+) ([]byte, error) {
+	return h.ac.OneNote().GetPageContent(ctx, api.SiteOneNoteOwner(h.siteID), pageID)
+}
+
+func (h siteBackupHandler) GetOneNoteResourceContent(
+	ctx context.Context,
+	resourceURL string,
+) ([]byte, error) {
+	return h.ac.OneNote().GetResourceContent(ctx, resourceURL)
+}
+
 // ---------------------------------------------------------------------------
 // Restore
 // ---------------------------------------------------------------------------
@@ -291,3 +333,57 @@ func (h siteRestoreHandler) GetRootFolder(
 ) (models.DriveItemable, error) {
 	return h.ac.Drives().GetRootFolder(ctx, driveID)
 }
+
+func (h siteRestoreHandler) GetOneNoteSectionByName(
+	ctx context.Context,
+	protectedResourceID, container, sectionName string,
+) (models.OnenoteSectionable, error) {
+	return h.ac.OneNote().GetSectionByName(
+		ctx,
+		api.SiteOneNoteOwner(protectedResourceID),
+		container,
+		sectionName)
+}
+
+func (h siteRestoreHandler) GetOrPostOneNoteNotebook(
+	ctx context.Context,
+	protectedResourceID, notebookName string,
+) (models.Notebookable, error) {
+	return h.ac.OneNote().GetOrPostNotebook(ctx, api.SiteOneNoteOwner(protectedResourceID), notebookName)
+}
+
+func (h siteRestoreHandler) GetOrPostOneNoteSectionGroup(
+	ctx context.Context,
+	protectedResourceID, container, sectionGroupName string,
+) (models.SectionGroupable, error) {
+	return h.ac.OneNote().GetOrPostSectionGroup(
+		ctx,
+		api.SiteOneNoteOwner(protectedResourceID),
+		container,
+		sectionGroupName)
+}
+
+func (h siteRestoreHandler) PostOneNoteSection(
+	ctx context.Context,
+	protectedResourceID, container, sectionName string,
+) (models.OnenoteSectionable, error) {
+	return h.ac.OneNote().PostSection(
+		ctx,
+		api.SiteOneNoteOwner(protectedResourceID),
+		container,
+		sectionName)
+}
+
+func (h siteRestoreHandler) PostOneNotePage(
+	ctx context.Context,
+	protectedResourceID, sectionID string,
+	content []byte,
+	resources []api.OneNotePageResource,
+) error {
+	return h.ac.OneNote().PostPage(
+		ctx,
+		api.SiteOneNoteOwner(protectedResourceID),
+		sectionID,
+		content,
+		resources)
+}
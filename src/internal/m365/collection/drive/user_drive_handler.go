@@ -173,6 +173,13 @@ func (h userDriveBackupHandler) GetItem(
 	return h.ac.GetItem(ctx, driveID, itemID)
 }
 
+func (h userDriveBackupHandler) GetItemVersions(
+	ctx context.Context,
+	driveID, itemID string,
+) ([]models.DriveItemVersionable, error) {
+	return h.ac.GetItemVersions(ctx, driveID, itemID)
+}
+
 func (h userDriveBackupHandler) IsAllPass() bool {
 	return h.scope.IsAny(selectors.OneDriveFolder)
 }
@@ -196,6 +203,41 @@ func (h userDriveBackupHandler) GetRootFolder(
 	return h.ac.Drives().GetRootFolder(ctx, driveID)
 }
 
+func (h userDriveBackupHandler) GetOneNoteSection(
+	ctx context.Context,
+	sectionID string,
+) (models.OnenoteSectionable, error) {
+	return h.ac.OneNote().GetSection(ctx, api.UserOneNoteOwner(h.userID), sectionID)
+}
+
+func (h userDriveBackupHandler) GetOneNoteSectionGroup(
+	ctx context.Context,
+	sectionGroupID string,
+) (models.SectionGroupable, error) {
+	return h.ac.OneNote().GetSectionGroup(ctx, api.UserOneNoteOwner(h.userID), sectionGroupID)
+}
+
+func (h userDriveBackupHandler) GetOneNoteSectionPages(
+	ctx context.Context,
+	sectionID string,
+) ([]models.OnenotePageable, error) {
+	return h.ac.OneNote().GetSectionPages(ctx, api.UserOneNoteOwner(h.userID), sectionID)
+}
+
+func (h userDriveBackupHandler) GetOneNotePageContent(
+	ctx context.Context,
+	pageID string,
+) ([]byte, error) {
+	return h.ac.OneNote().GetPageContent(ctx, api.UserOneNoteOwner(h.userID), pageID)
+}
+
+func (h userDriveBackupHandler) GetOneNoteResourceContent(
+	ctx context.Context,
+	resourceURL string,
+) ([]byte, error) {
+	return h.ac.OneNote().GetResourceContent(ctx, resourceURL)
+}
+
 // ---------------------------------------------------------------------------
 // Restore
 // ---------------------------------------------------------------------------
@@ -292,3 +334,57 @@ func (h userDriveRestoreHandler) GetRootFolder(
 ) (models.DriveItemable, error) {
 	return h.ac.GetRootFolder(ctx, driveID)
 }
+
+func (h userDriveRestoreHandler) GetOneNoteSectionByName(
+	ctx context.Context,
+	protectedResourceID, container, sectionName string,
+) (models.OnenoteSectionable, error) {
+	return h.ac.OneNote().GetSectionByName(
+		ctx,
+		api.UserOneNoteOwner(protectedResourceID),
+		container,
+		sectionName)
+}
+
+func (h userDriveRestoreHandler) GetOrPostOneNoteNotebook(
+	ctx context.Context,
+	protectedResourceID, notebookName string,
+) (models.Notebookable, error) {
+	return h.ac.OneNote().GetOrPostNotebook(ctx, api.UserOneNoteOwner(protectedResourceID), notebookName)
+}
+
+func (h userDriveRestoreHandler) GetOrPostOneNoteSectionGroup(
+	ctx context.Context,
+	protectedResourceID, container, sectionGroupName string,
+) (models.SectionGroupable, error) {
+	return h.ac.OneNote().GetOrPostSectionGroup(
+		ctx,
+		api.UserOneNoteOwner(protectedResourceID),
+		container,
+		sectionGroupName)
+}
+
+func (h userDriveRestoreHandler) PostOneNoteSection(
+	ctx context.Context,
+	protectedResourceID, container, sectionName string,
+) (models.OnenoteSectionable, error) {
+	return h.ac.OneNote().PostSection(
+		ctx,
+		api.UserOneNoteOwner(protectedResourceID),
+		container,
+		sectionName)
+}
+
+func (h userDriveRestoreHandler) PostOneNotePage(
+	ctx context.Context,
+	protectedResourceID, sectionID string,
+	content []byte,
+	resources []api.OneNotePageResource,
+) error {
+	return h.ac.OneNote().PostPage(
+		ctx,
+		api.UserOneNoteOwner(protectedResourceID),
+		sectionID,
+		content,
+		resources)
+}
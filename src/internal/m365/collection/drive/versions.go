@@ -0,0 +1,798 @@
+package drive
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/spatialcurrent/go-lazy/pkg/lazy"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph/metadata"
+)
+
+// errNoMatchingVersion is returned when a restore or export asks for a file
+// version that wasn't backed up.
+var errNoMatchingVersion = clues.New("no matching file version")
+
+// driveVersion describes a prior version of a drive file.  Within an item's
+// .versions file, each version is stored as a single line of json holding
+// this header, followed by the version content as a series of chunks.
+// Each chunk is prefixed by its length as a big-endian uint32, and the
+// content ends with an empty chunk.  Graph doesn't always report the size
+// of the content it serves, so the header's Size is only the reported
+// size; the chunks determine the real size.
+type driveVersion struct {
+	ID         string    `json:"id"`
+	Modified   time.Time `json:"modified"`
+	ModifiedBy string    `json:"modifiedBy,omitempty"`
+	Size       int64     `json:"size"`
+}
+
+func (dv driveVersion) toDetails() details.DriveItemVersion {
+	return details.DriveItemVersion{
+		ID:         dv.ID,
+		Modified:   dv.Modified,
+		ModifiedBy: dv.ModifiedBy,
+		Size:       dv.Size,
+	}
+}
+
+// filterDriveVersions drops the current version out of the version history,
+// along with any prior versions excluded by the config.  The remaining
+// versions are returned oldest first, which is the order in which they get
+// stored and restored.
+func filterDriveVersions(
+	versions []models.DriveItemVersionable,
+	cfg control.DriveVersionsConfig,
+	now time.Time,
+) []driveVersion {
+	sorted := slices.Clone(versions)
+
+	// graph lists versions newest first; sort anyways so that we don't mistake
+	// a prior version for the current one.
+	slices.SortStableFunc(sorted, func(a, b models.DriveItemVersionable) int {
+		return ptr.Val(b.GetLastModifiedDateTime()).Compare(ptr.Val(a.GetLastModifiedDateTime()))
+	})
+
+	if len(sorted) < 2 {
+		return nil
+	}
+
+	result := []driveVersion{}
+
+	for _, v := range sorted[1:] {
+		if cfg.MaxCount > 0 && len(result) >= cfg.MaxCount {
+			break
+		}
+
+		modified := ptr.Val(v.GetLastModifiedDateTime())
+
+		if cfg.MaxAge > 0 && now.Sub(modified) > cfg.MaxAge {
+			continue
+		}
+
+		result = append(result, driveVersion{
+			ID:         ptr.Val(v.GetId()),
+			Modified:   modified,
+			ModifiedBy: getVersionModifier(v),
+			Size:       ptr.Val(v.GetSize()),
+		})
+	}
+
+	slices.Reverse(result)
+
+	return result
+}
+
+func getVersionModifier(v models.DriveItemVersionable) string {
+	if v.GetLastModifiedBy() == nil || v.GetLastModifiedBy().GetUser() == nil {
+		return ""
+	}
+
+	user := v.GetLastModifiedBy().GetUser()
+
+	if email, err := str.AnyToString(user.GetAdditionalData()["email"]); err == nil {
+		return email
+	}
+
+	return ptr.Val(user.GetDisplayName())
+}
+
+// withItemVersions adds the backed up versions to the drive item's details.
+func withItemVersions(info details.ItemInfo, versions []driveVersion) details.ItemInfo {
+	dvs := make([]details.DriveItemVersion, 0, len(versions))
+
+	for _, v := range versions {
+		dvs = append(dvs, v.toDetails())
+	}
+
+	switch {
+	case info.OneDrive != nil:
+		info.OneDrive.Versions = dvs
+	case info.SharePoint != nil:
+		info.SharePoint.Versions = dvs
+	case info.Groups != nil:
+		info.Groups.Versions = dvs
+	}
+
+	return info
+}
+
+// ---------------------------------------------------------------------------
+// backup
+// ---------------------------------------------------------------------------
+
+func driveItemVersionURL(driveID, itemID, versionID string) string {
+	return "https://graph.microsoft.com/v1.0/drives/" + driveID +
+		"/items/" + itemID +
+		"/versions/" + versionID + "/content"
+}
+
+// writeDriveVersions downloads the content of each version and writes it,
+// preceded by its header, to w.
+func writeDriveVersions(
+	ctx context.Context,
+	w io.Writer,
+	getter api.Getter,
+	driveID, itemID string,
+	versions []driveVersion,
+) error {
+	var (
+		enc = json.NewEncoder(w)
+		buf = make([]byte, versionChunkSize)
+	)
+
+	for _, v := range versions {
+		ictx := clues.Add(ctx, "version_id", v.ID, "version_size", v.Size)
+
+		if err := enc.Encode(v); err != nil {
+			return clues.WrapWC(ictx, err, "writing version header")
+		}
+
+		rc, err := downloadFile(ictx, getter, driveItemVersionURL(driveID, itemID, v.ID), true)
+		if err != nil {
+			return clues.WrapWC(ictx, err, "downloading version content")
+		}
+
+		_, err = writeVersionChunks(w, rc, buf)
+		rc.Close()
+
+		if err != nil {
+			return clues.WrapWC(ictx, err, "writing version content")
+		}
+	}
+
+	return nil
+}
+
+const versionChunkSize = 64 * 1024
+
+// writeVersionChunks copies r to w as length-prefixed chunks, followed by
+// the terminating empty chunk.  Returns the number of content bytes written.
+func writeVersionChunks(w io.Writer, r io.Reader, buf []byte) (int64, error) {
+	var (
+		written int64
+		prefix  = make([]byte, 4)
+	)
+
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(prefix, uint32(n))
+
+			if _, werr := w.Write(prefix); werr != nil {
+				return written, clues.Stack(werr)
+			}
+
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return written, clues.Stack(werr)
+			}
+
+			written += int64(n)
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+
+		if err != nil {
+			return written, clues.Stack(err)
+		}
+	}
+
+	binary.BigEndian.PutUint32(prefix, 0)
+	_, err := w.Write(prefix)
+
+	return written, clues.Stack(err).OrNil()
+}
+
+// versionChunkReader reads the content of a single version out of its
+// length-prefixed chunks.  It produces io.EOF at the terminating chunk.
+type versionChunkReader struct {
+	r         *bufio.Reader
+	remaining uint32
+	done      bool
+}
+
+func (cr *versionChunkReader) Read(p []byte) (int, error) {
+	if cr.done {
+		return 0, io.EOF
+	}
+
+	if cr.remaining == 0 {
+		prefix := make([]byte, 4)
+
+		if _, err := io.ReadFull(cr.r, prefix); err != nil {
+			return 0, clues.Wrap(err, "reading version chunk length")
+		}
+
+		cr.remaining = binary.BigEndian.Uint32(prefix)
+
+		if cr.remaining == 0 {
+			cr.done = true
+			return 0, io.EOF
+		}
+	}
+
+	if uint32(len(p)) > cr.remaining {
+		p = p[:cr.remaining]
+	}
+
+	n, err := cr.r.Read(p)
+	cr.remaining -= uint32(n)
+
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return n, err
+}
+
+// lazyVersionsGetter streams the versions of a drive item into the item's
+// .versions file.  Versions are only downloaded once the file gets read.
+type lazyVersionsGetter struct {
+	getter   api.Getter
+	driveID  string
+	itemID   string
+	versions []driveVersion
+	counter  *count.Bus
+}
+
+func (lvg *lazyVersionsGetter) GetData(
+	ctx context.Context,
+	_ *fault.Bus,
+) (io.ReadCloser, *details.ItemInfo, bool, error) {
+	pr, pw := io.Pipe()
+
+	go func() {
+		err := writeDriveVersions(ctx, pw, lvg.getter, lvg.driveID, lvg.itemID, lvg.versions)
+		if err == nil {
+			lvg.counter.Add(count.DriveItemVersions, int64(len(lvg.versions)))
+		}
+
+		pw.CloseWithError(err)
+	}()
+
+	return pr, nil, false, nil
+}
+
+// ---------------------------------------------------------------------------
+// restore and export
+// ---------------------------------------------------------------------------
+
+// driveVersionsReader walks the versions stored in a .versions file.
+type driveVersionsReader struct {
+	br   *bufio.Reader
+	curr io.Reader
+}
+
+func newDriveVersionsReader(r io.Reader) *driveVersionsReader {
+	return &driveVersionsReader{br: bufio.NewReader(r)}
+}
+
+// Next advances to the following version, discarding any unread content of
+// the current one.  Returns io.EOF once all versions have been read.
+func (vr *driveVersionsReader) Next() (driveVersion, io.Reader, error) {
+	var v driveVersion
+
+	if vr.curr != nil {
+		if _, err := io.Copy(io.Discard, vr.curr); err != nil {
+			return v, nil, clues.Wrap(err, "skipping version content")
+		}
+	}
+
+	line, err := vr.br.ReadBytes('\n')
+	if errors.Is(err, io.EOF) && len(line) == 0 {
+		return v, nil, io.EOF
+	}
+
+	if err != nil {
+		return v, nil, clues.Wrap(err, "reading version header")
+	}
+
+	if err := json.Unmarshal(line, &v); err != nil {
+		return v, nil, clues.Wrap(err, "deserializing version header")
+	}
+
+	vr.curr = &versionChunkReader{r: vr.br}
+
+	return v, vr.curr, nil
+}
+
+// listDriveVersions produces the headers of all versions in the named
+// .versions file, with each Size set to the length of the stored content.
+// Items backed up without versions produce no results.
+func listDriveVersions(
+	ctx context.Context,
+	fibn data.FetchItemByNamer,
+	versionsName string,
+) ([]driveVersion, error) {
+	item, err := fibn.FetchItemByName(ctx, versionsName)
+	if errors.Is(err, data.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "getting versions file")
+	}
+
+	rc := item.ToReader()
+	defer rc.Close()
+
+	var (
+		vr     = newDriveVersionsReader(rc)
+		result = []driveVersion{}
+	)
+
+	for {
+		v, content, err := vr.Next()
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+
+		if err != nil {
+			return nil, clues.StackWC(ctx, err)
+		}
+
+		v.Size, err = io.Copy(io.Discard, content)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "reading version content")
+		}
+
+		result = append(result, v)
+	}
+}
+
+// driveVersionsStream hands out the content of the versions in a .versions
+// file.  Versions are read in the order they're stored, so handing out
+// each version in turn reads the file only once.  Asking for a version
+// that's already been passed, such as when retrying a failed upload,
+// reopens the file.
+type driveVersionsStream struct {
+	ctx          context.Context
+	fibn         data.FetchItemByNamer
+	versionsName string
+	// ids holds the stored version IDs, in order.
+	ids []string
+
+	mu sync.Mutex
+	rc io.ReadCloser
+	vr *driveVersionsReader
+	// next is the index of the version that vr produces next.
+	next int
+}
+
+func newDriveVersionsStream(
+	ctx context.Context,
+	fibn data.FetchItemByNamer,
+	versionsName string,
+	versions []driveVersion,
+) *driveVersionsStream {
+	ids := make([]string, 0, len(versions))
+
+	for _, v := range versions {
+		ids = append(ids, v.ID)
+	}
+
+	return &driveVersionsStream{
+		ctx:          ctx,
+		fibn:         fibn,
+		versionsName: versionsName,
+		ids:          ids,
+	}
+}
+
+// open produces a reader over the content of the version.  The reader is
+// only valid until the next call to open.
+func (s *driveVersionsStream) open(versionID string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := clues.Add(s.ctx, "version_id", versionID)
+
+	idx := slices.Index(s.ids, versionID)
+	if idx < 0 {
+		return nil, clues.StackWC(ctx, errNoMatchingVersion)
+	}
+
+	if s.vr == nil || idx < s.next {
+		if err := s.reopen(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		v, content, err := s.vr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, clues.StackWC(ctx, errNoMatchingVersion)
+		}
+
+		if err != nil {
+			return nil, clues.StackWC(ctx, err)
+		}
+
+		s.next++
+
+		if v.ID == versionID {
+			return io.NopCloser(content), nil
+		}
+	}
+}
+
+func (s *driveVersionsStream) reopen(ctx context.Context) error {
+	s.closeFile()
+
+	item, err := s.fibn.FetchItemByName(ctx, s.versionsName)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "getting versions file")
+	}
+
+	s.rc = item.ToReader()
+	s.vr = newDriveVersionsReader(s.rc)
+	s.next = 0
+
+	return nil
+}
+
+func (s *driveVersionsStream) closeFile() {
+	if s.rc != nil {
+		s.rc.Close()
+	}
+
+	s.rc = nil
+	s.vr = nil
+}
+
+func (s *driveVersionsStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closeFile()
+
+	return nil
+}
+
+// lastReadCloser closes the versions stream along with the content of the
+// last version handed out of it.
+type lastReadCloser struct {
+	io.ReadCloser
+	stream *driveVersionsStream
+}
+
+func (lrc lastReadCloser) Close() error {
+	lrc.ReadCloser.Close()
+	return lrc.stream.Close()
+}
+
+var (
+	_ data.Item             = &driveVersionItem{}
+	_ data.ItemSize         = &driveVersionItem{}
+	_ data.FetchItemByNamer = &driveVersionItem{}
+)
+
+// driveVersionItem presents a single backed up version of a drive file as a
+// data.Item, so that it can be restored like any other file content.
+type driveVersionItem struct {
+	stream  *driveVersionsStream
+	id      string
+	version driveVersion
+	// last marks the final version read out of the stream.
+	last bool
+}
+
+func newDriveVersionItem(
+	stream *driveVersionsStream,
+	itemID string,
+	version driveVersion,
+	last bool,
+) *driveVersionItem {
+	return &driveVersionItem{
+		stream:  stream,
+		id:      itemID + versionIDSuffix(version.ID),
+		version: version,
+		last:    last,
+	}
+}
+
+// newDriveVersionItems produces an item for each of the versions, sharing
+// a single stream over the .versions file.
+func newDriveVersionItems(
+	ctx context.Context,
+	fibn data.FetchItemByNamer,
+	itemID string,
+	stored, selected []driveVersion,
+) []*driveVersionItem {
+	var (
+		stream = newDriveVersionsStream(ctx, fibn, itemID+metadata.VersionsFileSuffix, stored)
+		items  = make([]*driveVersionItem, 0, len(selected))
+	)
+
+	for i, v := range selected {
+		items = append(items, newDriveVersionItem(stream, itemID, v, i == len(selected)-1))
+	}
+
+	return items
+}
+
+func (i driveVersionItem) ID() string {
+	return i.id
+}
+
+func (i driveVersionItem) ToReader() io.ReadCloser {
+	return lazy.NewLazyReadCloser(func() (io.ReadCloser, error) {
+		rc, err := i.stream.open(i.version.ID)
+		if err != nil || !i.last {
+			return rc, err
+		}
+
+		return lastReadCloser{ReadCloser: rc, stream: i.stream}, nil
+	})
+}
+
+func (i driveVersionItem) Deleted() bool {
+	return false
+}
+
+func (i driveVersionItem) Size() int64 {
+	return i.version.Size
+}
+
+// FetchItemByName always produces the version itself.  This allows file
+// restores to re-read the version content when retrying failed uploads.
+func (i *driveVersionItem) FetchItemByName(context.Context, string) (data.Item, error) {
+	return i, nil
+}
+
+func versionIDSuffix(versionID string) string {
+	return "@" + versionID
+}
+
+// versionedFileName appends the version ID to a file name, ahead of the
+// file extension: "report.docx" -> "report (version 1.0).docx".
+func versionedFileName(name, versionID string) string {
+	ext := filepath.Ext(name)
+	return fmt.Sprintf("%s (version %s)%s", strings.TrimSuffix(name, ext), versionID, ext)
+}
+
+// selectDriveVersions picks the versions, out of all backed up versions of
+// a file, that match the requested version.
+func selectDriveVersions(versions []driveVersion, version string) []driveVersion {
+	if version == control.AllVersions {
+		return versions
+	}
+
+	for _, v := range versions {
+		if v.ID == version {
+			return []driveVersion{v}
+		}
+	}
+
+	return nil
+}
+
+type versionUpload struct {
+	item data.Item
+	fibn data.FetchItemByNamer
+}
+
+// restoreFileVersions restores the requested backed up version of the file
+// in place of its current content.  When restoring all versions, each prior
+// version gets uploaded in turn, oldest first, followed by the current
+// content.  That rebuilds the file's version history within the drive.
+func restoreFileVersions(
+	ctx context.Context,
+	rcc inject.RestoreConsumerConfig,
+	ir itemRestorer,
+	fibn data.FetchItemByNamer,
+	name, itemID string,
+	itemData data.Item,
+	driveID, parentFolderID string,
+	collisionKeyToItemID map[string]api.DriveItemIDType,
+	copyBuffer []byte,
+	ctr *count.Bus,
+) (string, details.ItemInfo, error) {
+	ctx = clues.Add(ctx, "restore_version", rcc.RestoreConfig.Version)
+
+	versions, err := listDriveVersions(ctx, fibn, itemID+metadata.VersionsFileSuffix)
+	if err != nil {
+		return "", details.ItemInfo{}, err
+	}
+
+	var (
+		selected = selectDriveVersions(versions, rcc.RestoreConfig.Version)
+		vis      = newDriveVersionItems(ctx, fibn, itemID, versions, selected)
+		uploads  = []versionUpload{}
+	)
+
+	if len(vis) > 0 {
+		defer vis[0].stream.Close()
+	}
+
+	for _, vi := range vis {
+		uploads = append(uploads, versionUpload{item: vi, fibn: vi})
+	}
+
+	if rcc.RestoreConfig.Version == control.AllVersions {
+		uploads = append(uploads, versionUpload{item: itemData, fibn: fibn})
+	}
+
+	if len(uploads) == 0 {
+		ctr.Inc(count.VersionNotFoundSkip)
+		return "", details.ItemInfo{}, clues.StackWC(ctx, errNoMatchingVersion)
+	}
+
+	// the first upload creates the file, and handles any collisions.
+	newItemID, info, err := restoreFile(
+		ctx,
+		rcc,
+		ir,
+		uploads[0].fibn,
+		name,
+		uploads[0].item,
+		driveID,
+		parentFolderID,
+		collisionKeyToItemID,
+		copyBuffer,
+		ctr)
+	if err != nil {
+		return "", details.ItemInfo{}, err
+	}
+
+	// every following upload adds a new version to the same file.
+	for _, u := range uploads[1:] {
+		ss, ok := u.item.(data.ItemSize)
+		if !ok {
+			return "", details.ItemInfo{}, clues.NewWC(ctx, "item does not implement DataStreamInfo")
+		}
+
+		written, err := uploadFileContent(
+			ctx,
+			ir,
+			u.fibn,
+			name,
+			u.item,
+			ss.Size(),
+			driveID,
+			newItemID,
+			copyBuffer,
+			ctr)
+		if err != nil {
+			return "", details.ItemInfo{}, clues.Wrap(err, "restoring file version")
+		}
+
+		info = withItemSize(info, written)
+	}
+
+	return newItemID, info, nil
+}
+
+// withItemSize replaces the size recorded in the drive item's details.
+func withItemSize(info details.ItemInfo, size int64) details.ItemInfo {
+	switch {
+	case info.OneDrive != nil:
+		info.OneDrive.Size = size
+	case info.SharePoint != nil:
+		info.SharePoint.Size = size
+	case info.Groups != nil:
+		info.Groups.Size = size
+	}
+
+	return info
+}
+
+// exportFileVersions sends the requested backed up versions of the file into
+// the export stream.  Exporting all versions produces the current content
+// under the file's own name, plus each prior version under a name that
+// includes its version ID.  Files without a matching version are reported
+// as skipped.
+func exportFileVersions(
+	ctx context.Context,
+	fibn data.FetchItemByNamer,
+	driveID, itemUUID, name string,
+	item data.Item,
+	version string,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	itemID := strings.TrimSuffix(itemUUID, metadata.DataFileSuffix)
+
+	versions, err := listDriveVersions(ctx, fibn, itemID+metadata.VersionsFileSuffix)
+	if err != nil {
+		ch <- export.Item{
+			ID:    itemUUID,
+			Error: err,
+		}
+
+		return
+	}
+
+	var (
+		all      = version == control.AllVersions
+		selected = selectDriveVersions(versions, version)
+	)
+
+	if len(selected) == 0 && !all {
+		ch <- export.Item{
+			ID: itemUUID,
+			Skip: fault.FileSkip(
+				fault.SkipVersionNotFound,
+				driveID,
+				itemID,
+				name,
+				map[string]any{"version": version}),
+		}
+
+		return
+	}
+
+	// exports write items in the order they're produced, so the versions
+	// get read out of a single pass over the .versions file.
+	for _, vi := range newDriveVersionItems(ctx, fibn, itemID, versions, selected) {
+		vName := name
+
+		if all {
+			vName = versionedFileName(name, vi.version.ID)
+		}
+
+		stats.UpdateResourceCount(path.FilesCategory)
+
+		ch <- export.Item{
+			ID:   vi.ID(),
+			Name: vName,
+			Body: metrics.ReaderWithStats(vi.ToReader(), path.FilesCategory, stats),
+		}
+	}
+
+	if all {
+		stats.UpdateResourceCount(path.FilesCategory)
+
+		ch <- export.Item{
+			ID:   itemUUID,
+			Name: name,
+			Body: metrics.ReaderWithStats(item.ToReader(), path.FilesCategory, stats),
+		}
+	}
+}
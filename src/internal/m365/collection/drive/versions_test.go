@@ -0,0 +1,543 @@
+package drive
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph/metadata"
+)
+
+type VersionsUnitSuite struct {
+	tester.Suite
+}
+
+func TestVersionsUnitSuite(t *testing.T) {
+	suite.Run(t, &VersionsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func driveItemVersion(id string, modified time.Time, size int64) models.DriveItemVersionable {
+	v := models.NewDriveItemVersion()
+	v.SetId(ptr.To(id))
+	v.SetLastModifiedDateTime(ptr.To(modified))
+	v.SetSize(ptr.To(size))
+
+	return v
+}
+
+// fetchesNamedBytes produces a fresh item for each fetch, so that the same
+// file can be read more than once.
+type fetchesNamedBytes map[string][]byte
+
+func (f fetchesNamedBytes) FetchItemByName(_ context.Context, name string) (data.Item, error) {
+	bs, ok := f[name]
+	if !ok {
+		return nil, data.ErrNotFound
+	}
+
+	return &dataMock.Item{
+		ItemID:   name,
+		ItemSize: int64(len(bs)),
+		Reader:   io.NopCloser(bytes.NewReader(bs)),
+	}, nil
+}
+
+func (suite *VersionsUnitSuite) TestFilterDriveVersions() {
+	var (
+		now     = time.Now()
+		current = driveItemVersion("4.0", now.Add(-time.Hour), 4)
+		v3      = driveItemVersion("3.0", now.Add(-24*time.Hour), 3)
+		v2      = driveItemVersion("2.0", now.Add(-48*time.Hour), 2)
+		v1      = driveItemVersion("1.0", now.Add(-96*time.Hour), 1)
+		all     = []models.DriveItemVersionable{current, v3, v2, v1}
+	)
+
+	table := []struct {
+		name     string
+		versions []models.DriveItemVersionable
+		cfg      control.DriveVersionsConfig
+		expect   []string
+	}{
+		{
+			name:   "no versions",
+			cfg:    control.DriveVersionsConfig{Enabled: true},
+			expect: nil,
+		},
+		{
+			name:     "only the current version",
+			versions: []models.DriveItemVersionable{current},
+			cfg:      control.DriveVersionsConfig{Enabled: true},
+			expect:   nil,
+		},
+		{
+			name:     "all prior versions, oldest first",
+			versions: all,
+			cfg:      control.DriveVersionsConfig{Enabled: true},
+			expect:   []string{"1.0", "2.0", "3.0"},
+		},
+		{
+			name:     "unsorted versions",
+			versions: []models.DriveItemVersionable{v2, current, v1, v3},
+			cfg:      control.DriveVersionsConfig{Enabled: true},
+			expect:   []string{"1.0", "2.0", "3.0"},
+		},
+		{
+			name:     "max count keeps the most recent",
+			versions: all,
+			cfg:      control.DriveVersionsConfig{Enabled: true, MaxCount: 2},
+			expect:   []string{"2.0", "3.0"},
+		},
+		{
+			name:     "max age",
+			versions: all,
+			cfg:      control.DriveVersionsConfig{Enabled: true, MaxAge: 72 * time.Hour},
+			expect:   []string{"2.0", "3.0"},
+		},
+		{
+			name:     "max age and count",
+			versions: all,
+			cfg:      control.DriveVersionsConfig{Enabled: true, MaxAge: 72 * time.Hour, MaxCount: 1},
+			expect:   []string{"3.0"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result := filterDriveVersions(test.versions, test.cfg, now)
+
+			ids := []string{}
+			for _, v := range result {
+				ids = append(ids, v.ID)
+			}
+
+			if len(test.expect) == 0 {
+				assert.Empty(t, ids)
+				return
+			}
+
+			assert.Equal(t, test.expect, ids)
+		})
+	}
+}
+
+func (suite *VersionsUnitSuite) TestWithItemVersions() {
+	versions := []driveVersion{{ID: "1.0", Size: 1}}
+
+	table := []struct {
+		name   string
+		info   details.ItemInfo
+		expect func(*testing.T, details.ItemInfo)
+	}{
+		{
+			name: "onedrive",
+			info: details.ItemInfo{OneDrive: &details.OneDriveInfo{}},
+			expect: func(t *testing.T, info details.ItemInfo) {
+				assert.Len(t, info.OneDrive.Versions, 1)
+			},
+		},
+		{
+			name: "sharepoint",
+			info: details.ItemInfo{SharePoint: &details.SharePointInfo{}},
+			expect: func(t *testing.T, info details.ItemInfo) {
+				assert.Len(t, info.SharePoint.Versions, 1)
+			},
+		},
+		{
+			name: "groups",
+			info: details.ItemInfo{Groups: &details.GroupsInfo{}},
+			expect: func(t *testing.T, info details.ItemInfo) {
+				assert.Len(t, info.Groups.Versions, 1)
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), withItemVersions(test.info, versions))
+		})
+	}
+}
+
+func writeTestVersions(
+	ctx context.Context, //revive:disable-line:context-as-argument
+	versions []driveVersion,
+	contents ...string,
+) ([]byte, error) {
+	mbh := defaultOneDriveBH("user")
+	mbh.GetResps = nil
+	mbh.GetErrs = nil
+
+	for _, c := range contents {
+		mbh.GetResps = append(mbh.GetResps, &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(c))),
+		})
+		mbh.GetErrs = append(mbh.GetErrs, nil)
+	}
+
+	buf := &bytes.Buffer{}
+	err := writeDriveVersions(ctx, buf, mbh, "drive", "item", versions)
+
+	return buf.Bytes(), err
+}
+
+func (suite *VersionsUnitSuite) TestDriveVersions_writeAndRead() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	versions := []driveVersion{
+		{ID: "1.0", Size: 3, ModifiedBy: "a@b.c"},
+		{ID: "2.0", Size: 6},
+	}
+
+	bs, err := writeTestVersions(ctx, versions, "one", "second")
+	require.NoError(t, err, clues.ToCore(err))
+
+	fibn := fetchesNamedBytes{"item" + metadata.VersionsFileSuffix: bs}
+
+	listed, err := listDriveVersions(ctx, fibn, "item"+metadata.VersionsFileSuffix)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, listed, 2)
+	assert.Equal(t, "1.0", listed[0].ID)
+	assert.Equal(t, "a@b.c", listed[0].ModifiedBy)
+	assert.Equal(t, int64(3), listed[0].Size)
+	assert.Equal(t, "2.0", listed[1].ID)
+	assert.Equal(t, int64(6), listed[1].Size)
+
+	for i, vi := range newDriveVersionItems(ctx, fibn, "item", listed, listed) {
+		assert.Equal(t, versions[i].Size, vi.Size())
+
+		rc := vi.ToReader()
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err, clues.ToCore(err))
+		rc.Close()
+
+		assert.Len(t, content, int(versions[i].Size))
+	}
+
+	stream := newDriveVersionsStream(ctx, fibn, "item"+metadata.VersionsFileSuffix, listed)
+	defer stream.Close()
+
+	_, err = stream.open("3.0")
+	assert.ErrorIs(t, err, errNoMatchingVersion, clues.ToCore(err))
+
+	listed, err = listDriveVersions(ctx, fibn, "missing"+metadata.VersionsFileSuffix)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Empty(t, listed)
+}
+
+func (suite *VersionsUnitSuite) TestDriveVersions_sizeMismatch() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	// graph reports sizes that don't always match the served content.
+	bs, err := writeTestVersions(
+		ctx,
+		[]driveVersion{{ID: "1.0", Size: 10}, {ID: "2.0", Size: 1}},
+		"short",
+		"longer content")
+	require.NoError(t, err, clues.ToCore(err))
+
+	fibn := fetchesNamedBytes{"item" + metadata.VersionsFileSuffix: bs}
+
+	listed, err := listDriveVersions(ctx, fibn, "item"+metadata.VersionsFileSuffix)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, listed, 2)
+	assert.Equal(t, int64(len("short")), listed[0].Size)
+	assert.Equal(t, int64(len("longer content")), listed[1].Size)
+
+	stream := newDriveVersionsStream(ctx, fibn, "item"+metadata.VersionsFileSuffix, listed)
+	defer stream.Close()
+
+	rc, err := stream.open("2.0")
+	require.NoError(t, err, clues.ToCore(err))
+
+	content, err := io.ReadAll(rc)
+	require.NoError(t, err, clues.ToCore(err))
+	rc.Close()
+
+	assert.Equal(t, "longer content", string(content))
+}
+
+// countsFetches tracks how many times the named files get opened.
+type countsFetches struct {
+	fetchesNamedBytes
+	fetched int
+}
+
+func (f *countsFetches) FetchItemByName(ctx context.Context, name string) (data.Item, error) {
+	f.fetched++
+	return f.fetchesNamedBytes.FetchItemByName(ctx, name)
+}
+
+func (suite *VersionsUnitSuite) TestDriveVersionsStream() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	bs, err := writeTestVersions(
+		ctx,
+		[]driveVersion{{ID: "1.0"}, {ID: "2.0"}, {ID: "3.0"}},
+		"one",
+		"two",
+		"three")
+	require.NoError(t, err, clues.ToCore(err))
+
+	fibn := &countsFetches{
+		fetchesNamedBytes: fetchesNamedBytes{"item" + metadata.VersionsFileSuffix: bs},
+	}
+
+	listed, err := listDriveVersions(ctx, fibn, "item"+metadata.VersionsFileSuffix)
+	require.NoError(t, err, clues.ToCore(err))
+
+	fibn.fetched = 0
+
+	read := func(vi *driveVersionItem) string {
+		rc := vi.ToReader()
+		defer rc.Close()
+
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err, clues.ToCore(err))
+
+		return string(content)
+	}
+
+	vis := newDriveVersionItems(ctx, fibn, "item", listed, listed)
+	require.Len(t, vis, 3)
+
+	// reading the versions in order opens the file once.
+	assert.Equal(t, "one", read(vis[0]))
+	assert.Equal(t, "two", read(vis[1]))
+	assert.Equal(t, "three", read(vis[2]))
+	assert.Equal(t, 1, fibn.fetched)
+
+	// re-reading a passed version, ex: on an upload retry, reopens the file.
+	assert.Equal(t, "two", read(vis[1]))
+	assert.Equal(t, 2, fibn.fetched)
+
+	// skipping ahead doesn't.
+	assert.Equal(t, "three", read(vis[2]))
+	assert.Equal(t, 2, fibn.fetched)
+}
+
+func (suite *VersionsUnitSuite) TestWriteVersionChunks() {
+	t := suite.T()
+
+	var (
+		content = bytes.Repeat([]byte("abc"), 10)
+		buf     = &bytes.Buffer{}
+	)
+
+	// a small copy buffer spreads the content over several chunks.
+	written, err := writeVersionChunks(buf, bytes.NewReader(content), make([]byte, 7))
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, int64(len(content)), written)
+
+	read, err := io.ReadAll(&versionChunkReader{r: bufio.NewReader(buf)})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, content, read)
+
+	truncated := &versionChunkReader{r: bufio.NewReader(bytes.NewReader([]byte{0, 0, 0, 9, 'a'}))}
+	_, err = io.ReadAll(truncated)
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *VersionsUnitSuite) TestSelectDriveVersions() {
+	versions := []driveVersion{{ID: "1.0"}, {ID: "2.0"}}
+
+	assert.Equal(suite.T(), versions, selectDriveVersions(versions, control.AllVersions))
+	assert.Equal(suite.T(), []driveVersion{{ID: "2.0"}}, selectDriveVersions(versions, "2.0"))
+	assert.Empty(suite.T(), selectDriveVersions(versions, "3.0"))
+	assert.Empty(suite.T(), selectDriveVersions(nil, control.AllVersions))
+}
+
+func (suite *VersionsUnitSuite) TestVersionedFileName() {
+	assert.Equal(suite.T(), "report (version 1.0).docx", versionedFileName("report.docx", "1.0"))
+	assert.Equal(suite.T(), "notes (version 2.0)", versionedFileName("notes", "2.0"))
+}
+
+func (suite *VersionsUnitSuite) TestRestoreFileVersions_noMatchingVersion() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	bs, err := writeTestVersions(ctx, []driveVersion{{ID: "1.0", Size: 3}}, "one")
+	require.NoError(t, err, clues.ToCore(err))
+
+	table := []struct {
+		name string
+		fibn fetchesNamedBytes
+	}{
+		{
+			name: "version not in backup",
+			fibn: fetchesNamedBytes{"item" + metadata.VersionsFileSuffix: bs},
+		},
+		{
+			name: "no versions in backup",
+			fibn: fetchesNamedBytes{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				rh  = &mockRestoreHandler{PostItemResp: models.NewDriveItem()}
+				ctr = count.New()
+				rcc = inject.RestoreConsumerConfig{
+					BackupVersion: version.Backup,
+					Options:       control.DefaultOptions(),
+					RestoreConfig: control.RestoreConfig{
+						OnCollision: control.Copy,
+						Version:     "9.0",
+					},
+				}
+			)
+
+			_, _, err := restoreFileVersions(
+				ctx,
+				rcc,
+				rh,
+				test.fibn,
+				"file.txt",
+				"item",
+				&dataMock.Item{ItemID: "item" + metadata.DataFileSuffix},
+				"drive",
+				"folder",
+				map[string]api.DriveItemIDType{},
+				nil,
+				ctr)
+			assert.ErrorIs(t, err, errNoMatchingVersion, clues.ToCore(err))
+			assert.False(t, rh.CalledPostItem, "no file created")
+			assert.Equal(t, int64(1), ctr.Get(count.VersionNotFoundSkip))
+		})
+	}
+}
+
+func (suite *VersionsUnitSuite) TestExportFileVersions() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	versions := []driveVersion{
+		{ID: "1.0", Size: 3},
+		{ID: "2.0", Size: 6},
+	}
+
+	bs, err := writeTestVersions(ctx, versions, "one", "second")
+	require.NoError(t, err, clues.ToCore(err))
+
+	fibn := fetchesNamedBytes{"item" + metadata.VersionsFileSuffix: bs}
+
+	table := []struct {
+		name          string
+		version       string
+		expectNames   []string
+		expectContent []string
+		expectSkip    bool
+	}{
+		{
+			name:          "all versions",
+			version:       control.AllVersions,
+			expectNames:   []string{"file (version 1.0).txt", "file (version 2.0).txt", "file.txt"},
+			expectContent: []string{"one", "second", "current"},
+		},
+		{
+			name:          "single version",
+			version:       "2.0",
+			expectNames:   []string{"file.txt"},
+			expectContent: []string{"second"},
+		},
+		{
+			name:       "missing version",
+			version:    "3.0",
+			expectSkip: true,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				ch    = make(chan export.Item, 10)
+				stats = metrics.NewExportStats()
+				item  = &dataMock.Item{
+					ItemID: "item" + metadata.DataFileSuffix,
+					Reader: io.NopCloser(bytes.NewReader([]byte("current"))),
+				}
+			)
+
+			exportFileVersions(ctx, fibn, "drive", item.ID(), "file.txt", item, test.version, ch, stats)
+			close(ch)
+
+			var (
+				names, contents []string
+				skips           []*fault.Skipped
+			)
+
+			for ei := range ch {
+				require.NoError(t, ei.Error, clues.ToCore(ei.Error))
+
+				if ei.Skip != nil {
+					skips = append(skips, ei.Skip)
+					continue
+				}
+
+				bs, err := io.ReadAll(ei.Body)
+				require.NoError(t, err, clues.ToCore(err))
+
+				names = append(names, ei.Name)
+				contents = append(contents, string(bs))
+			}
+
+			assert.Equal(t, test.expectNames, names)
+			assert.Equal(t, test.expectContent, contents)
+
+			if !test.expectSkip {
+				assert.Empty(t, skips)
+				return
+			}
+
+			require.Len(t, skips, 1)
+			assert.True(t, skips[0].HasCause(fault.SkipVersionNotFound))
+			assert.Equal(t, "item", skips[0].Item.ID)
+			assert.Equal(t, "file.txt", skips[0].Item.Name)
+		})
+	}
+}
@@ -82,9 +82,8 @@ func uploadAttachment(
 		return nil
 	}
 
-	// item Attachments to be skipped until the completion of Issue #2353
 	if attachmentType == models.ITEM_ATTACHMENTTYPE {
-		a, err := toItemAttachment(attachment)
+		a, err := toItemAttachment(attachment, 1)
 		if err != nil {
 			logger.CtxErr(ctx, err).Info(fmt.Sprintf("item attachment type not supported: %v", attachmentType))
 			return nil
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
 
 	"github.com/alcionai/corso/src/internal/common/pii"
 	"github.com/alcionai/corso/src/internal/common/ptr"
@@ -13,6 +14,7 @@ import (
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/observe"
 	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/backup/metadata"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
@@ -295,7 +297,7 @@ func populateCollections(
 				addAndRem.DU.Reset,
 				cl),
 			qp.ProtectedResource.ID(),
-			bh.itemHandler(),
+			itemHandlerFor(bh, resolver, cID),
 			bh,
 			addAndRem.Added,
 			addAndRem.Removed,
@@ -482,3 +484,52 @@ func includeContainer(
 
 	return dirPath, loc, ok
 }
+
+// wellKnownFolderResolver is implemented by container caches that recognize
+// the mailbox's well-known folders.
+type wellKnownFolderResolver interface {
+	WellKnownFolder(id string) string
+}
+
+// itemHandlerFor produces the item handler for the container.  Items within
+// a well-known mail folder get the folder recorded in their details.
+func itemHandlerFor(
+	bh backupHandler,
+	resolver graph.ContainerResolver,
+	containerID string,
+) itemGetterSerializer {
+	wkr, ok := resolver.(wellKnownFolderResolver)
+	if !ok {
+		return bh.itemHandler()
+	}
+
+	folder, ok := wellKnownMailFoldersByName[wkr.WellKnownFolder(containerID)]
+	if !ok {
+		return bh.itemHandler()
+	}
+
+	return wellKnownFolderGetter{
+		itemGetterSerializer: bh.itemHandler(),
+		folder:               folder,
+	}
+}
+
+// wellKnownFolderGetter records the well-known mail folder holding each item
+// in the item's details.
+type wellKnownFolderGetter struct {
+	itemGetterSerializer
+	folder control.WellKnownMailFolder
+}
+
+func (g wellKnownFolderGetter) GetItem(
+	ctx context.Context,
+	user, itemID string,
+	errs *fault.Bus,
+) (serialization.Parsable, *details.ExchangeInfo, error) {
+	item, info, err := g.itemGetterSerializer.GetItem(ctx, user, itemID, errs)
+	if info != nil {
+		info.WellKnownFolder = string(g.folder)
+	}
+
+	return item, info, err
+}
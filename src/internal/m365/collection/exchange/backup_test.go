@@ -99,7 +99,7 @@ func (bh mockBackupHandler) CanSkipItemFailure(
 func (bh mockBackupHandler) NewContainerCache(
 	userID string,
 ) (string, graph.ContainerResolver) {
-	return BackupHandlers(bh.ac)[bh.category].NewContainerCache(bh.userID)
+	return BackupHandlers(bh.ac, control.MailFoldersConfig{})[bh.category].NewContainerCache(bh.userID)
 }
 
 var _ addedAndRemovedItemGetter = &mockGetter{}
@@ -505,7 +505,7 @@ func (suite *BackupIntgSuite) SetupSuite() {
 func (suite *BackupIntgSuite) TestMailFetch() {
 	var (
 		users    = []string{suite.m365.User.ID}
-		handlers = BackupHandlers(suite.m365.AC)
+		handlers = BackupHandlers(suite.m365.AC, control.MailFoldersConfig{})
 	)
 
 	tests := []struct {
@@ -592,7 +592,7 @@ func (suite *BackupIntgSuite) TestMailFetch() {
 func (suite *BackupIntgSuite) TestDelta() {
 	var (
 		users    = []string{suite.m365.User.ID}
-		handlers = BackupHandlers(suite.m365.AC)
+		handlers = BackupHandlers(suite.m365.AC, control.MailFoldersConfig{})
 	)
 
 	tests := []struct {
@@ -692,7 +692,7 @@ func (suite *BackupIntgSuite) TestMailSerializationRegression() {
 	var (
 		wg       sync.WaitGroup
 		users    = []string{suite.m365.User.ID}
-		handlers = BackupHandlers(suite.m365.AC)
+		handlers = BackupHandlers(suite.m365.AC, control.MailFoldersConfig{})
 	)
 
 	sel := selectors.NewExchangeBackup(users)
@@ -762,7 +762,7 @@ func (suite *BackupIntgSuite) TestMailSerializationRegression() {
 func (suite *BackupIntgSuite) TestContactSerializationRegression() {
 	var (
 		users    = []string{suite.m365.User.ID}
-		handlers = BackupHandlers(suite.m365.AC)
+		handlers = BackupHandlers(suite.m365.AC, control.MailFoldersConfig{})
 	)
 
 	tests := []struct {
@@ -864,7 +864,7 @@ func (suite *BackupIntgSuite) TestContactSerializationRegression() {
 func (suite *BackupIntgSuite) TestEventsSerializationRegression() {
 	var (
 		users    = []string{suite.m365.User.ID}
-		handlers = BackupHandlers(suite.m365.AC)
+		handlers = BackupHandlers(suite.m365.AC, control.MailFoldersConfig{})
 	)
 
 	tests := []struct {
@@ -973,6 +973,69 @@ func (suite *CollectionPopulationSuite) SetupSuite() {
 	suite.creds = m365
 }
 
+// wellKnownMockResolver recognizes a single well-known folder.
+type wellKnownMockResolver struct {
+	mockResolver
+	id, name string
+}
+
+func (r wellKnownMockResolver) WellKnownFolder(id string) string {
+	if id == r.id {
+		return r.name
+	}
+
+	return ""
+}
+
+func (suite *CollectionPopulationSuite) TestItemHandlerFor() {
+	var (
+		bh       = mockBackupHandler{}
+		resolver = wellKnownMockResolver{
+			mockResolver: newMockResolver(),
+			id:           "deletions",
+			name:         api.MailRecoverableItemsDeletions,
+		}
+	)
+
+	table := []struct {
+		name        string
+		resolver    graph.ContainerResolver
+		containerID string
+		expect      string
+	}{
+		{
+			name:        "well-known folder",
+			resolver:    resolver,
+			containerID: "deletions",
+			expect:      string(control.RecoverableItemsDeletions),
+		},
+		{
+			name:        "other folder",
+			resolver:    resolver,
+			containerID: "inbox",
+		},
+		{
+			name:        "resolver without well-known folders",
+			resolver:    newMockResolver(),
+			containerID: "deletions",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			getter := itemHandlerFor(bh, test.resolver, test.containerID)
+
+			_, info, err := getter.GetItem(ctx, "user", "item", fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, info.WellKnownFolder)
+		})
+	}
+}
+
 func (suite *CollectionPopulationSuite) TestPopulateCollections() {
 	var (
 		qp = graph.QueryParams{
@@ -22,6 +22,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/extensions"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -31,6 +32,8 @@ import (
 var (
 	_ data.BackupCollection = &prefetchCollection{}
 	_ data.BackupCollection = &lazyFetchCollection{}
+	_ data.ItemEstimator    = &prefetchCollection{}
+	_ data.ItemEstimator    = &lazyFetchCollection{}
 )
 
 const (
@@ -88,6 +91,10 @@ func getItemAndInfo(
 		return nil, nil, clues.WrapWC(ctx, err, "serializing item")
 	}
 
+	if err := graph.WaitForDownload(ctx, len(itemData)); err != nil {
+		return nil, nil, clues.WrapWC(ctx, err, "waiting on download rate")
+	}
+
 	// In case of mail the size of itemData is calc as- size of body content+size of attachment
 	// in all other case the size is - total item's serialized size
 	if info.Size <= 0 {
@@ -186,6 +193,12 @@ func (col *prefetchCollection) Items(ctx context.Context, errs *fault.Bus) <-cha
 	return stream
 }
 
+// EstimateItems reports the number of items added to the container.  Item
+// sizes aren't known until the items are fetched.
+func (col *prefetchCollection) EstimateItems() (int64, int64, bool) {
+	return int64(len(col.added)), 0, false
+}
+
 // streamItems is a utility function that uses col.collectionType to be able to serialize
 // all the M365IDs defined in the added field. data channel is closed by this function
 func (col *prefetchCollection) streamItems(
@@ -342,10 +355,28 @@ func (col *prefetchCollection) streamItems(
 				return
 			}
 
-			item, err := data.NewPrefetchedItemWithInfo(
+			itemInfo := details.ItemInfo{Exchange: info}
+
+			rc, extData, err := extensions.AddItemExtensions(
+				ctx,
 				io.NopCloser(bytes.NewReader(itemData)),
-				id,
-				details.ItemInfo{Exchange: info})
+				itemInfo,
+				col.Opts().ItemExtensionFactory)
+			if err != nil {
+				col.Counter.Inc(count.StreamItemsErred)
+				el.AddRecoverable(
+					ctx,
+					clues.WrapWC(ctx, err, "adding extensions").
+						Label(fault.LabelForceNoBackupCreation))
+
+				return
+			}
+
+			if extData.Data != nil {
+				itemInfo.Extension = extData
+			}
+
+			item, err := data.NewPrefetchedItemWithInfo(rc, id, itemInfo)
 			if err != nil {
 				col.Counter.Inc(count.StreamItemsErred)
 				el.AddRecoverable(
@@ -415,6 +446,12 @@ func (col *lazyFetchCollection) Items(ctx context.Context, errs *fault.Bus) <-ch
 	return stream
 }
 
+// EstimateItems reports the number of items added to the container.  Item
+// sizes aren't known until the items are fetched.
+func (col *lazyFetchCollection) EstimateItems() (int64, int64, bool) {
+	return int64(len(col.added)), 0, false
+}
+
 // streamItems is a utility function that uses col.collectionType to be able to
 // serialize all the M365IDs defined in the added field. data channel is closed
 // by this function.
@@ -566,8 +603,23 @@ func (lig *lazyItemGetter) GetData(
 	// for proper details merging.
 	info.Modified = lig.modTime
 
-	return io.NopCloser(bytes.NewReader(itemData)),
-		&details.ItemInfo{Exchange: info},
-		false,
-		nil
+	itemInfo := &details.ItemInfo{Exchange: info}
+
+	rc, extData, err := extensions.AddItemExtensions(
+		ctx,
+		io.NopCloser(bytes.NewReader(itemData)),
+		*itemInfo,
+		lig.opts.ItemExtensionFactory)
+	if err != nil {
+		err := clues.WrapWC(ctx, err, "adding extensions").
+			Label(fault.LabelForceNoBackupCreation)
+
+		return nil, nil, false, err
+	}
+
+	if extData.Data != nil {
+		itemInfo.Extension = extData
+	}
+
+	return rc, itemInfo, false, nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/extensions"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -449,7 +450,7 @@ func (suite *CollectionUnitSuite) TestPrefetchCollection_Items_skipFailure() {
 		{
 			name:     "mail only added items",
 			category: path.EmailCategory,
-			handler:  newMailBackupHandler(api.Client{}),
+			handler:  newMailBackupHandler(api.Client{}, control.MailFoldersConfig{}),
 			added: map[string]time.Time{
 				"fisher":    {},
 				"flannigan": {},
@@ -462,7 +463,7 @@ func (suite *CollectionUnitSuite) TestPrefetchCollection_Items_skipFailure() {
 		{
 			name:     "mail only removed items",
 			category: path.EmailCategory,
-			handler:  newMailBackupHandler(api.Client{}),
+			handler:  newMailBackupHandler(api.Client{}, control.MailFoldersConfig{}),
 			removed: map[string]struct{}{
 				"princess": {},
 				"poppy":    {},
@@ -475,7 +476,7 @@ func (suite *CollectionUnitSuite) TestPrefetchCollection_Items_skipFailure() {
 		{
 			name:     "mail added and removed items",
 			category: path.EmailCategory,
-			handler:  newMailBackupHandler(api.Client{}),
+			handler:  newMailBackupHandler(api.Client{}, control.MailFoldersConfig{}),
 			added: map[string]time.Time{
 				"general": {},
 			},
@@ -962,7 +963,7 @@ func (suite *CollectionUnitSuite) TestLazyFetchCollection_Items_skipFailure() {
 		{
 			name:     "mail only added items",
 			category: path.EmailCategory,
-			handler:  newMailBackupHandler(api.Client{}),
+			handler:  newMailBackupHandler(api.Client{}, control.MailFoldersConfig{}),
 			added: map[string]time.Time{
 				"fisher":    start.Add(time.Minute),
 				"flannigan": start.Add(2 * time.Minute),
@@ -981,7 +982,7 @@ func (suite *CollectionUnitSuite) TestLazyFetchCollection_Items_skipFailure() {
 		{
 			name:     "mail only removed items",
 			category: path.EmailCategory,
-			handler:  newMailBackupHandler(api.Client{}),
+			handler:  newMailBackupHandler(api.Client{}, control.MailFoldersConfig{}),
 			removed: map[string]struct{}{
 				"princess": {},
 				"poppy":    {},
@@ -995,7 +996,7 @@ func (suite *CollectionUnitSuite) TestLazyFetchCollection_Items_skipFailure() {
 		{
 			name:     "mail added and removed items",
 			category: path.EmailCategory,
-			handler:  newMailBackupHandler(api.Client{}),
+			handler:  newMailBackupHandler(api.Client{}, control.MailFoldersConfig{}),
 			added: map[string]time.Time{
 				"general": {},
 			},
@@ -1309,3 +1310,48 @@ func (suite *CollectionUnitSuite) TestLazyItem() {
 	assert.Equal(t, parentPath, info.Exchange.ParentPath)
 	assert.Equal(t, now, info.Modified())
 }
+
+func (suite *CollectionUnitSuite) TestLazyItem_extensions() {
+	var (
+		t   = suite.T()
+		now = time.Now()
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	testData := models.NewMessage()
+	testData.SetSubject(ptr.To("hello world"))
+
+	li := data.NewLazyItemWithInfo(
+		ctx,
+		&lazyItemGetter{
+			userID:     "userID",
+			itemID:     "itemID",
+			getter:     &mock.ItemGetSerialize{GetData: testData},
+			modTime:    now,
+			parentPath: "inbox",
+			opts: control.Options{
+				ItemExtensionFactory: []extensions.CreateItemExtensioner{
+					&extensions.MockItemExtensionFactory{},
+				},
+			},
+		},
+		"itemID",
+		now,
+		count.New(),
+		fault.New(true))
+
+	rc := li.ToReader()
+
+	_, err := io.ReadAll(rc)
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = rc.Close()
+	require.NoError(t, err, clues.ToCore(err))
+
+	info, err := li.Info()
+	require.NoError(t, err, clues.ToCore(err))
+	require.NotNil(t, info.Extension, "extension data is attached to the item")
+	assert.NotZero(t, info.Extension.Data[extensions.KNumBytes])
+}
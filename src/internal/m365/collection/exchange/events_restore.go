@@ -91,7 +91,7 @@ func (h eventRestoreHandler) restore(
 	errs *fault.Bus,
 	ctr *count.Bus,
 ) (*details.ExchangeInfo, error) {
-	return restoreEvent(
+	return RestoreEvent(
 		ctx,
 		h.ac,
 		body,
@@ -102,14 +102,20 @@ func (h eventRestoreHandler) restore(
 		ctr)
 }
 
-type eventRestorer interface {
+// EventRestorer covers the calls needed to restore an event into a
+// calendar, along with its attachments and any recurrence exceptions.
+type EventRestorer interface {
 	postItemer[models.Eventable]
 	eventInstanceAndAttachmenter
 }
 
-func restoreEvent(
+// RestoreEvent creates the event in the destination calendar, then
+// restores its attachments and fixes up its recurring instances.  The
+// userID is passed through to the EventRestorer as-is, so it may identify
+// any calendar owner the restorer supports, such as a group.
+func RestoreEvent(
 	ctx context.Context,
-	er eventRestorer,
+	er EventRestorer,
 	body []byte,
 	userID, destinationID string,
 	collisionKeyToItemID map[string]string,
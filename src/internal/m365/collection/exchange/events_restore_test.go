@@ -24,7 +24,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
-var _ eventRestorer = &eventRestoreMock{}
+var _ EventRestorer = &eventRestoreMock{}
 
 type eventRestoreMock struct {
 	postItemErr       error
@@ -261,7 +261,7 @@ func (suite *EventsRestoreIntgSuite) TestRestoreEvent() {
 
 			ctr := count.New()
 
-			_, err := restoreEvent(
+			_, err := RestoreEvent(
 				ctx,
 				test.apiMock,
 				body,
@@ -51,10 +51,13 @@ type itemGetterSerializer interface {
 	) ([]byte, error)
 }
 
-func BackupHandlers(ac api.Client) map[path.CategoryType]backupHandler {
+func BackupHandlers(
+	ac api.Client,
+	mailFolders control.MailFoldersConfig,
+) map[path.CategoryType]backupHandler {
 	return map[path.CategoryType]backupHandler{
 		path.ContactsCategory: newContactBackupHandler(ac),
-		path.EmailCategory:    newMailBackupHandler(ac),
+		path.EmailCategory:    newMailBackupHandler(ac, mailFolders),
 		path.EventsCategory:   newEventBackupHandler(ac),
 	}
 }
@@ -10,16 +10,19 @@ import (
 var _ backupHandler = &mailBackupHandler{}
 
 type mailBackupHandler struct {
-	ac api.Mail
+	ac      api.Mail
+	folders control.MailFoldersConfig
 }
 
 func newMailBackupHandler(
 	ac api.Client,
+	folders control.MailFoldersConfig,
 ) mailBackupHandler {
 	acm := ac.Mail()
 
 	return mailBackupHandler{
-		ac: acm,
+		ac:      acm,
+		folders: folders,
 	}
 }
 
@@ -43,20 +46,79 @@ func (h mailBackupHandler) previewIncludeContainers() []string {
 
 func (h mailBackupHandler) previewExcludeContainers() []string {
 	return []string{
-		"drafts",
-		"outbox",
-		"recoverableitemsdeletions",
-		"junkemail",
+		api.MailDrafts,
+		api.MailOutbox,
+		api.MailRecoverableItemsDeletions,
+		api.MailJunkEmail,
 	}
 }
 
+// wellKnownMailFolderNames maps the configurable mail folders to the
+// well-known names graph uses in place of their folder IDs.
+var wellKnownMailFolderNames = map[control.WellKnownMailFolder]string{
+	control.RecoverableItemsDeletions: api.MailRecoverableItemsDeletions,
+	control.RecoverableItemsPurges:    api.MailRecoverableItemsPurges,
+	control.RecoverableItemsVersions:  api.MailRecoverableItemsVersions,
+	control.JunkEmail:                 api.MailJunkEmail,
+	control.Drafts:                    api.MailDrafts,
+	control.Outbox:                    api.MailOutbox,
+	control.ConversationHistory:       api.MailConversationHistory,
+}
+
+// wellKnownMailFoldersByName maps the well-known names graph uses for the
+// configurable mail folders back to the folders.
+var wellKnownMailFoldersByName = func() map[string]control.WellKnownMailFolder {
+	m := make(map[string]control.WellKnownMailFolder, len(wellKnownMailFolderNames))
+
+	for f, name := range wellKnownMailFolderNames {
+		m[name] = f
+	}
+
+	return m
+}()
+
+// wellKnownMailFolders splits the config into the well-known names of the
+// Recoverable Items folders to add to the backup, and the well-known names
+// of the folders to leave out of it.
+func wellKnownMailFolders(cfg control.MailFoldersConfig) ([]string, []string) {
+	var includes, excludes []string
+
+	for _, f := range control.WellKnownMailFolders {
+		name, ok := wellKnownMailFolderNames[f]
+		if !ok {
+			continue
+		}
+
+		included := cfg.IsIncluded(f)
+
+		switch f {
+		case control.RecoverableItemsDeletions,
+			control.RecoverableItemsPurges,
+			control.RecoverableItemsVersions:
+			if included {
+				includes = append(includes, name)
+			}
+		default:
+			if !included {
+				excludes = append(excludes, name)
+			}
+		}
+	}
+
+	return includes, excludes
+}
+
 func (h mailBackupHandler) NewContainerCache(
 	userID string,
 ) (string, graph.ContainerResolver) {
+	includes, excludes := wellKnownMailFolders(h.folders)
+
 	return api.MsgFolderRoot, &mailContainerCache{
-		userID: userID,
-		enumer: h.ac,
-		getter: h.ac,
+		userID:              userID,
+		enumer:              h.ac,
+		getter:              h.ac,
+		recoverableIncludes: includes,
+		excludes:            excludes,
 	}
 }
 
@@ -70,7 +70,7 @@ func (suite *MailBackupHandlerUnitSuite) TestHandler_CanSkipItemFailure() {
 		suite.Run(test.name, func() {
 			t := suite.T()
 
-			h := newMailBackupHandler(api.Client{})
+			h := newMailBackupHandler(api.Client{}, control.MailFoldersConfig{})
 			cause, result := h.CanSkipItemFailure(
 				test.err,
 				resourceID,
@@ -81,3 +81,63 @@ func (suite *MailBackupHandlerUnitSuite) TestHandler_CanSkipItemFailure() {
 		})
 	}
 }
+
+func (suite *MailBackupHandlerUnitSuite) TestWellKnownMailFolders() {
+	table := []struct {
+		name           string
+		cfg            control.MailFoldersConfig
+		expectIncludes []string
+		expectExcludes []string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "all recoverable items",
+			cfg: control.MailFoldersConfig{
+				Include: []control.WellKnownMailFolder{control.RecoverableItems},
+			},
+			expectIncludes: []string{
+				api.MailRecoverableItemsDeletions,
+				api.MailRecoverableItemsPurges,
+				api.MailRecoverableItemsVersions,
+			},
+		},
+		{
+			name: "exclusions win over inclusions",
+			cfg: control.MailFoldersConfig{
+				Include: []control.WellKnownMailFolder{control.RecoverableItems},
+				Exclude: []control.WellKnownMailFolder{control.RecoverableItemsPurges, control.JunkEmail},
+			},
+			expectIncludes: []string{
+				api.MailRecoverableItemsDeletions,
+				api.MailRecoverableItemsVersions,
+			},
+			expectExcludes: []string{api.MailJunkEmail},
+		},
+		{
+			name: "exclude default folders",
+			cfg: control.MailFoldersConfig{
+				Exclude: []control.WellKnownMailFolder{
+					control.Drafts,
+					control.Outbox,
+					control.ConversationHistory,
+				},
+			},
+			expectExcludes: []string{
+				api.MailDrafts,
+				api.MailOutbox,
+				api.MailConversationHistory,
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			includes, excludes := wellKnownMailFolders(test.cfg)
+			assert.Equal(t, test.expectIncludes, includes)
+			assert.Equal(t, test.expectExcludes, excludes)
+		})
+	}
+}
@@ -2,11 +2,13 @@ package exchange
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -46,6 +48,17 @@ type mailContainerCache struct {
 	enumer containersEnumerator[models.MailFolderable]
 	getter containerGetter
 	userID string
+
+	// recoverableIncludes holds the well-known names of the Recoverable Items
+	// folders to add to the cache.  Those folders sit outside of the mail
+	// root, and aren't produced when enumerating the user's mail folders.
+	recoverableIncludes []string
+	// excludes holds the well-known names of the folders to leave out of the
+	// cache, along with all of their subfolders.
+	excludes []string
+	// wellKnownIDs maps the IDs of the mailbox's well-known folders to their
+	// well-known names.
+	wellKnownIDs map[string]string
 }
 
 // init ensures that the structure's fields are initialized.
@@ -119,6 +132,12 @@ func (mc *mailContainerCache) Populate(
 		return clues.WrapWC(ctx, err, "enumerating containers")
 	}
 
+	if err := mc.populateWellKnownIDs(ctx); err != nil {
+		return clues.Wrap(err, "resolving well-known containers")
+	}
+
+	containers = mc.dropExcludedContainers(containers)
+
 	for _, c := range containers {
 		if el.Failure() != nil {
 			return el.Failure()
@@ -133,6 +152,10 @@ func (mc *mailContainerCache) Populate(
 		}
 	}
 
+	if err := mc.populateRecoverableItems(ctx); err != nil {
+		return clues.Wrap(err, "populating recoverable items")
+	}
+
 	if err := mc.populatePaths(ctx, errs); err != nil {
 		return clues.Wrap(err, "populating paths")
 	}
@@ -143,3 +166,159 @@ func (mc *mailContainerCache) Populate(
 
 	return el.Failure()
 }
+
+// wellKnownMailFolderIDs are the well-known folders within the mail root
+// that get resolved to their folder IDs, so that their emails can be
+// recognized as being held within a well-known folder.
+var wellKnownMailFolderIDs = []string{
+	api.MailJunkEmail,
+	api.MailDrafts,
+	api.MailOutbox,
+	api.MailConversationHistory,
+}
+
+// populateWellKnownIDs resolves the IDs of the well-known folders within the
+// mail root.
+func (mc *mailContainerCache) populateWellKnownIDs(ctx context.Context) error {
+	for _, name := range wellKnownMailFolderIDs {
+		ictx := clues.Add(ctx, "container_id", name)
+
+		c, err := mc.getter.GetContainerByID(ictx, mc.userID, name)
+		if err != nil {
+			// not every mailbox has every well-known folder.
+			if graph.IsErrExchangeMailFolderNotFound(err) {
+				logger.CtxErr(ictx, err).Info("well-known folder not found")
+				continue
+			}
+
+			return clues.WrapWC(ictx, err, "getting well-known container")
+		}
+
+		mc.addWellKnownID(ptr.Val(c.GetId()), name)
+	}
+
+	return nil
+}
+
+func (mc *mailContainerCache) addWellKnownID(id, name string) {
+	if mc.wellKnownIDs == nil {
+		mc.wellKnownIDs = map[string]string{}
+	}
+
+	mc.wellKnownIDs[id] = name
+}
+
+// dropExcludedContainers removes the excluded well-known folders, and all of
+// their subfolders, from the set of containers.
+func (mc *mailContainerCache) dropExcludedContainers(
+	containers []models.MailFolderable,
+) []models.MailFolderable {
+	if len(mc.excludes) == 0 {
+		return containers
+	}
+
+	excludeIDs := map[string]struct{}{}
+
+	for id, name := range mc.wellKnownIDs {
+		if slices.Contains(mc.excludes, name) {
+			excludeIDs[id] = struct{}{}
+		}
+	}
+
+	parents := make(map[string]string, len(containers))
+
+	for _, c := range containers {
+		parents[ptr.Val(c.GetId())] = ptr.Val(c.GetParentFolderId())
+	}
+
+	result := make([]models.MailFolderable, 0, len(containers))
+
+	for _, c := range containers {
+		if !isInExcludedContainer(ptr.Val(c.GetId()), parents, excludeIDs) {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// WellKnownFolder produces the well-known name of the folder, or of the
+// closest parent folder, that's one of the mailbox's well-known folders.
+// Produces an empty string for any other folder.
+func (mc *mailContainerCache) WellKnownFolder(id string) string {
+	for i := 0; i < maxIterations && len(id) > 0; i++ {
+		if name, ok := mc.wellKnownIDs[id]; ok {
+			return name
+		}
+
+		c, ok := mc.cache[id]
+		if !ok {
+			return ""
+		}
+
+		id = ptr.Val(c.GetParentFolderId())
+	}
+
+	return ""
+}
+
+// isInExcludedContainer walks up the parent folders of the container,
+// looking for any one of the excluded container IDs.
+func isInExcludedContainer(
+	id string,
+	parents map[string]string,
+	excludeIDs map[string]struct{},
+) bool {
+	for i := 0; i < maxIterations && len(id) > 0; i++ {
+		if _, ok := excludeIDs[id]; ok {
+			return true
+		}
+
+		id = parents[id]
+	}
+
+	return false
+}
+
+// populateRecoverableItems adds the included Recoverable Items folders to the
+// cache.  Graph parents the Recoverable Items root under the top of the
+// mailbox instead of the mail root, so the root gets added with its path
+// already set, as a direct child of the mail root.  The root's path uses
+// its well-known name in place of its ID.
+func (mc *mailContainerCache) populateRecoverableItems(ctx context.Context) error {
+	if len(mc.recoverableIncludes) == 0 {
+		return nil
+	}
+
+	root, err := mc.getter.GetContainerByID(ctx, mc.userID, api.MailRecoverableItemsRoot)
+	if err != nil {
+		return clues.Wrap(err, "fetching recoverable items root folder")
+	}
+
+	rootFolder := graph.NewCacheFolder(
+		root,
+		path.Builder{}.Append(api.MailRecoverableItemsRoot),
+		path.Builder{}.Append(ptr.Val(root.GetDisplayName())))
+	if err := mc.addFolder(&rootFolder); err != nil {
+		return clues.WrapWC(ctx, err, "adding recoverable items root folder")
+	}
+
+	for _, name := range mc.recoverableIncludes {
+		ictx := clues.Add(ctx, "container_id", name)
+
+		c, err := mc.getter.GetContainerByID(ictx, mc.userID, name)
+		if err != nil {
+			return clues.WrapWC(ictx, err, "fetching recoverable items folder")
+		}
+
+		cacheFolder := graph.NewCacheFolder(c, nil, nil)
+
+		if err := mc.addFolder(&cacheFolder); err != nil {
+			return clues.WrapWC(ictx, err, "adding recoverable items folder")
+		}
+
+		mc.addWellKnownID(ptr.Val(c.GetId()), name)
+	}
+
+	return nil
+}
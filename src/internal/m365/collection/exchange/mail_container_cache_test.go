@@ -1,19 +1,24 @@
 package exchange
 
 import (
+	"context"
 	stdpath "path"
 	"testing"
 
 	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/tester/its"
 	"github.com/alcionai/corso/src/internal/tester/tconfig"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	graphTD "github.com/alcionai/corso/src/pkg/services/m365/api/graph/testdata"
 )
 
 const (
@@ -28,6 +33,163 @@ const (
 	expectedFolderPath = "toplevel/subFolder/subsubfolder"
 )
 
+// ---------------------------------------------------------------------------
+// unit tests
+// ---------------------------------------------------------------------------
+
+type mockMailFoldersEnumerator struct {
+	folders []models.MailFolderable
+}
+
+func (m mockMailFoldersEnumerator) EnumerateContainers(
+	context.Context,
+	string, string,
+) ([]models.MailFolderable, error) {
+	return m.folders, nil
+}
+
+func mailFolder(id, parentID, name string) models.MailFolderable {
+	f := models.NewMailFolder()
+	f.SetId(ptr.To(id))
+	f.SetParentFolderId(ptr.To(parentID))
+	f.SetDisplayName(ptr.To(name))
+
+	return f
+}
+
+type MailFolderCacheUnitSuite struct {
+	tester.Suite
+}
+
+func TestMailFolderCacheUnitSuite(t *testing.T) {
+	suite.Run(t, &MailFolderCacheUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *MailFolderCacheUnitSuite) TestPopulate_wellKnownFolders() {
+	var (
+		root        = mailFolder("root", "top", "Top of Information Store")
+		inbox       = mailFolder("inbox", "root", "Inbox")
+		junk        = mailFolder("junk", "root", "Junk Email")
+		junkChild   = mailFolder("junk-child", "junk", "Spam")
+		recoverable = mailFolder("recoverable", "top", "Recoverable Items")
+		deletions   = mailFolder("deletions", "recoverable", "Deletions")
+		purges      = mailFolder("purges", "recoverable", "Purges")
+		notFound    = graphTD.ODataErr(string(graph.ErrorItemNotFound))
+		enumer      = mockMailFoldersEnumerator{
+			folders: []models.MailFolderable{inbox, junk, junkChild},
+		}
+		getter = mockContainerGetter{
+			itemsByID: map[string]containerGetterRes{
+				api.MsgFolderRoot:                 {c: root},
+				api.MailJunkEmail:                 {c: junk},
+				api.MailDrafts:                    {err: notFound},
+				api.MailOutbox:                    {err: notFound},
+				api.MailConversationHistory:       {err: notFound},
+				api.MailRecoverableItemsRoot:      {c: recoverable},
+				api.MailRecoverableItemsDeletions: {c: deletions},
+				api.MailRecoverableItemsPurges:    {c: purges},
+			},
+		}
+	)
+
+	table := []struct {
+		name                string
+		recoverableIncludes []string
+		excludes            []string
+		expectLocations     map[string]string
+		expectPaths         map[string]string
+		expectWellKnown     map[string]string
+	}{
+		{
+			name: "defaults",
+			expectLocations: map[string]string{
+				"inbox":      "Inbox",
+				"junk":       "Junk Email",
+				"junk-child": "Junk Email/Spam",
+			},
+			expectWellKnown: map[string]string{
+				"inbox":      "",
+				"junk":       api.MailJunkEmail,
+				"junk-child": api.MailJunkEmail,
+			},
+		},
+		{
+			name:     "exclude junk and missing drafts",
+			excludes: []string{api.MailJunkEmail, api.MailDrafts},
+			expectLocations: map[string]string{
+				"inbox": "Inbox",
+			},
+		},
+		{
+			name:                "include recoverable items",
+			recoverableIncludes: []string{api.MailRecoverableItemsDeletions, api.MailRecoverableItemsPurges},
+			expectLocations: map[string]string{
+				"inbox":       "Inbox",
+				"junk":        "Junk Email",
+				"junk-child":  "Junk Email/Spam",
+				"recoverable": "Recoverable Items",
+				"deletions":   "Recoverable Items/Deletions",
+				"purges":      "Recoverable Items/Purges",
+			},
+			expectPaths: map[string]string{
+				"recoverable": api.MailRecoverableItemsRoot,
+				"deletions":   api.MailRecoverableItemsRoot + "/deletions",
+				"purges":      api.MailRecoverableItemsRoot + "/purges",
+			},
+			expectWellKnown: map[string]string{
+				"recoverable": "",
+				"deletions":   api.MailRecoverableItemsDeletions,
+				"purges":      api.MailRecoverableItemsPurges,
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			mfc := mailContainerCache{
+				userID:              "user",
+				enumer:              enumer,
+				getter:              getter,
+				recoverableIncludes: test.recoverableIncludes,
+				excludes:            test.excludes,
+			}
+
+			err := mfc.Populate(ctx, fault.New(true), api.MsgFolderRoot)
+			require.NoError(t, err, clues.ToCore(err))
+
+			locations := map[string]string{}
+
+			for _, c := range mfc.Items() {
+				if ptr.Val(c.GetId()) == "root" {
+					continue
+				}
+
+				locations[ptr.Val(c.GetId())] = c.Location().String()
+			}
+
+			assert.Equal(t, test.expectLocations, locations)
+
+			for id, expect := range test.expectPaths {
+				p, _, err := mfc.IDToPath(ctx, id)
+				require.NoError(t, err, clues.ToCore(err))
+				assert.Equal(t, expect, p.String())
+			}
+
+			for id, expect := range test.expectWellKnown {
+				assert.Equal(t, expect, mfc.WellKnownFolder(id), id)
+			}
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// integration tests
+// ---------------------------------------------------------------------------
+
 type MailFolderCacheIntgSuite struct {
 	tester.Suite
 	m365 its.M365IntgTestSetup
@@ -3,7 +3,10 @@ package exchange
 import (
 	"context"
 	"errors"
+	"mime"
+	"net/mail"
 	"regexp"
+	"strings"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
@@ -106,6 +109,15 @@ type mailRestorer interface {
 	postItemer[models.Messageable]
 	deleteItemer
 	attachmentPoster
+	mimePoster
+}
+
+type mimePoster interface {
+	PostItemMIME(
+		ctx context.Context,
+		userID, containerID string,
+		mime []byte,
+	) (models.Messageable, error)
 }
 
 func restoreMail(
@@ -146,17 +158,47 @@ func restoreMail(
 		shouldDeleteOriginal = collisionPolicy == control.Replace
 	}
 
-	msg = setMessageSVEPs(toMessage(msg))
+	mimeContent, hasMIME, err := api.MailMIMEContent(msg)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "reading mail mime content")
+	}
 
-	setReplyTos(msg)
+	// the mime content isn't a property of the message in graph.
+	delete(msg.GetAdditionalData(), api.MailMIMEContentKey)
 
-	attachments := msg.GetAttachments()
-	// Item.Attachments --> HasAttachments doesn't always have a value populated when deserialized
-	msg.SetAttachments([]models.Attachmentable{})
+	var (
+		item        models.Messageable
+		attachments []models.Attachmentable
+		size        int64
+	)
 
-	item, err := mr.PostItem(ctx, userID, destinationID, msg)
-	if err != nil {
-		return nil, clues.Wrap(err, "restoring mail message")
+	// Messages posted as MIME get created as drafts, so we only restore from
+	// the MIME content when the json can't faithfully recreate the message.
+	if hasMIME && (msg.GetBody() == nil || isSMIME(mimeContent)) {
+		item, err = mr.PostItemMIME(ctx, userID, destinationID, mimeContent)
+		if err != nil {
+			return nil, clues.Wrap(err, "restoring mail message from mime")
+		}
+
+		size = int64(len(mimeContent))
+	} else {
+		msg = setMessageSVEPs(toMessage(msg))
+
+		setReplyTos(msg)
+
+		attachments = msg.GetAttachments()
+		// Item.Attachments --> HasAttachments doesn't always have a value populated when deserialized
+		msg.SetAttachments([]models.Attachmentable{})
+
+		item, err = mr.PostItem(ctx, userID, destinationID, msg)
+		if err != nil {
+			return nil, clues.Wrap(err, "restoring mail message")
+		}
+
+		if msg.GetBody() != nil {
+			bc := ptr.Val(msg.GetBody().GetContent())
+			size = int64(len(bc))
+		}
 	}
 
 	// mails have no PUT request, and PATCH could retain data that's not
@@ -183,13 +225,6 @@ func restoreMail(
 		return nil, clues.Stack(err)
 	}
 
-	var size int64
-
-	if msg.GetBody() != nil {
-		bc := ptr.Val(msg.GetBody().GetContent())
-		size = int64(len(bc))
-	}
-
 	if shouldDeleteOriginal {
 		ctr.Inc(count.CollisionReplace)
 	} else {
@@ -296,3 +331,29 @@ func isValidDN(dn string) bool {
 
 	return r.MatchString(dn)
 }
+
+// isSMIME reports whether the MIME content is an S/MIME signed or encrypted
+// message.  Graph's json representation of those messages loses either the
+// signature or the content, so they can only be restored from their MIME.
+func isSMIME(content []byte) bool {
+	msg, err := mail.ReadMessage(strings.NewReader(string(content)))
+	if err != nil {
+		return false
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	switch mediaType {
+	case "application/pkcs7-mime", "application/x-pkcs7-mime":
+		return true
+	case "multipart/signed":
+		protocol := strings.ToLower(params["protocol"])
+		return protocol == "application/pkcs7-signature" ||
+			protocol == "application/x-pkcs7-signature"
+	}
+
+	return false
+}
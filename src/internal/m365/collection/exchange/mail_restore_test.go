@@ -146,6 +146,119 @@ func (suite *RestoreMailUnitSuite) TestSetReplyTos() {
 	}
 }
 
+const (
+	plainMIME = "Subject: plain\r\nContent-Type: text/plain\r\n\r\nbody\r\n"
+	//nolint:lll
+	signedMIME    = "Subject: signed\r\nContent-Type: multipart/signed; protocol=\"application/pkcs7-signature\"; micalg=sha-256; boundary=\"b\"\r\n\r\n--b\r\nContent-Type: text/plain\r\n\r\nbody\r\n--b\r\nContent-Type: application/pkcs7-signature\r\n\r\nsig\r\n--b--\r\n"
+	encryptedMIME = "Subject: encrypted\r\nContent-Type: application/pkcs7-mime; smime-type=enveloped-data\r\n\r\nciphertext\r\n"
+)
+
+func (suite *RestoreMailUnitSuite) TestIsSMIME() {
+	table := []struct {
+		name    string
+		content string
+		check   assert.BoolAssertionFunc
+	}{
+		{
+			name:    "plain",
+			content: plainMIME,
+			check:   assert.False,
+		},
+		{
+			name:    "signed",
+			content: signedMIME,
+			check:   assert.True,
+		},
+		{
+			name:    "encrypted",
+			content: encryptedMIME,
+			check:   assert.True,
+		},
+		{
+			name:    "not mime",
+			content: "{}",
+			check:   assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.check(suite.T(), isSMIME([]byte(test.content)))
+		})
+	}
+}
+
+func (suite *RestoreMailUnitSuite) TestRestoreMail_mime() {
+	table := []struct {
+		name       string
+		mime       string
+		dropBody   bool
+		expectMIME bool
+	}{
+		{
+			name:       "no mime",
+			expectMIME: false,
+		},
+		{
+			name:       "plain mime with json body",
+			mime:       plainMIME,
+			expectMIME: false,
+		},
+		{
+			name:       "plain mime without json body",
+			mime:       plainMIME,
+			dropBody:   true,
+			expectMIME: true,
+		},
+		{
+			name:       "signed mime with json body",
+			mime:       signedMIME,
+			expectMIME: true,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			msg, err := api.BytesToMessageable(mock.MessageBytes("subject"))
+			require.NoError(t, err, clues.ToCore(err))
+
+			if test.dropBody {
+				msg.SetBody(nil)
+			}
+
+			if len(test.mime) > 0 {
+				api.SetMailMIMEContent(msg, []byte(test.mime))
+			}
+
+			body, err := api.Mail{}.Serialize(ctx, msg, "user", "id")
+			require.NoError(t, err, clues.ToCore(err))
+
+			m := &mailRestoreMock{}
+
+			_, err = restoreMail(
+				ctx,
+				m,
+				body,
+				"user", "destination",
+				map[string]string{},
+				control.Copy,
+				fault.New(true),
+				count.New())
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectMIME, m.calledPostMIME, "posted mime")
+			assert.Equal(t, !test.expectMIME, m.calledPost, "posted json")
+
+			if test.expectMIME {
+				assert.Equal(t, test.mime, string(m.postedMIME))
+			}
+		})
+	}
+}
+
 var _ mailRestorer = &mailRestoreMock{}
 
 type mailRestoreMock struct {
@@ -154,6 +267,8 @@ type mailRestoreMock struct {
 	deleteItemErr     error
 	calledDelete      bool
 	postAttachmentErr error
+	calledPostMIME    bool
+	postedMIME        []byte
 }
 
 func (m *mailRestoreMock) PostItem(
@@ -165,6 +280,17 @@ func (m *mailRestoreMock) PostItem(
 	return models.NewMessage(), m.postItemErr
 }
 
+func (m *mailRestoreMock) PostItemMIME(
+	_ context.Context,
+	_, _ string,
+	mime []byte,
+) (models.Messageable, error) {
+	m.calledPostMIME = true
+	m.postedMIME = mime
+
+	return models.NewMessage(), m.postItemErr
+}
+
 func (m *mailRestoreMock) DeleteItem(
 	_ context.Context,
 	_, _ string,
@@ -8,6 +8,7 @@ import (
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
 //==========================================================
@@ -190,13 +191,20 @@ func insertStringToBody(body getContenter, newContent string) string {
 // support ODataType values
 
 // toItemAttachment transforms internal item, OutlookItemables, into
-// objects that are able to be uploaded into M365.
-func toItemAttachment(orig models.Attachmentable) (models.Attachmentable, error) {
+// objects that are able to be uploaded into M365.  The depth counts the
+// item attachments that enclose this one, and limits how many levels of
+// nested attachments get carried along.
+func toItemAttachment(orig models.Attachmentable, depth int) (models.Attachmentable, error) {
 	transform, ok := orig.(models.ItemAttachmentable)
 	if !ok { // Shouldn't ever happen
 		return nil, clues.New("transforming attachment to item attachment")
 	}
 
+	// Attachments can't be posted through an upload session, so items that
+	// exceed the size limit for a single request get restored without the
+	// attachments they contain.
+	keepNested := ptr.Val(orig.GetSize()) < largeAttachmentSize
+
 	item := transform.GetItem()
 
 	switch val := item.(type) {
@@ -207,7 +215,7 @@ func toItemAttachment(orig models.Attachmentable) (models.Attachmentable, error)
 		return transform, nil
 
 	case models.Eventable:
-		newEvent, err := sanitizeEvent(val)
+		newEvent, err := sanitizeEvent(val, depth, keepNested)
 		if err != nil {
 			return nil, err
 		}
@@ -217,7 +225,7 @@ func toItemAttachment(orig models.Attachmentable) (models.Attachmentable, error)
 		return transform, nil
 
 	case models.Messageable:
-		newMessage, err := sanitizeMessage(val)
+		newMessage, err := sanitizeMessage(val, depth, keepNested)
 		if err != nil {
 			return nil, err
 		}
@@ -234,27 +242,42 @@ func toItemAttachment(orig models.Attachmentable) (models.Attachmentable, error)
 	}
 }
 
-// TODO #2428 (dadam39): re-apply nested attachments for itemAttachments
-// func sanitizeAttachments(attached []models.Attachmentable) ([]models.Attachmentable, error) {
-// 	attachments := make([]models.Attachmentable, len(attached))
-
-// 	for _, ax := range attached {
-// 		if ptr.Val(ax.GetOdataType()) == itemAttachment {
-// 			newAttachment, err := ToItemAttachment(ax)
-// 			if err != nil {
-// 				return nil, err
-// 			}
-
-// 			attachments = append(attachments, newAttachment)
-
-// 			continue
-// 		}
-
-// 		attachments = append(attachments, ax)
-// 	}
+// sanitizeAttachments prepares the attachments of an item that is itself
+// an item attachment.  Nested item attachments get sanitized in turn, down
+// to api.MaxNestedAttachmentDepth.  Reference attachments, item attachments
+// past the depth limit, and item attachments holding items that can't be
+// restored (such as tasks and posts) are dropped, without dropping the
+// enclosing item.
+func sanitizeAttachments(
+	attached []models.Attachmentable,
+	depth int,
+) []models.Attachmentable {
+	attachments := make([]models.Attachmentable, 0, len(attached))
+
+	for _, ax := range attached {
+		switch ptr.Val(ax.GetOdataType()) {
+		case fileAttachmentOdataValue:
+			ax.SetId(nil)
+			attachments = append(attachments, ax)
+
+		case itemAttachmentOdataValue:
+			if depth >= api.MaxNestedAttachmentDepth {
+				continue
+			}
+
+			ax.SetId(nil)
+
+			newAttachment, err := toItemAttachment(ax, depth+1)
+			if err != nil {
+				continue
+			}
+
+			attachments = append(attachments, newAttachment)
+		}
+	}
 
-// 	return attachments, nil
-// }
+	return attachments
+}
 
 // sanitizeContact removes fields which prevent a Contact from
 // being uploaded as an attachment.
@@ -267,7 +290,11 @@ func sanitizeContact(orig models.Contactable) models.Contactable {
 
 // sanitizeEvent transfers data into event object and
 // removes unique IDs from the M365 object
-func sanitizeEvent(orig models.Eventable) (models.Eventable, error) {
+func sanitizeEvent(
+	orig models.Eventable,
+	depth int,
+	keepNested bool,
+) (models.Eventable, error) {
 	newEvent := models.NewEvent()
 	newEvent.SetAttendees(orig.GetAttendees())
 	newEvent.SetBody(orig.GetBody())
@@ -275,8 +302,6 @@ func sanitizeEvent(orig models.Eventable) (models.Eventable, error) {
 	newEvent.SetCalendar(orig.GetCalendar())
 	newEvent.SetCreatedDateTime(orig.GetCreatedDateTime())
 	newEvent.SetEnd(orig.GetEnd())
-	// TODO: dadams39 Nested attachments not supported
-	// Upstream: https://github.com/microsoft/kiota-serialization-json-go/issues/61
 	newEvent.SetHasAttachments(nil)
 	newEvent.SetHideAttendees(orig.GetHideAttendees())
 	newEvent.SetImportance(orig.GetImportance())
@@ -299,28 +324,29 @@ func sanitizeEvent(orig models.Eventable) (models.Eventable, error) {
 	newEvent.SetIsDraft(nil)
 	newEvent.SetAdditionalData(orig.GetAdditionalData())
 
-	// TODO #2428 (dadam39): re-apply nested attachments for itemAttachments
-	// Upstream: https://github.com/microsoft/kiota-serialization-json-go/issues/61
-	// attachments, err := sanitizeAttachments(message.GetAttachments())
-	// if err != nil {
-	// 	return nil, err
-	// }
 	newEvent.SetAttachments(nil)
 
+	if keepNested {
+		attachments := sanitizeAttachments(orig.GetAttachments(), depth)
+		newEvent.SetAttachments(attachments)
+	}
+
 	return newEvent, nil
 }
 
-func sanitizeMessage(orig models.Messageable) (models.Messageable, error) {
+func sanitizeMessage(
+	orig models.Messageable,
+	depth int,
+	keepNested bool,
+) (models.Messageable, error) {
 	message := toMessage(orig)
-
-	// TODO #2428 (dadam39): re-apply nested attachments for itemAttachments
-	// Upstream: https://github.com/microsoft/kiota-serialization-json-go/issues/61
-	// attachments, err := sanitizeAttachments(message.GetAttachments())
-	// if err != nil {
-	// 	return nil, err
-	// }
 	message.SetAttachments(nil)
 
+	if keepNested {
+		attachments := sanitizeAttachments(orig.GetAttachments(), depth)
+		message.SetAttachments(attachments)
+	}
+
 	// The following fields are set to nil to
 	// not interfere with M365 guard checks.
 	message.SetHasAttachments(nil)
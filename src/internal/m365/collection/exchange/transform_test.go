@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -277,3 +278,198 @@ func (suite *TransformUnitTest) TestInsertStringToBody() {
 		})
 	}
 }
+
+func itemAttachmentChain(depth int, size int32) models.Attachmentable {
+	var attached []models.Attachmentable
+
+	for i := depth; i > 0; i-- {
+		msg := models.NewMessage()
+		msg.SetId(ptr.To("message-id"))
+		msg.SetAttachments(attached)
+
+		ia := models.NewItemAttachment()
+		ia.SetId(ptr.To("attachment-id"))
+		ia.SetSize(ptr.To(size))
+		ia.SetItem(msg)
+
+		attached = []models.Attachmentable{ia}
+	}
+
+	return attached[0]
+}
+
+func nestedDepth(att models.Attachmentable) int {
+	depth := 0
+
+	for att != nil {
+		depth++
+
+		ia, ok := att.(models.ItemAttachmentable)
+		if !ok {
+			break
+		}
+
+		msg, ok := ia.GetItem().(models.Messageable)
+		if !ok || len(msg.GetAttachments()) == 0 {
+			break
+		}
+
+		att = msg.GetAttachments()[0]
+	}
+
+	return depth
+}
+
+func (suite *TransformUnitTest) TestToItemAttachment_nested() {
+	table := []struct {
+		name       string
+		depth      int
+		size       int32
+		expectKept int
+	}{
+		{
+			name:       "single level",
+			depth:      1,
+			size:       1024,
+			expectKept: 1,
+		},
+		{
+			name:       "within depth limit",
+			depth:      api.MaxNestedAttachmentDepth,
+			size:       1024,
+			expectKept: api.MaxNestedAttachmentDepth,
+		},
+		{
+			name:       "beyond depth limit",
+			depth:      api.MaxNestedAttachmentDepth + 2,
+			size:       1024,
+			expectKept: api.MaxNestedAttachmentDepth,
+		},
+		{
+			name:       "large attachment drops nested items",
+			depth:      3,
+			size:       largeAttachmentSize,
+			expectKept: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, err := toItemAttachment(itemAttachmentChain(test.depth, test.size), 1)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectKept, nestedDepth(result))
+
+			for att := result; att != nil; {
+				msg := att.(models.ItemAttachmentable).GetItem().(models.Messageable)
+				assert.Nil(t, msg.GetId(), "item id")
+
+				if len(msg.GetAttachments()) == 0 {
+					break
+				}
+
+				att = msg.GetAttachments()[0]
+				assert.Nil(t, att.GetId(), "nested attachment id")
+			}
+		})
+	}
+}
+
+func (suite *TransformUnitTest) TestToItemAttachment_unsupportedNested() {
+	t := suite.T()
+
+	post := models.NewItemAttachment()
+	post.SetId(ptr.To("post-id"))
+	post.SetItem(models.NewPost())
+
+	file := models.NewFileAttachment()
+	file.SetId(ptr.To("file-id"))
+
+	msg := models.NewMessage()
+	msg.SetAttachments([]models.Attachmentable{post, file})
+
+	outer := models.NewItemAttachment()
+	outer.SetSize(ptr.To[int32](1024))
+	outer.SetItem(msg)
+
+	result, err := toItemAttachment(outer, 1)
+	require.NoError(t, err, "unsupported nested attachments don't drop the outer one", clues.ToCore(err))
+
+	attached := result.(models.ItemAttachmentable).GetItem().(models.Messageable).GetAttachments()
+	require.Len(t, attached, 1)
+	assert.Equal(t, fileAttachmentOdataValue, ptr.Val(attached[0].GetOdataType()))
+}
+
+func (suite *TransformUnitTest) TestSanitizeAttachments() {
+	t := suite.T()
+
+	file := models.NewFileAttachment()
+	file.SetId(ptr.To("file-id"))
+
+	ref := models.NewReferenceAttachment()
+	ref.SetId(ptr.To("ref-id"))
+
+	item := models.NewItemAttachment()
+	item.SetId(ptr.To("item-id"))
+	item.SetItem(models.NewContact())
+
+	// restores only support contacts, events, and messages.
+	other := models.NewItemAttachment()
+	other.SetId(ptr.To("other-id"))
+	other.SetItem(models.NewOutlookItem())
+
+	post := models.NewItemAttachment()
+	post.SetId(ptr.To("post-id"))
+	post.SetItem(models.NewPost())
+
+	result := sanitizeAttachments([]models.Attachmentable{file, ref, other, item, post}, 1)
+	require.Len(t, result, 2)
+
+	assert.Equal(t, fileAttachmentOdataValue, ptr.Val(result[0].GetOdataType()))
+	assert.Nil(t, result[0].GetId())
+	assert.Equal(t, itemAttachmentOdataValue, ptr.Val(result[1].GetOdataType()))
+	assert.Nil(t, result[1].GetId())
+}
+
+// Nested attachments used to get lost when serializing item attachments
+// (kiota-serialization-json-go#61).  Make sure a sanitized chain survives
+// the trip through json that backup and restore put it through.
+func (suite *TransformUnitTest) TestToItemAttachment_nestedSerializationRoundTrip() {
+	t := suite.T()
+
+	msg := models.NewMessage()
+	msg.SetAttachments([]models.Attachmentable{itemAttachmentChain(3, 1024)})
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", msg)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	backedUp, err := api.BytesToMessageable(bs)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, backedUp.GetAttachments(), 1)
+	assert.Equal(t, 3, nestedDepth(backedUp.GetAttachments()[0]))
+
+	result, err := toItemAttachment(backedUp.GetAttachments()[0], 1)
+	require.NoError(t, err, clues.ToCore(err))
+
+	restoreWriter := kjson.NewJsonSerializationWriter()
+	defer restoreWriter.Close()
+
+	err = restoreWriter.WriteObjectValue("", result)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err = restoreWriter.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	parsed, err := api.CreateFromBytes(bs, models.CreateAttachmentFromDiscriminatorValue)
+	require.NoError(t, err, clues.ToCore(err))
+
+	restored, ok := parsed.(models.Attachmentable)
+	require.True(t, ok, "parsed attachment type %T", parsed)
+	assert.Equal(t, 3, nestedDepth(restored))
+}
@@ -141,6 +141,7 @@ func (bh mockChannelsBH) getItem(
 	_ string,
 	_ path.Elements,
 	itemID string,
+	_ *fault.Bus,
 ) (models.ChatMessageable, *details.GroupsInfo, error) {
 	return bh.messages[itemID], bh.info[itemID], bh.getMessageErr[itemID]
 }
@@ -601,6 +602,7 @@ func (bh mockConversationsBH) getItem(
 	_ string,
 	_ path.Elements,
 	itemID string,
+	_ *fault.Bus,
 ) (models.Postable, *details.GroupsInfo, error) {
 	return bh.Posts[itemID], bh.info[itemID], bh.getPostErr[itemID]
 }
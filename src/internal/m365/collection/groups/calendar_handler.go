@@ -0,0 +1,145 @@
+package groups
+
+import (
+	"context"
+	"io"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+var _ backupHandler[models.Calendarable, models.Eventable] = &calendarBackupHandler{}
+
+// calendarBackupHandler backs up the events in the group's shared calendar.
+// Groups have only the one calendar, so it is the only container.
+type calendarBackupHandler struct {
+	ac                api.GroupEvents
+	protectedResource string
+}
+
+func NewCalendarBackupHandler(
+	protectedResource string,
+	ac api.GroupEvents,
+) calendarBackupHandler {
+	return calendarBackupHandler{
+		ac:                ac,
+		protectedResource: protectedResource,
+	}
+}
+
+func (bh calendarBackupHandler) canMakeDeltaQueries() bool {
+	return true
+}
+
+//lint:ignore U1000 required for interface compliance
+func (bh calendarBackupHandler) getContainers(
+	ctx context.Context,
+	_ api.CallConfig,
+) ([]container[models.Calendarable], error) {
+	cal, err := bh.ac.GetCalendar(ctx, bh.protectedResource)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting calendar")
+	}
+
+	return []container[models.Calendarable]{calendarContainer(cal)}, nil
+}
+
+func (bh calendarBackupHandler) getContainerItemIDs(
+	ctx context.Context,
+	_ path.Elements,
+	prevDelta string,
+	cc api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	return bh.ac.GetAddedAndRemovedItemIDs(
+		ctx,
+		bh.protectedResource,
+		prevDelta,
+		cc)
+}
+
+//lint:ignore U1000 required for interface compliance
+func (bh calendarBackupHandler) includeContainer(
+	cal models.Calendarable,
+	scope selectors.GroupsScope,
+) bool {
+	return scope.Matches(selectors.GroupsEventCalendar, ptr.Val(cal.GetName()))
+}
+
+func (bh calendarBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			bh.protectedResource,
+			path.GroupsService,
+			path.EventsCategory,
+			false)
+}
+
+func (bh calendarBackupHandler) PathPrefix(tenantID string) (path.Path, error) {
+	return path.Build(
+		tenantID,
+		bh.protectedResource,
+		path.GroupsService,
+		path.EventsCategory,
+		false)
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh calendarBackupHandler) getItem(
+	ctx context.Context,
+	groupID string,
+	_ path.Elements,
+	eventID string,
+	_ *fault.Bus,
+) (models.Eventable, *details.GroupsInfo, error) {
+	return bh.ac.GetItem(ctx, groupID, eventID)
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh calendarBackupHandler) getItemMetadata(
+	_ context.Context,
+	_ models.Calendarable,
+) (io.ReadCloser, int, error) {
+	return nil, 0, errMetadataFilesNotSupported
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh calendarBackupHandler) augmentItemInfo(
+	*details.GroupsInfo,
+	models.Calendarable,
+) {
+	// no-op
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh calendarBackupHandler) supportsItemMetadata() bool {
+	return false
+}
+
+func (bh calendarBackupHandler) makeTombstones(
+	dps metadata.DeltaPaths,
+) (map[string]string, error) {
+	return makeTombstones(dps), nil
+}
+
+func calendarContainer(cal models.Calendarable) container[models.Calendarable] {
+	return container[models.Calendarable]{
+		storageDirFolders:   path.Elements{ptr.Val(cal.GetId())},
+		humanLocation:       path.Elements{ptr.Val(cal.GetName())},
+		canMakeDeltaQueries: true,
+		container:           cal,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -103,8 +104,9 @@ func (bh channelsBackupHandler) getItem(
 	groupID string,
 	containerIDs path.Elements,
 	messageID string,
+	errs *fault.Bus,
 ) (models.ChatMessageable, *details.GroupsInfo, error) {
-	return bh.ac.GetChannelMessage(ctx, groupID, containerIDs[0], messageID)
+	return bh.ac.GetChannelMessage(ctx, groupID, containerIDs[0], messageID, errs)
 }
 
 // Channel messages don't carry metadata files. Return unsupported error.
@@ -19,6 +19,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/extensions"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -29,6 +30,8 @@ import (
 var (
 	_ data.BackupCollection = &prefetchCollection[graph.GetIDer, groupsItemer]{}
 	_ data.BackupCollection = &lazyFetchCollection[graph.GetIDer, groupsItemer]{}
+	_ data.ItemEstimator    = &prefetchCollection[graph.GetIDer, groupsItemer]{}
+	_ data.ItemEstimator    = &lazyFetchCollection[graph.GetIDer, groupsItemer]{}
 )
 
 var errMetadataFilesNotSupported = clues.New("metadata files not supported")
@@ -126,6 +129,12 @@ func NewCollection[C graph.GetIDer, I groupsItemer](
 	}
 }
 
+// EstimateItems reports the number of items added to the container.  Item
+// sizes aren't known until the items are fetched.
+func (col *prefetchCollection[C, I]) EstimateItems() (int64, int64, bool) {
+	return int64(len(col.added)), 0, false
+}
+
 func (col *prefetchCollection[C, I]) Items(ctx context.Context, errs *fault.Bus) <-chan data.Item {
 	go col.streamItems(ctx, errs)
 	return col.stream
@@ -230,7 +239,8 @@ func (col *prefetchCollection[C, I]) streamItems(ctx context.Context, errs *faul
 				ctx,
 				col.protectedResource,
 				col.FullPath().Folders(),
-				id)
+				id,
+				el)
 			if err != nil {
 				err = clues.Wrap(err, "getting channel message data").Label(fault.LabelForceNoBackupCreation)
 				el.AddRecoverable(ctx, err)
@@ -255,12 +265,32 @@ func (col *prefetchCollection[C, I]) streamItems(ctx context.Context, errs *faul
 				return
 			}
 
+			if err := graph.WaitForDownload(ctx, len(itemData)); err != nil {
+				el.AddRecoverable(ctx, clues.Wrap(err, "waiting on download rate"))
+				return
+			}
+
 			info.ParentPath = col.LocationPath().String()
 
-			dataItem, err := data.NewPrefetchedItemWithInfo(
+			itemInfo := details.ItemInfo{Groups: info}
+
+			rc, extData, err := extensions.AddItemExtensions(
+				ictx,
 				io.NopCloser(bytes.NewReader(itemData)),
-				dataFile,
-				details.ItemInfo{Groups: info})
+				itemInfo,
+				col.Opts().ItemExtensionFactory)
+			if err != nil {
+				err := clues.WrapWC(ictx, err, "adding extensions").Label(fault.LabelForceNoBackupCreation)
+				el.AddRecoverable(ictx, err)
+
+				return
+			}
+
+			if extData.Data != nil {
+				itemInfo.Extension = extData
+			}
+
+			dataItem, err := data.NewPrefetchedItemWithInfo(rc, dataFile, itemInfo)
 			if err != nil {
 				err := clues.StackWC(ictx, err).Label(fault.LabelForceNoBackupCreation)
 				el.AddRecoverable(ictx, err)
@@ -333,6 +363,12 @@ type lazyFetchCollection[C graph.GetIDer, I groupsItemer] struct {
 	statusUpdater support.StatusUpdater
 }
 
+// EstimateItems reports the number of items added to the container.  Item
+// sizes aren't known until the items are fetched.
+func (col *lazyFetchCollection[C, I]) EstimateItems() (int64, int64, bool) {
+	return int64(len(col.added)), 0, false
+}
+
 func (col *lazyFetchCollection[C, I]) Items(
 	ctx context.Context,
 	errs *fault.Bus,
@@ -467,6 +503,7 @@ func (col *lazyFetchCollection[C, I]) streamItems(ctx context.Context, errs *fau
 					containerIDs:  col.FullPath().Folders(),
 					contains:      col.contains,
 					parentPath:    col.LocationPath().String(),
+					extensions:    col.Opts().ItemExtensionFactory,
 				},
 				dataFile,
 				modTime,
@@ -492,6 +529,7 @@ type lazyItemGetter[C graph.GetIDer, I groupsItemer] struct {
 	containerIDs  path.Elements
 	modTime       time.Time
 	contains      container[C]
+	extensions    []extensions.CreateItemExtensioner
 }
 
 func (lig *lazyItemGetter[C, I]) GetData(
@@ -505,7 +543,8 @@ func (lig *lazyItemGetter[C, I]) GetData(
 		ctx,
 		lig.resourceID,
 		lig.containerIDs,
-		lig.itemID)
+		lig.itemID,
+		errs)
 	if err != nil {
 		// For items that were deleted in flight, add the skip label so that
 		// they don't lead to recoverable failures during backup.
@@ -540,15 +579,37 @@ func (lig *lazyItemGetter[C, I]) GetData(
 		return nil, nil, false, err
 	}
 
+	if err := graph.WaitForDownload(ctx, len(itemData)); err != nil {
+		err = clues.WrapWC(ctx, err, "waiting on download rate")
+		errs.AddRecoverable(ctx, err)
+
+		return nil, nil, false, err
+	}
+
 	info.ParentPath = lig.parentPath
 	// Update the mod time to what we already told kopia about. This is required
 	// for proper details merging.
 	info.Modified = lig.modTime
 
-	return io.NopCloser(bytes.NewReader(itemData)),
-		&details.ItemInfo{Groups: info},
-		false,
-		nil
+	itemInfo := &details.ItemInfo{Groups: info}
+
+	rc, extData, err := extensions.AddItemExtensions(
+		ctx,
+		io.NopCloser(bytes.NewReader(itemData)),
+		*itemInfo,
+		lig.extensions)
+	if err != nil {
+		err = clues.WrapWC(ctx, err, "adding extensions").Label(fault.LabelForceNoBackupCreation)
+		errs.AddRecoverable(ctx, err)
+
+		return nil, nil, false, err
+	}
+
+	if extData.Data != nil {
+		itemInfo.Extension = extData
+	}
+
+	return rc, itemInfo, false, nil
 }
 
 func downloadItemMeta[C graph.GetIDer, I groupsItemer](
@@ -160,6 +160,7 @@ func (m getAndAugmentChannelMessage) getItem(
 	_ string,
 	_ path.Elements,
 	itemID string,
+	_ *fault.Bus,
 ) (models.ChatMessageable, *details.GroupsInfo, error) {
 	msg := models.NewChatMessage()
 	msg.SetId(ptr.To(itemID))
@@ -465,6 +466,7 @@ func (m *getAndAugmentConversation) getItem(
 	_ string,
 	_ path.Elements,
 	postID string,
+	_ *fault.Bus,
 ) (models.Postable, *details.GroupsInfo, error) {
 	m.CallIDs = append(m.CallIDs, postID)
 
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
@@ -13,6 +14,9 @@ import (
 	"github.com/alcionai/corso/src/internal/m365/collection/groups/metadata"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	deltaPath "github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -26,6 +30,10 @@ type conversationsBackupHandler struct {
 	protectedResource string
 	// SMTP address for the group
 	resourceEmail string
+	// conversationID/threadID -> the thread's lastDeliveredDateTime, as
+	// recorded when the containers were enumerated.  Posts have no delta
+	// query, so the delivery time stands in for a delta token.
+	lastDelivered map[string]time.Time
 }
 
 func NewConversationBackupHandler(
@@ -37,12 +45,14 @@ func NewConversationBackupHandler(
 		ac:                ac,
 		protectedResource: protectedResource,
 		resourceEmail:     resourceEmail,
+		lastDelivered:     map[string]time.Time{},
 	}
 }
 
 func (bh conversationsBackupHandler) canMakeDeltaQueries() bool {
-	// not supported for conversations
-	return false
+	// graph has no delta query for conversations; incrementals compare
+	// each thread's lastDeliveredDateTime against the previous backup.
+	return true
 }
 
 //lint:ignore U1000 required for interface compliance
@@ -70,25 +80,79 @@ func (bh conversationsBackupHandler) getContainers(
 		}
 
 		for _, thread := range threads {
-			results = append(results, conversationThreadContainer(conv, thread))
+			c := conversationThreadContainer(conv, thread)
+
+			if bh.lastDelivered != nil {
+				bh.lastDelivered[c.storageDirFolders.String()] = ptr.Val(thread.GetLastDeliveredDateTime())
+			}
+
+			results = append(results, c)
 		}
 	}
 
 	return results, nil
 }
 
+// getContainerItemIDs uses the thread's lastDeliveredDateTime in place of
+// a delta token.  The previous backup's delivery time is handed back as
+// prevDelta: if the thread hasn't received a post since then, nothing is
+// fetched; otherwise only the posts modified since then are added.  Posts
+// deleted from a thread don't change its delivery time, so they remain in
+// the backup until the thread itself is deleted or a full backup runs.
 func (bh conversationsBackupHandler) getContainerItemIDs(
 	ctx context.Context,
 	containerPath path.Elements,
-	_ string,
+	prevDelta string,
 	cc api.CallConfig,
 ) (pagers.AddedAndRemoved, error) {
-	return bh.ac.GetConversationThreadPostIDs(
+	var (
+		delivered      = bh.lastDelivered[containerPath.String()]
+		prevDelivered  time.Time
+		canIncremental = cc.CanMakeDeltaQueries && len(prevDelta) > 0 && !delivered.IsZero()
+	)
+
+	if canIncremental {
+		var err error
+
+		prevDelivered, err = dttm.ParseTime(prevDelta)
+		if err != nil {
+			logger.CtxErr(ctx, err).Info("unparseable previous delivery time; enumerating all posts")
+
+			canIncremental = false
+		}
+	}
+
+	if canIncremental && !delivered.After(prevDelivered) {
+		return pagers.AddedAndRemoved{
+			Added:         map[string]time.Time{},
+			Removed:       []string{},
+			DU:            pagers.DeltaUpdate{URL: prevDelta},
+			ValidModTimes: true,
+		}, nil
+	}
+
+	if !canIncremental {
+		prevDelivered = time.Time{}
+	}
+
+	aar, err := bh.ac.GetConversationThreadPostIDs(
 		ctx,
 		bh.protectedResource,
 		containerPath[0],
 		containerPath[1],
+		prevDelivered,
 		cc)
+	if err != nil {
+		return aar, err
+	}
+
+	aar.DU = pagers.DeltaUpdate{Reset: !canIncremental}
+
+	if !delivered.IsZero() {
+		aar.DU.URL = dttm.Format(delivered)
+	}
+
+	return aar, nil
 }
 
 //lint:ignore U1000 required for interface compliance
@@ -128,6 +192,7 @@ func (bh conversationsBackupHandler) getItem(
 	groupID string,
 	containerIDs path.Elements, // expects: [conversationID, threadID]
 	postID string,
+	_ *fault.Bus,
 ) (models.Postable, *details.GroupsInfo, error) {
 	return bh.ac.GetConversationPost(
 		ctx,
@@ -216,7 +281,7 @@ func conversationThreadContainer(
 		// "location" for the posts in the conversation.  We may need to revisit this, perhaps
 		// the subject (aka topic) is sufficiently acceptable.
 		humanLocation:       path.Elements{ptr.Val(c.GetTopic())},
-		canMakeDeltaQueries: false,
+		canMakeDeltaQueries: true,
 		container:           c,
 	}
 }
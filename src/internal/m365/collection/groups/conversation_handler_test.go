@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
@@ -15,6 +16,9 @@ import (
 	"github.com/alcionai/corso/src/internal/m365/collection/groups/metadata"
 	"github.com/alcionai/corso/src/internal/tester"
 	deltaPath "github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
 const (
@@ -134,3 +138,33 @@ func (suite *ConversationHandlerUnitSuite) TestMakeTombstones() {
 		})
 	}
 }
+
+func (suite *ConversationHandlerUnitSuite) TestGetContainerItemIDs_unchangedThread() {
+	var (
+		t         = suite.T()
+		delivered = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		prevDelta = dttm.Format(delivered)
+		bh        = conversationsBackupHandler{
+			lastDelivered: map[string]time.Time{
+				"cid/tid": delivered,
+			},
+		}
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	// the handler has no api client, so any graph call would panic.
+	aar, err := bh.getContainerItemIDs(
+		ctx,
+		path.Elements{"cid", "tid"},
+		prevDelta,
+		api.CallConfig{CanMakeDeltaQueries: true})
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Empty(t, aar.Added)
+	assert.Empty(t, aar.Removed)
+	assert.Equal(t, prevDelta, aar.DU.URL)
+	assert.False(t, aar.DU.Reset)
+	assert.True(t, aar.ValidModTimes)
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"time"
 
@@ -40,6 +42,10 @@ func NewExportCollection(
 		streamItems = streamChannelMessages
 	case path.ConversationPostsCategory:
 		streamItems = streamConversationPosts
+	case path.PlannerCategory:
+		streamItems = streamPlans
+	case path.TeamStructureCategory:
+		streamItems = streamTeamStructure
 	default:
 		return nil
 	}
@@ -73,23 +79,47 @@ func streamChannelMessages(
 
 	for _, rc := range drc {
 		for item := range rc.Items(ctx, errs) {
-			body, err := formatChannelMessage(cec, item.ToReader())
+			msg, bs, err := readChannelMessage(item.ToReader())
 			if err != nil {
 				ch <- export.Item{
 					ID:    item.ID(),
 					Error: err,
 				}
-			} else {
-				stats.UpdateResourceCount(path.ChannelMessagesCategory)
-				body = metrics.ReaderWithStats(body, path.ChannelMessagesCategory, stats)
 
-				// messages are exported as json and should be named as such
-				name := item.ID() + ".json"
+				continue
+			}
+
+			body, err := formatChannelMessage(cec, item.ID(), msg, bs)
+			if err != nil {
+				ch <- export.Item{
+					ID:    item.ID(),
+					Error: err,
+				}
+
+				continue
+			}
+
+			stats.UpdateResourceCount(path.ChannelMessagesCategory)
+			body = metrics.ReaderWithStats(body, path.ChannelMessagesCategory, stats)
+
+			// messages are exported as json and should be named as such
+			name := item.ID() + ".json"
+
+			ch <- export.Item{
+				ID:   item.ID(),
+				Name: name,
+				Body: body,
+			}
 
+			// inline images and attached files are exported next to the message.
+			for _, mc := range channelMessageContents(item.ID(), msg) {
 				ch <- export.Item{
 					ID:   item.ID(),
-					Name: name,
-					Body: body,
+					Name: mc.name,
+					Body: metrics.ReaderWithStats(
+						io.NopCloser(bytes.NewReader(mc.content)),
+						path.ChannelMessagesCategory,
+						stats),
 				}
 			}
 		}
@@ -114,12 +144,13 @@ func streamChannelMessages(
 
 type (
 	minimumChannelMessage struct {
-		Attachments          []minimumAttachment `json:"attachments"`
-		Content              string              `json:"content"`
-		CreatedDateTime      time.Time           `json:"createdDateTime"`
-		From                 string              `json:"from"`
-		LastModifiedDateTime time.Time           `json:"lastModifiedDateTime"`
-		Subject              string              `json:"subject"`
+		Attachments          []minimumAttachment    `json:"attachments"`
+		Content              string                 `json:"content"`
+		CreatedDateTime      time.Time              `json:"createdDateTime"`
+		From                 string                 `json:"from"`
+		HostedContents       []minimumHostedContent `json:"hostedContents,omitempty"`
+		LastModifiedDateTime time.Time              `json:"lastModifiedDateTime"`
+		Subject              string                 `json:"subject"`
 	}
 
 	minimumChannelMessageAndReplies struct {
@@ -130,35 +161,56 @@ type (
 	minimumAttachment struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
+		// File is the name of the exported copy of the attached file.
+		File string `json:"file,omitempty"`
 	}
-)
 
-func formatChannelMessage(
-	cec control.ExportConfig,
-	rc io.ReadCloser,
-) (io.ReadCloser, error) {
-	if cec.Format == control.JSONFormat {
-		return rc, nil
+	minimumHostedContent struct {
+		ID string `json:"id"`
+		// File is the name of the exported copy of the hosted content.
+		File string `json:"file"`
 	}
 
-	bs, err := io.ReadAll(rc)
-	if err != nil {
-		return nil, clues.Wrap(err, "reading item bytes")
+	// channelMessageContent is a hosted content or attached file that gets
+	// exported alongside the message.
+	channelMessageContent struct {
+		name    string
+		content []byte
 	}
+)
 
+func readChannelMessage(rc io.ReadCloser) (models.ChatMessageable, []byte, error) {
 	defer rc.Close()
 
+	bs, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "reading item bytes")
+	}
+
 	cfb, err := api.CreateFromBytes(bs, models.CreateChatMessageFromDiscriminatorValue)
 	if err != nil {
-		return nil, clues.Wrap(err, "deserializing bytes to message")
+		return nil, nil, clues.Wrap(err, "deserializing bytes to message")
 	}
 
 	msg, ok := cfb.(models.ChatMessageable)
 	if !ok {
-		return nil, clues.New("expected deserialized item to implement models.ChatMessageable")
+		return nil, nil, clues.New("expected deserialized item to implement models.ChatMessageable")
 	}
 
-	mItem := makeMinimumChannelMesasge(msg)
+	return msg, bs, nil
+}
+
+func formatChannelMessage(
+	cec control.ExportConfig,
+	itemID string,
+	msg models.ChatMessageable,
+	bs []byte,
+) (io.ReadCloser, error) {
+	if cec.Format == control.JSONFormat {
+		return io.NopCloser(bytes.NewReader(bs)), nil
+	}
+
+	mItem := makeMinimumChannelMesasge(itemID, msg)
 	replies := msg.GetReplies()
 
 	mcmar := minimumChannelMessageAndReplies{
@@ -167,10 +219,10 @@ func formatChannelMessage(
 	}
 
 	for _, r := range replies {
-		mcmar.Replies = append(mcmar.Replies, makeMinimumChannelMesasge(r))
+		mcmar.Replies = append(mcmar.Replies, makeMinimumChannelMesasge(itemID, r))
 	}
 
-	bs, err = marshalJSONContainingHTML(mcmar)
+	bs, err := marshalJSONContainingHTML(mcmar)
 	if err != nil {
 		return nil, clues.Wrap(err, "serializing minimized channel message")
 	}
@@ -193,7 +245,7 @@ func marshalJSONContainingHTML(a any) ([]byte, error) {
 	return buffer.Bytes(), clues.Stack(err).OrNil()
 }
 
-func makeMinimumChannelMesasge(item models.ChatMessageable) minimumChannelMessage {
+func makeMinimumChannelMesasge(itemID string, item models.ChatMessageable) minimumChannelMessage {
 	var content string
 
 	if item.GetBody() != nil {
@@ -204,9 +256,25 @@ func makeMinimumChannelMesasge(item models.ChatMessageable) minimumChannelMessag
 	minAttachments := make([]minimumAttachment, 0, len(attachments))
 
 	for _, a := range attachments {
+		var file string
+
+		if _, ok, err := api.ChatMessageFileContent(a); err == nil && ok {
+			file = attachmentFileName(itemID, item, a)
+		}
+
 		minAttachments = append(minAttachments, minimumAttachment{
 			ID:   ptr.Val(a.GetId()),
 			Name: ptr.Val(a.GetName()),
+			File: file,
+		})
+	}
+
+	var minHostedContents []minimumHostedContent
+
+	for i, hc := range item.GetHostedContents() {
+		minHostedContents = append(minHostedContents, minimumHostedContent{
+			ID:   ptr.Val(hc.GetId()),
+			File: hostedContentFileName(itemID, item, i, hc),
 		})
 	}
 
@@ -215,11 +283,83 @@ func makeMinimumChannelMesasge(item models.ChatMessageable) minimumChannelMessag
 		Content:              content,
 		CreatedDateTime:      ptr.Val(item.GetCreatedDateTime()),
 		From:                 api.GetChatMessageFrom(item),
+		HostedContents:       minHostedContents,
 		LastModifiedDateTime: ptr.Val(item.GetLastModifiedDateTime()),
 		Subject:              ptr.Val(item.GetSubject()),
 	}
 }
 
+// channelMessageContents collects the hosted contents and attached files
+// stored within the message and its replies.
+func channelMessageContents(
+	itemID string,
+	msg models.ChatMessageable,
+) []channelMessageContent {
+	var contents []channelMessageContent
+
+	for _, m := range append([]models.ChatMessageable{msg}, msg.GetReplies()...) {
+		for i, hc := range m.GetHostedContents() {
+			if len(hc.GetContentBytes()) == 0 {
+				continue
+			}
+
+			contents = append(contents, channelMessageContent{
+				name:    hostedContentFileName(itemID, m, i, hc),
+				content: hc.GetContentBytes(),
+			})
+		}
+
+		for _, a := range m.GetAttachments() {
+			content, ok, err := api.ChatMessageFileContent(a)
+			if err != nil || !ok {
+				continue
+			}
+
+			contents = append(contents, channelMessageContent{
+				name:    attachmentFileName(itemID, m, a),
+				content: content,
+			})
+		}
+	}
+
+	return contents
+}
+
+// messageFilePrefix scopes exported content names to the message (or the
+// reply) that holds them.
+func messageFilePrefix(itemID string, msg models.ChatMessageable) string {
+	prefix := itemID
+
+	if id := ptr.Val(msg.GetId()); len(id) > 0 && id != itemID {
+		prefix += "-" + id
+	}
+
+	return prefix
+}
+
+func attachmentFileName(
+	itemID string,
+	msg models.ChatMessageable,
+	att models.ChatMessageAttachmentable,
+) string {
+	name := ptr.Val(att.GetName())
+	if len(name) == 0 {
+		name = ptr.Val(att.GetId())
+	}
+
+	return messageFilePrefix(itemID, msg) + "-" + strings.ReplaceAll(name, "/", "_")
+}
+
+func hostedContentFileName(
+	itemID string,
+	msg models.ChatMessageable,
+	idx int,
+	hc models.ChatMessageHostedContentable,
+) string {
+	ext := api.HostedContentFileExtension(ptr.Val(hc.GetContentType()))
+	return fmt.Sprintf("%s-hosted-%d%s", messageFilePrefix(itemID, msg), idx, ext)
+}
+
 //-------------------------------------------------------------
 // Conversation Posts
 //-------------------------------------------------------------
@@ -318,6 +458,354 @@ func streamConversationPosts(
 	}
 }
 
+//-------------------------------------------------------------
+// Planner Plans
+//-------------------------------------------------------------
+
+// streamPlans adds the plan items into the export stream channel.
+func streamPlans(
+	ctx context.Context,
+	drc []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	streamFormattedItems(ctx, drc, cec, path.PlannerCategory, formatPlan, ch, stats)
+}
+
+func streamTeamStructure(
+	ctx context.Context,
+	drc []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	streamFormattedItems(ctx, drc, cec, path.TeamStructureCategory, formatTeamStructure, ch, stats)
+}
+
+// streamFormattedItems exports each item as a single json file, using the
+// format func to produce the file's contents.
+func streamFormattedItems(
+	ctx context.Context,
+	drc []data.RestoreCollection,
+	cec control.ExportConfig,
+	cat path.CategoryType,
+	format func(control.ExportConfig, io.ReadCloser) (io.ReadCloser, error),
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	defer close(ch)
+
+	errs := fault.New(false)
+
+	for _, rc := range drc {
+		for item := range rc.Items(ctx, errs) {
+			body, err := format(cec, item.ToReader())
+			if err != nil {
+				ch <- export.Item{
+					ID:    item.ID(),
+					Error: err,
+				}
+
+				continue
+			}
+
+			stats.UpdateResourceCount(cat)
+			body = metrics.ReaderWithStats(body, cat, stats)
+
+			ch <- export.Item{
+				ID:   item.ID(),
+				Name: item.ID() + ".json",
+				Body: body,
+			}
+		}
+
+		items, recovered := errs.ItemsAndRecovered()
+
+		// Return all the items that we failed to source from the persistence layer
+		for _, item := range items {
+			ch <- export.Item{
+				ID:    item.ID,
+				Error: &item,
+			}
+		}
+
+		for _, err := range recovered {
+			ch <- export.Item{
+				Error: err,
+			}
+		}
+	}
+}
+
+func formatPlan(
+	cec control.ExportConfig,
+	rc io.ReadCloser,
+) (io.ReadCloser, error) {
+	defer rc.Close()
+
+	bs, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, clues.Wrap(err, "reading item bytes")
+	}
+
+	if cec.Format == control.JSONFormat {
+		return io.NopCloser(bytes.NewReader(bs)), nil
+	}
+
+	plan, err := api.BytesToPlannerPlanable(bs)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	bs, err = marshalJSONContainingHTML(makeMinimumPlan(plan))
+	if err != nil {
+		return nil, clues.Wrap(err, "serializing minimized plan")
+	}
+
+	return io.NopCloser(bytes.NewReader(bs)), nil
+}
+
+type (
+	minimumPlan struct {
+		ID        string          `json:"id"`
+		Title     string          `json:"title"`
+		CreatedBy string          `json:"createdBy,omitempty"`
+		Created   time.Time       `json:"createdDateTime"`
+		Buckets   []minimumBucket `json:"buckets"`
+	}
+
+	minimumBucket struct {
+		ID    string        `json:"id"`
+		Name  string        `json:"name"`
+		Tasks []minimumTask `json:"tasks"`
+	}
+
+	minimumTask struct {
+		ID              string                 `json:"id"`
+		Title           string                 `json:"title"`
+		PercentComplete int32                  `json:"percentComplete"`
+		Priority        int32                  `json:"priority"`
+		Start           *time.Time             `json:"startDateTime,omitempty"`
+		Due             *time.Time             `json:"dueDateTime,omitempty"`
+		Completed       *time.Time             `json:"completedDateTime,omitempty"`
+		AssignedTo      []string               `json:"assignedTo,omitempty"`
+		Description     string                 `json:"description,omitempty"`
+		Checklist       []minimumChecklistItem `json:"checklist,omitempty"`
+	}
+
+	minimumChecklistItem struct {
+		Title     string `json:"title"`
+		IsChecked bool   `json:"isChecked"`
+		orderHint string
+	}
+)
+
+func makeMinimumPlan(plan models.PlannerPlanable) minimumPlan {
+	mp := minimumPlan{
+		ID:      ptr.Val(plan.GetId()),
+		Title:   ptr.Val(plan.GetTitle()),
+		Created: ptr.Val(plan.GetCreatedDateTime()),
+		Buckets: []minimumBucket{},
+	}
+
+	if plan.GetCreatedBy() != nil && plan.GetCreatedBy().GetUser() != nil {
+		mp.CreatedBy = ptr.Val(plan.GetCreatedBy().GetUser().GetDisplayName())
+	}
+
+	bucketIdx := map[string]int{}
+
+	for _, b := range plan.GetBuckets() {
+		bucketIdx[ptr.Val(b.GetId())] = len(mp.Buckets)
+		mp.Buckets = append(mp.Buckets, minimumBucket{
+			ID:    ptr.Val(b.GetId()),
+			Name:  ptr.Val(b.GetName()),
+			Tasks: []minimumTask{},
+		})
+	}
+
+	for _, t := range plan.GetTasks() {
+		idx, ok := bucketIdx[ptr.Val(t.GetBucketId())]
+		if !ok {
+			// tasks outside of any known bucket are collected together.
+			idx = len(mp.Buckets)
+			bucketIdx[ptr.Val(t.GetBucketId())] = idx
+			mp.Buckets = append(mp.Buckets, minimumBucket{
+				ID:    ptr.Val(t.GetBucketId()),
+				Tasks: []minimumTask{},
+			})
+		}
+
+		mp.Buckets[idx].Tasks = append(mp.Buckets[idx].Tasks, makeMinimumTask(t))
+	}
+
+	return mp
+}
+
+func makeMinimumTask(t models.PlannerTaskable) minimumTask {
+	mt := minimumTask{
+		ID:              ptr.Val(t.GetId()),
+		Title:           ptr.Val(t.GetTitle()),
+		PercentComplete: ptr.Val(t.GetPercentComplete()),
+		Priority:        ptr.Val(t.GetPriority()),
+		Start:           t.GetStartDateTime(),
+		Due:             t.GetDueDateTime(),
+		Completed:       t.GetCompletedDateTime(),
+	}
+
+	if t.GetAssignments() != nil {
+		for userID := range t.GetAssignments().GetAdditionalData() {
+			mt.AssignedTo = append(mt.AssignedTo, userID)
+		}
+
+		slices.Sort(mt.AssignedTo)
+	}
+
+	td := t.GetDetails()
+	if td == nil {
+		return mt
+	}
+
+	mt.Description = ptr.Val(td.GetDescription())
+
+	if td.GetChecklist() == nil {
+		return mt
+	}
+
+	for _, v := range td.GetChecklist().GetAdditionalData() {
+		ci, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		mci := minimumChecklistItem{}
+
+		if title, ok := ci["title"].(*string); ok {
+			mci.Title = ptr.Val(title)
+		}
+
+		if checked, ok := ci["isChecked"].(*bool); ok {
+			mci.IsChecked = ptr.Val(checked)
+		}
+
+		if hint, ok := ci["orderHint"].(*string); ok {
+			mci.orderHint = ptr.Val(hint)
+		}
+
+		mt.Checklist = append(mt.Checklist, mci)
+	}
+
+	// planner orders checklist items by an ordinal comparison of their hints.
+	slices.SortFunc(mt.Checklist, func(a, b minimumChecklistItem) int {
+		return strings.Compare(a.orderHint, b.orderHint)
+	})
+
+	return mt
+}
+
+func formatTeamStructure(
+	cec control.ExportConfig,
+	rc io.ReadCloser,
+) (io.ReadCloser, error) {
+	defer rc.Close()
+
+	bs, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, clues.Wrap(err, "reading item bytes")
+	}
+
+	if cec.Format == control.JSONFormat {
+		return io.NopCloser(bytes.NewReader(bs)), nil
+	}
+
+	team, err := api.BytesToTeamable(bs)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	bs, err = marshalJSONContainingHTML(makeMinimumTeam(team))
+	if err != nil {
+		return nil, clues.Wrap(err, "serializing minimized team")
+	}
+
+	return io.NopCloser(bytes.NewReader(bs)), nil
+}
+
+type (
+	minimumTeam struct {
+		ID          string           `json:"id"`
+		DisplayName string           `json:"displayName"`
+		Description string           `json:"description,omitempty"`
+		Channels    []minimumChannel `json:"channels"`
+		Apps        []string         `json:"apps"`
+	}
+
+	minimumChannel struct {
+		ID             string       `json:"id"`
+		DisplayName    string       `json:"displayName"`
+		Description    string       `json:"description,omitempty"`
+		MembershipType string       `json:"membershipType"`
+		Tabs           []minimumTab `json:"tabs"`
+	}
+
+	minimumTab struct {
+		DisplayName string `json:"displayName"`
+		App         string `json:"app"`
+		WebsiteURL  string `json:"websiteUrl,omitempty"`
+	}
+)
+
+func makeMinimumTeam(team models.Teamable) minimumTeam {
+	mt := minimumTeam{
+		ID:          ptr.Val(team.GetId()),
+		DisplayName: ptr.Val(team.GetDisplayName()),
+		Description: ptr.Val(team.GetDescription()),
+		Channels:    []minimumChannel{},
+		Apps:        []string{},
+	}
+
+	for _, ch := range team.GetChannels() {
+		mc := minimumChannel{
+			ID:          ptr.Val(ch.GetId()),
+			DisplayName: ptr.Val(ch.GetDisplayName()),
+			Description: ptr.Val(ch.GetDescription()),
+			Tabs:        []minimumTab{},
+		}
+
+		if ch.GetMembershipType() != nil {
+			mc.MembershipType = ch.GetMembershipType().String()
+		}
+
+		for _, tab := range ch.GetTabs() {
+			mtab := minimumTab{DisplayName: ptr.Val(tab.GetDisplayName())}
+
+			if tab.GetTeamsApp() != nil {
+				mtab.App = ptr.Val(tab.GetTeamsApp().GetDisplayName())
+			}
+
+			if tab.GetConfiguration() != nil {
+				mtab.WebsiteURL = ptr.Val(tab.GetConfiguration().GetWebsiteUrl())
+			}
+
+			mc.Tabs = append(mc.Tabs, mtab)
+		}
+
+		mt.Channels = append(mt.Channels, mc)
+	}
+
+	for _, app := range team.GetInstalledApps() {
+		if app.GetTeamsApp() != nil {
+			mt.Apps = append(mt.Apps, ptr.Val(app.GetTeamsApp().GetDisplayName()))
+		}
+	}
+
+	slices.Sort(mt.Apps)
+
+	return mt
+}
+
 func fetchAndReadMetadata(
 	ctx context.Context,
 	itemID string,
@@ -2,14 +2,19 @@ package groups
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/json"
 	"io"
 	"testing"
 
 	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
 	dataMock "github.com/alcionai/corso/src/internal/data/mock"
 	"github.com/alcionai/corso/src/internal/tester"
@@ -18,6 +23,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/export"
 	"github.com/alcionai/corso/src/pkg/metrics"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
 type ExportUnitSuite struct {
@@ -116,6 +122,82 @@ func (suite *ExportUnitSuite) TestStreamChannelMessages() {
 	}
 }
 
+func (suite *ExportUnitSuite) TestStreamChannelMessages_content() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	image := []byte("image bytes")
+	file := []byte("file bytes")
+
+	hc := models.NewChatMessageHostedContent()
+	hc.SetId(ptr.To("hcid"))
+	hc.SetContentType(ptr.To("image/png"))
+	hc.SetContentBytes(image)
+
+	att := models.NewChatMessageAttachment()
+	att.SetId(ptr.To("attid"))
+	att.SetName(ptr.To("notes.txt"))
+	att.SetAdditionalData(map[string]any{
+		api.ChatMessageFileContentKey: ptr.To(base64.StdEncoding.EncodeToString(file)),
+	})
+
+	reply := models.NewChatMessage()
+	reply.SetId(ptr.To("rid"))
+	reply.SetAttachments([]models.ChatMessageAttachmentable{att})
+
+	msg := models.NewChatMessage()
+	msg.SetId(ptr.To("mid"))
+	msg.SetHostedContents([]models.ChatMessageHostedContentable{hc})
+	msg.SetReplies([]models.ChatMessageable{reply})
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", msg)
+	require.NoError(t, err, clues.ToCore(err))
+
+	body, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	ch := make(chan export.Item)
+
+	go streamChannelMessages(
+		ctx,
+		[]data.RestoreCollection{dataMock.Collection{
+			ItemData: []data.Item{
+				&dataMock.Item{
+					ItemID: "mid",
+					Reader: io.NopCloser(bytes.NewReader(body)),
+				},
+			},
+		}},
+		version.NoBackup,
+		control.DefaultExportConfig(),
+		ch,
+		&metrics.ExportStats{})
+
+	results := map[string][]byte{}
+
+	for i := range ch {
+		require.NoError(t, i.Error, clues.ToCore(i.Error))
+
+		bs, err := io.ReadAll(i.Body)
+		require.NoError(t, err, clues.ToCore(err))
+
+		results[i.Name] = bs
+	}
+
+	require.Len(t, results, 3)
+	assert.Equal(t, image, results["mid-hosted-0.png"])
+	assert.Equal(t, file, results["mid-rid-notes.txt"])
+
+	transcript := string(results["mid.json"])
+	assert.Contains(t, transcript, `"file":"mid-hosted-0.png"`)
+	assert.Contains(t, transcript, `"file":"mid-rid-notes.txt"`)
+}
+
 func (suite *ExportUnitSuite) TestStreamConversationPosts() {
 	testPath, err := path.Build(
 		"t",
@@ -274,3 +356,213 @@ func (suite *ExportUnitSuite) TestStreamConversationPosts() {
 		})
 	}
 }
+
+func (suite *ExportUnitSuite) TestStreamPlans() {
+	checklist := models.NewPlannerChecklistItems()
+	checklist.SetAdditionalData(map[string]any{
+		"c2": map[string]any{
+			"title":     ptr.To("second"),
+			"isChecked": ptr.To(false),
+			"orderHint": ptr.To("8586"),
+		},
+		"c1": map[string]any{
+			"title":     ptr.To("first"),
+			"isChecked": ptr.To(true),
+			"orderHint": ptr.To("8585"),
+		},
+	})
+
+	td := models.NewPlannerTaskDetails()
+	td.SetDescription(ptr.To("all of them"))
+	td.SetChecklist(checklist)
+
+	task := models.NewPlannerTask()
+	task.SetId(ptr.To("tid"))
+	task.SetTitle(ptr.To("write the docs"))
+	task.SetBucketId(ptr.To("bid"))
+	task.SetDetails(td)
+
+	bucket := models.NewPlannerBucket()
+	bucket.SetId(ptr.To("bid"))
+	bucket.SetName(ptr.To("to do"))
+
+	plan := models.NewPlannerPlan()
+	plan.SetId(ptr.To("pid"))
+	plan.SetTitle(ptr.To("launch"))
+	plan.SetBuckets([]models.PlannerBucketable{bucket})
+	plan.SetTasks([]models.PlannerTaskable{task})
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", plan)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	body, err := writer.GetSerializedContent()
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name   string
+		cfg    control.ExportConfig
+		expect func(t *testing.T, bs []byte)
+	}{
+		{
+			name: "minimized",
+			cfg:  control.DefaultExportConfig(),
+			expect: func(t *testing.T, bs []byte) {
+				mp := minimumPlan{}
+
+				err := json.Unmarshal(bs, &mp)
+				require.NoError(t, err, clues.ToCore(err))
+
+				assert.Equal(t, "launch", mp.Title)
+				require.Len(t, mp.Buckets, 1)
+				assert.Equal(t, "to do", mp.Buckets[0].Name)
+				require.Len(t, mp.Buckets[0].Tasks, 1)
+
+				mt := mp.Buckets[0].Tasks[0]
+				assert.Equal(t, "write the docs", mt.Title)
+				assert.Equal(t, "all of them", mt.Description)
+				require.Len(t, mt.Checklist, 2)
+				assert.Equal(t, "first", mt.Checklist[0].Title)
+				assert.True(t, mt.Checklist[0].IsChecked)
+				assert.Equal(t, "second", mt.Checklist[1].Title)
+			},
+		},
+		{
+			name: "json",
+			cfg:  control.ExportConfig{Format: control.JSONFormat},
+			expect: func(t *testing.T, bs []byte) {
+				assert.Equal(t, body, bs)
+			},
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ch := make(chan export.Item)
+
+			go streamPlans(
+				ctx,
+				[]data.RestoreCollection{dataMock.Collection{
+					ItemData: []data.Item{
+						&dataMock.Item{
+							ItemID: "pid",
+							Reader: io.NopCloser(bytes.NewReader(body)),
+						},
+					},
+				}},
+				version.NoBackup,
+				test.cfg,
+				ch,
+				&metrics.ExportStats{})
+
+			results := []export.Item{}
+
+			for i := range ch {
+				require.NoError(t, i.Error, clues.ToCore(i.Error))
+				results = append(results, i)
+			}
+
+			require.Len(t, results, 1)
+			assert.Equal(t, "pid.json", results[0].Name)
+
+			bs, err := io.ReadAll(results[0].Body)
+			require.NoError(t, err, clues.ToCore(err))
+
+			test.expect(t, bs)
+		})
+	}
+}
+
+func (suite *ExportUnitSuite) TestStreamTeamStructure() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	tabApp := models.NewTeamsApp()
+	tabApp.SetDisplayName(ptr.To("Website"))
+
+	tabCfg := models.NewTeamsTabConfiguration()
+	tabCfg.SetWebsiteUrl(ptr.To("https://contoso.com"))
+
+	tab := models.NewTeamsTab()
+	tab.SetDisplayName(ptr.To("roadmap"))
+	tab.SetTeamsApp(tabApp)
+	tab.SetConfiguration(tabCfg)
+
+	mt := models.PRIVATE_CHANNELMEMBERSHIPTYPE
+
+	channel := models.NewChannel()
+	channel.SetId(ptr.To("cid"))
+	channel.SetDisplayName(ptr.To("secrets"))
+	channel.SetMembershipType(&mt)
+	channel.SetTabs([]models.TeamsTabable{tab})
+
+	app := models.NewTeamsAppInstallation()
+	app.SetTeamsApp(tabApp)
+
+	team := models.NewTeam()
+	team.SetId(ptr.To("tid"))
+	team.SetDisplayName(ptr.To("marketing"))
+	team.SetChannels([]models.Channelable{channel})
+	team.SetInstalledApps([]models.TeamsAppInstallationable{app})
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", team)
+	require.NoError(t, err, clues.ToCore(err))
+
+	body, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	ch := make(chan export.Item)
+
+	go streamTeamStructure(
+		ctx,
+		[]data.RestoreCollection{dataMock.Collection{
+			ItemData: []data.Item{
+				&dataMock.Item{
+					ItemID: "tid",
+					Reader: io.NopCloser(bytes.NewReader(body)),
+				},
+			},
+		}},
+		version.NoBackup,
+		control.DefaultExportConfig(),
+		ch,
+		&metrics.ExportStats{})
+
+	results := []export.Item{}
+
+	for i := range ch {
+		require.NoError(t, i.Error, clues.ToCore(i.Error))
+		results = append(results, i)
+	}
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "tid.json", results[0].Name)
+
+	bs, err := io.ReadAll(results[0].Body)
+	require.NoError(t, err, clues.ToCore(err))
+
+	result := minimumTeam{}
+
+	err = json.Unmarshal(bs, &result)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "marketing", result.DisplayName)
+	assert.Equal(t, []string{"Website"}, result.Apps)
+	require.Len(t, result.Channels, 1)
+	assert.Equal(t, "private", result.Channels[0].MembershipType)
+	require.Len(t, result.Channels[0].Tabs, 1)
+	assert.Equal(t, "Website", result.Channels[0].Tabs[0].App)
+	assert.Equal(t, "https://contoso.com", result.Channels[0].Tabs[0].WebsiteURL)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -17,10 +18,12 @@ import (
 
 // itemer standardizes common behavior that can be expected from all
 // items within a groups collection backup.
+// groupsItemer doesn't include graph.GetLastModifiedDateTimer: planner plans
+// and teams have no modification time in graph.  Collections take item mod
+// times from getContainerItemIDs instead.
 type groupsItemer interface {
 	serialization.Parsable
 	graph.GetIDer
-	graph.GetLastModifiedDateTimer
 }
 
 type backupHandler[C graph.GetIDer, I groupsItemer] interface {
@@ -53,6 +56,7 @@ type getItemer[I groupsItemer] interface {
 		protectedResource string,
 		containerIDs path.Elements,
 		itemID string,
+		errs *fault.Bus,
 	) (I, *details.GroupsInfo, error)
 }
 
@@ -23,6 +23,9 @@ func parseMetadataCollections(
 	cdp := metadata.CatDeltaPaths{
 		path.ChannelMessagesCategory:   {},
 		path.ConversationPostsCategory: {},
+		path.EventsCategory:            {},
+		path.PlannerCategory:           {},
+		path.TeamStructureCategory:     {},
 	}
 
 	// found tracks the metadata we've loaded, to make sure we don't
@@ -30,6 +33,9 @@ func parseMetadataCollections(
 	found := map[path.CategoryType]map[string]struct{}{
 		path.ChannelMessagesCategory:   {},
 		path.ConversationPostsCategory: {},
+		path.EventsCategory:            {},
+		path.PlannerCategory:           {},
+		path.TeamStructureCategory:     {},
 	}
 
 	// errors from metadata items should not stop the backup,
@@ -108,6 +114,9 @@ func parseMetadataCollections(
 		return metadata.CatDeltaPaths{
 			path.ChannelMessagesCategory:   {},
 			path.ConversationPostsCategory: {},
+			path.EventsCategory:            {},
+			path.PlannerCategory:           {},
+			path.TeamStructureCategory:     {},
 		}, false, nil
 	}
 
@@ -0,0 +1,154 @@
+package groups
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+var _ backupHandler[models.PlannerPlanable, models.PlannerPlanable] = &plannerBackupHandler{}
+
+// plannerBackupHandler backs up each plan as a container holding a single
+// item: the plan itself, populated with its buckets, tasks, and details.
+type plannerBackupHandler struct {
+	ac                api.Planner
+	protectedResource string
+}
+
+func NewPlannerBackupHandler(
+	protectedResource string,
+	ac api.Planner,
+) plannerBackupHandler {
+	return plannerBackupHandler{
+		ac:                ac,
+		protectedResource: protectedResource,
+	}
+}
+
+func (bh plannerBackupHandler) canMakeDeltaQueries() bool {
+	// planner has no delta support in the v1 api
+	return false
+}
+
+//lint:ignore U1000 required for interface compliance
+func (bh plannerBackupHandler) getContainers(
+	ctx context.Context,
+	cc api.CallConfig,
+) ([]container[models.PlannerPlanable], error) {
+	plans, err := bh.ac.GetPlans(ctx, bh.protectedResource, cc)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting plans")
+	}
+
+	results := make([]container[models.PlannerPlanable], 0, len(plans))
+
+	for _, plan := range plans {
+		results = append(results, planContainer(plan))
+	}
+
+	return results, nil
+}
+
+func (bh plannerBackupHandler) getContainerItemIDs(
+	_ context.Context,
+	containerPath path.Elements,
+	_ string,
+	_ api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	// planner doesn't expose a modification time for plans, so the plan
+	// is always fetched again.
+	return pagers.AddedAndRemoved{
+		Added:         map[string]time.Time{containerPath[0]: time.Now()},
+		ValidModTimes: false,
+	}, nil
+}
+
+//lint:ignore U1000 required for interface compliance
+func (bh plannerBackupHandler) includeContainer(
+	plan models.PlannerPlanable,
+	scope selectors.GroupsScope,
+) bool {
+	return scope.Matches(selectors.GroupsPlan, ptr.Val(plan.GetTitle()))
+}
+
+func (bh plannerBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			bh.protectedResource,
+			path.GroupsService,
+			path.PlannerCategory,
+			false)
+}
+
+func (bh plannerBackupHandler) PathPrefix(tenantID string) (path.Path, error) {
+	return path.Build(
+		tenantID,
+		bh.protectedResource,
+		path.GroupsService,
+		path.PlannerCategory,
+		false)
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh plannerBackupHandler) getItem(
+	ctx context.Context,
+	_ string,
+	_ path.Elements,
+	planID string,
+	_ *fault.Bus,
+) (models.PlannerPlanable, *details.GroupsInfo, error) {
+	return bh.ac.GetPlan(ctx, planID)
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh plannerBackupHandler) getItemMetadata(
+	_ context.Context,
+	_ models.PlannerPlanable,
+) (io.ReadCloser, int, error) {
+	return nil, 0, errMetadataFilesNotSupported
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh plannerBackupHandler) augmentItemInfo(
+	*details.GroupsInfo,
+	models.PlannerPlanable,
+) {
+	// no-op
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh plannerBackupHandler) supportsItemMetadata() bool {
+	return false
+}
+
+func (bh plannerBackupHandler) makeTombstones(
+	dps metadata.DeltaPaths,
+) (map[string]string, error) {
+	return makeTombstones(dps), nil
+}
+
+func planContainer(plan models.PlannerPlanable) container[models.PlannerPlanable] {
+	return container[models.PlannerPlanable]{
+		storageDirFolders:   path.Elements{ptr.Val(plan.GetId())},
+		humanLocation:       path.Elements{ptr.Val(plan.GetTitle())},
+		canMakeDeltaQueries: false,
+		container:           plan,
+	}
+}
@@ -0,0 +1,309 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type PlanRestorer interface {
+	GetPlans(
+		ctx context.Context,
+		groupID string,
+		cc api.CallConfig,
+	) ([]models.PlannerPlanable, error)
+	PostPlan(
+		ctx context.Context,
+		groupID, title string,
+	) (models.PlannerPlanable, error)
+	PatchPlanDetails(
+		ctx context.Context,
+		planID string,
+		pd models.PlannerPlanDetailsable,
+	) error
+	PostBucket(
+		ctx context.Context,
+		planID string,
+		bucket models.PlannerBucketable,
+	) (models.PlannerBucketable, error)
+	PostTask(
+		ctx context.Context,
+		planID, bucketID string,
+		task models.PlannerTaskable,
+	) (models.PlannerTaskable, error)
+	PatchTaskDetails(
+		ctx context.Context,
+		taskID string,
+		td models.PlannerTaskDetailsable,
+	) error
+	DeletePlan(ctx context.Context, planID string) error
+}
+
+var _ PlanRestorer = api.Planner{}
+
+// PlanCollisionKeys maps the title of each plan in the group to its ID.
+func PlanCollisionKeys(
+	ctx context.Context,
+	pr PlanRestorer,
+	groupID string,
+) (map[string]string, error) {
+	plans, err := pr.GetPlans(ctx, groupID, api.CallConfig{Select: []string{"id", "title"}})
+	if err != nil {
+		return nil, clues.Wrap(err, "getting plans")
+	}
+
+	keys := make(map[string]string, len(plans))
+
+	for _, plan := range plans {
+		keys[ptr.Val(plan.GetTitle())] = ptr.Val(plan.GetId())
+	}
+
+	return keys, nil
+}
+
+// RestorePlans recreates each plan in the collection within the group.
+// Plans are created fresh, with their buckets, tasks, and task details
+// added in turn.  Collisions are identified by plan title.
+func RestorePlans(
+	ctx context.Context,
+	pr PlanRestorer,
+	dc data.RestoreCollection,
+	groupID string,
+	restoreCfg control.RestoreConfig,
+	collisionKeyToPlanID map[string]string,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	return restoreItems(
+		ctx,
+		dc,
+		deets,
+		errs,
+		func(ictx context.Context, body []byte, el *fault.Bus) (*details.GroupsInfo, string, error) {
+			info, err := restorePlan(
+				ictx,
+				pr,
+				body,
+				groupID,
+				restoreCfg,
+				collisionKeyToPlanID,
+				el,
+				ctr)
+			if err != nil {
+				return nil, "", err
+			}
+
+			return info, info.Plan.Title, nil
+		})
+}
+
+// restoreItemFunc restores a single item from its serialized body,
+// returning the restored item's info and the name used to display it.
+type restoreItemFunc func(
+	ctx context.Context,
+	body []byte,
+	errs *fault.Bus,
+) (*details.GroupsInfo, string, error)
+
+// restoreItems runs the restore func for each item in the collection,
+// tracking metrics and details for every item that gets restored.
+func restoreItems(
+	ctx context.Context,
+	dc data.RestoreCollection,
+	deets *details.Builder,
+	errs *fault.Bus,
+	restore restoreItemFunc,
+) (support.CollectionMetrics, error) {
+	var (
+		el       = errs.Local()
+		metrics  support.CollectionMetrics
+		items    = dc.Items(ctx, errs)
+		fullPath = dc.FullPath()
+	)
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		fullPath.Category().HumanString(),
+		fullPath.Folder(false))
+	defer close(progressMessage)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return metrics, clues.WrapWC(ctx, ctx.Err(), "context cancelled")
+
+		case itemData, ok := <-items:
+			if !ok || el.Failure() != nil {
+				return metrics, el.Failure()
+			}
+
+			ictx := clues.Add(ctx, "item_id", itemData.ID())
+			metrics.Objects++
+
+			buf := &bytes.Buffer{}
+
+			_, err := buf.ReadFrom(itemData.ToReader())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading item bytes"))
+				continue
+			}
+
+			body := buf.Bytes()
+
+			info, name, err := restore(ictx, body, el)
+			if err != nil {
+				if !errors.Is(err, core.ErrAlreadyExists) {
+					el.AddRecoverable(ictx, clues.Wrap(err, "restoring item"))
+				}
+
+				continue
+			}
+
+			metrics.Bytes += int64(len(body))
+			metrics.Successes++
+
+			itemPath, err := fullPath.AppendItem(itemData.ID())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "adding item to collection path"))
+				continue
+			}
+
+			err = deets.Add(
+				itemPath,
+				path.Builder{}.Append(name),
+				details.ItemInfo{Groups: info})
+			if err != nil {
+				// These deets additions are for cli display purposes only.
+				// no need to fail out on error.
+				logger.Ctx(ictx).Infow("accounting for restored item", "error", err)
+			}
+
+			progressMessage <- struct{}{}
+		}
+	}
+}
+
+func restorePlan(
+	ctx context.Context,
+	pr PlanRestorer,
+	body []byte,
+	groupID string,
+	restoreCfg control.RestoreConfig,
+	collisionKeyToPlanID map[string]string,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (*details.GroupsInfo, error) {
+	plan, err := api.BytesToPlannerPlanable(body)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "creating plan from bytes")
+	}
+
+	var (
+		title                = restoredPlanTitle(restoreCfg.Location, ptr.Val(plan.GetTitle()))
+		collisionID          string
+		shouldDeleteOriginal bool
+	)
+
+	ctx = clues.Add(ctx, "plan_id", ptr.Val(plan.GetId()))
+
+	if id, ok := collisionKeyToPlanID[title]; ok {
+		log := logger.Ctx(ctx).With("collision_key", clues.Hide(title))
+		log.Debug("item collision")
+
+		if restoreCfg.OnCollision == control.Skip {
+			ctr.Inc(count.CollisionSkip)
+			log.Debug("skipping item with collision")
+
+			return nil, core.ErrAlreadyExists
+		}
+
+		collisionID = id
+		shouldDeleteOriginal = restoreCfg.OnCollision == control.Replace
+	}
+
+	created, err := pr.PostPlan(ctx, groupID, title)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	planID := ptr.Val(created.GetId())
+	ctx = clues.Add(ctx, "restored_plan_id", planID)
+
+	// everything past the plan itself is restored on a best-effort basis;
+	// a failed task shouldn't cost the user the rest of the plan.
+	if err := pr.PatchPlanDetails(ctx, planID, plan.GetDetails()); err != nil {
+		errs.AddRecoverable(ctx, clues.Stack(err))
+	}
+
+	bucketIDs := map[string]string{}
+
+	for _, bucket := range plan.GetBuckets() {
+		nb, err := pr.PostBucket(ctx, planID, bucket)
+		if err != nil {
+			errs.AddRecoverable(ctx, clues.Stack(err).With("bucket_id", ptr.Val(bucket.GetId())))
+			continue
+		}
+
+		bucketIDs[ptr.Val(bucket.GetId())] = ptr.Val(nb.GetId())
+	}
+
+	for _, task := range plan.GetTasks() {
+		tctx := clues.Add(ctx, "task_id", ptr.Val(task.GetId()))
+
+		nt, err := pr.PostTask(tctx, planID, bucketIDs[ptr.Val(task.GetBucketId())], task)
+		if err != nil {
+			errs.AddRecoverable(tctx, clues.Stack(err))
+			continue
+		}
+
+		if err := pr.PatchTaskDetails(tctx, ptr.Val(nt.GetId()), task.GetDetails()); err != nil {
+			errs.AddRecoverable(tctx, clues.Stack(err))
+		}
+	}
+
+	// plans have no PUT request, so the same as other services, we create
+	// the new plan first and delete the original afterward.
+	if shouldDeleteOriginal {
+		err := pr.DeletePlan(ctx, collisionID)
+		if err != nil && !errors.Is(err, core.ErrNotFound) {
+			return nil, clues.Wrap(err, "deleting colliding plan")
+		}
+
+		ctr.Inc(count.CollisionReplace)
+	} else {
+		ctr.Inc(count.NewItemCreated)
+	}
+
+	info := api.PlannerPlanInfo(plan, int64(len(body)))
+	info.Plan.Title = title
+
+	return info, nil
+}
+
+// restoredPlanTitle prefixes the plan title with the restore location, so
+// that restored plans can be told apart from the originals.  Plans have no
+// folders to restore into, so this is the nearest equivalent.
+func restoredPlanTitle(location, title string) string {
+	if len(location) == 0 || location == "/" {
+		return title
+	}
+
+	return location + " - " + title
+}
@@ -0,0 +1,98 @@
+package groups
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/exchange"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// GroupEventRestorer covers the calls needed to restore events into the
+// group's calendar.
+type GroupEventRestorer interface {
+	exchange.EventRestorer
+	GetItemsInContainerByCollisionKey(
+		ctx context.Context,
+		groupID, calendarID string,
+	) (map[string]string, error)
+}
+
+var _ GroupEventRestorer = api.GroupEvents{}
+
+// EventCollisionKeys maps the collision key of each event in the group's
+// calendar to its ID.
+func EventCollisionKeys(
+	ctx context.Context,
+	er GroupEventRestorer,
+	groupID string,
+) (map[string]string, error) {
+	keys, err := er.GetItemsInContainerByCollisionKey(ctx, groupID, "")
+	if err != nil {
+		return nil, clues.Wrap(err, "getting event collision keys")
+	}
+
+	return keys, nil
+}
+
+// RestoreEvents recreates each event in the collection within the group's
+// calendar.  Groups have only the one calendar, so unlike exchange there
+// is no restore folder; events are restored alongside the existing ones,
+// and collisions are handled the same way as for user calendars.
+func RestoreEvents(
+	ctx context.Context,
+	er GroupEventRestorer,
+	dc data.RestoreCollection,
+	groupID string,
+	restoreCfg control.RestoreConfig,
+	collisionKeyToItemID map[string]string,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	return restoreItems(
+		ctx,
+		dc,
+		deets,
+		errs,
+		func(ictx context.Context, body []byte, el *fault.Bus) (*details.GroupsInfo, string, error) {
+			ei, err := exchange.RestoreEvent(
+				ictx,
+				er,
+				body,
+				groupID,
+				"",
+				collisionKeyToItemID,
+				restoreCfg.OnCollision,
+				el,
+				ctr)
+			if err != nil {
+				return nil, "", err
+			}
+
+			return groupEventInfoFromExchange(ei), ei.Subject, nil
+		})
+}
+
+func groupEventInfoFromExchange(ei *details.ExchangeInfo) *details.GroupsInfo {
+	return &details.GroupsInfo{
+		ItemType: details.GroupsCalendarEvent,
+		Created:  ei.Created,
+		Modified: ei.Modified,
+		Size:     ei.Size,
+		Event: details.CalendarEventInfo{
+			EventEnd:    ei.EventEnd,
+			EventRecurs: ei.EventRecurs,
+			EventStart:  ei.EventStart,
+			Organizer:   ei.Organizer,
+			Subject:     ei.Subject,
+		},
+	}
+}
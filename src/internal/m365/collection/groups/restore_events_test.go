@@ -0,0 +1,250 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+var _ GroupEventRestorer = &mockGroupEventRestorer{}
+
+type mockGroupEventRestorer struct {
+	collisionKeys map[string]string
+	postedGroups  []string
+	deleted       []string
+}
+
+func (m *mockGroupEventRestorer) GetItemsInContainerByCollisionKey(
+	context.Context,
+	string, string,
+) (map[string]string, error) {
+	return m.collisionKeys, nil
+}
+
+func (m *mockGroupEventRestorer) PostItem(
+	_ context.Context,
+	groupID, _ string,
+	body models.Eventable,
+) (models.Eventable, error) {
+	m.postedGroups = append(m.postedGroups, groupID)
+
+	evt := models.NewEvent()
+	evt.SetId(ptr.To("new-eid"))
+	evt.SetSubject(body.GetSubject())
+
+	return evt, nil
+}
+
+func (m *mockGroupEventRestorer) DeleteItem(
+	_ context.Context,
+	_, itemID string,
+) error {
+	m.deleted = append(m.deleted, itemID)
+	return nil
+}
+
+func (m *mockGroupEventRestorer) GetAttachments(
+	context.Context,
+	string, string,
+) ([]models.Attachmentable, error) {
+	return nil, nil
+}
+
+func (m *mockGroupEventRestorer) DeleteAttachment(
+	context.Context,
+	string, string, string, string,
+) error {
+	return nil
+}
+
+func (m *mockGroupEventRestorer) PostSmallAttachment(
+	context.Context,
+	string, string, string,
+	models.Attachmentable,
+) error {
+	return nil
+}
+
+func (m *mockGroupEventRestorer) PostLargeAttachment(
+	context.Context,
+	string, string, string, string,
+	[]byte,
+) (string, error) {
+	return "", nil
+}
+
+func (m *mockGroupEventRestorer) GetItemInstances(
+	context.Context,
+	string, string, string, string,
+) ([]models.Eventable, error) {
+	return nil, nil
+}
+
+func (m *mockGroupEventRestorer) PatchItem(
+	_ context.Context,
+	_, _ string,
+	body models.Eventable,
+) (models.Eventable, error) {
+	return body, nil
+}
+
+type RestoreEventsUnitSuite struct {
+	tester.Suite
+}
+
+func TestRestoreEventsUnitSuite(t *testing.T) {
+	suite.Run(t, &RestoreEventsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func eventBytes(t *testing.T) ([]byte, string) {
+	evt := models.NewEvent()
+	evt.SetId(ptr.To("eid"))
+	evt.SetSubject(ptr.To("standup"))
+
+	body := models.NewItemBody()
+	body.SetContent(ptr.To("daily sync"))
+	evt.SetBody(body)
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", evt)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return bs, api.EventCollisionKey(evt)
+}
+
+func (suite *RestoreEventsUnitSuite) TestRestoreEvents() {
+	fullPath, err := path.Build("t", "g", path.GroupsService, path.EventsCategory, false, "cid")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	body, collisionKey := eventBytes(suite.T())
+
+	table := []struct {
+		name           string
+		onCollision    control.CollisionPolicy
+		collisionKeys  map[string]string
+		expectPosted   []string
+		expectDeleted  []string
+		expectSuccess  int
+		expectCountKey count.Key
+	}{
+		{
+			name:           "no collision",
+			onCollision:    control.Skip,
+			collisionKeys:  map[string]string{},
+			expectPosted:   []string{"g"},
+			expectSuccess:  1,
+			expectCountKey: count.NewItemCreated,
+		},
+		{
+			name:           "collision skip",
+			onCollision:    control.Skip,
+			collisionKeys:  map[string]string{collisionKey: "eid"},
+			expectCountKey: count.CollisionSkip,
+		},
+		{
+			name:           "collision copy",
+			onCollision:    control.Copy,
+			collisionKeys:  map[string]string{collisionKey: "eid"},
+			expectPosted:   []string{"g"},
+			expectSuccess:  1,
+			expectCountKey: count.NewItemCreated,
+		},
+		{
+			name:           "collision replace",
+			onCollision:    control.Replace,
+			collisionKeys:  map[string]string{collisionKey: "eid"},
+			expectPosted:   []string{"g"},
+			expectDeleted:  []string{"eid"},
+			expectSuccess:  1,
+			expectCountKey: count.CollisionReplace,
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				er    = &mockGroupEventRestorer{}
+				ctr   = count.New()
+				deets = &details.Builder{}
+				dc    = dataMock.Collection{
+					Path: fullPath,
+					ItemData: []data.Item{
+						&dataMock.Item{
+							ItemID: "eid",
+							Reader: io.NopCloser(bytes.NewReader(body)),
+						},
+					},
+				}
+			)
+
+			metrics, err := RestoreEvents(
+				ctx,
+				er,
+				dc,
+				"g",
+				control.RestoreConfig{OnCollision: test.onCollision},
+				test.collisionKeys,
+				deets,
+				fault.New(true),
+				ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, 1, metrics.Objects)
+			assert.Equal(t, test.expectSuccess, metrics.Successes)
+			assert.Equal(t, test.expectPosted, er.postedGroups)
+			assert.Equal(t, test.expectDeleted, er.deleted)
+			assert.Equal(t, int64(1), ctr.Get(test.expectCountKey))
+
+			items := deets.Details().Items()
+			require.Len(t, items, test.expectSuccess)
+
+			for _, item := range items {
+				require.NotNil(t, item.Groups)
+				assert.Equal(t, details.GroupsCalendarEvent, item.Groups.ItemType)
+				assert.Equal(t, "standup", item.Groups.Event.Subject)
+			}
+		})
+	}
+}
+
+func (suite *RestoreEventsUnitSuite) TestEventCollisionKeys() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	expect := map[string]string{"key": "eid"}
+
+	keys, err := EventCollisionKeys(ctx, &mockGroupEventRestorer{collisionKeys: expect}, "g")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, expect, keys)
+}
@@ -0,0 +1,305 @@
+package groups
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type TeamRestorer interface {
+	GetTeamByID(
+		ctx context.Context,
+		identifier string,
+		cc api.CallConfig,
+	) (models.Teamable, error)
+	PutTeam(
+		ctx context.Context,
+		groupID string,
+		team models.Teamable,
+	) (models.Teamable, error)
+	PatchTeam(
+		ctx context.Context,
+		teamID string,
+		team models.Teamable,
+	) error
+	GetInstalledApps(
+		ctx context.Context,
+		teamID string,
+	) ([]models.TeamsAppInstallationable, error)
+	PostInstalledApp(ctx context.Context, teamID, appID string) error
+}
+
+var _ TeamRestorer = api.Groups{}
+
+type ChannelRestorer interface {
+	GetChannels(ctx context.Context, teamID string) ([]models.Channelable, error)
+	PostChannel(
+		ctx context.Context,
+		teamID string,
+		channel models.Channelable,
+	) (models.Channelable, error)
+	PatchChannelModerationSettings(
+		ctx context.Context,
+		teamID, channelID string,
+		settings any,
+	) error
+	GetChannelTabs(
+		ctx context.Context,
+		teamID, channelID string,
+	) ([]models.TeamsTabable, error)
+	PostTab(
+		ctx context.Context,
+		teamID, channelID string,
+		tab models.TeamsTabable,
+	) (models.TeamsTabable, error)
+	DeleteTab(ctx context.Context, teamID, channelID, tabID string) error
+}
+
+var _ ChannelRestorer = api.Channels{}
+
+// tabs for these apps get added to every channel by teams itself, and
+// can't be created through the api.
+var autoCreatedTabAppIDs = map[string]struct{}{
+	"com.microsoft.teamspace.tab.files.sharepoint": {},
+	"com.microsoft.teamspace.tab.wiki":             {},
+}
+
+// RestoreTeamStructure recreates the team's settings, installed apps,
+// channels, and tabs within the group.  If the group doesn't have a team,
+// a new team is created for it.  Channels are matched to existing channels
+// by name, and merged into them.  Collisions between tabs are identified
+// by tab name within the channel.
+//
+// Channels can't be nested within a folder, so the restore location is
+// not used.
+func RestoreTeamStructure(
+	ctx context.Context,
+	tr TeamRestorer,
+	cr ChannelRestorer,
+	dc data.RestoreCollection,
+	groupID string,
+	restoreCfg control.RestoreConfig,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	return restoreItems(
+		ctx,
+		dc,
+		deets,
+		errs,
+		func(ictx context.Context, body []byte, el *fault.Bus) (*details.GroupsInfo, string, error) {
+			info, err := restoreTeam(ictx, tr, cr, body, groupID, restoreCfg, el, ctr)
+			if err != nil {
+				return nil, "", err
+			}
+
+			return info, info.Team.DisplayName, nil
+		})
+}
+
+func restoreTeam(
+	ctx context.Context,
+	tr TeamRestorer,
+	cr ChannelRestorer,
+	body []byte,
+	groupID string,
+	restoreCfg control.RestoreConfig,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (*details.GroupsInfo, error) {
+	team, err := api.BytesToTeamable(body)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "creating team from bytes")
+	}
+
+	ctx = clues.Add(ctx, "team_id", ptr.Val(team.GetId()))
+
+	_, err = tr.GetTeamByID(ctx, groupID, api.CallConfig{})
+
+	switch {
+	case errors.Is(err, core.ErrNotFound):
+		logger.Ctx(ctx).Info("group has no team, creating one")
+
+		if _, err := tr.PutTeam(ctx, groupID, team); err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		ctr.Inc(count.NewItemCreated)
+	case err != nil:
+		return nil, clues.Wrap(err, "getting team")
+	default:
+		// everything past the team itself is restored on a best-effort basis;
+		// a failed tab shouldn't cost the user the rest of the team.
+		if err := tr.PatchTeam(ctx, groupID, team); err != nil {
+			errs.AddRecoverable(ctx, clues.Stack(err))
+		}
+	}
+
+	// apps need to be installed before any tabs that use them get added.
+	if err := restoreInstalledApps(ctx, tr, team.GetInstalledApps(), groupID, errs); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	existing, err := cr.GetChannels(ctx, groupID)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting channels")
+	}
+
+	channelIDs := map[string]string{}
+
+	for _, ch := range existing {
+		channelIDs[strings.ToLower(ptr.Val(ch.GetDisplayName()))] = ptr.Val(ch.GetId())
+	}
+
+	for _, ch := range team.GetChannels() {
+		cctx := clues.Add(ctx, "channel_id", ptr.Val(ch.GetId()))
+
+		channelID, ok := channelIDs[strings.ToLower(ptr.Val(ch.GetDisplayName()))]
+		if !ok {
+			nc, err := cr.PostChannel(cctx, groupID, ch)
+			if err != nil {
+				errs.AddRecoverable(cctx, clues.Stack(err))
+				continue
+			}
+
+			channelID = ptr.Val(nc.GetId())
+
+			ctr.Inc(count.NewItemCreated)
+		}
+
+		cctx = clues.Add(cctx, "restored_channel_id", channelID)
+
+		settings, ok := ch.GetAdditionalData()[api.ChannelModerationSettingsKey]
+		if ok && settings != nil {
+			err := cr.PatchChannelModerationSettings(cctx, groupID, channelID, settings)
+			if err != nil {
+				errs.AddRecoverable(cctx, clues.Stack(err))
+			}
+		}
+
+		restoreTabs(cctx, cr, ch.GetTabs(), groupID, channelID, restoreCfg, errs, ctr)
+	}
+
+	return api.TeamStructureInfo(team, int64(len(body))), nil
+}
+
+func restoreInstalledApps(
+	ctx context.Context,
+	tr TeamRestorer,
+	apps []models.TeamsAppInstallationable,
+	teamID string,
+	errs *fault.Bus,
+) error {
+	installed, err := tr.GetInstalledApps(ctx, teamID)
+	if err != nil {
+		return clues.Wrap(err, "getting installed apps")
+	}
+
+	installedIDs := map[string]struct{}{}
+
+	for _, app := range installed {
+		installedIDs[api.TeamsInstalledAppID(app)] = struct{}{}
+	}
+
+	for _, app := range apps {
+		appID := api.TeamsInstalledAppID(app)
+
+		if _, ok := installedIDs[appID]; ok || len(appID) == 0 {
+			continue
+		}
+
+		if err := tr.PostInstalledApp(ctx, teamID, appID); err != nil {
+			errs.AddRecoverable(ctx, clues.Stack(err))
+			continue
+		}
+
+		installedIDs[appID] = struct{}{}
+	}
+
+	return nil
+}
+
+func restoreTabs(
+	ctx context.Context,
+	cr ChannelRestorer,
+	tabs []models.TeamsTabable,
+	teamID, channelID string,
+	restoreCfg control.RestoreConfig,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) {
+	existing, err := cr.GetChannelTabs(ctx, teamID, channelID)
+	if err != nil {
+		errs.AddRecoverable(ctx, clues.Wrap(err, "getting channel tabs"))
+		return
+	}
+
+	collisionKeyToTabID := map[string]string{}
+
+	for _, tab := range existing {
+		collisionKeyToTabID[ptr.Val(tab.GetDisplayName())] = ptr.Val(tab.GetId())
+	}
+
+	for _, tab := range tabs {
+		var (
+			appID                = api.TeamsTabAppID(tab)
+			name                 = ptr.Val(tab.GetDisplayName())
+			tctx                 = clues.Add(ctx, "tab_id", ptr.Val(tab.GetId()), "app_id", appID)
+			collisionID          string
+			shouldDeleteOriginal bool
+		)
+
+		if _, ok := autoCreatedTabAppIDs[appID]; ok || len(appID) == 0 {
+			continue
+		}
+
+		if id, ok := collisionKeyToTabID[name]; ok {
+			log := logger.Ctx(tctx).With("collision_key", clues.Hide(name))
+			log.Debug("item collision")
+
+			if restoreCfg.OnCollision == control.Skip {
+				ctr.Inc(count.CollisionSkip)
+				log.Debug("skipping item with collision")
+
+				continue
+			}
+
+			collisionID = id
+			shouldDeleteOriginal = restoreCfg.OnCollision == control.Replace
+		}
+
+		if _, err := cr.PostTab(tctx, teamID, channelID, tab); err != nil {
+			errs.AddRecoverable(tctx, clues.Stack(err))
+			continue
+		}
+
+		// tabs have no PUT request, so the same as other services, we create
+		// the new tab first and delete the original afterward.
+		if shouldDeleteOriginal {
+			err := cr.DeleteTab(tctx, teamID, channelID, collisionID)
+			if err != nil && !errors.Is(err, core.ErrNotFound) {
+				errs.AddRecoverable(tctx, clues.Wrap(err, "deleting colliding tab"))
+				continue
+			}
+
+			ctr.Inc(count.CollisionReplace)
+		} else {
+			ctr.Inc(count.NewItemCreated)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+var (
+	_ TeamRestorer    = &mockTeamRestorer{}
+	_ ChannelRestorer = &mockChannelRestorer{}
+)
+
+type mockTeamRestorer struct {
+	getErr    error
+	installed []models.TeamsAppInstallationable
+	putTeam   bool
+	patched   bool
+	posted    []string
+}
+
+func (m *mockTeamRestorer) GetTeamByID(
+	context.Context,
+	string,
+	api.CallConfig,
+) (models.Teamable, error) {
+	return models.NewTeam(), m.getErr
+}
+
+func (m *mockTeamRestorer) PutTeam(
+	context.Context,
+	string,
+	models.Teamable,
+) (models.Teamable, error) {
+	m.putTeam = true
+	return models.NewTeam(), nil
+}
+
+func (m *mockTeamRestorer) PatchTeam(context.Context, string, models.Teamable) error {
+	m.patched = true
+	return nil
+}
+
+func (m *mockTeamRestorer) GetInstalledApps(
+	context.Context,
+	string,
+) ([]models.TeamsAppInstallationable, error) {
+	return m.installed, nil
+}
+
+func (m *mockTeamRestorer) PostInstalledApp(_ context.Context, _, appID string) error {
+	m.posted = append(m.posted, appID)
+	return nil
+}
+
+type mockChannelRestorer struct {
+	channels      []models.Channelable
+	tabs          map[string][]models.TeamsTabable
+	postedChans   []string
+	moderated     []string
+	postedTabs    []string
+	deletedTabIDs []string
+}
+
+func (m *mockChannelRestorer) GetChannels(context.Context, string) ([]models.Channelable, error) {
+	return m.channels, nil
+}
+
+func (m *mockChannelRestorer) PostChannel(
+	_ context.Context,
+	_ string,
+	channel models.Channelable,
+) (models.Channelable, error) {
+	m.postedChans = append(m.postedChans, ptr.Val(channel.GetDisplayName()))
+
+	nc := models.NewChannel()
+	nc.SetId(ptr.To("new-" + ptr.Val(channel.GetId())))
+
+	return nc, nil
+}
+
+func (m *mockChannelRestorer) PatchChannelModerationSettings(
+	_ context.Context,
+	_, channelID string,
+	_ any,
+) error {
+	m.moderated = append(m.moderated, channelID)
+	return nil
+}
+
+func (m *mockChannelRestorer) GetChannelTabs(
+	_ context.Context,
+	_, channelID string,
+) ([]models.TeamsTabable, error) {
+	return m.tabs[channelID], nil
+}
+
+func (m *mockChannelRestorer) PostTab(
+	_ context.Context,
+	_, channelID string,
+	tab models.TeamsTabable,
+) (models.TeamsTabable, error) {
+	m.postedTabs = append(m.postedTabs, channelID+"/"+ptr.Val(tab.GetDisplayName()))
+	return models.NewTeamsTab(), nil
+}
+
+func (m *mockChannelRestorer) DeleteTab(_ context.Context, _, _, tabID string) error {
+	m.deletedTabIDs = append(m.deletedTabIDs, tabID)
+	return nil
+}
+
+type RestoreTeamUnitSuite struct {
+	tester.Suite
+}
+
+func TestRestoreTeamUnitSuite(t *testing.T) {
+	suite.Run(t, &RestoreTeamUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func makeTab(name, appID string) models.TeamsTabable {
+	app := models.NewTeamsApp()
+	app.SetId(ptr.To(appID))
+
+	tab := models.NewTeamsTab()
+	tab.SetId(ptr.To(name + "-id"))
+	tab.SetDisplayName(ptr.To(name))
+	tab.SetTeamsApp(app)
+
+	return tab
+}
+
+func teamBytes(t *testing.T) []byte {
+	standard := models.STANDARD_CHANNELMEMBERSHIPTYPE
+	private := models.PRIVATE_CHANNELMEMBERSHIPTYPE
+
+	general := models.NewChannel()
+	general.SetId(ptr.To("cid"))
+	general.SetDisplayName(ptr.To("General"))
+	general.SetMembershipType(&standard)
+	general.SetAdditionalData(map[string]any{
+		api.ChannelModerationSettingsKey: map[string]any{
+			"allowNewMessageFromBots": ptr.To(true),
+		},
+	})
+	general.SetTabs([]models.TeamsTabable{
+		makeTab("Files", "com.microsoft.teamspace.tab.files.sharepoint"),
+		makeTab("roadmap", "appid"),
+	})
+
+	secrets := models.NewChannel()
+	secrets.SetId(ptr.To("pcid"))
+	secrets.SetDisplayName(ptr.To("secrets"))
+	secrets.SetMembershipType(&private)
+	secrets.SetTabs([]models.TeamsTabable{makeTab("notes", "appid")})
+
+	teamsApp := models.NewTeamsApp()
+	teamsApp.SetId(ptr.To("appid"))
+
+	app := models.NewTeamsAppInstallation()
+	app.SetTeamsApp(teamsApp)
+
+	team := models.NewTeam()
+	team.SetId(ptr.To("tid"))
+	team.SetDisplayName(ptr.To("marketing"))
+	team.SetChannels([]models.Channelable{general, secrets})
+	team.SetInstalledApps([]models.TeamsAppInstallationable{app})
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", team)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return bs
+}
+
+func (suite *RestoreTeamUnitSuite) TestRestoreTeamStructure() {
+	fullPath, err := path.Build("t", "g", path.GroupsService, path.TeamStructureCategory, false, "tid")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	existingGeneral := models.NewChannel()
+	existingGeneral.SetId(ptr.To("existing-cid"))
+	existingGeneral.SetDisplayName(ptr.To("general"))
+
+	table := []struct {
+		name            string
+		getTeamErr      error
+		onCollision     control.CollisionPolicy
+		expectPutTeam   bool
+		expectTabs      []string
+		expectDeleted   []string
+		expectCreated   int64
+		expectSkipped   int64
+		expectReplaced  int64
+		expectAppPosted []string
+	}{
+		{
+			name:          "new team",
+			getTeamErr:    clues.Stack(core.ErrNotFound),
+			onCollision:   control.Skip,
+			expectPutTeam: true,
+			expectTabs:    []string{"new-pcid/notes"},
+			// team, channel, and tab
+			expectCreated:   3,
+			expectSkipped:   1,
+			expectAppPosted: []string{"appid"},
+		},
+		{
+			name:            "existing team, skip",
+			onCollision:     control.Skip,
+			expectTabs:      []string{"new-pcid/notes"},
+			expectCreated:   2,
+			expectSkipped:   1,
+			expectAppPosted: []string{"appid"},
+		},
+		{
+			name:            "existing team, copy",
+			onCollision:     control.Copy,
+			expectTabs:      []string{"existing-cid/roadmap", "new-pcid/notes"},
+			expectCreated:   3,
+			expectAppPosted: []string{"appid"},
+		},
+		{
+			name:            "existing team, replace",
+			onCollision:     control.Replace,
+			expectTabs:      []string{"existing-cid/roadmap", "new-pcid/notes"},
+			expectDeleted:   []string{"old-roadmap"},
+			expectCreated:   2,
+			expectReplaced:  1,
+			expectAppPosted: []string{"appid"},
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			existingTab := makeTab("roadmap", "appid")
+			existingTab.SetId(ptr.To("old-roadmap"))
+
+			var (
+				tr = &mockTeamRestorer{getErr: test.getTeamErr}
+				cr = &mockChannelRestorer{
+					channels: []models.Channelable{existingGeneral},
+					tabs: map[string][]models.TeamsTabable{
+						"existing-cid": {existingTab},
+					},
+				}
+				ctr   = count.New()
+				deets = &details.Builder{}
+				dc    = dataMock.Collection{
+					Path: fullPath,
+					ItemData: []data.Item{
+						&dataMock.Item{
+							ItemID: "tid",
+							Reader: io.NopCloser(bytes.NewReader(teamBytes(t))),
+						},
+					},
+				}
+			)
+
+			metrics, err := RestoreTeamStructure(
+				ctx,
+				tr,
+				cr,
+				dc,
+				"g",
+				control.RestoreConfig{OnCollision: test.onCollision},
+				deets,
+				fault.New(true),
+				ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, 1, metrics.Successes)
+			assert.Equal(t, test.expectPutTeam, tr.putTeam)
+			assert.Equal(t, !test.expectPutTeam, tr.patched)
+			assert.Equal(t, test.expectAppPosted, tr.posted)
+
+			// general already exists, and gets merged into.
+			assert.Equal(t, []string{"secrets"}, cr.postedChans)
+			assert.Equal(t, []string{"existing-cid"}, cr.moderated)
+			assert.Equal(t, test.expectTabs, cr.postedTabs)
+			assert.Equal(t, test.expectDeleted, cr.deletedTabIDs)
+
+			assert.Equal(t, test.expectCreated, ctr.Get(count.NewItemCreated))
+			assert.Equal(t, test.expectSkipped, ctr.Get(count.CollisionSkip))
+			assert.Equal(t, test.expectReplaced, ctr.Get(count.CollisionReplace))
+
+			require.Len(t, deets.Details().Items(), 1)
+			assert.Equal(t, "marketing", deets.Details().Items()[0].Groups.Team.DisplayName)
+		})
+	}
+}
+
+func (suite *RestoreTeamUnitSuite) TestRestoreInstalledApps_skipsInstalled() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	teamsApp := models.NewTeamsApp()
+	teamsApp.SetId(ptr.To("appid"))
+
+	app := models.NewTeamsAppInstallation()
+	app.SetTeamsApp(teamsApp)
+
+	tr := &mockTeamRestorer{installed: []models.TeamsAppInstallationable{app}}
+
+	err := restoreInstalledApps(ctx, tr, []models.TeamsAppInstallationable{app}, "tid", fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Empty(t, tr.posted)
+}
@@ -0,0 +1,254 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+var _ PlanRestorer = &mockPlanRestorer{}
+
+type mockPlanRestorer struct {
+	plans        []models.PlannerPlanable
+	postedTitles []string
+	taskBuckets  []string
+	deleted      []string
+}
+
+func (m *mockPlanRestorer) GetPlans(
+	context.Context,
+	string,
+	api.CallConfig,
+) ([]models.PlannerPlanable, error) {
+	return m.plans, nil
+}
+
+func (m *mockPlanRestorer) PostPlan(
+	_ context.Context,
+	_, title string,
+) (models.PlannerPlanable, error) {
+	m.postedTitles = append(m.postedTitles, title)
+
+	plan := models.NewPlannerPlan()
+	plan.SetId(ptr.To("new-pid"))
+	plan.SetTitle(ptr.To(title))
+
+	return plan, nil
+}
+
+func (m *mockPlanRestorer) PatchPlanDetails(
+	context.Context,
+	string,
+	models.PlannerPlanDetailsable,
+) error {
+	return nil
+}
+
+func (m *mockPlanRestorer) PostBucket(
+	_ context.Context,
+	_ string,
+	bucket models.PlannerBucketable,
+) (models.PlannerBucketable, error) {
+	nb := models.NewPlannerBucket()
+	nb.SetId(ptr.To("new-" + ptr.Val(bucket.GetId())))
+
+	return nb, nil
+}
+
+func (m *mockPlanRestorer) PostTask(
+	_ context.Context,
+	_, bucketID string,
+	task models.PlannerTaskable,
+) (models.PlannerTaskable, error) {
+	m.taskBuckets = append(m.taskBuckets, bucketID)
+
+	nt := models.NewPlannerTask()
+	nt.SetId(ptr.To("new-" + ptr.Val(task.GetId())))
+
+	return nt, nil
+}
+
+func (m *mockPlanRestorer) PatchTaskDetails(
+	context.Context,
+	string,
+	models.PlannerTaskDetailsable,
+) error {
+	return nil
+}
+
+func (m *mockPlanRestorer) DeletePlan(_ context.Context, planID string) error {
+	m.deleted = append(m.deleted, planID)
+	return nil
+}
+
+type RestoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestRestoreUnitSuite(t *testing.T) {
+	suite.Run(t, &RestoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func planBytes(t *testing.T) []byte {
+	bucket := models.NewPlannerBucket()
+	bucket.SetId(ptr.To("bid"))
+	bucket.SetName(ptr.To("to do"))
+
+	task := models.NewPlannerTask()
+	task.SetId(ptr.To("tid"))
+	task.SetTitle(ptr.To("write the docs"))
+	task.SetBucketId(ptr.To("bid"))
+
+	plan := models.NewPlannerPlan()
+	plan.SetId(ptr.To("pid"))
+	plan.SetTitle(ptr.To("launch"))
+	plan.SetBuckets([]models.PlannerBucketable{bucket})
+	plan.SetTasks([]models.PlannerTaskable{task})
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", plan)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return bs
+}
+
+func (suite *RestoreUnitSuite) TestRestorePlans() {
+	fullPath, err := path.Build("t", "g", path.GroupsService, path.PlannerCategory, false, "pid")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name           string
+		location       string
+		onCollision    control.CollisionPolicy
+		collisionKeys  map[string]string
+		expectTitles   []string
+		expectDeleted  []string
+		expectSuccess  int
+		expectCountKey count.Key
+	}{
+		{
+			name:           "no collision",
+			location:       "Corso_Restore",
+			onCollision:    control.Skip,
+			collisionKeys:  map[string]string{"launch": "pid"},
+			expectTitles:   []string{"Corso_Restore - launch"},
+			expectSuccess:  1,
+			expectCountKey: count.NewItemCreated,
+		},
+		{
+			name:           "collision skip",
+			onCollision:    control.Skip,
+			collisionKeys:  map[string]string{"launch": "pid"},
+			expectCountKey: count.CollisionSkip,
+		},
+		{
+			name:           "collision copy",
+			onCollision:    control.Copy,
+			collisionKeys:  map[string]string{"launch": "pid"},
+			expectTitles:   []string{"launch"},
+			expectSuccess:  1,
+			expectCountKey: count.NewItemCreated,
+		},
+		{
+			name:           "collision replace",
+			location:       "/",
+			onCollision:    control.Replace,
+			collisionKeys:  map[string]string{"launch": "pid"},
+			expectTitles:   []string{"launch"},
+			expectDeleted:  []string{"pid"},
+			expectSuccess:  1,
+			expectCountKey: count.CollisionReplace,
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				pr    = &mockPlanRestorer{}
+				ctr   = count.New()
+				deets = &details.Builder{}
+				dc    = dataMock.Collection{
+					Path: fullPath,
+					ItemData: []data.Item{
+						&dataMock.Item{
+							ItemID: "pid",
+							Reader: io.NopCloser(bytes.NewReader(planBytes(t))),
+						},
+					},
+				}
+				restoreCfg = control.RestoreConfig{
+					Location:    test.location,
+					OnCollision: test.onCollision,
+				}
+			)
+
+			metrics, err := RestorePlans(
+				ctx,
+				pr,
+				dc,
+				"g",
+				restoreCfg,
+				test.collisionKeys,
+				deets,
+				fault.New(true),
+				ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, 1, metrics.Objects)
+			assert.Equal(t, test.expectSuccess, metrics.Successes)
+			assert.Equal(t, test.expectTitles, pr.postedTitles)
+			assert.Equal(t, test.expectDeleted, pr.deleted)
+			assert.Equal(t, int64(1), ctr.Get(test.expectCountKey))
+			assert.Len(t, deets.Details().Items(), test.expectSuccess)
+
+			if test.expectSuccess > 0 {
+				// tasks get moved into the restored copy of their bucket.
+				assert.Equal(t, []string{"new-bid"}, pr.taskBuckets)
+			}
+		})
+	}
+}
+
+func (suite *RestoreUnitSuite) TestPlanCollisionKeys() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	plan := models.NewPlannerPlan()
+	plan.SetId(ptr.To("pid"))
+	plan.SetTitle(ptr.To("launch"))
+
+	keys, err := PlanCollisionKeys(ctx, &mockPlanRestorer{plans: []models.PlannerPlanable{plan}}, "g")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, map[string]string{"launch": "pid"}, keys)
+}
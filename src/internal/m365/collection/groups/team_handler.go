@@ -0,0 +1,148 @@
+package groups
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/metadata"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+var _ backupHandler[models.Teamable, models.Teamable] = &teamBackupHandler{}
+
+// teamBackupHandler backs up the team as a container holding a single
+// item: the team's structure, meaning its settings, channels, tabs, and
+// installed apps.
+type teamBackupHandler struct {
+	ac                api.Groups
+	protectedResource string
+}
+
+func NewTeamBackupHandler(
+	protectedResource string,
+	ac api.Groups,
+) teamBackupHandler {
+	return teamBackupHandler{
+		ac:                ac,
+		protectedResource: protectedResource,
+	}
+}
+
+func (bh teamBackupHandler) canMakeDeltaQueries() bool {
+	return false
+}
+
+//lint:ignore U1000 required for interface compliance
+func (bh teamBackupHandler) getContainers(
+	ctx context.Context,
+	cc api.CallConfig,
+) ([]container[models.Teamable], error) {
+	team, err := bh.ac.GetTeamByID(ctx, bh.protectedResource, cc)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting team")
+	}
+
+	return []container[models.Teamable]{teamContainer(team)}, nil
+}
+
+func (bh teamBackupHandler) getContainerItemIDs(
+	_ context.Context,
+	containerPath path.Elements,
+	_ string,
+	_ api.CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	// teams don't expose a modification time, so the structure is always
+	// fetched again.
+	return pagers.AddedAndRemoved{
+		Added:         map[string]time.Time{containerPath[0]: time.Now()},
+		ValidModTimes: false,
+	}, nil
+}
+
+//lint:ignore U1000 required for interface compliance
+func (bh teamBackupHandler) includeContainer(
+	team models.Teamable,
+	scope selectors.GroupsScope,
+) bool {
+	return scope.Matches(selectors.GroupsTeam, ptr.Val(team.GetDisplayName()))
+}
+
+func (bh teamBackupHandler) canonicalPath(
+	storageDirFolders path.Elements,
+	tenantID string,
+) (path.Path, error) {
+	return storageDirFolders.
+		Builder().
+		ToDataLayerPath(
+			tenantID,
+			bh.protectedResource,
+			path.GroupsService,
+			path.TeamStructureCategory,
+			false)
+}
+
+func (bh teamBackupHandler) PathPrefix(tenantID string) (path.Path, error) {
+	return path.Build(
+		tenantID,
+		bh.protectedResource,
+		path.GroupsService,
+		path.TeamStructureCategory,
+		false)
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh teamBackupHandler) getItem(
+	ctx context.Context,
+	_ string,
+	_ path.Elements,
+	teamID string,
+	_ *fault.Bus,
+) (models.Teamable, *details.GroupsInfo, error) {
+	return bh.ac.GetTeamStructure(ctx, teamID)
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh teamBackupHandler) getItemMetadata(
+	_ context.Context,
+	_ models.Teamable,
+) (io.ReadCloser, int, error) {
+	return nil, 0, errMetadataFilesNotSupported
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh teamBackupHandler) augmentItemInfo(
+	*details.GroupsInfo,
+	models.Teamable,
+) {
+	// no-op
+}
+
+//lint:ignore U1000 false linter issue due to generics
+func (bh teamBackupHandler) supportsItemMetadata() bool {
+	return false
+}
+
+func (bh teamBackupHandler) makeTombstones(
+	dps metadata.DeltaPaths,
+) (map[string]string, error) {
+	return makeTombstones(dps), nil
+}
+
+func teamContainer(team models.Teamable) container[models.Teamable] {
+	return container[models.Teamable]{
+		storageDirFolders:   path.Elements{ptr.Val(team.GetId())},
+		humanLocation:       path.Elements{ptr.Val(team.GetDisplayName())},
+		canMakeDeltaQueries: false,
+		container:           team,
+	}
+}
@@ -50,6 +50,8 @@ func CollectLibraries(
 			bpc.ProtectedResource,
 			su,
 			bpc.Options,
+			bpc.Selector.FileFilter,
+			bpc.EnumerationState,
 			counter)
 	)
 
@@ -51,6 +51,8 @@ const (
 var (
 	_ data.BackupCollection = &prefetchCollection{}
 	_ data.BackupCollection = &lazyFetchCollection{}
+	_ data.ItemEstimator    = &prefetchCollection{}
+	_ data.ItemEstimator    = &lazyFetchCollection{}
 )
 
 // Collection is the SharePoint.List or SharePoint.Page implementation of data.Collection.
@@ -137,6 +139,12 @@ func (pc prefetchCollection) DoNotMergeItems() bool {
 	return false
 }
 
+// EstimateItems reports the number of lists or pages in the collection.
+// Their sizes aren't known until they're fetched.
+func (pc *prefetchCollection) EstimateItems() (int64, int64, bool) {
+	return int64(len(pc.items)), 0, false
+}
+
 func (pc *prefetchCollection) Items(
 	ctx context.Context,
 	errs *fault.Bus,
@@ -161,6 +169,8 @@ func (pc *prefetchCollection) streamItems(
 		pc.streamLists(ctx, errs)
 	case path.PagesCategory:
 		pc.streamPages(ctx, pc.client, errs)
+	case path.SiteConfigurationCategory:
+		pc.streamSiteConfiguration(ctx, errs)
 	}
 }
 
@@ -330,7 +340,7 @@ func (pc *prefetchCollection) handleListItems(
 		err  error
 	)
 
-	list, info, err = pc.getter.GetItemByID(ctx, listID)
+	list, info, err = pc.getter.GetItemByID(ctx, listID, el)
 	if err != nil {
 		err = clues.WrapWC(ctx, err, "getting list data").Label(fault.LabelForceNoBackupCreation)
 		el.AddRecoverable(ctx, err)
@@ -435,6 +445,12 @@ func (lc lazyFetchCollection) DoNotMergeItems() bool {
 	return false
 }
 
+// EstimateItems reports the number of lists in the collection.  List sizes
+// aren't known until the lists are fetched.
+func (lc *lazyFetchCollection) EstimateItems() (int64, int64, bool) {
+	return int64(len(lc.items)), 0, false
+}
+
 func (lc lazyFetchCollection) Items(
 	ctx context.Context,
 	errs *fault.Bus,
@@ -501,7 +517,7 @@ func (lig *lazyItemGetter) GetData(
 	ctx context.Context,
 	el *fault.Bus,
 ) (io.ReadCloser, *details.ItemInfo, bool, error) {
-	list, info, err := lig.getter.GetItemByID(ctx, lig.itemID)
+	list, info, err := lig.getter.GetItemByID(ctx, lig.itemID, el)
 	if err != nil {
 		if clues.HasLabel(err, graph.LabelStatus(http.StatusNotFound)) || errors.Is(err, core.ErrNotFound) {
 			logger.CtxErr(ctx, err).Info("item deleted in flight. skipping")
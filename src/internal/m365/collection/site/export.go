@@ -1,14 +1,22 @@
 package site
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"strings"
 
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/export"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/metrics"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
 func NewExportCollection(
@@ -39,9 +47,17 @@ func streamItems(
 	errs := fault.New(false)
 
 	for _, rc := range drc {
+		cat := rc.FullPath().Category()
+
 		for item := range rc.Items(ctx, errs) {
-			stats.UpdateResourceCount(path.ListsCategory)
-			body := metrics.ReaderWithStats(item.ToReader(), path.ListsCategory, stats)
+			stats.UpdateResourceCount(cat)
+
+			if cat == path.ListsCategory {
+				streamList(item, ch, stats)
+				continue
+			}
+
+			body := metrics.ReaderWithStats(item.ToReader(), cat, stats)
 
 			name := item.ID() + ".json"
 
@@ -69,3 +85,78 @@ func streamItems(
 		}
 	}
 }
+
+// streamList exports the list as json, along with the files attached to
+// its items.
+func streamList(
+	item data.Item,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	bs, err := io.ReadAll(item.ToReader())
+	if err != nil {
+		ch <- export.Item{
+			ID:    item.ID(),
+			Error: clues.Wrap(err, "reading list bytes"),
+		}
+
+		return
+	}
+
+	ch <- export.Item{
+		ID:   item.ID(),
+		Name: item.ID() + ".json",
+		Body: metrics.ReaderWithStats(
+			io.NopCloser(bytes.NewReader(bs)),
+			path.ListsCategory,
+			stats),
+	}
+
+	// skip deserializing lists that hold no attached files.
+	if !bytes.Contains(bs, []byte(api.ListItemAttachmentsKey)) {
+		return
+	}
+
+	list, err := api.BytesToListable(bs)
+	if err != nil {
+		ch <- export.Item{
+			ID:    item.ID(),
+			Error: clues.Wrap(err, "deserializing list"),
+		}
+
+		return
+	}
+
+	for _, li := range list.GetItems() {
+		atts, err := api.ListItemAttachments(li)
+		if err != nil {
+			ch <- export.Item{
+				ID:    item.ID(),
+				Error: clues.Wrap(err, "reading list item attachments"),
+			}
+
+			continue
+		}
+
+		for _, att := range atts {
+			ch <- export.Item{
+				ID:   item.ID(),
+				Name: listItemAttachmentFileName(item.ID(), li, att),
+				Body: metrics.ReaderWithStats(
+					io.NopCloser(bytes.NewReader(att.Content)),
+					path.ListsCategory,
+					stats),
+			}
+		}
+	}
+}
+
+// listItemAttachmentFileName scopes the attached file's name to the list
+// and the list item that hold it.
+func listItemAttachmentFileName(
+	listID string,
+	li models.ListItemable,
+	att api.ListItemAttachment,
+) string {
+	return listID + "-" + ptr.Val(li.GetId()) + "-" + strings.ReplaceAll(att.Name, "/", "_")
+}
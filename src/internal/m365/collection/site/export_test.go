@@ -20,6 +20,8 @@ import (
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/export"
 	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
 type ExportUnitSuite struct {
@@ -33,6 +35,9 @@ func TestExportUnitSuite(t *testing.T) {
 func (suite *ExportUnitSuite) TestStreamItems() {
 	t := suite.T()
 
+	listsPath, err := path.Build("t", "s", path.SharePointService, path.ListsCategory, false, "list")
+	require.NoError(t, err, clues.ToCore(err))
+
 	table := []struct {
 		name        string
 		backingColl dataMock.Collection
@@ -42,6 +47,7 @@ func (suite *ExportUnitSuite) TestStreamItems() {
 		{
 			name: "no errors",
 			backingColl: dataMock.Collection{
+				Path: listsPath,
 				ItemData: []data.Item{
 					&dataMock.Item{
 						ItemID: "list1",
@@ -55,6 +61,7 @@ func (suite *ExportUnitSuite) TestStreamItems() {
 		{
 			name: "only recoverable errors",
 			backingColl: dataMock.Collection{
+				Path: listsPath,
 				ItemsRecoverableErrs: []error{
 					clues.New("some error"),
 				},
@@ -64,6 +71,7 @@ func (suite *ExportUnitSuite) TestStreamItems() {
 		{
 			name: "items and recoverable errors",
 			backingColl: dataMock.Collection{
+				Path: listsPath,
 				ItemData: []data.Item{
 					&dataMock.Item{
 						ItemID: "list2",
@@ -116,6 +124,70 @@ func (suite *ExportUnitSuite) TestStreamItems() {
 	}
 }
 
+func (suite *ExportUnitSuite) TestStreamItems_listAttachments() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	listsPath, err := path.Build("t", "s", path.SharePointService, path.ListsCategory, false, "list")
+	require.NoError(t, err, clues.ToCore(err))
+
+	li := models.NewListItem()
+	li.SetId(ptr.To("1"))
+	api.SetListItemAttachments(li, []api.ListItemAttachment{
+		{Name: "notes/a.txt", Content: []byte("content")},
+	})
+
+	list := models.NewList()
+	list.SetId(ptr.To("list1"))
+	list.SetItems([]models.ListItemable{li})
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err = writer.WriteObjectValue("", list)
+	require.NoError(t, err, clues.ToCore(err))
+
+	listBytes, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	coll := dataMock.Collection{
+		Path: listsPath,
+		ItemData: []data.Item{
+			&dataMock.Item{
+				ItemID: "list1",
+				Reader: io.NopCloser(bytes.NewReader(listBytes)),
+			},
+		},
+	}
+
+	ch := make(chan export.Item)
+
+	go streamItems(
+		ctx,
+		[]data.RestoreCollection{coll},
+		version.NoBackup,
+		control.DefaultExportConfig(),
+		ch,
+		&metrics.ExportStats{})
+
+	contents := map[string]string{}
+
+	for i := range ch {
+		require.NoError(t, i.Error, clues.ToCore(i.Error))
+
+		bs, err := io.ReadAll(i.Body)
+		require.NoError(t, err, clues.ToCore(err))
+
+		contents[i.Name] = string(bs)
+	}
+
+	assert.Equal(t, string(listBytes), contents["list1.json"])
+	assert.Equal(t, "content", contents["list1-1-notes_a.txt"])
+	assert.Len(t, contents, 2)
+}
+
 func makeListJSONReader(t *testing.T, listName string) io.ReadCloser {
 	listBytes := getListBytes(t, listName)
 	return io.NopCloser(bytes.NewReader(listBytes))
@@ -6,6 +6,7 @@ import (
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -27,7 +28,11 @@ type canonicalPather interface {
 }
 
 type getItemByIDer interface {
-	GetItemByID(ctx context.Context, itemID string) (models.Listable, *details.SharePointInfo, error)
+	GetItemByID(
+		ctx context.Context,
+		itemID string,
+		errs *fault.Bus,
+	) (models.Listable, *details.SharePointInfo, error)
 }
 
 type getItemser interface {
@@ -40,6 +45,19 @@ type restoreHandler interface {
 	DeleteLister
 	GetLister
 	GetListsByCollisionKeyser
+	ListTermsRemapper
+}
+
+type ListTermsRemapper interface {
+	// RemapListTerms points the list's managed metadata values at the
+	// matching terms of the destination's term store, creating the missing
+	// terms if createMissing is set.
+	RemapListTerms(
+		ctx context.Context,
+		storedList models.Listable,
+		createMissing bool,
+		ctr *count.Bus,
+	) error
 }
 
 type PostLister interface {
@@ -70,6 +88,7 @@ type GetLister interface {
 	GetList(
 		ctx context.Context,
 		listID string,
+		errs *fault.Bus,
 	) (models.Listable, *details.SharePointInfo, error)
 }
 
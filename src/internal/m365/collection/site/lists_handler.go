@@ -3,9 +3,11 @@ package site
 import (
 	"context"
 
+	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -16,12 +18,14 @@ var _ backupHandler = &listsBackupHandler{}
 type listsBackupHandler struct {
 	ac                api.Lists
 	protectedResource string
+	terms             *listTermsCache
 }
 
 func NewListsBackupHandler(protectedResource string, ac api.Lists) listsBackupHandler {
 	return listsBackupHandler{
 		ac:                ac,
 		protectedResource: protectedResource,
+		terms:             newListTermsCache(ac.TermStore(), protectedResource),
 	}
 }
 
@@ -42,8 +46,18 @@ func (bh listsBackupHandler) CanonicalPath(
 func (bh listsBackupHandler) GetItemByID(
 	ctx context.Context,
 	itemID string,
+	errs *fault.Bus,
 ) (models.Listable, *details.SharePointInfo, error) {
-	return bh.ac.GetListByID(ctx, bh.protectedResource, itemID)
+	list, info, err := bh.ac.GetListByID(ctx, bh.protectedResource, itemID, errs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := bh.terms.addListTerms(ctx, list); err != nil {
+		return nil, nil, clues.Wrap(err, "referencing managed metadata terms")
+	}
+
+	return list, info, nil
 }
 
 func (bh listsBackupHandler) GetItems(ctx context.Context, cc api.CallConfig) ([]models.Listable, error) {
@@ -55,15 +69,26 @@ var _ restoreHandler = &listsRestoreHandler{}
 type listsRestoreHandler struct {
 	ac                api.Lists
 	protectedResource string
+	terms             *termRestorer
 }
 
 func NewListsRestoreHandler(protectedResource string, ac api.Lists) listsRestoreHandler {
 	return listsRestoreHandler{
 		ac:                ac,
 		protectedResource: protectedResource,
+		terms:             newTermRestorer(ac.TermStore(), protectedResource),
 	}
 }
 
+func (rh listsRestoreHandler) RemapListTerms(
+	ctx context.Context,
+	storedList models.Listable,
+	createMissing bool,
+	ctr *count.Bus,
+) error {
+	return rh.terms.remapListTerms(ctx, storedList, createMissing, ctr)
+}
+
 func (rh listsRestoreHandler) PostList(
 	ctx context.Context,
 	listName string,
@@ -91,8 +116,9 @@ func (rh listsRestoreHandler) DeleteList(
 func (rh listsRestoreHandler) GetList(
 	ctx context.Context,
 	listID string,
+	errs *fault.Bus,
 ) (models.Listable, *details.SharePointInfo, error) {
-	return rh.ac.GetListByID(ctx, rh.protectedResource, listID)
+	return rh.ac.GetListByID(ctx, rh.protectedResource, listID, errs)
 }
 
 func (rh listsRestoreHandler) GetListsByCollisionKey(ctx context.Context) (map[string]string, error) {
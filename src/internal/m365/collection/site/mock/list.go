@@ -11,6 +11,7 @@ import (
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -40,6 +41,7 @@ func NewListHandler(lists []models.Listable, protectedResource string, err error
 func (lh ListHandler) GetItemByID(
 	ctx context.Context,
 	itemID string,
+	_ *fault.Bus,
 ) (models.Listable, *details.SharePointInfo, error) {
 	lstInfo := &details.SharePointInfo{
 		List: &details.ListInfo{
@@ -98,6 +100,8 @@ type ListRestoreHandler struct {
 	deleteListErr error
 	postListErr   error
 	patchListErr  error
+
+	RemapListTermsErr error
 }
 
 func NewListRestoreHandler(deleteListErr error, postListErr, patchListErr error) *ListRestoreHandler {
@@ -129,6 +133,7 @@ func (lh *ListRestoreHandler) PatchList(
 func (lh *ListRestoreHandler) GetList(
 	ctx context.Context,
 	listID string,
+	_ *fault.Bus,
 ) (models.Listable, *details.SharePointInfo, error) {
 	ls := models.NewList()
 	ls.SetId(ptr.To(listID))
@@ -144,6 +149,15 @@ func (lh *ListRestoreHandler) GetListsByCollisionKey(ctx context.Context) (map[s
 	return map[string]string{}, nil
 }
 
+func (lh *ListRestoreHandler) RemapListTerms(
+	context.Context,
+	models.Listable,
+	bool,
+	*count.Bus,
+) error {
+	return lh.RemapListTermsErr
+}
+
 func StubLists(ids ...string) []models.Listable {
 	lists := make([]models.Listable, 0, len(ids))
 
@@ -62,6 +62,15 @@ func restoreListItem(
 		return dii, clues.WrapWC(ctx, err, "generating list from stored bytes")
 	}
 
+	// a list whose terms can't be resolved is still restored, with its
+	// managed metadata values keeping their labels.  The failure is reported
+	// so that the unresolved values don't go unnoticed.
+	err = rh.RemapListTerms(ctx, storedList, restoreCfg.CreateMissingTerms, ctr)
+	if err != nil {
+		ctr.Inc(count.ListTermsNotRemapped)
+		errs.AddRecoverable(ctx, clues.WrapWC(ctx, err, "resolving managed metadata terms"))
+	}
+
 	var (
 		collisionKey = api.ListCollisionKey(storedList)
 		collisionID  string
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -85,6 +86,46 @@ func (suite *SharePointCollectionUnitSuite) TestFormatListsRestoreDestination()
 	}
 }
 
+func (suite *SharePointRestoreUnitSuite) TestRestoreListItem_termRemapFails() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	list := models.NewList()
+	list.SetDisplayName(ptr.To("terms"))
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", list)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		lrh  = siteMock.NewListRestoreHandler(nil, nil, nil)
+		ctr  = count.New()
+		errs = fault.New(false)
+		item = &dataMock.Item{
+			ItemID: "terms",
+			Reader: io.NopCloser(bytes.NewReader(bs)),
+		}
+	)
+
+	lrh.RemapListTermsErr = assert.AnError
+
+	restoreCfg := testdata.DefaultRestoreConfig("")
+	restoreCfg.CreateMissingTerms = true
+
+	dii, err := restoreListItem(ctx, lrh, item, "site-id", restoreCfg, nil, ctr, errs)
+	require.NoError(t, err, "list is still restored", clues.ToCore(err))
+	assert.NotNil(t, dii.SharePoint, "restored list info")
+	assert.Len(t, errs.Recovered(), 1, "term remap failure is reported")
+	assert.Equal(t, int64(1), ctr.Get(count.ListTermsNotRemapped))
+}
+
 type SharePointRestoreSuite struct {
 	tester.Suite
 	m365 its.M365IntgTestSetup
@@ -0,0 +1,611 @@
+package site
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"runtime/trace"
+	"sort"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/diagnostics"
+	betaAPI "github.com/alcionai/corso/src/internal/m365/service/sharepoint/api"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	betamodels "github.com/alcionai/corso/src/pkg/services/m365/api/graph/betasdk/models"
+)
+
+// ---------------------------------------------------------------------------
+// backup
+// ---------------------------------------------------------------------------
+
+// CollectSiteConfiguration produces a collection holding a single item:
+// the configuration of the site, meaning its site columns, content types,
+// permissions, settings, the managed metadata term store visible to the
+// site, and its navigation, SharePoint groups, features, and theme.  The
+// configuration is always fetched in full.
+func CollectSiteConfiguration(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	creds account.M365Config,
+	ac api.Client,
+	scope selectors.SharePointScope,
+	su support.StatusUpdater,
+	counter *count.Bus,
+) ([]data.BackupCollection, error) {
+	logger.Ctx(ctx).Debug("creating SharePoint Site Configuration collection")
+
+	siteID := bpc.ProtectedResource.ID()
+
+	if !scope.Matches(selectors.SharePointSiteConfigurationFolder, siteID) &&
+		!scope.Matches(selectors.SharePointSiteConfigurationFolder, bpc.ProtectedResource.Name()) {
+		return nil, nil
+	}
+
+	adpt, err := graph.CreateAdapter(
+		creds.AzureTenantID,
+		creds.AzureClientID,
+		creds.AzureClientSecret,
+		counter)
+	if err != nil {
+		return nil, clues.Wrap(err, "creating azure client adapter")
+	}
+
+	dir, err := path.Build(
+		creds.AzureTenantID,
+		siteID,
+		path.SharePointService,
+		path.SiteConfigurationCategory,
+		false,
+		siteID)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "creating site configuration collection path")
+	}
+
+	collection := NewPrefetchCollection(
+		nil,
+		dir,
+		nil,
+		nil,
+		ac,
+		scope,
+		su,
+		bpc.Options,
+		counter)
+	collection.SetBetaService(betaAPI.NewBetaService(adpt))
+	collection.AddItem(siteID, time.Now())
+
+	return []data.BackupCollection{collection}, nil
+}
+
+func (pc *prefetchCollection) streamSiteConfiguration(
+	ctx context.Context,
+	errs *fault.Bus,
+) {
+	var (
+		metrics support.CollectionMetrics
+		el      = errs.Local()
+		siteID  = pc.fullPath.ProtectedResource()
+	)
+
+	defer updateStatus(
+		ctx,
+		pc.stream[path.SiteConfigurationCategory],
+		pc.statusUpdater,
+		pc.fullPath,
+		&metrics)
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		pc.fullPath.Category().HumanString(),
+		pc.fullPath.Folders())
+	defer close(progressMessage)
+
+	if pc.betaService == nil {
+		logger.Ctx(ctx).Error(clues.New("beta service required"))
+		return
+	}
+
+	metrics.Objects = len(pc.items)
+
+	for itemID := range pc.items {
+		ictx := clues.Add(ctx, "item_id", itemID)
+
+		site, err := betaAPI.GetSiteConfiguration(ictx, pc.betaService, siteID)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "getting site configuration").Label(fault.LabelForceNoBackupCreation))
+			continue
+		}
+
+		// the term store requires its own permission, which not every
+		// backup has been granted.
+		store, err := pc.client.TermStore().GetTermStore(ictx, siteID)
+		if err != nil && !graph.IsErrAccessDenied(err) {
+			el.AddRecoverable(ictx, clues.Wrap(err, "getting term store").Label(fault.LabelForceNoBackupCreation))
+			continue
+		}
+
+		if err != nil {
+			logger.CtxErr(ictx, err).Info("term store not accessible; backing up site configuration without it")
+		}
+
+		betaAPI.SetSiteTermStore(site, store)
+
+		// graph doesn't expose the site's navigation, groups, features, or
+		// theme, and the SharePoint REST api only accepts certificate-granted
+		// app-only access.
+		web, err := pc.client.SiteWeb().GetSiteWebConfig(ictx, siteID)
+		if err != nil && !api.IsErrSharePointAccessDenied(err) && !graph.IsErrAccessDenied(err) {
+			el.AddRecoverable(ictx, clues.Wrap(err, "getting site web configuration").Label(fault.LabelForceNoBackupCreation))
+			continue
+		}
+
+		if err != nil {
+			logger.CtxErr(ictx, err).Info("sharepoint denied access to site web configuration; backing up without it")
+		}
+
+		betaAPI.SetSiteWeb(site, web)
+
+		byteArray, err := serializeContent(ictx, site)
+		if err != nil {
+			el.AddRecoverable(ictx, err)
+			continue
+		}
+
+		size := int64(len(byteArray))
+
+		item, err := data.NewPrefetchedItemWithInfo(
+			io.NopCloser(bytes.NewReader(byteArray)),
+			itemID,
+			details.ItemInfo{SharePoint: betaAPI.SiteConfigurationInfo(site, size)})
+		if err != nil {
+			el.AddRecoverable(ictx, clues.StackWC(ictx, err).Label(fault.LabelForceNoBackupCreation))
+			continue
+		}
+
+		metrics.Bytes += size
+		metrics.Successes++
+
+		pc.stream[path.SiteConfigurationCategory] <- item
+		progressMessage <- struct{}{}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// restore
+// ---------------------------------------------------------------------------
+
+type SiteConfigurationRestorer interface {
+	GetSiteConfiguration(ctx context.Context, siteID string) (models.Siteable, error)
+	PostSiteColumn(ctx context.Context, siteID string, column models.ColumnDefinitionable) error
+	PatchSiteColumn(ctx context.Context, siteID, columnID string, column models.ColumnDefinitionable) error
+	PostSiteContentType(ctx context.Context, siteID string, contentType models.ContentTypeable) error
+	PatchSiteContentType(ctx context.Context, siteID, contentTypeID string, contentType models.ContentTypeable) error
+	PostSitePermission(ctx context.Context, siteID string, perm models.Permissionable) error
+	PatchSiteSettings(ctx context.Context, siteID string, settings betamodels.SiteSettingsable) error
+	TermStoreRestorer
+	SiteWebRestorer
+}
+
+var _ SiteConfigurationRestorer = &siteConfigurationHandler{}
+
+type siteConfigurationHandler struct {
+	api.TermStore
+	api.SiteWeb
+	serv *betaAPI.BetaService
+}
+
+func NewSiteConfigurationHandler(
+	serv *betaAPI.BetaService,
+	ts api.TermStore,
+	sw api.SiteWeb,
+) siteConfigurationHandler {
+	return siteConfigurationHandler{
+		TermStore: ts,
+		SiteWeb:   sw,
+		serv:      serv,
+	}
+}
+
+func (h siteConfigurationHandler) GetSiteConfiguration(
+	ctx context.Context,
+	siteID string,
+) (models.Siteable, error) {
+	return betaAPI.GetSiteConfiguration(ctx, h.serv, siteID)
+}
+
+func (h siteConfigurationHandler) PostSiteColumn(
+	ctx context.Context,
+	siteID string,
+	column models.ColumnDefinitionable,
+) error {
+	return betaAPI.PostSiteColumn(ctx, h.serv, siteID, column)
+}
+
+func (h siteConfigurationHandler) PatchSiteColumn(
+	ctx context.Context,
+	siteID, columnID string,
+	column models.ColumnDefinitionable,
+) error {
+	return betaAPI.PatchSiteColumn(ctx, h.serv, siteID, columnID, column)
+}
+
+func (h siteConfigurationHandler) PostSiteContentType(
+	ctx context.Context,
+	siteID string,
+	contentType models.ContentTypeable,
+) error {
+	return betaAPI.PostSiteContentType(ctx, h.serv, siteID, contentType)
+}
+
+func (h siteConfigurationHandler) PatchSiteContentType(
+	ctx context.Context,
+	siteID, contentTypeID string,
+	contentType models.ContentTypeable,
+) error {
+	return betaAPI.PatchSiteContentType(ctx, h.serv, siteID, contentTypeID, contentType)
+}
+
+func (h siteConfigurationHandler) PostSitePermission(
+	ctx context.Context,
+	siteID string,
+	perm models.Permissionable,
+) error {
+	return betaAPI.PostSitePermission(ctx, h.serv, siteID, perm)
+}
+
+func (h siteConfigurationHandler) PatchSiteSettings(
+	ctx context.Context,
+	siteID string,
+	settings betamodels.SiteSettingsable,
+) error {
+	return betaAPI.PatchSiteSettings(ctx, h.serv, siteID, settings)
+}
+
+// RestoreSiteConfigurationCollection applies the backed up site
+// configuration to the collection's site.  Columns, content types, and
+// application permissions missing from the site are created.  Those that
+// already exist collide with the backed up copy; unless the collision
+// policy is to replace them, they're left as-is.  Site settings are only
+// overwritten on replace.  Built-in and read-only columns and content
+// types are never modified.  Managed metadata terms are only created when
+// the restore config allows it: each term missing from the site's term
+// store gets recreated, along with its missing term set and group.
+// Navigation links and SharePoint groups are matched by title, and are
+// handled like columns; missing features are activated, and the theme is
+// only applied on replace.
+//
+// Site configuration isn't nested within a folder, so the restore location
+// is not used.
+func RestoreSiteConfigurationCollection(
+	ctx context.Context,
+	sr SiteConfigurationRestorer,
+	dc data.RestoreCollection,
+	restoreCfg control.RestoreConfig,
+	deets *details.Builder,
+	ctr *count.Bus,
+	errs *fault.Bus,
+) (support.CollectionMetrics, error) {
+	ctx, end := diagnostics.Span(
+		ctx,
+		"m365:sharepoint:restoreSiteConfigurationCollection",
+		diagnostics.Label("path", dc.FullPath()))
+	defer end()
+
+	var (
+		metrics   = support.CollectionMetrics{}
+		directory = dc.FullPath()
+		siteID    = directory.ProtectedResource()
+		items     = dc.Items(ctx, errs)
+		el        = errs.Local()
+	)
+
+	trace.Log(ctx, "m365:sharepoint:restoreSiteConfigurationCollection", directory.String())
+
+	for {
+		if el.Failure() != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return metrics, clues.StackWC(ctx, ctx.Err())
+
+		case itemData, ok := <-items:
+			if !ok {
+				return metrics, el.Failure()
+			}
+
+			ictx := clues.Add(ctx, "item_id", itemData.ID())
+			metrics.Objects++
+
+			body, err := io.ReadAll(itemData.ToReader())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading backup data"))
+				continue
+			}
+
+			info, err := restoreSiteConfiguration(ictx, sr, siteID, body, restoreCfg, ctr, el)
+			if err != nil {
+				el.AddRecoverable(ictx, clues.Wrap(err, "restoring site configuration"))
+				continue
+			}
+
+			metrics.Bytes += int64(len(body))
+
+			itemPath, err := directory.AppendItem(itemData.ID())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "appending item to full path"))
+				continue
+			}
+
+			err = deets.Add(
+				itemPath,
+				&path.Builder{},
+				details.ItemInfo{SharePoint: info})
+			if err != nil {
+				// Not critical enough to need to stop restore operation.
+				logger.Ctx(ictx).Infow("accounting for restored item", "error", err)
+			}
+
+			metrics.Successes++
+		}
+	}
+
+	return metrics, el.Failure()
+}
+
+func restoreSiteConfiguration(
+	ctx context.Context,
+	sr SiteConfigurationRestorer,
+	siteID string,
+	body []byte,
+	restoreCfg control.RestoreConfig,
+	ctr *count.Bus,
+	errs *fault.Bus,
+) (*details.SharePointInfo, error) {
+	stored, err := betaAPI.BytesToSiteable(body)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "generating site configuration from stored bytes")
+	}
+
+	current, err := sr.GetSiteConfiguration(ctx, siteID)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting current site configuration")
+	}
+
+	replace := restoreCfg.OnCollision == control.Replace
+
+	restoreSiteColumns(ctx, sr, siteID, stored.GetColumns(), current.GetColumns(), replace, ctr, errs)
+	restoreSiteContentTypes(ctx, sr, siteID, stored.GetContentTypes(), current.GetContentTypes(), replace, ctr, errs)
+	restoreSitePermissions(ctx, sr, siteID, stored.GetPermissions(), current.GetPermissions(), ctr, errs)
+
+	if restoreCfg.CreateMissingTerms {
+		restoreTermStore(ctx, sr, siteID, stored, ctr, errs)
+	}
+
+	restoreSiteWeb(ctx, sr, siteID, stored, replace, ctr, errs)
+
+	if settings := betaAPI.SiteSettings(stored); settings != nil {
+		if !replace {
+			ctr.Inc(count.CollisionSkip)
+		} else if err := sr.PatchSiteSettings(ctx, siteID, settings); err != nil {
+			errs.AddRecoverable(ctx, clues.Stack(err))
+		} else {
+			ctr.Inc(count.CollisionReplace)
+		}
+	}
+
+	info := betaAPI.SiteConfigurationInfo(stored, int64(len(body)))
+
+	return info, nil
+}
+
+func restoreTermStore(
+	ctx context.Context,
+	sr SiteConfigurationRestorer,
+	siteID string,
+	stored models.Siteable,
+	ctr *count.Bus,
+	errs *fault.Bus,
+) {
+	store, err := betaAPI.SiteTermStore(stored)
+	if err != nil {
+		errs.AddRecoverable(ctx, clues.Stack(err))
+		return
+	}
+
+	if store == nil {
+		return
+	}
+
+	if err := newTermRestorer(sr, siteID).restoreTermStore(ctx, store, ctr); err != nil {
+		errs.AddRecoverable(ctx, clues.Wrap(err, "restoring term store"))
+	}
+}
+
+func restoreSiteColumns(
+	ctx context.Context,
+	sr SiteConfigurationRestorer,
+	siteID string,
+	stored, current []models.ColumnDefinitionable,
+	replace bool,
+	ctr *count.Bus,
+	errs *fault.Bus,
+) {
+	existing := map[string]models.ColumnDefinitionable{}
+
+	for _, col := range current {
+		existing[ptr.Val(col.GetName())] = col
+	}
+
+	for _, col := range stored {
+		if isBuiltInColumn(col) {
+			continue
+		}
+
+		cctx := clues.Add(ctx, "column_name", clues.Hide(ptr.Val(col.GetName())))
+
+		err := restoreSiteElement(
+			cctx,
+			existing[ptr.Val(col.GetName())],
+			replace,
+			isBuiltInColumn,
+			func() error { return sr.PostSiteColumn(cctx, siteID, col) },
+			func(c models.ColumnDefinitionable) error {
+				return sr.PatchSiteColumn(cctx, siteID, ptr.Val(c.GetId()), col)
+			},
+			ctr)
+		if err != nil {
+			errs.AddRecoverable(cctx, clues.Stack(err))
+		}
+	}
+}
+
+func restoreSiteContentTypes(
+	ctx context.Context,
+	sr SiteConfigurationRestorer,
+	siteID string,
+	stored, current []models.ContentTypeable,
+	replace bool,
+	ctr *count.Bus,
+	errs *fault.Bus,
+) {
+	existing := map[string]models.ContentTypeable{}
+
+	for _, ct := range current {
+		existing[ptr.Val(ct.GetName())] = ct
+	}
+
+	// content type ids extend the id of their parent, so sorting by length
+	// ensures parents get created before their children.
+	stored = append([]models.ContentTypeable{}, stored...)
+	sort.SliceStable(stored, func(i, j int) bool {
+		return len(ptr.Val(stored[i].GetId())) < len(ptr.Val(stored[j].GetId()))
+	})
+
+	for _, ct := range stored {
+		if isBuiltInContentType(ct) {
+			continue
+		}
+
+		cctx := clues.Add(ctx, "content_type_name", clues.Hide(ptr.Val(ct.GetName())))
+
+		err := restoreSiteElement(
+			cctx,
+			existing[ptr.Val(ct.GetName())],
+			replace,
+			isBuiltInContentType,
+			func() error { return sr.PostSiteContentType(cctx, siteID, ct) },
+			func(c models.ContentTypeable) error {
+				return sr.PatchSiteContentType(cctx, siteID, ptr.Val(c.GetId()), ct)
+			},
+			ctr)
+		if err != nil {
+			errs.AddRecoverable(cctx, clues.Stack(err))
+		}
+	}
+}
+
+// restoreSiteElement creates the element when it doesn't exist in the
+// site, and otherwise handles the collision according to the policy.
+func restoreSiteElement[T comparable](
+	ctx context.Context,
+	existing T,
+	replace bool,
+	isBuiltIn func(T) bool,
+	post func() error,
+	patch func(T) error,
+	ctr *count.Bus,
+) error {
+	var zero T
+
+	if existing == zero {
+		if err := post(); err != nil {
+			return err
+		}
+
+		ctr.Inc(count.NewItemCreated)
+
+		return nil
+	}
+
+	log := logger.Ctx(ctx)
+	log.Debug("item collision")
+
+	if !replace || isBuiltIn(existing) {
+		ctr.Inc(count.CollisionSkip)
+		log.Debug("skipping item with collision")
+
+		return nil
+	}
+
+	if err := patch(existing); err != nil {
+		return err
+	}
+
+	ctr.Inc(count.CollisionReplace)
+
+	return nil
+}
+
+func restoreSitePermissions(
+	ctx context.Context,
+	sr SiteConfigurationRestorer,
+	siteID string,
+	stored, current []models.Permissionable,
+	ctr *count.Bus,
+	errs *fault.Bus,
+) {
+	existing := map[string]struct{}{}
+
+	for _, perm := range current {
+		existing[betaAPI.SitePermissionApplicationID(perm)] = struct{}{}
+	}
+
+	for _, perm := range stored {
+		appID := betaAPI.SitePermissionApplicationID(perm)
+		if len(appID) == 0 {
+			continue
+		}
+
+		pctx := clues.Add(ctx, "app_id", appID)
+
+		// permissions can't be updated in place, so existing grants are kept.
+		if _, ok := existing[appID]; ok {
+			ctr.Inc(count.CollisionSkip)
+			continue
+		}
+
+		if err := sr.PostSitePermission(pctx, siteID, perm); err != nil {
+			errs.AddRecoverable(pctx, clues.Stack(err))
+			continue
+		}
+
+		ctr.Inc(count.NewItemCreated)
+	}
+}
+
+func isBuiltInColumn(col models.ColumnDefinitionable) bool {
+	return ptr.Val(col.GetReadOnly()) || ptr.Val(col.GetIsSealed())
+}
+
+func isBuiltInContentType(ct models.ContentTypeable) bool {
+	return ptr.Val(ct.GetIsBuiltIn()) || ptr.Val(ct.GetReadOnly()) || ptr.Val(ct.GetSealed())
+}
@@ -0,0 +1,241 @@
+package site
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	betaAPI "github.com/alcionai/corso/src/internal/m365/service/sharepoint/api"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	betamodels "github.com/alcionai/corso/src/pkg/services/m365/api/graph/betasdk/models"
+)
+
+type mockSiteConfigurationRestorer struct {
+	mockTermStore
+	mockSiteWeb
+
+	current models.Siteable
+
+	postedColumns      []string
+	patchedColumns     []string
+	postedContentTypes []string
+	patchedContentType []string
+	postedPermissions  []string
+	patchedSettings    bool
+}
+
+func (m *mockSiteConfigurationRestorer) GetSiteConfiguration(
+	context.Context,
+	string,
+) (models.Siteable, error) {
+	return m.current, nil
+}
+
+func (m *mockSiteConfigurationRestorer) PostSiteColumn(
+	_ context.Context,
+	_ string,
+	column models.ColumnDefinitionable,
+) error {
+	m.postedColumns = append(m.postedColumns, ptr.Val(column.GetName()))
+	return nil
+}
+
+func (m *mockSiteConfigurationRestorer) PatchSiteColumn(
+	_ context.Context,
+	_, columnID string,
+	_ models.ColumnDefinitionable,
+) error {
+	m.patchedColumns = append(m.patchedColumns, columnID)
+	return nil
+}
+
+func (m *mockSiteConfigurationRestorer) PostSiteContentType(
+	_ context.Context,
+	_ string,
+	contentType models.ContentTypeable,
+) error {
+	m.postedContentTypes = append(m.postedContentTypes, ptr.Val(contentType.GetId()))
+	return nil
+}
+
+func (m *mockSiteConfigurationRestorer) PatchSiteContentType(
+	_ context.Context,
+	_, contentTypeID string,
+	_ models.ContentTypeable,
+) error {
+	m.patchedContentType = append(m.patchedContentType, contentTypeID)
+	return nil
+}
+
+func (m *mockSiteConfigurationRestorer) PostSitePermission(
+	_ context.Context,
+	_ string,
+	perm models.Permissionable,
+) error {
+	m.postedPermissions = append(m.postedPermissions, betaAPI.SitePermissionApplicationID(perm))
+	return nil
+}
+
+func (m *mockSiteConfigurationRestorer) PatchSiteSettings(
+	context.Context,
+	string,
+	betamodels.SiteSettingsable,
+) error {
+	m.patchedSettings = true
+	return nil
+}
+
+func siteColumn(id, name string, sealed bool) models.ColumnDefinitionable {
+	col := models.NewColumnDefinition()
+	col.SetId(ptr.To(id))
+	col.SetName(ptr.To(name))
+	col.SetIsSealed(ptr.To(sealed))
+
+	return col
+}
+
+func siteContentType(id, name string, builtIn bool) models.ContentTypeable {
+	ct := models.NewContentType()
+	ct.SetId(ptr.To(id))
+	ct.SetName(ptr.To(name))
+	ct.SetIsBuiltIn(ptr.To(builtIn))
+
+	return ct
+}
+
+func sitePermission(appID string) models.Permissionable {
+	app := models.NewIdentity()
+	app.SetId(ptr.To(appID))
+
+	is := models.NewIdentitySet()
+	is.SetApplication(app)
+
+	perm := models.NewPermission()
+	perm.SetRoles([]string{"read"})
+	perm.SetGrantedToIdentities([]models.IdentitySetable{is})
+
+	return perm
+}
+
+type SiteConfigurationUnitSuite struct {
+	tester.Suite
+}
+
+func TestSiteConfigurationUnitSuite(t *testing.T) {
+	suite.Run(t, &SiteConfigurationUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SiteConfigurationUnitSuite) TestRestoreSiteConfiguration() {
+	stored := models.NewSite()
+	stored.SetId(ptr.To("site-id"))
+	stored.SetDisplayName(ptr.To("site"))
+	stored.SetColumns([]models.ColumnDefinitionable{
+		siteColumn("title", "Title", true),
+		siteColumn("c1", "existing", false),
+		siteColumn("c2", "missing", false),
+	})
+	stored.SetContentTypes([]models.ContentTypeable{
+		siteContentType("0x01", "Item", true),
+		siteContentType("0x0100AA01", "child", false),
+		siteContentType("0x0100AA", "parent", false),
+		siteContentType("0x0100BB", "existing", false),
+	})
+	stored.SetPermissions([]models.Permissionable{
+		sitePermission("existing-app"),
+		sitePermission("missing-app"),
+	})
+
+	settings := betamodels.NewSiteSettings()
+	settings.SetTimeZone(ptr.To("UTC"))
+	stored.SetAdditionalData(map[string]any{betaAPI.SiteSettingsKey: settings})
+
+	body, err := betaAPI.SiteConfigurationBytes(stored)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	current := models.NewSite()
+	current.SetColumns([]models.ColumnDefinitionable{
+		siteColumn("title", "Title", true),
+		siteColumn("current-c1", "existing", false),
+	})
+	current.SetContentTypes([]models.ContentTypeable{
+		siteContentType("0x01", "Item", true),
+		siteContentType("0x0100CC", "existing", false),
+	})
+	current.SetPermissions([]models.Permissionable{sitePermission("existing-app")})
+
+	table := []struct {
+		name              string
+		collisionPolicy   control.CollisionPolicy
+		expectPatchedCols []string
+		expectPatchedCTs  []string
+		expectSettings    bool
+		expectCounts      map[count.Key]int64
+	}{
+		{
+			name:            "skip",
+			collisionPolicy: control.Skip,
+			expectCounts: map[count.Key]int64{
+				count.NewItemCreated:   4,
+				count.CollisionSkip:    4,
+				count.CollisionReplace: 0,
+			},
+		},
+		{
+			name:              "replace",
+			collisionPolicy:   control.Replace,
+			expectPatchedCols: []string{"current-c1"},
+			expectPatchedCTs:  []string{"0x0100CC"},
+			expectSettings:    true,
+			expectCounts: map[count.Key]int64{
+				count.NewItemCreated:   4,
+				count.CollisionSkip:    1,
+				count.CollisionReplace: 3,
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				sr   = &mockSiteConfigurationRestorer{current: current}
+				ctr  = count.New()
+				errs = fault.New(true)
+				cfg  = control.DefaultRestoreConfig("")
+			)
+
+			cfg.OnCollision = test.collisionPolicy
+
+			info, err := restoreSiteConfiguration(ctx, sr, "site-id", body, cfg, ctr, errs)
+			require.NoError(t, err, clues.ToCore(err))
+			require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+
+			assert.Equal(t, details.SharePointSiteConfiguration, info.ItemType)
+			assert.Equal(t, "site", info.ItemName)
+
+			assert.Equal(t, []string{"missing"}, sr.postedColumns)
+			assert.Equal(t, []string{"0x0100AA", "0x0100AA01"}, sr.postedContentTypes, "parents created first")
+			assert.Equal(t, []string{"missing-app"}, sr.postedPermissions)
+			assert.Equal(t, test.expectPatchedCols, sr.patchedColumns)
+			assert.Equal(t, test.expectPatchedCTs, sr.patchedContentType)
+			assert.Equal(t, test.expectSettings, sr.patchedSettings)
+
+			for k, v := range test.expectCounts {
+				assert.Equal(t, v, ctr.Get(k), k)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package site
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	betaAPI "github.com/alcionai/corso/src/internal/m365/service/sharepoint/api"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// SiteWebRestorer reads and extends the navigation, SharePoint groups,
+// features, and theme of a site.
+type SiteWebRestorer interface {
+	GetSiteWebURL(ctx context.Context, siteID string) (string, error)
+	GetSiteWebConfig(ctx context.Context, siteID string) (*api.SiteWebConfig, error)
+	PostNavigationNode(
+		ctx context.Context,
+		siteURL, location string,
+		parentID int,
+		node api.NavigationNode,
+	) (api.NavigationNode, error)
+	PatchNavigationNode(ctx context.Context, siteURL string, nodeID int, node api.NavigationNode) error
+	PostSiteGroup(ctx context.Context, siteURL string, group api.SiteGroup) (api.SiteGroup, error)
+	PatchSiteGroup(ctx context.Context, siteURL string, groupID int, group api.SiteGroup) error
+	PostSiteGroupMember(ctx context.Context, siteURL string, groupID int, loginName string) error
+	ActivateSiteFeature(ctx context.Context, siteURL, featureID string) error
+	ApplySiteTheme(ctx context.Context, siteURL string, theme api.SiteTheme) error
+}
+
+var _ SiteWebRestorer = api.SiteWeb{}
+
+// restoreSiteWeb applies the stored navigation, SharePoint groups, features,
+// and theme to the site.  Navigation links and groups are matched by title.
+// Those missing from the site are created, and those that already exist
+// are only updated on replace.  Missing features get activated.  The theme
+// is only applied on replace.
+func restoreSiteWeb(
+	ctx context.Context,
+	sr SiteWebRestorer,
+	siteID string,
+	stored models.Siteable,
+	replace bool,
+	ctr *count.Bus,
+	errs *fault.Bus,
+) {
+	web, err := betaAPI.SiteWeb(stored)
+	if err != nil {
+		errs.AddRecoverable(ctx, clues.Stack(err))
+		return
+	}
+
+	if web == nil {
+		return
+	}
+
+	current, err := sr.GetSiteWebConfig(ctx, siteID)
+	if err != nil {
+		errs.AddRecoverable(ctx, clues.Wrap(err, "getting current site web configuration"))
+		return
+	}
+
+	siteURL, err := sr.GetSiteWebURL(ctx, siteID)
+	if err != nil {
+		errs.AddRecoverable(ctx, clues.Stack(err))
+		return
+	}
+
+	ctx = clues.Add(ctx, "site_url", clues.Hide(siteURL))
+
+	restoreNavigation(
+		ctx, sr, siteURL, api.QuickLaunch, 0,
+		web.QuickLaunch, current.QuickLaunch,
+		replace, ctr, errs)
+	restoreNavigation(
+		ctx, sr, siteURL, api.TopNavigationBar, 0,
+		web.TopNavigation, current.TopNavigation,
+		replace, ctr, errs)
+	restoreSiteGroups(ctx, sr, siteURL, web.Groups, current.Groups, replace, ctr, errs)
+	restoreSiteFeatures(ctx, sr, siteURL, web.FeatureIDs, current.FeatureIDs, ctr, errs)
+
+	if web.Theme != nil {
+		if !replace {
+			ctr.Inc(count.CollisionSkip)
+		} else if err := sr.ApplySiteTheme(ctx, siteURL, *web.Theme); err != nil {
+			errs.AddRecoverable(ctx, clues.Stack(err))
+		} else {
+			ctr.Inc(count.CollisionReplace)
+		}
+	}
+}
+
+// restoreNavigation restores the nodes beneath the parent node, or at the
+// top level of the location if the parent id is 0, along with all of their
+// descendants.
+func restoreNavigation(
+	ctx context.Context,
+	sr SiteWebRestorer,
+	siteURL, location string,
+	parentID int,
+	stored, current []api.NavigationNode,
+	replace bool,
+	ctr *count.Bus,
+	errs *fault.Bus,
+) {
+	existing := map[string]*api.NavigationNode{}
+
+	for i := range current {
+		existing[current[i].Title] = &current[i]
+	}
+
+	for _, node := range stored {
+		var (
+			nctx     = clues.Add(ctx, "navigation_node_title", clues.Hide(node.Title))
+			ex       = existing[node.Title]
+			nodeID   int
+			children []api.NavigationNode
+		)
+
+		err := restoreSiteElement(
+			nctx,
+			ex,
+			replace,
+			neverBuiltIn[*api.NavigationNode],
+			func() error {
+				created, err := sr.PostNavigationNode(nctx, siteURL, location, parentID, node)
+				nodeID = created.ID
+
+				return err
+			},
+			func(n *api.NavigationNode) error {
+				return sr.PatchNavigationNode(nctx, siteURL, n.ID, node)
+			},
+			ctr)
+		if err != nil {
+			errs.AddRecoverable(nctx, clues.Stack(err))
+			continue
+		}
+
+		if ex != nil {
+			nodeID = ex.ID
+			children = ex.Children
+		}
+
+		// without an id, the children would land at the top level.
+		if nodeID == 0 {
+			continue
+		}
+
+		restoreNavigation(nctx, sr, siteURL, location, nodeID, node.Children, children, replace, ctr, errs)
+	}
+}
+
+func restoreSiteGroups(
+	ctx context.Context,
+	sr SiteWebRestorer,
+	siteURL string,
+	stored, current []api.SiteGroup,
+	replace bool,
+	ctr *count.Bus,
+	errs *fault.Bus,
+) {
+	existing := map[string]*api.SiteGroup{}
+
+	for i := range current {
+		existing[current[i].Title] = &current[i]
+	}
+
+	for _, group := range stored {
+		var (
+			gctx    = clues.Add(ctx, "site_group_title", clues.Hide(group.Title))
+			ex      = existing[group.Title]
+			members = map[string]struct{}{}
+			groupID int
+		)
+
+		err := restoreSiteElement(
+			gctx,
+			ex,
+			replace,
+			neverBuiltIn[*api.SiteGroup],
+			func() error {
+				created, err := sr.PostSiteGroup(gctx, siteURL, group)
+				groupID = created.ID
+
+				return err
+			},
+			func(g *api.SiteGroup) error {
+				groupID = g.ID
+
+				for _, m := range g.Members {
+					members[m] = struct{}{}
+				}
+
+				return sr.PatchSiteGroup(gctx, siteURL, g.ID, group)
+			},
+			ctr)
+		if err != nil {
+			errs.AddRecoverable(gctx, clues.Stack(err))
+			continue
+		}
+
+		// members are only added to the groups that were created or replaced.
+		if groupID == 0 {
+			continue
+		}
+
+		for _, m := range group.Members {
+			if _, ok := members[m]; ok {
+				continue
+			}
+
+			if err := sr.PostSiteGroupMember(gctx, siteURL, groupID, m); err != nil {
+				errs.AddRecoverable(gctx, clues.Stack(err))
+			}
+		}
+	}
+}
+
+func restoreSiteFeatures(
+	ctx context.Context,
+	sr SiteWebRestorer,
+	siteURL string,
+	stored, current []string,
+	ctr *count.Bus,
+	errs *fault.Bus,
+) {
+	active := map[string]struct{}{}
+
+	for _, id := range current {
+		active[id] = struct{}{}
+	}
+
+	for _, id := range stored {
+		fctx := clues.Add(ctx, "feature_id", id)
+
+		if _, ok := active[id]; ok {
+			ctr.Inc(count.CollisionSkip)
+			continue
+		}
+
+		if err := sr.ActivateSiteFeature(fctx, siteURL, id); err != nil {
+			errs.AddRecoverable(fctx, clues.Stack(err))
+			continue
+		}
+
+		ctr.Inc(count.NewItemCreated)
+	}
+}
+
+func neverBuiltIn[T any](T) bool {
+	return false
+}
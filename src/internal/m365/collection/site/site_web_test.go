@@ -0,0 +1,240 @@
+package site
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	betaAPI "github.com/alcionai/corso/src/internal/m365/service/sharepoint/api"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type mockSiteWeb struct {
+	current *api.SiteWebConfig
+	// lastID is the id of the last created node or group.
+	lastID int
+
+	// postedNodes records each node as parentID/title.
+	postedNodes   []string
+	patchedNodes  []int
+	postedGroups  []string
+	patchedGroups []int
+	// postedMembers records each member as groupID:loginName.
+	postedMembers []string
+	activated     []string
+	appliedTheme  bool
+}
+
+func (m *mockSiteWeb) GetSiteWebURL(context.Context, string) (string, error) {
+	return "https://example.com/sites/s", nil
+}
+
+func (m *mockSiteWeb) GetSiteWebConfig(context.Context, string) (*api.SiteWebConfig, error) {
+	if m.current == nil {
+		return &api.SiteWebConfig{}, nil
+	}
+
+	return m.current, nil
+}
+
+func (m *mockSiteWeb) PostNavigationNode(
+	_ context.Context,
+	_, _ string,
+	parentID int,
+	node api.NavigationNode,
+) (api.NavigationNode, error) {
+	m.lastID++
+	m.postedNodes = append(m.postedNodes, fmt.Sprintf("%d/%s", parentID, node.Title))
+
+	return api.NavigationNode{ID: m.lastID, Title: node.Title}, nil
+}
+
+func (m *mockSiteWeb) PatchNavigationNode(_ context.Context, _ string, nodeID int, _ api.NavigationNode) error {
+	m.patchedNodes = append(m.patchedNodes, nodeID)
+	return nil
+}
+
+func (m *mockSiteWeb) PostSiteGroup(_ context.Context, _ string, group api.SiteGroup) (api.SiteGroup, error) {
+	m.lastID++
+	m.postedGroups = append(m.postedGroups, group.Title)
+
+	return api.SiteGroup{ID: m.lastID, Title: group.Title}, nil
+}
+
+func (m *mockSiteWeb) PatchSiteGroup(_ context.Context, _ string, groupID int, _ api.SiteGroup) error {
+	m.patchedGroups = append(m.patchedGroups, groupID)
+	return nil
+}
+
+func (m *mockSiteWeb) PostSiteGroupMember(_ context.Context, _ string, groupID int, loginName string) error {
+	m.postedMembers = append(m.postedMembers, fmt.Sprintf("%d:%s", groupID, loginName))
+	return nil
+}
+
+func (m *mockSiteWeb) ActivateSiteFeature(_ context.Context, _, featureID string) error {
+	m.activated = append(m.activated, featureID)
+	return nil
+}
+
+func (m *mockSiteWeb) ApplySiteTheme(context.Context, string, api.SiteTheme) error {
+	m.appliedTheme = true
+	return nil
+}
+
+type SiteWebUnitSuite struct {
+	tester.Suite
+}
+
+func TestSiteWebUnitSuite(t *testing.T) {
+	suite.Run(t, &SiteWebUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SiteWebUnitSuite) TestRestoreSiteWeb() {
+	stored := models.NewSite()
+	stored.SetId(ptr.To("site-id"))
+	stored.SetDisplayName(ptr.To("site"))
+
+	betaAPI.SetSiteWeb(stored, &api.SiteWebConfig{
+		QuickLaunch: []api.NavigationNode{
+			{
+				ID:       1,
+				Title:    "Home",
+				URL:      "/new-home",
+				Children: []api.NavigationNode{{ID: 2, Title: "Docs", URL: "/docs"}},
+			},
+			{
+				ID:       3,
+				Title:    "New",
+				URL:      "/new",
+				Children: []api.NavigationNode{{ID: 4, Title: "Child", URL: "/child"}},
+			},
+		},
+		Groups: []api.SiteGroup{
+			{ID: 1, Title: "Owners", Members: []string{"a", "b"}},
+			{ID: 2, Title: "Readers", Members: []string{"c"}},
+		},
+		FeatureIDs: []string{"f1", "f2"},
+		Theme:      &api.SiteTheme{Palette: map[string]string{"themePrimary": "#0078d4"}},
+	})
+
+	// the web configuration is restored from its serialized form.
+	body, err := betaAPI.SiteConfigurationBytes(stored)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	restored, err := betaAPI.BytesToSiteable(body)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	info := betaAPI.SiteConfigurationInfo(restored, int64(len(body)))
+	assert.Equal(suite.T(), 4, info.SiteConfiguration.NavigationNodeCount)
+	assert.Equal(suite.T(), 2, info.SiteConfiguration.GroupCount)
+	assert.Equal(suite.T(), 2, info.SiteConfiguration.FeatureCount)
+
+	current := func() *api.SiteWebConfig {
+		return &api.SiteWebConfig{
+			QuickLaunch: []api.NavigationNode{
+				{
+					ID:       10,
+					Title:    "Home",
+					URL:      "/home",
+					Children: []api.NavigationNode{{ID: 11, Title: "Docs", URL: "/docs"}},
+				},
+			},
+			Groups:     []api.SiteGroup{{ID: 20, Title: "Owners", Members: []string{"a"}}},
+			FeatureIDs: []string{"f1"},
+		}
+	}
+
+	table := []struct {
+		name                string
+		replace             bool
+		expectPatchedNodes  []int
+		expectPatchedGroups []int
+		expectMembers       []string
+		expectTheme         bool
+		expectCounts        map[count.Key]int64
+	}{
+		{
+			name:          "skip",
+			expectMembers: []string{"102:c"},
+			expectCounts: map[count.Key]int64{
+				count.NewItemCreated:   4,
+				count.CollisionSkip:    5,
+				count.CollisionReplace: 0,
+			},
+		},
+		{
+			name:                "replace",
+			replace:             true,
+			expectPatchedNodes:  []int{10, 11},
+			expectPatchedGroups: []int{20},
+			expectMembers:       []string{"20:b", "102:c"},
+			expectTheme:         true,
+			expectCounts: map[count.Key]int64{
+				count.NewItemCreated:   4,
+				count.CollisionSkip:    1,
+				count.CollisionReplace: 4,
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				sr   = &mockSiteWeb{current: current(), lastID: 99}
+				ctr  = count.New()
+				errs = fault.New(true)
+			)
+
+			restoreSiteWeb(ctx, sr, "site-id", restored, test.replace, ctr, errs)
+			require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+			assert.Empty(t, errs.Recovered())
+
+			assert.Equal(t, []string{"0/New", "100/Child"}, sr.postedNodes, "children are created beneath their parent")
+			assert.Equal(t, test.expectPatchedNodes, sr.patchedNodes)
+			assert.Equal(t, []string{"Readers"}, sr.postedGroups)
+			assert.Equal(t, test.expectPatchedGroups, sr.patchedGroups)
+			assert.Equal(t, test.expectMembers, sr.postedMembers)
+			assert.Equal(t, []string{"f2"}, sr.activated)
+			assert.Equal(t, test.expectTheme, sr.appliedTheme)
+
+			for k, v := range test.expectCounts {
+				assert.Equal(t, v, ctr.Get(k), k)
+			}
+		})
+	}
+}
+
+func (suite *SiteWebUnitSuite) TestRestoreSiteWeb_noneStored() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		sr   = &mockSiteWeb{}
+		ctr  = count.New()
+		errs = fault.New(true)
+	)
+
+	restoreSiteWeb(ctx, sr, "site-id", models.NewSite(), true, ctr, errs)
+	require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+
+	assert.Empty(t, sr.postedNodes)
+	assert.Empty(t, sr.postedGroups)
+	assert.Empty(t, sr.activated)
+	assert.False(t, sr.appliedTheme)
+}
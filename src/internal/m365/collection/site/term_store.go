@@ -0,0 +1,464 @@
+package site
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/termstore"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+type getTermStorer interface {
+	GetTermStore(ctx context.Context, siteID string) (termstore.Storeable, error)
+}
+
+// TermStoreRestorer reads and extends the term store of a site.
+type TermStoreRestorer interface {
+	getTermStorer
+	PostTermGroup(ctx context.Context, siteID string, group termstore.Groupable) (termstore.Groupable, error)
+	PostTermSet(ctx context.Context, siteID, groupID string, set termstore.Setable) (termstore.Setable, error)
+	PostTerm(
+		ctx context.Context,
+		siteID, setID, parentTermID string,
+		term termstore.Termable,
+	) (termstore.Termable, error)
+}
+
+var _ TermStoreRestorer = api.TermStore{}
+
+// ---------------------------------------------------------------------------
+// backup
+// ---------------------------------------------------------------------------
+
+// listTermsCache resolves the managed metadata terms referenced by the
+// items of each backed up list.  The site's term store is only fetched
+// once, the first time a list references any term.
+type listTermsCache struct {
+	gts    getTermStorer
+	siteID string
+
+	mu      sync.Mutex
+	fetched bool
+	refs    map[string]api.TermReference
+}
+
+func newListTermsCache(gts getTermStorer, siteID string) *listTermsCache {
+	return &listTermsCache{
+		gts:    gts,
+		siteID: siteID,
+	}
+}
+
+// addListTerms stores a reference to each term used by the list within
+// the list.  Sites whose term store can't be read are backed up without
+// any references.
+func (c *listTermsCache) addListTerms(ctx context.Context, list models.Listable) error {
+	ids := api.ListTermIDs(list)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fetched {
+		store, err := c.gts.GetTermStore(ctx, c.siteID)
+		if err != nil && !graph.IsErrAccessDenied(err) {
+			return clues.Wrap(err, "getting term store")
+		}
+
+		if err != nil {
+			logger.CtxErr(ctx, err).Info("term store not accessible; managed metadata terms won't be referenced")
+		}
+
+		c.refs = api.TermStoreReferences(store)
+		c.fetched = true
+	}
+
+	refs := make([]api.TermReference, 0, len(ids))
+
+	for id := range ids {
+		if ref, ok := c.refs[id]; ok {
+			refs = append(refs, ref)
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].ID < refs[j].ID
+	})
+
+	api.SetListTermReferences(list, refs)
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// restore
+// ---------------------------------------------------------------------------
+
+// termRestorer locates backed up terms within the term store of the
+// restore's destination site, matching each term set and term by its id,
+// or else by its name within its parent.  When allowed, missing groups,
+// sets, and terms get created.  The destination's term store is fetched
+// once, on first use, and is kept up to date with the terms created since.
+type termRestorer struct {
+	tsr    TermStoreRestorer
+	siteID string
+
+	mu       sync.Mutex
+	loaded   bool
+	store    termstore.Storeable
+	knownIDs map[string]struct{}
+}
+
+func newTermRestorer(tsr TermStoreRestorer, siteID string) *termRestorer {
+	return &termRestorer{
+		tsr:    tsr,
+		siteID: siteID,
+	}
+}
+
+// load fetches the destination's term store.  If the term store isn't
+// readable, the restorer is left without a store and resolves no terms.
+func (tr *termRestorer) load(ctx context.Context) error {
+	if tr.loaded {
+		return nil
+	}
+
+	store, err := tr.tsr.GetTermStore(ctx, tr.siteID)
+	if err != nil && !graph.IsErrAccessDenied(err) {
+		return clues.Wrap(err, "getting destination term store")
+	}
+
+	tr.loaded = true
+
+	if err != nil {
+		logger.CtxErr(ctx, err).Info("destination term store not accessible; managed metadata terms won't be resolved")
+		return nil
+	}
+
+	tr.store = store
+	tr.knownIDs = map[string]struct{}{}
+
+	for id := range api.TermStoreReferences(store) {
+		tr.knownIDs[id] = struct{}{}
+	}
+
+	return nil
+}
+
+// remapListTerms points the managed metadata values of the list's items
+// at the matching terms of the destination's term store.  Terms that can't
+// be matched or created keep their backed up ids.
+func (tr *termRestorer) remapListTerms(
+	ctx context.Context,
+	list models.Listable,
+	createMissing bool,
+	ctr *count.Bus,
+) error {
+	refs, err := api.ListTermReferences(list)
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	if len(refs) == 0 {
+		return nil
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if err := tr.load(ctx); err != nil {
+		return err
+	}
+
+	ids := map[string]string{}
+
+	for _, ref := range refs {
+		group, set, path := storedTermFromReference(ref)
+
+		id, err := tr.ensureTerm(ctx, group, set, path, createMissing, ctr)
+		if err != nil {
+			return clues.Wrap(err, "resolving term").With("term_id", ref.ID)
+		}
+
+		if len(id) == 0 {
+			ctr.Inc(count.TermNotResolved)
+			continue
+		}
+
+		ids[strings.ToLower(ref.ID)] = id
+	}
+
+	api.RemapListTermIDs(list, ids)
+
+	return nil
+}
+
+// restoreTermStore recreates each term of the stored term store that's
+// missing from the destination's term store.
+func (tr *termRestorer) restoreTermStore(
+	ctx context.Context,
+	stored termstore.Storeable,
+	ctr *count.Bus,
+) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	if err := tr.load(ctx); err != nil {
+		return err
+	}
+
+	var restoreTerms func(group termstore.Groupable, set termstore.Setable, path, terms []termstore.Termable) error
+
+	restoreTerms = func(group termstore.Groupable, set termstore.Setable, path, terms []termstore.Termable) error {
+		for _, term := range terms {
+			termPath := append(append([]termstore.Termable{}, path...), term)
+
+			id, err := tr.ensureTerm(ctx, group, set, termPath, true, ctr)
+			if err != nil {
+				return clues.Wrap(err, "restoring term").With("term_id", ptr.Val(term.GetId()))
+			}
+
+			if len(id) == 0 {
+				ctr.Inc(count.TermNotResolved)
+				continue
+			}
+
+			if err := restoreTerms(group, set, termPath, term.GetChildren()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, group := range stored.GetGroups() {
+		for _, set := range group.GetSets() {
+			if err := restoreTerms(group, set, nil, set.GetChildren()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ensureTerm returns the id of the destination's term matching the last
+// term in the path, creating it and its missing ancestors, set, and group
+// when allowed.  Returns an empty id if the term isn't found and isn't
+// created.
+func (tr *termRestorer) ensureTerm(
+	ctx context.Context,
+	group termstore.Groupable,
+	set termstore.Setable,
+	path []termstore.Termable,
+	create bool,
+	ctr *count.Bus,
+) (string, error) {
+	if tr.store == nil || len(path) == 0 {
+		return "", nil
+	}
+
+	termID := strings.ToLower(ptr.Val(path[len(path)-1].GetId()))
+	if _, ok := tr.knownIDs[termID]; ok && len(termID) > 0 {
+		return termID, nil
+	}
+
+	destGroup := findTermGroup(tr.store.GetGroups(), group)
+	if destGroup == nil {
+		if !create {
+			return "", nil
+		}
+
+		created, err := tr.tsr.PostTermGroup(ctx, tr.siteID, group)
+		if graph.IsErrAccessDenied(err) {
+			logger.CtxErr(ctx, err).Info("not permitted to create term group")
+			return "", nil
+		}
+
+		if err != nil {
+			return "", clues.Stack(err)
+		}
+
+		ctr.Inc(count.TermStoreItemCreated)
+
+		destGroup = created
+		tr.store.SetGroups(append(tr.store.GetGroups(), destGroup))
+	}
+
+	destSet := findTermSet(destGroup.GetSets(), set)
+	if destSet == nil {
+		if !create {
+			return "", nil
+		}
+
+		created, err := tr.tsr.PostTermSet(ctx, tr.siteID, ptr.Val(destGroup.GetId()), tr.setWithLanguage(set))
+		if graph.IsErrAccessDenied(err) {
+			logger.CtxErr(ctx, err).Info("not permitted to create term set")
+			return "", nil
+		}
+
+		if err != nil {
+			return "", clues.Stack(err)
+		}
+
+		ctr.Inc(count.TermStoreItemCreated)
+
+		destSet = created
+		destGroup.SetSets(append(destGroup.GetSets(), destSet))
+	}
+
+	var (
+		setID    = ptr.Val(destSet.GetId())
+		parentID string
+		siblings = destSet.GetChildren()
+		setKids  = destSet.SetChildren
+	)
+
+	for _, term := range path {
+		destTerm := findTerm(siblings, term)
+
+		if destTerm == nil {
+			if !create {
+				return "", nil
+			}
+
+			created, err := tr.tsr.PostTerm(ctx, tr.siteID, setID, parentID, tr.termWithLanguage(term))
+			if graph.IsErrAccessDenied(err) {
+				logger.CtxErr(ctx, err).Info("not permitted to create term")
+				return "", nil
+			}
+
+			if err != nil {
+				return "", clues.Stack(err)
+			}
+
+			ctr.Inc(count.TermStoreItemCreated)
+
+			destTerm = created
+			tr.knownIDs[strings.ToLower(ptr.Val(destTerm.GetId()))] = struct{}{}
+
+			setKids(append(siblings, destTerm))
+		}
+
+		parentID = ptr.Val(destTerm.GetId())
+		siblings = destTerm.GetChildren()
+		setKids = destTerm.SetChildren
+	}
+
+	return strings.ToLower(parentID), nil
+}
+
+// setWithLanguage fills in the language of each of the set's names that
+// has none, using the destination term store's default language.  Names
+// rebuilt from term references don't record a language.
+func (tr *termRestorer) setWithLanguage(set termstore.Setable) termstore.Setable {
+	for _, ln := range set.GetLocalizedNames() {
+		if len(ptr.Val(ln.GetLanguageTag())) == 0 {
+			ln.SetLanguageTag(tr.store.GetDefaultLanguageTag())
+		}
+	}
+
+	return set
+}
+
+// termWithLanguage fills in the language of each of the term's labels that
+// has none, using the destination term store's default language.
+func (tr *termRestorer) termWithLanguage(term termstore.Termable) termstore.Termable {
+	for _, l := range term.GetLabels() {
+		if len(ptr.Val(l.GetLanguageTag())) == 0 {
+			l.SetLanguageTag(tr.store.GetDefaultLanguageTag())
+		}
+	}
+
+	return term
+}
+
+func findTermGroup(groups []termstore.Groupable, stored termstore.Groupable) termstore.Groupable {
+	return findTermStoreElement(groups, stored, func(g termstore.Groupable) string {
+		return ptr.Val(g.GetDisplayName())
+	})
+}
+
+func findTermSet(sets []termstore.Setable, stored termstore.Setable) termstore.Setable {
+	return findTermStoreElement(sets, stored, api.TermSetName)
+}
+
+func findTerm(terms []termstore.Termable, stored termstore.Termable) termstore.Termable {
+	return findTermStoreElement(terms, stored, api.TermLabel)
+}
+
+// findTermStoreElement finds the element matching the stored element's id,
+// or else its name.
+func findTermStoreElement[T interface{ GetId() *string }](
+	elements []T,
+	stored T,
+	nameOf func(T) string,
+) T {
+	var (
+		zero     T
+		storedID = ptr.Val(stored.GetId())
+		named    = zero
+		found    bool
+	)
+
+	for _, e := range elements {
+		if len(storedID) > 0 && strings.EqualFold(ptr.Val(e.GetId()), storedID) {
+			return e
+		}
+
+		if !found && strings.EqualFold(nameOf(e), nameOf(stored)) {
+			named = e
+			found = true
+		}
+	}
+
+	return named
+}
+
+// storedTermFromReference rebuilds the term store elements that lead to the
+// referenced term.  Only the referenced term, its set, and its group carry
+// ids; ancestor terms are identified by their labels.
+func storedTermFromReference(
+	ref api.TermReference,
+) (termstore.Groupable, termstore.Setable, []termstore.Termable) {
+	group := termstore.NewGroup()
+	group.SetId(ptr.To(ref.GroupID))
+	group.SetDisplayName(ptr.To(ref.GroupName))
+
+	name := termstore.NewLocalizedName()
+	name.SetName(ptr.To(ref.SetName))
+
+	set := termstore.NewSet()
+	set.SetId(ptr.To(ref.SetID))
+	set.SetLocalizedNames([]termstore.LocalizedNameable{name})
+
+	path := make([]termstore.Termable, 0, len(ref.Path))
+
+	for _, l := range ref.Path {
+		label := termstore.NewLocalizedLabel()
+		label.SetName(ptr.To(l))
+		label.SetIsDefault(ptr.To(true))
+
+		term := termstore.NewTerm()
+		term.SetLabels([]termstore.LocalizedLabelable{label})
+
+		path = append(path, term)
+	}
+
+	if len(path) > 0 {
+		path[len(path)-1].SetId(ptr.To(ref.ID))
+	}
+
+	return group, set, path
+}
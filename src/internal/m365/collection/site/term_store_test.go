@@ -0,0 +1,374 @@
+package site
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/termstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	graphTD "github.com/alcionai/corso/src/pkg/services/m365/api/graph/testdata"
+)
+
+type mockTermStore struct {
+	store   termstore.Storeable
+	getErr  error
+	postErr error
+
+	gets   int
+	posted []string
+}
+
+func (m *mockTermStore) GetTermStore(context.Context, string) (termstore.Storeable, error) {
+	m.gets++
+	return m.store, m.getErr
+}
+
+func (m *mockTermStore) PostTermGroup(
+	_ context.Context,
+	_ string,
+	group termstore.Groupable,
+) (termstore.Groupable, error) {
+	if m.postErr != nil {
+		return nil, m.postErr
+	}
+
+	m.posted = append(m.posted, "group:"+ptr.Val(group.GetDisplayName()))
+
+	created := termstore.NewGroup()
+	created.SetId(ptr.To(fmt.Sprintf("new-%d", len(m.posted))))
+	created.SetDisplayName(group.GetDisplayName())
+
+	return created, nil
+}
+
+func (m *mockTermStore) PostTermSet(
+	_ context.Context,
+	_, _ string,
+	set termstore.Setable,
+) (termstore.Setable, error) {
+	if m.postErr != nil {
+		return nil, m.postErr
+	}
+
+	m.posted = append(m.posted, "set:"+api.TermSetName(set))
+
+	created := termstore.NewSet()
+	created.SetId(ptr.To(fmt.Sprintf("new-%d", len(m.posted))))
+	created.SetLocalizedNames(set.GetLocalizedNames())
+
+	return created, nil
+}
+
+func (m *mockTermStore) PostTerm(
+	_ context.Context,
+	_, _, _ string,
+	term termstore.Termable,
+) (termstore.Termable, error) {
+	if m.postErr != nil {
+		return nil, m.postErr
+	}
+
+	m.posted = append(m.posted, "term:"+api.TermLabel(term))
+
+	created := termstore.NewTerm()
+	created.SetId(ptr.To(fmt.Sprintf("new-%d", len(m.posted))))
+	created.SetLabels(term.GetLabels())
+
+	return created, nil
+}
+
+func testTerm(id, label string, children ...termstore.Termable) termstore.Termable {
+	l := termstore.NewLocalizedLabel()
+	l.SetName(ptr.To(label))
+	l.SetIsDefault(ptr.To(true))
+
+	term := termstore.NewTerm()
+	term.SetId(ptr.To(id))
+	term.SetLabels([]termstore.LocalizedLabelable{l})
+	term.SetChildren(children)
+
+	return term
+}
+
+func testTermStore(groupID, setID string, terms ...termstore.Termable) termstore.Storeable {
+	name := termstore.NewLocalizedName()
+	name.SetName(ptr.To("Departments"))
+
+	set := termstore.NewSet()
+	set.SetId(ptr.To(setID))
+	set.SetLocalizedNames([]termstore.LocalizedNameable{name})
+	set.SetChildren(terms)
+
+	group := termstore.NewGroup()
+	group.SetId(ptr.To(groupID))
+	group.SetDisplayName(ptr.To("Company"))
+	group.SetSets([]termstore.Setable{set})
+
+	store := termstore.NewStore()
+	store.SetDefaultLanguageTag(ptr.To("en-US"))
+	store.SetGroups([]termstore.Groupable{group})
+
+	return store
+}
+
+func listWithTerms(termIDs ...string) models.Listable {
+	items := make([]models.ListItemable, 0, len(termIDs))
+
+	for _, id := range termIDs {
+		fields := models.NewFieldValueSet()
+		fields.SetAdditionalData(map[string]any{
+			"Department": map[string]any{
+				api.MetadataLabelKey:    ptr.To("label"),
+				api.MetadataTermGUIDKey: ptr.To(id),
+				api.MetadataWssIDKey:    ptr.To("1"),
+			},
+		})
+
+		li := models.NewListItem()
+		li.SetFields(fields)
+
+		items = append(items, li)
+	}
+
+	list := models.NewList()
+	list.SetItems(items)
+
+	return list
+}
+
+func listTermID(list models.Listable, i int) string {
+	md := list.GetItems()[i].GetFields().GetAdditionalData()["Department"].(map[string]any)
+	return ptr.Val(md[api.MetadataTermGUIDKey].(*string))
+}
+
+type TermStoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestTermStoreUnitSuite(t *testing.T) {
+	suite.Run(t, &TermStoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *TermStoreUnitSuite) TestListTermsCache_AddListTerms() {
+	store := testTermStore(
+		"group-id", "set-id",
+		testTerm("eng-id", "Engineering", testTerm("plat-id", "Platform")),
+		testTerm("sales-id", "Sales"))
+
+	table := []struct {
+		name       string
+		mock       *mockTermStore
+		expectErr  assert.ErrorAssertionFunc
+		expectRefs []string
+	}{
+		{
+			name:       "resolved",
+			mock:       &mockTermStore{store: store},
+			expectErr:  assert.NoError,
+			expectRefs: []string{"plat-id", "sales-id"},
+		},
+		{
+			name:      "access denied",
+			mock:      &mockTermStore{getErr: graphTD.ODataErr("ErrorAccessDenied")},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "other error",
+			mock:      &mockTermStore{getErr: assert.AnError},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				cache = newListTermsCache(test.mock, "site-id")
+				list  = listWithTerms("SALES-ID", "plat-id", "unknown-id")
+			)
+
+			err := cache.addListTerms(ctx, list)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			err = cache.addListTerms(ctx, listWithTerms())
+			require.NoError(t, err, clues.ToCore(err))
+
+			refs, err := api.ListTermReferences(list)
+			require.NoError(t, err, clues.ToCore(err))
+
+			ids := []string{}
+			for _, ref := range refs {
+				ids = append(ids, ref.ID)
+			}
+
+			if len(test.expectRefs) == 0 {
+				assert.Empty(t, ids)
+			} else {
+				assert.Equal(t, test.expectRefs, ids)
+			}
+
+			assert.Equal(t, 1, test.mock.gets, "term store fetched once")
+		})
+	}
+}
+
+func (suite *TermStoreUnitSuite) TestTermRestorer_RemapListTerms() {
+	backup := testTermStore(
+		"group-id", "set-id",
+		testTerm("eng-id", "Engineering", testTerm("plat-id", "Platform")),
+		testTerm("sales-id", "Sales"))
+
+	table := []struct {
+		name          string
+		dest          func() termstore.Storeable
+		postErr       error
+		createMissing bool
+		expectIDs     []string
+		expectPosted  []string
+		expectCounts  map[count.Key]int64
+	}{
+		{
+			name: "same term store",
+			dest: func() termstore.Storeable {
+				return testTermStore(
+					"group-id", "set-id",
+					testTerm("eng-id", "Engineering", testTerm("plat-id", "Platform")),
+					testTerm("sales-id", "Sales"))
+			},
+			expectIDs: []string{"plat-id", "sales-id"},
+		},
+		{
+			name: "matched by name",
+			dest: func() termstore.Storeable {
+				return testTermStore(
+					"other-group", "other-set",
+					testTerm("eng-2", "engineering", testTerm("plat-2", "Platform")),
+					testTerm("sales-2", "Sales"))
+			},
+			expectIDs: []string{"plat-2", "sales-2"},
+		},
+		{
+			name: "missing, not created",
+			dest: func() termstore.Storeable {
+				return testTermStore(
+					"other-group", "other-set",
+					testTerm("eng-2", "Engineering"))
+			},
+			expectIDs: []string{"plat-id", "sales-id"},
+			expectCounts: map[count.Key]int64{
+				count.TermNotResolved:      2,
+				count.TermStoreItemCreated: 0,
+			},
+		},
+		{
+			name: "missing, created",
+			dest: func() termstore.Storeable {
+				return testTermStore(
+					"other-group", "other-set",
+					testTerm("eng-2", "Engineering"))
+			},
+			createMissing: true,
+			expectIDs:     []string{"new-1", "new-2"},
+			expectPosted:  []string{"term:Platform", "term:Sales"},
+			expectCounts: map[count.Key]int64{
+				count.TermNotResolved:      0,
+				count.TermStoreItemCreated: 2,
+			},
+		},
+		{
+			name: "missing set, created",
+			dest: func() termstore.Storeable {
+				store := testTermStore("other-group", "other-set")
+				store.GetGroups()[0].SetSets(nil)
+
+				return store
+			},
+			createMissing: true,
+			expectIDs:     []string{"new-3", "new-4"},
+			expectPosted:  []string{"set:Departments", "term:Engineering", "term:Platform", "term:Sales"},
+			expectCounts: map[count.Key]int64{
+				count.TermStoreItemCreated: 4,
+			},
+		},
+		{
+			name: "creation denied",
+			dest: func() termstore.Storeable {
+				return testTermStore("other-group", "other-set")
+			},
+			postErr:       graphTD.ODataErr("ErrorAccessDenied"),
+			createMissing: true,
+			expectIDs:     []string{"plat-id", "sales-id"},
+			expectCounts: map[count.Key]int64{
+				count.TermNotResolved:      2,
+				count.TermStoreItemCreated: 0,
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				mock = &mockTermStore{store: test.dest(), postErr: test.postErr}
+				tr   = newTermRestorer(mock, "site-id")
+				ctr  = count.New()
+				list = listWithTerms("plat-id", "sales-id")
+			)
+
+			err := newListTermsCache(&mockTermStore{store: backup}, "site-id").addListTerms(ctx, list)
+			require.NoError(t, err, clues.ToCore(err))
+
+			err = tr.remapListTerms(ctx, list, test.createMissing, ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectIDs, []string{listTermID(list, 0), listTermID(list, 1)})
+			assert.Equal(t, test.expectPosted, mock.posted)
+
+			for k, v := range test.expectCounts {
+				assert.Equal(t, v, ctr.Get(k), k)
+			}
+		})
+	}
+}
+
+func (suite *TermStoreUnitSuite) TestTermRestorer_RestoreTermStore() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		stored = testTermStore(
+			"group-id", "set-id",
+			testTerm("eng-id", "Engineering", testTerm("plat-id", "Platform")),
+			testTerm("sales-id", "Sales"))
+		mock = &mockTermStore{
+			store: testTermStore(
+				"group-id", "set-id",
+				testTerm("eng-id", "Engineering")),
+		}
+		ctr = count.New()
+	)
+
+	err := newTermRestorer(mock, "site-id").restoreTermStore(ctx, stored, ctr)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, []string{"term:Platform", "term:Sales"}, mock.posted)
+	assert.Equal(t, int64(2), ctr.Get(count.TermStoreItemCreated))
+}
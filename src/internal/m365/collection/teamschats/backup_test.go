@@ -98,6 +98,7 @@ func (bh mockBackupHandler) getItem(
 	_ context.Context,
 	_ string,
 	itemID string,
+	_ *fault.Bus,
 ) (models.Chatable, *details.TeamsChatsInfo, error) {
 	chat := models.NewChat()
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -81,9 +82,10 @@ func (bh usersChatsBackupHandler) getItem(
 	ctx context.Context,
 	userID string,
 	chatID string,
+	errs *fault.Bus,
 ) (models.Chatable, *details.TeamsChatsInfo, error) {
-	// FIXME: should retrieve and populate all messages in the chat.
-	return nil, nil, clues.New("not implemented")
+	chat, info, err := bh.ac.GetChatWithMessages(ctx, chatID, errs)
+	return chat, info, clues.Stack(err).OrNil()
 }
 
 //lint:ignore U1000 false linter issue due to generics
@@ -19,13 +19,17 @@ import (
 	"github.com/alcionai/corso/src/internal/observe"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/extensions"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
 )
 
-var _ data.BackupCollection = &lazyFetchCollection[chatsItemer]{}
+var (
+	_ data.BackupCollection = &lazyFetchCollection[chatsItemer]{}
+	_ data.ItemEstimator    = &lazyFetchCollection[chatsItemer]{}
+)
 
 const (
 	collectionChannelBufferSize = 1000
@@ -101,6 +105,12 @@ type lazyFetchCollection[I chatsItemer] struct {
 	statusUpdater support.StatusUpdater
 }
 
+// EstimateItems reports the number of chats in the collection.  Chat sizes
+// aren't known until their messages are fetched.
+func (col *lazyFetchCollection[I]) EstimateItems() (int64, int64, bool) {
+	return int64(len(col.items)), 0, false
+}
+
 func (col *lazyFetchCollection[I]) Items(
 	ctx context.Context,
 	errs *fault.Bus,
@@ -177,6 +187,7 @@ func (col *lazyFetchCollection[I]) streamItems(ctx context.Context, errs *fault.
 					containerIDs:  col.FullPath().Folders(),
 					contains:      col.contains,
 					parentPath:    col.LocationPath().String(),
+					extensions:    col.Opts().ItemExtensionFactory,
 				},
 				id,
 				modTime,
@@ -202,6 +213,7 @@ type lazyItemGetter[I chatsItemer] struct {
 	containerIDs  path.Elements
 	modTime       time.Time
 	contains      container[I]
+	extensions    []extensions.CreateItemExtensioner
 }
 
 func (lig *lazyItemGetter[I]) GetData(
@@ -214,7 +226,8 @@ func (lig *lazyItemGetter[I]) GetData(
 	item, info, err := lig.getAndAugment.getItem(
 		ctx,
 		lig.resourceID,
-		lig.itemID)
+		lig.itemID,
+		errs)
 	if err != nil {
 		// For items that were deleted in flight, add the skip label so that
 		// they don't lead to recoverable failures during backup.
@@ -254,8 +267,23 @@ func (lig *lazyItemGetter[I]) GetData(
 	// for proper details merging.
 	info.Modified = lig.modTime
 
-	return io.NopCloser(bytes.NewReader(itemData)),
-		&details.ItemInfo{TeamsChats: info},
-		false,
-		nil
+	itemInfo := &details.ItemInfo{TeamsChats: info}
+
+	rc, extData, err := extensions.AddItemExtensions(
+		ctx,
+		io.NopCloser(bytes.NewReader(itemData)),
+		*itemInfo,
+		lig.extensions)
+	if err != nil {
+		err = clues.WrapWC(ctx, err, "adding extensions").Label(fault.LabelForceNoBackupCreation)
+		errs.AddRecoverable(ctx, err)
+
+		return nil, nil, false, err
+	}
+
+	if extData.Data != nil {
+		itemInfo.Extension = extData
+	}
+
+	return rc, itemInfo, false, nil
 }
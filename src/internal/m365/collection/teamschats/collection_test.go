@@ -156,6 +156,7 @@ func (m getAndAugmentChat) getItem(
 	_ context.Context,
 	_ string,
 	itemID string,
+	_ *fault.Bus,
 ) (models.Chatable, *details.TeamsChatsInfo, error) {
 	chat := models.NewChat()
 	chat.SetId(ptr.To(itemID))
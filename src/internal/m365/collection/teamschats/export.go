@@ -0,0 +1,320 @@
+package teamschats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// NewExportCollection produces an export collection that writes each chat
+// as a json file.  The inline images and attached files stored with the
+// chat's messages get exported next to the chat.
+func NewExportCollection(
+	baseDir string,
+	backingCollections []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	stats *metrics.ExportStats,
+) export.Collectioner {
+	return export.BaseCollection{
+		BaseDir:           baseDir,
+		BackingCollection: backingCollections,
+		BackupVersion:     backupVersion,
+		Cfg:               cec,
+		Stream:            streamChats,
+		Stats:             stats,
+	}
+}
+
+// streamChats streams the items in the backingCollection into the export stream chan
+func streamChats(
+	ctx context.Context,
+	drc []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	defer close(ch)
+
+	errs := fault.New(false)
+
+	for _, rc := range drc {
+		for item := range rc.Items(ctx, errs) {
+			chat, bs, err := readChat(item.ToReader())
+			if err != nil {
+				ch <- export.Item{
+					ID:    item.ID(),
+					Error: err,
+				}
+
+				continue
+			}
+
+			body, err := formatChat(cec, item.ID(), chat, bs)
+			if err != nil {
+				ch <- export.Item{
+					ID:    item.ID(),
+					Error: err,
+				}
+
+				continue
+			}
+
+			stats.UpdateResourceCount(path.ChatsCategory)
+			body = metrics.ReaderWithStats(body, path.ChatsCategory, stats)
+
+			// chats are exported as json and should be named as such
+			ch <- export.Item{
+				ID:   item.ID(),
+				Name: item.ID() + ".json",
+				Body: body,
+			}
+
+			// inline images and attached files are exported next to the chat.
+			for _, cc := range chatContents(item.ID(), chat) {
+				ch <- export.Item{
+					ID:   item.ID(),
+					Name: cc.name,
+					Body: metrics.ReaderWithStats(
+						io.NopCloser(bytes.NewReader(cc.content)),
+						path.ChatsCategory,
+						stats),
+				}
+			}
+		}
+
+		items, recovered := errs.ItemsAndRecovered()
+
+		// Return all the items that we failed to source from the persistence layer
+		for _, item := range items {
+			ch <- export.Item{
+				ID:    item.ID,
+				Error: &item,
+			}
+		}
+
+		for _, err := range recovered {
+			ch <- export.Item{
+				Error: err,
+			}
+		}
+	}
+}
+
+type (
+	minimumChat struct {
+		CreatedDateTime     time.Time            `json:"createdDateTime"`
+		LastUpdatedDateTime time.Time            `json:"lastUpdatedDateTime"`
+		Messages            []minimumChatMessage `json:"messages"`
+		Topic               string               `json:"topic"`
+	}
+
+	minimumChatMessage struct {
+		Attachments          []minimumAttachment    `json:"attachments"`
+		Content              string                 `json:"content"`
+		CreatedDateTime      time.Time              `json:"createdDateTime"`
+		From                 string                 `json:"from"`
+		HostedContents       []minimumHostedContent `json:"hostedContents,omitempty"`
+		LastModifiedDateTime time.Time              `json:"lastModifiedDateTime"`
+	}
+
+	minimumAttachment struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		// File is the name of the exported copy of the attached file.
+		File string `json:"file,omitempty"`
+	}
+
+	minimumHostedContent struct {
+		ID string `json:"id"`
+		// File is the name of the exported copy of the hosted content.
+		File string `json:"file"`
+	}
+
+	// chatContent is a hosted content or attached file that gets
+	// exported alongside the chat.
+	chatContent struct {
+		name    string
+		content []byte
+	}
+)
+
+func readChat(rc io.ReadCloser) (models.Chatable, []byte, error) {
+	defer rc.Close()
+
+	bs, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "reading item bytes")
+	}
+
+	cfb, err := api.CreateFromBytes(bs, models.CreateChatFromDiscriminatorValue)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "deserializing bytes to chat")
+	}
+
+	chat, ok := cfb.(models.Chatable)
+	if !ok {
+		return nil, nil, clues.New("expected deserialized item to implement models.Chatable")
+	}
+
+	return chat, bs, nil
+}
+
+func formatChat(
+	cec control.ExportConfig,
+	itemID string,
+	chat models.Chatable,
+	bs []byte,
+) (io.ReadCloser, error) {
+	if cec.Format == control.JSONFormat {
+		return io.NopCloser(bytes.NewReader(bs)), nil
+	}
+
+	msgs := chat.GetMessages()
+
+	mc := minimumChat{
+		CreatedDateTime:     ptr.Val(chat.GetCreatedDateTime()),
+		LastUpdatedDateTime: ptr.Val(chat.GetLastUpdatedDateTime()),
+		Messages:            make([]minimumChatMessage, 0, len(msgs)),
+		Topic:               ptr.Val(chat.GetTopic()),
+	}
+
+	for _, msg := range msgs {
+		mc.Messages = append(mc.Messages, makeMinimumChatMessage(itemID, msg))
+	}
+
+	// json.Marshal would replace the html markup within message content
+	// with its unicode equivalent.
+	buf := &bytes.Buffer{}
+
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(mc); err != nil {
+		return nil, clues.Wrap(err, "serializing minimized chat")
+	}
+
+	return io.NopCloser(buf), nil
+}
+
+func makeMinimumChatMessage(itemID string, msg models.ChatMessageable) minimumChatMessage {
+	var content string
+
+	if msg.GetBody() != nil {
+		content = ptr.Val(msg.GetBody().GetContent())
+	}
+
+	attachments := msg.GetAttachments()
+	minAttachments := make([]minimumAttachment, 0, len(attachments))
+
+	for _, a := range attachments {
+		var file string
+
+		if _, ok, err := api.ChatMessageFileContent(a); err == nil && ok {
+			file = attachmentFileName(itemID, msg, a)
+		}
+
+		minAttachments = append(minAttachments, minimumAttachment{
+			ID:   ptr.Val(a.GetId()),
+			Name: ptr.Val(a.GetName()),
+			File: file,
+		})
+	}
+
+	var minHostedContents []minimumHostedContent
+
+	for i, hc := range msg.GetHostedContents() {
+		minHostedContents = append(minHostedContents, minimumHostedContent{
+			ID:   ptr.Val(hc.GetId()),
+			File: hostedContentFileName(itemID, msg, i, hc),
+		})
+	}
+
+	return minimumChatMessage{
+		Attachments:          minAttachments,
+		Content:              content,
+		CreatedDateTime:      ptr.Val(msg.GetCreatedDateTime()),
+		From:                 api.GetChatMessageFrom(msg),
+		HostedContents:       minHostedContents,
+		LastModifiedDateTime: ptr.Val(msg.GetLastModifiedDateTime()),
+	}
+}
+
+// chatContents collects the hosted contents and attached files stored
+// within the chat's messages.
+func chatContents(itemID string, chat models.Chatable) []chatContent {
+	var contents []chatContent
+
+	for _, msg := range chat.GetMessages() {
+		for i, hc := range msg.GetHostedContents() {
+			if len(hc.GetContentBytes()) == 0 {
+				continue
+			}
+
+			contents = append(contents, chatContent{
+				name:    hostedContentFileName(itemID, msg, i, hc),
+				content: hc.GetContentBytes(),
+			})
+		}
+
+		for _, a := range msg.GetAttachments() {
+			content, ok, err := api.ChatMessageFileContent(a)
+			if err != nil || !ok {
+				continue
+			}
+
+			contents = append(contents, chatContent{
+				name:    attachmentFileName(itemID, msg, a),
+				content: content,
+			})
+		}
+	}
+
+	return contents
+}
+
+// messageFilePrefix scopes exported content names to the message that
+// holds them.
+func messageFilePrefix(itemID string, msg models.ChatMessageable) string {
+	return itemID + "-" + ptr.Val(msg.GetId())
+}
+
+func attachmentFileName(
+	itemID string,
+	msg models.ChatMessageable,
+	att models.ChatMessageAttachmentable,
+) string {
+	name := ptr.Val(att.GetName())
+	if len(name) == 0 {
+		name = ptr.Val(att.GetId())
+	}
+
+	return messageFilePrefix(itemID, msg) + "-" + strings.ReplaceAll(name, "/", "_")
+}
+
+func hostedContentFileName(
+	itemID string,
+	msg models.ChatMessageable,
+	idx int,
+	hc models.ChatMessageHostedContentable,
+) string {
+	ext := api.HostedContentFileExtension(ptr.Val(hc.GetContentType()))
+	return fmt.Sprintf("%s-hosted-%d%s", messageFilePrefix(itemID, msg), idx, ext)
+}
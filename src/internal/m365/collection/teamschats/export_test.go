@@ -0,0 +1,142 @@
+package teamschats
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type ExportUnitSuite struct {
+	tester.Suite
+}
+
+func TestExportUnitSuite(t *testing.T) {
+	suite.Run(t, &ExportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ExportUnitSuite) TestStreamChats() {
+	image := []byte("image bytes")
+	file := []byte("file bytes")
+
+	hc := models.NewChatMessageHostedContent()
+	hc.SetId(ptr.To("hcid"))
+	hc.SetContentType(ptr.To("image/png"))
+	hc.SetContentBytes(image)
+
+	att := models.NewChatMessageAttachment()
+	att.SetId(ptr.To("attid"))
+	att.SetName(ptr.To("notes.txt"))
+	att.SetAdditionalData(map[string]any{
+		api.ChatMessageFileContentKey: ptr.To(base64.StdEncoding.EncodeToString(file)),
+	})
+
+	body := models.NewItemBody()
+	body.SetContent(ptr.To("<p>hi</p>"))
+
+	first := models.NewChatMessage()
+	first.SetId(ptr.To("m1"))
+	first.SetBody(body)
+	first.SetHostedContents([]models.ChatMessageHostedContentable{hc})
+
+	second := models.NewChatMessage()
+	second.SetId(ptr.To("m2"))
+	second.SetAttachments([]models.ChatMessageAttachmentable{att})
+
+	chat := models.NewChat()
+	chat.SetId(ptr.To("cid"))
+	chat.SetTopic(ptr.To("topic"))
+	chat.SetMessages([]models.ChatMessageable{first, second})
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", chat)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	serialized, err := writer.GetSerializedContent()
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name   string
+		format control.FormatType
+		expect func(t *testing.T, transcript string)
+	}{
+		{
+			name:   "default format",
+			format: control.DefaultFormat,
+			expect: func(t *testing.T, transcript string) {
+				assert.Contains(t, transcript, `"topic":"topic"`)
+				assert.Contains(t, transcript, `"content":"<p>hi</p>"`)
+				assert.Contains(t, transcript, `"file":"cid-m1-hosted-0.png"`)
+				assert.Contains(t, transcript, `"file":"cid-m2-notes.txt"`)
+			},
+		},
+		{
+			name:   "json format",
+			format: control.JSONFormat,
+			expect: func(t *testing.T, transcript string) {
+				assert.Equal(t, string(serialized), transcript)
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ch := make(chan export.Item)
+
+			go streamChats(
+				ctx,
+				[]data.RestoreCollection{dataMock.Collection{
+					ItemData: []data.Item{
+						&dataMock.Item{
+							ItemID: "cid",
+							Reader: io.NopCloser(bytes.NewReader(serialized)),
+						},
+					},
+				}},
+				version.NoBackup,
+				control.ExportConfig{Format: test.format},
+				ch,
+				&metrics.ExportStats{})
+
+			results := map[string][]byte{}
+
+			for i := range ch {
+				require.NoError(t, i.Error, clues.ToCore(i.Error))
+
+				bs, err := io.ReadAll(i.Body)
+				require.NoError(t, err, clues.ToCore(err))
+
+				results[i.Name] = bs
+			}
+
+			require.Len(t, results, 3)
+			assert.Equal(t, image, results["cid-m1-hosted-0.png"])
+			assert.Equal(t, file, results["cid-m2-notes.txt"])
+
+			test.expect(t, string(results["cid.json"]))
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/microsoft/kiota-abstractions-go/serialization"
 
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -63,6 +64,7 @@ type getItemer[I chatsItemer] interface {
 		ctx context.Context,
 		protectedResource string,
 		itemID string,
+		errs *fault.Bus,
 	) (I, *details.TeamsChatsInfo, error)
 }
 
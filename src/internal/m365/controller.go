@@ -127,6 +127,11 @@ func (ctrl *Controller) setResourceHandler(
 			enum:   resource.Sites,
 			getter: ctrl.AC.Sites(),
 		}
+	case path.DirectoryService:
+		rh = &resourceGetter{
+			enum:   resource.Tenants,
+			getter: ctrl.AC.Directory(),
+		}
 	}
 
 	ctrl.resourceHandler = rh
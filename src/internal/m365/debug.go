@@ -6,6 +6,7 @@ import (
 	"github.com/alcionai/clues"
 
 	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/directory"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
 	"github.com/alcionai/corso/src/internal/m365/collection/exchange"
 	"github.com/alcionai/corso/src/internal/m365/collection/groups"
@@ -37,6 +38,8 @@ func (ctrl *Controller) DeserializeMetadataFiles(
 		return groups.DeserializeMetadataFiles(ctx, colls)
 	case path.TeamsChatsService, path.TeamsChatsMetadataService:
 		return teamschats.DeserializeMetadataFiles(ctx, colls)
+	case path.DirectoryService, path.DirectoryMetadataService:
+		return directory.DeserializeMetadataFiles(ctx, colls)
 	default:
 		return nil, clues.NewWC(ctx, "unrecognized service").With("service", service)
 	}
@@ -0,0 +1,131 @@
+package directory
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/prefixmatcher"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/directory"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+type directoryBackup struct{}
+
+// NewBackup provides a struct that matches standard apis
+// across m365/service handlers.
+func NewBackup() *directoryBackup {
+	return &directoryBackup{}
+}
+
+func (directoryBackup) ProduceBackupCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	ac api.Client,
+	creds account.M365Config,
+	su support.StatusUpdater,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, *prefixmatcher.StringSetMatcher, bool, error) {
+	b, err := bpc.Selector.ToDirectoryBackup()
+	if err != nil {
+		return nil, nil, false, clues.WrapWC(ctx, err, "parsing selector")
+	}
+
+	var (
+		el                   = errs.Local()
+		collections          = []data.BackupCollection{}
+		categories           = map[path.CategoryType]struct{}{}
+		canUsePreviousBackup = true
+	)
+
+	ctx = clues.Add(
+		ctx,
+		"tenant_id", clues.Hide(bpc.ProtectedResource.ID()),
+		"tenant_name", clues.Hide(bpc.ProtectedResource.Name()))
+
+	for _, scope := range b.Scopes() {
+		if el.Failure() != nil {
+			break
+		}
+
+		var (
+			category = scope.Category().PathType()
+			cl       = counter.Local()
+			ictx     = clues.AddLabelCounter(ctx, cl.PlainAdder())
+		)
+
+		ictx = clues.Add(ictx, "category", category)
+
+		progressMessage := observe.MessageWithCompletion(
+			ictx,
+			observe.ProgressCfg{
+				Indent:            1,
+				CompletionMessage: func() string { return "(done)" },
+			},
+			category.HumanString())
+
+		bh, err := directory.NewBackupHandler(category, ac.Directory())
+		if err != nil {
+			close(progressMessage)
+			el.AddRecoverable(ictx, clues.Stack(err))
+
+			continue
+		}
+
+		colls, canUsePrev, err := directory.CreateCollections(
+			ictx,
+			bpc,
+			bh,
+			creds.AzureTenantID,
+			scope,
+			su,
+			cl,
+			el)
+
+		close(progressMessage)
+
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Stack(err))
+			continue
+		}
+
+		collections = append(collections, colls...)
+		categories[category] = struct{}{}
+		canUsePreviousBackup = canUsePreviousBackup && canUsePrev
+	}
+
+	if len(collections) > 0 {
+		baseCols, err := graph.BaseCollections(
+			ctx,
+			collections,
+			creds.AzureTenantID,
+			bpc.ProtectedResource.ID(),
+			path.DirectoryService,
+			categories,
+			su,
+			counter,
+			errs)
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		collections = append(collections, baseCols...)
+	}
+
+	counter.Add(count.Collections, int64(len(collections)))
+
+	logger.Ctx(ctx).Infow("produced collections", "stats", counter.Values())
+
+	return collections, nil, canUsePreviousBackup, clues.Stack(el.Failure()).OrNil()
+}
@@ -0,0 +1,33 @@
+package directory
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type getIDAndNamer interface {
+	GetIDAndName(
+		ctx context.Context,
+		tenantID string,
+		cc api.CallConfig,
+	) (string, string, error)
+}
+
+// IsServiceEnabled reports whether the tenant's directory can be backed
+// up.  Every tenant has a directory, so the only requirement is that the
+// tenant itself can be found.
+func IsServiceEnabled(
+	ctx context.Context,
+	gian getIDAndNamer,
+	resource string,
+) (bool, error) {
+	_, _, err := gian.GetIDAndName(ctx, resource, api.CallConfig{})
+	if err != nil {
+		return false, clues.WrapWC(ctx, err, "getting tenant")
+	}
+
+	return true, nil
+}
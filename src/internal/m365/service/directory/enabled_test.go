@@ -0,0 +1,84 @@
+package directory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type EnabledUnitSuite struct {
+	tester.Suite
+}
+
+func TestEnabledUnitSuite(t *testing.T) {
+	suite.Run(t, &EnabledUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+var _ getIDAndNamer = mockGIAN{}
+
+type mockGIAN struct {
+	err error
+}
+
+func (m mockGIAN) GetIDAndName(
+	ctx context.Context,
+	identifier string,
+	_ api.CallConfig,
+) (string, string, error) {
+	if m.err != nil {
+		return "", "", m.err
+	}
+
+	return identifier, "name", nil
+}
+
+func (suite *EnabledUnitSuite) TestIsServiceEnabled() {
+	table := []struct {
+		name      string
+		mock      func(context.Context) getIDAndNamer
+		expect    assert.BoolAssertionFunc
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "ok",
+			mock: func(ctx context.Context) getIDAndNamer {
+				return mockGIAN{}
+			},
+			expect:    assert.True,
+			expectErr: assert.NoError,
+		},
+		{
+			name: "tenant not found",
+			mock: func(ctx context.Context) getIDAndNamer {
+				return mockGIAN{
+					err: clues.StackWC(ctx, core.ErrNotFound),
+				}
+			},
+			expect: assert.False,
+			expectErr: func(t assert.TestingT, err error, i ...any) bool {
+				return assert.ErrorIs(t, err, core.ErrNotFound, i...)
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			gian := test.mock(ctx)
+
+			ok, err := IsServiceEnabled(ctx, gian, "resource_id")
+			test.expect(t, ok, "has directory enabled")
+			test.expectErr(t, err, clues.ToCore(err))
+		})
+	}
+}
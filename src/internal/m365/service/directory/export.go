@@ -0,0 +1,100 @@
+package directory
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/directory"
+	"github.com/alcionai/corso/src/internal/m365/resource"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+var _ inject.ServiceHandler = &directoryHandler{}
+
+func NewDirectoryHandler(
+	apiClient api.Client,
+	resourceGetter idname.GetResourceIDAndNamer,
+) *directoryHandler {
+	return &directoryHandler{
+		apiClient:      apiClient,
+		resourceGetter: resourceGetter,
+	}
+}
+
+// ========================================================================== //
+//                          baseDirectoryHandler
+// ========================================================================== //
+
+// baseDirectoryHandler contains logic for tracking data and doing operations
+// (e.x. export) that don't require contact with external M356 services.
+type baseDirectoryHandler struct{}
+
+func (h *baseDirectoryHandler) CacheItemInfo(v details.ItemInfo) {}
+
+// ProduceExportCollections will create the export collections for the
+// given restore collections.
+func (h *baseDirectoryHandler) ProduceExportCollections(
+	ctx context.Context,
+	backupVersion int,
+	exportCfg control.ExportConfig,
+	dcs []data.RestoreCollection,
+	stats *metrics.ExportStats,
+	errs *fault.Bus,
+) ([]export.Collectioner, error) {
+	ec := make([]export.Collectioner, 0, len(dcs))
+
+	for _, restoreColl := range dcs {
+		ec = append(ec, directory.NewExportCollection(
+			restoreColl.FullPath().Category().HumanString(),
+			[]data.RestoreCollection{restoreColl},
+			backupVersion,
+			exportCfg,
+			stats))
+	}
+
+	return ec, nil
+}
+
+// ========================================================================== //
+//                              directoryHandler
+// ========================================================================== //
+
+// directoryHandler contains logic for handling data and performing operations
+// (e.x. restore) regardless of whether they require contact with external M365
+// services or not.
+type directoryHandler struct {
+	baseDirectoryHandler
+	apiClient      api.Client
+	resourceGetter idname.GetResourceIDAndNamer
+}
+
+func (h *directoryHandler) IsServiceEnabled(
+	ctx context.Context,
+	resourceID string,
+) (bool, error) {
+	res, err := IsServiceEnabled(ctx, h.apiClient.Directory(), resourceID)
+	return res, clues.Stack(err).OrNil()
+}
+
+func (h *directoryHandler) PopulateProtectedResourceIDAndName(
+	ctx context.Context,
+	resourceID string, // Can be either ID or name.
+	ins idname.Cacher,
+) (idname.Provider, error) {
+	if h.resourceGetter == nil {
+		return nil, clues.StackWC(ctx, resource.ErrNoResourceLookup)
+	}
+
+	pr, err := h.resourceGetter.GetResourceIDAndNameFrom(ctx, resourceID, ins)
+
+	return pr, clues.Wrap(err, "identifying resource owner").OrNil()
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
 )
@@ -48,7 +49,7 @@ func (exchangeBackup) ProduceBackupCollections(
 		el          = errs.Local()
 		tenantID    = creds.AzureTenantID
 		categories  = map[path.CategoryType]struct{}{}
-		handlers    = exchange.BackupHandlers(ac)
+		handlers    = exchange.BackupHandlers(ac, eb.MailFoldersConfig())
 	)
 
 	canMakeDeltaQueries, err := canMakeDeltaQueries(ctx, ac.Users(), bpc.ProtectedResource.ID())
@@ -80,6 +81,12 @@ func (exchangeBackup) ProduceBackupCollections(
 			break
 		}
 
+		// well-known mail folder scopes only adjust the set of folders
+		// within the email backup.
+		if scope.InfoCategory() == selectors.ExchangeInfoMailWellKnownFolder {
+			continue
+		}
+
 		dcs, err := exchange.CreateCollections(
 			ctx,
 			bpc,
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/alcionai/corso/src/internal/m365/collection/exchange"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -22,7 +23,7 @@ func PopulateContainerCache(
 	resourceOwnerID string,
 	errs *fault.Bus,
 ) graph.ContainerResolver {
-	handler, ok := exchange.BackupHandlers(ac)[category]
+	handler, ok := exchange.BackupHandlers(ac, control.MailFoldersConfig{})[category]
 	require.Truef(t, ok, "container resolver registered for category %s", category)
 
 	root, cc := handler.NewContainerCache(resourceOwnerID)
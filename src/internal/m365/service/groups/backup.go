@@ -23,6 +23,7 @@ import (
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/pkg/account"
 	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/backup/membership"
 	"github.com/alcionai/corso/src/pkg/backup/metadata"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
@@ -113,6 +114,27 @@ func (groupsBackup) ProduceBackupCollections(
 				scope,
 				cl,
 				el)
+		case path.EventsCategory:
+			colls, err = backupEvents(
+				ictx,
+				bc,
+				scope,
+				cl,
+				el)
+		case path.PlannerCategory:
+			colls, err = backupPlanner(
+				ictx,
+				bc,
+				scope,
+				cl,
+				el)
+		case path.TeamStructureCategory:
+			colls, err = backupTeamStructure(
+				ictx,
+				bc,
+				scope,
+				cl,
+				el)
 		}
 
 		if err != nil {
@@ -156,6 +178,20 @@ func (groupsBackup) ProduceBackupCollections(
 
 	collections = append(collections, md)
 
+	// Add metadata about the group's owners and members
+	mmd, err := getMembershipMetadataCollection(
+		ctx,
+		ac.Groups(),
+		creds.AzureTenantID,
+		bpc.ProtectedResource.ID(),
+		su,
+		counter)
+	if err != nil {
+		el.AddRecoverable(ctx, clues.Stack(err))
+	} else {
+		collections = append(collections, mmd)
+	}
+
 	counter.Add(count.Collections, int64(len(collections)))
 
 	logger.Ctx(ctx).Infow("produced collections", "stats", counter.Values())
@@ -380,6 +416,176 @@ func backupConversations(
 	return colls, nil
 }
 
+func backupEvents(
+	ctx context.Context,
+	bc backupCommon,
+	scope selectors.GroupsScope,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, error) {
+	bh := groups.NewCalendarBackupHandler(
+		bc.producerConfig.ProtectedResource.ID(),
+		bc.apiCli.GroupEvents())
+
+	// the events delta query doesn't return modification times, so the
+	// lazy reader can't tell which items are unchanged.
+	return backupEagerCollections(
+		ctx,
+		scope,
+		bh,
+		"calendars",
+		func() ([]data.BackupCollection, bool, error) {
+			return groups.CreateCollections(
+				ctx,
+				bc.producerConfig,
+				bh,
+				bc.creds.AzureTenantID,
+				scope,
+				bc.statusUpdater,
+				false,
+				counter,
+				errs)
+		},
+		bc,
+		counter)
+}
+
+func backupPlanner(
+	ctx context.Context,
+	bc backupCommon,
+	scope selectors.GroupsScope,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, error) {
+	bh := groups.NewPlannerBackupHandler(
+		bc.producerConfig.ProtectedResource.ID(),
+		bc.apiCli.Planner())
+
+	// plans have no modification time, so there's nothing for the lazy
+	// reader to compare against; they're always fetched in full.
+	return backupEagerCollections(
+		ctx,
+		scope,
+		bh,
+		"plans",
+		func() ([]data.BackupCollection, bool, error) {
+			return groups.CreateCollections(
+				ctx,
+				bc.producerConfig,
+				bh,
+				bc.creds.AzureTenantID,
+				scope,
+				bc.statusUpdater,
+				false,
+				counter,
+				errs)
+		},
+		bc,
+		counter)
+}
+
+func backupTeamStructure(
+	ctx context.Context,
+	bc backupCommon,
+	scope selectors.GroupsScope,
+	counter *count.Bus,
+	errs *fault.Bus,
+) ([]data.BackupCollection, error) {
+	if !api.IsTeam(ctx, bc.group) {
+		return nil, nil
+	}
+
+	bh := groups.NewTeamBackupHandler(
+		bc.producerConfig.ProtectedResource.ID(),
+		bc.apiCli.Groups())
+
+	// teams have no modification time, so there's nothing for the lazy
+	// reader to compare against; they're always fetched in full.
+	return backupEagerCollections(
+		ctx,
+		scope,
+		bh,
+		"teams",
+		func() ([]data.BackupCollection, bool, error) {
+			return groups.CreateCollections(
+				ctx,
+				bc.producerConfig,
+				bh,
+				bc.creds.AzureTenantID,
+				scope,
+				bc.statusUpdater,
+				false,
+				counter,
+				errs)
+		},
+		bc,
+		counter)
+}
+
+type pathPrefixer interface {
+	PathPrefix(tenantID string) (path.Path, error)
+}
+
+// backupEagerCollections runs createCollections for a category whose items
+// are always fetched in full, reporting progress as it goes.  If the
+// previous backup holds the category but can't be used, the category's path
+// prefix gets tombstoned so that none of its previous items carry over.
+func backupEagerCollections(
+	ctx context.Context,
+	scope selectors.GroupsScope,
+	prefixer pathPrefixer,
+	containerKind string,
+	createCollections func() ([]data.BackupCollection, bool, error),
+	bc backupCommon,
+	counter *count.Bus,
+) ([]data.BackupCollection, error) {
+	var colls []data.BackupCollection
+
+	progressMessage := observe.MessageWithCompletion(
+		ctx,
+		observe.ProgressCfg{
+			Indent: 1,
+			CompletionMessage: func() string {
+				return fmt.Sprintf("(found %d %s)", len(colls), containerKind)
+			},
+		},
+		scope.Category().PathType().HumanString())
+	defer close(progressMessage)
+
+	colls, canUsePreviousBackup, err := createCollections()
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	category := scope.Category().PathType()
+
+	if !canUsePreviousBackup && hasPreviousCategory(bc.producerConfig.MetadataCollections, category) {
+		tp, err := prefixer.PathPrefix(bc.creds.AzureTenantID)
+		if err != nil {
+			err = clues.WrapWC(ctx, err, "getting path prefix").Label(count.BadPathPrefix)
+			return nil, err
+		}
+
+		colls = append(colls, data.NewTombstoneCollection(tp, control.Options{}, counter))
+	}
+
+	return colls, nil
+}
+
+// hasPreviousCategory reports whether the metadata of the previous backup
+// includes the category.  Categories that weren't in the previous backup,
+// such as on the first backup of an opt-in category, have nothing that
+// needs tombstoning.
+func hasPreviousCategory(mdColls []data.RestoreCollection, category path.CategoryType) bool {
+	for _, mdc := range mdColls {
+		if mdc.FullPath().Category() == category {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ---------------------------------------------------------------------------
 // metadata
 // ---------------------------------------------------------------------------
@@ -416,6 +622,42 @@ func getSitesMetadataCollection(
 	return md, err
 }
 
+func getMembershipMetadataCollection(
+	ctx context.Context,
+	ac api.Groups,
+	tenantID, groupID string,
+	su support.StatusUpdater,
+	counter *count.Bus,
+) (data.BackupCollection, error) {
+	m, err := ac.GetMembership(ctx, groupID)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting group membership")
+	}
+
+	counter.Add(count.GroupOwners, int64(len(m.Owners)))
+	counter.Add(count.GroupMembers, int64(len(m.Members)))
+
+	p, err := path.BuildMetadata(
+		tenantID,
+		groupID,
+		path.GroupsService,
+		path.GroupMembershipCategory,
+		false)
+	if err != nil {
+		return nil, clues.Wrap(err, "making membership metadata path")
+	}
+
+	md, err := graph.MakeMetadataCollection(
+		p,
+		[]graph.MetadataCollectionEntry{
+			graph.NewMetadataEntry(membership.FileName, m),
+		},
+		su,
+		counter.Local())
+
+	return md, clues.Wrap(err, "making membership metadata collection").OrNil()
+}
+
 func MetadataFiles(
 	ctx context.Context,
 	reason identity.Reasoner,
@@ -6,18 +6,23 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/repo/manifest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
 	"github.com/alcionai/corso/src/internal/kopia"
 	"github.com/alcionai/corso/src/internal/kopia/inject"
+	opInject "github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
 )
 
 type GroupsBackupUnitSuite struct {
@@ -143,3 +148,83 @@ func (suite *GroupsBackupUnitSuite) TestMetadataFiles() {
 		})
 	}
 }
+
+type mockPathPrefixer struct {
+	p path.Path
+}
+
+func (mpp mockPathPrefixer) PathPrefix(string) (path.Path, error) {
+	return mpp.p, nil
+}
+
+func (suite *GroupsBackupUnitSuite) TestBackupEagerCollections_tombstones() {
+	prefix, err := path.BuildPrefix("t", "g", path.GroupsService, path.PlannerCategory)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	mdPath, err := path.BuildMetadata("t", "g", path.GroupsService, path.PlannerCategory, false)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	otherMDPath, err := path.BuildMetadata("t", "g", path.GroupsService, path.ChannelMessagesCategory, false)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	scope := selectors.NewGroupsBackup([]string{"g"}).Plans(selectors.Any())[0]
+
+	table := []struct {
+		name            string
+		mdColls         []data.RestoreCollection
+		canUsePrevious  bool
+		expectTombstone bool
+	}{
+		{
+			name:           "first backup",
+			canUsePrevious: true,
+		},
+		{
+			name:    "previous backup without the category",
+			mdColls: []data.RestoreCollection{dataMock.Collection{Path: otherMDPath}},
+		},
+		{
+			name:            "previous backup can't be used",
+			mdColls:         []data.RestoreCollection{dataMock.Collection{Path: mdPath}},
+			expectTombstone: true,
+		},
+		{
+			name:           "previous backup can be used",
+			mdColls:        []data.RestoreCollection{dataMock.Collection{Path: mdPath}},
+			canUsePrevious: true,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			bc := backupCommon{
+				producerConfig: opInject.BackupProducerConfig{MetadataCollections: test.mdColls},
+			}
+
+			colls, err := backupEagerCollections(
+				ctx,
+				scope,
+				mockPathPrefixer{prefix},
+				"plans",
+				func() ([]data.BackupCollection, bool, error) {
+					return nil, test.canUsePrevious, nil
+				},
+				bc,
+				count.New())
+			require.NoError(t, err, clues.ToCore(err))
+
+			if !test.expectTombstone {
+				assert.Empty(t, colls)
+				return
+			}
+
+			require.Len(t, colls, 1)
+			assert.Equal(t, data.DeletedState, colls[0].State())
+			assert.Equal(t, prefix.String(), colls[0].PreviousPath().String())
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/exchange"
 	"github.com/alcionai/corso/src/internal/m365/collection/groups"
 	"github.com/alcionai/corso/src/internal/m365/resource"
 	"github.com/alcionai/corso/src/internal/operations/inject"
@@ -82,7 +83,10 @@ func (h *baseGroupsHandler) ProduceExportCollections(
 		)
 
 		switch cat {
-		case path.ChannelMessagesCategory, path.ConversationPostsCategory:
+		case path.ChannelMessagesCategory,
+			path.ConversationPostsCategory,
+			path.PlannerCategory,
+			path.TeamStructureCategory:
 			folders = append(folders, fp.Folders()...)
 
 			coll = groups.NewExportCollection(
@@ -93,6 +97,17 @@ func (h *baseGroupsHandler) ProduceExportCollections(
 				stats,
 				cat)
 
+		case path.EventsCategory:
+			folders = append(folders, fp.Folders()...)
+
+			// group events are stored in the same shape as user events,
+			// so they share the exchange ics conversion.
+			coll = exchange.NewExportCollection(
+				path.Builder{}.Append(folders...).String(),
+				[]data.RestoreCollection{restoreColl},
+				backupVersion,
+				stats)
+
 		case path.LibrariesCategory:
 			drivePath, err := path.ToDrivePath(restoreColl.FullPath())
 			if err != nil {
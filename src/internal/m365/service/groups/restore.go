@@ -11,6 +11,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/groups"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/pkg/backup/details"
@@ -54,8 +55,10 @@ func (h *groupsHandler) ConsumeRestoreCollections(
 		lrh            = drive.NewSiteRestoreHandler(
 			h.apiClient,
 			rcc.Selector.PathService())
-		el                = errs.Local()
-		webURLToSiteNames = map[string]string{}
+		el                 = errs.Local()
+		webURLToSiteNames  = map[string]string{}
+		planCollisionKeys  map[string]string
+		eventCollisionKeys map[string]string
 	)
 
 	// Reorder collections so that the parents directories are created
@@ -127,6 +130,57 @@ func (h *groupsHandler) ConsumeRestoreCollections(
 		case path.ChannelMessagesCategory:
 			// Message cannot be restored as of now using Graph API.
 			logger.Ctx(ictx).Debug("Skipping restore for channel messages")
+		case path.EventsCategory:
+			er := h.apiClient.GroupEvents()
+
+			if eventCollisionKeys == nil {
+				eventCollisionKeys, err = groups.EventCollisionKeys(ictx, er, rcc.ProtectedResource.ID())
+				if err != nil {
+					return nil, nil, clues.Wrap(err, "initializing event collision keys")
+				}
+			}
+
+			metrics, err = groups.RestoreEvents(
+				ictx,
+				er,
+				dc,
+				rcc.ProtectedResource.ID(),
+				rcc.RestoreConfig,
+				eventCollisionKeys,
+				deets,
+				errs,
+				ctr)
+		case path.PlannerCategory:
+			pr := h.apiClient.Planner()
+
+			if planCollisionKeys == nil {
+				planCollisionKeys, err = groups.PlanCollisionKeys(ictx, pr, rcc.ProtectedResource.ID())
+				if err != nil {
+					return nil, nil, clues.Wrap(err, "initializing plan collision keys")
+				}
+			}
+
+			metrics, err = groups.RestorePlans(
+				ictx,
+				pr,
+				dc,
+				rcc.ProtectedResource.ID(),
+				rcc.RestoreConfig,
+				planCollisionKeys,
+				deets,
+				errs,
+				ctr)
+		case path.TeamStructureCategory:
+			metrics, err = groups.RestoreTeamStructure(
+				ictx,
+				h.apiClient.Groups(),
+				h.apiClient.Channels(),
+				dc,
+				rcc.ProtectedResource.ID(),
+				rcc.RestoreConfig,
+				deets,
+				errs,
+				ctr)
 		default:
 			return nil, nil, clues.NewWC(ictx, "data category not supported").
 				With("category", category)
@@ -54,6 +54,12 @@ func (oneDriveBackup) ProduceBackupCollections(
 		canUsePreviousBackup bool
 	)
 
+	// a drive's enumeration progress depends on the scope that selected its
+	// folders, so it's only carried between backups of a single scope.
+	if len(odb.Scopes()) > 1 {
+		bpc.EnumerationState = nil
+	}
+
 	// for each scope that includes oneDrive items, get all
 	for _, scope := range odb.Scopes() {
 		if el.Failure() != nil {
@@ -68,6 +74,8 @@ func (oneDriveBackup) ProduceBackupCollections(
 			bpc.ProtectedResource,
 			su,
 			bpc.Options,
+			bpc.Selector.FileFilter,
+			bpc.EnumerationState,
 			counter)
 
 		progressMessage := observe.MessageWithCompletion(
@@ -230,6 +230,13 @@ func (h BackupHandler[T]) GetItem(ctx context.Context, _, _ string) (models.Driv
 	return h.GI.GetItem(ctx, "", "")
 }
 
+func (h BackupHandler[T]) GetItemVersions(
+	context.Context,
+	string, string,
+) ([]models.DriveItemVersionable, error) {
+	return nil, nil
+}
+
 func (h BackupHandler[T]) GetItemPermission(
 	ctx context.Context,
 	_, _ string,
@@ -237,6 +244,41 @@ func (h BackupHandler[T]) GetItemPermission(
 	return h.GIP.GetItemPermission(ctx, "", "")
 }
 
+func (h BackupHandler[T]) GetOneNoteSection(
+	context.Context,
+	string,
+) (models.OnenoteSectionable, error) {
+	return nil, api.ErrOneNoteSectionNotFound
+}
+
+func (h BackupHandler[T]) GetOneNoteSectionGroup(
+	context.Context,
+	string,
+) (models.SectionGroupable, error) {
+	return nil, clues.New("not defined")
+}
+
+func (h BackupHandler[T]) GetOneNoteSectionPages(
+	context.Context,
+	string,
+) ([]models.OnenotePageable, error) {
+	return nil, clues.New("not defined")
+}
+
+func (h BackupHandler[T]) GetOneNotePageContent(
+	context.Context,
+	string,
+) ([]byte, error) {
+	return nil, clues.New("not defined")
+}
+
+func (h BackupHandler[T]) GetOneNoteResourceContent(
+	context.Context,
+	string,
+) ([]byte, error) {
+	return nil, clues.New("not defined")
+}
+
 type canonPather func(*path.Builder, string, string) (path.Path, error)
 
 var defaultOneDriveCanonPather = func(pb *path.Builder, tID, ro string) (path.Path, error) {
@@ -670,6 +712,43 @@ func (h *RestoreHandler) GetRootFolder(
 	return models.NewDriveItem(), nil
 }
 
+func (h *RestoreHandler) GetOneNoteSectionByName(
+	context.Context,
+	string, string, string,
+) (models.OnenoteSectionable, error) {
+	return nil, api.ErrOneNoteSectionNotFound
+}
+
+func (h *RestoreHandler) GetOrPostOneNoteNotebook(
+	context.Context,
+	string, string,
+) (models.Notebookable, error) {
+	return nil, clues.New("not implemented")
+}
+
+func (h *RestoreHandler) GetOrPostOneNoteSectionGroup(
+	context.Context,
+	string, string, string,
+) (models.SectionGroupable, error) {
+	return nil, clues.New("not implemented")
+}
+
+func (h *RestoreHandler) PostOneNoteSection(
+	context.Context,
+	string, string, string,
+) (models.OnenoteSectionable, error) {
+	return nil, clues.New("not implemented")
+}
+
+func (h *RestoreHandler) PostOneNotePage(
+	context.Context,
+	string, string,
+	[]byte,
+	[]api.OneNotePageResource,
+) error {
+	return clues.New("not implemented")
+}
+
 // assumption is only one suffix per id.  Mostly using
 // the variadic as an "optional" extension.
 func id(v string, suffixes ...any) string {
@@ -0,0 +1,551 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/termstore"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	betamodels "github.com/alcionai/corso/src/pkg/services/m365/api/graph/betasdk/models"
+	betasites "github.com/alcionai/corso/src/pkg/services/m365/api/graph/betasdk/sites"
+)
+
+// SiteSettingsKey is the site's additional data key under which the
+// site's settings are stored.  Settings are only exposed by the beta api,
+// and the v1 site model has no property to hold them.
+const SiteSettingsKey = "settings"
+
+// SiteTermStoreKey is the site's additional data key under which the
+// managed metadata term store visible to the site is stored.  The v1 site
+// model has no property to hold it.
+const SiteTermStoreKey = "termStore"
+
+// SiteWebKey is the site's additional data key under which the site's
+// navigation, SharePoint groups, features, and theme are stored.  Graph
+// doesn't expose them, and the v1 site model has no property to hold them.
+const SiteWebKey = "web"
+
+// GetSiteConfiguration retrieves the configuration of the site: its site
+// columns, content types, permissions, and settings.  The configuration
+// is returned as the site itself, with the columns, content types, and
+// permissions populated, and the settings stored in the additional data
+// under SiteSettingsKey.
+//
+// Graph does not expose the site's navigation, theme, features, or
+// SharePoint permission groups, so those are fetched separately through
+// the SharePoint REST api and stored with SetSiteWeb.
+// API Reference: https://learn.microsoft.com/en-us/graph/api/resources/site?view=graph-rest-beta
+func GetSiteConfiguration(
+	ctx context.Context,
+	serv *BetaService,
+	siteID string,
+) (models.Siteable, error) {
+	ctx = clues.Add(ctx, "site_id", siteID)
+
+	site, err := serv.
+		Client().
+		SitesById(siteID).
+		Get(ctx, &betasites.SiteItemRequestBuilderGetRequestConfiguration{
+			QueryParameters: &betasites.SiteItemRequestBuilderGetQueryParameters{
+				Select: []string{
+					"id",
+					"name",
+					"displayName",
+					"description",
+					"webUrl",
+					"createdDateTime",
+					"lastModifiedDateTime",
+				},
+			},
+		})
+	if err != nil {
+		return nil, clues.Wrap(err, "getting site")
+	}
+
+	columns, err := getSiteColumns(ctx, serv, siteID)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	site.SetColumns(columns)
+
+	contentTypes, err := getSiteContentTypes(ctx, serv, siteID)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	site.SetContentTypes(contentTypes)
+
+	perms, err := getSitePermissions(ctx, serv, siteID)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	site.SetPermissions(perms)
+
+	settings, err := serv.
+		Client().
+		SitesById(siteID).
+		Settings().
+		Get(ctx, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting site settings")
+	}
+
+	if settings != nil {
+		ad := site.GetAdditionalData()
+		if ad == nil {
+			ad = map[string]any{}
+		}
+
+		ad[SiteSettingsKey] = settings
+		site.SetAdditionalData(ad)
+	}
+
+	return site, nil
+}
+
+func getSiteColumns(
+	ctx context.Context,
+	serv *BetaService,
+	siteID string,
+) ([]models.ColumnDefinitionable, error) {
+	var (
+		builder = serv.Client().SitesById(siteID).Columns()
+		columns = []models.ColumnDefinitionable{}
+	)
+
+	for {
+		resp, err := builder.Get(ctx, nil)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting site columns")
+		}
+
+		columns = append(columns, resp.GetValue()...)
+
+		link, ok := ptr.ValOK(resp.GetOdataNextLink())
+		if !ok {
+			break
+		}
+
+		builder = betasites.NewItemColumnsRequestBuilder(link, serv.Client().Adapter())
+	}
+
+	return columns, nil
+}
+
+func getSiteContentTypes(
+	ctx context.Context,
+	serv *BetaService,
+	siteID string,
+) ([]models.ContentTypeable, error) {
+	var (
+		builder      = serv.Client().SitesById(siteID).ContentTypes()
+		contentTypes = []models.ContentTypeable{}
+	)
+
+	for {
+		resp, err := builder.Get(ctx, nil)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting site content types")
+		}
+
+		contentTypes = append(contentTypes, resp.GetValue()...)
+
+		link, ok := ptr.ValOK(resp.GetOdataNextLink())
+		if !ok {
+			break
+		}
+
+		builder = betasites.NewItemContentTypesRequestBuilder(link, serv.Client().Adapter())
+	}
+
+	return contentTypes, nil
+}
+
+func getSitePermissions(
+	ctx context.Context,
+	serv *BetaService,
+	siteID string,
+) ([]models.Permissionable, error) {
+	var (
+		builder = serv.Client().SitesById(siteID).Permissions()
+		perms   = []models.Permissionable{}
+	)
+
+	for {
+		resp, err := builder.Get(ctx, nil)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting site permissions")
+		}
+
+		perms = append(perms, resp.GetValue()...)
+
+		link, ok := ptr.ValOK(resp.GetOdataNextLink())
+		if !ok {
+			break
+		}
+
+		builder = betasites.NewItemPermissionsRequestBuilder(link, serv.Client().Adapter())
+	}
+
+	return perms, nil
+}
+
+// PostSiteColumn creates the column in the site.
+// API Reference: https://learn.microsoft.com/en-us/graph/api/site-post-columns?view=graph-rest-beta
+func PostSiteColumn(
+	ctx context.Context,
+	serv *BetaService,
+	siteID string,
+	column models.ColumnDefinitionable,
+) error {
+	_, err := serv.
+		Client().
+		SitesById(siteID).
+		Columns().
+		Post(ctx, RestorableSiteColumn(column), nil)
+
+	return clues.Wrap(err, "creating site column").OrNil()
+}
+
+// PatchSiteColumn updates the site's column to match the provided column.
+// API Reference: https://learn.microsoft.com/en-us/graph/api/columndefinition-update?view=graph-rest-beta
+func PatchSiteColumn(
+	ctx context.Context,
+	serv *BetaService,
+	siteID, columnID string,
+	column models.ColumnDefinitionable,
+) error {
+	_, err := serv.
+		Client().
+		SitesById(siteID).
+		ColumnsById(columnID).
+		Patch(ctx, RestorableSiteColumn(column), nil)
+
+	return clues.Wrap(err, "updating site column").OrNil()
+}
+
+// PostSiteContentType creates the content type in the site.
+// API Reference: https://learn.microsoft.com/en-us/graph/api/site-post-contenttypes?view=graph-rest-beta
+func PostSiteContentType(
+	ctx context.Context,
+	serv *BetaService,
+	siteID string,
+	contentType models.ContentTypeable,
+) error {
+	body := RestorableSiteContentType(contentType)
+	// the base content type can only be provided on creation.
+	body.SetBase(contentType.GetBase())
+
+	_, err := serv.
+		Client().
+		SitesById(siteID).
+		ContentTypes().
+		Post(ctx, body, nil)
+
+	return clues.Wrap(err, "creating site content type").OrNil()
+}
+
+// PatchSiteContentType updates the site's content type to match the
+// provided content type.
+// API Reference: https://learn.microsoft.com/en-us/graph/api/contenttype-update?view=graph-rest-beta
+func PatchSiteContentType(
+	ctx context.Context,
+	serv *BetaService,
+	siteID, contentTypeID string,
+	contentType models.ContentTypeable,
+) error {
+	_, err := serv.
+		Client().
+		SitesById(siteID).
+		ContentTypesById(contentTypeID).
+		Patch(ctx, RestorableSiteContentType(contentType), nil)
+
+	return clues.Wrap(err, "updating site content type").OrNil()
+}
+
+// PostSitePermission grants the permission's roles to the applications
+// that held them in the backed up site.
+// API Reference: https://learn.microsoft.com/en-us/graph/api/site-post-permissions?view=graph-rest-beta
+func PostSitePermission(
+	ctx context.Context,
+	serv *BetaService,
+	siteID string,
+	perm models.Permissionable,
+) error {
+	body := models.NewPermission()
+	body.SetRoles(perm.GetRoles())
+	body.SetGrantedToIdentities(perm.GetGrantedToIdentities())
+
+	if len(perm.GetGrantedToIdentities()) == 0 {
+		ids := []models.IdentitySetable{}
+
+		for _, is := range perm.GetGrantedToIdentitiesV2() {
+			ids = append(ids, is)
+		}
+
+		body.SetGrantedToIdentities(ids)
+	}
+
+	_, err := serv.
+		Client().
+		SitesById(siteID).
+		Permissions().
+		Post(ctx, body, nil)
+
+	return clues.Wrap(err, "creating site permission").OrNil()
+}
+
+// PatchSiteSettings updates the site's settings.
+func PatchSiteSettings(
+	ctx context.Context,
+	serv *BetaService,
+	siteID string,
+	settings betamodels.SiteSettingsable,
+) error {
+	body := betamodels.NewSiteSettings()
+	body.SetLanguageTag(settings.GetLanguageTag())
+	body.SetTimeZone(settings.GetTimeZone())
+
+	_, err := serv.
+		Client().
+		SitesById(siteID).
+		Settings().
+		Patch(ctx, body, nil)
+
+	return clues.Wrap(err, "updating site settings").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// SiteConfigurationInfo extracts the details of the site's configuration.
+func SiteConfigurationInfo(site models.Siteable, size int64) *details.SharePointInfo {
+	// a term store that can't be deserialized gets reported when restored.
+	store, _ := SiteTermStore(site)
+
+	info := &details.SharePointInfo{
+		ItemType: details.SharePointSiteConfiguration,
+		ItemName: ptr.Val(site.GetDisplayName()),
+		Created:  ptr.Val(site.GetCreatedDateTime()),
+		Modified: ptr.Val(site.GetLastModifiedDateTime()),
+		WebURL:   ptr.Val(site.GetWebUrl()),
+		SiteID:   ptr.Val(site.GetId()),
+		Size:     size,
+		SiteConfiguration: &details.SiteConfigurationInfo{
+			ColumnCount:      len(site.GetColumns()),
+			ContentTypeCount: len(site.GetContentTypes()),
+			PermissionCount:  len(site.GetPermissions()),
+			TermCount:        len(api.TermStoreReferences(store)),
+		},
+	}
+
+	// as is a web configuration that can't be deserialized.
+	if web, _ := SiteWeb(site); web != nil {
+		info.SiteConfiguration.NavigationNodeCount = api.NavigationNodeCount(web.QuickLaunch) +
+			api.NavigationNodeCount(web.TopNavigation)
+		info.SiteConfiguration.GroupCount = len(web.Groups)
+		info.SiteConfiguration.FeatureCount = len(web.FeatureIDs)
+	}
+
+	return info
+}
+
+// SiteSettings returns the settings stored in the site's additional data,
+// or nil if the site has none.  Settings are held in their typed form when
+// fetched from graph, and in their untyped form when deserialized from a
+// backup.
+func SiteSettings(site models.Siteable) betamodels.SiteSettingsable {
+	switch v := site.GetAdditionalData()[SiteSettingsKey].(type) {
+	case betamodels.SiteSettingsable:
+		return v
+	case map[string]any:
+		settings := betamodels.NewSiteSettings()
+
+		if lt, ok := v["languageTag"].(*string); ok {
+			settings.SetLanguageTag(lt)
+		}
+
+		if tz, ok := v["timeZone"].(*string); ok {
+			settings.SetTimeZone(tz)
+		}
+
+		return settings
+	}
+
+	return nil
+}
+
+// SetSiteTermStore stores the term store in the site's additional data.
+func SetSiteTermStore(site models.Siteable, store termstore.Storeable) {
+	if store == nil {
+		return
+	}
+
+	ad := site.GetAdditionalData()
+	if ad == nil {
+		ad = map[string]any{}
+	}
+
+	ad[SiteTermStoreKey] = store
+	site.SetAdditionalData(ad)
+}
+
+// SiteTermStore returns the term store stored in the site's additional
+// data, or nil if the site has none.  Like the site's settings, the term
+// store is held in its untyped form when deserialized from a backup.
+func SiteTermStore(site models.Siteable) (termstore.Storeable, error) {
+	switch v := site.GetAdditionalData()[SiteTermStoreKey].(type) {
+	case termstore.Storeable:
+		return v, nil
+	case map[string]any:
+		bs, err := json.Marshal(v)
+		if err != nil {
+			return nil, clues.Wrap(err, "marshalling term store")
+		}
+
+		parsable, err := createFromBytes(bs, termstore.CreateStoreFromDiscriminatorValue)
+		if err != nil {
+			return nil, clues.Wrap(err, "deserializing term store")
+		}
+
+		return parsable.(termstore.Storeable), nil
+	}
+
+	return nil, nil
+}
+
+// SetSiteWeb stores the site's web configuration in the site's additional
+// data.
+func SetSiteWeb(site models.Siteable, web *api.SiteWebConfig) {
+	if web == nil {
+		return
+	}
+
+	ad := site.GetAdditionalData()
+	if ad == nil {
+		ad = map[string]any{}
+	}
+
+	ad[SiteWebKey] = web
+	site.SetAdditionalData(ad)
+}
+
+// SiteWeb returns the web configuration stored in the site's additional
+// data, or nil if the site has none.  Like the term store, it is held in
+// its untyped form when deserialized from a backup.
+func SiteWeb(site models.Siteable) (*api.SiteWebConfig, error) {
+	switch v := site.GetAdditionalData()[SiteWebKey].(type) {
+	case *api.SiteWebConfig:
+		return v, nil
+	case map[string]any:
+		bs, err := json.Marshal(v)
+		if err != nil {
+			return nil, clues.Wrap(err, "marshalling site web configuration")
+		}
+
+		web := &api.SiteWebConfig{}
+
+		if err := json.Unmarshal(bs, web); err != nil {
+			return nil, clues.Wrap(err, "deserializing site web configuration")
+		}
+
+		return web, nil
+	}
+
+	return nil, nil
+}
+
+// SiteConfigurationBytes serializes the site's configuration.
+func SiteConfigurationBytes(site models.Siteable) ([]byte, error) {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", site); err != nil {
+		return nil, clues.Wrap(err, "serializing site configuration")
+	}
+
+	bs, err := writer.GetSerializedContent()
+
+	return bs, clues.Wrap(err, "serializing site configuration").OrNil()
+}
+
+// BytesToSiteable deserializes the bytes into a site.
+func BytesToSiteable(bytes []byte) (models.Siteable, error) {
+	parsable, err := createFromBytes(bytes, models.CreateSiteFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Wrap(err, "deserializing bytes to site")
+	}
+
+	return parsable.(models.Siteable), nil
+}
+
+// RestorableSiteColumn copies the properties of the column which can be
+// written back, leaving out the read-only properties and the id.
+func RestorableSiteColumn(column models.ColumnDefinitionable) models.ColumnDefinitionable {
+	body := models.NewColumnDefinition()
+	body.SetName(column.GetName())
+	body.SetDisplayName(column.GetDisplayName())
+	body.SetDescription(column.GetDescription())
+	body.SetColumnGroup(column.GetColumnGroup())
+	body.SetEnforceUniqueValues(column.GetEnforceUniqueValues())
+	body.SetHidden(column.GetHidden())
+	body.SetIndexed(column.GetIndexed())
+	body.SetRequired(column.GetRequired())
+	body.SetDefaultValue(column.GetDefaultValue())
+	body.SetValidation(column.GetValidation())
+	body.SetBoolean(column.GetBoolean())
+	body.SetCalculated(column.GetCalculated())
+	body.SetChoice(column.GetChoice())
+	body.SetCurrency(column.GetCurrency())
+	body.SetDateTime(column.GetDateTime())
+	body.SetGeolocation(column.GetGeolocation())
+	body.SetHyperlinkOrPicture(column.GetHyperlinkOrPicture())
+	body.SetLookup(column.GetLookup())
+	body.SetNumber(column.GetNumber())
+	body.SetPersonOrGroup(column.GetPersonOrGroup())
+	body.SetTerm(column.GetTerm())
+	body.SetText(column.GetText())
+	body.SetThumbnail(column.GetThumbnail())
+
+	return body
+}
+
+// RestorableSiteContentType copies the properties of the content type
+// which can be written back, leaving out the read-only properties and
+// the id.
+func RestorableSiteContentType(contentType models.ContentTypeable) models.ContentTypeable {
+	body := models.NewContentType()
+	body.SetName(contentType.GetName())
+	body.SetDescription(contentType.GetDescription())
+	body.SetGroup(contentType.GetGroup())
+	body.SetHidden(contentType.GetHidden())
+
+	return body
+}
+
+// SitePermissionApplicationID returns the id of the application to which
+// the permission was granted.
+func SitePermissionApplicationID(perm models.Permissionable) string {
+	for _, is := range perm.GetGrantedToIdentities() {
+		if is.GetApplication() != nil {
+			return ptr.Val(is.GetApplication().GetId())
+		}
+	}
+
+	for _, is := range perm.GetGrantedToIdentitiesV2() {
+		if is.GetApplication() != nil {
+			return ptr.Val(is.GetApplication().GetId())
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,147 @@
+package api_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/m365/service/sharepoint/api"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	bmodels "github.com/alcionai/corso/src/pkg/services/m365/api/graph/betasdk/models"
+)
+
+type SiteConfigurationUnitSuite struct {
+	tester.Suite
+}
+
+func TestSiteConfigurationUnitSuite(t *testing.T) {
+	suite.Run(t, &SiteConfigurationUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func siteConfigurationForTest(appID string) models.Siteable {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	site := models.NewSite()
+	site.SetId(ptr.To("site-id"))
+	site.SetDisplayName(ptr.To("site"))
+	site.SetWebUrl(ptr.To("https://example.com/sites/site"))
+	site.SetCreatedDateTime(ptr.To(now))
+	site.SetLastModifiedDateTime(ptr.To(now))
+
+	col := models.NewColumnDefinition()
+	col.SetId(ptr.To("col-id"))
+	col.SetName(ptr.To("col"))
+	col.SetText(models.NewTextColumn())
+	site.SetColumns([]models.ColumnDefinitionable{col})
+
+	ct := models.NewContentType()
+	ct.SetId(ptr.To("0x0100AB"))
+	ct.SetName(ptr.To("ct"))
+	site.SetContentTypes([]models.ContentTypeable{ct})
+
+	app := models.NewIdentity()
+	app.SetId(ptr.To(appID))
+
+	is := models.NewIdentitySet()
+	is.SetApplication(app)
+
+	perm := models.NewPermission()
+	perm.SetRoles([]string{"write"})
+	perm.SetGrantedToIdentities([]models.IdentitySetable{is})
+	site.SetPermissions([]models.Permissionable{perm})
+
+	settings := bmodels.NewSiteSettings()
+	settings.SetLanguageTag(ptr.To("en-US"))
+	settings.SetTimeZone(ptr.To("Pacific Standard Time"))
+	site.SetAdditionalData(map[string]any{api.SiteSettingsKey: settings})
+
+	return site
+}
+
+func (suite *SiteConfigurationUnitSuite) TestSiteConfigurationBytes_roundTrip() {
+	t := suite.T()
+	site := siteConfigurationForTest("app-id")
+
+	bs, err := api.SiteConfigurationBytes(site)
+	require.NoError(t, err, clues.ToCore(err))
+
+	result, err := api.BytesToSiteable(bs)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, ptr.Val(site.GetId()), ptr.Val(result.GetId()))
+	assert.Len(t, result.GetColumns(), 1)
+	assert.Len(t, result.GetContentTypes(), 1)
+	require.Len(t, result.GetPermissions(), 1)
+	assert.Equal(t, "app-id", api.SitePermissionApplicationID(result.GetPermissions()[0]))
+
+	settings := api.SiteSettings(result)
+	require.NotNil(t, settings)
+	assert.Equal(t, "en-US", ptr.Val(settings.GetLanguageTag()))
+	assert.Equal(t, "Pacific Standard Time", ptr.Val(settings.GetTimeZone()))
+}
+
+func (suite *SiteConfigurationUnitSuite) TestSiteConfigurationInfo() {
+	t := suite.T()
+	site := siteConfigurationForTest("app-id")
+
+	info := api.SiteConfigurationInfo(site, 42)
+
+	assert.Equal(t, details.SharePointSiteConfiguration, info.ItemType)
+	assert.Equal(t, "site", info.ItemName)
+	assert.Equal(t, "site-id", info.SiteID)
+	assert.Equal(t, ptr.Val(site.GetWebUrl()), info.WebURL)
+	assert.Equal(t, int64(42), info.Size)
+	require.NotNil(t, info.SiteConfiguration)
+	assert.Equal(t, 1, info.SiteConfiguration.ColumnCount)
+	assert.Equal(t, 1, info.SiteConfiguration.ContentTypeCount)
+	assert.Equal(t, 1, info.SiteConfiguration.PermissionCount)
+}
+
+func (suite *SiteConfigurationUnitSuite) TestSiteSettings_missing() {
+	assert.Nil(suite.T(), api.SiteSettings(models.NewSite()))
+}
+
+func (suite *SiteConfigurationUnitSuite) TestSitePermissionApplicationID() {
+	app := models.NewIdentity()
+	app.SetId(ptr.To("app-v2"))
+
+	is := models.NewSharePointIdentitySet()
+	is.SetApplication(app)
+
+	v2 := models.NewPermission()
+	v2.SetGrantedToIdentitiesV2([]models.SharePointIdentitySetable{is})
+
+	table := []struct {
+		name   string
+		perm   models.Permissionable
+		expect string
+	}{
+		{
+			name:   "granted to identities",
+			perm:   siteConfigurationForTest("app-id").GetPermissions()[0],
+			expect: "app-id",
+		},
+		{
+			name:   "granted to identities v2",
+			perm:   v2,
+			expect: "app-v2",
+		},
+		{
+			name:   "no application",
+			perm:   models.NewPermission(),
+			expect: "",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, api.SitePermissionApplicationID(test.perm))
+		})
+	}
+}
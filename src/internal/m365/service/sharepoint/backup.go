@@ -55,6 +55,12 @@ func (sharePointBackup) ProduceBackupCollections(
 		"site_id", clues.Hide(bpc.ProtectedResource.ID()),
 		"site_url", clues.Hide(bpc.ProtectedResource.Name()))
 
+	// a drive's enumeration progress depends on the scope that selected its
+	// folders, so it's only carried between backups of a single scope.
+	if len(b.Scopes()) > 1 {
+		bpc.EnumerationState = nil
+	}
+
 	for _, scope := range b.Scopes() {
 		if el.Failure() != nil {
 			break
@@ -118,6 +124,23 @@ func (sharePointBackup) ProduceBackupCollections(
 			// Lists don't make use of previous metadata
 			// TODO: Revisit when we add support of pages
 			canUsePreviousBackup = true
+
+		case path.SiteConfigurationCategory:
+			spcs, err = site.CollectSiteConfiguration(
+				ctx,
+				bpc,
+				creds,
+				ac,
+				scope,
+				su,
+				counter)
+			if err != nil {
+				el.AddRecoverable(ctx, err)
+				continue
+			}
+
+			// the site configuration is always backed up in full.
+			canUsePreviousBackup = true
 		}
 
 		collections = append(collections, spcs...)
@@ -113,6 +113,8 @@ func (suite *LibrariesBackupUnitSuite) TestUpdateCollections() {
 				idname.NewProvider(siteID, siteID),
 				nil,
 				control.DefaultOptions(),
+				nil,
+				nil,
 				count.New())
 
 			c.CollectionMap = collMap
@@ -103,9 +103,9 @@ func (h *baseSharePointHandler) ProduceExportCollections(
 				stats)
 
 			ec = append(ec, coll)
-		case path.ListsCategory:
+		case path.ListsCategory, path.SiteConfigurationCategory:
 			folders := dc.FullPath().Folders()
-			pth := path.Builder{}.Append(path.ListsCategory.HumanString()).Append(folders...)
+			pth := path.Builder{}.Append(cat.HumanString()).Append(folders...)
 
 			ec = append(
 				ec,
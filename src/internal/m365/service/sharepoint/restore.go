@@ -9,6 +9,7 @@ import (
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
 	"github.com/alcionai/corso/src/internal/m365/collection/site"
+	betaAPI "github.com/alcionai/corso/src/internal/m365/service/sharepoint/api"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/pkg/backup/details"
@@ -125,6 +126,19 @@ func (h *sharepointHandler) ConsumeRestoreCollections(
 				deets,
 				errs)
 
+		case path.SiteConfigurationCategory:
+			metrics, err = site.RestoreSiteConfigurationCollection(
+				ictx,
+				site.NewSiteConfigurationHandler(
+					betaAPI.NewBetaService(h.apiClient.Stable.Adapter()),
+					h.apiClient.TermStore(),
+					h.apiClient.SiteWeb()),
+				dc,
+				rcc.RestoreConfig,
+				deets,
+				ctr,
+				errs)
+
 		default:
 			return nil, nil, clues.Wrap(clues.New(category.String()), "category not supported").With("category", category)
 		}
@@ -2,10 +2,12 @@ package operations
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
 
 	"github.com/alcionai/corso/src/internal/common/crash"
 	"github.com/alcionai/corso/src/internal/common/idname"
@@ -25,6 +27,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/backup/search"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/dttm"
@@ -62,6 +65,14 @@ type BackupOperation struct {
 	// When true, disables kopia-assisted incremental backups. This forces
 	// downloading and hashing all item data for items not in the merge base(s).
 	disableAssistBackup bool
+
+	// checkpoint is the backup model saved for the latest checkpoint of the
+	// upload, if any.
+	checkpoint *backup.Backup
+	// enumeration holds the enumeration progress saved by the checkpoints of
+	// an interrupted backup, and records the progress of this backup so that
+	// it gets saved with this backup's checkpoints.
+	enumeration *inject.EnumerationState
 }
 
 // BackupResults aggregate the details of the result of the operation.
@@ -71,6 +82,12 @@ type BackupResults struct {
 	BackupID model.StableID `json:"backupID"`
 	// keys are found in /pkg/count/keys.go
 	Counts map[string]int64 `json:"counts"`
+	// CategoryCounts holds the counts of each category of data, keyed by
+	// the category.  Only populated by dry runs.
+	CategoryCounts map[string]map[string]int64 `json:"categoryCounts,omitempty"`
+	// UnsizedCategories lists the categories in CategoryCounts whose item
+	// sizes weren't known during enumeration.  Only populated by dry runs.
+	UnsizedCategories []string `json:"unsizedCategories,omitempty"`
 }
 
 // NewBackupOperation constructs and validates a backup operation.
@@ -186,6 +203,42 @@ func isMergeBackup(
 	return len(err.Recovered()) == 0
 }
 
+// cancelOnInterrupt returns a ctx that gets cancelled if the interrupt
+// bound to ctx closes before the returned func gets called.  The func reports
+// whether the ctx got cancelled.
+func cancelOnInterrupt(ctx context.Context) (context.Context, func() bool) {
+	interrupt := kopia.CtxInterrupt(ctx)
+	if interrupt == nil {
+		return ctx, func() bool { return false }
+	}
+
+	var (
+		ictx, cancel = context.WithCancel(ctx)
+		done         = make(chan struct{})
+		stopped      = make(chan struct{})
+		interrupted  bool
+	)
+
+	go func() {
+		defer close(stopped)
+
+		select {
+		case <-interrupt:
+			interrupted = true
+
+			cancel()
+		case <-done:
+		}
+	}()
+
+	return ictx, func() bool {
+		close(done)
+		<-stopped
+
+		return interrupted
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Primary Controller
 // ---------------------------------------------------------------------------
@@ -238,6 +291,10 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 		return err
 	}
 
+	if op.Options.DryRun {
+		return op.estimate(ctx)
+	}
+
 	// -----
 	// Setup
 	// -----
@@ -249,6 +306,7 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 	)
 
 	op.Results.BackupID = model.StableID(uuid.NewString())
+	op.Results.StartedAt = startTime
 
 	cats, err := op.Selectors.AllHumanPathCategories()
 	if err != nil {
@@ -325,6 +383,11 @@ func (op *BackupOperation) Run(ctx context.Context) (err error) {
 		sstore,
 		deets,
 		startTime)
+
+	if opStats.k != nil && opStats.k.Incomplete {
+		op.Errors.Fail(clues.StackWC(ctx, core.ErrBackupInterrupted))
+	}
+
 	finalizeErrorHandling(ctx, op.Options, op.Errors, "running backup")
 
 	logger.Ctx(ctx).Infow(
@@ -420,6 +483,19 @@ func (op *BackupOperation) do(
 	//   * the base finder code to skip over older bases (breaks isolation a bit
 	//     by requiring knowledge of good/bad backup versions for different
 	//     services)
+	// Files excluded by the file filter are removed from the backup the same
+	// way deleted files are, and delta queries won't report files that were
+	// previously excluded.  Bases made with a different filter have to be
+	// dropped so that the drives get fully enumerated.
+	if fileFilterChanged(op.Selectors, mans.MergeBases()) {
+		logger.Ctx(ctx).Info("dropping merge bases due to file filter change")
+
+		mans.DisableMergeBases()
+
+		canUseMetadata = false
+		mdColls = nil
+	}
+
 	if op.Selectors.PathService() == path.GroupsService {
 		if mans.MinBackupVersion() != version.NoBackup &&
 			mans.MinBackupVersion() < version.Groups9Update {
@@ -449,20 +525,40 @@ func (op *BackupOperation) do(
 		lastBackupVersion = mans.MinBackupVersion()
 	}
 
+	// an interrupted backup of the same data may have saved how far it got
+	// through enumeration, so that this backup can continue from there.
+	op.enumeration = inject.NewEnumerationState(getEnumerationFromBases(
+		ctx,
+		mans.UniqueAssistBases(),
+		op.Selectors,
+		detailsStore))
+
+	// Enumeration can take a long time too, so interrupts cancel it.  Nothing
+	// has been uploaded at that point, so there's nothing to save.
+	enumCtx, stopEnum := cancelOnInterrupt(ctx)
+
 	// TODO(ashmrtn): This should probably just return a collection that deletes
 	// the entire subtree instead of returning an additional bool. That way base
 	// selection is controlled completely by flags and merging is controlled
 	// completely by collections.
 	cs, ssmb, canUsePreviousBackup, err := produceBackupDataCollections(
-		ctx,
+		enumCtx,
 		op.bp,
 		op.ResourceOwner,
 		op.Selectors,
 		mdColls,
 		lastBackupVersion,
+		op.enumeration,
 		op.Options,
 		op.Counter,
 		op.Errors)
+
+	interrupted := stopEnum()
+
+	if err != nil && interrupted {
+		return nil, clues.Stack(core.ErrBackupInterrupted, err)
+	}
+
 	if err != nil {
 		return nil, clues.Wrap(err, "producing backup data collections")
 	}
@@ -472,8 +568,18 @@ func (op *BackupOperation) do(
 		"can_use_previous_backup", canUsePreviousBackup,
 		"collection_count", len(cs))
 
+	// Each checkpoint of the upload gets saved as an assist backup, so that the
+	// items uploaded before it aren't downloaded again if this backup never
+	// finishes.
+	uploadCtx := kopia.BindCheckpointInterval(ctx, op.Options.CheckpointInterval)
+	uploadCtx = kopia.BindCheckpointHandler(
+		uploadCtx,
+		func(ctx context.Context, snapshotID string, deets *details.Details) error {
+			return op.persistCheckpoint(ctx, reasons, snapshotID, deets)
+		})
+
 	writeStats, deets, toMerge, err := consumeBackupCollections(
-		ctx,
+		uploadCtx,
 		op.kopia,
 		op.account.ID(),
 		reasons,
@@ -505,6 +611,10 @@ func (op *BackupOperation) do(
 		return nil, clues.Wrap(err, "merging details")
 	}
 
+	if writeStats.Incomplete {
+		logger.Ctx(ctx).Info("backup upload was interrupted")
+	}
+
 	opStats.ctrl = op.bp.Wait()
 
 	logger.Ctx(ctx).Debug(opStats.ctrl)
@@ -512,6 +622,149 @@ func (op *BackupOperation) do(
 	return deets, nil
 }
 
+// ---------------------------------------------------------------------------
+// Dry run
+// ---------------------------------------------------------------------------
+
+// estimate runs the enumeration half of a backup.  Collections get produced
+// as they would for a full backup, but their items are never streamed, and
+// nothing is read from, or written to, the repository.  The counts of the
+// containers, items, and bytes the backup would capture, and of the api
+// call tokens it took to find them, are recorded in the operation's results.
+func (op *BackupOperation) estimate(ctx context.Context) error {
+	var (
+		startTime = time.Now()
+		apiTokens = op.Counter.Get(count.APICallTokensConsumed)
+	)
+
+	ctx = clues.Add(
+		ctx,
+		"resource_owner_id", op.ResourceOwner.ID(),
+		"resource_owner_name", clues.Hide(op.ResourceOwner.Name()),
+		"service", op.Selectors.Service,
+		"dry_run", true)
+
+	pcfg := observe.ProgressCfg{
+		NewSection:        true,
+		SectionIdentifier: clues.Hide(op.ResourceOwner.Name()),
+	}
+	observe.Message(ctx, pcfg, "Estimating Backup")
+
+	op.Results.StartedAt = startTime
+	op.Results.ResourceOwners = 1
+
+	cs, _, _, err := produceBackupDataCollections(
+		ctx,
+		op.bp,
+		op.ResourceOwner,
+		op.Selectors,
+		nil,
+		version.NoBackup,
+		nil,
+		op.Options,
+		op.Counter,
+		op.Errors)
+	if err != nil {
+		logger.CtxErr(ctx, err).Error("estimating backup")
+		op.Errors.Fail(clues.Wrap(err, "producing backup data collections"))
+	}
+
+	totals, categories, unsized := estimateCollections(cs)
+	totals.Add(count.APICallTokensConsumed, op.Counter.Get(count.APICallTokensConsumed)-apiTokens)
+
+	op.Results.CompletedAt = time.Now()
+	op.Results.Counts = totals.Values()
+	op.Results.CategoryCounts = map[string]map[string]int64{}
+
+	for cat, ctr := range categories {
+		op.Results.CategoryCounts[cat.HumanString()] = ctr.Values()
+	}
+
+	for _, cat := range unsized {
+		op.Results.UnsizedCategories = append(op.Results.UnsizedCategories, cat.HumanString())
+	}
+
+	sort.Strings(op.Results.UnsizedCategories)
+
+	op.Status = Completed
+
+	if op.Errors.Failure() != nil {
+		op.Status = Failed
+	}
+
+	LogFaultErrors(ctx, op.Errors.Errors(), "estimating backup")
+	finalizeErrorHandling(ctx, op.Options, op.Errors, "estimating backup")
+
+	logger.Ctx(ctx).Infow(
+		"completed backup estimate",
+		"results", op.Results,
+		"failure", op.Errors.Failure())
+
+	return op.Errors.Failure()
+}
+
+// estimateCollections tallies the containers, items, and bytes described by
+// the collections, in total and for each category of data.  Item sizes are
+// added to StreamBytesAdded, since nothing gets streamed in a dry run.
+// Categories holding any collection that can't report the size of its items
+// are returned as unsized, so that their bytes aren't mistaken for a complete
+// total.  Metadata and deleted collections are not included.
+func estimateCollections(
+	cs []data.BackupCollection,
+) (*count.Bus, map[path.CategoryType]*count.Bus, []path.CategoryType) {
+	var (
+		totals     = count.New()
+		categories = map[path.CategoryType]*count.Bus{}
+		unsized    = map[path.CategoryType]struct{}{}
+	)
+
+	for _, c := range cs {
+		fp := c.FullPath()
+
+		if fp == nil ||
+			c.State() == data.DeletedState ||
+			fp.Service() == fp.Service().ToMetadata() {
+			continue
+		}
+
+		ctr, ok := categories[fp.Category()]
+		if !ok {
+			ctr = totals.Local()
+			categories[fp.Category()] = ctr
+		}
+
+		ctr.Inc(count.Containers)
+
+		ie, ok := c.(data.ItemEstimator)
+		if !ok {
+			unsized[fp.Category()] = struct{}{}
+			continue
+		}
+
+		items, bytes, sized := ie.EstimateItems()
+		if !sized {
+			unsized[fp.Category()] = struct{}{}
+		}
+
+		ctr.Add(count.ItemsAdded, items)
+		ctr.Add(count.StreamBytesAdded, bytes)
+	}
+
+	return totals, categories, maps.Keys(unsized)
+}
+
+// fileFilterChanged is true if any of the bases was made with a different
+// file filter than the selector.
+func fileFilterChanged(sel selectors.Selector, bases []kopia.BackupBase) bool {
+	for _, base := range bases {
+		if base.Backup != nil && !sel.FileFilter.Equal(base.Backup.Selector.FileFilter) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func makeFallbackReasons(tenant string, sel selectors.Selector) ([]identity.Reasoner, error) {
 	if sel.PathService() != path.SharePointService &&
 		sel.DiscreteOwner != sel.DiscreteOwnerName {
@@ -544,6 +797,7 @@ func produceBackupDataCollections(
 	sel selectors.Selector,
 	metadata []data.RestoreCollection,
 	lastBackupVersion int,
+	enumeration *inject.EnumerationState,
 	ctrlOpts control.Options,
 	counter *count.Bus,
 	errs *fault.Bus,
@@ -552,6 +806,7 @@ func produceBackupDataCollections(
 	defer close(progressMessage)
 
 	bpc := inject.BackupProducerConfig{
+		EnumerationState:    enumeration,
 		LastBackupVersion:   lastBackupVersion,
 		MetadataCollections: metadata,
 		Options:             ctrlOpts,
@@ -703,6 +958,8 @@ func mergeItemsFromBase(
 			clues.WrapWC(ctx, err, "fetching base details for backup")
 	}
 
+	baseContents := getContentsFromBackup(ctx, baseBackup.Backup, detailsStore)
+
 	for _, entry := range baseDeets.Items() {
 		// Track this here instead of calling Items() again to get the count since
 		// it can be a bit expensive.
@@ -749,8 +1006,8 @@ func mergeItemsFromBase(
 			continue
 		}
 
-		// Fixup paths in the item.
-		item := entry.ItemInfo
+		// Fixup paths in the item, and carry over its text.
+		item := baseContents.WithText(entry)
 		details.UpdateItem(&item, newLoc)
 
 		err = deets.Add(
@@ -896,6 +1153,11 @@ func (op *BackupOperation) persistResults(
 		return clues.New("backup persistence never completed")
 	}
 
+	// An interrupted upload only holds part of the backup's data.
+	if opStats.k.Incomplete {
+		op.Status = Failed
+	}
+
 	// the summary of all counts collected during backup
 	op.Results.Counts = counter.TotalValues()
 
@@ -959,6 +1221,11 @@ func (op *BackupOperation) createBackupModels(
 		return clues.NewWC(ctx, "no snapshot ID to record")
 	}
 
+	// the index reads the text of the items, which then moves out of the
+	// details so that reading details doesn't read the text of every item.
+	idx := search.NewBackupIndex(deets)
+	contents := search.TakeContents(deets)
+
 	err := sscw.Collect(ctx, streamstore.DetailsCollector(deets))
 	if err != nil {
 		return clues.Wrap(err, "collecting details for persistence")
@@ -969,12 +1236,26 @@ func (op *BackupOperation) createBackupModels(
 		return clues.Wrap(err, "collecting errors for persistence")
 	}
 
-	metadataReasons := make([]identity.Reasoner, 0, len(reasons))
-	for _, reason := range reasons {
-		metadataReasons = append(metadataReasons, reason.ToMetadata())
+	err = sscw.Collect(ctx, streamstore.SearchIndexCollector(idx))
+	if err != nil {
+		return clues.Wrap(err, "collecting search index for persistence")
+	}
+
+	err = sscw.Collect(ctx, streamstore.ContentsCollector(contents))
+	if err != nil {
+		return clues.Wrap(err, "collecting contents for persistence")
 	}
 
-	ssid, err := sscw.Write(ctx, metadataReasons, errs)
+	// unfinished backups are assist bases, and the next backup continues
+	// their enumeration.
+	if opStats.k.Incomplete {
+		err = sscw.Collect(ctx, streamstore.EnumerationCollector(op.enumeration))
+		if err != nil {
+			return clues.Wrap(err, "collecting enumeration for persistence")
+		}
+	}
+
+	ssid, err := sscw.Write(ctx, metadataReasons(reasons), errs)
 	if err != nil {
 		return clues.Wrap(err, "persisting details and errors")
 	}
@@ -991,6 +1272,18 @@ func (op *BackupOperation) createBackupModels(
 	//
 	// model.BackupTypeTag has more info about how these tags are used.
 	switch {
+	case opStats.k.Incomplete:
+		// Interrupted backups only hold part of the data, so they can't be
+		// merge bases, regardless of the failure policy.  Persisting them as
+		// assist backups lets the next backup reuse the items they uploaded
+		// instead of downloading those items again.
+		if !opStats.hasNewDetailEntries {
+			logger.Ctx(ctx).Info("interrupted backup has no new items to save")
+			return nil
+		}
+
+		tags[model.BackupTypeTag] = model.AssistBackup
+
 	case op.Options.PreviewLimits.Enabled:
 		// Preview backups need to be successful and without errors to be considered
 		// valid. Just reuse the merge base check for that since it has the same
@@ -1044,6 +1337,20 @@ func (op *BackupOperation) createBackupModels(
 		op.Errors.Errors(),
 		tags)
 
+	// The model saved for the last checkpoint gets replaced, since it has the
+	// same backup ID.
+	if op.checkpoint != nil {
+		b.ModelStoreID = op.checkpoint.ModelStoreID
+
+		logger.Ctx(ctx).Info("replacing checkpoint backup")
+
+		if err = op.store.Update(ctx, model.BackupSchema, b); err != nil {
+			return clues.Wrap(err, "replacing checkpoint backup model")
+		}
+
+		return nil
+	}
+
 	logger.Ctx(ctx).Info("creating new backup")
 
 	if err = op.store.Put(ctx, model.BackupSchema, b); err != nil {
@@ -1052,3 +1359,116 @@ func (op *BackupOperation) createBackupModels(
 
 	return nil
 }
+
+// persistCheckpoint saves an assist backup for a checkpoint of the item data
+// upload, so that later backups can reuse the items uploaded before the
+// checkpoint even if this backup never finishes.  Each checkpoint replaces
+// the backup saved for the one before it.
+//
+// Checkpoint backups are never merge bases.  The delta tokens and previous
+// paths produced by enumeration describe all of the backup's data, so later
+// backups would skip the items that are missing from the checkpoint.  Those
+// backups use the metadata of the prior complete backup instead.  The
+// enumeration progress gets saved with each checkpoint, so that the next
+// backup can continue enumerating from where this one got to instead of
+// enumerating everything since the prior complete backup again.
+func (op *BackupOperation) persistCheckpoint(
+	ctx context.Context,
+	reasons []identity.Reasoner,
+	snapshotID string,
+	deets *details.Details,
+) error {
+	ctx = clues.Add(
+		ctx,
+		"snapshot_id", snapshotID,
+		"backup_id", op.Results.BackupID,
+		"details_entry_count", len(deets.Entries))
+
+	if op.Errors.Failure() != nil {
+		return clues.WrapWC(ctx, op.Errors.Failure(), "non-recoverable failure")
+	}
+
+	if len(deets.Entries) == 0 {
+		logger.Ctx(ctx).Info("checkpoint has no items to save")
+		return nil
+	}
+
+	var (
+		sstore = streamstore.NewStreamer(op.kopia, op.account.ID(), op.Selectors.PathService())
+		// checkpoints aren't searched, but later backups merge the text of
+		// their items.
+		contents = search.TakeContents(deets)
+	)
+
+	err := sstore.Collect(ctx, streamstore.DetailsCollector(deets))
+	if err != nil {
+		return clues.Wrap(err, "collecting checkpoint details for persistence")
+	}
+
+	err = sstore.Collect(ctx, streamstore.FaultErrorsCollector(op.Errors.Errors()))
+	if err != nil {
+		return clues.Wrap(err, "collecting checkpoint errors for persistence")
+	}
+
+	err = sstore.Collect(ctx, streamstore.ContentsCollector(contents))
+	if err != nil {
+		return clues.Wrap(err, "collecting checkpoint contents for persistence")
+	}
+
+	err = sstore.Collect(ctx, streamstore.EnumerationCollector(op.enumeration))
+	if err != nil {
+		return clues.Wrap(err, "collecting checkpoint enumeration for persistence")
+	}
+
+	ssid, err := sstore.Write(ctx, metadataReasons(reasons), fault.New(true))
+	if err != nil {
+		return clues.Wrap(err, "persisting checkpoint details and errors")
+	}
+
+	b := backup.New(
+		snapshotID, ssid,
+		op.Status.String(),
+		op.BackupVersion,
+		op.Results.BackupID,
+		op.Selectors,
+		op.ResourceOwner.ID(),
+		op.ResourceOwner.Name(),
+		op.Results.ReadWrites,
+		stats.StartAndEndTime{
+			StartedAt:   op.Results.StartedAt,
+			CompletedAt: time.Now(),
+		},
+		op.Errors.Errors(),
+		map[string]string{
+			model.ServiceTag:    op.Selectors.PathService().String(),
+			model.BackupTypeTag: model.AssistBackup,
+		})
+
+	if op.checkpoint == nil {
+		err = op.store.Put(ctx, model.BackupSchema, b)
+	} else {
+		b.ModelStoreID = op.checkpoint.ModelStoreID
+		err = op.store.Update(ctx, model.BackupSchema, b)
+	}
+
+	if err != nil {
+		return clues.Wrap(err, "saving checkpoint backup model")
+	}
+
+	op.checkpoint = b
+
+	logger.Ctx(ctx).Info("saved checkpoint backup")
+
+	return nil
+}
+
+// metadataReasons returns the reasons to tag the details and errors of a
+// backup with.
+func metadataReasons(reasons []identity.Reasoner) []identity.Reasoner {
+	mrs := make([]identity.Reasoner, 0, len(reasons))
+	for _, reason := range reasons {
+		mrs = append(mrs, reason.ToMetadata())
+	}
+
+	return mrs
+}
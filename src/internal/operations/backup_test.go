@@ -420,6 +420,23 @@ func (suite *BackupOpUnitSuite) TestNewBackupOperation_configuredOptionsMatchInp
 			MaxPages:             46,
 			Enabled:              true,
 		},
+		DriveVersions: control.DriveVersionsConfig{
+			Enabled:  true,
+			MaxCount: 5,
+			MaxAge:   time.Hour,
+		},
+		ListItemVersions: true,
+		MailMIME:         control.MailMIMEInclude,
+		DryRun:           true,
+		DownloadRate: control.DownloadRateConfig{
+			BytesPerSecond: 1 << 20,
+			Schedule: []control.DownloadRateWindow{{
+				Start:          9 * time.Hour,
+				End:            17 * time.Hour,
+				BytesPerSecond: 1 << 10,
+			}},
+		},
+		CheckpointInterval: 10 * time.Minute,
 		SkipEventsOnInstance503ForResources: map[string]struct{}{
 			"resource": {},
 		},
@@ -506,6 +523,20 @@ func (suite *BackupOpUnitSuite) TestBackupOperation_PersistResults() {
 				ctrl: &data.CollectionStats{},
 			},
 		},
+		{
+			// interrupted uploads fail without a non-recoverable error, which
+			// still allows persisting an assist backup.
+			expectStatus: Failed,
+			expectErr:    assert.NoError,
+			stats: backupStats{
+				k: &kopia.BackupStats{
+					TotalFileCount:   1,
+					Incomplete:       true,
+					IncompleteReason: "canceled",
+				},
+				ctrl: &data.CollectionStats{},
+			},
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.expectStatus.String(), func() {
@@ -546,6 +577,257 @@ func (suite *BackupOpUnitSuite) TestBackupOperation_PersistResults() {
 	}
 }
 
+type estimatorCollection struct {
+	dataMock.Collection
+	items, bytes int64
+	sized        bool
+}
+
+func (c estimatorCollection) EstimateItems() (int64, int64, bool) {
+	return c.items, c.bytes, c.sized
+}
+
+func (suite *BackupOpUnitSuite) TestBackupOperation_DryRun() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	const (
+		tenant = "tenant"
+		user   = "user"
+	)
+
+	mustPath := func(p path.Path, err error) path.Path {
+		require.NoError(t, err, clues.ToCore(err))
+		return p
+	}
+
+	var (
+		filesPath = mustPath(path.Build(tenant, user, path.OneDriveService, path.FilesCategory, false, "drive", "root:"))
+		subPath   = mustPath(path.Build(tenant, user, path.OneDriveService, path.FilesCategory, false, "drive", "root:", "sub"))
+		mdPath    = mustPath(path.BuildMetadata(tenant, user, path.OneDriveService, path.FilesCategory, false))
+		ctrl      = &mock.Controller{
+			Collections: []data.BackupCollection{
+				estimatorCollection{
+					Collection: dataMock.Collection{Path: filesPath, CState: data.NewState},
+					items:      3,
+					bytes:      300,
+					sized:      true,
+				},
+				estimatorCollection{
+					Collection: dataMock.Collection{Path: subPath, CState: data.NewState},
+					items:      1,
+					bytes:      50,
+					sized:      true,
+				},
+				estimatorCollection{
+					Collection: dataMock.Collection{Path: subPath, CState: data.DeletedState},
+					items:      7,
+				},
+				dataMock.Collection{Path: mdPath, CState: data.NewState},
+				dataMock.Collection{Path: subPath, CState: data.NewState},
+			},
+		}
+		opts = control.DefaultOptions()
+		sel  = selectors.NewOneDriveBackup([]string{user})
+		ctr  = count.New()
+	)
+
+	opts.DryRun = true
+	sel.DiscreteOwner = user
+	sel.Include(sel.AllData())
+
+	// the dry run never touches the repository.
+	op, err := NewBackupOperation(
+		ctx,
+		opts,
+		&kopia.Wrapper{},
+		store.NewWrapper(&kopia.ModelStore{}),
+		ctrl,
+		account.Account{},
+		sel.Selector,
+		sel.Selector,
+		evmock.NewBus(),
+		ctr)
+	require.NoError(t, err, clues.ToCore(err))
+
+	ctr.Add(count.APICallTokensConsumed, 10)
+
+	err = op.Run(ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, Completed, op.Status)
+	assert.Empty(t, op.Results.BackupID)
+
+	expect := map[string]int64{
+		string(count.Containers):       3,
+		string(count.ItemsAdded):       4,
+		string(count.StreamBytesAdded): 350,
+	}
+
+	assert.Equal(t, expect, op.Results.CategoryCounts[path.FilesCategory.HumanString()])
+	assert.Equal(
+		t,
+		[]string{path.FilesCategory.HumanString()},
+		op.Results.UnsizedCategories,
+		"the collection that can't estimate its items leaves the category unsized")
+
+	expect[string(count.APICallTokensConsumed)] = 0
+	assert.Equal(t, expect, op.Results.Counts)
+}
+
+func (suite *BackupOpUnitSuite) TestFileFilterChanged() {
+	var (
+		pdfs    = &selectors.FileFilter{Extensions: []string{"pdf"}}
+		sel     = selectors.NewOneDriveBackup([]string{"user"})
+		noFilt  = kopia.BackupBase{Backup: &backup.Backup{}}
+		pdfOnly = kopia.BackupBase{
+			Backup: &backup.Backup{Selector: selectors.Selector{FileFilter: pdfs}},
+		}
+	)
+
+	sel.FilterFiles(*pdfs)
+
+	table := []struct {
+		name   string
+		sel    selectors.Selector
+		bases  []kopia.BackupBase
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "no bases",
+			sel:    sel.Selector,
+			expect: assert.False,
+		},
+		{
+			name:   "no filters",
+			sel:    selectors.NewOneDriveBackup([]string{"user"}).Selector,
+			bases:  []kopia.BackupBase{noFilt},
+			expect: assert.False,
+		},
+		{
+			name:   "same filter",
+			sel:    sel.Selector,
+			bases:  []kopia.BackupBase{pdfOnly},
+			expect: assert.False,
+		},
+		{
+			name:   "filter added",
+			sel:    sel.Selector,
+			bases:  []kopia.BackupBase{pdfOnly, noFilt},
+			expect: assert.True,
+		},
+		{
+			name:   "filter removed",
+			sel:    selectors.NewOneDriveBackup([]string{"user"}).Selector,
+			bases:  []kopia.BackupBase{pdfOnly},
+			expect: assert.True,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), fileFilterChanged(test.sel, test.bases))
+		})
+	}
+}
+
+func (suite *BackupOpUnitSuite) TestGetEnumerationFromBases() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		now   = time.Now()
+		sel   = selectors.NewOneDriveBackup([]string{"user"})
+		other = selectors.NewOneDriveBackup([]string{"user"})
+		older = inject.NewEnumerationState(nil)
+		newer = inject.NewEnumerationState(nil)
+		diff  = inject.NewEnumerationState(nil)
+	)
+
+	sel.Include(sel.AllData())
+	other.Include(other.Folders([]string{"folder"}))
+
+	older.Record("drive1", "older")
+	older.Record("drive2", "older")
+	newer.Record("drive1", "newer")
+	diff.Record("drive3", "other")
+
+	base := func(ssid string, created time.Time, sel selectors.Selector) kopia.BackupBase {
+		bup := &backup.Backup{
+			StreamStoreID: ssid,
+			Selector:      sel,
+		}
+		bup.CreationTime = created
+
+		return kopia.BackupBase{Backup: bup}
+	}
+
+	ss := ssmock.Streamer{
+		Enumerations: map[string]*inject.EnumerationState{
+			"older": older,
+			"newer": newer,
+			"other": diff,
+		},
+	}
+
+	bases := []kopia.BackupBase{
+		base("older", now.Add(-time.Hour), sel.Selector),
+		base("newer", now, sel.Selector),
+		base("other", now, other.Selector),
+		base("missing", now, sel.Selector),
+		base("", now, sel.Selector),
+		{},
+	}
+
+	result := getEnumerationFromBases(ctx, bases, sel.Selector, ss)
+
+	expect := map[string]json.RawMessage{
+		"drive1": json.RawMessage(`"newer"`),
+		"drive2": json.RawMessage(`"older"`),
+	}
+
+	assert.Equal(t, expect, result)
+}
+
+func (suite *BackupOpUnitSuite) TestCancelOnInterrupt() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	// no interrupt bound
+	ictx, stop := cancelOnInterrupt(ctx)
+	assert.False(t, stop(), "unbound")
+	assert.NoError(t, ictx.Err(), "unbound")
+
+	// interrupted before stopping
+	interrupt := make(chan struct{})
+	ictx, stop = cancelOnInterrupt(kopia.BindInterrupt(ctx, interrupt))
+
+	close(interrupt)
+
+	assert.Eventually(
+		t,
+		func() bool { return ictx.Err() != nil },
+		time.Second,
+		10*time.Millisecond,
+		"interrupted")
+	assert.True(t, stop(), "interrupted")
+
+	// stopped before interrupting
+	interrupt = make(chan struct{})
+	ictx, stop = cancelOnInterrupt(kopia.BindInterrupt(ctx, interrupt))
+
+	assert.False(t, stop(), "stopped")
+
+	close(interrupt)
+
+	assert.NoError(t, ictx.Err(), "stopped")
+}
+
 func (suite *BackupOpUnitSuite) TestBackupOperation_ConsumeBackupDataCollections_Paths() {
 	var (
 		t = suite.T()
@@ -2,14 +2,21 @@ package operations
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
 
 	"github.com/alcionai/clues"
 
+	"github.com/alcionai/corso/src/internal/kopia"
 	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/internal/streamstore"
 	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/search"
 	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/store"
 )
 
@@ -59,3 +66,104 @@ func getDetailsFromBackup(
 
 	return &deets, nil
 }
+
+// getContentsFromBackup reads the text of the backup's items recorded by the
+// content indexing extension.  Backups made before the text was stored
+// apart from their details don't have any, and produce nil.
+func getContentsFromBackup(
+	ctx context.Context,
+	bup *backup.Backup,
+	detailsStore streamstore.Reader,
+) *search.Contents {
+	var (
+		contents search.Contents
+		umt      = streamstore.ContentsReader(search.UnmarshalContentsTo(&contents))
+	)
+
+	if len(bup.StreamStoreID) == 0 {
+		return nil
+	}
+
+	// a missing blob isn't a failure of the operation, so it gets its own bus.
+	if err := detailsStore.Read(ctx, bup.StreamStoreID, umt, fault.New(true)); err != nil {
+		logger.CtxErr(ctx, err).Debug("base backup has no contents")
+		return nil
+	}
+
+	return &contents
+}
+
+// getEnumerationFromBases reads the enumeration progress saved with the
+// checkpoints of the assist bases.  Progress only carries over to backups
+// with the same selection, since it describes the items that selection
+// enumerated.  When bases hold progress for the same key, the most recent
+// base wins.
+func getEnumerationFromBases(
+	ctx context.Context,
+	bases []kopia.BackupBase,
+	sel selectors.Selector,
+	detailsStore streamstore.Reader,
+) map[string]json.RawMessage {
+	var (
+		result = map[string]json.RawMessage{}
+		bups   = make([]*backup.Backup, 0, len(bases))
+	)
+
+	for _, base := range bases {
+		if base.Backup == nil || len(base.Backup.StreamStoreID) == 0 {
+			continue
+		}
+
+		if !sameSelection(sel, base.Backup.Selector) {
+			continue
+		}
+
+		bups = append(bups, base.Backup)
+	}
+
+	sort.Slice(bups, func(i, j int) bool {
+		return bups[i].CreationTime.After(bups[j].CreationTime)
+	})
+
+	for _, bup := range bups {
+		var (
+			prev map[string]json.RawMessage
+			umt  = streamstore.EnumerationReader(inject.UnmarshalEnumerationStateTo(&prev))
+			ictx = clues.Add(ctx, "base_backup_id", bup.ID)
+		)
+
+		// a missing blob isn't a failure of the operation, so it gets its own bus.
+		if err := detailsStore.Read(ictx, bup.StreamStoreID, umt, fault.New(true)); err != nil {
+			logger.CtxErr(ictx, err).Debug("base backup has no enumeration progress")
+			continue
+		}
+
+		for k, v := range prev {
+			if _, ok := result[k]; !ok {
+				result[k] = v
+			}
+		}
+	}
+
+	return result
+}
+
+// sameSelection is true if both selectors select the same data.
+func sameSelection(a, b selectors.Selector) bool {
+	if !a.FileFilter.Equal(b.FileFilter) {
+		return false
+	}
+
+	scopes := func(s selectors.Selector) string {
+		bs, err := json.Marshal([]any{s.Includes, s.Excludes, s.Filters})
+		if err != nil {
+			return ""
+		}
+
+		return string(bs)
+	}
+
+	as, bs := scopes(a), scopes(b)
+
+	return len(as) > 0 && as == bs
+}
@@ -22,6 +22,10 @@ type RestoreConsumerConfig struct {
 // configurations from various packages, all of which are widely used by
 // backup producers independent of service or data category.
 type BackupProducerConfig struct {
+	// EnumerationState holds the enumeration progress of the interrupted
+	// backup this one continues, and records the progress of this one.
+	// Nil if progress isn't tracked.
+	EnumerationState    *EnumerationState
 	LastBackupVersion   int
 	MetadataCollections []data.RestoreCollection
 	Options             control.Options
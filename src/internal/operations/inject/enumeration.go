@@ -0,0 +1,97 @@
+package inject
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/alcionai/clues"
+)
+
+// EnumerationState holds the enumeration progress of backup producers.  The
+// progress recorded by an interrupted backup gets saved with its checkpoints,
+// so that the next backup can continue enumerating where it left off instead
+// of starting over.  Progress is keyed by producer-defined keys, such as a
+// drive ID.
+//
+// A nil EnumerationState has no previous progress and records nothing.
+type EnumerationState struct {
+	prev map[string]json.RawMessage
+
+	mu   sync.Mutex
+	curr map[string]any
+}
+
+// NewEnumerationState returns a state that holds the progress recorded by
+// an earlier backup.
+func NewEnumerationState(prev map[string]json.RawMessage) *EnumerationState {
+	if prev == nil {
+		prev = map[string]json.RawMessage{}
+	}
+
+	return &EnumerationState{
+		prev: prev,
+		curr: map[string]any{},
+	}
+}
+
+// Previous returns the serialized progress an earlier backup recorded for
+// the key.
+func (es *EnumerationState) Previous(key string) (json.RawMessage, bool) {
+	if es == nil {
+		return nil, false
+	}
+
+	bs, ok := es.prev[key]
+
+	return bs, ok && len(bs) > 0
+}
+
+// Record stores the progress of this backup for the key, replacing anything
+// recorded for it before.  The progress must be serializable to json.
+func (es *EnumerationState) Record(key string, progress any) {
+	if es == nil {
+		return
+	}
+
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	es.curr[key] = progress
+}
+
+// Recorded returns the number of keys this backup recorded progress for.
+func (es *EnumerationState) Recorded() int {
+	if es == nil {
+		return 0
+	}
+
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	return len(es.curr)
+}
+
+// Marshal implements the streamstore.Marshaller interface.
+func (es *EnumerationState) Marshal() ([]byte, error) {
+	curr := map[string]any{}
+
+	if es != nil {
+		es.mu.Lock()
+		defer es.mu.Unlock()
+
+		curr = es.curr
+	}
+
+	bs, err := json.Marshal(curr)
+
+	return bs, clues.Stack(err).OrNil()
+}
+
+// UnmarshalEnumerationStateTo produces a func that complies with the
+// unmarshaller type in streamStore.
+func UnmarshalEnumerationStateTo(prev *map[string]json.RawMessage) func(io.ReadCloser) error {
+	return func(rc io.ReadCloser) error {
+		return json.NewDecoder(rc).Decode(prev)
+	}
+}
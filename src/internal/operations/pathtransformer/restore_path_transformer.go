@@ -142,9 +142,15 @@ func makeRestorePathsForEntry(
 	//   * OneDrive/SharePoint (needs drive information)
 	switch true {
 	case ent.Exchange != nil ||
+		ent.Directory != nil ||
+		ent.TeamsChats != nil ||
 		(ent.Groups != nil && ent.Groups.ItemType == details.GroupsChannelMessage) ||
 		(ent.Groups != nil && ent.Groups.ItemType == details.GroupsConversationPost) ||
-		(ent.SharePoint != nil && ent.SharePoint.ItemType == details.SharePointList):
+		(ent.Groups != nil && ent.Groups.ItemType == details.GroupsCalendarEvent) ||
+		(ent.Groups != nil && ent.Groups.ItemType == details.GroupsPlannerPlan) ||
+		(ent.Groups != nil && ent.Groups.ItemType == details.GroupsTeamStructure) ||
+		(ent.SharePoint != nil && ent.SharePoint.ItemType == details.SharePointList) ||
+		(ent.SharePoint != nil && ent.SharePoint.ItemType == details.SharePointSiteConfiguration):
 		// TODO(ashmrtn): Eventually make Events have it's own function to handle
 		// setting the restore destination properly.
 		res.RestorePath, err = basicLocationPath(repoRef, locRef)
@@ -42,4 +42,5 @@ type SkippedCounts struct {
 	TotalSkippedItems         int `json:"totalSkippedItems"`
 	SkippedMalware            int `json:"skippedMalware"`
 	SkippedInvalidOneNoteFile int `json:"skippedInvalidOneNoteFile"`
+	SkippedByFileFilter       int `json:"skippedByFileFilter"`
 }
@@ -16,6 +16,18 @@ const (
 	DetailsType     = "details"
 	detailsItemName = "details"
 	detailsPurpose  = "details"
+
+	SearchIndexType     = "search_index"
+	searchIndexItemName = "search_index"
+	searchIndexPurpose  = "search_index"
+
+	ContentsType     = "contents"
+	contentsItemName = "contents"
+	contentsPurpose  = "contents"
+
+	EnumerationType     = "enumeration"
+	enumerationItemName = "enumeration"
+	enumerationPurpose  = "enumeration"
 )
 
 // FaultErrorsCollector generates a collection of fault.Errors
@@ -40,6 +52,39 @@ func DetailsCollector(mr Marshaller) Collectable {
 	}
 }
 
+// SearchIndexCollector generates a collection of search.BackupIndex
+// entries containing the marshalled bytes from the provided marshaller.
+func SearchIndexCollector(mr Marshaller) Collectable {
+	return Collectable{
+		mr:       mr,
+		itemName: searchIndexItemName,
+		purpose:  searchIndexPurpose,
+		Type:     SearchIndexType,
+	}
+}
+
+// ContentsCollector generates a collection of search.Contents entries
+// containing the marshalled bytes from the provided marshaller.
+func ContentsCollector(mr Marshaller) Collectable {
+	return Collectable{
+		mr:       mr,
+		itemName: contentsItemName,
+		purpose:  contentsPurpose,
+		Type:     ContentsType,
+	}
+}
+
+// EnumerationCollector generates a collection of inject.EnumerationState
+// entries containing the marshalled bytes from the provided marshaller.
+func EnumerationCollector(mr Marshaller) Collectable {
+	return Collectable{
+		mr:       mr,
+		itemName: enumerationItemName,
+		purpose:  enumerationPurpose,
+		Type:     EnumerationType,
+	}
+}
+
 // FaultErrorsReader reads a collection of fault.Errors
 // entries using the provided unmarshaller.
 func FaultErrorsReader(unmr Unmarshaller) Collectable {
@@ -61,3 +106,36 @@ func DetailsReader(unmr Unmarshaller) Collectable {
 		Type:     DetailsType,
 	}
 }
+
+// SearchIndexReader reads a collection of search.BackupIndex
+// entries using the provided unmarshaller.
+func SearchIndexReader(unmr Unmarshaller) Collectable {
+	return Collectable{
+		Unmr:     unmr,
+		itemName: searchIndexItemName,
+		purpose:  searchIndexPurpose,
+		Type:     SearchIndexType,
+	}
+}
+
+// ContentsReader reads a collection of search.Contents entries using the
+// provided unmarshaller.
+func ContentsReader(unmr Unmarshaller) Collectable {
+	return Collectable{
+		Unmr:     unmr,
+		itemName: contentsItemName,
+		purpose:  contentsPurpose,
+		Type:     ContentsType,
+	}
+}
+
+// EnumerationReader reads a collection of inject.EnumerationState entries
+// using the provided unmarshaller.
+func EnumerationReader(unmr Unmarshaller) Collectable {
+	return Collectable{
+		Unmr:     unmr,
+		itemName: enumerationItemName,
+		purpose:  enumerationPurpose,
+		Type:     EnumerationType,
+	}
+}
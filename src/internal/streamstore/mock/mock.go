@@ -7,6 +7,7 @@ import (
 
 	"github.com/alcionai/clues"
 
+	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/internal/streamstore"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/backup/identity"
@@ -16,8 +17,9 @@ import (
 var _ streamstore.Streamer = &Streamer{}
 
 type Streamer struct {
-	Deets  map[string]*details.Details
-	Errors map[string]*fault.Errors
+	Deets        map[string]*details.Details
+	Errors       map[string]*fault.Errors
+	Enumerations map[string]*inject.EnumerationState
 }
 
 func (ms Streamer) Collect(context.Context, streamstore.Collectable) error {
@@ -42,6 +44,10 @@ func (ms Streamer) Read(
 		mr = ms.Deets[snapshotID]
 	case streamstore.FaultErrorsType:
 		mr = ms.Errors[snapshotID]
+	case streamstore.EnumerationType:
+		if es, ok := ms.Enumerations[snapshotID]; ok {
+			mr = es
+		}
 	default:
 		return clues.NewWC(ctx, "unknown type: "+col.Type)
 	}
@@ -93,7 +93,7 @@ func New(
 		skipCount = len(fe.Skipped)
 		failMsg   string
 
-		malware, invalidONFile, otherSkips int
+		malware, invalidONFile, fileFiltered, otherSkips int
 	)
 
 	if fe.Failure != nil {
@@ -107,6 +107,8 @@ func New(
 			malware++
 		case s.HasCause(fault.SkipOneNote):
 			invalidONFile++
+		case s.HasCause(fault.SkipFileFilter):
+			fileFiltered++
 		default:
 			otherSkips++
 		}
@@ -140,6 +142,7 @@ func New(
 			TotalSkippedItems:         skipCount,
 			SkippedMalware:            malware,
 			SkippedInvalidOneNoteFile: invalidONFile,
+			SkippedByFileFilter:       fileFiltered,
 		},
 	}
 }
@@ -266,7 +269,7 @@ func (b Backup) Values(skipID bool) []string {
 	if b.TotalSkippedItems > 0 {
 		status += fmt.Sprintf("%d skipped", b.TotalSkippedItems)
 
-		if b.SkippedMalware+b.SkippedInvalidOneNoteFile > 0 {
+		if b.SkippedMalware+b.SkippedInvalidOneNoteFile+b.SkippedByFileFilter > 0 {
 			status += ": "
 		}
 	}
@@ -281,6 +284,10 @@ func (b Backup) Values(skipID bool) []string {
 		skipped = append(skipped, fmt.Sprintf("%d invalid OneNote file", b.SkippedInvalidOneNoteFile))
 	}
 
+	if b.SkippedByFileFilter > 0 {
+		skipped = append(skipped, fmt.Sprintf("%d filtered", b.SkippedByFileFilter))
+	}
+
 	status += strings.Join(skipped, ", ")
 
 	if errCount+b.TotalSkippedItems > 0 {
@@ -310,6 +310,18 @@ func (suite *BackupUnitSuite) TestBackup_Values_statusVariations() {
 			},
 			expect: "test (42 errors, 1 skipped: 1 malware, 1 invalid OneNote file)",
 		},
+		{
+			name: "file filter",
+			bup: backup.Backup{
+				Status: "test",
+				SkippedCounts: stats.SkippedCounts{
+					TotalSkippedItems:   3,
+					SkippedMalware:      1,
+					SkippedByFileFilter: 2,
+				},
+			},
+			expect: "test (3 skipped: 1 malware, 2 filtered)",
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -162,18 +162,22 @@ func (suite *DetailsUnitSuite) TestDetailsEntry_HeadersValues() {
 						Modified: now,
 						WebURL:   "https://example.com/Lists/list1",
 						List: &ListInfo{
-							Name:      "list1",
-							ItemCount: 50,
-							Template:  "genericList",
+							Name:            "list1",
+							ItemCount:       50,
+							Template:        "genericList",
+							AttachmentCount: 3,
+							VersionCount:    120,
 						},
 					},
 				},
 			},
-			expectHs: []string{"ID", "List", "Items", "Created", "Modified"},
+			expectHs: []string{"ID", "List", "Items", "Attachments", "Versions", "Created", "Modified"},
 			expectVs: []string{
 				"deadbeef",
 				"list1",
 				"50",
+				"3",
+				"120",
 				nowStr,
 				nowStr,
 			},
@@ -199,11 +203,13 @@ func (suite *DetailsUnitSuite) TestDetailsEntry_HeadersValues() {
 					},
 				},
 			},
-			expectHs: []string{"ID", "List", "Items", "Created", "Modified"},
+			expectHs: []string{"ID", "List", "Items", "Attachments", "Versions", "Created", "Modified"},
 			expectVs: []string{
 				"deadbeef",
 				"Shared%20Documents",
 				"50",
+				"0",
+				"0",
 				nowStr,
 				nowStr,
 			},
@@ -0,0 +1,282 @@
+package details
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// ChangeType describes how an item differs between two backups.
+type ChangeType string
+
+const (
+	ItemAdded    ChangeType = "added"
+	ItemModified ChangeType = "modified"
+	ItemMoved    ChangeType = "moved"
+	ItemDeleted  ChangeType = "deleted"
+)
+
+var changeOrder = map[ChangeType]int{
+	ItemAdded:    0,
+	ItemModified: 1,
+	ItemMoved:    2,
+	ItemDeleted:  3,
+}
+
+// Change is a single difference between the details of two backups.
+// Deleted items hold the entry from the earlier backup, and all other
+// changes hold the entry from the later backup.
+type Change struct {
+	Change   ChangeType `json:"change"`
+	Category string     `json:"category"`
+	// PreviousLocation is the LocationRef of a moved item in the earlier
+	// backup.
+	PreviousLocation string `json:"previousLocation,omitempty"`
+	Entry
+}
+
+// Compare lists the items that were added, modified, moved, or deleted
+// between the prev and curr details.  Items are matched by their ItemRef
+// within each category.  An item that was both moved and modified produces
+// a change of each type.  Folder entries aren't compared.
+func Compare(prev, curr *Details) ([]Change, error) {
+	prevItems, err := itemsByRef(prev)
+	if err != nil {
+		return nil, clues.Wrap(err, "indexing earlier details")
+	}
+
+	currItems, err := itemsByRef(curr)
+	if err != nil {
+		return nil, clues.Wrap(err, "indexing later details")
+	}
+
+	changes := []Change{}
+
+	for key, ce := range currItems {
+		pe, ok := prevItems[key]
+		if !ok {
+			changes = append(changes, Change{Change: ItemAdded, Category: ce.category, Entry: *ce.entry})
+			continue
+		}
+
+		if modifiedBetween(*pe.entry, *ce.entry) {
+			changes = append(changes, Change{Change: ItemModified, Category: ce.category, Entry: *ce.entry})
+		}
+
+		if movedBetween(*pe.entry, *ce.entry) {
+			changes = append(changes, Change{
+				Change:           ItemMoved,
+				Category:         ce.category,
+				PreviousLocation: pe.entry.LocationRef,
+				Entry:            *ce.entry,
+			})
+		}
+	}
+
+	for key, pe := range prevItems {
+		if _, ok := currItems[key]; !ok {
+			changes = append(changes, Change{Change: ItemDeleted, Category: pe.category, Entry: *pe.entry})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		ci, cj := changes[i], changes[j]
+
+		if ci.Category != cj.Category {
+			return ci.Category < cj.Category
+		}
+
+		if ci.infoType() != cj.infoType() {
+			return ci.infoType() < cj.infoType()
+		}
+
+		if ci.Change != cj.Change {
+			return changeOrder[ci.Change] < changeOrder[cj.Change]
+		}
+
+		if ci.LocationRef != cj.LocationRef {
+			return ci.LocationRef < cj.LocationRef
+		}
+
+		return ci.RepoRef < cj.RepoRef
+	})
+
+	return changes, nil
+}
+
+type categorizedEntry struct {
+	category string
+	ref      string
+	entry    *Entry
+}
+
+// itemsByRef indexes the non-folder entries by category and ItemRef.
+// Entries from older backups that have no ItemRef use the item name from
+// their RepoRef.
+func itemsByRef(d *Details) (map[string]categorizedEntry, error) {
+	res := map[string]categorizedEntry{}
+
+	if d == nil {
+		return res, nil
+	}
+
+	for _, ent := range d.Items() {
+		rr, err := path.FromDataLayerPath(ent.RepoRef, true)
+		if err != nil {
+			return nil, clues.Wrap(err, "parsing RepoRef").With("repo_ref", ent.RepoRef)
+		}
+
+		ref := ent.ItemRef
+		if len(ref) == 0 {
+			ref = withoutMetadataSuffix(rr.Item())
+		}
+
+		cat := rr.Category().String()
+		res[cat+"/"+ref] = categorizedEntry{category: cat, ref: ref, entry: ent}
+	}
+
+	return res, nil
+}
+
+// modifiedBetween compares the item's modified time and size.
+func modifiedBetween(prev, curr Entry) bool {
+	return !prev.Modified().Equal(curr.Modified()) || prev.size() != curr.size()
+}
+
+// movedBetween compares the item's locations, if both entries record one,
+// and otherwise the containers that store the item.
+func movedBetween(prev, curr Entry) bool {
+	if len(prev.LocationRef) > 0 && len(curr.LocationRef) > 0 {
+		return prev.LocationRef != curr.LocationRef
+	}
+
+	return prev.ParentRef != curr.ParentRef
+}
+
+// ---------------------------------------------------------------------------
+// printing
+// ---------------------------------------------------------------------------
+
+// PrintChanges writes the changes to StdOut, in the format requested by the
+// caller.  Tables start with a count of each type of change per category,
+// followed by the changed items of each category.
+func PrintChanges(ctx context.Context, changes []Change) {
+	if len(changes) == 0 {
+		print.Info(ctx, "No changes found")
+		return
+	}
+
+	if print.DisplayJSONFormat() {
+		ps := make([]print.Printable, 0, len(changes))
+		for _, c := range changes {
+			ps = append(ps, print.Printable(c))
+		}
+
+		print.All(ctx, ps...)
+
+		return
+	}
+
+	print.All(ctx, summarizeChanges(changes)...)
+
+	// changes are sorted by category and item type, which groups the
+	// entries that share table headers.
+	var group []print.Printable
+
+	for i, c := range changes {
+		group = append(group, print.Printable(c))
+
+		last := i == len(changes)-1
+		if !last &&
+			changes[i+1].Category == c.Category &&
+			changes[i+1].infoType() == c.infoType() {
+			continue
+		}
+
+		print.Info(ctx, "\n"+path.ToCategoryType(c.Category).HumanString()+":")
+		print.All(ctx, group...)
+
+		group = nil
+	}
+}
+
+// MinimumPrintable reduces the Change to its minimally printable details.
+func (c Change) MinimumPrintable() any {
+	return c
+}
+
+// Headers returns the human-readable names of properties in a Change
+// for printing out to a terminal in a columnar display.
+func (c Change) Headers(skipID bool) []string {
+	return append([]string{"Change", "Previous Location"}, c.Entry.Headers(skipID)...)
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (c Change) Values(skipID bool) []string {
+	return append([]string{string(c.Change), c.PreviousLocation}, c.Entry.Values(skipID)...)
+}
+
+// ChangeCounts counts the changes of each type in a category.
+type ChangeCounts struct {
+	Category string `json:"category"`
+	Added    int    `json:"added"`
+	Modified int    `json:"modified"`
+	Moved    int    `json:"moved"`
+	Deleted  int    `json:"deleted"`
+}
+
+func summarizeChanges(changes []Change) []print.Printable {
+	var (
+		res    = []print.Printable{}
+		counts *ChangeCounts
+	)
+
+	for _, c := range changes {
+		if counts == nil || counts.Category != c.Category {
+			counts = &ChangeCounts{Category: c.Category}
+			res = append(res, counts)
+		}
+
+		switch c.Change {
+		case ItemAdded:
+			counts.Added++
+		case ItemModified:
+			counts.Modified++
+		case ItemMoved:
+			counts.Moved++
+		case ItemDeleted:
+			counts.Deleted++
+		}
+	}
+
+	return res
+}
+
+// MinimumPrintable reduces the ChangeCounts to its minimally printable details.
+func (cc *ChangeCounts) MinimumPrintable() any {
+	return cc
+}
+
+// Headers returns the human-readable names of properties in a ChangeCounts
+// for printing out to a terminal in a columnar display.
+func (cc *ChangeCounts) Headers(bool) []string {
+	return []string{"Category", "Added", "Modified", "Moved", "Deleted"}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (cc *ChangeCounts) Values(bool) []string {
+	return []string{
+		path.ToCategoryType(cc.Category).HumanString(),
+		strconv.Itoa(cc.Added),
+		strconv.Itoa(cc.Modified),
+		strconv.Itoa(cc.Moved),
+		strconv.Itoa(cc.Deleted),
+	}
+}
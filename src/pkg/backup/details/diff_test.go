@@ -0,0 +1,186 @@
+package details
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type DiffUnitSuite struct {
+	tester.Suite
+}
+
+func TestDiffUnitSuite(t *testing.T) {
+	suite.Run(t, &DiffUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func driveEntry(id, itemRef, folder string, size int64, modified time.Time) Entry {
+	parent := "tenant/onedrive/user/files/drives/d/root:/" + folder
+
+	return Entry{
+		RepoRef:     parent + "/" + id + ".data",
+		ParentRef:   parent,
+		LocationRef: "root:/" + folder,
+		ItemRef:     itemRef,
+		ItemInfo: ItemInfo{
+			OneDrive: &OneDriveInfo{
+				ItemType: OneDriveItem,
+				ItemName: id,
+				Size:     size,
+				Modified: modified,
+			},
+		},
+	}
+}
+
+func folderEntry(folder string) Entry {
+	return Entry{
+		RepoRef:     "tenant/onedrive/user/files/drives/d/root:/" + folder,
+		LocationRef: "root:/" + folder,
+		ItemInfo: ItemInfo{
+			Folder: &FolderInfo{ItemType: FolderItem, DisplayName: folder},
+		},
+	}
+}
+
+func detailsOf(ents ...Entry) *Details {
+	return &Details{DetailsModel: DetailsModel{Entries: ents}}
+}
+
+func (suite *DiffUnitSuite) TestCompare() {
+	var (
+		then = time.Now().Add(-time.Hour).UTC()
+		now  = time.Now().UTC()
+	)
+
+	type change struct {
+		change   ChangeType
+		itemRef  string
+		prevLoc  string
+		location string
+	}
+
+	table := []struct {
+		name   string
+		prev   *Details
+		curr   *Details
+		expect []change
+	}{
+		{
+			name:   "no changes",
+			prev:   detailsOf(driveEntry("a", "a", "fld", 1, then)),
+			curr:   detailsOf(driveEntry("a", "a", "fld", 1, then)),
+			expect: []change{},
+		},
+		{
+			name: "added and deleted",
+			prev: detailsOf(driveEntry("a", "a", "fld", 1, then)),
+			curr: detailsOf(driveEntry("b", "b", "fld", 1, then)),
+			expect: []change{
+				{change: ItemAdded, itemRef: "b", location: "root:/fld"},
+				{change: ItemDeleted, itemRef: "a", location: "root:/fld"},
+			},
+		},
+		{
+			name: "modified time",
+			prev: detailsOf(driveEntry("a", "a", "fld", 1, then)),
+			curr: detailsOf(driveEntry("a", "a", "fld", 1, now)),
+			expect: []change{
+				{change: ItemModified, itemRef: "a", location: "root:/fld"},
+			},
+		},
+		{
+			name: "modified size",
+			prev: detailsOf(driveEntry("a", "a", "fld", 1, then)),
+			curr: detailsOf(driveEntry("a", "a", "fld", 2, then)),
+			expect: []change{
+				{change: ItemModified, itemRef: "a", location: "root:/fld"},
+			},
+		},
+		{
+			name: "moved and modified",
+			prev: detailsOf(driveEntry("a", "a", "fld", 1, then)),
+			curr: detailsOf(driveEntry("a", "a", "other", 1, now)),
+			expect: []change{
+				{change: ItemModified, itemRef: "a", location: "root:/other"},
+				{change: ItemMoved, itemRef: "a", prevLoc: "root:/fld", location: "root:/other"},
+			},
+		},
+		{
+			name: "no ItemRef",
+			prev: detailsOf(driveEntry("a", "", "fld", 1, then)),
+			curr: detailsOf(driveEntry("a", "", "other", 1, then)),
+			expect: []change{
+				{change: ItemMoved, itemRef: "", prevLoc: "root:/fld", location: "root:/other"},
+			},
+		},
+		{
+			name:   "folders are ignored",
+			prev:   detailsOf(folderEntry("fld")),
+			curr:   detailsOf(folderEntry("other")),
+			expect: []change{},
+		},
+		{
+			name: "no earlier details",
+			curr: detailsOf(driveEntry("a", "a", "fld", 1, then)),
+			expect: []change{
+				{change: ItemAdded, itemRef: "a", location: "root:/fld"},
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			changes, err := Compare(test.prev, test.curr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			result := make([]change, 0, len(changes))
+			for _, c := range changes {
+				assert.Equal(t, "files", c.Category)
+
+				result = append(result, change{
+					change:   c.Change,
+					itemRef:  c.ItemRef,
+					prevLoc:  c.PreviousLocation,
+					location: c.LocationRef,
+				})
+			}
+
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *DiffUnitSuite) TestCompare_badRepoRef() {
+	t := suite.T()
+
+	_, err := Compare(nil, detailsOf(Entry{
+		RepoRef:  "not-a-path",
+		ItemInfo: ItemInfo{OneDrive: &OneDriveInfo{ItemType: OneDriveItem}},
+	}))
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *DiffUnitSuite) TestSummarizeChanges() {
+	t := suite.T()
+
+	changes := []Change{
+		{Change: ItemAdded, Category: "email"},
+		{Change: ItemAdded, Category: "email"},
+		{Change: ItemDeleted, Category: "email"},
+		{Change: ItemModified, Category: "files"},
+		{Change: ItemMoved, Category: "files"},
+	}
+
+	result := summarizeChanges(changes)
+	require.Len(t, result, 2)
+	assert.Equal(t, &ChangeCounts{Category: "email", Added: 2, Deleted: 1}, result[0])
+	assert.Equal(t, &ChangeCounts{Category: "files", Modified: 1, Moved: 1}, result[1])
+}
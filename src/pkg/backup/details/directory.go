@@ -0,0 +1,137 @@
+package details
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// NewDirectoryLocationIDer builds a LocationIDer for the directory.
+func NewDirectoryLocationIDer(
+	category path.CategoryType,
+	escapedFolders ...string,
+) (uniqueLoc, error) {
+	if err := path.ValidateServiceAndCategory(path.DirectoryService, category); err != nil {
+		return uniqueLoc{}, clues.Wrap(err, "making directory LocationIDer")
+	}
+
+	pb := path.Builder{}.Append(category.String()).Append(escapedFolders...)
+
+	return uniqueLoc{
+		pb:          pb,
+		prefixElems: 1,
+	}, nil
+}
+
+// DirectoryInfo describes an object within the tenant directory.
+type DirectoryInfo struct {
+	ItemType   ItemType  `json:"itemType,omitempty"`
+	Modified   time.Time `json:"modified,omitempty"`
+	ParentPath string    `json:"parentPath,omitempty"`
+	Size       int64     `json:"size,omitempty"`
+
+	Object DirectoryObjectInfo `json:"object,omitempty"`
+}
+
+type DirectoryObjectInfo struct {
+	CreatedAt   time.Time `json:"createdAt,omitempty"`
+	DisplayName string    `json:"displayName,omitempty"`
+	// Identifier is the secondary, human-readable key of the object:
+	// the principal name of users, the mail nickname of groups, the
+	// app id of applications and service principals, and the state of
+	// conditional access policies.
+	Identifier  string `json:"identifier,omitempty"`
+	MemberCount int    `json:"memberCount,omitempty"`
+}
+
+// Headers returns the human-readable names of properties in a DirectoryInfo
+// for printing out to a terminal in a columnar display.
+func (i DirectoryInfo) Headers() []string {
+	switch i.ItemType {
+	case DirectoryUser:
+		return []string{"Display Name", "Principal Name", "Created", "Modified"}
+	case DirectoryGroup:
+		return []string{"Display Name", "Mail Nickname", "Members", "Created", "Modified"}
+	case DirectoryApplication, DirectoryServicePrincipal:
+		return []string{"Display Name", "App ID", "Created", "Modified"}
+	case DirectoryConditionalAccessPolicy:
+		return []string{"Display Name", "State", "Created", "Modified"}
+	case DirectoryAdminUnit:
+		return []string{"Display Name", "Members", "Modified"}
+	}
+
+	return []string{}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (i DirectoryInfo) Values() []string {
+	switch i.ItemType {
+	case DirectoryUser,
+		DirectoryApplication,
+		DirectoryServicePrincipal,
+		DirectoryConditionalAccessPolicy:
+		return []string{
+			i.Object.DisplayName,
+			i.Object.Identifier,
+			dttm.FormatToTabularDisplay(i.Object.CreatedAt),
+			dttm.FormatToTabularDisplay(i.Modified),
+		}
+	case DirectoryGroup:
+		return []string{
+			i.Object.DisplayName,
+			i.Object.Identifier,
+			strconv.Itoa(i.Object.MemberCount),
+			dttm.FormatToTabularDisplay(i.Object.CreatedAt),
+			dttm.FormatToTabularDisplay(i.Modified),
+		}
+	case DirectoryAdminUnit:
+		return []string{
+			i.Object.DisplayName,
+			strconv.Itoa(i.Object.MemberCount),
+			dttm.FormatToTabularDisplay(i.Modified),
+		}
+	}
+
+	return []string{}
+}
+
+func (i *DirectoryInfo) UpdateParentPath(newLocPath *path.Builder) {
+	i.ParentPath = newLocPath.String()
+}
+
+func (i *DirectoryInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error) {
+	category, ok := directoryItemTypeToCategory[i.ItemType]
+	if !ok {
+		return nil, clues.New("unsupported directory ItemType").
+			With("item_type", i.ItemType)
+	}
+
+	loc, err := NewDirectoryLocationIDer(category, baseLoc.Elements()...)
+
+	return &loc, err
+}
+
+func (i *DirectoryInfo) updateFolder(f *FolderInfo) error {
+	if _, ok := directoryItemTypeToCategory[i.ItemType]; !ok {
+		return clues.New("unsupported non-Directory ItemType").
+			With("item_type", i.ItemType)
+	}
+
+	f.DataType = i.ItemType
+
+	return nil
+}
+
+var directoryItemTypeToCategory = map[ItemType]path.CategoryType{
+	DirectoryUser:                    path.DirectoryUsersCategory,
+	DirectoryGroup:                   path.DirectoryGroupsCategory,
+	DirectoryApplication:             path.ApplicationsCategory,
+	DirectoryServicePrincipal:        path.ServicePrincipalsCategory,
+	DirectoryConditionalAccessPolicy: path.ConditionalAccessCategory,
+	DirectoryAdminUnit:               path.AdminUnitsCategory,
+}
@@ -0,0 +1,122 @@
+package details_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/dttm"
+)
+
+type DirectoryUnitSuite struct {
+	tester.Suite
+}
+
+func TestDirectoryUnitSuite(t *testing.T) {
+	suite.Run(t, &DirectoryUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *DirectoryUnitSuite) TestDirectoryPrintable() {
+	now := time.Now()
+	then := now.Add(time.Minute)
+
+	table := []struct {
+		name     string
+		info     details.DirectoryInfo
+		expectHs []string
+		expectVs []string
+	}{
+		{
+			name: "user",
+			info: details.DirectoryInfo{
+				ItemType: details.DirectoryUser,
+				Modified: then,
+				Object: details.DirectoryObjectInfo{
+					CreatedAt:   now,
+					DisplayName: "Bob",
+					Identifier:  "bob@company.hr",
+				},
+			},
+			expectHs: []string{"Display Name", "Principal Name", "Created", "Modified"},
+			expectVs: []string{
+				"Bob",
+				"bob@company.hr",
+				dttm.FormatToTabularDisplay(now),
+				dttm.FormatToTabularDisplay(then),
+			},
+		},
+		{
+			name: "group",
+			info: details.DirectoryInfo{
+				ItemType: details.DirectoryGroup,
+				Modified: then,
+				Object: details.DirectoryObjectInfo{
+					CreatedAt:   now,
+					DisplayName: "Marketing",
+					Identifier:  "marketing",
+					MemberCount: 3,
+				},
+			},
+			expectHs: []string{"Display Name", "Mail Nickname", "Members", "Created", "Modified"},
+			expectVs: []string{
+				"Marketing",
+				"marketing",
+				"3",
+				dttm.FormatToTabularDisplay(now),
+				dttm.FormatToTabularDisplay(then),
+			},
+		},
+		{
+			name: "conditional access policy",
+			info: details.DirectoryInfo{
+				ItemType: details.DirectoryConditionalAccessPolicy,
+				Modified: then,
+				Object: details.DirectoryObjectInfo{
+					CreatedAt:   now,
+					DisplayName: "Require MFA",
+					Identifier:  "enabled",
+				},
+			},
+			expectHs: []string{"Display Name", "State", "Created", "Modified"},
+			expectVs: []string{
+				"Require MFA",
+				"enabled",
+				dttm.FormatToTabularDisplay(now),
+				dttm.FormatToTabularDisplay(then),
+			},
+		},
+		{
+			name: "administrative unit",
+			info: details.DirectoryInfo{
+				ItemType: details.DirectoryAdminUnit,
+				Modified: then,
+				Object: details.DirectoryObjectInfo{
+					DisplayName: "Seattle",
+					MemberCount: 12,
+				},
+			},
+			expectHs: []string{"Display Name", "Members", "Modified"},
+			expectVs: []string{
+				"Seattle",
+				"12",
+				dttm.FormatToTabularDisplay(then),
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			hs := test.info.Headers()
+			vs := test.info.Values()
+
+			assert.Equal(t, len(hs), len(vs))
+			assert.Equal(t, test.expectHs, hs)
+			assert.Equal(t, test.expectVs, vs)
+		})
+	}
+}
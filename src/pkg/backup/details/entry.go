@@ -163,6 +163,10 @@ func (de Entry) Headers(skipID bool) []string {
 		hs = de.ItemInfo.Groups.Headers()
 	}
 
+	if de.ItemInfo.Directory != nil {
+		hs = de.ItemInfo.Directory.Headers()
+	}
+
 	if skipID {
 		return hs
 	}
@@ -194,6 +198,10 @@ func (de Entry) Values(skipID bool) []string {
 		vs = de.ItemInfo.Groups.Values()
 	}
 
+	if de.ItemInfo.Directory != nil {
+		vs = de.ItemInfo.Directory.Values()
+	}
+
 	if skipID {
 		return vs
 	}
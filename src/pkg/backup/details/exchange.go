@@ -45,6 +45,9 @@ type ExchangeInfo struct {
 	Created     time.Time `json:"created,omitempty"`
 	Modified    time.Time `json:"modified,omitempty"`
 	Size        int64     `json:"size,omitempty"`
+	// WellKnownFolder names the well-known mail folder (see
+	// control.WellKnownMailFolders) that holds the email, if any.
+	WellKnownFolder string `json:"wellKnownFolder,omitempty"`
 }
 
 // Headers returns the human-readable names of properties in an ExchangeInfo
@@ -48,6 +48,15 @@ type GroupsInfo struct {
 	// Conversations Specific
 	Post ConversationPostInfo `json:"post,omitempty"`
 
+	// Planner Specific
+	Plan PlannerPlanInfo `json:"plan,omitempty"`
+
+	// Team Structure Specific
+	Team TeamStructureInfo `json:"team,omitempty"`
+
+	// Calendar Specific
+	Event CalendarEventInfo `json:"event,omitempty"`
+
 	// SharePoint specific
 	Created    time.Time `json:"created,omitempty"`
 	DriveName  string    `json:"driveName,omitempty"`
@@ -58,6 +67,9 @@ type GroupsInfo struct {
 	SiteID     string    `json:"siteID,omitempty"`
 	Size       int64     `json:"size,omitempty"`
 	WebURL     string    `json:"webURL,omitempty"`
+	// Versions lists the prior versions of a library file that were backed
+	// up alongside its current content.
+	Versions []DriveItemVersion `json:"versions,omitempty"`
 }
 
 type ConversationPostInfo struct {
@@ -69,6 +81,31 @@ type ConversationPostInfo struct {
 	Topic      string    `json:"topic,omitempty"`
 }
 
+type PlannerPlanInfo struct {
+	BucketCount int       `json:"bucketCount"`
+	CreatedAt   time.Time `json:"createdAt,omitempty"`
+	Creator     string    `json:"creator,omitempty"`
+	TaskCount   int       `json:"taskCount"`
+	Title       string    `json:"title,omitempty"`
+}
+
+type TeamStructureInfo struct {
+	AppCount     int    `json:"appCount"`
+	ChannelCount int    `json:"channelCount"`
+	DisplayName  string `json:"displayName,omitempty"`
+	TabCount     int    `json:"tabCount"`
+}
+
+// CalendarEventInfo holds the same event properties that are
+// recorded for exchange events.
+type CalendarEventInfo struct {
+	EventEnd    time.Time `json:"eventEnd,omitempty"`
+	EventRecurs bool      `json:"eventRecurs,omitempty"`
+	EventStart  time.Time `json:"eventStart,omitempty"`
+	Organizer   string    `json:"organizer,omitempty"`
+	Subject     string    `json:"subject,omitempty"`
+}
+
 type ChannelMessageInfo struct {
 	AttachmentNames []string  `json:"attachmentNames,omitempty"`
 	CreatedAt       time.Time `json:"createdAt,omitempty"`
@@ -89,6 +126,12 @@ func (i GroupsInfo) Headers() []string {
 		return []string{"Message", "Channel", "Subject", "Replies", "Creator", "Created", "Last Reply"}
 	case GroupsConversationPost:
 		return []string{"Post", "Conversation", "Sender", "Created"}
+	case GroupsPlannerPlan:
+		return []string{"Plan", "Buckets", "Tasks", "Creator", "Created"}
+	case GroupsTeamStructure:
+		return []string{"Team", "Channels", "Tabs", "Apps"}
+	case GroupsCalendarEvent:
+		return []string{"Organizer", "Subject", "Starts", "Ends", "Recurring"}
 	}
 
 	return []string{}
@@ -132,6 +175,29 @@ func (i GroupsInfo) Values() []string {
 			i.Post.Creator,
 			dttm.FormatToTabularDisplay(i.Post.CreatedAt),
 		}
+	case GroupsPlannerPlan:
+		return []string{
+			i.Plan.Title,
+			strconv.Itoa(i.Plan.BucketCount),
+			strconv.Itoa(i.Plan.TaskCount),
+			i.Plan.Creator,
+			dttm.FormatToTabularDisplay(i.Plan.CreatedAt),
+		}
+	case GroupsTeamStructure:
+		return []string{
+			i.Team.DisplayName,
+			strconv.Itoa(i.Team.ChannelCount),
+			strconv.Itoa(i.Team.TabCount),
+			strconv.Itoa(i.Team.AppCount),
+		}
+	case GroupsCalendarEvent:
+		return []string{
+			i.Event.Organizer,
+			i.Event.Subject,
+			dttm.FormatToTabularDisplay(i.Event.EventStart),
+			dttm.FormatToTabularDisplay(i.Event.EventEnd),
+			strconv.FormatBool(i.Event.EventRecurs),
+		}
 	}
 
 	return []string{}
@@ -158,6 +224,12 @@ func (i *GroupsInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error) {
 		loc, err = NewGroupsLocationIDer(path.ChannelMessagesCategory, "", baseLoc.Elements()...)
 	case GroupsConversationPost:
 		loc, err = NewGroupsLocationIDer(path.ConversationPostsCategory, "", baseLoc.Elements()...)
+	case GroupsPlannerPlan:
+		loc, err = NewGroupsLocationIDer(path.PlannerCategory, "", baseLoc.Elements()...)
+	case GroupsTeamStructure:
+		loc, err = NewGroupsLocationIDer(path.TeamStructureCategory, "", baseLoc.Elements()...)
+	case GroupsCalendarEvent:
+		loc, err = NewGroupsLocationIDer(path.EventsCategory, "", baseLoc.Elements()...)
 	}
 
 	return &loc, err
@@ -169,7 +241,7 @@ func (i *GroupsInfo) updateFolder(f *FolderInfo) error {
 	switch i.ItemType {
 	case SharePointLibrary:
 		return updateFolderWithinDrive(SharePointLibrary, i.DriveName, i.DriveID, f)
-	case GroupsChannelMessage, GroupsConversationPost:
+	case GroupsChannelMessage, GroupsConversationPost, GroupsPlannerPlan, GroupsTeamStructure, GroupsCalendarEvent:
 		return nil
 	}
 
@@ -76,6 +76,62 @@ func (suite *GroupsUnitSuite) TestGroupsPrintable() {
 				dttm.FormatToTabularDisplay(now),
 			},
 		},
+		{
+			name: "planner plan",
+			info: details.GroupsInfo{
+				ItemType: details.GroupsPlannerPlan,
+				Plan: details.PlannerPlanInfo{
+					Title:       "plan",
+					BucketCount: 2,
+					TaskCount:   5,
+					Creator:     "creator",
+					CreatedAt:   now,
+				},
+			},
+			expectHs: []string{"Plan", "Buckets", "Tasks", "Creator", "Created"},
+			expectVs: []string{
+				"plan",
+				"2",
+				"5",
+				"creator",
+				dttm.FormatToTabularDisplay(now),
+			},
+		},
+		{
+			name: "team structure",
+			info: details.GroupsInfo{
+				ItemType: details.GroupsTeamStructure,
+				Team: details.TeamStructureInfo{
+					DisplayName:  "team",
+					ChannelCount: 3,
+					TabCount:     4,
+					AppCount:     2,
+				},
+			},
+			expectHs: []string{"Team", "Channels", "Tabs", "Apps"},
+			expectVs: []string{"team", "3", "4", "2"},
+		},
+		{
+			name: "calendar event",
+			info: details.GroupsInfo{
+				ItemType: details.GroupsCalendarEvent,
+				Event: details.CalendarEventInfo{
+					Organizer:   "organizer",
+					Subject:     "subject",
+					EventStart:  now,
+					EventEnd:    then,
+					EventRecurs: true,
+				},
+			},
+			expectHs: []string{"Organizer", "Subject", "Starts", "Ends", "Recurring"},
+			expectVs: []string{
+				"organizer",
+				"subject",
+				dttm.FormatToTabularDisplay(now),
+				dttm.FormatToTabularDisplay(then),
+				"true",
+			},
+		},
 		{
 			name: "sharepoint library",
 			info: details.GroupsInfo{
@@ -0,0 +1,196 @@
+package details
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// BackupDetails holds the details of a single backup.
+type BackupDetails struct {
+	BackupID  string
+	CreatedAt time.Time
+	Details   *Details
+}
+
+// ItemVersion is a distinct version of an item.  The entry is taken from
+// the first backup that holds the version.
+type ItemVersion struct {
+	Version         int       `json:"version"`
+	BackupID        string    `json:"backupID"`
+	BackupCreatedAt time.Time `json:"backupCreatedAt"`
+	// Backups counts the backups that hold this version of the item.
+	Backups int `json:"backups"`
+	Entry
+}
+
+// ItemHistory lists the distinct versions of an item, oldest first.
+type ItemHistory struct {
+	ItemRef  string        `json:"itemRef"`
+	Category string        `json:"category"`
+	Versions []ItemVersion `json:"versions"`
+}
+
+// Latest returns the most recent version of the item.
+func (ih ItemHistory) Latest() ItemVersion {
+	return ih.Versions[len(ih.Versions)-1]
+}
+
+// History groups the items in the details of several backups by their
+// ItemRef within each category, and lists the distinct versions of each
+// item.  A backup holds a new version of an item when the item's modified
+// time or size differs from the version in the preceding backups.  Moving
+// an item doesn't produce a new version.  Folder entries are ignored.
+func History(bds []BackupDetails) ([]ItemHistory, error) {
+	bds = append([]BackupDetails{}, bds...)
+
+	sort.SliceStable(bds, func(i, j int) bool {
+		return bds[i].CreatedAt.Before(bds[j].CreatedAt)
+	})
+
+	var (
+		res   = []ItemHistory{}
+		byKey = map[string]int{}
+	)
+
+	for _, bd := range bds {
+		items, err := itemsByRef(bd.Details)
+		if err != nil {
+			return nil, clues.Wrap(err, "indexing details").With("backup_id", bd.BackupID)
+		}
+
+		for key, ce := range items {
+			iv := ItemVersion{
+				BackupID:        bd.BackupID,
+				BackupCreatedAt: bd.CreatedAt,
+				Backups:         1,
+				Entry:           *ce.entry,
+			}
+
+			idx, ok := byKey[key]
+			if !ok {
+				iv.Version = 1
+				byKey[key] = len(res)
+				res = append(res, ItemHistory{
+					ItemRef:  ce.ref,
+					Category: ce.category,
+					Versions: []ItemVersion{iv},
+				})
+
+				continue
+			}
+
+			ih := &res[idx]
+			last := &ih.Versions[len(ih.Versions)-1]
+
+			if !modifiedBetween(last.Entry, *ce.entry) {
+				last.Backups++
+				continue
+			}
+
+			iv.Version = last.Version + 1
+			ih.Versions = append(ih.Versions, iv)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		hi, hj := res[i], res[j]
+
+		if hi.Category != hj.Category {
+			return hi.Category < hj.Category
+		}
+
+		li, lj := hi.Latest(), hj.Latest()
+
+		if li.LocationRef != lj.LocationRef {
+			return li.LocationRef < lj.LocationRef
+		}
+
+		return hi.ItemRef < hj.ItemRef
+	})
+
+	return res, nil
+}
+
+// ---------------------------------------------------------------------------
+// printing
+// ---------------------------------------------------------------------------
+
+// PrintHistory writes the item histories to StdOut, in the format requested
+// by the caller.  Tables list the versions of each item separately.
+func PrintHistory(ctx context.Context, hs []ItemHistory) {
+	if print.DisplayJSONFormat() {
+		ps := make([]print.Printable, 0, len(hs))
+		for _, h := range hs {
+			ps = append(ps, print.Printable(h))
+		}
+
+		print.All(ctx, ps...)
+
+		return
+	}
+
+	for _, h := range hs {
+		ps := make([]print.Printable, 0, len(h.Versions))
+		for _, v := range h.Versions {
+			ps = append(ps, print.Printable(v))
+		}
+
+		print.Info(ctx, "\n"+path.ToCategoryType(h.Category).HumanString()+" item "+h.ItemRef+":")
+		print.All(ctx, ps...)
+	}
+}
+
+// MinimumPrintable reduces the ItemHistory to its minimally printable details.
+func (ih ItemHistory) MinimumPrintable() any {
+	return ih
+}
+
+// Headers returns the human-readable names of properties in an ItemHistory
+// for printing out to a terminal in a columnar display.
+func (ih ItemHistory) Headers(bool) []string {
+	return []string{"Category", "Item", "Versions"}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (ih ItemHistory) Values(bool) []string {
+	return []string{
+		path.ToCategoryType(ih.Category).HumanString(),
+		ih.ItemRef,
+		strconv.Itoa(len(ih.Versions)),
+	}
+}
+
+// MinimumPrintable reduces the ItemVersion to its minimally printable details.
+func (iv ItemVersion) MinimumPrintable() any {
+	return iv
+}
+
+// Headers returns the human-readable names of properties in an ItemVersion
+// for printing out to a terminal in a columnar display.
+func (iv ItemVersion) Headers(skipID bool) []string {
+	return append(
+		[]string{"Version", "Backup ID", "Backup Time", "Backups"},
+		iv.Entry.Headers(skipID)...)
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (iv ItemVersion) Values(skipID bool) []string {
+	return append(
+		[]string{
+			strconv.Itoa(iv.Version),
+			iv.BackupID,
+			dttm.FormatToTabularDisplay(iv.BackupCreatedAt),
+			strconv.Itoa(iv.Backups),
+		},
+		iv.Entry.Values(skipID)...)
+}
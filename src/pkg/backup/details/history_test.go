@@ -0,0 +1,134 @@
+package details
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type HistoryUnitSuite struct {
+	tester.Suite
+}
+
+func TestHistoryUnitSuite(t *testing.T) {
+	suite.Run(t, &HistoryUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *HistoryUnitSuite) TestHistory() {
+	var (
+		t1 = time.Now().Add(-3 * time.Hour).UTC()
+		t2 = time.Now().Add(-2 * time.Hour).UTC()
+		t3 = time.Now().Add(-time.Hour).UTC()
+	)
+
+	type version struct {
+		version  int
+		backupID string
+		backups  int
+	}
+
+	table := []struct {
+		name   string
+		bds    []BackupDetails
+		expect map[string][]version
+	}{
+		{
+			name: "unchanged",
+			bds: []BackupDetails{
+				{BackupID: "b1", CreatedAt: t1, Details: detailsOf(driveEntry("a", "a", "fld", 1, t1))},
+				{BackupID: "b2", CreatedAt: t2, Details: detailsOf(driveEntry("a", "a", "fld", 1, t1))},
+			},
+			expect: map[string][]version{
+				"a": {{version: 1, backupID: "b1", backups: 2}},
+			},
+		},
+		{
+			name: "modified",
+			bds: []BackupDetails{
+				{BackupID: "b1", CreatedAt: t1, Details: detailsOf(driveEntry("a", "a", "fld", 1, t1))},
+				{BackupID: "b2", CreatedAt: t2, Details: detailsOf(driveEntry("a", "a", "fld", 2, t2))},
+				{BackupID: "b3", CreatedAt: t3, Details: detailsOf(driveEntry("a", "a", "fld", 2, t2))},
+			},
+			expect: map[string][]version{
+				"a": {
+					{version: 1, backupID: "b1", backups: 1},
+					{version: 2, backupID: "b2", backups: 2},
+				},
+			},
+		},
+		{
+			name: "moved",
+			bds: []BackupDetails{
+				{BackupID: "b1", CreatedAt: t1, Details: detailsOf(driveEntry("a", "a", "fld", 1, t1))},
+				{BackupID: "b2", CreatedAt: t2, Details: detailsOf(driveEntry("a", "a", "other", 1, t1))},
+			},
+			expect: map[string][]version{
+				"a": {{version: 1, backupID: "b1", backups: 2}},
+			},
+		},
+		{
+			name: "sorted by backup time",
+			bds: []BackupDetails{
+				{BackupID: "b2", CreatedAt: t2, Details: detailsOf(driveEntry("a", "a", "fld", 2, t2))},
+				{BackupID: "b1", CreatedAt: t1, Details: detailsOf(driveEntry("a", "a", "fld", 1, t1))},
+			},
+			expect: map[string][]version{
+				"a": {
+					{version: 1, backupID: "b1", backups: 1},
+					{version: 2, backupID: "b2", backups: 1},
+				},
+			},
+		},
+		{
+			name: "several items",
+			bds: []BackupDetails{
+				{
+					BackupID:  "b1",
+					CreatedAt: t1,
+					Details: detailsOf(
+						driveEntry("a", "a", "fld", 1, t1),
+						driveEntry("b", "b", "fld", 1, t1),
+						folderEntry("fld")),
+				},
+				{BackupID: "b2", CreatedAt: t2, Details: detailsOf(driveEntry("b", "b", "fld", 3, t2))},
+			},
+			expect: map[string][]version{
+				"a": {{version: 1, backupID: "b1", backups: 1}},
+				"b": {
+					{version: 1, backupID: "b1", backups: 1},
+					{version: 2, backupID: "b2", backups: 1},
+				},
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			hs, err := History(test.bds)
+			require.NoError(t, err, clues.ToCore(err))
+
+			result := map[string][]version{}
+
+			for _, h := range hs {
+				assert.Equal(t, "files", h.Category)
+
+				for _, v := range h.Versions {
+					result[h.ItemRef] = append(result[h.ItemRef], version{
+						version:  v.Version,
+						backupID: v.BackupID,
+						backups:  v.Backups,
+					})
+				}
+			}
+
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
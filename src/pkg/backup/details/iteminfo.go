@@ -31,6 +31,9 @@ const (
 	SharePointLibrary ItemType = 101 // also used for groups
 	SharePointList    ItemType = 102
 	SharePointPage    ItemType = 103
+	// SharePointSiteConfiguration holds the site's columns, content types,
+	// permissions, and settings.
+	SharePointSiteConfiguration ItemType = 104
 
 	// OneDrive (20x)
 	OneDriveItem ItemType = 205
@@ -41,9 +44,20 @@ const (
 	// Groups/Teams(40x)
 	GroupsChannelMessage   ItemType = 401
 	GroupsConversationPost ItemType = 402
+	GroupsPlannerPlan      ItemType = 403
+	GroupsTeamStructure    ItemType = 404
+	GroupsCalendarEvent    ItemType = 405
 
 	// Teams Chat
 	TeamsChat ItemType = 501
+
+	// Directory (60x)
+	DirectoryUser                    ItemType = 601
+	DirectoryGroup                   ItemType = 602
+	DirectoryApplication             ItemType = 603
+	DirectoryServicePrincipal        ItemType = 604
+	DirectoryConditionalAccessPolicy ItemType = 605
+	DirectoryAdminUnit               ItemType = 606
 )
 
 func UpdateItem(item *ItemInfo, newLocPath *path.Builder) {
@@ -65,6 +79,8 @@ func UpdateItem(item *ItemInfo, newLocPath *path.Builder) {
 		item.OneDrive.UpdateParentPath(newLocPath)
 	} else if item.Groups != nil {
 		item.Groups.UpdateParentPath(newLocPath)
+	} else if item.Directory != nil {
+		item.Directory.UpdateParentPath(newLocPath)
 	}
 }
 
@@ -77,6 +93,7 @@ type ItemInfo struct {
 	OneDrive   *OneDriveInfo   `json:"oneDrive,omitempty"`
 	Groups     *GroupsInfo     `json:"groups,omitempty"`
 	TeamsChats *TeamsChatsInfo `json:"teamsChats,omitempty"`
+	Directory  *DirectoryInfo  `json:"directory,omitempty"`
 	// Optional item extension data
 	Extension *ExtensionData `json:"extension,omitempty"`
 }
@@ -106,6 +123,9 @@ func (i ItemInfo) infoType() ItemType {
 
 	case i.TeamsChats != nil:
 		return i.TeamsChats.ItemType
+
+	case i.Directory != nil:
+		return i.Directory.ItemType
 	}
 
 	return UnknownType
@@ -129,7 +149,10 @@ func (i ItemInfo) size() int64 {
 		return i.Folder.Size
 
 	case i.TeamsChats != nil:
-		return int64(i.TeamsChats.Chat.MessageCount)
+		return i.TeamsChats.Size
+
+	case i.Directory != nil:
+		return i.Directory.Size
 	}
 
 	return 0
@@ -154,6 +177,9 @@ func (i ItemInfo) Modified() time.Time {
 
 	case i.TeamsChats != nil:
 		return i.TeamsChats.Modified
+
+	case i.Directory != nil:
+		return i.Directory.Modified
 	}
 
 	return time.Time{}
@@ -176,6 +202,9 @@ func (i ItemInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error) {
 	case i.TeamsChats != nil:
 		return i.TeamsChats.uniqueLocation(baseLoc)
 
+	case i.Directory != nil:
+		return i.Directory.uniqueLocation(baseLoc)
+
 	default:
 		return nil, clues.New("unsupported type")
 	}
@@ -198,6 +227,9 @@ func (i ItemInfo) updateFolder(f *FolderInfo) error {
 	case i.TeamsChats != nil:
 		return i.TeamsChats.updateFolder(f)
 
+	case i.Directory != nil:
+		return i.Directory.updateFolder(f)
+
 	default:
 		return clues.New("unsupported type")
 	}
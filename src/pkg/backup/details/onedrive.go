@@ -38,6 +38,17 @@ type OneDriveInfo struct {
 	Owner      string    `json:"owner,omitempty"`
 	ParentPath string    `json:"parentPath"`
 	Size       int64     `json:"size,omitempty"`
+	// Versions lists the prior versions of the file that were backed up
+	// alongside its current content.
+	Versions []DriveItemVersion `json:"versions,omitempty"`
+}
+
+// DriveItemVersion describes a prior version of a drive file.
+type DriveItemVersion struct {
+	ID         string    `json:"id"`
+	Modified   time.Time `json:"modified,omitempty"`
+	ModifiedBy string    `json:"modifiedBy,omitempty"`
+	Size       int64     `json:"size,omitempty"`
 }
 
 // Headers returns the human-readable names of properties in a OneDriveInfo
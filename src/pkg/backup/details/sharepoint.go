@@ -49,12 +49,39 @@ type SharePointInfo struct {
 	WebURL     string    `json:"webUrl,omitempty"`
 	SiteID     string    `json:"siteID,omitempty"`
 	List       *ListInfo `json:"list,omitempty"`
+	// SiteConfiguration summarizes the configuration of the site when the
+	// item is the site's configuration.
+	SiteConfiguration *SiteConfigurationInfo `json:"siteConfiguration,omitempty"`
+	// Versions lists the prior versions of a library file that were backed
+	// up alongside its current content.
+	Versions []DriveItemVersion `json:"versions,omitempty"`
 }
 
 type ListInfo struct {
 	Name      string `json:"name,omitempty"`
 	ItemCount int64  `json:"itemCount,omitempty"`
 	Template  string `json:"template,omitempty"`
+	// AttachmentCount is the number of files attached across all list items.
+	AttachmentCount int64 `json:"attachmentCount,omitempty"`
+	// VersionCount is the number of list item versions, if the backup
+	// included the version history of the list items.
+	VersionCount int64 `json:"versionCount,omitempty"`
+}
+
+type SiteConfigurationInfo struct {
+	ColumnCount      int `json:"columnCount"`
+	ContentTypeCount int `json:"contentTypeCount"`
+	PermissionCount  int `json:"permissionCount"`
+	// TermCount is the number of managed metadata terms in the term
+	// store, across all of its groups and sets.
+	TermCount int `json:"termCount,omitempty"`
+	// NavigationNodeCount is the number of links in the site's quick launch
+	// and top navigation, including nested links.
+	NavigationNodeCount int `json:"navigationNodeCount,omitempty"`
+	// GroupCount is the number of SharePoint permission groups.
+	GroupCount int `json:"groupCount,omitempty"`
+	// FeatureCount is the number of features activated in the site.
+	FeatureCount int `json:"featureCount,omitempty"`
 }
 
 // Headers returns the human-readable names of properties in a SharePointInfo
@@ -64,7 +91,9 @@ func (i SharePointInfo) Headers() []string {
 	case SharePointLibrary:
 		return []string{"ItemName", "Library", "ParentPath", "Size", "Owner", "Created", "Modified"}
 	case SharePointList:
-		return []string{"List", "Items", "Created", "Modified"}
+		return []string{"List", "Items", "Attachments", "Versions", "Created", "Modified"}
+	case SharePointSiteConfiguration:
+		return []string{"Site", "Columns", "Content Types", "Permissions", "Terms", "Groups", "Features", "Modified"}
 	}
 
 	return []string{}
@@ -88,9 +117,27 @@ func (i SharePointInfo) Values() []string {
 		return []string{
 			i.List.Name,
 			fmt.Sprintf("%d", i.List.ItemCount),
+			fmt.Sprintf("%d", i.List.AttachmentCount),
+			fmt.Sprintf("%d", i.List.VersionCount),
 			dttm.FormatToTabularDisplay(i.Created),
 			dttm.FormatToTabularDisplay(i.Modified),
 		}
+	case SharePointSiteConfiguration:
+		cfg := i.SiteConfiguration
+		if cfg == nil {
+			cfg = &SiteConfigurationInfo{}
+		}
+
+		return []string{
+			i.ItemName,
+			fmt.Sprintf("%d", cfg.ColumnCount),
+			fmt.Sprintf("%d", cfg.ContentTypeCount),
+			fmt.Sprintf("%d", cfg.PermissionCount),
+			fmt.Sprintf("%d", cfg.TermCount),
+			fmt.Sprintf("%d", cfg.GroupCount),
+			fmt.Sprintf("%d", cfg.FeatureCount),
+			dttm.FormatToTabularDisplay(i.Modified),
+		}
 	}
 
 	return []string{}
@@ -108,6 +155,8 @@ func (i *SharePointInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, erro
 		loc = NewSharePointLocationIDer(path.LibrariesCategory, i.DriveID, baseLoc.Elements()...)
 	case SharePointList:
 		loc = NewSharePointLocationIDer(path.ListsCategory, "", baseLoc.Elements()...)
+	case SharePointSiteConfiguration:
+		loc = NewSharePointLocationIDer(path.SiteConfigurationCategory, "", baseLoc.Elements()...)
 	}
 
 	return &loc, nil
@@ -119,7 +168,7 @@ func (i *SharePointInfo) updateFolder(f *FolderInfo) error {
 	switch i.ItemType {
 	case OneDriveItem, SharePointLibrary:
 		return updateFolderWithinDrive(SharePointLibrary, i.DriveName, i.DriveID, f)
-	case SharePointList:
+	case SharePointList, SharePointSiteConfiguration:
 		return nil
 	}
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/alcionai/clues"
+	"github.com/dustin/go-humanize"
 
 	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -33,6 +34,9 @@ type TeamsChatsInfo struct {
 	ItemType   ItemType  `json:"itemType,omitempty"`
 	Modified   time.Time `json:"modified,omitempty"`
 	ParentPath string    `json:"parentPath,omitempty"`
+	// Size counts the content of every message in the chat, including
+	// their hosted contents and stored file attachments.
+	Size int64 `json:"size,omitempty"`
 
 	Chat ChatInfo `json:"chat,omitempty"`
 }
@@ -52,7 +56,7 @@ type ChatInfo struct {
 func (i TeamsChatsInfo) Headers() []string {
 	switch i.ItemType {
 	case TeamsChat:
-		return []string{"Name", "Last message", "Last message at", "Message count", "Created", "Members"}
+		return []string{"Name", "Last message", "Last message at", "Message count", "Size", "Created", "Members"}
 	}
 
 	return []string{}
@@ -79,6 +83,7 @@ func (i TeamsChatsInfo) Values() []string {
 			i.Chat.LastMessagePreview,
 			dttm.FormatToTabularDisplay(i.Chat.LastMessageAt),
 			strconv.Itoa(i.Chat.MessageCount),
+			humanize.Bytes(uint64(i.Size)),
 			dttm.FormatToTabularDisplay(i.Chat.CreatedAt),
 			members,
 		}
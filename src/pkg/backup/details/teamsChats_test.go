@@ -35,6 +35,7 @@ func (suite *ChatsUnitSuite) TestChatsPrintable() {
 			info: details.TeamsChatsInfo{
 				ItemType:   details.TeamsChat,
 				ParentPath: "parentpath",
+				Size:       2048,
 				Chat: details.ChatInfo{
 					CreatedAt:          now,
 					HasExternalMembers: true,
@@ -45,12 +46,13 @@ func (suite *ChatsUnitSuite) TestChatsPrintable() {
 					Name:               "chat name",
 				},
 			},
-			expectHs: []string{"Name", "Last message", "Last message at", "Message count", "Created", "Members"},
+			expectHs: []string{"Name", "Last message", "Last message at", "Message count", "Size", "Created", "Members"},
 			expectVs: []string{
 				"chat name",
 				"last message preview",
 				dttm.FormatToTabularDisplay(then),
 				"42",
+				"2.0 kB",
 				dttm.FormatToTabularDisplay(now),
 				"foo@bar.baz, and 1 more",
 			},
@@ -0,0 +1,394 @@
+package details
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/dustin/go-humanize"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/internal/common/str"
+	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// FolderNode is a folder in the hierarchy of a backup's details.  Like du,
+// the size and item count of a folder include the items in all of its
+// subfolders.
+type FolderNode struct {
+	Name string
+	// Path holds the names of the folders from the root down to, and
+	// including, this folder.  The root has an empty path.
+	Path      []string
+	Modified  time.Time
+	Size      int64
+	ItemCount int
+	// Folders and Items hold the direct children of the folder, sorted
+	// by name.
+	Folders []*FolderNode
+	Items   []*Entry
+
+	children map[string]*FolderNode
+}
+
+func newFolderNode(elems []string) *FolderNode {
+	var name string
+	if len(elems) > 0 {
+		name = elems[len(elems)-1]
+	}
+
+	return &FolderNode{
+		Name:     name,
+		Path:     elems,
+		children: map[string]*FolderNode{},
+	}
+}
+
+// child returns the named subfolder, adding it if it doesn't exist.
+func (n *FolderNode) child(name string) *FolderNode {
+	c, ok := n.children[name]
+	if !ok {
+		elems := append(append([]string{}, n.Path...), name)
+		c = newFolderNode(elems)
+		n.children[name] = c
+		n.Folders = append(n.Folders, c)
+	}
+
+	return c
+}
+
+func (n *FolderNode) addItem(ent *Entry) {
+	n.Size += ent.size()
+	n.ItemCount++
+	n.updateModified(ent.Modified())
+}
+
+func (n *FolderNode) updateModified(mod time.Time) {
+	if n.Modified.Before(mod) {
+		n.Modified = mod
+	}
+}
+
+func (n *FolderNode) sort() {
+	sort.Slice(n.Folders, func(i, j int) bool {
+		return n.Folders[i].Name < n.Folders[j].Name
+	})
+
+	sort.SliceStable(n.Items, func(i, j int) bool {
+		return n.Items[i].displayName() < n.Items[j].displayName()
+	})
+
+	for _, f := range n.Folders {
+		f.sort()
+	}
+}
+
+// Find returns the folder at the path below this folder.  An empty path
+// returns the folder itself.
+func (n *FolderNode) Find(elems []string) (*FolderNode, error) {
+	curr := n
+
+	for i, e := range elems {
+		c, ok := curr.children[e]
+		if !ok {
+			return nil, clues.New("No folder exists at " + FolderPathString(elems[:i+1]))
+		}
+
+		curr = c
+	}
+
+	return curr, nil
+}
+
+// Walk calls fn for the folder and each of its subfolders, depth first.
+// Subfolders more than maxDepth levels below the folder are skipped; a
+// maxDepth less than zero walks every subfolder.
+func (n *FolderNode) Walk(maxDepth int, fn func(*FolderNode)) {
+	n.walk(0, maxDepth, fn)
+}
+
+func (n *FolderNode) walk(depth, maxDepth int, fn func(*FolderNode)) {
+	fn(n)
+
+	if maxDepth >= 0 && depth >= maxDepth {
+		return
+	}
+
+	for _, f := range n.Folders {
+		f.walk(depth+1, maxDepth, fn)
+	}
+}
+
+// Tree arranges the entries in the details into their folder hierarchy.
+// Folders come from the details' folder entries, and items are placed by
+// their LocationRef.  Drive contents are placed at the top of the tree,
+// without the drive root folder, which matches the paths accepted by the
+// restore --folder flags.  As with those flags, folders that share a path
+// across drives or data categories are merged.
+func Tree(d *Details) (*FolderNode, error) {
+	root := newFolderNode(nil)
+
+	for i := range d.Entries {
+		ent := &d.Entries[i]
+
+		if ent.isMetaFile() {
+			continue
+		}
+
+		elems, err := locationElements(ent.LocationRef)
+		if err != nil {
+			return nil, clues.Wrap(err, "parsing entry location").
+				With("location_ref", ent.LocationRef)
+		}
+
+		if ent.Folder != nil {
+			elems = normalizeFolderPath(append(elems, ent.Folder.DisplayName))
+
+			n := root
+			for _, e := range elems {
+				n = n.child(e)
+			}
+
+			n.updateModified(ent.Folder.Modified)
+
+			continue
+		}
+
+		n := root
+		n.addItem(ent)
+
+		for _, e := range elems {
+			n = n.child(e)
+			n.addItem(ent)
+		}
+
+		n.Items = append(n.Items, ent)
+	}
+
+	root.sort()
+
+	return root, nil
+}
+
+// locationElements returns the folder names in the LocationRef, without
+// the drive root folder.
+func locationElements(locRef string) ([]string, error) {
+	pb, err := path.Builder{}.SplitUnescapeAppend(locRef)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	return normalizeFolderPath(pb.Elements()), nil
+}
+
+func normalizeFolderPath(elems []string) []string {
+	if len(elems) > 0 && elems[0] == odConsts.RootPathDir {
+		return elems[1:]
+	}
+
+	return elems
+}
+
+// SplitFolderPath splits a folder path into the names of its folders.  It
+// accepts the same syntax as the restore --folder flags: folder names are
+// separated by '/', a '/' within a name is escaped as '\/', and leading and
+// trailing separators are ignored.  "/" is the root of the backup.
+func SplitFolderPath(p string) ([]string, error) {
+	elems, err := locationElements(p)
+	if err != nil {
+		return nil, clues.Wrap(err, "parsing folder path").With("folder_path", p)
+	}
+
+	return elems, nil
+}
+
+// FolderPathString joins the folder names into a path that can be passed to
+// SplitFolderPath, or to the restore --folder flags.
+func FolderPathString(elems []string) string {
+	return "/" + path.Builder{}.Append(elems...).String()
+}
+
+// displayName returns the name that best identifies the item to a user.
+func (de Entry) displayName() string {
+	var name string
+
+	switch {
+	case de.Exchange != nil:
+		name = str.First(de.Exchange.Subject, de.Exchange.ContactName)
+	case de.OneDrive != nil:
+		name = de.OneDrive.ItemName
+	case de.SharePoint != nil:
+		name = de.SharePoint.ItemName
+	case de.Groups != nil:
+		name = str.First(
+			de.Groups.ItemName,
+			de.Groups.Message.Subject,
+			de.Groups.Message.Preview,
+			de.Groups.Post.Topic,
+			de.Groups.Post.Preview)
+	case de.TeamsChats != nil:
+		name = str.First(de.TeamsChats.Chat.Name, de.TeamsChats.Chat.LastMessagePreview)
+	case de.Directory != nil:
+		name = de.Directory.Object.DisplayName
+	}
+
+	return str.First(name, de.ShortRef)
+}
+
+// ---------------------------------------------------------------------------
+// printing
+// ---------------------------------------------------------------------------
+
+// ListingEntry is a folder or item in the listing of a folder.
+type ListingEntry struct {
+	Name      string    `json:"name"`
+	IsFolder  bool      `json:"isFolder"`
+	ID        string    `json:"id,omitempty"`
+	Size      int64     `json:"size"`
+	ItemCount int       `json:"itemCount,omitempty"`
+	Modified  time.Time `json:"modified,omitempty"`
+}
+
+// Listing returns the direct subfolders of the folder, followed by its
+// items.
+func (n *FolderNode) Listing() []ListingEntry {
+	les := make([]ListingEntry, 0, len(n.Folders)+len(n.Items))
+
+	for _, f := range n.Folders {
+		les = append(les, ListingEntry{
+			Name:      f.Name,
+			IsFolder:  true,
+			Size:      f.Size,
+			ItemCount: f.ItemCount,
+			Modified:  f.Modified,
+		})
+	}
+
+	for _, ent := range n.Items {
+		les = append(les, ListingEntry{
+			Name:     ent.displayName(),
+			ID:       ent.ShortRef,
+			Size:     ent.size(),
+			Modified: ent.Modified(),
+		})
+	}
+
+	return les
+}
+
+// PrintListing writes the contents of the folder to StdOut, in the format
+// requested by the caller.
+func PrintListing(ctx context.Context, n *FolderNode) {
+	les := n.Listing()
+	if len(les) == 0 {
+		print.Info(ctx, "No folders or items found in "+FolderPathString(n.Path))
+		return
+	}
+
+	ps := make([]print.Printable, 0, len(les))
+	for _, le := range les {
+		ps = append(ps, print.Printable(le))
+	}
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable reduces the ListingEntry to its minimally printable details.
+func (le ListingEntry) MinimumPrintable() any {
+	return le
+}
+
+// Headers returns the human-readable names of properties in a ListingEntry
+// for printing out to a terminal in a columnar display.
+func (le ListingEntry) Headers(skipID bool) []string {
+	hs := []string{"Name", "Size", "Items", "Modified"}
+
+	if !skipID {
+		hs = append([]string{"ID"}, hs...)
+	}
+
+	return hs
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (le ListingEntry) Values(skipID bool) []string {
+	var (
+		name  = le.Name
+		items string
+		mod   string
+	)
+
+	if le.IsFolder {
+		name += "/"
+		items = strconv.Itoa(le.ItemCount)
+	}
+
+	if !le.Modified.IsZero() {
+		mod = dttm.FormatToTabularDisplay(le.Modified)
+	}
+
+	vs := []string{name, humanize.Bytes(uint64(le.Size)), items, mod}
+
+	if !skipID {
+		vs = append([]string{le.ID}, vs...)
+	}
+
+	return vs
+}
+
+// TreeEntry is a folder in the tree of a backup's folders.
+type TreeEntry struct {
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	ItemCount int       `json:"itemCount"`
+	Modified  time.Time `json:"modified,omitempty"`
+}
+
+// PrintTree writes the folder and its subfolders, down to maxDepth levels
+// below the folder, to StdOut in the format requested by the caller.
+func PrintTree(ctx context.Context, n *FolderNode, maxDepth int) {
+	ps := []print.Printable{}
+
+	n.Walk(maxDepth, func(f *FolderNode) {
+		ps = append(ps, TreeEntry{
+			Path:      FolderPathString(f.Path),
+			Size:      f.Size,
+			ItemCount: f.ItemCount,
+			Modified:  f.Modified,
+		})
+	})
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable reduces the TreeEntry to its minimally printable details.
+func (te TreeEntry) MinimumPrintable() any {
+	return te
+}
+
+// Headers returns the human-readable names of properties in a TreeEntry
+// for printing out to a terminal in a columnar display.
+func (te TreeEntry) Headers(bool) []string {
+	return []string{"Size", "Items", "Modified", "Folder"}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (te TreeEntry) Values(bool) []string {
+	var mod string
+	if !te.Modified.IsZero() {
+		mod = dttm.FormatToTabularDisplay(te.Modified)
+	}
+
+	return []string{
+		humanize.Bytes(uint64(te.Size)),
+		strconv.Itoa(te.ItemCount),
+		mod,
+		te.Path,
+	}
+}
@@ -0,0 +1,186 @@
+package details
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type TreeUnitSuite struct {
+	tester.Suite
+}
+
+func TestTreeUnitSuite(t *testing.T) {
+	suite.Run(t, &TreeUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func treeFolderEntry(parentLoc, name string, modified time.Time) Entry {
+	return Entry{
+		LocationRef: parentLoc,
+		ItemInfo: ItemInfo{
+			Folder: &FolderInfo{
+				ItemType:    FolderItem,
+				DisplayName: name,
+				Modified:    modified,
+			},
+		},
+	}
+}
+
+func treeMailEntry(id, loc, subject string, size int64, modified time.Time) Entry {
+	return Entry{
+		ShortRef:    id,
+		LocationRef: loc,
+		ItemInfo: ItemInfo{
+			Exchange: &ExchangeInfo{
+				ItemType: ExchangeMail,
+				Subject:  subject,
+				Size:     size,
+				Modified: modified,
+			},
+		},
+	}
+}
+
+func treeFileEntry(id, loc, name string, size int64, modified time.Time) Entry {
+	return Entry{
+		ShortRef:    id,
+		LocationRef: loc,
+		ItemInfo: ItemInfo{
+			OneDrive: &OneDriveInfo{
+				ItemType: OneDriveItem,
+				ItemName: name,
+				Size:     size,
+				Modified: modified,
+			},
+		},
+	}
+}
+
+func (suite *TreeUnitSuite) TestTree() {
+	var (
+		t     = suite.T()
+		then  = time.Now().Add(-time.Hour).UTC()
+		now   = time.Now().UTC()
+		deets = detailsOf(
+			treeFolderEntry("", "Inbox", then),
+			treeFolderEntry("Inbox", "Sub", then),
+			treeMailEntry("m1", "Inbox", "hello", 10, then),
+			treeMailEntry("m2", "Inbox/Sub", "bye", 20, now),
+			treeMailEntry("m3", "Inbox/Sub", "again", 30, then),
+			treeFolderEntry("", "root:", then),
+			treeFolderEntry("root:", "Docs", then),
+			treeFolderEntry(`root:/Docs`, "a/b", then),
+			treeFileEntry("f1", `root:/Docs/a\/b`, "report.docx", 100, then),
+			treeFileEntry("f2", "root:", "notes.txt", 5, then))
+	)
+
+	root, err := Tree(deets)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, int64(165), root.Size)
+	assert.Equal(t, 5, root.ItemCount)
+	assert.Equal(t, now, root.Modified)
+
+	names := []string{}
+	for _, f := range root.Folders {
+		names = append(names, f.Name)
+	}
+
+	assert.Equal(t, []string{"Docs", "Inbox"}, names, "drive contents sit at the top of the tree")
+	require.Len(t, root.Items, 1)
+	assert.Equal(t, "f2", root.Items[0].ShortRef)
+
+	inbox, err := root.Find([]string{"Inbox"})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, int64(60), inbox.Size, "sizes include subfolders")
+	assert.Equal(t, 3, inbox.ItemCount, "counts include subfolders")
+	assert.Equal(t, now, inbox.Modified)
+
+	sub, err := root.Find([]string{"Inbox", "Sub"})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, []string{"Inbox", "Sub"}, sub.Path)
+	assert.Equal(t, 2, sub.ItemCount)
+
+	// items are sorted by name
+	les := sub.Listing()
+	require.Len(t, les, 2)
+	assert.Equal(t, "again", les[0].Name)
+	assert.Equal(t, "bye", les[1].Name)
+
+	ab, err := root.Find([]string{"Docs", "a/b"})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, int64(100), ab.Size)
+
+	_, err = root.Find([]string{"Inbox", "missing"})
+	assert.Error(t, err, clues.ToCore(err))
+
+	walked := []string{}
+	root.Walk(1, func(f *FolderNode) {
+		walked = append(walked, FolderPathString(f.Path))
+	})
+
+	assert.Equal(t, []string{"/", "/Docs", "/Inbox"}, walked)
+
+	walked = []string{}
+	root.Walk(-1, func(f *FolderNode) {
+		walked = append(walked, FolderPathString(f.Path))
+	})
+
+	assert.Equal(t, []string{"/", "/Docs", `/Docs/a\/b`, "/Inbox", "/Inbox/Sub"}, walked)
+}
+
+func (suite *TreeUnitSuite) TestTree_listing() {
+	t := suite.T()
+	now := time.Now().UTC()
+
+	root, err := Tree(detailsOf(
+		treeFolderEntry("", "Inbox", now),
+		treeMailEntry("m1", "Inbox", "hello", 10, now),
+		treeMailEntry("m2", "", "", 20, now)))
+	require.NoError(t, err, clues.ToCore(err))
+
+	les := root.Listing()
+	require.Len(t, les, 2)
+
+	assert.Equal(t, ListingEntry{Name: "Inbox", IsFolder: true, Size: 10, ItemCount: 1, Modified: now}, les[0])
+	assert.Equal(t, ListingEntry{Name: "m2", ID: "m2", Size: 20, Modified: now}, les[1], "unnamed items use their ID")
+
+	assert.Equal(t, "Inbox/", les[0].Values(true)[0], "folders are marked")
+	assert.Equal(t, "1", les[0].Values(true)[2])
+	assert.Empty(t, les[1].Values(true)[2], "items have no item count")
+}
+
+func (suite *TreeUnitSuite) TestSplitFolderPath() {
+	table := []struct {
+		input  string
+		expect []string
+	}{
+		{input: "", expect: []string{}},
+		{input: "/", expect: []string{}},
+		{input: "Inbox", expect: []string{"Inbox"}},
+		{input: "/Inbox/Sub/", expect: []string{"Inbox", "Sub"}},
+		{input: `Docs/a\/b`, expect: []string{"Docs", "a/b"}},
+		{input: "root:/Docs", expect: []string{"Docs"}},
+	}
+	for _, test := range table {
+		suite.Run(test.input, func() {
+			t := suite.T()
+
+			result, err := SplitFolderPath(test.input)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.ElementsMatch(t, test.expect, result)
+
+			// paths round trip through FolderPathString
+			again, err := SplitFolderPath(FolderPathString(result))
+			require.NoError(t, err, clues.ToCore(err))
+			assert.ElementsMatch(t, result, again)
+		})
+	}
+}
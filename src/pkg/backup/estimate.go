@@ -0,0 +1,144 @@
+package backup
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+	"golang.org/x/exp/slices"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/count"
+)
+
+// AllCategories labels the estimate that totals every category of data.
+const AllCategories = "all"
+
+// Estimate describes the data a backup would capture, as found by a dry
+// run: either for a single category of data, or for all of them.
+type Estimate struct {
+	ProtectedResource string `json:"protectedResource"`
+	Category          string `json:"category"`
+	Containers        int64  `json:"containers"`
+	Items             int64  `json:"items"`
+	Bytes             int64  `json:"bytes"`
+	// Unsized is set when enumeration didn't report the size of some of the
+	// items, such as emails or chats.  Bytes then only covers the rest.
+	Unsized bool `json:"unsized,omitempty"`
+	// APICallTokens is only tallied across all categories.
+	APICallTokens int64 `json:"apiCallTokens,omitempty"`
+}
+
+// NewEstimates produces an estimate for each category of data in the dry
+// run's counts, sorted by category, followed by the estimate for all
+// categories.  Keys of the counts are found in /pkg/count/keys.go.  Unsized
+// lists the categories whose item sizes weren't known during enumeration.
+func NewEstimates(
+	protectedResource string,
+	totals map[string]int64,
+	categories map[string]map[string]int64,
+	unsized []string,
+) []Estimate {
+	es := make([]Estimate, 0, len(categories)+1)
+
+	for cat, counts := range categories {
+		e := estimateFromCounts(protectedResource, cat, counts)
+		e.Unsized = slices.Contains(unsized, cat)
+
+		es = append(es, e)
+	}
+
+	sort.Slice(es, func(i, j int) bool {
+		return es[i].Category < es[j].Category
+	})
+
+	all := estimateFromCounts(protectedResource, AllCategories, totals)
+	all.Unsized = len(unsized) > 0
+
+	return append(es, all)
+}
+
+func estimateFromCounts(protectedResource, category string, counts map[string]int64) Estimate {
+	return Estimate{
+		ProtectedResource: protectedResource,
+		Category:          category,
+		Containers:        counts[string(count.Containers)],
+		Items:             counts[string(count.ItemsAdded)],
+		Bytes:             counts[string(count.StreamBytesAdded)],
+		APICallTokens:     counts[string(count.APICallTokensConsumed)],
+	}
+}
+
+// Add sums the counts of both estimates.
+func (e Estimate) Add(other Estimate) Estimate {
+	e.Containers += other.Containers
+	e.Items += other.Items
+	e.Bytes += other.Bytes
+	e.Unsized = e.Unsized || other.Unsized
+	e.APICallTokens += other.APICallTokens
+
+	return e
+}
+
+// PrintEstimates writes the estimates to StdOut, in the format requested by the caller.
+func PrintEstimates(ctx context.Context, es []Estimate) {
+	if len(es) == 0 {
+		print.Info(ctx, "No data found")
+		return
+	}
+
+	ps := make([]print.Printable, 0, len(es))
+	for _, e := range es {
+		ps = append(ps, print.Printable(e))
+	}
+
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable reduces the Estimate to its minimally printable details.
+func (e Estimate) MinimumPrintable() any {
+	return e
+}
+
+// Headers returns the human-readable names of properties in an Estimate
+// for printing out to a terminal in a columnar display.
+func (e Estimate) Headers(bool) []string {
+	return []string{
+		"Resource",
+		"Category",
+		"Containers",
+		"Items",
+		"Bytes",
+		"Graph Tokens",
+	}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.  Bytes that leave out unsized
+// items are shown as a lower bound.
+func (e Estimate) Values(bool) []string {
+	var bytes, calls string
+
+	switch {
+	case e.Unsized && e.Bytes == 0:
+		bytes = "unknown"
+	case e.Unsized:
+		bytes = humanize.Bytes(uint64(e.Bytes)) + "+"
+	default:
+		bytes = humanize.Bytes(uint64(e.Bytes))
+	}
+
+	if e.Category == AllCategories {
+		calls = strconv.FormatInt(e.APICallTokens, 10)
+	}
+
+	return []string{
+		e.ProtectedResource,
+		e.Category,
+		strconv.FormatInt(e.Containers, 10),
+		strconv.FormatInt(e.Items, 10),
+		bytes,
+		calls,
+	}
+}
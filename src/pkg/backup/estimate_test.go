@@ -0,0 +1,77 @@
+package backup_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/count"
+)
+
+type EstimateUnitSuite struct {
+	tester.Suite
+}
+
+func TestEstimateUnitSuite(t *testing.T) {
+	suite.Run(t, &EstimateUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *EstimateUnitSuite) TestNewEstimates() {
+	t := suite.T()
+
+	es := backup.NewEstimates(
+		"alice",
+		map[string]int64{
+			string(count.Containers):            3,
+			string(count.ItemsAdded):            12,
+			string(count.StreamBytesAdded):      2000,
+			string(count.APICallTokensConsumed): 9,
+		},
+		map[string]map[string]int64{
+			"Files": {
+				string(count.Containers):       2,
+				string(count.ItemsAdded):       10,
+				string(count.StreamBytesAdded): 2000,
+			},
+			"Email": {
+				string(count.Containers): 1,
+				string(count.ItemsAdded): 2,
+			},
+		},
+		[]string{"Email"})
+	require.Len(t, es, 3)
+
+	assert.Equal(t, "Email", es[0].Category, "sorted by category")
+	assert.True(t, es[0].Unsized, "email sizes are unknown")
+	assert.Equal(t, "Files", es[1].Category, "sorted by category")
+	assert.False(t, es[1].Unsized, "file sizes are known")
+	assert.Equal(
+		t,
+		backup.Estimate{
+			ProtectedResource: "alice",
+			Category:          backup.AllCategories,
+			Containers:        3,
+			Items:             12,
+			Bytes:             2000,
+			Unsized:           true,
+			APICallTokens:     9,
+		},
+		es[2])
+
+	assert.Equal(t, []string{"alice", "Email", "1", "2", "unknown", ""}, es[0].Values(false))
+	assert.Equal(t, []string{"alice", "Files", "2", "10", "2.0 kB", ""}, es[1].Values(false))
+	assert.Equal(t, []string{"alice", "all", "3", "12", "2.0 kB+", "9"}, es[2].Values(false))
+
+	total := es[1].Add(es[1])
+	assert.Equal(t, int64(20), total.Items)
+	assert.False(t, total.Unsized)
+
+	total = total.Add(es[2])
+	assert.Equal(t, int64(32), total.Items)
+	assert.Equal(t, int64(9), total.APICallTokens)
+	assert.True(t, total.Unsized, "unsized totals stay unsized")
+}
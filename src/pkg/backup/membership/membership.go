@@ -0,0 +1,207 @@
+package membership
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/alcionai/corso/src/cli/print"
+)
+
+// FileName is the name of the metadata file that holds a group's
+// membership within a groups backup.
+const FileName = "membership"
+
+// Role identifies the capacity in which a user belongs to a group.
+type Role string
+
+const (
+	OwnerRole  Role = "owner"
+	MemberRole Role = "member"
+	GuestRole  Role = "guest"
+)
+
+// Member is a single user's membership in a group.  A user who both
+// owns and belongs to a group produces one Member for each role.
+type Member struct {
+	ID          string `json:"id"`
+	UPN         string `json:"userPrincipalName"`
+	DisplayName string `json:"displayName,omitempty"`
+	Role        Role   `json:"role"`
+}
+
+// Membership records the owners and members of a group at the
+// time of a backup.  Guests are included in the members.
+type Membership struct {
+	Owners  []Member `json:"owners"`
+	Members []Member `json:"members"`
+}
+
+// All returns the owners followed by the members.
+func (m Membership) All() []Member {
+	all := make([]Member, 0, len(m.Owners)+len(m.Members))
+	all = append(all, m.Owners...)
+
+	return append(all, m.Members...)
+}
+
+func (m Member) key() string {
+	return strings.ToLower(m.ID) + "/" + string(m.Role)
+}
+
+// MinimumPrintable reduces the Member to its minimally printable details.
+func (m Member) MinimumPrintable() any {
+	return m
+}
+
+// Headers returns the human-readable names of properties in a Member
+// for printing out to a terminal in a columnar display.
+func (m Member) Headers(skipID bool) []string {
+	headers := []string{"ID", "User", "Name", "Role"}
+
+	if skipID {
+		headers = headers[1:]
+	}
+
+	return headers
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (m Member) Values(skipID bool) []string {
+	values := []string{m.ID, m.UPN, m.DisplayName, string(m.Role)}
+
+	if skipID {
+		values = values[1:]
+	}
+
+	return values
+}
+
+// PrintAll writes the members to StdOut, in the format requested by the caller.
+func PrintAll(ctx context.Context, ms []Member) {
+	if len(ms) == 0 {
+		print.Info(ctx, "No members found")
+		return
+	}
+
+	ps := make([]print.Printable, 0, len(ms))
+	for _, m := range ms {
+		ps = append(ps, print.Printable(m))
+	}
+
+	print.All(ctx, ps...)
+}
+
+// ---------------------------------------------------------------------------
+// comparisons
+// ---------------------------------------------------------------------------
+
+// ChangeType describes how a membership differs between two backups.
+type ChangeType string
+
+const (
+	Added   ChangeType = "added"
+	Removed ChangeType = "removed"
+)
+
+// Change is a single membership that was added or removed between
+// two backups.
+type Change struct {
+	Member
+	Change ChangeType `json:"change"`
+}
+
+// MinimumPrintable reduces the Change to its minimally printable details.
+func (c Change) MinimumPrintable() any {
+	return c
+}
+
+// Headers returns the human-readable names of properties in a Change
+// for printing out to a terminal in a columnar display.
+func (c Change) Headers(skipID bool) []string {
+	return append([]string{"Change"}, c.Member.Headers(skipID)...)
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (c Change) Values(skipID bool) []string {
+	return append([]string{string(c.Change)}, c.Member.Values(skipID)...)
+}
+
+// PrintChanges writes the changes to StdOut, in the format requested by the caller.
+func PrintChanges(ctx context.Context, cs []Change) {
+	if len(cs) == 0 {
+		print.Info(ctx, "No membership changes")
+		return
+	}
+
+	ps := make([]print.Printable, 0, len(cs))
+	for _, c := range cs {
+		ps = append(ps, print.Printable(c))
+	}
+
+	print.All(ctx, ps...)
+}
+
+// Diff compares the membership recorded in an earlier backup (prev) with
+// a later one (curr).  Each user and role present only in curr is Added,
+// and each present only in prev is Removed.  A user whose role changed
+// produces both a removal and an addition.
+func Diff(prev, curr Membership) []Change {
+	var (
+		changes = []Change{}
+		before  = keyed(prev)
+		after   = keyed(curr)
+	)
+
+	for k, m := range after {
+		if _, ok := before[k]; !ok {
+			changes = append(changes, Change{Member: m, Change: Added})
+		}
+	}
+
+	for k, m := range before {
+		if _, ok := after[k]; !ok {
+			changes = append(changes, Change{Member: m, Change: Removed})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Change != changes[j].Change {
+			return changes[i].Change < changes[j].Change
+		}
+
+		return changes[i].key() < changes[j].key()
+	})
+
+	return changes
+}
+
+// Missing returns the owners and members recorded in the backup that
+// are no longer present in the current membership.  Owners are listed
+// first, matching the order in which they need to be restored.
+func Missing(backup, current Membership) []Member {
+	var (
+		missing = []Member{}
+		present = keyed(current)
+	)
+
+	for _, m := range backup.All() {
+		if _, ok := present[m.key()]; !ok {
+			missing = append(missing, m)
+		}
+	}
+
+	return missing
+}
+
+func keyed(m Membership) map[string]Member {
+	r := map[string]Member{}
+
+	for _, mem := range m.All() {
+		r[mem.key()] = mem
+	}
+
+	return r
+}
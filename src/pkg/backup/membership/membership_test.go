@@ -0,0 +1,117 @@
+package membership_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/membership"
+)
+
+type MembershipUnitSuite struct {
+	tester.Suite
+}
+
+func TestMembershipUnitSuite(t *testing.T) {
+	suite.Run(t, &MembershipUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+var (
+	owner       = membership.Member{ID: "a", UPN: "a@contoso.com", Role: membership.OwnerRole}
+	ownerMember = membership.Member{ID: "a", UPN: "a@contoso.com", Role: membership.MemberRole}
+	member      = membership.Member{ID: "b", UPN: "b@contoso.com", Role: membership.MemberRole}
+	guest       = membership.Member{ID: "c", UPN: "c@fabrikam.com", Role: membership.GuestRole}
+)
+
+func (suite *MembershipUnitSuite) TestDiff() {
+	table := []struct {
+		name   string
+		prev   membership.Membership
+		curr   membership.Membership
+		expect []membership.Change
+	}{
+		{
+			name:   "no changes",
+			prev:   membership.Membership{Owners: []membership.Member{owner}, Members: []membership.Member{member}},
+			curr:   membership.Membership{Owners: []membership.Member{owner}, Members: []membership.Member{member}},
+			expect: []membership.Change{},
+		},
+		{
+			name: "added and removed",
+			prev: membership.Membership{Owners: []membership.Member{owner}, Members: []membership.Member{member}},
+			curr: membership.Membership{Owners: []membership.Member{owner}, Members: []membership.Member{guest}},
+			expect: []membership.Change{
+				{Member: guest, Change: membership.Added},
+				{Member: member, Change: membership.Removed},
+			},
+		},
+		{
+			name: "user keeps membership but loses ownership",
+			prev: membership.Membership{
+				Owners:  []membership.Member{owner},
+				Members: []membership.Member{ownerMember},
+			},
+			curr: membership.Membership{Members: []membership.Member{ownerMember}},
+			expect: []membership.Change{
+				{Member: owner, Change: membership.Removed},
+			},
+		},
+		{
+			name:   "user ids are case insensitive",
+			prev:   membership.Membership{Members: []membership.Member{member}},
+			curr:   membership.Membership{Members: []membership.Member{{ID: "B", Role: membership.MemberRole}}},
+			expect: []membership.Change{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, membership.Diff(test.prev, test.curr))
+		})
+	}
+}
+
+func (suite *MembershipUnitSuite) TestMissing() {
+	backup := membership.Membership{
+		Owners:  []membership.Member{owner},
+		Members: []membership.Member{ownerMember, member, guest},
+	}
+
+	table := []struct {
+		name    string
+		current membership.Membership
+		expect  []membership.Member
+	}{
+		{
+			name:    "empty group",
+			current: membership.Membership{},
+			expect:  []membership.Member{owner, ownerMember, member, guest},
+		},
+		{
+			name:    "nothing missing",
+			current: backup,
+			expect:  []membership.Member{},
+		},
+		{
+			name: "missing owner",
+			current: membership.Membership{
+				Members: []membership.Member{ownerMember, member, guest},
+			},
+			expect: []membership.Member{owner},
+		},
+		{
+			name: "extra members are ignored",
+			current: membership.Membership{
+				Owners:  []membership.Member{owner},
+				Members: []membership.Member{ownerMember, {ID: "d", Role: membership.MemberRole}},
+			},
+			expect: []membership.Member{member, guest},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, membership.Missing(backup, test.current))
+		})
+	}
+}
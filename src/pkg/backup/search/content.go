@@ -0,0 +1,128 @@
+package search
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/extensions"
+)
+
+// Contents holds the text recorded by the content indexing extension for
+// the items in a single backup.  It's stored apart from the backup's
+// details, so that reading the details doesn't read the text of every item,
+// and only gets read to show the snippets of content search matches.
+type Contents struct {
+	Items []ContentItem `json:"items"`
+
+	byKey map[ItemKey]string
+}
+
+// ContentItem is an item's text in Contents.
+type ContentItem struct {
+	Category string `json:"category"`
+	ItemRef  string `json:"itemRef"`
+	Text     string `json:"text"`
+}
+
+// TakeContents moves the text recorded by the content indexing extension
+// out of the details entries, and returns it.
+func TakeContents(deets *details.Details) *Contents {
+	c := &Contents{
+		Items: []ContentItem{},
+		byKey: map[ItemKey]string{},
+	}
+
+	for i := range deets.Entries {
+		ent := &deets.Entries[i]
+
+		text := contentText(ent.ItemInfo)
+		if len(text) == 0 {
+			continue
+		}
+
+		key := entryKey(ent)
+
+		if _, ok := c.byKey[key]; !ok {
+			c.byKey[key] = text
+			c.Items = append(c.Items, ContentItem{
+				Category: key.Category,
+				ItemRef:  key.ItemRef,
+				Text:     text,
+			})
+		}
+
+		// the extension data can be shared with the item that produced it,
+		// so it gets replaced instead of modified.
+		data := maps.Clone(ent.Extension.Data)
+		delete(data, extensions.KContentText)
+
+		ent.Extension = nil
+
+		if len(data) > 0 {
+			ent.Extension = &details.ExtensionData{Data: data}
+		}
+	}
+
+	return c
+}
+
+// WithText returns a copy of the entry's item info holding the entry's
+// text from the contents, if it has any.  It's used to carry the text of
+// items merged from a base backup into the new backup's contents.
+func (c *Contents) WithText(ent *details.Entry) details.ItemInfo {
+	info := ent.ItemInfo
+
+	text, ok := c.Text(entryKey(ent))
+	if !ok {
+		return info
+	}
+
+	data := map[string]any{}
+
+	if info.Extension != nil {
+		data = maps.Clone(info.Extension.Data)
+	}
+
+	data[extensions.KContentText] = text
+	info.Extension = &details.ExtensionData{Data: data}
+
+	return info
+}
+
+// Text returns the text of the item, if it has any.
+func (c *Contents) Text(key ItemKey) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	text, ok := c.byKey[key]
+
+	return text, ok
+}
+
+// Marshal complies with the marshaller interface in streamStore.
+func (c *Contents) Marshal() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// UnmarshalContentsTo produces a func that complies with the unmarshaller
+// type in streamStore.
+func UnmarshalContentsTo(c *Contents) func(io.ReadCloser) error {
+	return func(rc io.ReadCloser) error {
+		if err := json.NewDecoder(rc).Decode(c); err != nil {
+			return clues.Wrap(err, "decoding contents")
+		}
+
+		c.byKey = make(map[ItemKey]string, len(c.Items))
+
+		for _, it := range c.Items {
+			c.byKey[ItemKey{Category: it.Category, ItemRef: it.ItemRef}] = it.Text
+		}
+
+		return nil
+	}
+}
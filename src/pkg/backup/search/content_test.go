@@ -0,0 +1,109 @@
+package search
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/extensions"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type ContentsUnitSuite struct {
+	tester.Suite
+}
+
+func TestContentsUnitSuite(t *testing.T) {
+	suite.Run(t, &ContentsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ContentsUnitSuite) TestTakeContents() {
+	var (
+		t   = suite.T()
+		now = time.Now().UTC()
+
+		withData = contentEntry("f2", "other.txt", "more text", now)
+		deets    = detailsOf(
+			contentEntry("f1", "notes.txt", "some text", now),
+			withData,
+			fileEntry("f3", "photo.jpg", now))
+	)
+
+	// other extension data stays in the details.
+	shared := withData.Extension
+	shared.Data["other"] = "value"
+
+	c := TakeContents(deets)
+
+	assert.Equal(
+		t,
+		[]ContentItem{
+			{Category: path.FilesCategory.String(), ItemRef: "f1", Text: "some text"},
+			{Category: path.FilesCategory.String(), ItemRef: "f2", Text: "more text"},
+		},
+		c.Items)
+
+	assert.Nil(t, deets.Entries[0].Extension)
+	assert.Equal(t, map[string]any{"other": "value"}, deets.Entries[1].Extension.Data)
+	assert.Nil(t, deets.Entries[2].Extension)
+	assert.Contains(t, shared.Data, extensions.KContentText, "extension data isn't modified in place")
+
+	text, ok := c.Text(ItemKey{Category: path.FilesCategory.String(), ItemRef: "f1"})
+	assert.True(t, ok)
+	assert.Equal(t, "some text", text)
+
+	_, ok = c.Text(ItemKey{Category: path.EmailCategory.String(), ItemRef: "f1"})
+	assert.False(t, ok, "items are keyed by category")
+}
+
+func (suite *ContentsUnitSuite) TestContents_MarshalWithText() {
+	var (
+		t   = suite.T()
+		now = time.Now().UTC()
+		c   = TakeContents(detailsOf(contentEntry("f1", "notes.txt", "some text", now)))
+	)
+
+	bs, err := c.Marshal()
+	require.NoError(t, err, clues.ToCore(err))
+
+	var result Contents
+
+	err = UnmarshalContentsTo(&result)(io.NopCloser(bytes.NewReader(bs)))
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, c.Items, result.Items)
+
+	// merged items get their text back.
+	ent := fileEntry("f1", "notes.txt", now)
+	info := result.WithText(&ent)
+	assert.Equal(t, "some text", info.Extension.Data[extensions.KContentText])
+	assert.Nil(t, ent.Extension, "the entry isn't modified")
+
+	ent = fileEntry("f2", "other.txt", now)
+	info = result.WithText(&ent)
+	assert.Nil(t, info.Extension, "items without text are unchanged")
+
+	// backups without contents leave items unchanged.
+	var none *Contents
+
+	info = none.WithText(&ent)
+	assert.Equal(t, ent.ItemInfo, info)
+
+	err = UnmarshalContentsTo(&Contents{})(io.NopCloser(bytes.NewReader([]byte("not contents"))))
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *ContentsUnitSuite) TestContents_nilEntryInfo() {
+	t := suite.T()
+
+	ent := details.Entry{ItemRef: "x"}
+	info := (&Contents{}).WithText(&ent)
+	assert.Equal(t, ent.ItemInfo, info)
+}
@@ -0,0 +1,285 @@
+package search
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// indexVersion is bumped whenever the format of backup indexes changes.
+// Indexes of other versions are ignored, and the backup's details get
+// indexed in their place.
+const indexVersion = 2
+
+// BackupIndex holds the searchable properties of the items in a single
+// backup.  It's written to the repository along with the backup's details,
+// and never changes afterwards.  Searches only need to read the details of
+// the backups whose indexes hold matching items.
+type BackupIndex struct {
+	Version int `json:"version"`
+	// Items lists the backup's items, one per ItemKey.
+	Items []IndexItem `json:"items"`
+	// Each property's words, sorted, along with the items holding them.
+	Subjects  []Term `json:"subjects,omitempty"`
+	Senders   []Term `json:"senders,omitempty"`
+	FileNames []Term `json:"fileNames,omitempty"`
+	Content   []Term `json:"content,omitempty"`
+}
+
+// IndexItem is an item in a BackupIndex.
+type IndexItem struct {
+	Category string    `json:"category"`
+	ItemRef  string    `json:"itemRef"`
+	Modified time.Time `json:"modified"`
+}
+
+func (it IndexItem) key() ItemKey {
+	return ItemKey{Category: it.Category, ItemRef: it.ItemRef}
+}
+
+// ItemKey identifies an item within a backup.  ItemRefs are only unique
+// within a category: a group's post and event, or a site's list and
+// library items, can share an ID.
+type ItemKey struct {
+	Category string
+	ItemRef  string
+}
+
+// entryKey returns the key of the details entry.  Entries whose RepoRef
+// can't be parsed are keyed by their ItemRef alone.
+func entryKey(ent *details.Entry) ItemKey {
+	key := ItemKey{ItemRef: ent.ItemRef}
+
+	if rr, err := path.FromDataLayerPath(ent.RepoRef, true); err == nil {
+		key.Category = rr.Category().String()
+	}
+
+	return key
+}
+
+// Term is a lowercased word, along with the positions in BackupIndex.Items
+// of the items holding it.
+type Term struct {
+	Word  string `json:"word"`
+	Items []int  `json:"items"`
+}
+
+// NewBackupIndex indexes the items in the backup's details.
+func NewBackupIndex(deets *details.Details) *BackupIndex {
+	var (
+		bi = &BackupIndex{
+			Version: indexVersion,
+			Items:   []IndexItem{},
+		}
+		seen         = map[ItemKey]struct{}{}
+		subjectTerms = termSet{}
+		senderTerms  = termSet{}
+		nameTerms    = termSet{}
+		contentTerms = termSet{}
+	)
+
+	for _, ent := range deets.Items() {
+		key := entryKey(ent)
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		pos := len(bi.Items)
+
+		bi.Items = append(bi.Items, IndexItem{
+			Category: key.Category,
+			ItemRef:  key.ItemRef,
+			Modified: ent.Modified(),
+		})
+
+		subjectTerms.add(pos, subjects(ent.ItemInfo)...)
+		senderTerms.add(pos, senders(ent.ItemInfo)...)
+		nameTerms.add(pos, fileNames(ent.ItemInfo)...)
+		contentTerms.add(pos, contentText(ent.ItemInfo))
+	}
+
+	bi.Subjects = subjectTerms.terms()
+	bi.Senders = senderTerms.terms()
+	bi.FileNames = nameTerms.terms()
+	bi.Content = contentTerms.terms()
+
+	return bi
+}
+
+// Marshal complies with the marshaller interface in streamStore.
+func (bi *BackupIndex) Marshal() ([]byte, error) {
+	return json.Marshal(bi)
+}
+
+// UnmarshalIndexTo produces a func that complies with the unmarshaller type
+// in streamStore.
+func UnmarshalIndexTo(bi *BackupIndex) func(io.ReadCloser) error {
+	return func(rc io.ReadCloser) error {
+		if err := json.NewDecoder(rc).Decode(bi); err != nil {
+			return err
+		}
+
+		if bi.Version != indexVersion {
+			return clues.New("unsupported search index version").
+				With("index_version", bi.Version)
+		}
+
+		return nil
+	}
+}
+
+// Match returns the keys of the indexed items that match the query.
+// Text values match the items holding words that start with each of the
+// value's words.  Content matches only narrow down the items whose text
+// may contain the query's content phrase.
+func (bi *BackupIndex) Match(q Query) map[ItemKey]struct{} {
+	var (
+		// nil holds every item.
+		cands map[int]struct{}
+		props = []struct {
+			value string
+			terms []Term
+		}{
+			{q.Subject, bi.Subjects},
+			{q.Sender, bi.Senders},
+			{q.FileName, bi.FileNames},
+			{q.Content, bi.Content},
+		}
+	)
+
+	for _, p := range props {
+		if len(p.value) == 0 {
+			continue
+		}
+
+		ws := words(p.value)
+		if len(ws) == 0 {
+			return map[ItemKey]struct{}{}
+		}
+
+		for _, w := range ws {
+			cands = intersect(cands, withPrefix(p.terms, w))
+			if len(cands) == 0 {
+				return map[ItemKey]struct{}{}
+			}
+		}
+	}
+
+	res := map[ItemKey]struct{}{}
+
+	matches := func(it IndexItem) bool {
+		if !q.ModifiedAfter.IsZero() && !it.Modified.After(q.ModifiedAfter) {
+			return false
+		}
+
+		return q.ModifiedBefore.IsZero() || it.Modified.Before(q.ModifiedBefore)
+	}
+
+	if cands == nil {
+		for _, it := range bi.Items {
+			if matches(it) {
+				res[it.key()] = struct{}{}
+			}
+		}
+
+		return res
+	}
+
+	for pos := range cands {
+		if pos < len(bi.Items) && matches(bi.Items[pos]) {
+			res[bi.Items[pos].key()] = struct{}{}
+		}
+	}
+
+	return res
+}
+
+// withPrefix returns the items holding a word that starts with the prefix.
+func withPrefix(terms []Term, prefix string) map[int]struct{} {
+	res := map[int]struct{}{}
+
+	i := sort.Search(len(terms), func(i int) bool {
+		return terms[i].Word >= prefix
+	})
+
+	for ; i < len(terms) && strings.HasPrefix(terms[i].Word, prefix); i++ {
+		for _, pos := range terms[i].Items {
+			res[pos] = struct{}{}
+		}
+	}
+
+	return res
+}
+
+// intersect returns the items in both sets, where a nil set holds every
+// item.
+func intersect(a, b map[int]struct{}) map[int]struct{} {
+	if a == nil {
+		return b
+	}
+
+	res := map[int]struct{}{}
+
+	for pos := range a {
+		if _, ok := b[pos]; ok {
+			res[pos] = struct{}{}
+		}
+	}
+
+	return res
+}
+
+// words splits the value into lowercased words of letters and digits.
+func words(value string) []string {
+	return strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// termSet collects the words of a property, along with the items holding
+// each word.
+type termSet map[string][]int
+
+func (ts termSet) add(pos int, values ...string) {
+	for _, v := range values {
+		for _, w := range words(v) {
+			items := ts[w]
+
+			// items are added in order, so repeats are always last.
+			if len(items) > 0 && items[len(items)-1] == pos {
+				continue
+			}
+
+			ts[w] = append(items, pos)
+		}
+	}
+}
+
+func (ts termSet) terms() []Term {
+	if len(ts) == 0 {
+		return nil
+	}
+
+	res := make([]Term, 0, len(ts))
+
+	for w, items := range ts {
+		res = append(res, Term{Word: w, Items: items})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Word < res[j].Word
+	})
+
+	return res
+}
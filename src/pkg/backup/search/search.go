@@ -0,0 +1,406 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/extensions"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// BackupGetter retrieves the backups, indexes, contents, and details that
+// get searched.
+type BackupGetter interface {
+	BackupsByTag(ctx context.Context, fs ...store.FilterOption) ([]*backup.Backup, error)
+	GetBackupSearchIndex(
+		ctx context.Context,
+		backupID string,
+	) (*BackupIndex, *backup.Backup, *fault.Bus)
+	GetBackupContents(
+		ctx context.Context,
+		backupID string,
+	) (*Contents, *backup.Backup, *fault.Bus)
+	GetBackupDetails(
+		ctx context.Context,
+		backupID string,
+	) (*details.Details, *backup.Backup, *fault.Bus)
+}
+
+// Item is a details entry matching a search, along with the backup that
+// holds it.
+type Item struct {
+	BackupID          string    `json:"backupID"`
+	BackupCreatedAt   time.Time `json:"backupCreatedAt"`
+	ProtectedResource string    `json:"protectedResource"`
+	// Snippet holds the part of the item's indexed content that matched a
+	// content search, with the match highlighted.
+	Snippet string `json:"snippet,omitempty"`
+	details.Entry
+}
+
+// ---------------------------------------------------------------------------
+// queries
+// ---------------------------------------------------------------------------
+
+// Query describes the items to search for.  Text matches are case
+// insensitive, and match the values holding words that start with each of
+// the query value's words.  Items only match if they match every property
+// set in the query.
+type Query struct {
+	// Subject matches the subject of emails, events, channel messages, and
+	// the topic of conversation posts.
+	Subject string
+	// Sender matches the sender of emails, and the creator of channel
+	// messages and conversation posts.
+	Sender string
+	// FileName matches the name of OneDrive and library files.
+	FileName string
+	// Content matches the text of items that were backed up with content
+	// indexing.  Unlike other text values, it has to appear in the text
+	// exactly as given, apart from case.
+	Content string
+	// ModifiedAfter and ModifiedBefore bound the item's modified time.
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+}
+
+// Search returns the items matching the query in every backup in the
+// repository, sorted by protected resource, then by the time of the backup
+// that holds them, newest first.  Items matching a content search carry a
+// snippet of the matching text.
+//
+// Each backup's index gets read to find its matching items, and the
+// backup's details only get read if there are any.  Backups made before
+// indexes were stored get their details indexed instead.  Backups that
+// can't be read are skipped.
+func Search(ctx context.Context, bg BackupGetter, q Query) ([]Item, error) {
+	bups, err := bg.BackupsByTag(ctx)
+	if err != nil {
+		return nil, clues.Wrap(err, "listing backups")
+	}
+
+	res := []Item{}
+
+	for _, bup := range bups {
+		if err := ctx.Err(); err != nil {
+			return nil, clues.StackWC(ctx, err)
+		}
+
+		ictx := clues.Add(ctx, "backup_id", bup.ID)
+
+		items, err := searchBackup(ictx, bg, bup, q)
+		if err != nil {
+			logger.CtxErr(ictx, err).Info("skipping backup in search")
+			continue
+		}
+
+		res = append(res, items...)
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		ri, rj := res[i], res[j]
+
+		if ri.ProtectedResource != rj.ProtectedResource {
+			return ri.ProtectedResource < rj.ProtectedResource
+		}
+
+		if !ri.BackupCreatedAt.Equal(rj.BackupCreatedAt) {
+			return ri.BackupCreatedAt.After(rj.BackupCreatedAt)
+		}
+
+		return ri.LocationRef < rj.LocationRef
+	})
+
+	return res, nil
+}
+
+// searchBackup returns the backup's items that match the query.
+func searchBackup(
+	ctx context.Context,
+	bg BackupGetter,
+	bup *backup.Backup,
+	q Query,
+) ([]Item, error) {
+	var (
+		bid      = string(bup.ID)
+		deets    *details.Details
+		contents *Contents
+	)
+
+	idx, _, errs := bg.GetBackupSearchIndex(ctx, bid)
+	if errs.Failure() != nil {
+		logger.CtxErr(ctx, errs.Failure()).Debug("indexing details of backup without a search index")
+
+		deets, _, errs = bg.GetBackupDetails(ctx, bid)
+		if errs.Failure() != nil {
+			return nil, clues.Wrap(errs.Failure(), "getting backup details")
+		}
+
+		idx = NewBackupIndex(deets)
+	}
+
+	keys := idx.Match(q)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	if deets == nil {
+		deets, _, errs = bg.GetBackupDetails(ctx, bid)
+		if errs.Failure() != nil {
+			return nil, clues.Wrap(errs.Failure(), "getting backup details")
+		}
+
+		// the text for snippets is kept apart from the details of backups
+		// that store an index.
+		if len(q.Content) > 0 {
+			contents, _, errs = bg.GetBackupContents(ctx, bid)
+			if errs.Failure() != nil {
+				return nil, clues.Wrap(errs.Failure(), "getting backup contents")
+			}
+		}
+	}
+
+	res := []Item{}
+
+	for _, ent := range deets.Items() {
+		key := entryKey(ent)
+
+		if _, ok := keys[key]; !ok {
+			continue
+		}
+
+		// each item is only listed once.
+		delete(keys, key)
+
+		it := Item{
+			BackupID:          bid,
+			BackupCreatedAt:   bup.CreationTime,
+			ProtectedResource: bup.ProtectedResourceName,
+			Entry:             *ent,
+		}
+
+		if len(q.Content) > 0 {
+			text, ok := contents.Text(key)
+			if !ok {
+				text = contentText(ent.ItemInfo)
+			}
+
+			snip, ok := snippet(text, q.Content)
+			if !ok {
+				continue
+			}
+
+			it.Snippet = snip
+		}
+
+		res = append(res, it)
+	}
+
+	return res, nil
+}
+
+// contentText returns the item's text recorded by the content indexing
+// extension in its details, if any.  Backups made before the text was kept
+// apart from their details still hold it there.
+func contentText(info details.ItemInfo) string {
+	if info.Extension == nil {
+		return ""
+	}
+
+	text, _ := info.Extension.Data[extensions.KContentText].(string)
+
+	return text
+}
+
+const (
+	// snippetContext is the number of bytes of text shown on either side of
+	// a content match.
+	snippetContext = 60
+	highlightStart = "**"
+	highlightEnd   = "**"
+)
+
+// snippet finds the first case insensitive match of substr in the text, and
+// returns the text surrounding it with the match highlighted.  Returns false
+// if the text doesn't contain substr.
+func snippet(text, substr string) (string, bool) {
+	start := indexFold(text, substr)
+	if start < 0 {
+		return "", false
+	}
+
+	end := start + len(substr)
+
+	from := max(start-snippetContext, 0)
+	for from > 0 && !utf8.RuneStart(text[from]) {
+		from--
+	}
+
+	to := min(end+snippetContext, len(text))
+	for to < len(text) && !utf8.RuneStart(text[to]) {
+		to++
+	}
+
+	sb := strings.Builder{}
+
+	if from > 0 {
+		sb.WriteString("...")
+	}
+
+	sb.WriteString(text[from:start])
+	sb.WriteString(highlightStart)
+	sb.WriteString(text[start:end])
+	sb.WriteString(highlightEnd)
+	sb.WriteString(text[end:to])
+
+	if to < len(text) {
+		sb.WriteString("...")
+	}
+
+	return sb.String(), true
+}
+
+// indexFold returns the byte offset of the first case insensitive match of
+// substr in s, or -1 if s doesn't contain substr.
+func indexFold(s, substr string) int {
+	if len(substr) == 0 {
+		return 0
+	}
+
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if utf8.RuneStart(s[i]) && strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func subjects(info details.ItemInfo) []string {
+	switch {
+	case info.Exchange != nil:
+		return []string{info.Exchange.Subject}
+
+	case info.Groups != nil:
+		return []string{
+			info.Groups.Message.Subject,
+			info.Groups.Post.Topic,
+			info.Groups.Event.Subject,
+		}
+	}
+
+	return nil
+}
+
+func senders(info details.ItemInfo) []string {
+	switch {
+	case info.Exchange != nil && info.Exchange.ItemType == details.ExchangeMail:
+		return []string{info.Exchange.Sender}
+
+	case info.Groups != nil:
+		return []string{info.Groups.Message.Creator, info.Groups.Post.Creator}
+	}
+
+	return nil
+}
+
+func fileNames(info details.ItemInfo) []string {
+	switch {
+	case info.OneDrive != nil:
+		return []string{info.OneDrive.ItemName}
+
+	case info.SharePoint != nil && info.SharePoint.ItemType == details.SharePointLibrary:
+		return []string{info.SharePoint.ItemName}
+
+	case info.Groups != nil && info.Groups.ItemType == details.SharePointLibrary:
+		return []string{info.Groups.ItemName}
+	}
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// printing
+// ---------------------------------------------------------------------------
+
+// PrintItems writes the items to StdOut, in the format requested by the
+// caller.  Tables list each type of item separately.
+func PrintItems(ctx context.Context, items []Item) {
+	ps := make([]print.Printable, 0, len(items))
+	for _, it := range items {
+		ps = append(ps, print.Printable(it))
+	}
+
+	if print.DisplayJSONFormat() {
+		print.All(ctx, ps...)
+		return
+	}
+
+	var (
+		order  = []string{}
+		byType = map[string][]print.Printable{}
+	)
+
+	// items of the same type share their table headers.
+	for _, p := range ps {
+		key := strings.Join(p.Headers(false), "|")
+
+		if _, ok := byType[key]; !ok {
+			order = append(order, key)
+		}
+
+		byType[key] = append(byType[key], p)
+	}
+
+	for _, key := range order {
+		print.All(ctx, byType[key]...)
+	}
+}
+
+// MinimumPrintable reduces the Item to its minimally printable details.
+func (it Item) MinimumPrintable() any {
+	return it
+}
+
+// Headers returns the human-readable names of properties in an Item
+// for printing out to a terminal in a columnar display.
+func (it Item) Headers(skipID bool) []string {
+	hs := append(
+		[]string{"Backup ID", "Backup Time", "Protected Resource"},
+		it.Entry.Headers(skipID)...)
+
+	if len(it.Snippet) > 0 {
+		hs = append(hs, "Snippet")
+	}
+
+	return hs
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (it Item) Values(skipID bool) []string {
+	vs := append(
+		[]string{
+			it.BackupID,
+			dttm.FormatToTabularDisplay(it.BackupCreatedAt),
+			it.ProtectedResource,
+		},
+		it.Entry.Values(skipID)...)
+
+	if len(it.Snippet) > 0 {
+		vs = append(vs, it.Snippet)
+	}
+
+	return vs
+}
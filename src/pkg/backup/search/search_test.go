@@ -0,0 +1,542 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/extensions"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+type SearchUnitSuite struct {
+	tester.Suite
+}
+
+func TestSearchUnitSuite(t *testing.T) {
+	suite.Run(t, &SearchUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+func bup(id, resource string, created time.Time) *backup.Backup {
+	return &backup.Backup{
+		BaseModel:             model.BaseModel{ID: model.StableID(id)},
+		CreationTime:          created,
+		ProtectedResourceName: resource,
+	}
+}
+
+func mailEntry(id, subject, sender string, modified time.Time) details.Entry {
+	return details.Entry{
+		RepoRef:     "tenant/exchange/user/email/inbox/" + id,
+		ItemRef:     id,
+		LocationRef: "Inbox",
+		ItemInfo: details.ItemInfo{
+			Exchange: &details.ExchangeInfo{
+				ItemType: details.ExchangeMail,
+				Subject:  subject,
+				Sender:   sender,
+				Modified: modified,
+			},
+		},
+	}
+}
+
+func eventEntry(id, subject string, modified time.Time) details.Entry {
+	return details.Entry{
+		RepoRef:     "tenant/exchange/user/events/calendar/" + id,
+		ItemRef:     id,
+		LocationRef: "Calendar",
+		ItemInfo: details.ItemInfo{
+			Exchange: &details.ExchangeInfo{
+				ItemType: details.ExchangeEvent,
+				Subject:  subject,
+				Modified: modified,
+			},
+		},
+	}
+}
+
+func fileEntry(id, name string, modified time.Time) details.Entry {
+	return details.Entry{
+		RepoRef:     "tenant/onedrive/user/files/drives/drive/root:/reports/" + id,
+		ItemRef:     id,
+		LocationRef: "root:/Reports",
+		ItemInfo: details.ItemInfo{
+			OneDrive: &details.OneDriveInfo{
+				ItemType: details.OneDriveItem,
+				ItemName: name,
+				Modified: modified,
+			},
+		},
+	}
+}
+
+func contentEntry(id, name, text string, modified time.Time) details.Entry {
+	ent := fileEntry(id, name, modified)
+	ent.Extension = &details.ExtensionData{
+		Data: map[string]any{extensions.KContentText: text},
+	}
+
+	return ent
+}
+
+func detailsOf(ents ...details.Entry) *details.Details {
+	return &details.Details{
+		DetailsModel: details.DetailsModel{Entries: ents},
+	}
+}
+
+// persisted produces the index and contents stored with a backup, and
+// moves the items' text out of the details, like backups do.
+func persisted(deets *details.Details) (*BackupIndex, *Contents) {
+	idx := NewBackupIndex(deets)
+	return idx, TakeContents(deets)
+}
+
+type mockBackupGetter struct {
+	backups  []*backup.Backup
+	indexes  map[string]*BackupIndex
+	contents map[string]*Contents
+	details  map[string]*details.Details
+	// detailsRead records the backups whose details were read.
+	detailsRead map[string]struct{}
+}
+
+func (bg mockBackupGetter) BackupsByTag(
+	context.Context,
+	...store.FilterOption,
+) ([]*backup.Backup, error) {
+	return bg.backups, nil
+}
+
+func (bg mockBackupGetter) GetBackupSearchIndex(
+	_ context.Context,
+	backupID string,
+) (*BackupIndex, *backup.Backup, *fault.Bus) {
+	errs := fault.New(false)
+
+	bi, ok := bg.indexes[backupID]
+	if !ok {
+		return nil, nil, errs.Fail(clues.New("no search index"))
+	}
+
+	return bi, nil, errs
+}
+
+func (bg mockBackupGetter) GetBackupContents(
+	_ context.Context,
+	backupID string,
+) (*Contents, *backup.Backup, *fault.Bus) {
+	errs := fault.New(false)
+
+	c, ok := bg.contents[backupID]
+	if !ok {
+		return nil, nil, errs.Fail(clues.New("no contents"))
+	}
+
+	return c, nil, errs
+}
+
+func (bg mockBackupGetter) GetBackupDetails(
+	_ context.Context,
+	backupID string,
+) (*details.Details, *backup.Backup, *fault.Bus) {
+	errs := fault.New(false)
+
+	if bg.detailsRead != nil {
+		bg.detailsRead[backupID] = struct{}{}
+	}
+
+	deets, ok := bg.details[backupID]
+	if !ok {
+		return nil, nil, errs.Fail(clues.New("no details"))
+	}
+
+	return deets, nil, errs
+}
+
+// ---------------------------------------------------------------------------
+// tests
+// ---------------------------------------------------------------------------
+
+func (suite *SearchUnitSuite) TestBackupIndex_Match() {
+	var (
+		t1 = time.Now().Add(-3 * time.Hour).UTC()
+		t2 = time.Now().Add(-2 * time.Hour).UTC()
+		t3 = time.Now().Add(-time.Hour).UTC()
+	)
+
+	bi := NewBackupIndex(detailsOf(
+		mailEntry("m1", "Budget review", "bob@example.com", t1),
+		mailEntry("m2", "Lunch", "carol@example.com", t2),
+		fileEntry("f1", "Fiscal 22.xlsx", t3),
+		contentEntry("f2", "notes.txt", "the quarterly forecast is up", t2)))
+
+	table := []struct {
+		name   string
+		q      Query
+		expect []string
+	}{
+		{
+			name:   "subject",
+			q:      Query{Subject: "budget"},
+			expect: []string{"m1"},
+		},
+		{
+			name:   "subject word prefixes",
+			q:      Query{Subject: "REV bud"},
+			expect: []string{"m1"},
+		},
+		{
+			name:   "subject word in the middle of a word",
+			q:      Query{Subject: "dget"},
+			expect: []string{},
+		},
+		{
+			name:   "sender",
+			q:      Query{Sender: "CAROL"},
+			expect: []string{"m2"},
+		},
+		{
+			name:   "sender domain",
+			q:      Query{Sender: "example.com"},
+			expect: []string{"m1", "m2"},
+		},
+		{
+			name:   "file name",
+			q:      Query{FileName: "fiscal 22"},
+			expect: []string{"f1"},
+		},
+		{
+			name:   "content",
+			q:      Query{Content: "Quarterly Forecast"},
+			expect: []string{"f2"},
+		},
+		{
+			name:   "content and file name",
+			q:      Query{Content: "forecast", FileName: "fiscal"},
+			expect: []string{},
+		},
+		{
+			name:   "modified after",
+			q:      Query{ModifiedAfter: t1},
+			expect: []string{"m2", "f1", "f2"},
+		},
+		{
+			name:   "modified before",
+			q:      Query{Subject: "budget", ModifiedBefore: t2},
+			expect: []string{"m1"},
+		},
+		{
+			name:   "every property must match",
+			q:      Query{Subject: "budget", Sender: "carol"},
+			expect: []string{},
+		},
+		{
+			name:   "value without words",
+			q:      Query{Subject: "--"},
+			expect: []string{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			res := []string{}
+			for key := range bi.Match(test.q) {
+				res = append(res, key.ItemRef)
+			}
+
+			assert.ElementsMatch(t, test.expect, res)
+		})
+	}
+}
+
+func (suite *SearchUnitSuite) TestNewBackupIndex_dedupe() {
+	var (
+		t   = suite.T()
+		now = time.Now().UTC()
+	)
+
+	bi := NewBackupIndex(detailsOf(
+		mailEntry("m1", "hi there", "bob", now),
+		mailEntry("m1", "hi there", "bob", now),
+		mailEntry("m2", "hi", "bob", now),
+		// items in other categories can share an ID.
+		eventEntry("m1", "hi", now)))
+
+	require.Len(t, bi.Items, 3)
+	assert.Equal(t, IndexItem{Category: path.EmailCategory.String(), ItemRef: "m1", Modified: now}, bi.Items[0])
+	assert.Equal(t, IndexItem{Category: path.EmailCategory.String(), ItemRef: "m2", Modified: now}, bi.Items[1])
+	assert.Equal(t, IndexItem{Category: path.EventsCategory.String(), ItemRef: "m1", Modified: now}, bi.Items[2])
+
+	require.Len(t, bi.Subjects, 2)
+	assert.Equal(t, Term{Word: "hi", Items: []int{0, 1, 2}}, bi.Subjects[0])
+	assert.Equal(t, Term{Word: "there", Items: []int{0}}, bi.Subjects[1])
+
+	assert.Equal(
+		t,
+		map[ItemKey]struct{}{
+			{Category: path.EmailCategory.String(), ItemRef: "m1"}: {},
+		},
+		bi.Match(Query{Subject: "there"}))
+}
+
+func (suite *SearchUnitSuite) TestBackupIndex_MarshalUnmarshal() {
+	var (
+		t   = suite.T()
+		now = time.Now().UTC().Truncate(time.Second)
+		bi  = NewBackupIndex(detailsOf(mailEntry("m1", "hi", "bob", now)))
+	)
+
+	bs, err := bi.Marshal()
+	require.NoError(t, err, clues.ToCore(err))
+
+	var result BackupIndex
+
+	err = UnmarshalIndexTo(&result)(io.NopCloser(bytes.NewReader(bs)))
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, *bi, result)
+
+	// indexes of another version aren't used
+	bi.Version = indexVersion + 1
+
+	bs, err = bi.Marshal()
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = UnmarshalIndexTo(&BackupIndex{})(io.NopCloser(bytes.NewReader(bs)))
+	assert.Error(t, err, clues.ToCore(err))
+
+	err = UnmarshalIndexTo(&BackupIndex{})(io.NopCloser(strings.NewReader("not an index")))
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *SearchUnitSuite) TestSearch() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		t1 = time.Now().Add(-3 * time.Hour).UTC()
+		t2 = time.Now().Add(-2 * time.Hour).UTC()
+		t3 = time.Now().Add(-time.Hour).UTC()
+
+		d1 = detailsOf(
+			mailEntry("m1", "Budget review", "bob@example.com", t1),
+			fileEntry("f1", "Fiscal 22.xlsx", t1))
+		d2 = detailsOf(
+			mailEntry("m1", "Budget review", "bob@example.com", t1),
+			fileEntry("f1", "Fiscal 22.xlsx", t3),
+			contentEntry("f2", "notes.txt", "the quarterly forecast is up", t2))
+		d3 = detailsOf(
+			mailEntry("m3", "Lunch", "carol@example.com", t2),
+			eventEntry("m3", "Lunch plans", t2))
+
+		i2, c2 = persisted(d2)
+		i3, c3 = persisted(d3)
+
+		bg = mockBackupGetter{
+			backups: []*backup.Backup{
+				bup("b1", "alice", t1),
+				bup("b2", "alice", t3),
+				bup("b3", "alice", t2),
+				bup("b4", "alice", t2),
+			},
+			// b1 predates stored indexes, and b4 can't be read.
+			indexes: map[string]*BackupIndex{
+				"b2": i2,
+				"b3": i3,
+			},
+			contents: map[string]*Contents{
+				"b2": c2,
+				"b3": c3,
+			},
+			details: map[string]*details.Details{
+				"b1": d1,
+				"b2": d2,
+				"b3": d3,
+			},
+		}
+	)
+
+	type result struct {
+		backupID string
+		itemRef  string
+	}
+
+	table := []struct {
+		name        string
+		q           Query
+		expect      []result
+		expectReads []string
+	}{
+		{
+			name:        "newer backups first",
+			q:           Query{Subject: "budget"},
+			expect:      []result{{"b2", "m1"}, {"b1", "m1"}},
+			expectReads: []string{"b1", "b2", "b4"},
+		},
+		{
+			name:        "only backups with matches are read",
+			q:           Query{Sender: "carol"},
+			expect:      []result{{"b3", "m3"}},
+			expectReads: []string{"b1", "b3", "b4"},
+		},
+		{
+			name:        "items sharing an ID in different categories",
+			q:           Query{Subject: "lunch"},
+			expect:      []result{{"b3", "m3"}, {"b3", "m3"}},
+			expectReads: []string{"b1", "b3", "b4"},
+		},
+		{
+			name:        "content",
+			q:           Query{Content: "quarterly forecast"},
+			expect:      []result{{"b2", "f2"}},
+			expectReads: []string{"b1", "b2", "b4"},
+		},
+		{
+			name:        "content words out of order",
+			q:           Query{Content: "forecast quarterly"},
+			expect:      []result{},
+			expectReads: []string{"b1", "b2", "b4"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			bg.detailsRead = map[string]struct{}{}
+
+			items, err := Search(ctx, bg, test.q)
+			require.NoError(t, err, clues.ToCore(err))
+
+			res := []result{}
+			for _, it := range items {
+				res = append(res, result{it.BackupID, it.ItemRef})
+			}
+
+			assert.Equal(t, test.expect, res)
+
+			reads := []string{}
+			for id := range bg.detailsRead {
+				reads = append(reads, id)
+			}
+
+			assert.ElementsMatch(t, test.expectReads, reads)
+		})
+	}
+}
+
+func (suite *SearchUnitSuite) TestSearch_snippet() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		now   = time.Now().UTC()
+		deets = detailsOf(
+			contentEntry("f1", "notes.txt", "the quarterly forecast is up", now),
+			fileEntry("f2", "notes.txt", now))
+		// older backups keep the text in their details, and have no index.
+		legacy = detailsOf(
+			contentEntry("f3", "notes.txt", "the forecast for next year", now))
+
+		idx, contents = persisted(deets)
+
+		bg = mockBackupGetter{
+			backups:  []*backup.Backup{bup("b1", "alice", now), bup("b2", "bob", now)},
+			indexes:  map[string]*BackupIndex{"b1": idx},
+			contents: map[string]*Contents{"b1": contents},
+			details: map[string]*details.Details{
+				"b1": deets,
+				"b2": legacy,
+			},
+		}
+	)
+
+	require.Nil(t, deets.Entries[0].Extension, "text is kept out of the details")
+
+	res, err := Search(ctx, bg, Query{FileName: "notes"})
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, res, 3)
+
+	for _, it := range res {
+		assert.Empty(t, it.Snippet, "only content searches produce snippets")
+	}
+
+	res, err = Search(ctx, bg, Query{Content: "FORECAST"})
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, res, 2)
+	assert.Equal(t, "the quarterly **forecast** is up", res[0].Snippet)
+	assert.Equal(t, "the **forecast** for next year", res[1].Snippet)
+	assert.Equal(t, "Snippet", res[0].Headers(false)[len(res[0].Headers(false))-1])
+}
+
+func (suite *SearchUnitSuite) TestSnippet() {
+	long := strings.Repeat("a", 100)
+
+	table := []struct {
+		name      string
+		text      string
+		substr    string
+		expect    string
+		expectHit assert.BoolAssertionFunc
+	}{
+		{
+			name:      "no match",
+			text:      "some text",
+			substr:    "other",
+			expectHit: assert.False,
+		},
+		{
+			name:      "keeps the case of the text",
+			text:      "Some Text",
+			substr:    "some",
+			expect:    "**Some** Text",
+			expectHit: assert.True,
+		},
+		{
+			name:      "clipped on both sides",
+			text:      long + "match" + long,
+			substr:    "match",
+			expect:    "..." + long[:60] + "**match**" + long[:60] + "...",
+			expectHit: assert.True,
+		},
+		{
+			name:      "clipped on a character boundary",
+			text:      "é" + strings.Repeat("a", 59) + "match",
+			substr:    "match",
+			expect:    "é" + strings.Repeat("a", 59) + "**match**",
+			expectHit: assert.True,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			snip, ok := snippet(test.text, test.substr)
+			test.expectHit(t, ok)
+			assert.Equal(t, test.expect, snip)
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/dttm"
+)
+
+// oneNoteSectionExt is the file extension of OneNote section files.
+const oneNoteSectionExt = ".one"
+
+// Problem identifies the way in which part of a backup failed verification.
+type Problem string
+
+const (
+	// the backup's details couldn't be read from the repository.
+	DetailsUnreadable Problem = "details unreadable"
+	// the backup's errors couldn't be read from the repository.
+	ErrorsUnreadable Problem = "errors unreadable"
+	// a details entry has no item data in the snapshot.
+	MissingData Problem = "missing data"
+	// item data in the snapshot has no details entry.
+	MissingDetails Problem = "missing details"
+	// item data couldn't be read, decrypted or decompressed.
+	UnreadableData Problem = "unreadable data"
+	// the size of the item data differs from the size in the snapshot.
+	SizeMismatch Problem = "size mismatch"
+	// the size of the item data differs from the size in the item's
+	// details.  Files can change between enumeration and download, so
+	// this gets reported as a warning instead of a failure.
+	DetailsSizeMismatch Problem = "details size mismatch"
+)
+
+// File is a file found in a backup's snapshot.
+type File struct {
+	// RepoRef is the file's path, in the format of details.Entry.RepoRef.
+	RepoRef string
+	// Size is the size of the file's content as recorded in the snapshot.
+	Size int64
+	// Meta is true for files that hold the metadata of another item.
+	// Those files never get their own details entry.
+	Meta bool
+}
+
+// Content describes the data read from a File.
+type Content struct {
+	Size int64
+	// DelInFlight is true for items that were deleted while they were
+	// backed up.  Those items are stored without data or details entry.
+	DelInFlight bool
+}
+
+// Failure is a single verification failure.
+type Failure struct {
+	Problem  Problem `json:"problem"`
+	RepoRef  string  `json:"repoRef,omitempty"`
+	Location string  `json:"location,omitempty"`
+	Reason   string  `json:"reason,omitempty"`
+}
+
+// Report holds the results of verifying a backup.
+type Report struct {
+	BackupID          string    `json:"backupID"`
+	ProtectedResource string    `json:"protectedResource"`
+	BackupCreatedAt   time.Time `json:"backupCreatedAt"`
+	VerifiedAt        time.Time `json:"verifiedAt"`
+	Passed            bool      `json:"passed"`
+
+	// DetailsEntries counts the items listed in the backup's details.
+	DetailsEntries int `json:"detailsEntries"`
+	// SnapshotFiles counts the files stored in the backup's snapshot,
+	// including the files holding item metadata.
+	SnapshotFiles int `json:"snapshotFiles"`
+	// FilesRead counts the files whose data was read.  Fewer than
+	// SnapshotFiles when only a sample gets read.
+	FilesRead int   `json:"filesRead"`
+	BytesRead int64 `json:"bytesRead"`
+
+	Failures []Failure `json:"failures"`
+	// Warnings hold problems that don't fail verification.
+	Warnings []Failure `json:"warnings"`
+}
+
+// ---------------------------------------------------------------------------
+// verification
+// ---------------------------------------------------------------------------
+
+// Verifier cross-checks the details of a backup against the files in its
+// snapshot, and records the results in a Report.
+type Verifier struct {
+	report  Report
+	entries map[string]*details.Entry
+	files   []File
+	found   map[string]struct{}
+}
+
+// NewVerifier starts a report for the backup.  Call SetDetails, AddFile for
+// every file in the backup's snapshot, then AddContent for the files
+// returned by ToRead, before producing the Report.
+func NewVerifier(backupID, protectedResource string, createdAt time.Time) *Verifier {
+	return &Verifier{
+		report: Report{
+			BackupID:          backupID,
+			ProtectedResource: protectedResource,
+			BackupCreatedAt:   createdAt,
+			Failures:          []Failure{},
+			Warnings:          []Failure{},
+		},
+		found: map[string]struct{}{},
+	}
+}
+
+// Fail records a failure that wasn't found by the Verifier itself, such as
+// a streamstore blob that couldn't be read.
+func (v *Verifier) Fail(f Failure) {
+	v.report.Failures = append(v.report.Failures, f)
+}
+
+// Warn records a problem that doesn't fail verification.
+func (v *Verifier) Warn(f Failure) {
+	v.report.Warnings = append(v.report.Warnings, f)
+}
+
+// SetDetails provides the details to check against.  Without details, the
+// files can still be read, but can't be matched to details entries.
+func (v *Verifier) SetDetails(deets *details.Details) {
+	v.entries = map[string]*details.Entry{}
+
+	for _, ent := range deets.Items() {
+		v.entries[ent.RepoRef] = ent
+	}
+
+	v.report.DetailsEntries = len(v.entries)
+}
+
+// AddFile records a file found in the snapshot.
+func (v *Verifier) AddFile(f File) {
+	v.files = append(v.files, f)
+	v.found[f.RepoRef] = struct{}{}
+	v.report.SnapshotFiles++
+}
+
+// ToRead returns the files that should be read.  If sample is greater than
+// zero, a random selection of that many files gets returned instead of all
+// files.  Item files without a details entry are always included, since
+// only their content shows whether the item was deleted during the backup.
+func (v *Verifier) ToRead(sample int) []File {
+	if sample <= 0 || sample >= len(v.files) {
+		return v.files
+	}
+
+	var (
+		res       = make([]File, 0, sample)
+		remaining = make([]File, 0, len(v.files))
+	)
+
+	for _, f := range v.files {
+		if v.needsDetails(f) && v.entry(f) == nil {
+			res = append(res, f)
+			continue
+		}
+
+		remaining = append(remaining, f)
+	}
+
+	rand.Shuffle(len(remaining), func(i, j int) {
+		remaining[i], remaining[j] = remaining[j], remaining[i]
+	})
+
+	return append(res, remaining[:max(sample-len(res), 0)]...)
+}
+
+// AddContent records the result of reading the file.
+func (v *Verifier) AddContent(f File, c Content, err error) {
+	ent := v.entry(f)
+
+	fail := Failure{RepoRef: f.RepoRef}
+	if ent != nil {
+		fail.Location = ent.LocationRef
+	}
+
+	if err != nil {
+		fail.Problem = UnreadableData
+		fail.Reason = err.Error()
+		v.Fail(fail)
+
+		return
+	}
+
+	v.report.FilesRead++
+	v.report.BytesRead += c.Size
+
+	if c.Size != f.Size {
+		fail.Problem = SizeMismatch
+		fail.Reason = fmt.Sprintf("read %d bytes; snapshot records %d", c.Size, f.Size)
+		v.Fail(fail)
+
+		return
+	}
+
+	if !v.needsDetails(f) {
+		return
+	}
+
+	if ent == nil {
+		if !c.DelInFlight {
+			fail.Problem = MissingDetails
+			v.Fail(fail)
+		}
+
+		return
+	}
+
+	if size, ok := dataSize(ent.ItemInfo); ok && size != c.Size {
+		fail.Problem = DetailsSizeMismatch
+		fail.Reason = fmt.Sprintf("read %d bytes; details record %d", c.Size, size)
+		v.Warn(fail)
+	}
+}
+
+// Report completes and returns the report.
+func (v *Verifier) Report() Report {
+	for rr, ent := range v.entries {
+		if _, ok := v.found[rr]; !ok {
+			v.Fail(Failure{
+				Problem:  MissingData,
+				RepoRef:  rr,
+				Location: ent.LocationRef,
+			})
+		}
+	}
+
+	sortFailures(v.report.Failures)
+	sortFailures(v.report.Warnings)
+
+	v.report.VerifiedAt = time.Now().UTC()
+	v.report.Passed = len(v.report.Failures) == 0
+
+	return v.report
+}
+
+func sortFailures(fs []Failure) {
+	sort.Slice(fs, func(i, j int) bool {
+		if fs[i].Problem != fs[j].Problem {
+			return fs[i].Problem < fs[j].Problem
+		}
+
+		return fs[i].RepoRef < fs[j].RepoRef
+	})
+}
+
+// needsDetails is true if the file should have a details entry.  That
+// can only be checked when the details could be read.
+func (v *Verifier) needsDetails(f File) bool {
+	return v.entries != nil && !f.Meta
+}
+
+func (v *Verifier) entry(f File) *details.Entry {
+	return v.entries[f.RepoRef]
+}
+
+// dataSize returns the size recorded in the details for items that are
+// stored as-is.  Other items are serialized when they get stored, and the
+// size in their details doesn't count the stored bytes.  OneNote sections
+// are stored as their serialized pages, and backups made before their
+// serialized size got recorded list the size of the .one file instead.
+func dataSize(info details.ItemInfo) (int64, bool) {
+	var (
+		name string
+		size int64
+	)
+
+	switch {
+	case info.OneDrive != nil:
+		name, size = info.OneDrive.ItemName, info.OneDrive.Size
+
+	case info.SharePoint != nil && info.SharePoint.ItemType == details.SharePointLibrary:
+		name, size = info.SharePoint.ItemName, info.SharePoint.Size
+
+	case info.Groups != nil && info.Groups.ItemType == details.SharePointLibrary:
+		name, size = info.Groups.ItemName, info.Groups.Size
+
+	default:
+		return 0, false
+	}
+
+	if strings.HasSuffix(strings.ToLower(name), oneNoteSectionExt) {
+		return 0, false
+	}
+
+	return size, true
+}
+
+// ---------------------------------------------------------------------------
+// printing
+// ---------------------------------------------------------------------------
+
+// Print writes the report to StdOut, in the format requested by the caller.
+func (r Report) Print(ctx context.Context) {
+	print.Item(ctx, r)
+
+	if print.DisplayJSONFormat() {
+		return
+	}
+
+	printFailures(ctx, "\nFailures:", r.Failures)
+	printFailures(ctx, "\nWarnings:", r.Warnings)
+}
+
+func printFailures(ctx context.Context, title string, fs []Failure) {
+	if len(fs) == 0 {
+		return
+	}
+
+	ps := make([]print.Printable, 0, len(fs))
+	for _, f := range fs {
+		ps = append(ps, print.Printable(f))
+	}
+
+	print.Info(ctx, title)
+	print.All(ctx, ps...)
+}
+
+// MinimumPrintable reduces the Report to its minimally printable details.
+func (r Report) MinimumPrintable() any {
+	return r
+}
+
+// Headers returns the human-readable names of properties in a Report
+// for printing out to a terminal in a columnar display.
+func (r Report) Headers(skipID bool) []string {
+	headers := []string{
+		"ID",
+		"Result",
+		"Protected resource",
+		"Created at",
+		"Details entries",
+		"Snapshot files",
+		"Files read",
+		"Data read",
+		"Failures",
+		"Warnings",
+	}
+
+	if skipID {
+		headers = headers[1:]
+	}
+
+	return headers
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (r Report) Values(skipID bool) []string {
+	result := "Failed"
+	if r.Passed {
+		result = "Passed"
+	}
+
+	values := []string{
+		r.BackupID,
+		result,
+		r.ProtectedResource,
+		dttm.FormatToTabularDisplay(r.BackupCreatedAt),
+		strconv.Itoa(r.DetailsEntries),
+		strconv.Itoa(r.SnapshotFiles),
+		strconv.Itoa(r.FilesRead),
+		humanize.Bytes(uint64(r.BytesRead)),
+		strconv.Itoa(len(r.Failures)),
+		strconv.Itoa(len(r.Warnings)),
+	}
+
+	if skipID {
+		values = values[1:]
+	}
+
+	return values
+}
+
+// MinimumPrintable reduces the Failure to its minimally printable details.
+func (f Failure) MinimumPrintable() any {
+	return f
+}
+
+// Headers returns the human-readable names of properties in a Failure
+// for printing out to a terminal in a columnar display.
+func (f Failure) Headers(bool) []string {
+	return []string{"Problem", "Item", "Location", "Reason"}
+}
+
+// Values returns the values matching the Headers list for printing
+// out to a terminal in a columnar display.
+func (f Failure) Values(bool) []string {
+	return []string{string(f.Problem), f.RepoRef, f.Location, f.Reason}
+}
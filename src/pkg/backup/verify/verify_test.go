@@ -0,0 +1,287 @@
+package verify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+type VerifyUnitSuite struct {
+	tester.Suite
+}
+
+func TestVerifyUnitSuite(t *testing.T) {
+	suite.Run(t, &VerifyUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+const (
+	mailRef  = "tenant/exchange/user/email/inbox/mail"
+	fileRef  = "tenant/onedrive/user/files/drives/d/root:/file.data"
+	metaRef  = "tenant/onedrive/user/files/drives/d/root:/file.meta"
+	otherRef = "tenant/exchange/user/email/inbox/other"
+)
+
+func testDetails() *details.Details {
+	return &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{
+				{
+					RepoRef:     mailRef,
+					LocationRef: "Inbox",
+					ItemInfo: details.ItemInfo{
+						Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMail, Size: 5},
+					},
+				},
+				{
+					RepoRef:     fileRef,
+					LocationRef: "root:",
+					ItemInfo: details.ItemInfo{
+						OneDrive: &details.OneDriveInfo{ItemType: details.OneDriveItem, Size: 10},
+					},
+				},
+				{
+					RepoRef: "tenant/exchange/user/email/inbox",
+					ItemInfo: details.ItemInfo{
+						Folder: &details.FolderInfo{ItemType: details.FolderItem, DisplayName: "Inbox"},
+					},
+				},
+			},
+		},
+	}
+}
+
+type read struct {
+	file    File
+	content Content
+	err     error
+}
+
+func (suite *VerifyUnitSuite) TestVerifier() {
+	var (
+		mail  = File{RepoRef: mailRef, Size: 20}
+		file  = File{RepoRef: fileRef, Size: 10}
+		meta  = File{RepoRef: metaRef, Size: 3, Meta: true}
+		other = File{RepoRef: otherRef, Size: 4}
+	)
+
+	oneNoteDeets := testDetails()
+	oneNoteDeets.Entries[1].OneDrive.ItemName = "section.one"
+
+	table := []struct {
+		name         string
+		deets        *details.Details
+		files        []File
+		reads        []read
+		expect       []Failure
+		expectWarned []Failure
+	}{
+		{
+			name:  "passes",
+			deets: testDetails(),
+			files: []File{mail, file, meta},
+			reads: []read{
+				// the size of serialized items doesn't match their details.
+				{file: mail, content: Content{Size: 20}},
+				{file: file, content: Content{Size: 10}},
+				{file: meta, content: Content{Size: 3}},
+			},
+			expect: []Failure{},
+		},
+		{
+			name:  "missing data",
+			deets: testDetails(),
+			files: []File{mail, meta},
+			reads: []read{
+				{file: mail, content: Content{Size: 20}},
+			},
+			expect: []Failure{
+				{Problem: MissingData, RepoRef: fileRef, Location: "root:"},
+			},
+		},
+		{
+			name:  "missing details",
+			deets: testDetails(),
+			files: []File{mail, file, other},
+			reads: []read{
+				{file: other, content: Content{Size: 4}},
+			},
+			expect: []Failure{
+				{Problem: MissingDetails, RepoRef: otherRef},
+			},
+		},
+		{
+			name:  "deleted in flight",
+			deets: testDetails(),
+			files: []File{mail, file, other},
+			reads: []read{
+				{file: other, content: Content{Size: 4, DelInFlight: true}},
+			},
+			expect: []Failure{},
+		},
+		{
+			name:  "unreadable",
+			deets: testDetails(),
+			files: []File{mail, file},
+			reads: []read{
+				{file: file, err: assert.AnError},
+			},
+			expect: []Failure{
+				{Problem: UnreadableData, RepoRef: fileRef, Location: "root:", Reason: assert.AnError.Error()},
+			},
+		},
+		{
+			name:  "size differs from snapshot",
+			deets: testDetails(),
+			files: []File{mail, file},
+			reads: []read{
+				{file: mail, content: Content{Size: 19}},
+			},
+			expect: []Failure{
+				{
+					Problem:  SizeMismatch,
+					RepoRef:  mailRef,
+					Location: "Inbox",
+					Reason:   "read 19 bytes; snapshot records 20",
+				},
+			},
+		},
+		{
+			name:  "size differs from details",
+			deets: testDetails(),
+			files: []File{mail, {RepoRef: fileRef, Size: 11}},
+			reads: []read{
+				{file: File{RepoRef: fileRef, Size: 11}, content: Content{Size: 11}},
+			},
+			expect: []Failure{},
+			expectWarned: []Failure{
+				{
+					Problem:  DetailsSizeMismatch,
+					RepoRef:  fileRef,
+					Location: "root:",
+					Reason:   "read 11 bytes; details record 10",
+				},
+			},
+		},
+		{
+			name:  "onenote section size differs from details",
+			deets: oneNoteDeets,
+			files: []File{mail, {RepoRef: fileRef, Size: 11}},
+			reads: []read{
+				{file: File{RepoRef: fileRef, Size: 11}, content: Content{Size: 11}},
+			},
+			expect: []Failure{},
+		},
+		{
+			name:  "no details",
+			files: []File{mail, other},
+			reads: []read{
+				{file: mail, content: Content{Size: 20}},
+				{file: other, content: Content{Size: 4}},
+			},
+			expect: []Failure{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			v := NewVerifier("bid", "user", time.Now())
+
+			if test.deets != nil {
+				v.SetDetails(test.deets)
+			}
+
+			for _, f := range test.files {
+				v.AddFile(f)
+			}
+
+			for _, r := range test.reads {
+				v.AddContent(r.file, r.content, r.err)
+			}
+
+			expectWarned := test.expectWarned
+			if expectWarned == nil {
+				expectWarned = []Failure{}
+			}
+
+			rep := v.Report()
+			assert.Equal(t, test.expect, rep.Failures)
+			assert.Equal(t, expectWarned, rep.Warnings)
+			assert.Equal(t, len(test.expect) == 0, rep.Passed)
+			assert.Equal(t, len(test.files), rep.SnapshotFiles)
+		})
+	}
+}
+
+func (suite *VerifyUnitSuite) TestVerifier_Fail() {
+	t := suite.T()
+
+	v := NewVerifier("bid", "user", time.Now())
+	v.Fail(Failure{Problem: DetailsUnreadable, Reason: "corrupt"})
+
+	rep := v.Report()
+	assert.False(t, rep.Passed)
+	assert.Equal(t, []Failure{{Problem: DetailsUnreadable, Reason: "corrupt"}}, rep.Failures)
+}
+
+func (suite *VerifyUnitSuite) TestVerifier_ToRead() {
+	var (
+		mail  = File{RepoRef: mailRef}
+		file  = File{RepoRef: fileRef}
+		meta  = File{RepoRef: metaRef, Meta: true}
+		other = File{RepoRef: otherRef}
+	)
+
+	table := []struct {
+		name        string
+		sample      int
+		expectLen   int
+		expectFiles []File
+	}{
+		{
+			name:      "all",
+			expectLen: 4,
+		},
+		{
+			name:      "sample larger than files",
+			sample:    10,
+			expectLen: 4,
+		},
+		{
+			name:        "sample",
+			sample:      2,
+			expectLen:   2,
+			expectFiles: []File{other},
+		},
+		{
+			name:        "sample smaller than files without details",
+			sample:      1,
+			expectLen:   1,
+			expectFiles: []File{other},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			v := NewVerifier("bid", "user", time.Now())
+			v.SetDetails(testDetails())
+
+			for _, f := range []File{mail, file, meta, other} {
+				v.AddFile(f)
+			}
+
+			toRead := v.ToRead(test.sample)
+			assert.Len(t, toRead, test.expectLen)
+
+			for _, f := range test.expectFiles {
+				assert.Contains(t, toRead, f)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package control
 
 import (
+	"time"
+
 	"github.com/alcionai/corso/src/pkg/extensions"
 )
 
@@ -30,6 +32,18 @@ type BackupConfig struct {
 	Incrementals         IncrementalsConfig                 `json:"incrementalsConfig"`
 	M365                 BackupM365Config                   `json:"m365Config"`
 
+	// DryRun limits the backup to enumerating the data it would capture.
+	// Item data isn't fetched, and nothing is written to the repository.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DownloadRate caps the rate at which the backup downloads item content.
+	DownloadRate DownloadRateConfig `json:"downloadRate,omitempty"`
+
+	// CheckpointInterval is how often the backup flushes its upload progress
+	// to the repository.  Zero uses the default of 45 minutes, which is also
+	// the longest interval allowed.
+	CheckpointInterval time.Duration `json:"checkpointInterval,omitempty"`
+
 	// PreviewLimits defines the number of items and/or amount of data to fetch on
 	// a best-effort basis for preview backups.
 	//
@@ -65,6 +79,123 @@ type BackupM365Config struct {
 
 	// see: https://github.com/alcionai/corso/issues/4688
 	UseOldDriveDeltaProcess bool `json:"useOldDriveDeltaProcess"`
+
+	// ListItemVersions opts SharePoint list backups into including the
+	// version history of each list item.
+	ListItemVersions bool `json:"listItemVersions,omitempty"`
+
+	// MailMIME controls whether email backups capture the raw MIME content
+	// of each message.
+	MailMIME MailMIMEMode `json:"mailMime,omitempty"`
+}
+
+// DriveVersionsConfig describes which prior versions of drive files get
+// backed up alongside each file's current content.
+type DriveVersionsConfig struct {
+	// Enabled opts into fetching the version history of every backed up
+	// file.  Disabled by default, since each version is a full copy of the
+	// file.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MaxCount caps the number of prior versions kept per file, favoring the
+	// most recent ones.  Zero means no limit.
+	MaxCount int `json:"maxCount,omitempty"`
+
+	// MaxAge drops prior versions that were last modified longer ago than the
+	// given duration.  Zero means no limit.
+	MaxAge time.Duration `json:"maxAge,omitempty"`
+}
+
+// WellKnownMailFolder identifies one of the mailbox folders that Exchange
+// creates for every user.
+type WellKnownMailFolder string
+
+const (
+	RecoverableItemsDeletions WellKnownMailFolder = "recoverable-deletions"
+	RecoverableItemsPurges    WellKnownMailFolder = "recoverable-purges"
+	RecoverableItemsVersions  WellKnownMailFolder = "recoverable-versions"
+	JunkEmail                 WellKnownMailFolder = "junk"
+	Drafts                    WellKnownMailFolder = "drafts"
+	Outbox                    WellKnownMailFolder = "outbox"
+	ConversationHistory       WellKnownMailFolder = "conversation-history"
+)
+
+// RecoverableItems is shorthand for all of the Recoverable Items subtrees.
+const RecoverableItems WellKnownMailFolder = "recoverable-items"
+
+// WellKnownMailFolders lists every folder that can be included in, or
+// excluded from, email backups.
+var WellKnownMailFolders = []WellKnownMailFolder{
+	RecoverableItems,
+	RecoverableItemsDeletions,
+	RecoverableItemsPurges,
+	RecoverableItemsVersions,
+	JunkEmail,
+	Drafts,
+	Outbox,
+	ConversationHistory,
+}
+
+// MailFoldersConfig describes the well-known mail folders to include in, or
+// exclude from, email backups.  Recoverable Items are excluded by default,
+// while all other folders are included by default.  Exclusions take priority
+// over inclusions, and apply to the folder along with all of its subfolders.
+type MailFoldersConfig struct {
+	Include []WellKnownMailFolder `json:"include,omitempty"`
+	Exclude []WellKnownMailFolder `json:"exclude,omitempty"`
+}
+
+// IsIncluded reports whether the folder is part of the backup.
+func (c MailFoldersConfig) IsIncluded(f WellKnownMailFolder) bool {
+	if containsMailFolder(c.Exclude, f) {
+		return false
+	}
+
+	switch f {
+	case RecoverableItemsDeletions, RecoverableItemsPurges, RecoverableItemsVersions:
+		return containsMailFolder(c.Include, f)
+	}
+
+	return true
+}
+
+func containsMailFolder(fs []WellKnownMailFolder, f WellKnownMailFolder) bool {
+	for _, is := range fs {
+		if is == f {
+			return true
+		}
+
+		if is == RecoverableItems &&
+			(f == RecoverableItemsDeletions || f == RecoverableItemsPurges || f == RecoverableItemsVersions) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MailMIMEMode describes how email backups store the raw MIME content of
+// each message.  Graph's json representation of a message drops some headers,
+// S/MIME signatures, and the exact formatting of the original message, all of
+// which are retained by the MIME content.
+type MailMIMEMode string
+
+const (
+	// MailMIMENone stores messages in their json representation only.
+	MailMIMENone MailMIMEMode = ""
+	// MailMIMEInclude stores the raw MIME content alongside the complete
+	// json representation of each message.
+	MailMIMEInclude MailMIMEMode = "include"
+	// MailMIMEOnly stores the raw MIME content in place of the json body and
+	// attachments.  The remaining json properties are kept to describe the
+	// message in backup details.
+	MailMIMEOnly MailMIMEMode = "only"
+)
+
+// MailMIMEModes lists the accepted, non-default, MIME modes.
+var MailMIMEModes = []MailMIMEMode{
+	MailMIMEInclude,
+	MailMIMEOnly,
 }
 
 type Parallelism struct {
@@ -16,6 +16,12 @@ type ExportConfig struct {
 	// ex: html vs pst vs other.
 	// Default format is decided on a per-service or per-data basis.
 	Format FormatType
+
+	// Version selects which backed up version of each drive file gets
+	// exported.  Accepts a version ID, or AllVersions to export every
+	// version alongside the current one.  Files without a matching version
+	// are skipped.  Defaults to empty, which exports the current version.
+	Version string
 }
 
 type FormatType string
@@ -1,6 +1,8 @@
 package control
 
 import (
+	"time"
+
 	"github.com/alcionai/corso/src/pkg/control/repository"
 	"github.com/alcionai/corso/src/pkg/extensions"
 )
@@ -28,12 +30,80 @@ type Options struct {
 	// had already backed up.
 	PreviewLimits PreviewItemLimits `json:"previewItemLimits"`
 
+	// DriveVersions controls the backup of prior versions of files in
+	// OneDrive and SharePoint document libraries.
+	DriveVersions DriveVersionsConfig `json:"driveVersions,omitempty"`
+
+	// ListItemVersions opts SharePoint list backups into including the
+	// version history of each list item.
+	ListItemVersions bool `json:"listItemVersions,omitempty"`
+
+	// MailMIME controls whether email backups capture the raw MIME content
+	// of each message.
+	MailMIME MailMIMEMode `json:"mailMime,omitempty"`
+
+	// DryRun limits backups to enumerating the data they would capture.
+	// Item data isn't fetched, and nothing is written to the repository.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DownloadRate caps the rate at which backups download item content.
+	DownloadRate DownloadRateConfig `json:"downloadRate,omitempty"`
+
+	// CheckpointInterval is how often backups flush their upload progress to
+	// the repository.  Zero uses the default of 45 minutes, which is also the
+	// longest interval allowed.
+	CheckpointInterval time.Duration `json:"checkpointInterval,omitempty"`
+
 	// specifying a resource tuple in this map allows that resource to produce
 	// a Skip instead of a recoverable error in case of a failure due to 503 when
 	// retrieving calendar event item data.
 	SkipEventsOnInstance503ForResources map[string]struct{}
 }
 
+// DownloadRateConfig caps the bytes per second that backups download from
+// the service, optionally varying the cap by the time of day.
+type DownloadRateConfig struct {
+	// BytesPerSecond is the cap outside of any scheduled window.  Zero means
+	// no limit.
+	BytesPerSecond int64 `json:"bytesPerSecond,omitempty"`
+	// Schedule replaces BytesPerSecond during each of its windows.  The first
+	// window containing the current time wins.
+	Schedule []DownloadRateWindow `json:"schedule,omitempty"`
+}
+
+// DownloadRateWindow is a daily span of local time with its own download cap.
+type DownloadRateWindow struct {
+	// Start and End are offsets from midnight, local time.  A window whose
+	// End comes before its Start wraps past midnight.
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+	// BytesPerSecond is the cap during the window.  Zero means no limit.
+	BytesPerSecond int64 `json:"bytesPerSecond,omitempty"`
+}
+
+// RateAt returns the download cap, in bytes per second, at time t.  Zero
+// means no limit.
+func (c DownloadRateConfig) RateAt(t time.Time) int64 {
+	h, m, sec := t.Clock()
+	offset := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec)*time.Second
+
+	for _, w := range c.Schedule {
+		if w.contains(offset) {
+			return w.BytesPerSecond
+		}
+	}
+
+	return c.BytesPerSecond
+}
+
+func (w DownloadRateWindow) contains(offset time.Duration) bool {
+	if w.Start <= w.End {
+		return offset >= w.Start && offset < w.End
+	}
+
+	return offset >= w.Start || offset < w.End
+}
+
 // RateLimiter is the set of options applied to any external service facing rate
 // limiters Corso may use during backups or restores.
 type RateLimiter struct {
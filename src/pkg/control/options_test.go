@@ -0,0 +1,90 @@
+package control_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type OptionsUnitSuite struct {
+	tester.Suite
+}
+
+func TestOptionsUnitSuite(t *testing.T) {
+	suite.Run(t, &OptionsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *OptionsUnitSuite) TestDownloadRateConfig_RateAt() {
+	cfg := control.DownloadRateConfig{
+		BytesPerSecond: 1000,
+		Schedule: []control.DownloadRateWindow{
+			{
+				Start:          9 * time.Hour,
+				End:            17 * time.Hour,
+				BytesPerSecond: 10,
+			},
+			{
+				// wraps past midnight
+				Start: 22 * time.Hour,
+				End:   6 * time.Hour,
+			},
+		},
+	}
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 3, 4, hour, minute, 0, 0, time.Local)
+	}
+
+	table := []struct {
+		name   string
+		cfg    control.DownloadRateConfig
+		t      time.Time
+		expect int64
+	}{
+		{
+			name:   "no config",
+			t:      at(12, 0),
+			expect: 0,
+		},
+		{
+			name:   "outside of windows",
+			cfg:    cfg,
+			t:      at(8, 59),
+			expect: 1000,
+		},
+		{
+			name:   "window start",
+			cfg:    cfg,
+			t:      at(9, 0),
+			expect: 10,
+		},
+		{
+			name:   "window end is exclusive",
+			cfg:    cfg,
+			t:      at(17, 0),
+			expect: 1000,
+		},
+		{
+			name:   "wrapping window before midnight",
+			cfg:    cfg,
+			t:      at(23, 30),
+			expect: 0,
+		},
+		{
+			name:   "wrapping window after midnight",
+			cfg:    cfg,
+			t:      at(1, 0),
+			expect: 0,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, test.cfg.RateAt(test.t))
+		})
+	}
+}
@@ -38,6 +38,10 @@ func IsValidCollisionPolicy(cp CollisionPolicy) bool {
 
 const RootLocation = "/"
 
+// AllVersions can be used as the RestoreConfig or ExportConfig Version to
+// process every backed up version of a drive file instead of just one.
+const AllVersions = "all"
+
 // RestoreConfig contains
 type RestoreConfig struct {
 	// Defines the per-item collision handling policy.
@@ -64,6 +68,19 @@ type RestoreConfig struct {
 	// IncludePermissions toggles whether the restore will include the original
 	// folder- and item-level permissions.
 	IncludePermissions bool `json:"includePermissions"`
+
+	// Version selects which backed up version of each drive file gets
+	// restored.  Accepts a version ID, or AllVersions to recreate each
+	// file's full version history.  Files without a matching version are
+	// skipped.
+	// Defaults to empty, which restores the current version.
+	Version string `json:"version,omitempty"`
+
+	// CreateMissingTerms toggles whether managed metadata terms that are
+	// missing from the destination's term store get recreated, along with
+	// their term sets and groups.  Creating terms requires write access to
+	// the term store.
+	CreateMissingTerms bool `json:"createMissingTerms,omitempty"`
 }
 
 func DefaultRestoreConfig(timeFormat dttm.TimeFormat) RestoreConfig {
@@ -124,6 +141,8 @@ func (rc RestoreConfig) concealed() RestoreConfig {
 		Location:           path.LoggableDir(rc.Location),
 		Drive:              clues.Conceal(rc.Drive),
 		IncludePermissions: rc.IncludePermissions,
+		Version:            rc.Version,
+		CreateMissingTerms: rc.CreateMissingTerms,
 	}
 }
 
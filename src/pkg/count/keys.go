@@ -47,8 +47,12 @@ const (
 	DeleteItemMarker              Key = "delete-item-marker"
 	Drives                        Key = "drives"
 	DriveTombstones               Key = "drive-tombstones"
+	DriveItemVersions             Key = "drive-item-versions"
 	Files                         Key = "files"
+	FilteredFiles                 Key = "filtered-files"
 	Folders                       Key = "folders"
+	GroupMembers                  Key = "group-members"
+	GroupOwners                   Key = "group-owners"
 	ItemsAdded                    Key = "items-added"
 	ItemsRemoved                  Key = "items-removed"
 	LazyDeletedInFlight           Key = "lazy-deleted-in-flight"
@@ -59,11 +63,14 @@ const (
 	NewDeltas                     Key = "new-delta-tokens"
 	NewPrevPaths                  Key = "new-previous-paths"
 	NoDeltaQueries                Key = "cannot-make-delta-queries"
+	OneNotePages                  Key = "onenote-pages"
+	OneNoteSections               Key = "onenote-sections"
 	Packages                      Key = "packages"
 	PagerResets                   Key = "pager-resets"
 	PrevDeltas                    Key = "previous-deltas"
 	PrevPaths                     Key = "previous-paths"
 	PreviousPathMetadataCollision Key = "previous-path-metadata-collision"
+	ResumedEnumerations           Key = "resumed-enumerations"
 	Sites                         Key = "sites"
 	Lists                         Key = "lists"
 	SkippedContainers             Key = "skipped-containers"
@@ -129,4 +136,16 @@ const (
 	// non-meta item creation counting.  IE: use it specifically
 	// for counting new items (no collision) or copied items.
 	NewItemCreated Key = "new-item-created"
+	// count of drive files that were skipped because the requested
+	// file version wasn't included in the backup.
+	VersionNotFoundSkip Key = "version-not-found-skip"
+	// count of term groups, term sets, and terms created in the
+	// destination's term store.
+	TermStoreItemCreated Key = "term-store-item-created"
+	// count of managed metadata terms that couldn't be matched in, or
+	// created in, the destination's term store.
+	TermNotResolved Key = "term-not-resolved"
+	// count of restored lists whose managed metadata values couldn't be
+	// pointed at the destination's terms.
+	ListTermsNotRemapped Key = "list-terms-not-remapped"
 )
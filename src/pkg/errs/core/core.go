@@ -63,6 +63,10 @@ var (
 	ErrApplicationThrottled = &Err{msg: "application throttled"}
 	// for use when a short-lived auth token (a jwt or something similar) expires.
 	ErrAuthTokenExpired = &Err{msg: "auth token expired"}
+	// the backup stopped before reading all of its data, usually because the
+	// user asked it to.  Items uploaded before the interruption are kept so
+	// that the next backup doesn't need to download them again.
+	ErrBackupInterrupted = &Err{msg: "backup interrupted"}
 	// about what it sounds like: we tried to look for a backup by ID, but the
 	// storage layer couldn't find anything for that ID.
 	ErrBackupNotFound = &Err{msg: "backup not found"}
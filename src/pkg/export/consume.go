@@ -46,6 +46,11 @@ func ConsumeExportCollections(
 				continue
 			}
 
+			if item.Skip != nil {
+				el.AddSkip(ictx, item.Skip)
+				continue
+			}
+
 			if err := writeItem(ictx, item, folder); err != nil {
 				el.AddRecoverable(
 					ictx,
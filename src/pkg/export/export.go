@@ -6,6 +6,7 @@ import (
 
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/metrics"
 )
 
@@ -86,4 +87,8 @@ type Item struct {
 	// In case we have the error bound to a particular item, we will
 	// also return the id of the item.
 	Error error
+
+	// Skip is populated when the item was deliberately left out of the
+	// export.  Skipped items have no Body.
+	Skip *fault.Skipped
 }
@@ -0,0 +1,534 @@
+package extensions
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/alcionai/clues"
+	"github.com/jaytaylor/html2text"
+	"github.com/jhillyerd/enmime"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+const (
+	// KContentText is the extension data key that holds the text extracted
+	// from an item's content.
+	KContentText = "ContentText"
+
+	// DefaultContentMaxBytes is the amount of each item's content that gets
+	// read for text extraction.  Documents larger than this aren't indexed;
+	// text files are indexed up to this size.
+	DefaultContentMaxBytes = 20 * 1024 * 1024
+	// DefaultContentMaxBuffered is the amount of content buffered across all
+	// the items read at the same time.  Items that don't fit in it while other
+	// items are being read are handled like items over DefaultContentMaxBytes.
+	DefaultContentMaxBuffered = 64 * 1024 * 1024
+	// DefaultContentMaxText is the amount of text, in bytes, kept for each
+	// item.  The text is stored with the item's backup details, so the limit
+	// keeps the details of large drives from growing unbounded.
+	DefaultContentMaxText = 16 * 1024
+)
+
+type contentKind int
+
+const (
+	noContent contentKind = iota
+	textContent
+	htmlContent
+	officeContent
+	pdfContent
+	// messageContent covers emails, events, posts, and chats, which are
+	// stored as their serialized graph api json, or as mime for emails
+	// backed up in mime form.
+	messageContent
+)
+
+// partial reports whether the leading part of content of this kind can be
+// indexed when the whole content can't be buffered.
+func (k contentKind) partial() bool {
+	return k == textContent || k == htmlContent
+}
+
+var contentKindByExtension = map[string]contentKind{
+	".csv":      textContent,
+	".json":     textContent,
+	".log":      textContent,
+	".markdown": textContent,
+	".md":       textContent,
+	".text":     textContent,
+	".tsv":      textContent,
+	".txt":      textContent,
+	".xml":      textContent,
+	".yaml":     textContent,
+	".yml":      textContent,
+	".htm":      htmlContent,
+	".html":     htmlContent,
+	".docx":     officeContent,
+	".pptx":     officeContent,
+	".xlsx":     officeContent,
+	".pdf":      pdfContent,
+}
+
+var _ CreateItemExtensioner = &ContentIndexFactory{}
+
+// ContentIndexFactory creates extensions that extract the text of items as
+// they stream through a backup, and record it in the item's extension data
+// under KContentText.  Text, html, office (docx, pptx, xlsx), and pdf files
+// are indexed, along with the bodies of emails, events, conversation posts,
+// channel messages, and chats.  Other items pass through untouched.
+//
+// The factory must not be copied after first use, since it tracks the
+// content buffered by all of its extensions.
+type ContentIndexFactory struct {
+	// MaxBytes limits how much of each item gets read for text extraction.
+	// Zero uses DefaultContentMaxBytes.
+	MaxBytes int
+	// MaxText limits how much text gets kept for each item.  Zero uses
+	// DefaultContentMaxText.
+	MaxText int
+	// MaxBuffered limits how much content gets buffered across all the items
+	// being read.  Zero uses DefaultContentMaxBuffered.
+	MaxBuffered int
+
+	mu       sync.Mutex
+	buffered int
+}
+
+func (f *ContentIndexFactory) CreateItemExtension(
+	ctx context.Context,
+	rc io.ReadCloser,
+	info details.ItemInfo,
+	extData *details.ExtensionData,
+) (io.ReadCloser, error) {
+	kind := contentKindOf(info)
+	if kind == noContent {
+		return rc, nil
+	}
+
+	maxBytes := f.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultContentMaxBytes
+	}
+
+	maxText := f.MaxText
+	if maxText <= 0 {
+		maxText = DefaultContentMaxText
+	}
+
+	return &contentExtension{
+		ctx:      ctx,
+		innerRc:  rc,
+		extData:  extData,
+		kind:     kind,
+		maxBytes: maxBytes,
+		maxText:  maxText,
+		budget:   f,
+	}, nil
+}
+
+// reserve claims n bytes of the buffer budget, and reports whether they
+// fit in it.  Reservations never wait on other items, since the items
+// holding the budget may only get read after the reserving item is done.
+func (f *ContentIndexFactory) reserve(n int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	maxBuffered := f.MaxBuffered
+	if maxBuffered <= 0 {
+		maxBuffered = DefaultContentMaxBuffered
+	}
+
+	if f.buffered+n > maxBuffered {
+		return false
+	}
+
+	f.buffered += n
+
+	return true
+}
+
+// release returns n bytes to the buffer budget.
+func (f *ContentIndexFactory) release(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.buffered -= n
+}
+
+func contentKindOf(info details.ItemInfo) contentKind {
+	switch {
+	case info.Exchange != nil:
+		switch info.Exchange.ItemType {
+		case details.ExchangeMail, details.ExchangeEvent:
+			return messageContent
+		}
+
+	case info.TeamsChats != nil:
+		return messageContent
+
+	case info.Groups != nil:
+		switch info.Groups.ItemType {
+		case details.GroupsChannelMessage,
+			details.GroupsConversationPost,
+			details.GroupsCalendarEvent:
+			return messageContent
+		case details.SharePointLibrary:
+			return fileContentKind(info.Groups.ItemName)
+		}
+
+	case info.OneDrive != nil:
+		return fileContentKind(info.OneDrive.ItemName)
+
+	case info.SharePoint != nil:
+		return fileContentKind(info.SharePoint.ItemName)
+	}
+
+	return noContent
+}
+
+func fileContentKind(name string) contentKind {
+	return contentKindByExtension[strings.ToLower(filepath.Ext(name))]
+}
+
+var _ io.ReadCloser = &contentExtension{}
+
+// contentExtension buffers the item's content as it gets read, and extracts
+// its text once the content was read in full.
+type contentExtension struct {
+	ctx      context.Context
+	innerRc  io.ReadCloser
+	extData  *details.ExtensionData
+	kind     contentKind
+	maxBytes int
+	maxText  int
+	budget   *ContentIndexFactory
+	buf      bytes.Buffer
+	// reserved is the part of the budget held by buf.
+	reserved int
+	// truncated is set when the content outgrew maxBytes, or the budget.
+	truncated  bool
+	overBudget bool
+	indexed    bool
+}
+
+func (ce *contentExtension) Read(p []byte) (int, error) {
+	n, err := ce.innerRc.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, clues.Stack(err)
+	}
+
+	ce.buffer(p[:n])
+
+	if errors.Is(err, io.EOF) {
+		ce.index()
+	}
+
+	return n, err
+}
+
+func (ce *contentExtension) Close() error {
+	// content that wasn't read in full doesn't get indexed.
+	ce.reset()
+	return clues.Stack(ce.innerRc.Close()).OrNil()
+}
+
+func (ce *contentExtension) buffer(p []byte) {
+	if ce.truncated || len(p) == 0 {
+		return
+	}
+
+	n := min(len(p), ce.maxBytes-ce.buf.Len())
+	if n > 0 && !ce.budget.reserve(n) {
+		n = 0
+		ce.overBudget = true
+	}
+
+	ce.buf.Write(p[:n])
+	ce.reserved += n
+
+	if n == len(p) {
+		return
+	}
+
+	ce.truncated = true
+
+	// the leading part of a text file is still worth indexing, but a
+	// partial document or message can't be opened.
+	if !ce.kind.partial() {
+		ce.reset()
+	}
+}
+
+// reset drops the buffered content, and returns its part of the budget.
+func (ce *contentExtension) reset() {
+	ce.buf = bytes.Buffer{}
+	ce.budget.release(ce.reserved)
+	ce.reserved = 0
+}
+
+func (ce *contentExtension) index() {
+	if ce.indexed {
+		return
+	}
+
+	ce.indexed = true
+
+	defer ce.reset()
+
+	if ce.overBudget {
+		logger.Ctx(ce.ctx).Info("content indexing buffer full; indexing item partially or not at all")
+	}
+
+	if !ce.kind.partial() && ce.truncated {
+		logger.Ctx(ce.ctx).Debug("item too large for content indexing")
+		return
+	}
+
+	text, err := extractText(ce.kind, ce.buf.Bytes())
+	if err != nil {
+		logger.CtxErr(ce.ctx, err).Info("extracting item content for indexing")
+		return
+	}
+
+	text = clipText(normalizeSpace(text), ce.maxText)
+	if len(text) > 0 {
+		ce.extData.Data[KContentText] = text
+	}
+}
+
+// ---------------------------------------------------------------------------
+// text extraction
+// ---------------------------------------------------------------------------
+
+func extractText(kind contentKind, content []byte) (string, error) {
+	switch kind {
+	case textContent:
+		return strings.ToValidUTF8(string(content), ""), nil
+
+	case htmlContent:
+		text, err := html2text.FromString(
+			strings.ToValidUTF8(string(content), ""),
+			html2text.Options{OmitLinks: true, TextOnly: true})
+
+		return text, clues.Stack(err).OrNil()
+
+	case officeContent:
+		return officeText(content)
+
+	case pdfContent:
+		return pdfText(content)
+
+	case messageContent:
+		return messageText(content)
+	}
+
+	return "", nil
+}
+
+// graphMessage holds the parts of a serialized email, event, post, channel
+// message, or chat that hold its text.
+type graphMessage struct {
+	Body *graphBody `json:"body,omitempty"`
+	// Replies holds the replies to a channel message.
+	Replies []graphMessage `json:"replies,omitempty"`
+	// Messages holds the messages of a chat.
+	Messages []graphMessage `json:"messages,omitempty"`
+}
+
+type graphBody struct {
+	Content     string `json:"content"`
+	ContentType string `json:"contentType"`
+}
+
+// messageText extracts the body text of the message, and of its replies
+// or chat messages.
+func messageText(content []byte) (string, error) {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) > 0 && trimmed[0] != '{' {
+		return mimeText(content)
+	}
+
+	var msg graphMessage
+
+	if err := json.Unmarshal(content, &msg); err != nil {
+		return "", clues.Wrap(err, "decoding message")
+	}
+
+	sb := strings.Builder{}
+
+	if err := msg.writeText(&sb); err != nil {
+		return "", clues.Stack(err)
+	}
+
+	return sb.String(), nil
+}
+
+func (msg graphMessage) writeText(sb *strings.Builder) error {
+	if msg.Body != nil && len(msg.Body.Content) > 0 {
+		text := msg.Body.Content
+
+		if strings.EqualFold(msg.Body.ContentType, "html") {
+			var err error
+
+			text, err = html2text.FromString(
+				text,
+				html2text.Options{OmitLinks: true, TextOnly: true})
+			if err != nil {
+				return clues.Wrap(err, "reading message body")
+			}
+		}
+
+		sb.WriteString(text)
+		sb.WriteByte(' ')
+	}
+
+	for _, m := range append(msg.Replies, msg.Messages...) {
+		if err := m.writeText(sb); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mimeText extracts the body text of an email stored as mime.  Html
+// bodies without a text part get converted to text.
+func mimeText(content []byte) (string, error) {
+	env, err := enmime.ReadEnvelope(bytes.NewReader(content))
+	if err != nil {
+		return "", clues.Wrap(err, "reading mime message")
+	}
+
+	return env.Text, nil
+}
+
+// officeText extracts the text from the parts of an office open xml
+// document (docx, pptx, xlsx) that hold its text.
+func officeText(content []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", clues.Wrap(err, "opening office document")
+	}
+
+	files := []*zip.File{}
+
+	for _, f := range zr.File {
+		if isOfficeTextPart(f.Name) {
+			files = append(files, f)
+		}
+	}
+
+	// slides are kept in document order.
+	sort.Slice(files, func(i, j int) bool {
+		return officePartLess(files[i].Name, files[j].Name)
+	})
+
+	sb := strings.Builder{}
+
+	for _, f := range files {
+		if err := xmlText(f, &sb); err != nil {
+			return "", clues.Wrap(err, "reading office document part").With("part", f.Name)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func isOfficeTextPart(name string) bool {
+	switch {
+	case name == "word/document.xml",
+		name == "xl/sharedStrings.xml":
+		return true
+	case strings.HasPrefix(name, "ppt/slides/slide") && strings.HasSuffix(name, ".xml"):
+		return true
+	}
+
+	return false
+}
+
+// officePartLess sorts slide2.xml before slide10.xml.
+func officePartLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+
+	return a < b
+}
+
+// officeBreaks are the elements that separate runs of text in office
+// documents: paragraphs, table cells, shared strings, tabs and line breaks.
+// Text within a paragraph can be split across runs mid-word, so runs are
+// joined without a separator.
+var officeBreaks = map[string]struct{}{
+	"br":  {},
+	"p":   {},
+	"si":  {},
+	"tab": {},
+	"tc":  {},
+}
+
+// xmlText writes the character data of the xml part to the builder.
+func xmlText(f *zip.File, sb *strings.Builder) error {
+	rc, err := f.Open()
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	defer rc.Close()
+
+	dec := xml.NewDecoder(rc)
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return clues.Stack(err)
+		}
+
+		switch t := tok.(type) {
+		case xml.CharData:
+			sb.Write(t)
+
+		case xml.EndElement:
+			if _, ok := officeBreaks[t.Name.Local]; ok {
+				sb.WriteByte(' ')
+			}
+		}
+	}
+}
+
+// normalizeSpace collapses each run of whitespace into a single space.
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// clipText cuts the text down to at most size bytes, without splitting
+// a multi-byte character.
+func clipText(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+
+	s = s[:size]
+
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+
+	return s
+}
@@ -0,0 +1,374 @@
+package extensions
+
+// Tests for content.go
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+type ContentUnitSuite struct {
+	tester.Suite
+}
+
+func TestContentUnitSuite(t *testing.T) {
+	suite.Run(t, &ContentUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func officeDoc(t *testing.T, parts map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		require.NoError(t, err, clues.ToCore(err))
+
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	err := zw.Close()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return buf.Bytes()
+}
+
+func (suite *ContentUnitSuite) TestContentIndexFactory() {
+	t := suite.T()
+
+	docx := officeDoc(t, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="w"><w:body>` +
+			`<w:p><w:r><w:t>Quarterly </w:t></w:r><w:r><w:t>fore</w:t></w:r><w:r><w:t>cast</w:t></w:r></w:p>` +
+			`<w:p><w:r><w:t>Second paragraph</w:t></w:r></w:p>` +
+			`</w:body></w:document>`,
+		"word/styles.xml": `<w:styles xmlns:w="w"><w:t>not content</w:t></w:styles>`,
+	})
+
+	pptx := officeDoc(t, map[string]string{
+		"ppt/slides/slide10.xml": `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:t>last</a:t></a:p></p:sld>`,
+		"ppt/slides/slide2.xml":  `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:t>first</a:t></a:p></p:sld>`,
+	})
+
+	table := []struct {
+		name     string
+		itemName string
+		content  []byte
+		factory  *ContentIndexFactory
+		expect   string
+	}{
+		{
+			name:     "text",
+			itemName: "notes.TXT",
+			content:  []byte("some\n\tplain   text"),
+			expect:   "some plain text",
+		},
+		{
+			name:     "html",
+			itemName: "page.html",
+			content:  []byte("<html><body><p>Some <a href=\"https://example.com\">linked</a> text</p><div>More text</div></body></html>"),
+			expect:   "Some linked text More text",
+		},
+		{
+			name:     "docx",
+			itemName: "report.docx",
+			content:  docx,
+			expect:   "Quarterly forecast Second paragraph",
+		},
+		{
+			name:     "pptx slides in order",
+			itemName: "deck.pptx",
+			content:  pptx,
+			expect:   "first last",
+		},
+		{
+			name:     "text over the read limit",
+			itemName: "notes.txt",
+			content:  []byte("0123456789"),
+			factory:  &ContentIndexFactory{MaxBytes: 4},
+			expect:   "0123",
+		},
+		{
+			name:     "document over the read limit",
+			itemName: "report.docx",
+			content:  docx,
+			factory:  &ContentIndexFactory{MaxBytes: 4},
+		},
+		{
+			name:     "text over the text limit",
+			itemName: "notes.txt",
+			content:  []byte("aé"),
+			factory:  &ContentIndexFactory{MaxText: 2},
+			expect:   "a",
+		},
+		{
+			name:     "pdf",
+			itemName: "report.pdf",
+			content:  pdfDoc(t, "BT 72 712 Td (Quarterly forecast) Tj ET"),
+			expect:   "Quarterly forecast",
+		},
+		{
+			name:     "pdf over the read limit",
+			itemName: "report.pdf",
+			content:  pdfDoc(t, "BT (Quarterly forecast) Tj ET"),
+			factory:  &ContentIndexFactory{MaxBytes: 4},
+		},
+		{
+			name:     "document over the buffer budget",
+			itemName: "report.docx",
+			content:  docx,
+			factory:  &ContentIndexFactory{MaxBuffered: 4},
+		},
+		{
+			name:     "text over the buffer budget",
+			itemName: "notes.txt",
+			content:  []byte("0123456789"),
+			factory:  &ContentIndexFactory{MaxBuffered: 4},
+			expect:   "0123",
+		},
+		{
+			name:     "not a document",
+			itemName: "photo.jpg",
+			content:  []byte("some data"),
+		},
+		{
+			name:     "unreadable document",
+			itemName: "report.docx",
+			content:  []byte("not a zip"),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				info = details.ItemInfo{
+					OneDrive: &details.OneDriveInfo{ItemName: test.itemName},
+				}
+				extData = &details.ExtensionData{Data: map[string]any{}}
+				f       = test.factory
+			)
+
+			if f == nil {
+				f = &ContentIndexFactory{}
+			}
+
+			rc, err := f.CreateItemExtension(
+				ctx,
+				io.NopCloser(bytes.NewReader(test.content)),
+				info,
+				extData)
+			require.NoError(t, err, clues.ToCore(err))
+
+			// single byte reads, to make sure content gets stitched
+			// together across reads.
+			read, err := io.ReadAll(iotest.OneByteReader(rc))
+			require.NoError(t, err, clues.ToCore(err))
+
+			err = rc.Close()
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.content, read, "content passes through unchanged")
+			assert.Zero(t, f.buffered, "buffer budget is returned")
+
+			text, ok := extData.Data[KContentText]
+			if len(test.expect) == 0 {
+				assert.False(t, ok, "no text indexed")
+				return
+			}
+
+			assert.Equal(t, test.expect, text)
+		})
+	}
+}
+
+func (suite *ContentUnitSuite) TestContentIndexFactory_partialRead() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		f       = &ContentIndexFactory{}
+		info    = details.ItemInfo{OneDrive: &details.OneDriveInfo{ItemName: "notes.txt"}}
+		extData = &details.ExtensionData{Data: map[string]any{}}
+	)
+
+	rc, err := f.CreateItemExtension(
+		ctx,
+		io.NopCloser(strings.NewReader("some text")),
+		info,
+		extData)
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = rc.Read(make([]byte, 4))
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = rc.Close()
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.NotContains(t, extData.Data, KContentText, "content that wasn't read in full isn't indexed")
+	assert.Zero(t, f.buffered, "buffer budget is returned")
+}
+
+func (suite *ContentUnitSuite) TestContentIndexFactory_sharedBudget() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		f    = &ContentIndexFactory{MaxBuffered: 12}
+		info = details.ItemInfo{OneDrive: &details.OneDriveInfo{ItemName: "notes.txt"}}
+		data = []*details.ExtensionData{
+			{Data: map[string]any{}},
+			{Data: map[string]any{}},
+		}
+		rcs = []io.ReadCloser{}
+	)
+
+	for _, extData := range data {
+		rc, err := f.CreateItemExtension(
+			ctx,
+			io.NopCloser(strings.NewReader("0123456789")),
+			info,
+			extData)
+		require.NoError(t, err, clues.ToCore(err))
+
+		rcs = append(rcs, rc)
+	}
+
+	// items read at the same time share the budget.
+	for _, rc := range rcs {
+		_, err := rc.Read(make([]byte, 8))
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	for _, rc := range rcs {
+		_, err := io.ReadAll(rc)
+		require.NoError(t, err, clues.ToCore(err))
+
+		err = rc.Close()
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	assert.Equal(t, "0123456789", data[0].Data[KContentText])
+	assert.NotContains(t, data[1].Data, KContentText, "nothing fit in the budget")
+	assert.Zero(t, f.buffered, "buffer budget is returned")
+}
+
+func (suite *ContentUnitSuite) TestContentIndexFactory_messages() {
+	chat := `{"topic":"plans","messages":[` +
+		`{"body":{"contentType":"html","content":"<p>Quarterly forecast</p>"}},` +
+		`{"body":null},` +
+		`{"body":{"contentType":"text","content":"sounds good"}}]}`
+
+	mime := "From: bob@example.com\r\n" +
+		"Subject: Budget\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"The quarterly forecast is up.\r\n"
+
+	table := []struct {
+		name    string
+		info    details.ItemInfo
+		content string
+		expect  string
+	}{
+		{
+			name: "email",
+			info: details.ItemInfo{
+				Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMail},
+			},
+			content: `{"subject":"Budget","body":{"contentType":"html","content":"<div>The forecast</div>"}}`,
+			expect:  "The forecast",
+		},
+		{
+			name: "mime email",
+			info: details.ItemInfo{
+				Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMail},
+			},
+			content: mime,
+			expect:  "The quarterly forecast is up.",
+		},
+		{
+			name: "contact",
+			info: details.ItemInfo{
+				Exchange: &details.ExchangeInfo{ItemType: details.ExchangeContact},
+			},
+			content: `{"personalNotes":"not indexed"}`,
+		},
+		{
+			name: "channel message with replies",
+			info: details.ItemInfo{
+				Groups: &details.GroupsInfo{ItemType: details.GroupsChannelMessage},
+			},
+			content: `{"body":{"contentType":"text","content":"first"},` +
+				`"replies":[{"body":{"contentType":"text","content":"second"}}]}`,
+			expect: "first second",
+		},
+		{
+			name: "chat",
+			info: details.ItemInfo{
+				TeamsChats: &details.TeamsChatsInfo{ItemType: details.TeamsChat},
+			},
+			content: chat,
+			expect:  "Quarterly forecast sounds good",
+		},
+		{
+			name: "unreadable message",
+			info: details.ItemInfo{
+				Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMail},
+			},
+			content: `{"body":`,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				f       = &ContentIndexFactory{}
+				extData = &details.ExtensionData{Data: map[string]any{}}
+			)
+
+			rc, err := f.CreateItemExtension(
+				ctx,
+				io.NopCloser(strings.NewReader(test.content)),
+				test.info,
+				extData)
+			require.NoError(t, err, clues.ToCore(err))
+
+			_, err = io.ReadAll(rc)
+			require.NoError(t, err, clues.ToCore(err))
+
+			err = rc.Close()
+			require.NoError(t, err, clues.ToCore(err))
+
+			text, ok := extData.Data[KContentText]
+			if len(test.expect) == 0 {
+				assert.False(t, ok, "no text indexed")
+				return
+			}
+
+			assert.Equal(t, test.expect, text)
+		})
+	}
+}
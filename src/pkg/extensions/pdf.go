@@ -0,0 +1,447 @@
+package extensions
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/alcionai/clues"
+)
+
+// pdfMaxStream limits the size of each decompressed pdf stream, so that
+// a small document can't inflate into an unbounded amount of memory.
+const pdfMaxStream = 8 * 1024 * 1024
+
+var (
+	pdfStreamStart = []byte("stream")
+	pdfStreamEnd   = []byte("endstream")
+	pdfObjStart    = []byte(" obj")
+)
+
+// pdfText extracts the text shown by the content streams of a pdf document.
+// Text is only readable when it's drawn with fonts that use the standard
+// encodings; text drawn with fonts that map their own glyph ids (most cid
+// fonts) comes out as noise, and gets dropped along with other unprintable
+// characters.
+func pdfText(content []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(content), []byte("%PDF-")) {
+		return "", clues.New("not a pdf document")
+	}
+
+	sb := strings.Builder{}
+
+	for rest := content; ; {
+		dict, data, next, ok := nextPDFStream(rest)
+		if !ok {
+			break
+		}
+
+		rest = next
+
+		if !isPDFContentStream(dict) {
+			continue
+		}
+
+		if bytes.Contains(dict, []byte("/FlateDecode")) {
+			inflated, err := inflate(data)
+			if err != nil {
+				// a damaged stream doesn't hide the text of the others.
+				continue
+			}
+
+			data = inflated
+		} else if bytes.Contains(dict, []byte("/Filter")) {
+			// other filters are used for images and fonts.
+			continue
+		}
+
+		writePDFText(data, &sb)
+	}
+
+	return sb.String(), nil
+}
+
+// nextPDFStream finds the next stream in the document, and returns its
+// dictionary, its data, and the remainder of the document.
+func nextPDFStream(doc []byte) ([]byte, []byte, []byte, bool) {
+	for {
+		i := bytes.Index(doc, pdfStreamStart)
+		if i < 0 {
+			return nil, nil, nil, false
+		}
+
+		// skip the "stream" within "endstream".
+		if i >= 3 && bytes.Equal(doc[i-3:i], []byte("end")) {
+			doc = doc[i+len(pdfStreamStart):]
+			continue
+		}
+
+		start := i + len(pdfStreamStart)
+
+		switch {
+		case bytes.HasPrefix(doc[start:], []byte("\r\n")):
+			start += 2
+		case bytes.HasPrefix(doc[start:], []byte("\n")):
+			start++
+		default:
+			doc = doc[start:]
+			continue
+		}
+
+		end := bytes.Index(doc[start:], pdfStreamEnd)
+		if end < 0 {
+			return nil, nil, nil, false
+		}
+
+		dict := doc[:i]
+		if j := bytes.LastIndex(dict, pdfObjStart); j >= 0 {
+			dict = dict[j:]
+		}
+
+		return dict, doc[start : start+end], doc[start+end+len(pdfStreamEnd):], true
+	}
+}
+
+// isPDFContentStream reports whether the stream may hold page content,
+// rather than fonts, images, or the document's structure.
+func isPDFContentStream(dict []byte) bool {
+	for _, kind := range []string{
+		"/Type /XRef", "/Type/XRef",
+		"/Type /ObjStm", "/Type/ObjStm",
+		"/Type /Metadata", "/Type/Metadata",
+		"/Subtype /Image", "/Subtype/Image",
+		"/Length1", "/Length2", "/Length3",
+	} {
+		if bytes.Contains(dict, []byte(kind)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func inflate(data []byte) ([]byte, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	defer zr.Close()
+
+	out, err := io.ReadAll(io.LimitReader(zr, pdfMaxStream))
+
+	// streams often lack their checksum; keep what was inflated.
+	if len(out) > 0 {
+		return out, nil
+	}
+
+	return nil, clues.Stack(err).OrNil()
+}
+
+// writePDFText writes the strings shown by the text operators of the
+// content stream to the builder.
+func writePDFText(data []byte, sb *strings.Builder) {
+	var (
+		lex      = pdfLexer{data: data}
+		inText   bool
+		operands []pdfToken
+	)
+
+	for {
+		tok, ok := lex.next()
+		if !ok {
+			return
+		}
+
+		if tok.kind != pdfOperator {
+			operands = append(operands, tok)
+			continue
+		}
+
+		switch tok.text {
+		case "BT":
+			inText = true
+
+		case "ET":
+			inText = false
+
+			sb.WriteByte(' ')
+
+		case "Td", "TD", "T*", "Tm":
+			if inText {
+				sb.WriteByte(' ')
+			}
+
+		case "Tj", "'", `"`, "TJ":
+			if !inText {
+				break
+			}
+
+			if tok.text != "Tj" && tok.text != "TJ" {
+				sb.WriteByte(' ')
+			}
+
+			for _, op := range operands {
+				switch {
+				case op.kind == pdfString:
+					sb.WriteString(op.text)
+				case op.kind == pdfNumber && op.gap:
+					// large negative adjustments in TJ arrays space out words.
+					sb.WriteByte(' ')
+				}
+			}
+		}
+
+		operands = operands[:0]
+	}
+}
+
+type pdfTokenKind int
+
+const (
+	pdfOperator pdfTokenKind = iota
+	pdfString
+	pdfNumber
+	pdfOther
+)
+
+type pdfToken struct {
+	kind pdfTokenKind
+	text string
+	// gap is set on numbers that are large enough word gaps.
+	gap bool
+}
+
+// pdfLexer splits a content stream into tokens.
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0 || isPDFSpace(c)
+}
+
+func isPDFSpace(c byte) bool {
+	return strings.IndexByte(" \t\r\n\f\x00", c) >= 0
+}
+
+func (l *pdfLexer) next() (pdfToken, bool) {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+
+		case c == '(':
+			l.pos++
+			return pdfToken{kind: pdfString, text: decodePDFString(l.literal())}, true
+
+		case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<',
+			c == '>' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '>':
+			l.pos += 2
+			return pdfToken{kind: pdfOther}, true
+
+		case c == '<':
+			l.pos++
+			return pdfToken{kind: pdfString, text: decodePDFString(l.hex())}, true
+
+		case strings.IndexByte("[]{}>)", c) >= 0:
+			l.pos++
+			return pdfToken{kind: pdfOther}, true
+
+		case c == '/':
+			l.pos++
+			l.word()
+
+			return pdfToken{kind: pdfOther}, true
+
+		default:
+			w := l.word()
+			if len(w) == 0 {
+				l.pos++
+				continue
+			}
+
+			if strings.IndexByte("+-.0123456789", w[0]) >= 0 {
+				return pdfToken{kind: pdfNumber, gap: isPDFWordGap(w)}, true
+			}
+
+			return pdfToken{kind: pdfOperator, text: w}, true
+		}
+	}
+
+	return pdfToken{}, false
+}
+
+func (l *pdfLexer) word() string {
+	start := l.pos
+
+	for l.pos < len(l.data) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+
+	return string(l.data[start:l.pos])
+}
+
+// isPDFWordGap reports whether the TJ adjustment, in thousandths of a text
+// space unit, is wide enough to separate words.
+func isPDFWordGap(w string) bool {
+	if !strings.HasPrefix(w, "-") {
+		return false
+	}
+
+	digits := strings.TrimLeft(strings.SplitN(w[1:], ".", 2)[0], "0")
+
+	return len(digits) > 3 || (len(digits) == 3 && digits >= "200")
+}
+
+// literal reads a literal string, whose opening parenthesis was consumed.
+func (l *pdfLexer) literal() []byte {
+	var (
+		out   []byte
+		depth = 1
+	)
+
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return out
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return out
+			}
+
+			e := l.data[l.pos]
+			l.pos++
+
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				// line continuation
+				if e == '\r' && l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+
+				continue
+			default:
+				if e < '0' || e > '7' {
+					c = e
+					break
+				}
+
+				c = e - '0'
+
+				for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+					c = c*8 + l.data[l.pos] - '0'
+					l.pos++
+				}
+			}
+		}
+
+		out = append(out, c)
+	}
+
+	return out
+}
+
+// hex reads a hex string, whose opening bracket was consumed.
+func (l *pdfLexer) hex() []byte {
+	var (
+		out  []byte
+		half = -1
+	)
+
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+
+		var v int
+
+		switch {
+		case c == '>':
+			if half >= 0 {
+				out = append(out, byte(half<<4))
+			}
+
+			return out
+		case c >= '0' && c <= '9':
+			v = int(c - '0')
+		case c >= 'a' && c <= 'f':
+			v = int(c-'a') + 10
+		case c >= 'A' && c <= 'F':
+			v = int(c-'A') + 10
+		default:
+			continue
+		}
+
+		if half < 0 {
+			half = v
+			continue
+		}
+
+		out = append(out, byte(half<<4|v))
+		half = -1
+	}
+
+	return out
+}
+
+// decodePDFString turns the bytes of a pdf string into text.  Strings that
+// start with a byte order mark are utf-16; others are read as latin-1, which
+// matches the standard encodings for letters and common punctuation.
+func decodePDFString(bs []byte) string {
+	var rs []rune
+
+	if len(bs) >= 2 && bs[0] == 0xFE && bs[1] == 0xFF {
+		units := make([]uint16, 0, len(bs)/2)
+
+		for i := 2; i+1 < len(bs); i += 2 {
+			units = append(units, uint16(bs[i])<<8|uint16(bs[i+1]))
+		}
+
+		rs = utf16.Decode(units)
+	} else {
+		rs = make([]rune, 0, len(bs))
+
+		for _, b := range bs {
+			rs = append(rs, rune(b))
+		}
+	}
+
+	sb := strings.Builder{}
+
+	for _, r := range rs {
+		if unicode.IsPrint(r) || unicode.IsSpace(r) {
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
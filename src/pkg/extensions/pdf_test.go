@@ -0,0 +1,138 @@
+package extensions
+
+// Tests for pdf.go
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type PDFUnitSuite struct {
+	tester.Suite
+}
+
+func TestPDFUnitSuite(t *testing.T) {
+	suite.Run(t, &PDFUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// pdfDoc produces a pdf document with a single compressed content stream.
+func pdfDoc(t *testing.T, content string) []byte {
+	return pdfDocWithStreams(t, pdfStream(t, "", content, true))
+}
+
+func pdfStream(t *testing.T, dict, content string, compress bool) string {
+	data := []byte(content)
+
+	if compress {
+		buf := &bytes.Buffer{}
+		zw := zlib.NewWriter(buf)
+
+		_, err := zw.Write(data)
+		require.NoError(t, err, clues.ToCore(err))
+
+		err = zw.Close()
+		require.NoError(t, err, clues.ToCore(err))
+
+		data = buf.Bytes()
+		dict += " /Filter /FlateDecode"
+	}
+
+	return fmt.Sprintf("<< /Length %d%s >>\nstream\n%s\nendstream", len(data), dict, data)
+}
+
+func pdfDocWithStreams(t *testing.T, streams ...string) []byte {
+	buf := bytes.NewBufferString("%PDF-1.4\n")
+
+	for i, s := range streams {
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", i+1, s)
+	}
+
+	buf.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+
+	return buf.Bytes()
+}
+
+func (suite *PDFUnitSuite) TestPDFText() {
+	table := []struct {
+		name      string
+		doc       func(t *testing.T) []byte
+		expect    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "shown strings",
+			doc: func(t *testing.T) []byte {
+				return pdfDoc(t, "BT /F1 12 Tf 72 712 Td (Quarterly) Tj 0 -14 Td "+
+					"[(fore) -20 (cast) -300 (review)] TJ ET")
+			},
+			expect:    "Quarterly forecast review",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "escapes and encodings",
+			doc: func(t *testing.T) []byte {
+				return pdfDoc(t, `BT (caf\351 \(draft\)) Tj T* <FEFF00480069> Tj ET`)
+			},
+			expect:    "café (draft) Hi",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "text outside text objects",
+			doc: func(t *testing.T) []byte {
+				return pdfDoc(t, "(hidden) Tj BT (shown) Tj ET")
+			},
+			expect:    "shown",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "uncompressed streams, fonts, and images",
+			doc: func(t *testing.T) []byte {
+				return pdfDocWithStreams(
+					t,
+					pdfStream(t, " /Length1 10", "BT (font) Tj ET", true),
+					pdfStream(t, " /Subtype /Image", "BT (image) Tj ET", false),
+					pdfStream(t, " /Filter /DCTDecode", "BT (jpeg) Tj ET", false),
+					pdfStream(t, "", "BT (plain) Tj ET", false),
+					pdfStream(t, "", "BT (compressed) Tj ET", true))
+			},
+			expect:    "plain compressed",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "damaged stream",
+			doc: func(t *testing.T) []byte {
+				return pdfDocWithStreams(
+					t,
+					"<< /Filter /FlateDecode >>\nstream\nnot zlib\nendstream",
+					pdfStream(t, "", "BT (readable) Tj ET", true))
+			},
+			expect:    "readable",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "not a pdf",
+			doc: func(t *testing.T) []byte {
+				return []byte("BT (text) Tj ET")
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			text, err := pdfText(test.doc(t))
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, normalizeSpace(text))
+		})
+	}
+}
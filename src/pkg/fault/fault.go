@@ -274,8 +274,11 @@ func (e *Bus) addSkip(s *Skipped) *Bus {
 // ---------------------------------------------------------------------------
 
 // Errors returns the plain record of errors that were aggregated
-// within a fult Bus.
+// within a fult Bus.  Safe to call while errors are still being added.
 func (e *Bus) Errors() *Errors {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
 	items, nonItems := itemsIn(e.failure, e.recoverable)
 
 	return &Errors{
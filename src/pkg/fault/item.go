@@ -9,6 +9,7 @@ const (
 	AddtlContainerName = "container_name"
 	AddtlContainerPath = "container_path"
 	AddtlMalwareDesc   = "malware_description"
+	AddtlFilterReason  = "filter_reason"
 )
 
 type ItemType string
@@ -33,6 +33,11 @@ const (
 	// https://support.microsoft.com/en-us/office/restrictions-and-limitations-in-onedrive-and-sharepoint-64883a5d-228e-48f5-b3d2-eb39e07630fa#onenotenotebooks
 	SkipOneNote SkipCause = "inaccessible_one_note_file"
 
+	// SkipOneNotePage identifies a page that was left out of its backed up
+	// OneNote section because OneNote failed to return the page content or
+	// one of the page's resources.  The rest of the section is still kept.
+	SkipOneNotePage SkipCause = "inaccessible_one_note_page"
+
 	// SkipInvalidRecipients identifies that an email was skipped because Exchange
 	// believes it is not valid and fails any attempt to read it.
 	SkipInvalidRecipients SkipCause = "invalid_recipients_email"
@@ -45,6 +50,36 @@ const (
 	// of event IDs where the events are known to fail with a 503 due to there being
 	// too many instances to retrieve from graph api.
 	SkipKnownEventInstance503s SkipCause = "known_event_instance_503"
+
+	// SkipVersionNotFound identifies that a file was left out of a restore or
+	// export because the requested version wasn't captured in the backup.
+	SkipVersionNotFound SkipCause = "version_not_found"
+
+	// SkipChatMessageContentTooLarge identifies an inline image or attached
+	// file that wasn't stored along with its chat or channel message because
+	// it exceeded the size limits for message content.  Attached files are
+	// still referenced by their drive item.
+	SkipChatMessageContentTooLarge SkipCause = "chat_message_content_too_large"
+
+	// SkipChatMessageFileNotFound identifies a file attached to a chat or
+	// channel message that was deleted after it got shared.
+	SkipChatMessageFileNotFound SkipCause = "chat_message_file_not_found"
+
+	// SkipListItemAttachmentTooLarge identifies a file attached to a list item
+	// that was left out of the backup because it exceeded the size limits for
+	// list attachments.
+	SkipListItemAttachmentTooLarge SkipCause = "list_item_attachment_too_large"
+
+	// SkipListItemAttachmentsDenied identifies a list item whose attached files
+	// were left out of the backup because SharePoint denied access to them.
+	// SharePoint rejects app-only tokens unless they were granted through a
+	// certificate credential.
+	SkipListItemAttachmentsDenied SkipCause = "list_item_attachments_denied"
+
+	// SkipFileFilter identifies a library file that was left out of the
+	// backup because it didn't pass the backup's file filters (extension,
+	// MIME type, size, or modified time).
+	SkipFileFilter SkipCause = "excluded_by_file_filter"
 )
 
 var _ print.Printable = &Skipped{}
@@ -29,6 +29,16 @@ const (
 	ChannelMessagesCategory   CategoryType = 9  // channelMessages
 	ConversationPostsCategory CategoryType = 10 // conversationPosts
 	ChatsCategory             CategoryType = 11 // chats
+	PlannerCategory           CategoryType = 12 // planner
+	TeamStructureCategory     CategoryType = 13 // teamStructure
+	GroupMembershipCategory   CategoryType = 14 // groupMembership
+	DirectoryUsersCategory    CategoryType = 15 // directoryUsers
+	DirectoryGroupsCategory   CategoryType = 16 // directoryGroups
+	ApplicationsCategory      CategoryType = 17 // applications
+	ServicePrincipalsCategory CategoryType = 18 // servicePrincipals
+	ConditionalAccessCategory CategoryType = 19 // conditionalAccessPolicies
+	AdminUnitsCategory        CategoryType = 20 // administrativeUnits
+	SiteConfigurationCategory CategoryType = 21 // siteConfiguration
 )
 
 var strToCat = map[string]CategoryType{
@@ -43,6 +53,16 @@ var strToCat = map[string]CategoryType{
 	strings.ToLower(ChannelMessagesCategory.String()):   ChannelMessagesCategory,
 	strings.ToLower(ConversationPostsCategory.String()): ConversationPostsCategory,
 	strings.ToLower(ChatsCategory.String()):             ChatsCategory,
+	strings.ToLower(PlannerCategory.String()):           PlannerCategory,
+	strings.ToLower(TeamStructureCategory.String()):     TeamStructureCategory,
+	strings.ToLower(GroupMembershipCategory.String()):   GroupMembershipCategory,
+	strings.ToLower(DirectoryUsersCategory.String()):    DirectoryUsersCategory,
+	strings.ToLower(DirectoryGroupsCategory.String()):   DirectoryGroupsCategory,
+	strings.ToLower(ApplicationsCategory.String()):      ApplicationsCategory,
+	strings.ToLower(ServicePrincipalsCategory.String()): ServicePrincipalsCategory,
+	strings.ToLower(ConditionalAccessCategory.String()): ConditionalAccessCategory,
+	strings.ToLower(AdminUnitsCategory.String()):        AdminUnitsCategory,
+	strings.ToLower(SiteConfigurationCategory.String()): SiteConfigurationCategory,
 }
 
 func ToCategoryType(s string) CategoryType {
@@ -66,6 +86,16 @@ var catToHuman = map[CategoryType]string{
 	ChannelMessagesCategory:   "Messages",
 	ConversationPostsCategory: "Posts",
 	ChatsCategory:             "Chats",
+	PlannerCategory:           "Plans",
+	TeamStructureCategory:     "Team Structure",
+	GroupMembershipCategory:   "Group Membership",
+	DirectoryUsersCategory:    "Users",
+	DirectoryGroupsCategory:   "Groups",
+	ApplicationsCategory:      "Applications",
+	ServicePrincipalsCategory: "Service Principals",
+	ConditionalAccessCategory: "Conditional Access Policies",
+	AdminUnitsCategory:        "Administrative Units",
+	SiteConfigurationCategory: "Site Configuration",
 }
 
 // HumanString produces a more human-readable string version of the category.
@@ -94,18 +124,31 @@ var serviceCategories = map[ServiceType]map[CategoryType]struct{}{
 		FilesCategory: {},
 	},
 	SharePointService: {
-		LibrariesCategory: {},
-		ListsCategory:     {},
-		PagesCategory:     {},
+		LibrariesCategory:         {},
+		ListsCategory:             {},
+		PagesCategory:             {},
+		SiteConfigurationCategory: {},
 	},
 	GroupsService: {
 		ChannelMessagesCategory:   {},
 		ConversationPostsCategory: {},
+		EventsCategory:            {},
 		LibrariesCategory:         {},
+		PlannerCategory:           {},
+		TeamStructureCategory:     {},
+		GroupMembershipCategory:   {},
 	},
 	TeamsChatsService: {
 		ChatsCategory: {},
 	},
+	DirectoryService: {
+		DirectoryUsersCategory:    {},
+		DirectoryGroupsCategory:   {},
+		ApplicationsCategory:      {},
+		ServicePrincipalsCategory: {},
+		ConditionalAccessCategory: {},
+		AdminUnitsCategory:        {},
+	},
 }
 
 func validateServiceAndCategoryStrings(s, c string) (ServiceType, CategoryType, error) {
@@ -20,11 +20,21 @@ func _() {
 	_ = x[ChannelMessagesCategory-9]
 	_ = x[ConversationPostsCategory-10]
 	_ = x[ChatsCategory-11]
+	_ = x[PlannerCategory-12]
+	_ = x[TeamStructureCategory-13]
+	_ = x[GroupMembershipCategory-14]
+	_ = x[DirectoryUsersCategory-15]
+	_ = x[DirectoryGroupsCategory-16]
+	_ = x[ApplicationsCategory-17]
+	_ = x[ServicePrincipalsCategory-18]
+	_ = x[ConditionalAccessCategory-19]
+	_ = x[AdminUnitsCategory-20]
+	_ = x[SiteConfigurationCategory-21]
 }
 
-const _CategoryType_name = "UnknownCategoryemailcontactseventsfileslistslibrariespagesdetailschannelMessagesconversationPostschats"
+const _CategoryType_name = "UnknownCategoryemailcontactseventsfileslistslibrariespagesdetailschannelMessagesconversationPostschatsplannerteamStructuregroupMembershipdirectoryUsersdirectoryGroupsapplicationsservicePrincipalsconditionalAccessPoliciesadministrativeUnitssiteConfiguration"
 
-var _CategoryType_index = [...]uint8{0, 15, 20, 28, 34, 39, 44, 53, 58, 65, 80, 97, 102}
+var _CategoryType_index = [...]uint16{0, 15, 20, 28, 34, 39, 44, 53, 58, 65, 80, 97, 102, 109, 122, 137, 151, 166, 178, 195, 220, 239, 256}
 
 func (i CategoryType) String() string {
 	if i < 0 || i >= CategoryType(len(_CategoryType_index)-1) {
@@ -48,6 +48,9 @@ var piiSafePathElems = pii.MapWithPlurals(
 	"msgfolderroot",
 	"outbox",
 	"recoverableitemsdeletion",
+	"recoverableitemspurge",
+	"recoverableitemsroot",
+	"recoverableitemsversion",
 	"scheduled",
 	"searchfolder",
 	"sentitem",
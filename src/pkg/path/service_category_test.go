@@ -126,6 +126,22 @@ func (suite *ServiceCategoryUnitSuite) TestValidateServiceAndCategory() {
 			expectedCategory: ChatsCategory,
 			check:            assert.NoError,
 		},
+		{
+			name:             "DirectoryGroups",
+			service:          DirectoryService.String(),
+			category:         DirectoryGroupsCategory.String(),
+			expectedService:  DirectoryService,
+			expectedCategory: DirectoryGroupsCategory,
+			check:            assert.NoError,
+		},
+		{
+			name:             "DirectoryConditionalAccessPolicies",
+			service:          DirectoryService.String(),
+			category:         ConditionalAccessCategory.String(),
+			expectedService:  DirectoryService,
+			expectedCategory: ConditionalAccessCategory,
+			check:            assert.NoError,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -33,6 +33,8 @@ const (
 	GroupsMetadataService     ServiceType = 8  // groupsMetadata
 	TeamsChatsService         ServiceType = 9  // teamsChats
 	TeamsChatsMetadataService ServiceType = 10 // teamsChatsMetadata
+	DirectoryService          ServiceType = 11 // directory
+	DirectoryMetadataService  ServiceType = 12 // directoryMetadata
 )
 
 var strToSvc = map[string]ServiceType{
@@ -46,6 +48,8 @@ var strToSvc = map[string]ServiceType{
 	strings.ToLower(GroupsMetadataService.String()):     GroupsMetadataService,
 	strings.ToLower(TeamsChatsService.String()):         TeamsChatsService,
 	strings.ToLower(TeamsChatsMetadataService.String()): TeamsChatsMetadataService,
+	strings.ToLower(DirectoryService.String()):          DirectoryService,
+	strings.ToLower(DirectoryMetadataService.String()):  DirectoryMetadataService,
 }
 
 func ToServiceType(service string) ServiceType {
@@ -63,6 +67,7 @@ var serviceToHuman = map[ServiceType]string{
 	SharePointService: "SharePoint",
 	GroupsService:     "Groups",
 	TeamsChatsService: "Chats",
+	DirectoryService:  "Directory",
 }
 
 // HumanString produces a more human-readable string version of the service.
@@ -88,6 +93,8 @@ func (svc ServiceType) ToMetadata() ServiceType {
 		return GroupsMetadataService
 	case TeamsChatsService, TeamsChatsMetadataService:
 		return TeamsChatsMetadataService
+	case DirectoryService, DirectoryMetadataService:
+		return DirectoryMetadataService
 	case UnknownService:
 		fallthrough
 	default:
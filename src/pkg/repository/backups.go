@@ -16,6 +16,7 @@ import (
 	"github.com/alcionai/corso/src/internal/version"
 	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/search"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph/metadata"
@@ -36,6 +37,14 @@ type BackupGetter interface {
 		ctx context.Context,
 		backupID string,
 	) (*fault.Errors, *backup.Backup, *fault.Bus)
+	GetBackupSearchIndex(
+		ctx context.Context,
+		backupID string,
+	) (*search.BackupIndex, *backup.Backup, *fault.Bus)
+	GetBackupContents(
+		ctx context.Context,
+		backupID string,
+	) (*search.Contents, *backup.Backup, *fault.Bus)
 }
 
 type Backuper interface {
@@ -227,22 +236,28 @@ func getBackupDetails(
 		return nil, nil, err
 	}
 
-	// Retroactively fill in isMeta information for items in older
-	// backup versions without that info
-	// version.Restore2 introduces the IsMeta flag, so only v1 needs a check.
-	if b.Version >= version.OneDrive1DataAndMetaFiles && b.Version < version.OneDrive3IsMetaMarker {
-		for _, d := range deets.Entries {
-			if d.OneDrive != nil {
-				d.OneDrive.IsMeta = metadata.HasMetaSuffix(d.RepoRef)
-			}
-		}
-	}
+	fillLegacyIsMeta(&deets, b.Version)
 
 	deets.DetailsModel = deets.FilterMetaFiles()
 
 	return &deets, b, nil
 }
 
+// fillLegacyIsMeta retroactively fills in isMeta information for items in
+// older backup versions without that info.
+func fillLegacyIsMeta(deets *details.Details, backupVersion int) {
+	// version.Restore2 introduces the IsMeta flag, so only v1 needs a check.
+	if backupVersion < version.OneDrive1DataAndMetaFiles || backupVersion >= version.OneDrive3IsMetaMarker {
+		return
+	}
+
+	for _, d := range deets.Entries {
+		if d.OneDrive != nil {
+			d.OneDrive.IsMeta = metadata.HasMetaSuffix(d.RepoRef)
+		}
+	}
+}
+
 // BackupErrors returns the specified backup's fault.Errors
 func (r repository) GetBackupErrors(
 	ctx context.Context,
@@ -296,6 +311,113 @@ func getBackupErrors(
 	return &fe, b, nil
 }
 
+// GetBackupSearchIndex returns the specified backup's search index.
+func (r repository) GetBackupSearchIndex(
+	ctx context.Context,
+	backupID string,
+) (*search.BackupIndex, *backup.Backup, *fault.Bus) {
+	errs := fault.New(false)
+
+	bi, bup, err := getBackupSearchIndex(
+		ctx,
+		backupID,
+		r.Account.ID(),
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		errs)
+
+	return bi, bup, errs.Fail(err)
+}
+
+// getBackupSearchIndex handles the processing for GetBackupSearchIndex.
+func getBackupSearchIndex(
+	ctx context.Context,
+	backupID, tenantID string,
+	kw *kopia.Wrapper,
+	sw store.BackupGetter,
+	errs *fault.Bus,
+) (*search.BackupIndex, *backup.Backup, error) {
+	b, err := sw.GetBackup(ctx, model.StableID(backupID))
+	if err != nil {
+		return nil, nil, errWrapper(err)
+	}
+
+	ssid := b.StreamStoreID
+	if len(ssid) == 0 {
+		return nil, b, clues.NewWC(ctx, "missing streamstore id in backup")
+	}
+
+	var (
+		sstore = streamstore.NewStreamer(kw, tenantID, b.Selector.PathService())
+		bi     search.BackupIndex
+	)
+
+	err = sstore.Read(
+		ctx,
+		ssid,
+		streamstore.SearchIndexReader(search.UnmarshalIndexTo(&bi)),
+		errs)
+	if err != nil {
+		return nil, b, err
+	}
+
+	return &bi, b, nil
+}
+
+// GetBackupContents returns the text of the specified backup's items
+// recorded by the content indexing extension.
+func (r repository) GetBackupContents(
+	ctx context.Context,
+	backupID string,
+) (*search.Contents, *backup.Backup, *fault.Bus) {
+	errs := fault.New(false)
+
+	c, bup, err := getBackupContents(
+		ctx,
+		backupID,
+		r.Account.ID(),
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		errs)
+
+	return c, bup, errs.Fail(err)
+}
+
+// getBackupContents handles the processing for GetBackupContents.
+func getBackupContents(
+	ctx context.Context,
+	backupID, tenantID string,
+	kw *kopia.Wrapper,
+	sw store.BackupGetter,
+	errs *fault.Bus,
+) (*search.Contents, *backup.Backup, error) {
+	b, err := sw.GetBackup(ctx, model.StableID(backupID))
+	if err != nil {
+		return nil, nil, errWrapper(err)
+	}
+
+	ssid := b.StreamStoreID
+	if len(ssid) == 0 {
+		return nil, b, clues.NewWC(ctx, "missing streamstore id in backup")
+	}
+
+	var (
+		sstore = streamstore.NewStreamer(kw, tenantID, b.Selector.PathService())
+		c      search.Contents
+	)
+
+	err = sstore.Read(
+		ctx,
+		ssid,
+		streamstore.ContentsReader(search.UnmarshalContentsTo(&c)),
+		errs)
+	if err != nil {
+		return nil, b, err
+	}
+
+	return &c, b, nil
+}
+
 // DeleteBackups removes the backups from both the model store and the backup
 // storage.
 //
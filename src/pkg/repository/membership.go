@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/membership"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type MembershipGetter interface {
+	GetGroupMembership(
+		ctx context.Context,
+		backupID string,
+	) (*membership.Membership, *backup.Backup, error)
+}
+
+// GetGroupMembership retrieves the group owners and members that were
+// recorded by a groups backup.  Returns core.ErrNotFound if the backup
+// was made before membership was recorded.
+func (r repository) GetGroupMembership(
+	ctx context.Context,
+	backupID string,
+) (*membership.Membership, *backup.Backup, error) {
+	bup, err := r.Backup(ctx, backupID)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "looking up backup")
+	}
+
+	if bup.Selector.Service != selectors.ServiceGroups {
+		return nil, bup, clues.NewWC(ctx, "membership is only recorded for groups backups").
+			With("service", bup.Selector.Service.String())
+	}
+
+	pth, err := path.BuildMetadata(
+		r.Account.ID(),
+		bup.ResourceOwnerID,
+		path.GroupsService,
+		path.GroupMembershipCategory,
+		true,
+		membership.FileName)
+	if err != nil {
+		return nil, bup, clues.Wrap(err, "building membership path")
+	}
+
+	dir, err := pth.Dir()
+	if err != nil {
+		return nil, bup, clues.Wrap(err, "building membership collection path")
+	}
+
+	colls, err := r.dataLayer.ProduceRestoreCollections(
+		ctx,
+		bup.SnapshotID,
+		[]path.RestorePaths{{StoragePath: pth, RestorePath: dir}},
+		nil,
+		fault.New(true))
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			err = clues.Stack(core.ErrNotFound, err)
+		}
+
+		return nil, bup, clues.Wrap(err, "looking up membership")
+	}
+
+	m, err := readMembership(ctx, colls)
+
+	return m, bup, clues.Stack(err).OrNil()
+}
+
+func readMembership(
+	ctx context.Context,
+	colls []data.RestoreCollection,
+) (*membership.Membership, error) {
+	var (
+		m    *membership.Membership
+		errs = fault.New(true)
+	)
+
+	for _, coll := range colls {
+		for item := range coll.Items(ctx, errs) {
+			if m != nil || item.ID() != membership.FileName {
+				continue
+			}
+
+			mem, err := decodeMembership(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+
+			m = mem
+		}
+	}
+
+	if errs.Failure() != nil {
+		return nil, clues.Wrap(errs.Failure(), "reading membership")
+	}
+
+	if m == nil {
+		return nil, clues.StackWC(ctx, core.ErrNotFound)
+	}
+
+	return m, nil
+}
+
+func decodeMembership(
+	ctx context.Context,
+	item data.Item,
+) (*membership.Membership, error) {
+	rc := item.ToReader()
+	defer rc.Close()
+
+	m := &membership.Membership{}
+
+	if err := json.NewDecoder(rc).Decode(m); err != nil {
+		return nil, clues.WrapWC(ctx, err, "decoding membership")
+	}
+
+	return m, nil
+}
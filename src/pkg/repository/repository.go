@@ -39,6 +39,8 @@ type Repositoryer interface {
 	Restorer
 	Exporter
 	Debugger
+	MembershipGetter
+	BackupVerifier
 	DataProviderConnector
 
 	Initialize(
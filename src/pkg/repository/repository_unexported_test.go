@@ -137,6 +137,60 @@ func (suite *RepositoryBackupsUnitSuite) TestGetBackup() {
 	}
 }
 
+func (suite *RepositoryBackupsUnitSuite) TestFillLegacyIsMeta() {
+	entries := func() []details.Entry {
+		return []details.Entry{
+			{RepoRef: "tid/onedrive/uid/files/drives/did/root:/item.data"},
+			{RepoRef: "tid/onedrive/uid/files/drives/did/root:/item.meta"},
+			{RepoRef: "tid/onedrive/uid/files/drives/did/root:/folder.dirmeta"},
+			{RepoRef: "tid/onedrive/uid/files/drives/did/root:/item.versions"},
+			{RepoRef: "tid/onedrive/uid/files/drives/did/root:/report.versions.data"},
+		}
+	}
+
+	table := []struct {
+		name          string
+		backupVersion int
+		expectIsMeta  []bool
+	}{
+		{
+			name:          "data and meta files",
+			backupVersion: version.OneDrive1DataAndMetaFiles,
+			// .versions files carry version content, and get treated as
+			// metadata along with the .meta and .dirmeta files.
+			expectIsMeta: []bool{false, true, true, true, false},
+		},
+		{
+			name:          "before data and meta files",
+			backupVersion: version.NoBackup,
+			expectIsMeta:  []bool{false, false, false, false, false},
+		},
+		{
+			name:          "isMeta marker",
+			backupVersion: version.OneDrive3IsMetaMarker,
+			expectIsMeta:  []bool{false, false, false, false, false},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			deets := &details.Details{}
+
+			for _, ent := range entries() {
+				ent.ItemInfo = details.ItemInfo{OneDrive: &details.OneDriveInfo{}}
+				deets.Entries = append(deets.Entries, ent)
+			}
+
+			fillLegacyIsMeta(deets, test.backupVersion)
+
+			for i, ent := range deets.Entries {
+				assert.Equal(t, test.expectIsMeta[i], ent.OneDrive.IsMeta, ent.RepoRef)
+			}
+		})
+	}
+}
+
 func (suite *RepositoryBackupsUnitSuite) TestBackupsByTag() {
 	unlabeled1 := &backup.Backup{
 		BaseModel: model.BaseModel{
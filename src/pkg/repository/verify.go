@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/pkg/backup/verify"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph/metadata"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+type BackupVerifier interface {
+	VerifyBackup(
+		ctx context.Context,
+		backupID string,
+		sample int,
+	) (*verify.Report, error)
+}
+
+// VerifyBackup checks that the backup can be restored.  It reads the
+// backup's details and errors, checks that every item in the details has
+// data in the backup's snapshot and vice versa, and reads the data of
+// every file in the snapshot.  If sample is greater than zero, only that
+// many randomly chosen files get read.
+//
+// Problems found in the backup are listed in the report.  Errors are only
+// returned if the verification couldn't run.
+func (r repository) VerifyBackup(
+	ctx context.Context,
+	backupID string,
+	sample int,
+) (*verify.Report, error) {
+	ctx = clues.Add(ctx, "backup_id", backupID)
+
+	bup, err := r.Backup(ctx, backupID)
+	if err != nil {
+		return nil, clues.Wrap(err, "looking up backup")
+	}
+
+	v := verify.NewVerifier(backupID, bup.ProtectedResourceName, bup.CreationTime)
+	sw := store.NewWrapper(r.modelStore)
+
+	deets, _, err := getBackupDetails(ctx, backupID, r.Account.ID(), r.dataLayer, sw, fault.New(false))
+	if err != nil {
+		v.Fail(verify.Failure{Problem: verify.DetailsUnreadable, Reason: err.Error()})
+	} else {
+		v.SetDetails(deets)
+	}
+
+	// backups made before errors were recorded have no streamstore id.
+	if len(bup.StreamStoreID) > 0 {
+		_, _, err = getBackupErrors(ctx, backupID, r.Account.ID(), r.dataLayer, sw, fault.New(false))
+		if err != nil {
+			v.Fail(verify.Failure{Problem: verify.ErrorsUnreadable, Reason: err.Error()})
+		}
+	}
+
+	files := map[string]kopia.SnapshotFile{}
+	prefix := path.Builder{}.Append(r.Account.ID(), bup.Selector.PathService().String())
+
+	err = r.dataLayer.WalkSnapshotFiles(
+		ctx,
+		bup.SnapshotID,
+		prefix,
+		func(ictx context.Context, sf kopia.SnapshotFile) error {
+			p, err := path.FromDataLayerPath(sf.RepoRef, true)
+			if err != nil {
+				return clues.WrapWC(ictx, err, "parsing snapshot file path")
+			}
+
+			files[sf.RepoRef] = sf
+
+			v.AddFile(verify.File{
+				RepoRef: sf.RepoRef,
+				Size:    sf.Size,
+				Meta:    metadata.IsMetadataFile(p),
+			})
+
+			return nil
+		})
+	if err != nil {
+		return nil, clues.Wrap(err, "walking backup snapshot")
+	}
+
+	toRead := v.ToRead(sample)
+
+	for i, f := range toRead {
+		if err := ctx.Err(); err != nil {
+			return nil, clues.StackWC(ctx, err)
+		}
+
+		sf := files[f.RepoRef]
+
+		fc, err := sf.ReadAll(ctx)
+		if err != nil {
+			logger.CtxErr(ctx, err).Info("reading snapshot file")
+		}
+
+		v.AddContent(f, verify.Content{Size: fc.Size, DelInFlight: fc.DelInFlight}, err)
+
+		if (i+1)%1000 == 0 {
+			logger.Ctx(ctx).Infow("verifying backup data", "read_files", i+1, "total_files", len(toRead))
+		}
+	}
+
+	rep := v.Report()
+
+	return &rep, nil
+}
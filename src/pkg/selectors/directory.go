@@ -0,0 +1,621 @@
+package selectors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/filters"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// ---------------------------------------------------------------------------
+// Selectors
+// ---------------------------------------------------------------------------
+
+type (
+	// directory provides an api for selecting
+	// data scopes applicable to the Directory service.
+	directory struct {
+		Selector
+	}
+
+	// DirectoryBackup provides an api for selecting
+	// data scopes applicable to the Directory service,
+	// plus backup-specific methods.
+	DirectoryBackup struct {
+		directory
+	}
+
+	// DirectoryRestore provides an api for selecting
+	// data scopes applicable to the Directory service,
+	// plus restore-specific methods.
+	DirectoryRestore struct {
+		directory
+	}
+)
+
+var (
+	_ Reducer        = &DirectoryRestore{}
+	_ pathCategorier = &DirectoryRestore{}
+	_ reasoner       = &DirectoryRestore{}
+)
+
+// NewDirectoryBackup produces a new Selector with the service set to ServiceDirectory.
+// The directory is owned by the tenant, so the tenant IDs act as the
+// protected resources.
+func NewDirectoryBackup(tenants []string) *DirectoryBackup {
+	src := DirectoryBackup{
+		directory{
+			newSelector(ServiceDirectory, tenants),
+		},
+	}
+
+	return &src
+}
+
+// ToDirectoryBackup transforms the generic selector into a DirectoryBackup.
+// Errors if the service defined by the selector is not ServiceDirectory.
+func (s Selector) ToDirectoryBackup() (*DirectoryBackup, error) {
+	if s.Service != ServiceDirectory {
+		return nil, badCastErr(ServiceDirectory, s.Service)
+	}
+
+	src := DirectoryBackup{directory{s}}
+
+	return &src, nil
+}
+
+func (s DirectoryBackup) SplitByResourceOwner(tenants []string) []DirectoryBackup {
+	sels := splitByProtectedResource[DirectoryScope](s.Selector, tenants, DirectoryTenant)
+
+	ss := make([]DirectoryBackup, 0, len(sels))
+	for _, sel := range sels {
+		ss = append(ss, DirectoryBackup{directory{sel}})
+	}
+
+	return ss
+}
+
+// NewDirectoryRestore produces a new Selector with the service set to ServiceDirectory.
+func NewDirectoryRestore(tenants []string) *DirectoryRestore {
+	src := DirectoryRestore{
+		directory{
+			newSelector(ServiceDirectory, tenants),
+		},
+	}
+
+	return &src
+}
+
+// ToDirectoryRestore transforms the generic selector into a DirectoryRestore.
+// Errors if the service defined by the selector is not ServiceDirectory.
+func (s Selector) ToDirectoryRestore() (*DirectoryRestore, error) {
+	if s.Service != ServiceDirectory {
+		return nil, badCastErr(ServiceDirectory, s.Service)
+	}
+
+	src := DirectoryRestore{directory{s}}
+
+	return &src, nil
+}
+
+func (sr DirectoryRestore) SplitByResourceOwner(tenants []string) []DirectoryRestore {
+	sels := splitByProtectedResource[DirectoryScope](sr.Selector, tenants, DirectoryTenant)
+
+	ss := make([]DirectoryRestore, 0, len(sels))
+	for _, sel := range sels {
+		ss = append(ss, DirectoryRestore{directory{sel}})
+	}
+
+	return ss
+}
+
+// PathCategories produces the aggregation of discrete tenants described by each type of scope.
+func (s directory) PathCategories() selectorPathCategories {
+	return selectorPathCategories{
+		Excludes: pathCategoriesIn[DirectoryScope, directoryCategory](s.Excludes),
+		Filters:  pathCategoriesIn[DirectoryScope, directoryCategory](s.Filters),
+		Includes: pathCategoriesIn[DirectoryScope, directoryCategory](s.Includes),
+	}
+}
+
+// Reasons returns a deduplicated set of the backup reasons produced
+// using the selector's discrete owner and each scopes' service and
+// category types.
+func (s directory) Reasons(tenantID string, useOwnerNameForID bool) []identity.Reasoner {
+	return reasonsFor(s, tenantID, useOwnerNameForID)
+}
+
+// ---------------------------------------------------------------------------
+// Stringers and Concealers
+// ---------------------------------------------------------------------------
+
+func (s DirectoryScope) Conceal() string             { return conceal(s) }
+func (s DirectoryScope) Format(fs fmt.State, r rune) { format(s, fs, r) }
+func (s DirectoryScope) String() string              { return conceal(s) }
+func (s DirectoryScope) PlainString() string         { return plainString(s) }
+
+// -------------------
+// Exclude/Includes
+
+// Exclude appends the provided scopes to the selector's exclusion set.
+// Every Exclusion scope applies globally, affecting all inclusion scopes.
+// Data is excluded if it matches ANY exclusion (of the same data category).
+//
+// All parts of the scope must match for data to be exclucded.
+// Ex: Users(u1) => only excludes the directory user u1.
+// Use selectors.Any() to wildcard a scope value.
+// No value will match if selectors.None() is provided.
+//
+// Group-level scopes will automatically apply the Any() wildcard to
+// child properties.
+// ex: Tenant(t1) automatically cascades to all users, groups, etc.
+func (s *directory) Exclude(scopes ...[]DirectoryScope) {
+	s.Excludes = appendScopes(s.Excludes, scopes...)
+}
+
+// Filter appends the provided scopes to the selector's filters set.
+// A selector with >0 filters and 0 inclusions will include any data
+// that passes all filters.
+// A selector with >0 filters and >0 inclusions will reduce the
+// inclusion set to only the data that passes all filters.
+// Data is retained if it passes ALL filters (of the same data category).
+//
+// All parts of the scope must match for data to pass the filter.
+// Ex: Users(u1) => only passes the directory user u1.
+// Use selectors.Any() to wildcard a scope value.
+// No value will match if selectors.None() is provided.
+//
+// Group-level scopes will automatically apply the Any() wildcard to
+// child properties.
+// ex: Tenant(t1) automatically cascades to all users, groups, etc.
+func (s *directory) Filter(scopes ...[]DirectoryScope) {
+	s.Filters = appendScopes(s.Filters, scopes...)
+}
+
+// Include appends the provided scopes to the selector's inclusion set.
+// Data is included if it matches ANY inclusion.
+// The inclusion set is later filtered (all included data must pass ALL
+// filters) and excluded (all included data must not match ANY exclusion).
+// Data is included if it matches ANY inclusion (of the same data category).
+//
+// All parts of the scope must match for data to be included.
+// Ex: Users(u1) => only includes the directory user u1.
+// Use selectors.Any() to wildcard a scope value.
+// No value will match if selectors.None() is provided.
+//
+// Group-level scopes will automatically apply the Any() wildcard to
+// child properties.
+// ex: Tenant(t1) automatically cascades to all users, groups, etc.
+func (s *directory) Include(scopes ...[]DirectoryScope) {
+	s.Includes = appendScopes(s.Includes, scopes...)
+}
+
+// Scopes retrieves the list of directoryScopes in the selector.
+func (s *directory) Scopes() []DirectoryScope {
+	return scopes[DirectoryScope](s.Selector)
+}
+
+// -------------------
+// Scope Factories
+
+// Users produces one or more directory user scopes.
+// Users are matched by their object ID or display name.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *directory) Users(users []string) []DirectoryScope {
+	return []DirectoryScope{
+		makeScope[DirectoryScope](DirectoryUser, users, defaultItemOptions(s.Cfg)...),
+	}
+}
+
+// Groups produces one or more directory group scopes.
+// Groups are matched by their object ID or display name.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *directory) Groups(groups []string) []DirectoryScope {
+	return []DirectoryScope{
+		makeScope[DirectoryScope](DirectoryGroup, groups, defaultItemOptions(s.Cfg)...),
+	}
+}
+
+// Applications produces one or more app registration scopes.
+// Applications are matched by their object ID or display name.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *directory) Applications(apps []string) []DirectoryScope {
+	return []DirectoryScope{
+		makeScope[DirectoryScope](DirectoryApplication, apps, defaultItemOptions(s.Cfg)...),
+	}
+}
+
+// ServicePrincipals produces one or more service principal scopes.
+// Service principals are matched by their object ID or display name.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *directory) ServicePrincipals(sps []string) []DirectoryScope {
+	return []DirectoryScope{
+		makeScope[DirectoryScope](DirectoryServicePrincipal, sps, defaultItemOptions(s.Cfg)...),
+	}
+}
+
+// ConditionalAccessPolicies produces one or more conditional access policy scopes.
+// Policies are matched by their ID or display name.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *directory) ConditionalAccessPolicies(policies []string) []DirectoryScope {
+	return []DirectoryScope{
+		makeScope[DirectoryScope](DirectoryConditionalAccessPolicy, policies, defaultItemOptions(s.Cfg)...),
+	}
+}
+
+// AdministrativeUnits produces one or more administrative unit scopes.
+// Administrative units are matched by their object ID or display name.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *directory) AdministrativeUnits(units []string) []DirectoryScope {
+	return []DirectoryScope{
+		makeScope[DirectoryScope](DirectoryAdminUnit, units, defaultItemOptions(s.Cfg)...),
+	}
+}
+
+// Retrieves all directory data.
+// Each tenant id generates a scope for each data type: users, groups,
+// applications, service principals, conditional access policies, and
+// administrative units.
+func (s *directory) AllData() []DirectoryScope {
+	scopes := []DirectoryScope{}
+
+	scopes = append(
+		scopes,
+		makeScope[DirectoryScope](DirectoryUser, Any()),
+		makeScope[DirectoryScope](DirectoryGroup, Any()),
+		makeScope[DirectoryScope](DirectoryApplication, Any()),
+		makeScope[DirectoryScope](DirectoryServicePrincipal, Any()),
+		makeScope[DirectoryScope](DirectoryConditionalAccessPolicy, Any()),
+		makeScope[DirectoryScope](DirectoryAdminUnit, Any()))
+
+	return scopes
+}
+
+// -------------------
+// ItemInfo Factories
+
+// UserPrincipalName produces one or more directory user info scopes.
+// Matches any user whose principal name contains the provided string.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (sr *DirectoryRestore) UserPrincipalName(upn string) []DirectoryScope {
+	return []DirectoryScope{
+		makeInfoScope[DirectoryScope](
+			DirectoryUser,
+			DirectoryInfoUserPrincipalName,
+			[]string{upn},
+			filters.In),
+	}
+}
+
+// GroupMailNickname produces one or more directory group info scopes.
+// Matches any group whose mail nickname contains the provided string.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (sr *DirectoryRestore) GroupMailNickname(nickname string) []DirectoryScope {
+	return []DirectoryScope{
+		makeInfoScope[DirectoryScope](
+			DirectoryGroup,
+			DirectoryInfoGroupMailNickname,
+			[]string{nickname},
+			filters.In),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Categories
+// ---------------------------------------------------------------------------
+
+// directoryCategory enumerates the type of the lowest level
+// of data specified by the scope.
+type directoryCategory string
+
+// interface compliance checks
+var _ categorizer = DirectoryCategoryUnknown
+
+const (
+	DirectoryCategoryUnknown directoryCategory = ""
+
+	// types of data identified by directory
+	DirectoryTenant                  directoryCategory = "DirectoryTenant"
+	DirectoryUser                    directoryCategory = "DirectoryUser"
+	DirectoryGroup                   directoryCategory = "DirectoryGroup"
+	DirectoryApplication             directoryCategory = "DirectoryApplication"
+	DirectoryServicePrincipal        directoryCategory = "DirectoryServicePrincipal"
+	DirectoryConditionalAccessPolicy directoryCategory = "DirectoryConditionalAccessPolicy"
+	DirectoryAdminUnit               directoryCategory = "DirectoryAdminUnit"
+
+	// data contained within details.ItemInfo
+	DirectoryInfoUserPrincipalName directoryCategory = "DirectoryInfoUserPrincipalName"
+	DirectoryInfoGroupMailNickname directoryCategory = "DirectoryInfoGroupMailNickname"
+)
+
+// directoryLeafProperties describes common metadata of the leaf categories
+var directoryLeafProperties = map[categorizer]leafProperty{
+	DirectoryUser: {
+		pathKeys: []categorizer{DirectoryUser},
+		pathType: path.DirectoryUsersCategory,
+	},
+	DirectoryGroup: {
+		pathKeys: []categorizer{DirectoryGroup},
+		pathType: path.DirectoryGroupsCategory,
+	},
+	DirectoryApplication: {
+		pathKeys: []categorizer{DirectoryApplication},
+		pathType: path.ApplicationsCategory,
+	},
+	DirectoryServicePrincipal: {
+		pathKeys: []categorizer{DirectoryServicePrincipal},
+		pathType: path.ServicePrincipalsCategory,
+	},
+	DirectoryConditionalAccessPolicy: {
+		pathKeys: []categorizer{DirectoryConditionalAccessPolicy},
+		pathType: path.ConditionalAccessCategory,
+	},
+	DirectoryAdminUnit: {
+		pathKeys: []categorizer{DirectoryAdminUnit},
+		pathType: path.AdminUnitsCategory,
+	},
+	DirectoryTenant: { // the root category must be represented, even though it isn't a leaf
+		pathKeys: []categorizer{DirectoryTenant},
+		pathType: path.UnknownCategory,
+	},
+}
+
+func (c directoryCategory) String() string {
+	return string(c)
+}
+
+// leafCat returns the leaf category of the receiver.
+// If the receiver category has multiple leaves (ex: Tenant) or no leaves,
+// (ex: Unknown), the receiver itself is returned.
+// If the receiver category is an info type (ex: DirectoryInfoUserPrincipalName),
+// returns the category covered by the info.
+// Ex: DirectoryInfoUserPrincipalName.leafCat() => DirectoryUser
+// Ex: DirectoryTenant.leafCat() => DirectoryTenant
+func (c directoryCategory) leafCat() categorizer {
+	switch c {
+	case DirectoryUser, DirectoryInfoUserPrincipalName:
+		return DirectoryUser
+	case DirectoryGroup, DirectoryInfoGroupMailNickname:
+		return DirectoryGroup
+	}
+
+	return c
+}
+
+// rootCat returns the root category type.
+func (c directoryCategory) rootCat() categorizer {
+	return DirectoryTenant
+}
+
+// unknownCat returns the unknown category type.
+func (c directoryCategory) unknownCat() categorizer {
+	return DirectoryCategoryUnknown
+}
+
+// isUnion returns true if c is a tenant
+func (c directoryCategory) isUnion() bool {
+	return c == c.rootCat()
+}
+
+// isLeaf is true if the category is one of the directory object categories.
+func (c directoryCategory) isLeaf() bool {
+	return c == c.leafCat()
+}
+
+// pathValues transforms the two paths to maps of identified properties.
+//
+// Example:
+// [tenantID, service, tenantID, category, objectID]
+// => {directoryUser: objectID}
+func (c directoryCategory) pathValues(
+	repo path.Path,
+	ent details.Entry,
+	cfg Config,
+) (map[categorizer][]string, error) {
+	switch c {
+	case DirectoryUser,
+		DirectoryGroup,
+		DirectoryApplication,
+		DirectoryServicePrincipal,
+		DirectoryConditionalAccessPolicy,
+		DirectoryAdminUnit:
+	default:
+		return nil, clues.New("bad Directory Category").With("category", c)
+	}
+
+	if ent.Directory == nil {
+		return nil, clues.New("no Directory ItemInfo in details")
+	}
+
+	item := ent.ItemRef
+	if len(item) == 0 {
+		item = repo.Item()
+	}
+
+	items := []string{ent.ShortRef, item, ent.Directory.Object.DisplayName}
+
+	// the object ID is dropped when the caller is only matching item
+	// names, so that ids and display names don't get mixed up.
+	if cfg.OnlyMatchItemNames {
+		items = []string{ent.ShortRef, ent.Directory.Object.DisplayName}
+	}
+
+	result := map[categorizer][]string{
+		c: items,
+	}
+
+	return result, nil
+}
+
+// pathKeys returns the path keys recognized by the receiver's leaf type.
+func (c directoryCategory) pathKeys() []categorizer {
+	return directoryLeafProperties[c.leafCat()].pathKeys
+}
+
+// PathType converts the category's leaf type into the matching path.CategoryType.
+func (c directoryCategory) PathType() path.CategoryType {
+	return directoryLeafProperties[c.leafCat()].pathType
+}
+
+// ---------------------------------------------------------------------------
+// Scopes
+// ---------------------------------------------------------------------------
+
+// DirectoryScope specifies the data available
+// when interfacing with the Directory service.
+type DirectoryScope scope
+
+// interface compliance checks
+var _ scoper = &DirectoryScope{}
+
+// Category describes the type of the data in scope.
+func (s DirectoryScope) Category() directoryCategory {
+	return directoryCategory(getCategory(s))
+}
+
+// categorizer type is a generic wrapper around Category.
+// Primarily used by scopes.go to for abstract comparisons.
+func (s DirectoryScope) categorizer() categorizer {
+	return s.Category()
+}
+
+// Matches returns true if the category is included in the scope's
+// data type, and the target string matches that category's comparator.
+func (s DirectoryScope) Matches(cat directoryCategory, target string) bool {
+	return matches(s, cat, target)
+}
+
+// InfoCategory returns the category enum of the scope info.
+// If the scope is not an info type, returns DirectoryCategoryUnknown.
+func (s DirectoryScope) InfoCategory() directoryCategory {
+	return directoryCategory(getInfoCategory(s))
+}
+
+// IncludeCategory checks whether the scope includes a certain category of data.
+// Ex: to check if the scope includes user data:
+// s.IncludesCategory(selector.DirectoryUser)
+func (s DirectoryScope) IncludesCategory(cat directoryCategory) bool {
+	return categoryMatches(s.Category(), cat)
+}
+
+// returns true if the category is included in the scope's data type,
+// and the value is set to Any().
+func (s DirectoryScope) IsAny(cat directoryCategory) bool {
+	return IsAnyTarget(s, cat)
+}
+
+// Get returns the data category in the scope.  If the scope
+// contains all data types for a tenant, it'll return the
+// DirectoryTenant category.
+func (s DirectoryScope) Get(cat directoryCategory) []string {
+	return getCatValue(s, cat)
+}
+
+// setDefaults ensures that tenant scopes express `AnyTgt` for
+// their child category types.
+func (s DirectoryScope) setDefaults() {
+	switch s.Category() {
+	case DirectoryTenant:
+		s[DirectoryUser.String()] = passAny
+		s[DirectoryGroup.String()] = passAny
+		s[DirectoryApplication.String()] = passAny
+		s[DirectoryServicePrincipal.String()] = passAny
+		s[DirectoryConditionalAccessPolicy.String()] = passAny
+		s[DirectoryAdminUnit.String()] = passAny
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Backup Details Filtering
+// ---------------------------------------------------------------------------
+
+// Reduce filters the entries in a details struct to only those that match the
+// inclusions, filters, and exclusions in the selector.
+func (s directory) Reduce(
+	ctx context.Context,
+	deets *details.Details,
+	errs *fault.Bus,
+) *details.Details {
+	return reduce[DirectoryScope](
+		ctx,
+		deets,
+		s.Selector,
+		map[path.CategoryType]directoryCategory{
+			path.DirectoryUsersCategory:    DirectoryUser,
+			path.DirectoryGroupsCategory:   DirectoryGroup,
+			path.ApplicationsCategory:      DirectoryApplication,
+			path.ServicePrincipalsCategory: DirectoryServicePrincipal,
+			path.ConditionalAccessCategory: DirectoryConditionalAccessPolicy,
+			path.AdminUnitsCategory:        DirectoryAdminUnit,
+		},
+		errs)
+}
+
+// matchesInfo handles the standard behavior when comparing a scope and a DirectoryInfo
+// returns true if the scope and info match for the provided category.
+func (s DirectoryScope) matchesInfo(dii details.ItemInfo) bool {
+	info := dii.Directory
+	if info == nil {
+		return false
+	}
+
+	infoCat := s.InfoCategory()
+
+	cfpc := directoryCategoryFromItemType(info.ItemType)
+	if !typeAndCategoryMatches(infoCat, cfpc) {
+		return false
+	}
+
+	i := ""
+
+	switch infoCat {
+	case DirectoryInfoUserPrincipalName, DirectoryInfoGroupMailNickname:
+		i = info.Object.Identifier
+	}
+
+	return s.Matches(infoCat, i)
+}
+
+// directoryCategoryFromItemType interprets the category represented by the DirectoryInfo
+// struct.  Since every DirectoryInfo can hold all directory data info, the exact
+// type that the struct represents must be compared using its ItemType prop.
+func directoryCategoryFromItemType(pct details.ItemType) directoryCategory {
+	switch pct {
+	case details.DirectoryUser:
+		return DirectoryUser
+	case details.DirectoryGroup:
+		return DirectoryGroup
+	case details.DirectoryApplication:
+		return DirectoryApplication
+	case details.DirectoryServicePrincipal:
+		return DirectoryServicePrincipal
+	case details.DirectoryConditionalAccessPolicy:
+		return DirectoryConditionalAccessPolicy
+	case details.DirectoryAdminUnit:
+		return DirectoryAdminUnit
+	}
+
+	return DirectoryCategoryUnknown
+}
@@ -0,0 +1,338 @@
+package selectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type DirectorySelectorSuite struct {
+	tester.Suite
+}
+
+func TestDirectorySelectorSuite(t *testing.T) {
+	suite.Run(t, &DirectorySelectorSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *DirectorySelectorSuite) TestToDirectoryBackup() {
+	t := suite.T()
+	eb := NewDirectoryBackup(nil)
+	s := eb.Selector
+	eb, err := s.ToDirectoryBackup()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, eb.Service, ServiceDirectory)
+	assert.NotZero(t, eb.Scopes())
+}
+
+func (suite *DirectorySelectorSuite) TestToDirectoryRestore() {
+	t := suite.T()
+	eb := NewDirectoryRestore(nil)
+	s := eb.Selector
+	eb, err := s.ToDirectoryRestore()
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, eb.Service, ServiceDirectory)
+	assert.NotZero(t, eb.Scopes())
+
+	_, err = NewTeamsChatsRestore(nil).Selector.ToDirectoryRestore()
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *DirectorySelectorSuite) TestDirectoryBackup_AllData() {
+	t := suite.T()
+	sel := NewDirectoryBackup(Any())
+	sel.Include(sel.AllData())
+
+	cats := map[path.CategoryType]struct{}{}
+
+	for _, sc := range sel.Scopes() {
+		cats[sc.Category().PathType()] = struct{}{}
+	}
+
+	assert.Equal(
+		t,
+		map[path.CategoryType]struct{}{
+			path.DirectoryUsersCategory:    {},
+			path.DirectoryGroupsCategory:   {},
+			path.ApplicationsCategory:      {},
+			path.ServicePrincipalsCategory: {},
+			path.ConditionalAccessCategory: {},
+			path.AdminUnitsCategory:        {},
+		},
+		cats)
+}
+
+func (suite *DirectorySelectorSuite) TestDirectoryScope_MatchesInfo() {
+	ds := NewDirectoryRestore(Any())
+
+	const (
+		upn      = "bob@company.hr"
+		nickname = "marketing"
+	)
+
+	infoWith := func(itype details.ItemType, identifier string) details.ItemInfo {
+		return details.ItemInfo{
+			Directory: &details.DirectoryInfo{
+				ItemType: itype,
+				Object: details.DirectoryObjectInfo{
+					DisplayName: "display name",
+					Identifier:  identifier,
+				},
+			},
+		}
+	}
+
+	table := []struct {
+		name       string
+		itype      details.ItemType
+		identifier string
+		scope      []DirectoryScope
+		expect     assert.BoolAssertionFunc
+	}{
+		{"user with the same upn", details.DirectoryUser, upn, ds.UserPrincipalName(upn), assert.True},
+		{"user with a upn submatch", details.DirectoryUser, upn, ds.UserPrincipalName(upn[2:5]), assert.True},
+		{"user with a different upn", details.DirectoryUser, upn, ds.UserPrincipalName("smarf"), assert.False},
+		{"group compared to a upn", details.DirectoryGroup, upn, ds.UserPrincipalName(upn), assert.False},
+		{"group with the same nickname", details.DirectoryGroup, nickname, ds.GroupMailNickname(nickname), assert.True},
+		{"group with a different nickname", details.DirectoryGroup, nickname, ds.GroupMailNickname("smarf"), assert.False},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			scopes := setScopesToDefault(test.scope)
+			for _, scope := range scopes {
+				test.expect(t, scope.matchesInfo(infoWith(test.itype, test.identifier)))
+			}
+		})
+	}
+}
+
+func (suite *DirectorySelectorSuite) TestDirectoryScope_MatchesPath() {
+	const (
+		tenant = "tenantID"
+		group  = "groupID"
+		name   = "Marketing"
+	)
+
+	repoRef, err := path.Build(tenant, tenant, path.DirectoryService, path.DirectoryGroupsCategory, true, group)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	var (
+		short = "thisisahashofsomekind"
+		ds    = NewDirectoryRestore(Any())
+		ent   = details.Entry{
+			RepoRef:  repoRef.String(),
+			ShortRef: short,
+			ItemRef:  group,
+			ItemInfo: details.ItemInfo{
+				Directory: &details.DirectoryInfo{
+					ItemType: details.DirectoryGroup,
+					Object:   details.DirectoryObjectInfo{DisplayName: name},
+				},
+			},
+		}
+	)
+
+	table := []struct {
+		name      string
+		scope     []DirectoryScope
+		onlyNames bool
+		expect    assert.BoolAssertionFunc
+	}{
+		{"all items", ds.AllData(), false, assert.True},
+		{"all groups", ds.Groups(Any()), false, assert.True},
+		{"no groups", ds.Groups(None()), false, assert.False},
+		{"matching group id", ds.Groups([]string{group}), false, assert.True},
+		{"matching group name", ds.Groups([]string{name}), false, assert.True},
+		{"non-matching group", ds.Groups([]string{"smarf"}), false, assert.False},
+		{"group short ref", ds.Groups([]string{short}), false, assert.True},
+		{"group id when only matching names", ds.Groups([]string{group}), true, assert.False},
+		{"group name when only matching names", ds.Groups([]string{name}), true, assert.True},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			scopes := setScopesToDefault(test.scope)
+			var aMatch bool
+			for _, scope := range scopes {
+				pvs, err := DirectoryGroup.pathValues(repoRef, ent, Config{OnlyMatchItemNames: test.onlyNames})
+				require.NoError(t, err)
+
+				if matchesPathValues(scope, DirectoryGroup, pvs) {
+					aMatch = true
+					break
+				}
+			}
+			test.expect(t, aMatch)
+		})
+	}
+}
+
+func (suite *DirectorySelectorSuite) TestDirectoryRestore_Reduce() {
+	user, err := path.Build("tid", "tid", path.DirectoryService, path.DirectoryUsersCategory, true, "uid")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	group, err := path.Build("tid", "tid", path.DirectoryService, path.DirectoryGroupsCategory, true, "gid")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	policy, err := path.Build("tid", "tid", path.DirectoryService, path.ConditionalAccessCategory, true, "pid")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	toRR := func(p path.Path) string {
+		newElems := []string{}
+
+		for _, e := range p.Folders() {
+			newElems = append(newElems, e+".d")
+		}
+
+		joinedFldrs := strings.Join(newElems, "/")
+
+		return stubRepoRef(p.Service(), p.Category(), p.ProtectedResource(), joinedFldrs, p.Item())
+	}
+
+	makeDeets := func(refs ...path.Path) *details.Details {
+		deets := &details.Details{
+			DetailsModel: details.DetailsModel{
+				Entries: []details.Entry{},
+			},
+		}
+
+		for _, r := range refs {
+			itype := details.UnknownType
+
+			switch r {
+			case user:
+				itype = details.DirectoryUser
+			case group:
+				itype = details.DirectoryGroup
+			case policy:
+				itype = details.DirectoryConditionalAccessPolicy
+			}
+
+			deets.Entries = append(deets.Entries, details.Entry{
+				RepoRef: toRR(r),
+				// Don't escape because we assume nice paths.
+				LocationRef: r.Folder(false),
+				ItemInfo: details.ItemInfo{
+					Directory: &details.DirectoryInfo{
+						ItemType: itype,
+					},
+				},
+			})
+		}
+
+		return deets
+	}
+
+	table := []struct {
+		name         string
+		deets        *details.Details
+		makeSelector func() *DirectoryRestore
+		expect       []string
+	}{
+		{
+			"no refs",
+			makeDeets(),
+			func() *DirectoryRestore {
+				er := NewDirectoryRestore(Any())
+				er.Include(er.AllData())
+				return er
+			},
+			[]string{},
+		},
+		{
+			"all data",
+			makeDeets(user, group, policy),
+			func() *DirectoryRestore {
+				er := NewDirectoryRestore(Any())
+				er.Include(er.AllData())
+				return er
+			},
+			[]string{toRR(user), toRR(group), toRR(policy)},
+		},
+		{
+			"only groups",
+			makeDeets(user, group, policy),
+			func() *DirectoryRestore {
+				er := NewDirectoryRestore(Any())
+				er.Include(er.Groups(Any()))
+				return er
+			},
+			[]string{toRR(group)},
+		},
+		{
+			"exclude policies",
+			makeDeets(user, group, policy),
+			func() *DirectoryRestore {
+				er := NewDirectoryRestore(Any())
+				er.Include(er.AllData())
+				er.Exclude(er.ConditionalAccessPolicies(Any()))
+				return er
+			},
+			[]string{toRR(user), toRR(group)},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sel := test.makeSelector()
+			results := sel.Reduce(ctx, test.deets, fault.New(true))
+			paths := results.Paths()
+			assert.Equal(t, test.expect, paths)
+		})
+	}
+}
+
+func (suite *DirectorySelectorSuite) TestDirectoryCategory_leafCat() {
+	table := []struct {
+		cat    directoryCategory
+		expect directoryCategory
+	}{
+		{DirectoryCategoryUnknown, DirectoryCategoryUnknown},
+		{DirectoryTenant, DirectoryTenant},
+		{DirectoryUser, DirectoryUser},
+		{DirectoryInfoUserPrincipalName, DirectoryUser},
+		{DirectoryInfoGroupMailNickname, DirectoryGroup},
+		{DirectoryAdminUnit, DirectoryAdminUnit},
+	}
+	for _, test := range table {
+		suite.Run(test.cat.String(), func() {
+			assert.Equal(suite.T(), test.expect, test.cat.leafCat())
+		})
+	}
+}
+
+func (suite *DirectorySelectorSuite) TestDirectoryCategoryFromItemType() {
+	table := []struct {
+		itype  details.ItemType
+		expect directoryCategory
+	}{
+		{details.UnknownType, DirectoryCategoryUnknown},
+		{details.DirectoryUser, DirectoryUser},
+		{details.DirectoryGroup, DirectoryGroup},
+		{details.DirectoryApplication, DirectoryApplication},
+		{details.DirectoryServicePrincipal, DirectoryServicePrincipal},
+		{details.DirectoryConditionalAccessPolicy, DirectoryConditionalAccessPolicy},
+		{details.DirectoryAdminUnit, DirectoryAdminUnit},
+		{details.TeamsChat, DirectoryCategoryUnknown},
+	}
+	for _, test := range table {
+		suite.Run(test.expect.String(), func() {
+			assert.Equal(suite.T(), test.expect, directoryCategoryFromItemType(test.itype))
+		})
+	}
+}
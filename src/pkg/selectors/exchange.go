@@ -9,6 +9,7 @@ import (
 
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/backup/identity"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/filters"
@@ -483,6 +484,63 @@ func (sr *ExchangeRestore) MailSubject(subject string) []ExchangeScope {
 	}
 }
 
+// MailWellKnownFolder produces one or more exchange well-known mail folder
+// info scopes.  Matches any mail held within the well-known folder, or its
+// subfolders, where the folder is one of control.WellKnownMailFolders.
+// In email backups, including one of the Recoverable Items folders adds
+// it to the backup, and excluding any well-known folder leaves it out.
+func (s *exchange) MailWellKnownFolder(folder string) []ExchangeScope {
+	folders := []string{folder}
+
+	if control.WellKnownMailFolder(folder) == control.RecoverableItems {
+		folders = []string{
+			string(control.RecoverableItemsDeletions),
+			string(control.RecoverableItemsPurges),
+			string(control.RecoverableItemsVersions),
+		}
+	}
+
+	return []ExchangeScope{
+		makeInfoScope[ExchangeScope](
+			ExchangeMail,
+			ExchangeInfoMailWellKnownFolder,
+			folders,
+			filters.Equal),
+	}
+}
+
+// MailFoldersConfig produces the well-known mail folders that the backup's
+// MailWellKnownFolder scopes include and exclude.
+func (s ExchangeBackup) MailFoldersConfig() control.MailFoldersConfig {
+	cfg := control.MailFoldersConfig{}
+
+	for _, sc := range s.Includes {
+		cfg.Include = append(cfg.Include, wellKnownMailFolders(ExchangeScope(sc))...)
+	}
+
+	for _, sc := range s.Excludes {
+		cfg.Exclude = append(cfg.Exclude, wellKnownMailFolders(ExchangeScope(sc))...)
+	}
+
+	return cfg
+}
+
+func wellKnownMailFolders(sc ExchangeScope) []control.WellKnownMailFolder {
+	if sc.InfoCategory() != ExchangeInfoMailWellKnownFolder {
+		return nil
+	}
+
+	var result []control.WellKnownMailFolder
+
+	for _, f := range control.WellKnownMailFolders {
+		if sc.Matches(ExchangeInfoMailWellKnownFolder, string(f)) {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
+
 // ---------------------------------------------------------------------------
 // Categories
 // ---------------------------------------------------------------------------
@@ -507,16 +565,17 @@ const (
 	ExchangeUser          exchangeCategory = "ExchangeUser"
 
 	// data contained within details.ItemInfo
-	ExchangeInfoMailSender         exchangeCategory = "ExchangeInfoMailSender"
-	ExchangeInfoMailSubject        exchangeCategory = "ExchangeInfoMailSubject"
-	ExchangeInfoMailReceivedAfter  exchangeCategory = "ExchangeInfoMailReceivedAfter"
-	ExchangeInfoMailReceivedBefore exchangeCategory = "ExchangeInfoMailReceivedBefore"
-	ExchangeInfoContactName        exchangeCategory = "ExchangeInfoContactName"
-	ExchangeInfoEventOrganizer     exchangeCategory = "ExchangeInfoEventOrganizer"
-	ExchangeInfoEventRecurs        exchangeCategory = "ExchangeInfoEventRecurs"
-	ExchangeInfoEventStartsAfter   exchangeCategory = "ExchangeInfoEventStartsAfter"
-	ExchangeInfoEventStartsBefore  exchangeCategory = "ExchangeInfoEventStartsBefore"
-	ExchangeInfoEventSubject       exchangeCategory = "ExchangeInfoEventSubject"
+	ExchangeInfoMailSender          exchangeCategory = "ExchangeInfoMailSender"
+	ExchangeInfoMailSubject         exchangeCategory = "ExchangeInfoMailSubject"
+	ExchangeInfoMailReceivedAfter   exchangeCategory = "ExchangeInfoMailReceivedAfter"
+	ExchangeInfoMailReceivedBefore  exchangeCategory = "ExchangeInfoMailReceivedBefore"
+	ExchangeInfoMailWellKnownFolder exchangeCategory = "ExchangeInfoMailWellKnownFolder"
+	ExchangeInfoContactName         exchangeCategory = "ExchangeInfoContactName"
+	ExchangeInfoEventOrganizer      exchangeCategory = "ExchangeInfoEventOrganizer"
+	ExchangeInfoEventRecurs         exchangeCategory = "ExchangeInfoEventRecurs"
+	ExchangeInfoEventStartsAfter    exchangeCategory = "ExchangeInfoEventStartsAfter"
+	ExchangeInfoEventStartsBefore   exchangeCategory = "ExchangeInfoEventStartsBefore"
+	ExchangeInfoEventSubject        exchangeCategory = "ExchangeInfoEventSubject"
 )
 
 // exchangeLeafProperties describes common metadata of the leaf categories
@@ -561,7 +620,8 @@ func (ec exchangeCategory) leafCat() categorizer {
 		return ExchangeEvent
 
 	case ExchangeMail, ExchangeMailFolder, ExchangeInfoMailReceivedAfter,
-		ExchangeInfoMailReceivedBefore, ExchangeInfoMailSender, ExchangeInfoMailSubject:
+		ExchangeInfoMailReceivedBefore, ExchangeInfoMailSender, ExchangeInfoMailSubject,
+		ExchangeInfoMailWellKnownFolder:
 		return ExchangeMail
 	}
 
@@ -796,6 +856,8 @@ func (s ExchangeScope) matchesInfo(dii details.ItemInfo) bool {
 		i = info.Subject
 	case ExchangeInfoMailReceivedAfter, ExchangeInfoMailReceivedBefore:
 		i = dttm.Format(info.Received)
+	case ExchangeInfoMailWellKnownFolder:
+		i = info.WellKnownFolder
 	}
 
 	return s.Matches(infoCat, i)
@@ -12,6 +12,7 @@ import (
 
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/filters"
@@ -466,6 +467,29 @@ func (suite *ExchangeSelectorSuite) TestExchangeBackup_Scopes() {
 	}
 }
 
+func (suite *ExchangeSelectorSuite) TestExchangeBackup_MailFoldersConfig() {
+	t := suite.T()
+
+	sel := NewExchangeBackup(Any())
+	sel.Include(sel.MailFolders(Any()))
+	sel.Include(sel.MailWellKnownFolder(string(control.RecoverableItems)))
+	sel.Exclude(sel.MailWellKnownFolder(string(control.Drafts)))
+
+	cfg := sel.MailFoldersConfig()
+
+	assert.ElementsMatch(
+		t,
+		[]control.WellKnownMailFolder{
+			control.RecoverableItemsDeletions,
+			control.RecoverableItemsPurges,
+			control.RecoverableItemsVersions,
+		},
+		cfg.Include)
+	assert.Equal(t, []control.WellKnownMailFolder{control.Drafts}, cfg.Exclude)
+
+	assert.Empty(t, NewExchangeBackup(Any()).MailFoldersConfig().Include)
+}
+
 func (suite *ExchangeSelectorSuite) TestExchangeScope_Category() {
 	table := []struct {
 		is     exchangeCategory
@@ -597,14 +621,15 @@ func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesInfo() {
 	infoWith := func(itype details.ItemType) details.ItemInfo {
 		return details.ItemInfo{
 			Exchange: &details.ExchangeInfo{
-				ItemType:    itype,
-				ContactName: name,
-				EventRecurs: true,
-				EventStart:  now,
-				Organizer:   organizer,
-				Sender:      sender,
-				Subject:     subject,
-				Received:    now,
+				ItemType:        itype,
+				ContactName:     name,
+				EventRecurs:     true,
+				EventStart:      now,
+				Organizer:       organizer,
+				Sender:          sender,
+				Subject:         subject,
+				Received:        now,
+				WellKnownFolder: string(control.RecoverableItemsPurges),
 			},
 		}
 	}
@@ -624,6 +649,24 @@ func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesInfo() {
 		{"mail with a different subject", details.ExchangeMail, es.MailSubject("fancy"), assert.False},
 		{"mail with the matching subject", details.ExchangeMail, es.MailSubject(subject), assert.True},
 		{"mail with a substring subject match", details.ExchangeMail, es.MailSubject(subject[5:9]), assert.True},
+		{
+			"mail in the matching well-known folder",
+			details.ExchangeMail,
+			es.MailWellKnownFolder(string(control.RecoverableItemsPurges)),
+			assert.True,
+		},
+		{
+			"mail in any recoverable items folder",
+			details.ExchangeMail,
+			es.MailWellKnownFolder(string(control.RecoverableItems)),
+			assert.True,
+		},
+		{
+			"mail in a different well-known folder",
+			details.ExchangeMail,
+			es.MailWellKnownFolder(string(control.JunkEmail)),
+			assert.False,
+		},
 		{"mail received after the epoch", details.ExchangeMail, es.MailReceivedAfter(dttm.Format(epoch)), assert.True},
 		{"mail received after now", details.ExchangeMail, es.MailReceivedAfter(dttm.Format(now)), assert.False},
 		{
@@ -0,0 +1,161 @@
+package selectors
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"golang.org/x/exp/slices"
+)
+
+// reasons reported by FileFilter.Excludes.
+const (
+	FileFilterExtension = "extension"
+	FileFilterMIMEType  = "mime_type"
+	FileFilterSize      = "size"
+	FileFilterModified  = "modified_time"
+)
+
+// FileFilter restricts which OneDrive, SharePoint, and Groups library files
+// get backed up.  Unlike scopes, which are matched against folders and the
+// entries in backup details, file filters are applied while enumerating
+// the drive: files that don't pass them are skipped and never downloaded.
+// Folders are never filtered.
+type FileFilter struct {
+	// Extensions, if populated, only includes files with one of the
+	// extensions.  Comparisons ignore case and the leading dot.
+	Extensions []string `json:"extensions,omitempty"`
+	// ExcludeExtensions skips files with any of the extensions.
+	ExcludeExtensions []string `json:"excludeExtensions,omitempty"`
+	// MIMETypes, if populated, only includes files with one of the MIME types.
+	// A type ending in "/*", such as "video/*", matches all of its subtypes.
+	MIMETypes []string `json:"mimeTypes,omitempty"`
+	// ExcludeMIMETypes skips files with any of the MIME types.
+	ExcludeMIMETypes []string `json:"excludeMimeTypes,omitempty"`
+	// MaxSize skips files larger than this many bytes.  Zero means no limit.
+	MaxSize int64 `json:"maxSize,omitempty"`
+	// ModifiedAfter and ModifiedBefore skip files last modified outside of
+	// the window.  Zero values leave that side of the window open.
+	ModifiedAfter  time.Time `json:"modifiedAfter,omitempty"`
+	ModifiedBefore time.Time `json:"modifiedBefore,omitempty"`
+}
+
+// IsZero is true if the filter doesn't exclude any files.
+func (ff *FileFilter) IsZero() bool {
+	return ff == nil ||
+		(len(ff.Extensions) == 0 &&
+			len(ff.ExcludeExtensions) == 0 &&
+			len(ff.MIMETypes) == 0 &&
+			len(ff.ExcludeMIMETypes) == 0 &&
+			ff.MaxSize == 0 &&
+			ff.ModifiedAfter.IsZero() &&
+			ff.ModifiedBefore.IsZero())
+}
+
+// Equal is true if both filters exclude the same files.  Backups record the
+// filter in their selector, so that a later backup can tell whether the
+// files excluded from its bases match the files it would exclude.
+func (ff *FileFilter) Equal(other *FileFilter) bool {
+	if ff.IsZero() || other.IsZero() {
+		return ff.IsZero() && other.IsZero()
+	}
+
+	return slices.Equal(ff.Extensions, other.Extensions) &&
+		slices.Equal(ff.ExcludeExtensions, other.ExcludeExtensions) &&
+		slices.Equal(ff.MIMETypes, other.MIMETypes) &&
+		slices.Equal(ff.ExcludeMIMETypes, other.ExcludeMIMETypes) &&
+		ff.MaxSize == other.MaxSize &&
+		ff.ModifiedAfter.Equal(other.ModifiedAfter) &&
+		ff.ModifiedBefore.Equal(other.ModifiedBefore)
+}
+
+// Validate checks the filter for settings that would exclude every file.
+func (ff *FileFilter) Validate() error {
+	if ff == nil {
+		return nil
+	}
+
+	if ff.MaxSize < 0 {
+		return clues.New("max file size must not be negative")
+	}
+
+	if !ff.ModifiedAfter.IsZero() &&
+		!ff.ModifiedBefore.IsZero() &&
+		!ff.ModifiedAfter.Before(ff.ModifiedBefore) {
+		return clues.New("modified-after time must be before the modified-before time")
+	}
+
+	return nil
+}
+
+// Excludes reports whether the filter skips the file and, if it does,
+// which of the filter's rules excluded it.  A nil filter excludes nothing.
+func (ff *FileFilter) Excludes(
+	name, mimeType string,
+	size int64,
+	modified time.Time,
+) (string, bool) {
+	if ff.IsZero() {
+		return "", false
+	}
+
+	ext := normalizeExtension(filepath.Ext(name))
+
+	if (len(ff.Extensions) > 0 && !matchesExtension(ff.Extensions, ext)) ||
+		matchesExtension(ff.ExcludeExtensions, ext) {
+		return FileFilterExtension, true
+	}
+
+	if (len(ff.MIMETypes) > 0 && !matchesMIMEType(ff.MIMETypes, mimeType)) ||
+		matchesMIMEType(ff.ExcludeMIMETypes, mimeType) {
+		return FileFilterMIMEType, true
+	}
+
+	if ff.MaxSize > 0 && size > ff.MaxSize {
+		return FileFilterSize, true
+	}
+
+	if (!ff.ModifiedAfter.IsZero() && !modified.After(ff.ModifiedAfter)) ||
+		(!ff.ModifiedBefore.IsZero() && !modified.Before(ff.ModifiedBefore)) {
+		return FileFilterModified, true
+	}
+
+	return "", false
+}
+
+func normalizeExtension(ext string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(ext), "."))
+}
+
+func matchesExtension(exts []string, ext string) bool {
+	for _, e := range exts {
+		if normalizeExtension(e) == ext {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesMIMEType(types []string, mimeType string) bool {
+	mimeType = strings.ToLower(mimeType)
+
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+
+		if prefix, ok := strings.CutSuffix(t, "/*"); ok {
+			if strings.HasPrefix(mimeType, prefix+"/") {
+				return true
+			}
+
+			continue
+		}
+
+		if t == mimeType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,264 @@
+package selectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type FileFilterSuite struct {
+	tester.Suite
+}
+
+func TestFileFilterSuite(t *testing.T) {
+	suite.Run(t, &FileFilterSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *FileFilterSuite) TestExcludes() {
+	var (
+		now      = time.Now()
+		earlier  = now.Add(-time.Hour)
+		later    = now.Add(time.Hour)
+		pdfMIME  = "application/pdf"
+		pngMIME  = "image/png"
+		fileSize = int64(1024)
+	)
+
+	table := []struct {
+		name         string
+		filter       *FileFilter
+		fileName     string
+		mimeType     string
+		expectReason string
+		expectExcl   bool
+	}{
+		{
+			name:     "nil filter",
+			fileName: "a.pdf",
+			mimeType: pdfMIME,
+		},
+		{
+			name:     "zero filter",
+			filter:   &FileFilter{},
+			fileName: "a.pdf",
+			mimeType: pdfMIME,
+		},
+		{
+			name:     "included extension ignores case and dot",
+			filter:   &FileFilter{Extensions: []string{".PDF"}},
+			fileName: "a.pdf",
+			mimeType: pdfMIME,
+		},
+		{
+			name:         "extension not included",
+			filter:       &FileFilter{Extensions: []string{"docx"}},
+			fileName:     "a.pdf",
+			mimeType:     pdfMIME,
+			expectReason: FileFilterExtension,
+			expectExcl:   true,
+		},
+		{
+			name:         "extensionless file not included",
+			filter:       &FileFilter{Extensions: []string{"pdf"}},
+			fileName:     "README",
+			mimeType:     pdfMIME,
+			expectReason: FileFilterExtension,
+			expectExcl:   true,
+		},
+		{
+			name:         "excluded extension",
+			filter:       &FileFilter{ExcludeExtensions: []string{"pdf"}},
+			fileName:     "a.pdf",
+			mimeType:     pdfMIME,
+			expectReason: FileFilterExtension,
+			expectExcl:   true,
+		},
+		{
+			name:     "included MIME wildcard",
+			filter:   &FileFilter{MIMETypes: []string{"image/*"}},
+			fileName: "a.png",
+			mimeType: pngMIME,
+		},
+		{
+			name:         "MIME not included",
+			filter:       &FileFilter{MIMETypes: []string{"image/*"}},
+			fileName:     "a.pdf",
+			mimeType:     pdfMIME,
+			expectReason: FileFilterMIMEType,
+			expectExcl:   true,
+		},
+		{
+			name:         "excluded MIME",
+			filter:       &FileFilter{ExcludeMIMETypes: []string{"Application/PDF"}},
+			fileName:     "a.pdf",
+			mimeType:     pdfMIME,
+			expectReason: FileFilterMIMEType,
+			expectExcl:   true,
+		},
+		{
+			name:     "at max size",
+			filter:   &FileFilter{MaxSize: fileSize},
+			fileName: "a.pdf",
+			mimeType: pdfMIME,
+		},
+		{
+			name:         "over max size",
+			filter:       &FileFilter{MaxSize: fileSize - 1},
+			fileName:     "a.pdf",
+			mimeType:     pdfMIME,
+			expectReason: FileFilterSize,
+			expectExcl:   true,
+		},
+		{
+			name:     "inside modified window",
+			filter:   &FileFilter{ModifiedAfter: earlier, ModifiedBefore: later},
+			fileName: "a.pdf",
+			mimeType: pdfMIME,
+		},
+		{
+			name:         "modified before window",
+			filter:       &FileFilter{ModifiedAfter: later},
+			fileName:     "a.pdf",
+			mimeType:     pdfMIME,
+			expectReason: FileFilterModified,
+			expectExcl:   true,
+		},
+		{
+			name:         "modified after window",
+			filter:       &FileFilter{ModifiedBefore: earlier},
+			fileName:     "a.pdf",
+			mimeType:     pdfMIME,
+			expectReason: FileFilterModified,
+			expectExcl:   true,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			reason, excl := test.filter.Excludes(test.fileName, test.mimeType, fileSize, now)
+			assert.Equal(t, test.expectExcl, excl)
+			assert.Equal(t, test.expectReason, reason)
+		})
+	}
+}
+
+func (suite *FileFilterSuite) TestEqual() {
+	now := time.Now()
+
+	table := []struct {
+		name   string
+		a, b   *FileFilter
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "both nil",
+			expect: assert.True,
+		},
+		{
+			name:   "nil and zero",
+			b:      &FileFilter{},
+			expect: assert.True,
+		},
+		{
+			name:   "nil and populated",
+			b:      &FileFilter{MaxSize: 1},
+			expect: assert.False,
+		},
+		{
+			name:   "same",
+			a:      &FileFilter{Extensions: []string{"pdf"}, ModifiedAfter: now},
+			b:      &FileFilter{Extensions: []string{"pdf"}, ModifiedAfter: now.UTC()},
+			expect: assert.True,
+		},
+		{
+			name:   "different extensions",
+			a:      &FileFilter{Extensions: []string{"pdf"}},
+			b:      &FileFilter{Extensions: []string{"docx"}},
+			expect: assert.False,
+		},
+		{
+			name:   "different mime types",
+			a:      &FileFilter{ExcludeMIMETypes: []string{"video/*"}},
+			b:      &FileFilter{ExcludeMIMETypes: []string{"audio/*"}},
+			expect: assert.False,
+		},
+		{
+			name:   "different size",
+			a:      &FileFilter{MaxSize: 1},
+			b:      &FileFilter{MaxSize: 2},
+			expect: assert.False,
+		},
+		{
+			name:   "different window",
+			a:      &FileFilter{ModifiedBefore: now},
+			b:      &FileFilter{ModifiedBefore: now.Add(time.Hour)},
+			expect: assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			test.expect(t, test.a.Equal(test.b))
+			test.expect(t, test.b.Equal(test.a))
+		})
+	}
+}
+
+func (suite *FileFilterSuite) TestValidate() {
+	now := time.Now()
+
+	table := []struct {
+		name      string
+		filter    *FileFilter
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "nil",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "valid",
+			filter:    &FileFilter{MaxSize: 1, ModifiedAfter: now, ModifiedBefore: now.Add(time.Hour)},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "negative size",
+			filter:    &FileFilter{MaxSize: -1},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "empty window",
+			filter:    &FileFilter{ModifiedAfter: now, ModifiedBefore: now},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := test.filter.Validate()
+			test.expectErr(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *FileFilterSuite) TestFilterFiles() {
+	t := suite.T()
+
+	sel := NewOneDriveBackup(Any())
+	sel.FilterFiles(FileFilter{})
+	assert.Nil(t, sel.FileFilter, "zero filter is not stored")
+
+	sel.FilterFiles(FileFilter{Extensions: []string{"pdf"}})
+	assert.Equal(t, []string{"pdf"}, sel.FileFilter.Extensions)
+
+	ss := sel.SplitByResourceOwner([]string{"a", "b"})
+	for _, s := range ss {
+		assert.Equal(t, sel.FileFilter, s.FileFilter, "split selectors keep the filter")
+	}
+}
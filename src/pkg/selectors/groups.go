@@ -3,6 +3,7 @@ package selectors
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/alcionai/clues"
 
@@ -80,6 +81,16 @@ func (s GroupsBackup) SplitByResourceOwner(resources []string) []GroupsBackup {
 	return ss
 }
 
+// FilterFiles restricts the group library files included in the backup to those
+// that pass the file filter.  A filter that excludes nothing is ignored.
+func (s *GroupsBackup) FilterFiles(ff FileFilter) {
+	if ff.IsZero() {
+		return
+	}
+
+	s.FileFilter = &ff
+}
+
 // NewGroupsRestore produces a new Selector with the service set to ServiceGroups.
 func NewGroupsRestore(resources []string) *GroupsRestore {
 	src := GroupsRestore{
@@ -223,6 +234,22 @@ func (s *groups) AllData() []GroupsScope {
 	return scopes
 }
 
+// OptInData produces the scopes for data that backups only include when
+// it's selected explicitly, since it requires additional permissions.
+// Details, restore, and export selectors that want every backed up item
+// should include these alongside AllData.
+func (s *groups) OptInData() []GroupsScope {
+	scopes := []GroupsScope{}
+
+	scopes = append(
+		scopes,
+		makeScope[GroupsScope](GroupsPlan, Any()),
+		makeScope[GroupsScope](GroupsTeam, Any()),
+		makeScope[GroupsScope](GroupsEventCalendar, Any()))
+
+	return scopes
+}
+
 // Channels produces one or more SharePoint channel scopes, where the channel
 // matches upon a given channel by ID or Name.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
@@ -295,6 +322,78 @@ func (s *groups) ConversationPosts(conversations, posts []string, opts ...option
 	return scopes
 }
 
+// Plans produces one or more Groups planner plan scopes, where the plan
+// matches with a given plan by ID or Title.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *groups) Plans(plans []string, opts ...option) []GroupsScope {
+	var (
+		scopes = []GroupsScope{}
+		os     = append([]option{pathComparator()}, opts...)
+	)
+
+	scopes = append(
+		scopes,
+		makeScope[GroupsScope](GroupsPlan, plans, os...))
+
+	return scopes
+}
+
+// TeamStructure produces one or more Groups team structure scopes, where
+// the team matches with a given team by ID or display name.  The team
+// structure holds the team's settings, channels, tabs, and installed apps.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *groups) TeamStructure(teams []string, opts ...option) []GroupsScope {
+	var (
+		scopes = []GroupsScope{}
+		os     = append([]option{pathComparator()}, opts...)
+	)
+
+	scopes = append(
+		scopes,
+		makeScope[GroupsScope](GroupsTeam, teams, os...))
+
+	return scopes
+}
+
+// EventCalendars produces one or more Groups event calendar scopes, where
+// the calendar matches with a given calendar by ID or name.  Groups have a
+// single, shared calendar; calendars act as folders to contain events.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *groups) EventCalendars(calendars []string, opts ...option) []GroupsScope {
+	var (
+		scopes = []GroupsScope{}
+		os     = append([]option{pathComparator()}, opts...)
+	)
+
+	scopes = append(
+		scopes,
+		makeScope[GroupsScope](GroupsEventCalendar, calendars, os...))
+
+	return scopes
+}
+
+// Events produces one or more Groups calendar event scopes.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+// options are only applied to the calendar scopes.
+func (s *groups) Events(calendars, events []string, opts ...option) []GroupsScope {
+	scopes := []GroupsScope{}
+
+	scopes = append(
+		scopes,
+		makeScope[GroupsScope](GroupsEvent, events, defaultItemOptions(s.Cfg)...).
+			set(GroupsEventCalendar, calendars, opts...))
+
+	return scopes
+}
+
 // Sites produces one or more Groups site scopes, where the site
 // matches upon a given site by ID or URL.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
@@ -536,6 +635,79 @@ func (s *GroupsRestore) MessageLastReplyBefore(timeStrings string) []GroupsScope
 	}
 }
 
+// EventOrganizer produces one or more groups event organizer info scopes.
+// Matches any event where the organizer contains one of the provided strings.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *GroupsRestore) EventOrganizer(organizer string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsEvent,
+			GroupsInfoEventOrganizer,
+			[]string{organizer},
+			filters.In),
+	}
+}
+
+// EventRecurs produces one or more groups event recurrence info scopes.
+// Matches any event if the comparator flag matches the event recurrence flag.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *GroupsRestore) EventRecurs(recurs string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsEvent,
+			GroupsInfoEventRecurs,
+			[]string{recurs},
+			filters.Equal),
+	}
+}
+
+// EventStartsAfter produces a groups event starts-after info scope.
+// Matches any event where the start time is after the timestring.
+// If the input equals selectors.Any, the scope will match all times.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *GroupsRestore) EventStartsAfter(timeStrings string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsEvent,
+			GroupsInfoEventStartsAfter,
+			[]string{timeStrings},
+			filters.Less),
+	}
+}
+
+// EventStartsBefore produces a groups event starts-before info scope.
+// Matches any event where the start time is before the timestring.
+// If the input equals selectors.Any, the scope will match all times.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *GroupsRestore) EventStartsBefore(timeStrings string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsEvent,
+			GroupsInfoEventStartsBefore,
+			[]string{timeStrings},
+			filters.Greater),
+	}
+}
+
+// EventSubject produces one or more groups event subject info scopes.
+// Matches any event where the event subject contains one of the provided strings.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *GroupsRestore) EventSubject(subject string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsEvent,
+			GroupsInfoEventSubject,
+			[]string{subject},
+			filters.In),
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Categories
 // ---------------------------------------------------------------------------
@@ -556,6 +728,12 @@ const (
 	GroupsChannelMessage   groupsCategory = "GroupsChannelMessage"
 	GroupsConversation     groupsCategory = "GroupsConversation"
 	GroupsConversationPost groupsCategory = "GroupsConversationPost"
+	GroupsPlan             groupsCategory = "GroupsPlan"
+	GroupsPlanItem         groupsCategory = "GroupsPlanItem"
+	GroupsTeam             groupsCategory = "GroupsTeam"
+	GroupsTeamStructure    groupsCategory = "GroupsTeamStructure"
+	GroupsEventCalendar    groupsCategory = "GroupsEventCalendar"
+	GroupsEvent            groupsCategory = "GroupsEvent"
 	GroupsLibraryFolder    groupsCategory = "GroupsLibraryFolder"
 	GroupsLibraryItem      groupsCategory = "GroupsLibraryItem"
 	GroupsList             groupsCategory = "GroupsList"
@@ -579,6 +757,11 @@ const (
 	GroupsInfoChannelMessageCreator         groupsCategory = "GroupsInfoChannelMessageCreator"
 	GroupsInfoChannelMessageLastReplyAfter  groupsCategory = "GroupsInfoChannelMessageLastReplyAfter"
 	GroupsInfoChannelMessageLastReplyBefore groupsCategory = "GroupsInfoChannelMessageLastReplyBefore"
+	GroupsInfoEventOrganizer                groupsCategory = "GroupsInfoEventOrganizer"
+	GroupsInfoEventRecurs                   groupsCategory = "GroupsInfoEventRecurs"
+	GroupsInfoEventStartsAfter              groupsCategory = "GroupsInfoEventStartsAfter"
+	GroupsInfoEventStartsBefore             groupsCategory = "GroupsInfoEventStartsBefore"
+	GroupsInfoEventSubject                  groupsCategory = "GroupsInfoEventSubject"
 )
 
 // groupsLeafProperties describes common metadata of the leaf categories
@@ -591,6 +774,18 @@ var groupsLeafProperties = map[categorizer]leafProperty{
 		pathKeys: []categorizer{GroupsConversation, GroupsConversationPost},
 		pathType: path.ConversationPostsCategory,
 	},
+	GroupsPlanItem: {
+		pathKeys: []categorizer{GroupsPlan, GroupsPlanItem},
+		pathType: path.PlannerCategory,
+	},
+	GroupsTeamStructure: {
+		pathKeys: []categorizer{GroupsTeam, GroupsTeamStructure},
+		pathType: path.TeamStructureCategory,
+	},
+	GroupsEvent: {
+		pathKeys: []categorizer{GroupsEventCalendar, GroupsEvent},
+		pathType: path.EventsCategory,
+	},
 	GroupsLibraryItem: {
 		pathKeys: []categorizer{GroupsLibraryFolder, GroupsLibraryItem},
 		pathType: path.LibrariesCategory,
@@ -618,6 +813,14 @@ func (c groupsCategory) leafCat() categorizer {
 		return GroupsChannelMessage
 	case GroupsConversation, GroupsConversationPost:
 		return GroupsConversationPost
+	case GroupsPlan, GroupsPlanItem:
+		return GroupsPlanItem
+	case GroupsTeam, GroupsTeamStructure:
+		return GroupsTeamStructure
+	case GroupsEventCalendar, GroupsEvent,
+		GroupsInfoEventOrganizer, GroupsInfoEventRecurs, GroupsInfoEventStartsAfter,
+		GroupsInfoEventStartsBefore, GroupsInfoEventSubject:
+		return GroupsEvent
 	case GroupsLibraryFolder, GroupsLibraryItem, GroupsInfoSite, GroupsInfoSiteLibraryDrive,
 		GroupsInfoLibraryItemCreatedAfter, GroupsInfoLibraryItemCreatedBefore,
 		GroupsInfoLibraryItemModifiedAfter, GroupsInfoLibraryItemModifiedBefore:
@@ -675,6 +878,15 @@ func (c groupsCategory) pathValues(
 	case GroupsConversation, GroupsConversationPost:
 		folderCat, itemCat = GroupsConversation, GroupsConversationPost
 		rFld = ent.Groups.ParentPath
+	case GroupsPlan, GroupsPlanItem:
+		folderCat, itemCat = GroupsPlan, GroupsPlanItem
+		rFld = ent.Groups.ParentPath
+	case GroupsTeam, GroupsTeamStructure:
+		folderCat, itemCat = GroupsTeam, GroupsTeamStructure
+		rFld = ent.Groups.ParentPath
+	case GroupsEventCalendar, GroupsEvent:
+		folderCat, itemCat = GroupsEventCalendar, GroupsEvent
+		rFld = ent.Groups.ParentPath
 	case GroupsLibraryFolder, GroupsLibraryItem:
 		folderCat, itemCat = GroupsLibraryFolder, GroupsLibraryItem
 		rFld = ent.Groups.ParentPath
@@ -773,7 +985,7 @@ func (s GroupsScope) set(cat groupsCategory, v []string, opts ...option) GroupsS
 	os := []option{}
 
 	switch cat {
-	case GroupsChannel, GroupsConversation, GroupsLibraryFolder:
+	case GroupsChannel, GroupsConversation, GroupsPlan, GroupsTeam, GroupsEventCalendar, GroupsLibraryFolder:
 		os = append(os, pathComparator())
 	}
 
@@ -788,6 +1000,12 @@ func (s GroupsScope) setDefaults() {
 		s[GroupsChannelMessage.String()] = passAny
 		s[GroupsConversation.String()] = passAny
 		s[GroupsConversationPost.String()] = passAny
+		s[GroupsPlan.String()] = passAny
+		s[GroupsPlanItem.String()] = passAny
+		s[GroupsTeam.String()] = passAny
+		s[GroupsTeamStructure.String()] = passAny
+		s[GroupsEventCalendar.String()] = passAny
+		s[GroupsEvent.String()] = passAny
 		s[GroupsLibraryFolder.String()] = passAny
 		s[GroupsLibraryItem.String()] = passAny
 	case GroupsChannel:
@@ -796,6 +1014,12 @@ func (s GroupsScope) setDefaults() {
 		s[GroupsLibraryItem.String()] = passAny
 	case GroupsConversation:
 		s[GroupsConversationPost.String()] = passAny
+	case GroupsPlan:
+		s[GroupsPlanItem.String()] = passAny
+	case GroupsTeam:
+		s[GroupsTeamStructure.String()] = passAny
+	case GroupsEventCalendar:
+		s[GroupsEvent.String()] = passAny
 	}
 }
 
@@ -817,7 +1041,10 @@ func (s groups) Reduce(
 		map[path.CategoryType]groupsCategory{
 			path.ChannelMessagesCategory:   GroupsChannelMessage,
 			path.ConversationPostsCategory: GroupsConversationPost,
+			path.EventsCategory:            GroupsEvent,
 			path.LibrariesCategory:         GroupsLibraryItem,
+			path.PlannerCategory:           GroupsPlanItem,
+			path.TeamStructureCategory:     GroupsTeamStructure,
 		},
 		errs)
 }
@@ -844,6 +1071,12 @@ func (s GroupsScope) matchesInfo(dii details.ItemInfo) bool {
 		acceptableItemType = int(details.GroupsChannelMessage)
 	case GroupsConversationPost:
 		acceptableItemType = int(details.GroupsConversationPost)
+	case GroupsPlanItem:
+		acceptableItemType = int(details.GroupsPlannerPlan)
+	case GroupsTeamStructure:
+		acceptableItemType = int(details.GroupsTeamStructure)
+	case GroupsEvent:
+		acceptableItemType = int(details.GroupsCalendarEvent)
 	}
 
 	switch infoCat {
@@ -885,6 +1118,14 @@ func (s GroupsScope) matchesInfo(dii details.ItemInfo) bool {
 		}
 
 		i = dttm.Format(info.LastReply.CreatedAt)
+	case GroupsInfoEventOrganizer:
+		i = info.Event.Organizer
+	case GroupsInfoEventRecurs:
+		i = strconv.FormatBool(info.Event.EventRecurs)
+	case GroupsInfoEventStartsAfter, GroupsInfoEventStartsBefore:
+		i = dttm.Format(info.Event.EventStart)
+	case GroupsInfoEventSubject:
+		i = info.Event.Subject
 	}
 
 	return s.Matches(infoCat, i) && int(info.ItemType) == acceptableItemType
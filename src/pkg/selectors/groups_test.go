@@ -61,6 +61,25 @@ func (suite *GroupsSelectorSuite) TestToGroupsRestore() {
 	assert.NotZero(t, or.Scopes())
 }
 
+func (suite *GroupsSelectorSuite) TestGroupsBackup_AllData() {
+	t := suite.T()
+
+	sel := NewGroupsBackup(Any())
+	cats := map[groupsCategory]struct{}{}
+
+	for _, sc := range sel.AllData() {
+		cats[sc.Category()] = struct{}{}
+	}
+
+	assert.NotContains(t, cats, GroupsPlan, "plans are opt-in")
+	assert.NotContains(t, cats, GroupsTeam, "team structure is opt-in")
+	assert.NotContains(t, cats, GroupsEventCalendar, "events are opt-in")
+
+	for _, sc := range sel.OptInData() {
+		assert.NotContains(t, cats, sc.Category(), "opt-in data is not in AllData")
+	}
+}
+
 func (suite *GroupsSelectorSuite) TestGroupsRestore_Reduce() {
 	toRR := func(cat path.CategoryType, midID string, folders []string, item string) string {
 		var (
@@ -389,6 +408,39 @@ func (suite *GroupsSelectorSuite) TestGroupsCategory_PathValues() {
 			},
 			cfg: Config{},
 		},
+		{
+			name:      "Groups Plans",
+			sc:        GroupsPlanItem,
+			pathElems: elems,
+			locRef:    "",
+			expected: map[categorizer][]string{
+				GroupsPlan:     {""},
+				GroupsPlanItem: {itemID, shortRef},
+			},
+			cfg: Config{},
+		},
+		{
+			name:      "Groups Team Structure",
+			sc:        GroupsTeamStructure,
+			pathElems: elems,
+			locRef:    "",
+			expected: map[categorizer][]string{
+				GroupsTeam:          {""},
+				GroupsTeamStructure: {itemID, shortRef},
+			},
+			cfg: Config{},
+		},
+		{
+			name:      "Groups Events",
+			sc:        GroupsEvent,
+			pathElems: elems,
+			locRef:    "",
+			expected: map[categorizer][]string{
+				GroupsEventCalendar: {""},
+				GroupsEvent:         {itemID, shortRef},
+			},
+			cfg: Config{},
+		},
 	}
 
 	for _, test := range table {
@@ -437,6 +489,7 @@ func (suite *GroupsSelectorSuite) TestGroupsScope_MatchesInfo() {
 		future = now.Add(45 * time.Minute)
 		dgcm   = details.GroupsChannelMessage
 		dspl   = details.SharePointLibrary
+		dgce   = details.GroupsCalendarEvent
 	)
 
 	type expectation func(t assert.TestingT, value bool, msg string, args ...any) bool
@@ -500,6 +553,18 @@ func (suite *GroupsSelectorSuite) TestGroupsScope_MatchesInfo() {
 		{"chan msg last reply before future", dgcm, user, sel.MessageLastReplyBefore(dttm.Format(future)), assert.Truef},
 		{"chan msg last reply before now", dgcm, user, sel.MessageLastReplyBefore(dttm.Format(now)), assert.Falsef},
 		{"chan msg last reply before epoch", dgcm, user, sel.MessageLastReplyBefore(dttm.Format(now)), assert.Falsef},
+
+		{"event organized by", dgce, user, sel.EventOrganizer(user), assert.Truef},
+		{"event not organized by", dgce, user, sel.EventOrganizer(host), assert.Falsef},
+		{"event organized by wrong type", dgcm, user, sel.EventOrganizer(user), assert.Falsef},
+		{"event subject", dgce, user, sel.EventSubject("standup"), assert.Truef},
+		{"event wrong subject", dgce, user, sel.EventSubject("retro"), assert.Falsef},
+		{"event recurs", dgce, user, sel.EventRecurs("true"), assert.Truef},
+		{"event does not recur", dgce, user, sel.EventRecurs("false"), assert.Falsef},
+		{"event starts after the epoch", dgce, user, sel.EventStartsAfter(dttm.Format(epoch)), assert.Truef},
+		{"event starts after later", dgce, user, sel.EventStartsAfter(dttm.Format(future)), assert.Falsef},
+		{"event starts before future", dgce, user, sel.EventStartsBefore(dttm.Format(future)), assert.Truef},
+		{"event starts before epoch", dgce, user, sel.EventStartsBefore(dttm.Format(epoch)), assert.Falsef},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -521,6 +586,12 @@ func (suite *GroupsSelectorSuite) TestGroupsScope_MatchesInfo() {
 					LastReply: details.ChannelMessageInfo{
 						CreatedAt: mod,
 					},
+					Event: details.CalendarEventInfo{
+						Organizer:   test.creator,
+						Subject:     "daily standup",
+						EventStart:  now,
+						EventRecurs: true,
+					},
 				},
 			}
 
@@ -547,6 +618,13 @@ func (suite *GroupsSelectorSuite) TestCategory_PathType() {
 		{GroupsChannelMessage, path.ChannelMessagesCategory},
 		{GroupsConversation, path.ConversationPostsCategory},
 		{GroupsConversationPost, path.ConversationPostsCategory},
+		{GroupsPlan, path.PlannerCategory},
+		{GroupsPlanItem, path.PlannerCategory},
+		{GroupsTeam, path.TeamStructureCategory},
+		{GroupsTeamStructure, path.TeamStructureCategory},
+		{GroupsEventCalendar, path.EventsCategory},
+		{GroupsEvent, path.EventsCategory},
+		{GroupsInfoEventSubject, path.EventsCategory},
 		{GroupsInfoChannelMessageCreator, path.ChannelMessagesCategory},
 		{GroupsInfoChannelMessageCreatedAfter, path.ChannelMessagesCategory},
 		{GroupsInfoChannelMessageCreatedBefore, path.ChannelMessagesCategory},
@@ -80,6 +80,16 @@ func (s OneDriveBackup) SplitByResourceOwner(users []string) []OneDriveBackup {
 	return ss
 }
 
+// FilterFiles restricts the OneDrive files included in the backup to those
+// that pass the file filter.  A filter that excludes nothing is ignored.
+func (s *OneDriveBackup) FilterFiles(ff FileFilter) {
+	if ff.IsZero() {
+		return
+	}
+
+	s.FileFilter = &ff
+}
+
 // NewOneDriveRestore produces a new Selector with the service set to ServiceOneDrive.
 func NewOneDriveRestore(users []string) *OneDriveRestore {
 	src := OneDriveRestore{
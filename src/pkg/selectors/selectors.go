@@ -27,6 +27,7 @@ const (
 	ServiceSharePoint service = 3 // SharePoint
 	ServiceGroups     service = 4 // Groups
 	ServiceTeamsChats service = 5 // TeamsChats
+	ServiceDirectory  service = 6 // Directory
 )
 
 var serviceToPathType = map[service]path.ServiceType{
@@ -36,6 +37,7 @@ var serviceToPathType = map[service]path.ServiceType{
 	ServiceSharePoint: path.SharePointService,
 	ServiceGroups:     path.GroupsService,
 	ServiceTeamsChats: path.TeamsChatsService,
+	ServiceDirectory:  path.DirectoryService,
 }
 
 var (
@@ -140,6 +142,10 @@ type Selector struct {
 	// or all filters, to be included.
 	Includes []scope `json:"includes,omitempty"`
 
+	// Backup-time filters on library files.  Only applies to backups of
+	// OneDrive, SharePoint, and Groups libraries.
+	FileFilter *FileFilter `json:"fileFilter,omitempty"`
+
 	Cfg Config `json:"cfg,omitempty"`
 }
 
@@ -358,6 +364,9 @@ func selectorAsIface[T any](s Selector) (T, error) {
 	case ServiceTeamsChats:
 		a, err = func() (any, error) { return s.ToTeamsChatsRestore() }()
 		t = a.(T)
+	case ServiceDirectory:
+		a, err = func() (any, error) { return s.ToDirectoryRestore() }()
+		t = a.(T)
 	default:
 		err = clues.Stack(ErrorUnrecognizedService, clues.New(s.Service.String()))
 	}
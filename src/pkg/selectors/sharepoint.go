@@ -80,6 +80,16 @@ func (s SharePointBackup) SplitByResourceOwner(sites []string) []SharePointBacku
 	return ss
 }
 
+// FilterFiles restricts the SharePoint library files included in the backup to those
+// that pass the file filter.  A filter that excludes nothing is ignored.
+func (s *SharePointBackup) FilterFiles(ff FileFilter) {
+	if ff.IsZero() {
+		return
+	}
+
+	s.FileFilter = &ff
+}
+
 // NewSharePointRestore produces a new Selector with the service set to ServiceSharePoint.
 func NewSharePointRestore(sites []string) *SharePointRestore {
 	src := SharePointRestore{
@@ -236,6 +246,11 @@ func (s *SharePointRestore) WebURL(urls []string, opts ...option) []SharePointSc
 			SharePointPage,
 			SharePointWebURL,
 			urls,
+			pathFilterFactory(os...)),
+		makeInfoScope[SharePointScope](
+			SharePointSiteConfiguration,
+			SharePointWebURL,
+			urls,
 			pathFilterFactory(os...)))
 
 	return scopes
@@ -258,6 +273,20 @@ func (s *sharePoint) AllData() []SharePointScope {
 	return scopes
 }
 
+// OptInData produces the scopes for data that backups only include when
+// it's selected explicitly, since it requires additional permissions.
+// Details, restore, and export selectors that want every backed up item
+// should include these alongside AllData.
+func (s *sharePoint) OptInData() []SharePointScope {
+	scopes := []SharePointScope{}
+
+	scopes = append(
+		scopes,
+		makeScope[SharePointScope](SharePointSiteConfigurationFolder, Any()))
+
+	return scopes
+}
+
 // Lists produces one or more SharePoint list scopes.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
 // If any slice contains selectors.None, that slice is reduced to [selectors.None]
@@ -367,6 +396,26 @@ func (s *sharePoint) PageItems(pages, items []string, opts ...option) []SharePoi
 	return scopes
 }
 
+// SiteConfiguration produces one or more SharePoint site configuration
+// scopes, where the site matches with a given site by ID or URL.  The site
+// configuration holds the site's columns, content types, permissions, and
+// settings.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *sharePoint) SiteConfiguration(sites []string, opts ...option) []SharePointScope {
+	var (
+		scopes = []SharePointScope{}
+		os     = append([]option{pathComparator()}, opts...)
+	)
+
+	scopes = append(
+		scopes,
+		makeScope[SharePointScope](SharePointSiteConfigurationFolder, sites, os...))
+
+	return scopes
+}
+
 // -------------------
 // ItemInfo Factories
 
@@ -474,6 +523,9 @@ const (
 	SharePointPageFolder    sharePointCategory = "SharePointPageFolder"
 	SharePointPage          sharePointCategory = "SharePointPage"
 
+	SharePointSiteConfigurationFolder sharePointCategory = "SharePointSiteConfigurationFolder"
+	SharePointSiteConfiguration       sharePointCategory = "SharePointSiteConfiguration"
+
 	// details.itemInfo comparables
 	SharePointInfoCreatedAfter   sharePointCategory = "SharePointInfoCreatedAfter"
 	SharePointInfoCreatedBefore  sharePointCategory = "SharePointInfoCreatedBefore"
@@ -503,6 +555,10 @@ var sharePointLeafProperties = map[categorizer]leafProperty{
 		pathKeys: []categorizer{SharePointPageFolder, SharePointPage},
 		pathType: path.PagesCategory,
 	},
+	SharePointSiteConfiguration: {
+		pathKeys: []categorizer{SharePointSiteConfigurationFolder, SharePointSiteConfiguration},
+		pathType: path.SiteConfigurationCategory,
+	},
 	SharePointSite: { // the root category must be represented, even though it isn't a leaf
 		pathKeys: []categorizer{SharePointSite},
 		pathType: path.UnknownCategory,
@@ -530,6 +586,8 @@ func (c sharePointCategory) leafCat() categorizer {
 		return SharePointListItem
 	case SharePointPage, SharePointPageFolder:
 		return SharePointPage
+	case SharePointSiteConfiguration, SharePointSiteConfigurationFolder:
+		return SharePointSiteConfiguration
 	}
 
 	return c
@@ -598,6 +656,11 @@ func (c sharePointCategory) pathValues(
 		rFld = ent.LocationRef
 		itemName = ent.ItemInfo.SharePoint.ItemName
 
+	case SharePointSiteConfiguration, SharePointSiteConfigurationFolder:
+		folderCat, itemCat = SharePointSiteConfigurationFolder, SharePointSiteConfiguration
+		rFld = repo.Folder(false)
+		itemName = ent.ItemInfo.SharePoint.ItemName
+
 	default:
 		return nil, clues.New("unrecognized sharePointCategory").With("category", c)
 	}
@@ -697,7 +760,7 @@ func (s SharePointScope) set(cat sharePointCategory, v []string, opts ...option)
 	// 1.there is no nested folders -> there cannot be lists within other lists
 	// 2. list itself is the item -> so container and item are the same
 	// since there is no path involved here, we do not need any path filters.
-	case SharePointLibraryFolder, SharePointPage:
+	case SharePointLibraryFolder, SharePointPage, SharePointSiteConfigurationFolder:
 		os = append(os, pathComparator())
 	}
 
@@ -714,12 +777,16 @@ func (s SharePointScope) setDefaults() {
 		s[SharePointListItem.String()] = passAny
 		s[SharePointPageFolder.String()] = passAny
 		s[SharePointPage.String()] = passAny
+		s[SharePointSiteConfigurationFolder.String()] = passAny
+		s[SharePointSiteConfiguration.String()] = passAny
 	case SharePointLibraryFolder:
 		s[SharePointLibraryItem.String()] = passAny
 	case SharePointList:
 		s[SharePointListItem.String()] = passAny
 	case SharePointPageFolder:
 		s[SharePointPage.String()] = passAny
+	case SharePointSiteConfigurationFolder:
+		s[SharePointSiteConfiguration.String()] = passAny
 	}
 }
 
@@ -739,9 +806,10 @@ func (s sharePoint) Reduce(
 		deets,
 		s.Selector,
 		map[path.CategoryType]sharePointCategory{
-			path.LibrariesCategory: SharePointLibraryItem,
-			path.ListsCategory:     SharePointListItem,
-			path.PagesCategory:     SharePointPage,
+			path.LibrariesCategory:         SharePointLibraryItem,
+			path.ListsCategory:             SharePointListItem,
+			path.PagesCategory:             SharePointPage,
+			path.SiteConfigurationCategory: SharePointSiteConfiguration,
 		},
 		errs)
 }
@@ -44,6 +44,23 @@ func (suite *SharePointSelectorSuite) TestToSharePointBackup() {
 	assert.NotZero(t, ob.Scopes())
 }
 
+func (suite *SharePointSelectorSuite) TestSharePointBackup_AllData() {
+	t := suite.T()
+
+	sel := NewSharePointBackup(Any())
+	cats := map[sharePointCategory]struct{}{}
+
+	for _, sc := range sel.AllData() {
+		cats[sc.Category()] = struct{}{}
+	}
+
+	assert.NotContains(t, cats, SharePointSiteConfigurationFolder, "site configuration is opt-in")
+
+	for _, sc := range sel.OptInData() {
+		assert.NotContains(t, cats, sc.Category(), "opt-in data is not in AllData")
+	}
+}
+
 func (suite *SharePointSelectorSuite) TestSharePointSelector_Include_WebURLs() {
 	t := suite.T()
 
@@ -57,7 +74,7 @@ func (suite *SharePointSelectorSuite) TestSharePointSelector_Include_WebURLs() {
 	sel := NewSharePointRestore(s12)
 	sel.Include(sel.WebURL(s12))
 	scopes := sel.Includes
-	require.Len(t, scopes, 3)
+	require.Len(t, scopes, 4)
 
 	for _, sc := range scopes {
 		scopeMustHave(
@@ -91,7 +108,7 @@ func (suite *SharePointSelectorSuite) TestSharePointSelector_Include_WebURLs_any
 			sel := NewSharePointRestore(Any())
 			sel.Include(sel.WebURL(test.in))
 			scopes := sel.Includes
-			require.Len(t, scopes, 3)
+			require.Len(t, scopes, 4)
 
 			for _, sc := range scopes {
 				scopeMustHave(
@@ -116,7 +133,7 @@ func (suite *SharePointSelectorSuite) TestSharePointSelector_Exclude_WebURLs() {
 	sel := NewSharePointRestore(s12)
 	sel.Exclude(sel.WebURL(s12))
 	scopes := sel.Excludes
-	require.Len(t, scopes, 3)
+	require.Len(t, scopes, 4)
 
 	for _, sc := range scopes {
 		scopeMustHave(
@@ -459,6 +476,16 @@ func (suite *SharePointSelectorSuite) TestSharePointCategory_PathValues() {
 			},
 			cfg: Config{},
 		},
+		{
+			name:      "SharePoint Site Configuration",
+			sc:        SharePointSiteConfiguration,
+			pathElems: elems,
+			expected: map[categorizer][]string{
+				SharePointSiteConfigurationFolder: {itemID},
+				SharePointSiteConfiguration:       {itemID, shortRef},
+			},
+			cfg: Config{},
+		},
 	}
 
 	for _, test := range table {
@@ -481,6 +508,11 @@ func (suite *SharePointSelectorSuite) TestSharePointCategory_PathValues() {
 					ItemName:   itemName,
 					ParentPath: test.parentPath,
 				}
+			} else if test.sc.PathType() == path.SiteConfigurationCategory {
+				di.SharePoint = &details.SharePointInfo{
+					ItemType: details.SharePointSiteConfiguration,
+					ItemName: itemName,
+				}
 			} else if test.sc.PathType() == path.ListsCategory {
 				di.SharePoint = &details.SharePointInfo{
 					List: &details.ListInfo{
@@ -596,6 +628,8 @@ func (suite *SharePointSelectorSuite) TestCategory_PathType() {
 		{SharePointLibraryFolder, path.LibrariesCategory},
 		{SharePointLibraryItem, path.LibrariesCategory},
 		{SharePointList, path.ListsCategory},
+		{SharePointSiteConfigurationFolder, path.SiteConfigurationCategory},
+		{SharePointSiteConfiguration, path.SiteConfigurationCategory},
 	}
 	for _, test := range table {
 		suite.Run(test.cat.String(), func() {
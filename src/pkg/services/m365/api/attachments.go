@@ -10,6 +10,48 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 )
 
+// MaxNestedAttachmentDepth caps the number of item attachment levels, each
+// holding a message or event with attachments of its own, that are retained
+// within a single item.
+const MaxNestedAttachmentDepth = 5
+
+// itemAttachmentExpand produces the $expand value that retrieves the item
+// held by each item attachment.  Depths greater than one also retrieve the
+// attachments of those items, and the items within them, down to the given
+// number of levels.
+func itemAttachmentExpand(depth int) string {
+	expand := "microsoft.graph.itemattachment/item"
+	if depth <= 1 {
+		return expand
+	}
+
+	nested := fmt.Sprintf("attachments($expand=%s)", itemAttachmentExpand(depth-1))
+
+	return fmt.Sprintf(
+		"%s($expand=microsoft.graph.message/%s,microsoft.graph.event/%s)",
+		expand,
+		nested,
+		nested)
+}
+
+// hasUnfetchedNestedAttachments reports whether the item within an item
+// attachment has attachments of its own that weren't retrieved alongside it.
+func hasUnfetchedNestedAttachments(attachment models.Attachmentable) bool {
+	ia, ok := attachment.(models.ItemAttachmentable)
+	if !ok {
+		return false
+	}
+
+	switch item := ia.GetItem().(type) {
+	case models.Messageable:
+		return ptr.Val(item.GetHasAttachments()) && len(item.GetAttachments()) == 0
+	case models.Eventable:
+		return ptr.Val(item.GetHasAttachments()) && len(item.GetAttachments()) == 0
+	}
+
+	return false
+}
+
 func HasAttachments(body models.ItemBodyable) bool {
 	if body == nil {
 		return false
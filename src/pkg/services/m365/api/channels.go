@@ -14,6 +14,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/common/str"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 )
 
@@ -102,9 +103,14 @@ func (c Channels) GetChannelByName(
 // message
 // ---------------------------------------------------------------------------
 
+// GetChannelMessage retrieves the message along with its replies.  The hosted
+// contents and attached files of the message and its replies are downloaded
+// and stored within each message.  Content that can't be stored is reported
+// to errs.
 func (c Channels) GetChannelMessage(
 	ctx context.Context,
 	teamID, channelID, messageID string,
+	errs *fault.Bus,
 ) (models.ChatMessageable, *details.GroupsInfo, error) {
 	message, err := c.Stable.
 		Client().
@@ -124,6 +130,24 @@ func (c Channels) GetChannelMessage(
 		return nil, nil, clues.Wrap(err, "retrieving message replies")
 	}
 
+	budget := newContentBudget(maxChatItemContentSize)
+
+	c.populateChatMessageContent(
+		ctx,
+		message,
+		channelMessageURL(teamID, channelID, messageID),
+		budget,
+		errs)
+
+	for _, r := range replies {
+		c.populateChatMessageContent(
+			ctx,
+			r,
+			channelMessageReplyURL(teamID, channelID, messageID, ptr.Val(r.GetId())),
+			budget,
+			errs)
+	}
+
 	message.SetReplies(replies)
 
 	info := channelMessageInfo(message)
@@ -172,7 +196,7 @@ func channelMessageInfo(
 		Creator:         GetChatMessageFrom(msg),
 		Preview:         preview,
 		ReplyCount:      len(replies),
-		Size:            contentLen,
+		Size:            contentLen + chatMessageContentSize(msg),
 		Subject:         ptr.Val(msg.GetSubject()),
 	}
 
@@ -189,7 +213,7 @@ func channelMessageInfo(
 			CreatedAt:       ptr.Val(lastReply.GetCreatedDateTime()),
 			Creator:         GetChatMessageFrom(lastReply),
 			Preview:         preview,
-			Size:            contentLen,
+			Size:            contentLen + chatMessageContentSize(lastReply),
 		}
 	}
 
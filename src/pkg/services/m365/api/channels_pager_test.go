@@ -14,6 +14,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/str"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/tester/tconfig"
+	"github.com/alcionai/corso/src/pkg/fault"
 )
 
 type ChannelsPagerIntgSuite struct {
@@ -103,7 +104,7 @@ func testEnumerateChannelMessageReplies(
 	ctx, flush := tester.NewContext(t)
 	defer flush()
 
-	msg, info, err := ac.GetChannelMessage(ctx, groupID, channelID, messageID)
+	msg, info, err := ac.GetChannelMessage(ctx, groupID, channelID, messageID, fault.New(true))
 	require.NoError(t, err, clues.ToCore(err))
 
 	replies, err := ac.GetChannelMessageReplies(ctx, groupID, channelID, messageID)
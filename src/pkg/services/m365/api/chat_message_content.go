@@ -0,0 +1,300 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/chats"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+const (
+	chatMessageURLFmt    = "https://graph.microsoft.com/v1.0/chats/%s/messages/%s"
+	channelMessageURLFmt = "https://graph.microsoft.com/v1.0/teams/%s/channels/%s/messages/%s"
+
+	// ChatMessageFileContentKey is the additionalData key that holds the
+	// base64 encoded content of a file attached to a chat or channel message.
+	ChatMessageFileContentKey = "@corso.fileContent"
+	// ChatMessageDriveIDKey and ChatMessageDriveItemIDKey are the additionalData
+	// keys that hold the IDs of the drive item an attached file resolved to.
+	ChatMessageDriveIDKey     = "@corso.driveId"
+	ChatMessageDriveItemIDKey = "@corso.driveItemId"
+
+	// referenceAttachmentContentType marks message attachments that link to
+	// a file stored in OneDrive or SharePoint.
+	referenceAttachmentContentType = "reference"
+
+	// maxChatMessageFileSize is the largest attached file that gets backed up
+	// along with its message.  Larger files are only recorded as a reference
+	// to their drive item, and are expected to be covered by drive backups.
+	maxChatMessageFileSize = 25 * 1024 * 1024
+	// maxChatItemContentSize caps the hosted contents and files stored within
+	// a single backup item: a channel message with its replies, or a chat with
+	// all of its messages.
+	maxChatItemContentSize = 100 * 1024 * 1024
+)
+
+func chatMessageURL(chatID, messageID string) string {
+	return fmt.Sprintf(chatMessageURLFmt, chatID, messageID)
+}
+
+func channelMessageURL(teamID, channelID, messageID string) string {
+	return fmt.Sprintf(channelMessageURLFmt, teamID, channelID, messageID)
+}
+
+func channelMessageReplyURL(teamID, channelID, messageID, replyID string) string {
+	return channelMessageURL(teamID, channelID, messageID) + "/replies/" + replyID
+}
+
+// populateChatMessageContent downloads the hosted contents (ex: inline
+// images) and the attached files of the message found at msgURL, and adds
+// them to the message.  Content that exceeds the size limits, and files
+// that were deleted since they got shared, are recorded as skips.  Any other
+// content that can't be retrieved is added to errs as a recoverable error,
+// and left in the message as graph provided it.
+func (c Client) populateChatMessageContent(
+	ctx context.Context,
+	msg models.ChatMessageable,
+	msgURL string,
+	budget *contentBudget,
+	errs *fault.Bus,
+) {
+	ctx = clues.Add(ctx, "chat_message_id", ptr.Val(msg.GetId()))
+
+	if err := c.populateHostedContents(ctx, msg, msgURL, budget, errs); err != nil {
+		errs.AddRecoverable(ctx, clues.Wrap(err, "backing up chat message hosted contents"))
+	}
+
+	for _, att := range msg.GetAttachments() {
+		if errs.Failure() != nil {
+			return
+		}
+
+		if err := c.populateFileAttachment(ctx, msg, att, budget, errs); err != nil {
+			errs.AddRecoverable(ctx, clues.Wrap(err, "backing up chat message file attachment"))
+		}
+	}
+}
+
+func (c Client) populateHostedContents(
+	ctx context.Context,
+	msg models.ChatMessageable,
+	msgURL string,
+	budget *contentBudget,
+	errs *fault.Bus,
+) error {
+	// hosted contents are always referenced from within the message body, so
+	// messages without a reference can skip the lookup.
+	if msg.GetBody() == nil ||
+		!strings.Contains(ptr.Val(msg.GetBody().GetContent()), "/hostedContents/") {
+		return nil
+	}
+
+	hcURL := msgURL + "/hostedContents"
+
+	resp, err := chats.
+		NewItemMessagesItemHostedContentsRequestBuilder(hcURL, c.Stable.Adapter()).
+		Get(ctx, nil)
+	if err != nil {
+		return clues.Wrap(graph.Stack(ctx, err), "listing hosted contents")
+	}
+
+	var (
+		hcs   = resp.GetValue()
+		kept  = make([]models.ChatMessageHostedContentable, 0, len(hcs))
+		msgID = ptr.Val(msg.GetId())
+	)
+
+	for _, hc := range hcs {
+		id := ptr.Val(hc.GetId())
+
+		content, err := chats.
+			NewItemMessagesItemHostedContentsItemValueContentRequestBuilder(
+				hcURL+"/"+id+"/$value",
+				c.LargeItem.Adapter()).
+			Get(ctx, nil)
+		if err != nil {
+			return clues.Wrap(graph.Stack(ctx, err), "getting hosted content").With("hosted_content_id", id)
+		}
+
+		// the size of hosted contents isn't known until they're downloaded.
+		if !budget.take(int64(len(content))) {
+			errs.AddSkip(ctx, fault.FileSkip(
+				fault.SkipChatMessageContentTooLarge,
+				msgID,
+				id,
+				id,
+				map[string]any{"size": len(content)}))
+
+			continue
+		}
+
+		hc.SetContentBytes(content)
+
+		if len(ptr.Val(hc.GetContentType())) == 0 {
+			hc.SetContentType(ptr.To(http.DetectContentType(content)))
+		}
+
+		kept = append(kept, hc)
+	}
+
+	msg.SetHostedContents(kept)
+
+	return nil
+}
+
+func (c Client) populateFileAttachment(
+	ctx context.Context,
+	msg models.ChatMessageable,
+	att models.ChatMessageAttachmentable,
+	budget *contentBudget,
+	errs *fault.Bus,
+) error {
+	contentURL := ptr.Val(att.GetContentUrl())
+
+	if ptr.Val(att.GetContentType()) != referenceAttachmentContentType || len(contentURL) == 0 {
+		return nil
+	}
+
+	var (
+		attID   = ptr.Val(att.GetId())
+		attName = ptr.Val(att.GetName())
+		msgID   = ptr.Val(msg.GetId())
+	)
+
+	ctx = clues.Add(ctx, "attachment_id", attID)
+
+	// the content url is a sharing url for the file, which we can resolve
+	// to its drive item through the shares api.
+	// https://learn.microsoft.com/en-us/graph/api/shares-get
+	shareID := "u!" + base64.RawURLEncoding.EncodeToString([]byte(contentURL))
+
+	item, err := c.Stable.
+		Client().
+		Shares().
+		BySharedDriveItemId(shareID).
+		DriveItem().
+		Get(ctx, nil)
+	if err != nil {
+		// files get deleted after they're shared, which leaves the message
+		// with an attachment that can never be resolved.
+		if errors.Is(graph.Stack(ctx, err), core.ErrNotFound) {
+			errs.AddSkip(ctx, fault.FileSkip(fault.SkipChatMessageFileNotFound, msgID, attID, attName, nil))
+			return nil
+		}
+
+		return clues.Wrap(graph.Stack(ctx, err), "resolving attached file to its drive item")
+	}
+
+	ad := att.GetAdditionalData()
+	if ad == nil {
+		ad = map[string]any{}
+	}
+
+	ad[ChatMessageDriveItemIDKey] = ptr.To(ptr.Val(item.GetId()))
+
+	if item.GetParentReference() != nil {
+		ad[ChatMessageDriveIDKey] = ptr.To(ptr.Val(item.GetParentReference().GetDriveId()))
+	}
+
+	att.SetAdditionalData(ad)
+
+	size := ptr.Val(item.GetSize())
+
+	if size > maxChatMessageFileSize || !budget.take(size) {
+		logger.Ctx(ctx).Infow(
+			"attached file exceeds size limit; keeping drive item reference only",
+			"file_size", size)
+
+		errs.AddSkip(ctx, fault.FileSkip(
+			fault.SkipChatMessageContentTooLarge,
+			msgID,
+			attID,
+			attName,
+			map[string]any{"size": size}))
+
+		return nil
+	}
+
+	content, err := c.LargeItem.
+		Client().
+		Shares().
+		BySharedDriveItemId(shareID).
+		DriveItem().
+		Content().
+		Get(ctx, nil)
+	if err != nil {
+		return clues.Wrap(graph.Stack(ctx, err), "getting attached file content")
+	}
+
+	ad[ChatMessageFileContentKey] = ptr.To(base64.StdEncoding.EncodeToString(content))
+
+	return nil
+}
+
+// ChatMessageFileContent returns the content of the attached file, if the
+// backup captured it.
+func ChatMessageFileContent(att models.ChatMessageAttachmentable) ([]byte, bool, error) {
+	var encoded string
+
+	switch v := att.GetAdditionalData()[ChatMessageFileContentKey].(type) {
+	case *string:
+		encoded = ptr.Val(v)
+	case string:
+		encoded = v
+	default:
+		return nil, false, nil
+	}
+
+	content, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false, clues.Wrap(err, "decoding attached file content")
+	}
+
+	return content, true, nil
+}
+
+var hostedContentExtensions = map[string]string{
+	"image/bmp":  ".bmp",
+	"image/gif":  ".gif",
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// HostedContentFileExtension returns the file extension matching the
+// content type of a hosted content.  Unknown content types produce an
+// empty extension.
+func HostedContentFileExtension(contentType string) string {
+	return hostedContentExtensions[contentType]
+}
+
+// chatMessageContentSize sums the size of the hosted contents and attached
+// files stored within the message.
+func chatMessageContentSize(msg models.ChatMessageable) int64 {
+	var size int64
+
+	for _, hc := range msg.GetHostedContents() {
+		size += int64(len(hc.GetContentBytes()))
+	}
+
+	for _, att := range msg.GetAttachments() {
+		content, ok, err := ChatMessageFileContent(att)
+		if err == nil && ok {
+			size += int64(len(content))
+		}
+	}
+
+	return size
+}
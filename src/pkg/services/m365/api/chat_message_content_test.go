@@ -0,0 +1,278 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/h2non/gock"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/tester/tconfig"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+type ChatMessageContentUnitSuite struct {
+	tester.Suite
+}
+
+func TestChatMessageContentUnitSuite(t *testing.T) {
+	suite.Run(t, &ChatMessageContentUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func chatMessageWithContent(body string, atts ...models.ChatMessageAttachmentable) models.ChatMessageable {
+	msg := models.NewChatMessage()
+	msg.SetId(ptr.To("mid"))
+
+	ib := models.NewItemBody()
+	ib.SetContent(ptr.To(body))
+	msg.SetBody(ib)
+	msg.SetAttachments(atts)
+
+	return msg
+}
+
+func referenceAttachment(id, url string) models.ChatMessageAttachmentable {
+	att := models.NewChatMessageAttachment()
+	att.SetId(ptr.To(id))
+	att.SetName(ptr.To(id + ".txt"))
+	att.SetContentType(ptr.To(referenceAttachmentContentType))
+	att.SetContentUrl(ptr.To(url))
+
+	return att
+}
+
+func (suite *ChatMessageContentUnitSuite) TestPopulateChatMessageContent() {
+	const (
+		msgURL  = "https://graph.microsoft.com/v1.0/chats/cid/messages/mid"
+		fileURL = "https://tenant.sharepoint.com/sites/site/Shared Documents/file.txt"
+		bigURL  = "https://tenant.sharepoint.com/sites/site/Shared Documents/big.txt"
+		goneURL = "https://tenant.sharepoint.com/sites/site/Shared Documents/gone.txt"
+	)
+
+	var (
+		image   = []byte("\x89PNG\r\n\x1a\nimage")
+		file    = []byte("file content")
+		shareID = func(url string) string {
+			return "u!" + base64.RawURLEncoding.EncodeToString([]byte(url))
+		}
+	)
+
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := tconfig.NewFakeM365Account(t)
+	creds, err := a.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+	require.NoError(t, err, clues.ToCore(err))
+
+	t.Cleanup(gock.Off)
+
+	interceptV1Path("chats", "cid", "messages", "mid", "hostedContents").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{{"id": "hcid"}},
+		})
+
+	interceptV1Path("chats", "cid", "messages", "mid", "hostedContents", "hcid", "$value").
+		Reply(200).
+		Body(bytes.NewReader(image))
+
+	interceptV1Path("shares", shareID(fileURL), "driveItem").
+		Reply(200).
+		JSON(map[string]any{
+			"id":              "fileid",
+			"size":            len(file),
+			"parentReference": map[string]any{"driveId": "driveid"},
+		})
+
+	interceptV1Path("shares", shareID(fileURL), "driveItem", "content").
+		Reply(200).
+		Body(bytes.NewReader(file))
+
+	interceptV1Path("shares", shareID(bigURL), "driveItem").
+		Reply(200).
+		JSON(map[string]any{
+			"id":   "bigid",
+			"size": maxChatMessageFileSize + 1,
+		})
+
+	interceptV1Path("shares", shareID(goneURL), "driveItem").
+		Reply(404).
+		JSON(map[string]any{
+			"error": map[string]any{"code": "itemNotFound", "message": "gone"},
+		})
+
+	msg := chatMessageWithContent(
+		`<img src="`+msgURL+`/hostedContents/hcid/$value">`,
+		referenceAttachment("file", fileURL),
+		referenceAttachment("big", bigURL),
+		referenceAttachment("gone", goneURL))
+
+	errs := fault.New(false)
+
+	client.populateChatMessageContent(ctx, msg, msgURL, newContentBudget(maxChatItemContentSize), errs)
+	require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+	assert.Empty(t, errs.Recovered(), "recovered errors")
+
+	skipped := errs.Skipped()
+	require.Len(t, skipped, 2)
+	assert.True(t, skipped[0].HasCause(fault.SkipChatMessageContentTooLarge), skipped[0].String())
+	assert.Equal(t, "big", skipped[0].Item.ID)
+	assert.True(t, skipped[1].HasCause(fault.SkipChatMessageFileNotFound), skipped[1].String())
+	assert.Equal(t, "gone", skipped[1].Item.ID)
+
+	hcs := msg.GetHostedContents()
+	require.Len(t, hcs, 1)
+	assert.Equal(t, image, hcs[0].GetContentBytes())
+	assert.Equal(t, "image/png", ptr.Val(hcs[0].GetContentType()))
+
+	atts := msg.GetAttachments()
+	require.Len(t, atts, 3)
+
+	content, ok, err := ChatMessageFileContent(atts[0])
+	require.NoError(t, err, clues.ToCore(err))
+	assert.True(t, ok, "file content stored")
+	assert.Equal(t, file, content)
+	assert.Equal(t, "fileid", ptr.Val(atts[0].GetAdditionalData()[ChatMessageDriveItemIDKey].(*string)))
+	assert.Equal(t, "driveid", ptr.Val(atts[0].GetAdditionalData()[ChatMessageDriveIDKey].(*string)))
+
+	_, ok, err = ChatMessageFileContent(atts[1])
+	require.NoError(t, err, clues.ToCore(err))
+	assert.False(t, ok, "oversized file content stored")
+	assert.Equal(t, "bigid", ptr.Val(atts[1].GetAdditionalData()[ChatMessageDriveItemIDKey].(*string)))
+
+	_, ok, err = ChatMessageFileContent(atts[2])
+	require.NoError(t, err, clues.ToCore(err))
+	assert.False(t, ok, "missing file content stored")
+
+	assert.Equal(t, int64(len(image)+len(file)), chatMessageContentSize(msg))
+
+	// chat details count the message content along with its stored content.
+	_, contentLen, err := getChatMessageContentPreview(msg)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(
+		t,
+		contentLen+int64(len(image)+len(file)),
+		chatMessagesSize([]models.ChatMessageable{msg}))
+}
+
+func (suite *ChatMessageContentUnitSuite) TestPopulateChatMessageContent_noContent() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := tconfig.NewFakeM365Account(t)
+	creds, err := a.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+	require.NoError(t, err, clues.ToCore(err))
+
+	t.Cleanup(gock.Off)
+
+	// nothing in the message requires a call to graph.
+	att := models.NewChatMessageAttachment()
+	att.SetContentType(ptr.To("application/vnd.microsoft.card.adaptive"))
+
+	msg := chatMessageWithContent("<p>no images here</p>", att)
+
+	errs := fault.New(true)
+
+	client.populateChatMessageContent(
+		ctx,
+		msg,
+		"https://graph.microsoft.com/v1.0/chats/cid/messages/mid",
+		newContentBudget(maxChatItemContentSize),
+		errs)
+	require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+	assert.Empty(t, errs.Skipped())
+
+	assert.Empty(t, msg.GetHostedContents())
+	assert.Empty(t, att.GetAdditionalData())
+	assert.Zero(t, chatMessageContentSize(msg))
+	assert.False(t, gock.HasUnmatchedRequest(), "unexpected graph calls")
+}
+
+func (suite *ChatMessageContentUnitSuite) TestPopulateChatMessageContent_limitsAndFailures() {
+	const msgURL = "https://graph.microsoft.com/v1.0/chats/cid/messages/mid"
+
+	var (
+		image   = []byte("\x89PNG\r\n\x1a\nimage")
+		fileURL = "https://tenant.sharepoint.com/sites/site/Shared Documents/file.txt"
+	)
+
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := tconfig.NewFakeM365Account(t)
+	creds, err := a.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+	require.NoError(t, err, clues.ToCore(err))
+
+	t.Cleanup(gock.Off)
+
+	interceptV1Path("chats", "cid", "messages", "mid", "hostedContents").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{{"id": "fits"}, {"id": "overflows"}},
+		})
+
+	interceptV1Path("chats", "cid", "messages", "mid", "hostedContents", "fits", "$value").
+		Reply(200).
+		Body(bytes.NewReader(image))
+
+	interceptV1Path("chats", "cid", "messages", "mid", "hostedContents", "overflows", "$value").
+		Reply(200).
+		Body(bytes.NewReader(image))
+
+	interceptV1Path("shares", "u!"+base64.RawURLEncoding.EncodeToString([]byte(fileURL)), "driveItem").
+		Reply(403).
+		JSON(map[string]any{
+			"error": map[string]any{"code": "accessDenied", "message": "access denied"},
+		})
+
+	msg := chatMessageWithContent(
+		`<img src="`+msgURL+`/hostedContents/fits/$value">`,
+		referenceAttachment("file", fileURL))
+
+	// only room for one of the two images.
+	budget := &contentBudget{remaining: int64(len(image) + 1)}
+	errs := fault.New(false)
+
+	client.populateChatMessageContent(ctx, msg, msgURL, budget, errs)
+	require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+
+	hcs := msg.GetHostedContents()
+	require.Len(t, hcs, 1)
+	assert.Equal(t, "fits", ptr.Val(hcs[0].GetId()))
+
+	skipped := errs.Skipped()
+	require.Len(t, skipped, 1)
+	assert.True(t, skipped[0].HasCause(fault.SkipChatMessageContentTooLarge), skipped[0].String())
+	assert.Equal(t, "overflows", skipped[0].Item.ID)
+
+	assert.Len(t, errs.Recovered(), 1, "file resolution failure is recoverable")
+	assert.False(t, gock.HasUnmatchedRequest(), "unexpected graph calls")
+
+	_, ok, err := ChatMessageFileContent(msg.GetAttachments()[0])
+	require.NoError(t, err, clues.ToCore(err))
+	assert.False(t, ok, "failed file content stored")
+}
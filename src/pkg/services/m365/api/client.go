@@ -40,6 +40,10 @@ type Client struct {
 	// graph api client.
 	Requester graph.Requester
 
+	// The SharePointRequester calls the SharePoint REST api, which covers
+	// the few SharePoint features that aren't exposed through graph.
+	SharePointRequester graph.Requester
+
 	counter *count.Bus
 
 	options control.Options
@@ -72,6 +76,10 @@ func NewClient(
 		counter,
 		graph.AuthorizeRequester(azureAuth))
 
+	spRqr := graph.NewNoTimeoutHTTPWrapper(
+		counter,
+		graph.AuthorizeRequester(graph.NewSharePointAuth(creds)))
+
 	if co.DeltaPageSize < 1 || co.DeltaPageSize > maxDeltaPageSize {
 		co.DeltaPageSize = maxDeltaPageSize
 	}
@@ -83,6 +91,8 @@ func NewClient(
 		Requester:   rqr,
 		counter:     counter,
 		options:     co,
+
+		SharePointRequester: spRqr,
 	}
 
 	return cli, nil
@@ -153,7 +163,7 @@ func (c Client) Get(
 	return c.Requester.Request(ctx, http.MethodGet, url, nil, headers, requireAuth)
 }
 
-// Get performs an ad-hoc get request using its graph.Requester
+// Post performs an ad-hoc post request using its graph.Requester
 func (c Client) Post(
 	ctx context.Context,
 	url string,
@@ -161,7 +171,7 @@ func (c Client) Post(
 	body io.Reader,
 	requireAuth bool,
 ) (*http.Response, error) {
-	return c.Requester.Request(ctx, http.MethodGet, url, body, headers, requireAuth)
+	return c.Requester.Request(ctx, http.MethodPost, url, body, headers, requireAuth)
 }
 
 // ---------------------------------------------------------------------------
@@ -121,7 +121,8 @@ func DefaultDriveItemProps() []string {
 		"size",
 		"deleted",
 		"malware",
-		"shared")
+		"shared",
+		"sharepointIds")
 }
 
 // URL cache only needs to fetch a small subset of item properties
@@ -12,6 +12,16 @@ const (
 	MailInbox       = "Inbox"
 	MsgFolderRoot   = "msgfolderroot"
 
+	// well known mail folder names, usable in place of a mail folder ID.
+	MailConversationHistory       = "conversationhistory"
+	MailDrafts                    = "drafts"
+	MailJunkEmail                 = "junkemail"
+	MailOutbox                    = "outbox"
+	MailRecoverableItemsRoot      = "recoverableitemsroot"
+	MailRecoverableItemsDeletions = "recoverableitemsdeletions"
+	MailRecoverableItemsPurges    = "recoverableitemspurges"
+	MailRecoverableItemsVersions  = "recoverableitemsversions"
+
 	// Kiota JSON invalid JSON error message.
 	invalidJSON = "invalid json type"
 )
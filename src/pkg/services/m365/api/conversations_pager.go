@@ -2,11 +2,13 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/groups"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
+	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
 )
@@ -231,23 +233,38 @@ func (c Conversations) GetConversationThreadPosts(
 	return items, clues.Stack(err).OrNil()
 }
 
-// GetConversations fetches all added and deleted conversation posts in the group.
+// GetConversationThreadPostIDs fetches the IDs of the posts in the thread.
+// Posts have no delta query, so every post in the thread gets enumerated.
+// If modifiedSince is non-zero, only the posts modified after that time
+// are returned as added.
 func (c Conversations) GetConversationThreadPostIDs(
 	ctx context.Context,
 	groupID, conversationID, threadID string,
+	modifiedSince time.Time,
 	cc CallConfig,
 ) (pagers.AddedAndRemoved, error) {
 	canMakeDeltaQueries := false
+	filter := pagers.FilterIncludeAll[models.Postable]
+
+	if !modifiedSince.IsZero() {
+		filter = func(p models.Postable) bool {
+			return ptr.Val(p.GetLastModifiedDateTime()).After(modifiedSince)
+		}
+	}
 
 	aarh, err := pagers.GetAddedAndRemovedItemIDs[models.Postable](
 		ctx,
-		c.NewConversationThreadPostsPager(groupID, conversationID, threadID, CallConfig{}),
+		c.NewConversationThreadPostsPager(
+			groupID,
+			conversationID,
+			threadID,
+			CallConfig{Select: idAnd(lastModifiedDateTime)}),
 		nil,
 		"",
 		canMakeDeltaQueries,
 		0,
 		pagers.AddedAndRemovedAddAll[models.Postable],
-		pagers.FilterIncludeAll[models.Postable])
+		filter)
 
 	return aarh, clues.Stack(err).OrNil()
 }
@@ -2,6 +2,7 @@ package api
 
 import (
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
@@ -58,6 +59,7 @@ func (suite *ConversationsPagerIntgSuite) TestEnumerateConversations_withThreads
 				suite.its.group.id,
 				ptr.Val(conv.GetId()),
 				ptr.Val(thread.GetId()),
+				time.Time{},
 				CallConfig{})
 			require.NoError(t, err, clues.ToCore(err))
 			require.Equal(t, len(posts), len(aar.Added), "added the same number of ids and posts")
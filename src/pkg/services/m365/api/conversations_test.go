@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/exp/maps"
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	exchMock "github.com/alcionai/corso/src/internal/m365/service/exchange/mock"
@@ -17,6 +18,8 @@ import (
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/tester/tconfig"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
 	graphTD "github.com/alcionai/corso/src/pkg/services/m365/api/graph/testdata"
 )
 
@@ -179,6 +182,74 @@ func (suite *ConversationsAPIUnitSuite) TestBytesToPostable() {
 	}
 }
 
+func (suite *ConversationsAPIUnitSuite) TestGetConversationThreadPostIDs() {
+	var (
+		older = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		newer = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	table := []struct {
+		name          string
+		modifiedSince time.Time
+		expect        []string
+	}{
+		{
+			name:   "all posts",
+			expect: []string{"old", "new"},
+		},
+		{
+			name:          "modified since",
+			modifiedSince: older.Add(time.Hour),
+			expect:        []string{"new"},
+		},
+		{
+			name:          "nothing modified since",
+			modifiedSince: newer,
+			expect:        []string{},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			a := tconfig.NewFakeM365Account(t)
+			creds, err := a.M365Config()
+			require.NoError(t, err, clues.ToCore(err))
+
+			client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+			require.NoError(t, err, clues.ToCore(err))
+
+			t.Cleanup(gock.Off)
+
+			interceptV1Path("groups", "gid", "conversations", "cid", "threads", "tid", "posts").
+				Reply(200).
+				JSON(map[string]any{
+					"value": []map[string]any{
+						{"id": "old", "lastModifiedDateTime": older.Format(time.RFC3339)},
+						{"id": "new", "lastModifiedDateTime": newer.Format(time.RFC3339)},
+					},
+				})
+
+			aar, err := client.Conversations().GetConversationThreadPostIDs(
+				ctx,
+				"gid",
+				"cid",
+				"tid",
+				test.modifiedSince,
+				CallConfig{})
+			require.NoError(t, err, clues.ToCore(err))
+			assert.False(t, gock.HasUnmatchedRequest(), "unmatched graph calls")
+
+			assert.ElementsMatch(t, test.expect, maps.Keys(aar.Added))
+			assert.Empty(t, aar.Removed)
+			assert.True(t, aar.ValidModTimes)
+		})
+	}
+}
+
 type ConversationAPIIntgSuite struct {
 	tester.Suite
 	its intgTesterSetup
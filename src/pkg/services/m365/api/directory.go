@@ -0,0 +1,444 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/applications"
+	"github.com/microsoftgraph/msgraph-sdk-go/directory"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/identity"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+)
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) Directory() Directory {
+	return Directory{c}
+}
+
+// Directory is an interface-compliant provider of the client.  It covers
+// the objects within the tenant directory: users, groups, applications,
+// service principals, conditional access policies and administrative units.
+type Directory struct {
+	Client
+}
+
+// ---------------------------------------------------------------------------
+// tenant
+// ---------------------------------------------------------------------------
+
+// GetIDAndName looks up the organization matching the given tenant ID,
+// and returns its canonical ID and display name.
+func (c Directory) GetIDAndName(
+	ctx context.Context,
+	tenantID string,
+	_ CallConfig, // matching standards
+) (string, string, error) {
+	ctx = clues.Add(ctx, "tenant_id", tenantID)
+
+	resp, err := c.Stable.
+		Client().
+		Organization().
+		Get(ctx, nil)
+	if err != nil {
+		return "", "", clues.Wrap(err, "getting organization")
+	}
+
+	for _, org := range resp.GetValue() {
+		id := ptr.Val(org.GetId())
+
+		if strings.EqualFold(id, tenantID) {
+			return id, ptr.Val(org.GetDisplayName()), nil
+		}
+	}
+
+	return "", "", clues.StackWC(ctx, core.ErrNotFound)
+}
+
+// ---------------------------------------------------------------------------
+// objects
+// ---------------------------------------------------------------------------
+
+func (c Directory) GetUser(
+	ctx context.Context,
+	userID string,
+	cc CallConfig,
+) (models.Userable, *details.DirectoryInfo, error) {
+	config := &users.UserItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.UserItemRequestBuilderGetQueryParameters{},
+	}
+
+	if len(cc.Select) > 0 {
+		config.QueryParameters.Select = cc.Select
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		Get(ctx, config)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting user")
+	}
+
+	return resp, DirectoryUserInfo(resp), nil
+}
+
+// GetGroup retrieves the group along with the users who own and belong
+// to it.  Members that aren't users, such as nested groups and devices,
+// are not included.
+func (c Directory) GetGroup(
+	ctx context.Context,
+	groupID string,
+	cc CallConfig,
+) (models.Groupable, *details.DirectoryInfo, error) {
+	config := &groups.GroupItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.GroupItemRequestBuilderGetQueryParameters{},
+	}
+
+	if len(cc.Select) > 0 {
+		config.QueryParameters.Select = cc.Select
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Get(ctx, config)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting group")
+	}
+
+	owners, err := c.Groups().GetOwners(ctx, groupID)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting group owners")
+	}
+
+	members, err := c.Groups().GetMembers(ctx, groupID)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting group members")
+	}
+
+	resp.SetOwners(usersToDirectoryObjects(owners))
+	resp.SetMembers(usersToDirectoryObjects(members))
+
+	return resp, DirectoryGroupInfo(resp), nil
+}
+
+func (c Directory) GetApplication(
+	ctx context.Context,
+	appID string,
+	cc CallConfig,
+) (models.Applicationable, *details.DirectoryInfo, error) {
+	config := &applications.ApplicationItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &applications.ApplicationItemRequestBuilderGetQueryParameters{},
+	}
+
+	if len(cc.Select) > 0 {
+		config.QueryParameters.Select = cc.Select
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Applications().
+		ByApplicationId(appID).
+		Get(ctx, config)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting application")
+	}
+
+	return resp, DirectoryApplicationInfo(resp), nil
+}
+
+func (c Directory) GetServicePrincipal(
+	ctx context.Context,
+	spID string,
+	cc CallConfig,
+) (models.ServicePrincipalable, *details.DirectoryInfo, error) {
+	config := &serviceprincipals.ServicePrincipalItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &serviceprincipals.ServicePrincipalItemRequestBuilderGetQueryParameters{},
+	}
+
+	if len(cc.Select) > 0 {
+		config.QueryParameters.Select = cc.Select
+	}
+
+	resp, err := c.Stable.
+		Client().
+		ServicePrincipals().
+		ByServicePrincipalId(spID).
+		Get(ctx, config)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting service principal")
+	}
+
+	return resp, DirectoryServicePrincipalInfo(resp), nil
+}
+
+func (c Directory) GetConditionalAccessPolicy(
+	ctx context.Context,
+	policyID string,
+	cc CallConfig,
+) (models.ConditionalAccessPolicyable, *details.DirectoryInfo, error) {
+	config := &identity.ConditionalAccessPoliciesConditionalAccessPolicyItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &identity.ConditionalAccessPoliciesConditionalAccessPolicyItemRequestBuilderGetQueryParameters{},
+	}
+
+	if len(cc.Select) > 0 {
+		config.QueryParameters.Select = cc.Select
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Identity().
+		ConditionalAccess().
+		Policies().
+		ByConditionalAccessPolicyId(policyID).
+		Get(ctx, config)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting conditional access policy")
+	}
+
+	return resp, DirectoryConditionalAccessPolicyInfo(resp), nil
+}
+
+// GetAdministrativeUnit retrieves the administrative unit along with
+// its members.
+func (c Directory) GetAdministrativeUnit(
+	ctx context.Context,
+	unitID string,
+	cc CallConfig,
+) (models.AdministrativeUnitable, *details.DirectoryInfo, error) {
+	config := &directory.AdministrativeUnitsAdministrativeUnitItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &directory.AdministrativeUnitsAdministrativeUnitItemRequestBuilderGetQueryParameters{},
+	}
+
+	if len(cc.Select) > 0 {
+		config.QueryParameters.Select = cc.Select
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Directory().
+		AdministrativeUnits().
+		ByAdministrativeUnitId(unitID).
+		Get(ctx, config)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting administrative unit")
+	}
+
+	members, err := c.GetAdministrativeUnitMembers(ctx, unitID)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting administrative unit members")
+	}
+
+	resp.SetMembers(members)
+
+	return resp, DirectoryAdminUnitInfo(resp), nil
+}
+
+// ---------------------------------------------------------------------------
+// Restore
+// ---------------------------------------------------------------------------
+
+// RestoreDeletedGroup restores a soft-deleted group from the directory's
+// deleted items.  Returns core.ErrNotFound if the group is no longer
+// available for restoration.
+func (c Directory) RestoreDeletedGroup(
+	ctx context.Context,
+	groupID string,
+) error {
+	_, err := c.Stable.
+		Client().
+		Directory().
+		DeletedItems().
+		ByDirectoryObjectId(groupID).
+		Restore().
+		Post(ctx, nil)
+
+	return clues.Wrap(err, "restoring deleted group").OrNil()
+}
+
+// PostGroup creates a new group using the restorable attributes of the
+// provided group.  Membership is not included.
+func (c Directory) PostGroup(
+	ctx context.Context,
+	group models.Groupable,
+) (models.Groupable, error) {
+	body := restorableGroupAttributes(group)
+	body.SetMailEnabled(ptr.To(ptr.Val(group.GetMailEnabled())))
+	body.SetSecurityEnabled(ptr.To(ptr.Val(group.GetSecurityEnabled())))
+	body.SetGroupTypes(group.GetGroupTypes())
+	body.SetVisibility(group.GetVisibility())
+
+	resp, err := c.Stable.
+		Client().
+		Groups().
+		Post(ctx, body, nil)
+
+	return resp, clues.Wrap(err, "creating group").OrNil()
+}
+
+// PatchGroup updates the group's attributes to match the provided group.
+func (c Directory) PatchGroup(
+	ctx context.Context,
+	groupID string,
+	group models.Groupable,
+) error {
+	_, err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Patch(ctx, restorableGroupAttributes(group), nil)
+
+	return clues.Wrap(err, "updating group attributes").OrNil()
+}
+
+// PostGroupMember adds the directory object as a member of the group.
+func (c Directory) PostGroupMember(ctx context.Context, groupID, memberID string) error {
+	return c.Groups().PostMember(ctx, groupID, memberID)
+}
+
+// PostGroupOwner adds the directory object as an owner of the group.
+func (c Directory) PostGroupOwner(ctx context.Context, groupID, ownerID string) error {
+	return c.Groups().PostOwner(ctx, groupID, ownerID)
+}
+
+// ---------------------------------------------------------------------------
+// Serialization
+// ---------------------------------------------------------------------------
+
+// BytesToGroupable deserializes the bytes into a group.
+func BytesToGroupable(body []byte) (models.Groupable, error) {
+	v, err := CreateFromBytes(body, models.CreateGroupFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Wrap(err, "deserializing bytes to group")
+	}
+
+	return v.(models.Groupable), nil
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// restorableGroupAttributes produces a group containing only the
+// attributes that can be written back to an existing group.
+func restorableGroupAttributes(group models.Groupable) models.Groupable {
+	body := models.NewGroup()
+	body.SetDisplayName(group.GetDisplayName())
+	body.SetMailNickname(group.GetMailNickname())
+
+	if len(ptr.Val(group.GetDescription())) > 0 {
+		body.SetDescription(group.GetDescription())
+	}
+
+	return body
+}
+
+func usersToDirectoryObjects(us []models.Userable) []models.DirectoryObjectable {
+	dos := make([]models.DirectoryObjectable, 0, len(us))
+
+	for _, u := range us {
+		dos = append(dos, u)
+	}
+
+	return dos
+}
+
+// Most directory objects don't track a last modified time.  Their info
+// uses the time of the backup instead, which the collection replaces
+// with the mod time reported to kopia.
+
+func DirectoryUserInfo(u models.Userable) *details.DirectoryInfo {
+	return &details.DirectoryInfo{
+		ItemType: details.DirectoryUser,
+		Modified: time.Now(),
+		Object: details.DirectoryObjectInfo{
+			CreatedAt:   ptr.Val(u.GetCreatedDateTime()),
+			DisplayName: ptr.Val(u.GetDisplayName()),
+			Identifier:  ptr.Val(u.GetUserPrincipalName()),
+		},
+	}
+}
+
+func DirectoryGroupInfo(g models.Groupable) *details.DirectoryInfo {
+	return &details.DirectoryInfo{
+		ItemType: details.DirectoryGroup,
+		Modified: time.Now(),
+		Object: details.DirectoryObjectInfo{
+			CreatedAt:   ptr.Val(g.GetCreatedDateTime()),
+			DisplayName: ptr.Val(g.GetDisplayName()),
+			Identifier:  ptr.Val(g.GetMailNickname()),
+			MemberCount: len(g.GetMembers()),
+		},
+	}
+}
+
+func DirectoryApplicationInfo(a models.Applicationable) *details.DirectoryInfo {
+	return &details.DirectoryInfo{
+		ItemType: details.DirectoryApplication,
+		Modified: time.Now(),
+		Object: details.DirectoryObjectInfo{
+			CreatedAt:   ptr.Val(a.GetCreatedDateTime()),
+			DisplayName: ptr.Val(a.GetDisplayName()),
+			Identifier:  ptr.Val(a.GetAppId()),
+		},
+	}
+}
+
+func DirectoryServicePrincipalInfo(sp models.ServicePrincipalable) *details.DirectoryInfo {
+	return &details.DirectoryInfo{
+		ItemType: details.DirectoryServicePrincipal,
+		Modified: time.Now(),
+		Object: details.DirectoryObjectInfo{
+			DisplayName: ptr.Val(sp.GetDisplayName()),
+			Identifier:  ptr.Val(sp.GetAppId()),
+		},
+	}
+}
+
+func DirectoryConditionalAccessPolicyInfo(p models.ConditionalAccessPolicyable) *details.DirectoryInfo {
+	var state string
+
+	if p.GetState() != nil {
+		state = p.GetState().String()
+	}
+
+	modified := p.GetModifiedDateTime()
+	if modified == nil {
+		modified = p.GetCreatedDateTime()
+	}
+
+	return &details.DirectoryInfo{
+		ItemType: details.DirectoryConditionalAccessPolicy,
+		Modified: ptr.OrNow(modified),
+		Object: details.DirectoryObjectInfo{
+			CreatedAt:   ptr.Val(p.GetCreatedDateTime()),
+			DisplayName: ptr.Val(p.GetDisplayName()),
+			Identifier:  state,
+		},
+	}
+}
+
+func DirectoryAdminUnitInfo(au models.AdministrativeUnitable) *details.DirectoryInfo {
+	return &details.DirectoryInfo{
+		ItemType: details.DirectoryAdminUnit,
+		Modified: time.Now(),
+		Object: details.DirectoryObjectInfo{
+			DisplayName: ptr.Val(au.GetDisplayName()),
+			MemberCount: len(au.GetMembers()),
+		},
+	}
+}
@@ -0,0 +1,684 @@
+package api
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/applications"
+	"github.com/microsoftgraph/msgraph-sdk-go/directory"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/identity"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/serviceprincipals"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// Directory objects don't expose a last modified time.  Delta queries
+// identify changed objects by returning them again, so the enumeration
+// only needs the id (and the @removed annotation, which is always present).
+
+// ---------------------------------------------------------------------------
+// users
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Userable] = &directoryUsersPageCtrl{}
+
+type directoryUsersPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.UsersRequestBuilder
+	options *users.UsersRequestBuilderGetRequestConfiguration
+}
+
+func (p *directoryUsersPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewUsersRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *directoryUsersPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Userable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *directoryUsersPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewUsersPager(selectProps ...string) *directoryUsersPageCtrl {
+	options := &users.UsersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.UsersRequestBuilderGetQueryParameters{},
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	return &directoryUsersPageCtrl{
+		gs:      c.Stable,
+		builder: c.Stable.Client().Users(),
+		options: options,
+	}
+}
+
+var _ pagers.DeltaHandler[models.Userable] = &directoryUsersDeltaPager{}
+
+type directoryUsersDeltaPager struct {
+	gs      graph.Servicer
+	builder *users.DeltaRequestBuilder
+	options *users.DeltaRequestBuilderGetRequestConfiguration
+}
+
+func (c Directory) NewUsersDeltaPager(
+	prevDeltaLink string,
+	selectProps ...string,
+) *directoryUsersDeltaPager {
+	options := &users.DeltaRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.DeltaRequestBuilderGetQueryParameters{},
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	builder := c.Stable.Client().Users().Delta()
+	if len(prevDeltaLink) > 0 {
+		builder = users.NewDeltaRequestBuilder(prevDeltaLink, c.Stable.Adapter())
+	}
+
+	return &directoryUsersDeltaPager{c.Stable, builder, options}
+}
+
+func (p *directoryUsersDeltaPager) GetPage(
+	ctx context.Context,
+) (pagers.DeltaLinkValuer[models.Userable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *directoryUsersDeltaPager) SetNextLink(nextLink string) {
+	p.builder = users.NewDeltaRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *directoryUsersDeltaPager) Reset(context.Context) {
+	p.builder = p.gs.Client().Users().Delta()
+}
+
+func (p *directoryUsersDeltaPager) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) GetAddedAndRemovedUserIDs(
+	ctx context.Context,
+	prevDeltaLink string,
+	cc CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	ctx = clues.Add(ctx, "data_category", path.DirectoryUsersCategory)
+
+	return pagers.GetAddedAndRemovedItemIDs[models.Userable](
+		ctx,
+		c.NewUsersPager(idAnd()...),
+		c.NewUsersDeltaPager(prevDeltaLink, idAnd()...),
+		prevDeltaLink,
+		cc.CanMakeDeltaQueries,
+		cc.LimitResults,
+		pagers.AddedAndRemovedByAddtlData[models.Userable])
+}
+
+// ---------------------------------------------------------------------------
+// groups
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Groupable] = &directoryGroupsPageCtrl{}
+
+type directoryGroupsPageCtrl struct {
+	gs      graph.Servicer
+	builder *groups.GroupsRequestBuilder
+	options *groups.GroupsRequestBuilderGetRequestConfiguration
+}
+
+func (p *directoryGroupsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = groups.NewGroupsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *directoryGroupsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Groupable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *directoryGroupsPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewGroupsPager(selectProps ...string) *directoryGroupsPageCtrl {
+	options := &groups.GroupsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.GroupsRequestBuilderGetQueryParameters{},
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	return &directoryGroupsPageCtrl{
+		gs:      c.Stable,
+		builder: c.Stable.Client().Groups(),
+		options: options,
+	}
+}
+
+var _ pagers.DeltaHandler[models.Groupable] = &directoryGroupsDeltaPager{}
+
+type directoryGroupsDeltaPager struct {
+	gs      graph.Servicer
+	builder *groups.DeltaRequestBuilder
+	options *groups.DeltaRequestBuilderGetRequestConfiguration
+}
+
+func (c Directory) NewGroupsDeltaPager(
+	prevDeltaLink string,
+	selectProps ...string,
+) *directoryGroupsDeltaPager {
+	options := &groups.DeltaRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.DeltaRequestBuilderGetQueryParameters{},
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	builder := c.Stable.Client().Groups().Delta()
+	if len(prevDeltaLink) > 0 {
+		builder = groups.NewDeltaRequestBuilder(prevDeltaLink, c.Stable.Adapter())
+	}
+
+	return &directoryGroupsDeltaPager{c.Stable, builder, options}
+}
+
+func (p *directoryGroupsDeltaPager) GetPage(
+	ctx context.Context,
+) (pagers.DeltaLinkValuer[models.Groupable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *directoryGroupsDeltaPager) SetNextLink(nextLink string) {
+	p.builder = groups.NewDeltaRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *directoryGroupsDeltaPager) Reset(context.Context) {
+	p.builder = p.gs.Client().Groups().Delta()
+}
+
+func (p *directoryGroupsDeltaPager) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) GetAddedAndRemovedGroupIDs(
+	ctx context.Context,
+	prevDeltaLink string,
+	cc CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	ctx = clues.Add(ctx, "data_category", path.DirectoryGroupsCategory)
+
+	return pagers.GetAddedAndRemovedItemIDs[models.Groupable](
+		ctx,
+		c.NewGroupsPager(idAnd()...),
+		c.NewGroupsDeltaPager(prevDeltaLink, idAnd()...),
+		prevDeltaLink,
+		cc.CanMakeDeltaQueries,
+		cc.LimitResults,
+		pagers.AddedAndRemovedByAddtlData[models.Groupable])
+}
+
+// ---------------------------------------------------------------------------
+// applications
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Applicationable] = &applicationsPageCtrl{}
+
+type applicationsPageCtrl struct {
+	gs      graph.Servicer
+	builder *applications.ApplicationsRequestBuilder
+	options *applications.ApplicationsRequestBuilderGetRequestConfiguration
+}
+
+func (p *applicationsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = applications.NewApplicationsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *applicationsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Applicationable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *applicationsPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewApplicationsPager(selectProps ...string) *applicationsPageCtrl {
+	options := &applications.ApplicationsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &applications.ApplicationsRequestBuilderGetQueryParameters{},
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	return &applicationsPageCtrl{
+		gs:      c.Stable,
+		builder: c.Stable.Client().Applications(),
+		options: options,
+	}
+}
+
+var _ pagers.DeltaHandler[models.Applicationable] = &applicationsDeltaPager{}
+
+type applicationsDeltaPager struct {
+	gs      graph.Servicer
+	builder *applications.DeltaRequestBuilder
+	options *applications.DeltaRequestBuilderGetRequestConfiguration
+}
+
+func (c Directory) NewApplicationsDeltaPager(
+	prevDeltaLink string,
+	selectProps ...string,
+) *applicationsDeltaPager {
+	options := &applications.DeltaRequestBuilderGetRequestConfiguration{
+		QueryParameters: &applications.DeltaRequestBuilderGetQueryParameters{},
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	builder := c.Stable.Client().Applications().Delta()
+	if len(prevDeltaLink) > 0 {
+		builder = applications.NewDeltaRequestBuilder(prevDeltaLink, c.Stable.Adapter())
+	}
+
+	return &applicationsDeltaPager{c.Stable, builder, options}
+}
+
+func (p *applicationsDeltaPager) GetPage(
+	ctx context.Context,
+) (pagers.DeltaLinkValuer[models.Applicationable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *applicationsDeltaPager) SetNextLink(nextLink string) {
+	p.builder = applications.NewDeltaRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *applicationsDeltaPager) Reset(context.Context) {
+	p.builder = p.gs.Client().Applications().Delta()
+}
+
+func (p *applicationsDeltaPager) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) GetAddedAndRemovedApplicationIDs(
+	ctx context.Context,
+	prevDeltaLink string,
+	cc CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	ctx = clues.Add(ctx, "data_category", path.ApplicationsCategory)
+
+	return pagers.GetAddedAndRemovedItemIDs[models.Applicationable](
+		ctx,
+		c.NewApplicationsPager(idAnd()...),
+		c.NewApplicationsDeltaPager(prevDeltaLink, idAnd()...),
+		prevDeltaLink,
+		cc.CanMakeDeltaQueries,
+		cc.LimitResults,
+		pagers.AddedAndRemovedByAddtlData[models.Applicationable])
+}
+
+// ---------------------------------------------------------------------------
+// service principals
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.ServicePrincipalable] = &servicePrincipalsPageCtrl{}
+
+type servicePrincipalsPageCtrl struct {
+	gs      graph.Servicer
+	builder *serviceprincipals.ServicePrincipalsRequestBuilder
+	options *serviceprincipals.ServicePrincipalsRequestBuilderGetRequestConfiguration
+}
+
+func (p *servicePrincipalsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = serviceprincipals.NewServicePrincipalsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *servicePrincipalsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.ServicePrincipalable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *servicePrincipalsPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewServicePrincipalsPager(selectProps ...string) *servicePrincipalsPageCtrl {
+	options := &serviceprincipals.ServicePrincipalsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &serviceprincipals.ServicePrincipalsRequestBuilderGetQueryParameters{},
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	return &servicePrincipalsPageCtrl{
+		gs:      c.Stable,
+		builder: c.Stable.Client().ServicePrincipals(),
+		options: options,
+	}
+}
+
+var _ pagers.DeltaHandler[models.ServicePrincipalable] = &servicePrincipalsDeltaPager{}
+
+type servicePrincipalsDeltaPager struct {
+	gs      graph.Servicer
+	builder *serviceprincipals.DeltaRequestBuilder
+	options *serviceprincipals.DeltaRequestBuilderGetRequestConfiguration
+}
+
+func (c Directory) NewServicePrincipalsDeltaPager(
+	prevDeltaLink string,
+	selectProps ...string,
+) *servicePrincipalsDeltaPager {
+	options := &serviceprincipals.DeltaRequestBuilderGetRequestConfiguration{
+		QueryParameters: &serviceprincipals.DeltaRequestBuilderGetQueryParameters{},
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	builder := c.Stable.Client().ServicePrincipals().Delta()
+	if len(prevDeltaLink) > 0 {
+		builder = serviceprincipals.NewDeltaRequestBuilder(prevDeltaLink, c.Stable.Adapter())
+	}
+
+	return &servicePrincipalsDeltaPager{c.Stable, builder, options}
+}
+
+func (p *servicePrincipalsDeltaPager) GetPage(
+	ctx context.Context,
+) (pagers.DeltaLinkValuer[models.ServicePrincipalable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *servicePrincipalsDeltaPager) SetNextLink(nextLink string) {
+	p.builder = serviceprincipals.NewDeltaRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *servicePrincipalsDeltaPager) Reset(context.Context) {
+	p.builder = p.gs.Client().ServicePrincipals().Delta()
+}
+
+func (p *servicePrincipalsDeltaPager) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) GetAddedAndRemovedServicePrincipalIDs(
+	ctx context.Context,
+	prevDeltaLink string,
+	cc CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	ctx = clues.Add(ctx, "data_category", path.ServicePrincipalsCategory)
+
+	return pagers.GetAddedAndRemovedItemIDs[models.ServicePrincipalable](
+		ctx,
+		c.NewServicePrincipalsPager(idAnd()...),
+		c.NewServicePrincipalsDeltaPager(prevDeltaLink, idAnd()...),
+		prevDeltaLink,
+		cc.CanMakeDeltaQueries,
+		cc.LimitResults,
+		pagers.AddedAndRemovedByAddtlData[models.ServicePrincipalable])
+}
+
+// ---------------------------------------------------------------------------
+// conditional access policies
+// ---------------------------------------------------------------------------
+
+// delta queries are not supported
+var _ pagers.NonDeltaHandler[models.ConditionalAccessPolicyable] = &conditionalAccessPoliciesPageCtrl{}
+
+type conditionalAccessPoliciesPageCtrl struct {
+	gs      graph.Servicer
+	builder *identity.ConditionalAccessPoliciesRequestBuilder
+	options *identity.ConditionalAccessPoliciesRequestBuilderGetRequestConfiguration
+}
+
+func (p *conditionalAccessPoliciesPageCtrl) SetNextLink(nextLink string) {
+	p.builder = identity.NewConditionalAccessPoliciesRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *conditionalAccessPoliciesPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.ConditionalAccessPolicyable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *conditionalAccessPoliciesPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewConditionalAccessPoliciesPager(
+	selectProps ...string,
+) *conditionalAccessPoliciesPageCtrl {
+	options := &identity.ConditionalAccessPoliciesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &identity.ConditionalAccessPoliciesRequestBuilderGetQueryParameters{},
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	return &conditionalAccessPoliciesPageCtrl{
+		gs: c.Stable,
+		builder: c.Stable.
+			Client().
+			Identity().
+			ConditionalAccess().
+			Policies(),
+		options: options,
+	}
+}
+
+// GetConditionalAccessPolicyIDs enumerates all policies in the tenant.
+// Delta queries are not supported, so every policy is returned as added.
+func (c Directory) GetConditionalAccessPolicyIDs(
+	ctx context.Context,
+	cc CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	ctx = clues.Add(ctx, "data_category", path.ConditionalAccessCategory)
+
+	return pagers.GetAddedAndRemovedItemIDs[models.ConditionalAccessPolicyable](
+		ctx,
+		c.NewConditionalAccessPoliciesPager(idAnd()...),
+		nil,
+		"",
+		false, // delta queries are not supported
+		cc.LimitResults,
+		pagers.AddedAndRemovedByAddtlData[models.ConditionalAccessPolicyable])
+}
+
+// ---------------------------------------------------------------------------
+// administrative units
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.AdministrativeUnitable] = &adminUnitsPageCtrl{}
+
+type adminUnitsPageCtrl struct {
+	gs      graph.Servicer
+	builder *directory.AdministrativeUnitsRequestBuilder
+	options *directory.AdministrativeUnitsRequestBuilderGetRequestConfiguration
+}
+
+func (p *adminUnitsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = directory.NewAdministrativeUnitsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *adminUnitsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.AdministrativeUnitable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *adminUnitsPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewAdministrativeUnitsPager(selectProps ...string) *adminUnitsPageCtrl {
+	options := &directory.AdministrativeUnitsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &directory.AdministrativeUnitsRequestBuilderGetQueryParameters{},
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	return &adminUnitsPageCtrl{
+		gs:      c.Stable,
+		builder: c.Stable.Client().Directory().AdministrativeUnits(),
+		options: options,
+	}
+}
+
+var _ pagers.DeltaHandler[models.AdministrativeUnitable] = &adminUnitsDeltaPager{}
+
+type adminUnitsDeltaPager struct {
+	gs      graph.Servicer
+	builder *directory.AdministrativeUnitsDeltaRequestBuilder
+	options *directory.AdministrativeUnitsDeltaRequestBuilderGetRequestConfiguration
+}
+
+func (c Directory) NewAdministrativeUnitsDeltaPager(
+	prevDeltaLink string,
+	selectProps ...string,
+) *adminUnitsDeltaPager {
+	options := &directory.AdministrativeUnitsDeltaRequestBuilderGetRequestConfiguration{
+		QueryParameters: &directory.AdministrativeUnitsDeltaRequestBuilderGetQueryParameters{},
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	builder := c.Stable.Client().Directory().AdministrativeUnits().Delta()
+	if len(prevDeltaLink) > 0 {
+		builder = directory.NewAdministrativeUnitsDeltaRequestBuilder(prevDeltaLink, c.Stable.Adapter())
+	}
+
+	return &adminUnitsDeltaPager{c.Stable, builder, options}
+}
+
+func (p *adminUnitsDeltaPager) GetPage(
+	ctx context.Context,
+) (pagers.DeltaLinkValuer[models.AdministrativeUnitable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *adminUnitsDeltaPager) SetNextLink(nextLink string) {
+	p.builder = directory.NewAdministrativeUnitsDeltaRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *adminUnitsDeltaPager) Reset(context.Context) {
+	p.builder = p.gs.Client().Directory().AdministrativeUnits().Delta()
+}
+
+func (p *adminUnitsDeltaPager) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) GetAddedAndRemovedAdministrativeUnitIDs(
+	ctx context.Context,
+	prevDeltaLink string,
+	cc CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	ctx = clues.Add(ctx, "data_category", path.AdminUnitsCategory)
+
+	return pagers.GetAddedAndRemovedItemIDs[models.AdministrativeUnitable](
+		ctx,
+		c.NewAdministrativeUnitsPager(idAnd()...),
+		c.NewAdministrativeUnitsDeltaPager(prevDeltaLink, idAnd()...),
+		prevDeltaLink,
+		cc.CanMakeDeltaQueries,
+		cc.LimitResults,
+		pagers.AddedAndRemovedByAddtlData[models.AdministrativeUnitable])
+}
+
+// ---------------------------------------------------------------------------
+// administrative unit members
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.DirectoryObjectable] = &adminUnitMembersPageCtrl{}
+
+type adminUnitMembersPageCtrl struct {
+	gs      graph.Servicer
+	builder *directory.AdministrativeUnitsItemMembersRequestBuilder
+	options *directory.AdministrativeUnitsItemMembersRequestBuilderGetRequestConfiguration
+}
+
+func (p *adminUnitMembersPageCtrl) SetNextLink(nextLink string) {
+	p.builder = directory.NewAdministrativeUnitsItemMembersRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *adminUnitMembersPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.DirectoryObjectable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *adminUnitMembersPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Directory) NewAdministrativeUnitMembersPager(unitID string) *adminUnitMembersPageCtrl {
+	builder := c.Stable.
+		Client().
+		Directory().
+		AdministrativeUnits().
+		ByAdministrativeUnitId(unitID).
+		Members()
+
+	options := &directory.AdministrativeUnitsItemMembersRequestBuilderGetRequestConfiguration{
+		QueryParameters: &directory.AdministrativeUnitsItemMembersRequestBuilderGetQueryParameters{
+			Select: idAnd(displayName),
+		},
+	}
+
+	return &adminUnitMembersPageCtrl{
+		gs:      c.Stable,
+		builder: builder,
+		options: options,
+	}
+}
+
+// GetAdministrativeUnitMembers fetches all members of the administrative unit.
+func (c Directory) GetAdministrativeUnitMembers(
+	ctx context.Context,
+	unitID string,
+) ([]models.DirectoryObjectable, error) {
+	pager := c.NewAdministrativeUnitMembersPager(unitID)
+	items, err := pagers.BatchEnumerateItems[models.DirectoryObjectable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
@@ -0,0 +1,202 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/h2non/gock"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/tester/tconfig"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+type DirectoryUnitSuite struct {
+	tester.Suite
+}
+
+func TestDirectoryUnitSuite(t *testing.T) {
+	suite.Run(t, &DirectoryUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *DirectoryUnitSuite) TestGetIDAndName() {
+	table := []struct {
+		name       string
+		tenantID   string
+		expectID   string
+		expectName string
+		expectErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "found",
+			tenantID:   "TID",
+			expectID:   "tid",
+			expectName: "contoso",
+			expectErr:  assert.NoError,
+		},
+		{
+			name:     "not found",
+			tenantID: "other",
+			expectErr: func(t assert.TestingT, err error, i ...any) bool {
+				return assert.ErrorIs(t, err, core.ErrNotFound, i...)
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			a := tconfig.NewFakeM365Account(t)
+			creds, err := a.M365Config()
+			require.NoError(t, err, clues.ToCore(err))
+
+			client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+			require.NoError(t, err, clues.ToCore(err))
+
+			t.Cleanup(gock.Off)
+
+			interceptV1Path("organization").
+				Reply(200).
+				JSON(map[string]any{
+					"value": []map[string]any{
+						{"id": "tid", "displayName": "contoso"},
+					},
+				})
+
+			id, name, err := client.Directory().GetIDAndName(ctx, test.tenantID, CallConfig{})
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectID, id)
+			assert.Equal(t, test.expectName, name)
+		})
+	}
+}
+
+func (suite *DirectoryUnitSuite) TestGetAdministrativeUnit() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := tconfig.NewFakeM365Account(t)
+	creds, err := a.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+	require.NoError(t, err, clues.ToCore(err))
+
+	t.Cleanup(gock.Off)
+
+	// gock matches paths by regex, so the more specific paths are
+	// registered first.
+	interceptV1Path("directory", "administrativeUnits", "auid", "members").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{
+				{"@odata.type": "#microsoft.graph.user", "id": "uid"},
+				{"@odata.type": "#microsoft.graph.group", "id": "gid"},
+			},
+		})
+
+	interceptV1Path("directory", "administrativeUnits", "auid").
+		Reply(200).
+		JSON(map[string]any{
+			"id":          "auid",
+			"displayName": "west region",
+			"visibility":  "HiddenMembership",
+		})
+
+	au, info, err := client.Directory().GetAdministrativeUnit(ctx, "auid", CallConfig{})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.False(t, gock.HasUnmatchedRequest(), "unmatched graph calls")
+
+	assert.Equal(t, "west region", ptr.Val(au.GetDisplayName()))
+	assert.Len(t, au.GetMembers(), 2)
+
+	assert.Equal(t, details.DirectoryAdminUnit, info.ItemType)
+	assert.Equal(t, "west region", info.Object.DisplayName)
+	assert.Equal(t, 2, info.Object.MemberCount)
+}
+
+func (suite *DirectoryUnitSuite) TestDirectoryConditionalAccessPolicyInfo() {
+	var (
+		created  = time.Now().Add(-time.Hour).UTC()
+		modified = time.Now().UTC()
+		enabled  = models.ENABLED_CONDITIONALACCESSPOLICYSTATE
+	)
+
+	table := []struct {
+		name           string
+		policy         func() models.ConditionalAccessPolicyable
+		expectModified time.Time
+		expectState    string
+	}{
+		{
+			name: "modified",
+			policy: func() models.ConditionalAccessPolicyable {
+				p := models.NewConditionalAccessPolicy()
+				p.SetDisplayName(ptr.To("require mfa"))
+				p.SetCreatedDateTime(ptr.To(created))
+				p.SetModifiedDateTime(ptr.To(modified))
+				p.SetState(&enabled)
+
+				return p
+			},
+			expectModified: modified,
+			expectState:    enabled.String(),
+		},
+		{
+			name: "never modified",
+			policy: func() models.ConditionalAccessPolicyable {
+				p := models.NewConditionalAccessPolicy()
+				p.SetDisplayName(ptr.To("require mfa"))
+				p.SetCreatedDateTime(ptr.To(created))
+
+				return p
+			},
+			expectModified: created,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			info := DirectoryConditionalAccessPolicyInfo(test.policy())
+
+			assert.Equal(t, details.DirectoryConditionalAccessPolicy, info.ItemType)
+			assert.Equal(t, "require mfa", info.Object.DisplayName)
+			assert.Equal(t, created, info.Object.CreatedAt)
+			assert.Equal(t, test.expectModified, info.Modified)
+			assert.Equal(t, test.expectState, info.Object.Identifier)
+		})
+	}
+}
+
+func (suite *DirectoryUnitSuite) TestRestorableGroupAttributes() {
+	t := suite.T()
+
+	group := models.NewGroup()
+	group.SetId(ptr.To("gid"))
+	group.SetDisplayName(ptr.To("Marketing"))
+	group.SetMailNickname(ptr.To("marketing"))
+	group.SetMail(ptr.To("marketing@contoso.com"))
+
+	result := restorableGroupAttributes(group)
+
+	assert.Nil(t, result.GetId(), "ids are read only")
+	assert.Nil(t, result.GetMail(), "mail addresses are read only")
+	assert.Nil(t, result.GetDescription(), "empty values aren't restored")
+	assert.Equal(t, "Marketing", ptr.Val(result.GetDisplayName()))
+	assert.Equal(t, "marketing", ptr.Val(result.GetMailNickname()))
+}
@@ -121,6 +121,60 @@ func (c Drives) GetItemIDsInContainer(
 	return m, nil
 }
 
+// ---------------------------------------------------------------------------
+// item versions pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.DriveItemVersionable] = &driveItemVersionsPageCtrl{}
+
+type driveItemVersionsPageCtrl struct {
+	gs      graph.Servicer
+	builder *drives.ItemItemsItemVersionsRequestBuilder
+}
+
+func (c Drives) NewDriveItemVersionsPager(
+	driveID, itemID string,
+) pagers.NonDeltaHandler[models.DriveItemVersionable] {
+	builder := c.Stable.
+		Client().
+		Drives().
+		ByDriveId(driveID).
+		Items().
+		ByDriveItemId(itemID).
+		Versions()
+
+	return &driveItemVersionsPageCtrl{c.Stable, builder}
+}
+
+func (p *driveItemVersionsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.DriveItemVersionable], error) {
+	page, err := p.builder.Get(ctx, nil)
+	return page, clues.Stack(err).OrNil()
+}
+
+func (p *driveItemVersionsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = drives.NewItemItemsItemVersionsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *driveItemVersionsPageCtrl) ValidModTimes() bool {
+	return true
+}
+
+// GetItemVersions retrieves the version history of the drive item.  Graph
+// lists versions from newest to oldest, starting with the current version.
+func (c Drives) GetItemVersions(
+	ctx context.Context,
+	driveID, itemID string,
+) ([]models.DriveItemVersionable, error) {
+	ctx = clues.Add(ctx, "item_id", itemID)
+	pager := c.NewDriveItemVersionsPager(driveID, itemID)
+
+	versions, err := pagers.BatchEnumerateItems(ctx, pager)
+
+	return versions, clues.Wrap(err, "enumerating drive item versions").OrNil()
+}
+
 // ---------------------------------------------------------------------------
 // delta item pager
 // ---------------------------------------------------------------------------
@@ -231,13 +231,20 @@ func (c Events) GetItem(
 	return event, EventInfo(event), nil
 }
 
+type eventAttachmentGetter interface {
+	GetAttachments(
+		ctx context.Context,
+		resourceID, itemID string,
+	) ([]models.Attachmentable, error)
+}
+
 // fixupExceptionOccurrences gets attachments and converts the data
 // into a format that gets serialized when storing to kopia
 func fixupExceptionOccurrences(
 	ctx context.Context,
-	client Events,
+	client eventAttachmentGetter,
 	event models.Eventable,
-	userID string,
+	resourceID string,
 ) error {
 	// Fetch attachments for exceptions
 	exceptionOccurrences := event.GetAdditionalData()["exceptionOccurrences"]
@@ -268,7 +275,7 @@ func fixupExceptionOccurrences(
 
 		var attachments []models.Attachmentable
 		if ptr.Val(event.GetHasAttachments()) || HasAttachments(event.GetBody()) {
-			attachments, err = client.GetAttachments(ctx, userID, ptr.Val(evt.GetId()))
+			attachments, err = client.GetAttachments(ctx, resourceID, ptr.Val(evt.GetId()))
 			if err != nil {
 				return clues.Wrap(err, "getting event instance attachments").
 					With("event_instance_id", ptr.Val(evt.GetId()))
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/alcionai/clues"
@@ -14,6 +15,13 @@ import (
 )
 
 func GetAuth(tenant, client, secret string) (*kauth.AzureIdentityAuthenticationProvider, error) {
+	return getAuthWithScopes(tenant, client, secret, []string{"https://graph.microsoft.com/.default"})
+}
+
+func getAuthWithScopes(
+	tenant, client, secret string,
+	scopes []string,
+) (*kauth.AzureIdentityAuthenticationProvider, error) {
 	// Client Provider: Uses Secret for access to tenant-level data
 	cred, err := azidentity.NewClientSecretCredential(tenant, client, secret, nil)
 	if err != nil {
@@ -22,7 +30,7 @@ func GetAuth(tenant, client, secret string) (*kauth.AzureIdentityAuthenticationP
 
 	auth, err := kauth.NewAzureIdentityAuthenticationProviderWithScopes(
 		cred,
-		[]string{"https://graph.microsoft.com/.default"})
+		scopes)
 	if err != nil {
 		return nil, clues.Wrap(err, "creating azure authentication")
 	}
@@ -92,3 +100,65 @@ func (aa azureAuth) addAuthToHeaders(
 
 	return clues.WrapWC(ctx, err, "authorizing request").OrNil()
 }
+
+// ---------------------------------------------------------------------------
+// SharePoint Authorizer
+// ---------------------------------------------------------------------------
+
+// sharePointAuth authorizes calls to the SharePoint REST api, which covers
+// a few features that graph doesn't expose.  Tokens are scoped to the host
+// of the requested url, since each tenant's sites are served from the
+// tenant's own SharePoint domain.
+type sharePointAuth struct {
+	creds account.M365Config
+
+	mu    sync.Mutex
+	hosts map[string]authenticateRequester
+}
+
+func NewSharePointAuth(creds account.M365Config) *sharePointAuth {
+	return &sharePointAuth{
+		creds: creds,
+		hosts: map[string]authenticateRequester{},
+	}
+}
+
+func (sa *sharePointAuth) authForHost(scheme, host string) (authenticateRequester, error) {
+	sa.mu.Lock()
+	defer sa.mu.Unlock()
+
+	if auth, ok := sa.hosts[host]; ok {
+		return auth, nil
+	}
+
+	auth, err := getAuthWithScopes(
+		sa.creds.AzureTenantID,
+		sa.creds.AzureClientID,
+		sa.creds.AzureClientSecret,
+		[]string{scheme + "://" + host + "/.default"})
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	sa.hosts[host] = auth
+
+	return auth, nil
+}
+
+func (sa *sharePointAuth) addAuthToHeaders(
+	ctx context.Context,
+	urlStr string,
+	headers http.Header,
+) error {
+	uri, err := url.Parse(urlStr)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "parsing url").OrNil()
+	}
+
+	auth, err := sa.authForHost(uri.Scheme, uri.Host)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "creating sharepoint authorizer")
+	}
+
+	return azureAuth{auth}.addAuthToHeaders(ctx, urlStr, headers)
+}
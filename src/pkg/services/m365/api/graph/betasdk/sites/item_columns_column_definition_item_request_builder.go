@@ -0,0 +1,95 @@
+package sites
+
+import (
+	"context"
+
+	i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f "github.com/microsoft/kiota-abstractions-go"
+	msmodel "github.com/microsoftgraph/msgraph-sdk-go/models"
+	i7ad325c11fbf3db4d761c429267362d8b24daa1eda0081f914ebc3cdc85181a0 "github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+)
+
+// ItemColumnsColumnDefinitionItemRequestBuilder provides operations to manage the columns property of the microsoft.graph.site entity.
+type ItemColumnsColumnDefinitionItemRequestBuilder struct {
+	// Path parameters for the request
+	pathParameters map[string]string
+	// The request adapter to use to execute the requests.
+	requestAdapter i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestAdapter
+	// Url template to use to build the URL for the current request builder
+	urlTemplate string
+}
+
+// ItemColumnsColumnDefinitionItemRequestBuilderPatchRequestConfiguration configuration for the request such as headers, query parameters, and middleware options.
+//
+//nolint:lll
+type ItemColumnsColumnDefinitionItemRequestBuilderPatchRequestConfiguration struct {
+	// Request headers
+	Headers *i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestHeaders
+	// Request options
+	Options []i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestOption
+}
+
+// NewItemColumnsColumnDefinitionItemRequestBuilderInternal instantiates a new ItemColumnsColumnDefinitionItemRequestBuilder and sets the default values.
+//
+//nolint:lll,wsl
+func NewItemColumnsColumnDefinitionItemRequestBuilderInternal(pathParameters map[string]string, requestAdapter i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestAdapter) *ItemColumnsColumnDefinitionItemRequestBuilder {
+	m := &ItemColumnsColumnDefinitionItemRequestBuilder{}
+	m.urlTemplate = "{+baseurl}/sites/{site%2Did}/columns/{columnDefinition%2Did}"
+	urlTplParams := make(map[string]string)
+	for idx, item := range pathParameters {
+		urlTplParams[idx] = item
+	}
+	m.pathParameters = urlTplParams
+	m.requestAdapter = requestAdapter
+	return m
+}
+
+// NewItemColumnsColumnDefinitionItemRequestBuilder instantiates a new ItemColumnsColumnDefinitionItemRequestBuilder and sets the default values.
+//
+//nolint:lll,revive,wsl
+func NewItemColumnsColumnDefinitionItemRequestBuilder(rawUrl string, requestAdapter i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestAdapter) *ItemColumnsColumnDefinitionItemRequestBuilder {
+	urlParams := make(map[string]string)
+	urlParams["request-raw-url"] = rawUrl
+	return NewItemColumnsColumnDefinitionItemRequestBuilderInternal(urlParams, requestAdapter)
+}
+
+// CreatePatchRequestInformation update a [site][] [column][columnDefinition].
+//
+//nolint:lll,errcheck,wsl
+func (m *ItemColumnsColumnDefinitionItemRequestBuilder) CreatePatchRequestInformation(ctx context.Context, body msmodel.ColumnDefinitionable, requestConfiguration *ItemColumnsColumnDefinitionItemRequestBuilderPatchRequestConfiguration) (*i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestInformation, error) {
+	requestInfo := i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.NewRequestInformation()
+	requestInfo.UrlTemplate = m.urlTemplate
+	requestInfo.PathParameters = m.pathParameters
+	requestInfo.Method = i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.PATCH
+	requestInfo.Headers.Add("Accept", "application/json")
+	requestInfo.SetContentFromParsable(ctx, m.requestAdapter, "application/json", body)
+	if requestConfiguration != nil {
+		requestInfo.Headers.AddAll(requestConfiguration.Headers)
+		requestInfo.AddRequestOptions(requestConfiguration.Options)
+	}
+	return requestInfo, nil
+}
+
+// Patch update a [site][] [column][columnDefinition].
+// [Find more info here]
+//
+// [Find more info here]: https://docs.microsoft.com/graph/api/columndefinition-update?view=graph-rest-beta
+//
+//nolint:wsl,revive,lll
+func (m *ItemColumnsColumnDefinitionItemRequestBuilder) Patch(ctx context.Context, body msmodel.ColumnDefinitionable, requestConfiguration *ItemColumnsColumnDefinitionItemRequestBuilderPatchRequestConfiguration) (msmodel.ColumnDefinitionable, error) {
+	requestInfo, err := m.CreatePatchRequestInformation(ctx, body, requestConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	errorMapping := i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.ErrorMappings{
+		"4XX": i7ad325c11fbf3db4d761c429267362d8b24daa1eda0081f914ebc3cdc85181a0.CreateODataErrorFromDiscriminatorValue,
+		"5XX": i7ad325c11fbf3db4d761c429267362d8b24daa1eda0081f914ebc3cdc85181a0.CreateODataErrorFromDiscriminatorValue,
+	}
+	res, err := m.requestAdapter.Send(ctx, requestInfo, msmodel.CreateColumnDefinitionFromDiscriminatorValue, errorMapping)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+	return res.(msmodel.ColumnDefinitionable), nil
+}
@@ -0,0 +1,95 @@
+package sites
+
+import (
+	"context"
+
+	i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f "github.com/microsoft/kiota-abstractions-go"
+	msmodel "github.com/microsoftgraph/msgraph-sdk-go/models"
+	i7ad325c11fbf3db4d761c429267362d8b24daa1eda0081f914ebc3cdc85181a0 "github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+)
+
+// ItemContentTypesContentTypeItemRequestBuilder provides operations to manage the contentTypes property of the microsoft.graph.site entity.
+type ItemContentTypesContentTypeItemRequestBuilder struct {
+	// Path parameters for the request
+	pathParameters map[string]string
+	// The request adapter to use to execute the requests.
+	requestAdapter i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestAdapter
+	// Url template to use to build the URL for the current request builder
+	urlTemplate string
+}
+
+// ItemContentTypesContentTypeItemRequestBuilderPatchRequestConfiguration configuration for the request such as headers, query parameters, and middleware options.
+//
+//nolint:lll
+type ItemContentTypesContentTypeItemRequestBuilderPatchRequestConfiguration struct {
+	// Request headers
+	Headers *i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestHeaders
+	// Request options
+	Options []i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestOption
+}
+
+// NewItemContentTypesContentTypeItemRequestBuilderInternal instantiates a new ItemContentTypesContentTypeItemRequestBuilder and sets the default values.
+//
+//nolint:lll,wsl
+func NewItemContentTypesContentTypeItemRequestBuilderInternal(pathParameters map[string]string, requestAdapter i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestAdapter) *ItemContentTypesContentTypeItemRequestBuilder {
+	m := &ItemContentTypesContentTypeItemRequestBuilder{}
+	m.urlTemplate = "{+baseurl}/sites/{site%2Did}/contentTypes/{contentType%2Did}"
+	urlTplParams := make(map[string]string)
+	for idx, item := range pathParameters {
+		urlTplParams[idx] = item
+	}
+	m.pathParameters = urlTplParams
+	m.requestAdapter = requestAdapter
+	return m
+}
+
+// NewItemContentTypesContentTypeItemRequestBuilder instantiates a new ItemContentTypesContentTypeItemRequestBuilder and sets the default values.
+//
+//nolint:lll,revive,wsl
+func NewItemContentTypesContentTypeItemRequestBuilder(rawUrl string, requestAdapter i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestAdapter) *ItemContentTypesContentTypeItemRequestBuilder {
+	urlParams := make(map[string]string)
+	urlParams["request-raw-url"] = rawUrl
+	return NewItemContentTypesContentTypeItemRequestBuilderInternal(urlParams, requestAdapter)
+}
+
+// CreatePatchRequestInformation update a [content type][contentType].
+//
+//nolint:lll,errcheck,wsl
+func (m *ItemContentTypesContentTypeItemRequestBuilder) CreatePatchRequestInformation(ctx context.Context, body msmodel.ContentTypeable, requestConfiguration *ItemContentTypesContentTypeItemRequestBuilderPatchRequestConfiguration) (*i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestInformation, error) {
+	requestInfo := i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.NewRequestInformation()
+	requestInfo.UrlTemplate = m.urlTemplate
+	requestInfo.PathParameters = m.pathParameters
+	requestInfo.Method = i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.PATCH
+	requestInfo.Headers.Add("Accept", "application/json")
+	requestInfo.SetContentFromParsable(ctx, m.requestAdapter, "application/json", body)
+	if requestConfiguration != nil {
+		requestInfo.Headers.AddAll(requestConfiguration.Headers)
+		requestInfo.AddRequestOptions(requestConfiguration.Options)
+	}
+	return requestInfo, nil
+}
+
+// Patch update a [content type][contentType].
+// [Find more info here]
+//
+// [Find more info here]: https://docs.microsoft.com/graph/api/contenttype-update?view=graph-rest-beta
+//
+//nolint:wsl,revive,lll
+func (m *ItemContentTypesContentTypeItemRequestBuilder) Patch(ctx context.Context, body msmodel.ContentTypeable, requestConfiguration *ItemContentTypesContentTypeItemRequestBuilderPatchRequestConfiguration) (msmodel.ContentTypeable, error) {
+	requestInfo, err := m.CreatePatchRequestInformation(ctx, body, requestConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	errorMapping := i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.ErrorMappings{
+		"4XX": i7ad325c11fbf3db4d761c429267362d8b24daa1eda0081f914ebc3cdc85181a0.CreateODataErrorFromDiscriminatorValue,
+		"5XX": i7ad325c11fbf3db4d761c429267362d8b24daa1eda0081f914ebc3cdc85181a0.CreateODataErrorFromDiscriminatorValue,
+	}
+	res, err := m.requestAdapter.Send(ctx, requestInfo, msmodel.CreateContentTypeFromDiscriminatorValue, errorMapping)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+	return res.(msmodel.ContentTypeable), nil
+}
@@ -0,0 +1,173 @@
+package sites
+
+import (
+	"context"
+
+	i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f "github.com/microsoft/kiota-abstractions-go"
+	msmodel "github.com/microsoftgraph/msgraph-sdk-go/models"
+	i7ad325c11fbf3db4d761c429267362d8b24daa1eda0081f914ebc3cdc85181a0 "github.com/microsoftgraph/msgraph-sdk-go/models/odataerrors"
+)
+
+// ItemPermissionsRequestBuilder provides operations to manage the permissions property of the microsoft.graph.site entity.
+type ItemPermissionsRequestBuilder struct {
+	// Path parameters for the request
+	pathParameters map[string]string
+	// The request adapter to use to execute the requests.
+	requestAdapter i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestAdapter
+	// Url template to use to build the URL for the current request builder
+	urlTemplate string
+}
+
+// ItemPermissionsRequestBuilderGetQueryParameters get the permission resources from the permissions navigation property on a site.
+//
+//nolint:lll
+type ItemPermissionsRequestBuilderGetQueryParameters struct {
+	// Include count of items
+	Count *bool `uriparametername:"%24count"`
+	// Expand related entities
+	Expand []string `uriparametername:"%24expand"`
+	// Filter items by property values
+	Filter *string `uriparametername:"%24filter"`
+	// Order items by property values
+	Orderby []string `uriparametername:"%24orderby"`
+	// Search items by search phrases
+	Search *string `uriparametername:"%24search"`
+	// Select properties to be returned
+	Select []string `uriparametername:"%24select"`
+	// Skip the first n items
+	Skip *int32 `uriparametername:"%24skip"`
+	// Show only the first n items
+	Top *int32 `uriparametername:"%24top"`
+}
+
+// ItemPermissionsRequestBuilderGetRequestConfiguration configuration for the request such as headers, query parameters, and middleware options.
+//
+//nolint:lll
+type ItemPermissionsRequestBuilderGetRequestConfiguration struct {
+	// Request headers
+	Headers *i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestHeaders
+	// Request options
+	Options []i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestOption
+	// Request query parameters
+	QueryParameters *ItemPermissionsRequestBuilderGetQueryParameters
+}
+
+// ItemPermissionsRequestBuilderPostRequestConfiguration configuration for the request such as headers, query parameters, and middleware options.
+//
+//nolint:lll
+type ItemPermissionsRequestBuilderPostRequestConfiguration struct {
+	// Request headers
+	Headers *i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestHeaders
+	// Request options
+	Options []i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestOption
+}
+
+// NewItemPermissionsRequestBuilderInternal instantiates a new ItemPermissionsRequestBuilder and sets the default values.
+//
+//nolint:lll,wsl
+func NewItemPermissionsRequestBuilderInternal(pathParameters map[string]string, requestAdapter i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestAdapter) *ItemPermissionsRequestBuilder {
+	m := &ItemPermissionsRequestBuilder{}
+	m.urlTemplate = "{+baseurl}/sites/{site%2Did}/permissions{?%24top,%24skip,%24search,%24filter,%24count,%24orderby,%24select,%24expand}"
+	urlTplParams := make(map[string]string)
+	for idx, item := range pathParameters {
+		urlTplParams[idx] = item
+	}
+	m.pathParameters = urlTplParams
+	m.requestAdapter = requestAdapter
+	return m
+}
+
+// NewItemPermissionsRequestBuilder instantiates a new ItemPermissionsRequestBuilder and sets the default values.
+//
+//nolint:lll,revive,wsl
+func NewItemPermissionsRequestBuilder(rawUrl string, requestAdapter i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestAdapter) *ItemPermissionsRequestBuilder {
+	urlParams := make(map[string]string)
+	urlParams["request-raw-url"] = rawUrl
+	return NewItemPermissionsRequestBuilderInternal(urlParams, requestAdapter)
+}
+
+// CreateGetRequestInformation get the permission resources from the permissions navigation property on a site.
+//
+//nolint:lll,wsl
+func (m *ItemPermissionsRequestBuilder) CreateGetRequestInformation(ctx context.Context, requestConfiguration *ItemPermissionsRequestBuilderGetRequestConfiguration) (*i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestInformation, error) {
+	requestInfo := i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.NewRequestInformation()
+	requestInfo.UrlTemplate = m.urlTemplate
+	requestInfo.PathParameters = m.pathParameters
+	requestInfo.Method = i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.GET
+	requestInfo.Headers.Add("Accept", "application/json")
+	if requestConfiguration != nil {
+		if requestConfiguration.QueryParameters != nil {
+			requestInfo.AddQueryParameters(*(requestConfiguration.QueryParameters))
+		}
+		requestInfo.Headers.AddAll(requestConfiguration.Headers)
+		requestInfo.AddRequestOptions(requestConfiguration.Options)
+	}
+	return requestInfo, nil
+}
+
+// CreatePostRequestInformation create a new permission object on a site.
+//
+//nolint:lll,errcheck,wsl
+func (m *ItemPermissionsRequestBuilder) CreatePostRequestInformation(ctx context.Context, body msmodel.Permissionable, requestConfiguration *ItemPermissionsRequestBuilderPostRequestConfiguration) (*i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.RequestInformation, error) {
+	requestInfo := i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.NewRequestInformation()
+	requestInfo.UrlTemplate = m.urlTemplate
+	requestInfo.PathParameters = m.pathParameters
+	requestInfo.Method = i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.POST
+	requestInfo.Headers.Add("Accept", "application/json")
+	requestInfo.SetContentFromParsable(ctx, m.requestAdapter, "application/json", body)
+	if requestConfiguration != nil {
+		requestInfo.Headers.AddAll(requestConfiguration.Headers)
+		requestInfo.AddRequestOptions(requestConfiguration.Options)
+	}
+	return requestInfo, nil
+}
+
+// Get get the permission resources from the permissions navigation property on a site.
+// [Find more info here]
+//
+// [Find more info here]: https://docs.microsoft.com/graph/api/site-list-permissions?view=graph-rest-beta
+//
+//nolint:wsl,revive,lll
+func (m *ItemPermissionsRequestBuilder) Get(ctx context.Context, requestConfiguration *ItemPermissionsRequestBuilderGetRequestConfiguration) (msmodel.PermissionCollectionResponseable, error) {
+	requestInfo, err := m.CreateGetRequestInformation(ctx, requestConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	errorMapping := i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.ErrorMappings{
+		"4XX": i7ad325c11fbf3db4d761c429267362d8b24daa1eda0081f914ebc3cdc85181a0.CreateODataErrorFromDiscriminatorValue,
+		"5XX": i7ad325c11fbf3db4d761c429267362d8b24daa1eda0081f914ebc3cdc85181a0.CreateODataErrorFromDiscriminatorValue,
+	}
+	res, err := m.requestAdapter.Send(ctx, requestInfo, msmodel.CreatePermissionCollectionResponseFromDiscriminatorValue, errorMapping)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+	return res.(msmodel.PermissionCollectionResponseable), nil
+}
+
+// Post create a new permission object on a site.
+// [Find more info here]
+//
+// [Find more info here]: https://docs.microsoft.com/graph/api/site-post-permissions?view=graph-rest-beta
+//
+//nolint:wsl,revive,lll
+func (m *ItemPermissionsRequestBuilder) Post(ctx context.Context, body msmodel.Permissionable, requestConfiguration *ItemPermissionsRequestBuilderPostRequestConfiguration) (msmodel.Permissionable, error) {
+	requestInfo, err := m.CreatePostRequestInformation(ctx, body, requestConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	errorMapping := i2ae4187f7daee263371cb1c977df639813ab50ffa529013b7437480d1ec0158f.ErrorMappings{
+		"4XX": i7ad325c11fbf3db4d761c429267362d8b24daa1eda0081f914ebc3cdc85181a0.CreateODataErrorFromDiscriminatorValue,
+		"5XX": i7ad325c11fbf3db4d761c429267362d8b24daa1eda0081f914ebc3cdc85181a0.CreateODataErrorFromDiscriminatorValue,
+	}
+	res, err := m.requestAdapter.Send(ctx, requestInfo, msmodel.CreatePermissionFromDiscriminatorValue, errorMapping)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+	return res.(msmodel.Permissionable), nil
+}
@@ -54,8 +54,23 @@ type SiteItemRequestBuilderPatchRequestConfiguration struct {
 // REMOVED Analytics for minimial
 
 // Columns provides operations to manage the columns property of the microsoft.graph.site entity.
+func (m *SiteItemRequestBuilder) Columns() *ItemColumnsRequestBuilder {
+	return NewItemColumnsRequestBuilderInternal(m.pathParameters, m.requestAdapter)
+}
 
 // ColumnsById provides operations to manage the columns property of the microsoft.graph.site entity.
+//
+//nolint:revive,wsl
+func (m *SiteItemRequestBuilder) ColumnsById(id string) *ItemColumnsColumnDefinitionItemRequestBuilder {
+	urlTplParams := make(map[string]string)
+	for idx, item := range m.pathParameters {
+		urlTplParams[idx] = item
+	}
+	if len(id) > 0 {
+		urlTplParams["columnDefinition%2Did"] = id
+	}
+	return NewItemColumnsColumnDefinitionItemRequestBuilderInternal(urlTplParams, m.requestAdapter)
+}
 
 // NewSiteItemRequestBuilderInternal instantiates a new SiteItemRequestBuilder and sets the default values.
 //
@@ -82,8 +97,24 @@ func NewSiteItemRequestBuilder(rawUrl string, requestAdapter i2ae4187f7daee26337
 }
 
 // ContentTypes provides operations to manage the contentTypes property of the microsoft.graph.site entity.
+func (m *SiteItemRequestBuilder) ContentTypes() *ItemContentTypesRequestBuilder {
+	return NewItemContentTypesRequestBuilderInternal(m.pathParameters, m.requestAdapter)
+}
 
 // ContentTypesById provides operations to manage the contentTypes property of the microsoft.graph.site entity.
+//
+//nolint:revive,wsl
+func (m *SiteItemRequestBuilder) ContentTypesById(id string) *ItemContentTypesContentTypeItemRequestBuilder {
+	urlTplParams := make(map[string]string)
+	for idx, item := range m.pathParameters {
+		urlTplParams[idx] = item
+	}
+	if len(id) > 0 {
+		urlTplParams["contentType%2Did"] = id
+	}
+	return NewItemContentTypesContentTypeItemRequestBuilderInternal(urlTplParams, m.requestAdapter)
+}
+
 // CreateGetRequestInformation retrieve properties and relationships for a [site][] resource.A **site** resource represents a team site in SharePoint.
 //
 //nolint:lll,wsl
@@ -229,7 +260,16 @@ func (m *SiteItemRequestBuilder) Patch(
 }
 
 // Permissions provides operations to manage the permissions property of the microsoft.graph.site entity.
+func (m *SiteItemRequestBuilder) Permissions() *ItemPermissionsRequestBuilder {
+	return NewItemPermissionsRequestBuilderInternal(m.pathParameters, m.requestAdapter)
+}
+
 // PermissionsById provides operations to manage the permissions property of the microsoft.graph.site entity.
+// Settings provides operations to manage the settings property of the microsoft.graph.site entity.
+func (m *SiteItemRequestBuilder) Settings() *ItemSettingsRequestBuilder {
+	return NewItemSettingsRequestBuilderInternal(m.pathParameters, m.requestAdapter)
+}
+
 // Sites provides operations to manage the sites property of the microsoft.graph.site entity.
 // func (m *SiteItemRequestBuilder) Sites()
 // SitesById provides operations to manage the sites property of the microsoft.graph.site entity.
@@ -12,7 +12,10 @@ import (
 	"github.com/alcionai/corso/src/pkg/path"
 )
 
-var _ data.BackupCollection = prefixCollection{}
+var (
+	_ data.BackupCollection = prefixCollection{}
+	_ data.ItemEstimator    = prefixCollection{}
+)
 
 // TODO: move this out of graph.  /data would be a much better owner
 // for a generic struct like this.  However, support.StatusUpdater makes
@@ -34,6 +37,11 @@ func (c prefixCollection) Items(ctx context.Context, _ *fault.Bus) <-chan data.I
 	return res
 }
 
+// EstimateItems reports that prefix collections hold no items.
+func (c prefixCollection) EstimateItems() (int64, int64, bool) {
+	return 0, 0, true
+}
+
 func (c prefixCollection) FullPath() path.Path {
 	return c.full
 }
@@ -0,0 +1,33 @@
+package graph
+
+import (
+	"context"
+	"io"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/limiters"
+)
+
+// downloadLimiter paces the download of item content across every backup
+// in the process, on top of the per-service request limiters.  It doesn't
+// limit anything until a rate is set.
+var downloadLimiter = limiters.NewBandwidthLimiter(nil)
+
+// SetDownloadRate sets the bytes per second allowed for item content
+// downloads.  A nil rateAt, or one that returns zero, removes the limit.
+func SetDownloadRate(rateAt limiters.RateFunc) {
+	downloadLimiter.SetRate(rateAt)
+}
+
+// LimitDownload wraps a streamed download so that reads from it wait on
+// the download rate.
+func LimitDownload(ctx context.Context, rc io.ReadCloser) io.ReadCloser {
+	return limiters.NewLimitedReader(ctx, rc, downloadLimiter)
+}
+
+// WaitForDownload waits on the download rate for n bytes of content that
+// was fetched in full, such as an item's serialized json.
+func WaitForDownload(ctx context.Context, n int) error {
+	return clues.Stack(downloadLimiter.WaitN(ctx, n)).OrNil()
+}
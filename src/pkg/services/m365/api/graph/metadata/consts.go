@@ -8,8 +8,13 @@ const (
 	MetaFileSuffix    = ".meta"
 	DirMetaFileSuffix = ".dirmeta"
 	DataFileSuffix    = ".data"
+	// VersionsFileSuffix marks the file holding the prior versions of a
+	// drive item.  It sits beside the item's data and meta files.
+	VersionsFileSuffix = ".versions"
 )
 
 func HasMetaSuffix(name string) bool {
-	return strings.HasSuffix(name, MetaFileSuffix) || strings.HasSuffix(name, DirMetaFileSuffix)
+	return strings.HasSuffix(name, MetaFileSuffix) ||
+		strings.HasSuffix(name, DirMetaFileSuffix) ||
+		strings.HasSuffix(name, VersionsFileSuffix)
 }
@@ -77,6 +77,9 @@ var SafeURLPathParams = pii.MapWithPlurals(
 	"page",
 	"primarychannel",
 	"recoverableitemsdeletion",
+	"recoverableitemspurge",
+	"recoverableitemsroot",
+	"recoverableitemsversion",
 	"root",
 	"scheduled",
 	"searchfolder",
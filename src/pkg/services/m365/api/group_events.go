@@ -0,0 +1,328 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) GroupEvents() GroupEvents {
+	return GroupEvents{c}
+}
+
+// GroupEvents is an interface-compliant provider of the client for the
+// events in a group's shared calendar.  Its funcs mirror those of Events,
+// with the group ID standing in for the user ID, so that the exchange
+// event handling can be shared between the two.
+type GroupEvents struct {
+	Client
+}
+
+const (
+	// Beta version cannot have /calendar for get and Patch, same as with
+	// user events.
+	groupEventExceptionsBetaURLTemplate = "https://graph.microsoft.com/beta/groups/%s/events/%s?$expand=exceptionOccurrences"
+	groupEventPostBetaURLTemplate       = "https://graph.microsoft.com/beta/groups/%s/calendar/events"
+	groupEventPatchBetaURLTemplate      = "https://graph.microsoft.com/beta/groups/%s/events/%s"
+)
+
+// ---------------------------------------------------------------------------
+// containers
+// ---------------------------------------------------------------------------
+
+// GetCalendar retrieves the group's calendar.  Groups have exactly one
+// calendar, which contains all of the group's events.
+func (c GroupEvents) GetCalendar(
+	ctx context.Context,
+	groupID string,
+) (models.Calendarable, error) {
+	config := &groups.ItemCalendarRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.ItemCalendarRequestBuilderGetQueryParameters{
+			Select: idAnd("name"),
+		},
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Calendar().
+		Get(ctx, config)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting group calendar")
+	}
+
+	return resp, nil
+}
+
+// ---------------------------------------------------------------------------
+// items
+// ---------------------------------------------------------------------------
+
+// GetItem retrieves an event from the group's calendar, along with its
+// exception occurrences and attachments.
+func (c GroupEvents) GetItem(
+	ctx context.Context,
+	groupID, itemID string,
+) (models.Eventable, *details.GroupsInfo, error) {
+	// See Events.GetItem for why the beta endpoint is used here.
+	rawURL := fmt.Sprintf(groupEventExceptionsBetaURLTemplate, groupID, itemID)
+
+	event, err := groups.
+		NewItemEventsEventItemRequestBuilder(rawURL, c.Stable.Adapter()).
+		Get(ctx, nil)
+	if err != nil {
+		return nil, nil, clues.Stack(err)
+	}
+
+	_, err = GetCancelledEventDateStrings(event)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "verify cancelled occurrences")
+	}
+
+	err = fixupExceptionOccurrences(ctx, c, event, groupID)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "fixup exception occurrences")
+	}
+
+	var attachments []models.Attachmentable
+	if ptr.Val(event.GetHasAttachments()) || HasAttachments(event.GetBody()) {
+		attachments, err = c.GetAttachments(ctx, groupID, itemID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	event.SetAttachments(attachments)
+
+	size, err := groupEventSize(event)
+	if err != nil {
+		return nil, nil, clues.WrapWC(ctx, err, "sizing event")
+	}
+
+	return event, GroupEventInfo(event, size), nil
+}
+
+func (c GroupEvents) GetAttachments(
+	ctx context.Context,
+	groupID, itemID string,
+) ([]models.Attachmentable, error) {
+	config := &groups.ItemEventsItemAttachmentsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.ItemEventsItemAttachmentsRequestBuilderGetQueryParameters{
+			Expand: []string{"microsoft.graph.itemattachment/item"},
+		},
+		Headers: newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+	}
+
+	attached, err := c.LargeItem.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Events().
+		ByEventId(itemID).
+		Attachments().
+		Get(ctx, config)
+	if err != nil {
+		return nil, clues.Wrap(err, "group event attachment download")
+	}
+
+	return attached.GetValue(), nil
+}
+
+// DeleteAttachment removes an attachment from an event.  The calendar
+// ID is ignored, since groups have only the one calendar.
+func (c GroupEvents) DeleteAttachment(
+	ctx context.Context,
+	groupID, _, eventID, attachmentID string,
+) error {
+	return c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Events().
+		ByEventId(eventID).
+		Attachments().
+		ByAttachmentId(attachmentID).
+		Delete(ctx, nil)
+}
+
+func (c GroupEvents) GetItemInstances(
+	ctx context.Context,
+	groupID, itemID, startDate, endDate string,
+) ([]models.Eventable, error) {
+	config := &groups.ItemEventsItemInstancesRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.ItemEventsItemInstancesRequestBuilderGetQueryParameters{
+			Select:        []string{"id"},
+			StartDateTime: ptr.To(startDate),
+			EndDateTime:   ptr.To(endDate),
+		},
+	}
+
+	events, err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Events().
+		ByEventId(itemID).
+		Instances().
+		Get(ctx, config)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	return events.GetValue(), nil
+}
+
+// PostItem creates the event in the group's calendar.  The container ID
+// is ignored, since groups have only the one calendar.
+func (c GroupEvents) PostItem(
+	ctx context.Context,
+	groupID, _ string,
+	body models.Eventable,
+) (models.Eventable, error) {
+	rawURL := fmt.Sprintf(groupEventPostBetaURLTemplate, groupID)
+	builder := groups.NewItemCalendarEventsRequestBuilder(rawURL, c.Stable.Adapter())
+
+	itm, err := builder.Post(ctx, body, nil)
+
+	return itm, clues.Wrap(err, "creating group calendar event").OrNil()
+}
+
+func (c GroupEvents) PatchItem(
+	ctx context.Context,
+	groupID, eventID string,
+	body models.Eventable,
+) (models.Eventable, error) {
+	rawURL := fmt.Sprintf(groupEventPatchBetaURLTemplate, groupID, eventID)
+	builder := groups.NewItemEventsEventItemRequestBuilder(rawURL, c.Stable.Adapter())
+
+	itm, err := builder.Patch(ctx, body, nil)
+
+	return itm, clues.Wrap(err, "updating group calendar event").OrNil()
+}
+
+func (c GroupEvents) DeleteItem(
+	ctx context.Context,
+	groupID, itemID string,
+) error {
+	// deletes require unique http clients
+	// https://github.com/alcionai/corso/issues/2707
+	srv, err := c.Service(c.counter)
+	if err != nil {
+		return clues.StackWC(ctx, err)
+	}
+
+	err = srv.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Events().
+		ByEventId(itemID).
+		Delete(ctx, nil)
+
+	return clues.Wrap(err, "deleting group calendar event").OrNil()
+}
+
+func (c GroupEvents) PostSmallAttachment(
+	ctx context.Context,
+	groupID, _, parentItemID string,
+	body models.Attachmentable,
+) error {
+	_, err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Calendar().
+		Events().
+		ByEventId(parentItemID).
+		Attachments().
+		Post(ctx, body, nil)
+
+	return clues.Wrap(err, "uploading small group event attachment").OrNil()
+}
+
+func (c GroupEvents) PostLargeAttachment(
+	ctx context.Context,
+	groupID, _, parentItemID, itemName string,
+	content []byte,
+) (string, error) {
+	size := int64(len(content))
+	session := groups.NewItemCalendarEventsItemAttachmentsCreateUploadSessionPostRequestBody()
+	session.SetAttachmentItem(makeSessionAttachment(itemName, size))
+
+	us, err := c.LargeItem.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Calendar().
+		Events().
+		ByEventId(parentItemID).
+		Attachments().
+		CreateUploadSession().
+		Post(ctx, session, nil)
+	if err != nil {
+		return "", clues.Wrap(err, "uploading large group event attachment")
+	}
+
+	var (
+		url        = ptr.Val(us.GetUploadUrl())
+		w          = graph.NewLargeItemWriter(parentItemID, url, size, c.counter)
+		reader     = bytes.NewReader(content)
+		copyBuffer = make([]byte, graph.AttachmentChunkSize)
+	)
+
+	_, err = io.CopyBuffer(w, reader, copyBuffer)
+
+	return w.ID, clues.WrapWC(ctx, err, "buffering large attachment content").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// helper funcs
+// ---------------------------------------------------------------------------
+
+func groupEventSize(evt models.Eventable) (int64, error) {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", evt); err != nil {
+		return 0, clues.Stack(err)
+	}
+
+	bs, err := writer.GetSerializedContent()
+
+	return int64(len(bs)), clues.Stack(err).OrNil()
+}
+
+// GroupEventInfo produces the groups details for an event in the group's
+// calendar.  The event properties match those recorded for exchange events.
+func GroupEventInfo(evt models.Eventable, size int64) *details.GroupsInfo {
+	ei := EventInfo(evt)
+
+	return &details.GroupsInfo{
+		ItemType: details.GroupsCalendarEvent,
+		Created:  ei.Created,
+		Modified: ei.Modified,
+		Size:     size,
+		Event: details.CalendarEventInfo{
+			EventEnd:    ei.EventEnd,
+			EventRecurs: ei.EventRecurs,
+			EventStart:  ei.EventStart,
+			Organizer:   ei.Organizer,
+			Subject:     ei.Subject,
+		},
+	}
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+const groupEventBetaDeltaURLTemplate = "https://graph.microsoft.com/beta/groups/%s/calendar/events/delta"
+
+// ---------------------------------------------------------------------------
+// item pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Eventable] = &groupEventsPageCtrl{}
+
+type groupEventsPageCtrl struct {
+	gs      graph.Servicer
+	builder *groups.ItemCalendarEventsRequestBuilder
+	options *groups.ItemCalendarEventsRequestBuilderGetRequestConfiguration
+}
+
+func (c GroupEvents) NewGroupEventsPager(
+	groupID string,
+	selectProps ...string,
+) pagers.NonDeltaHandler[models.Eventable] {
+	options := &groups.ItemCalendarEventsRequestBuilderGetRequestConfiguration{
+		Headers:         newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+		QueryParameters: &groups.ItemCalendarEventsRequestBuilderGetQueryParameters{},
+		// do NOT set Top.  It limits the total items received.
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	builder := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Calendar().
+		Events()
+
+	return &groupEventsPageCtrl{c.Stable, builder, options}
+}
+
+func (p *groupEventsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Eventable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *groupEventsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = groups.NewItemCalendarEventsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *groupEventsPageCtrl) ValidModTimes() bool {
+	return true
+}
+
+// GetItemsInContainerByCollisionKey maps the collision key of each event
+// in the group's calendar to its ID.  The container ID is ignored, since
+// groups have only the one calendar.
+func (c GroupEvents) GetItemsInContainerByCollisionKey(
+	ctx context.Context,
+	groupID, _ string,
+) (map[string]string, error) {
+	pager := c.NewGroupEventsPager(groupID, eventCollisionKeyProps()...)
+
+	items, err := pagers.BatchEnumerateItems(ctx, pager)
+	if err != nil {
+		return nil, clues.Wrap(err, "enumerating group events")
+	}
+
+	m := map[string]string{}
+
+	for _, item := range items {
+		m[EventCollisionKey(item)] = ptr.Val(item.GetId())
+	}
+
+	return m, nil
+}
+
+// ---------------------------------------------------------------------------
+// delta item ID pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.DeltaHandler[models.Eventable] = &groupEventDeltaPager{}
+
+type groupEventDeltaPager struct {
+	gs      graph.Servicer
+	groupID string
+	builder *groups.ItemCalendarEventsDeltaRequestBuilder
+	options *groups.ItemCalendarEventsDeltaRequestBuilderGetRequestConfiguration
+}
+
+func getGroupEventDeltaBuilder(
+	gs graph.Servicer,
+	groupID string,
+) *groups.ItemCalendarEventsDeltaRequestBuilder {
+	rawURL := fmt.Sprintf(groupEventBetaDeltaURLTemplate, groupID)
+	return groups.NewItemCalendarEventsDeltaRequestBuilder(rawURL, gs.Adapter())
+}
+
+func (c GroupEvents) NewGroupEventsDeltaPager(
+	groupID, prevDeltaLink string,
+	pageSize int32,
+	selectProps ...string,
+) pagers.DeltaHandler[models.Eventable] {
+	options := &groups.ItemCalendarEventsDeltaRequestBuilderGetRequestConfiguration{
+		// do NOT set Top.  It limits the total items received.
+		QueryParameters: &groups.ItemCalendarEventsDeltaRequestBuilderGetQueryParameters{},
+		Headers:         newPreferHeaders(preferPageSize(pageSize)),
+	}
+
+	if len(selectProps) > 0 {
+		options.QueryParameters.Select = selectProps
+	}
+
+	var builder *groups.ItemCalendarEventsDeltaRequestBuilder
+
+	if len(prevDeltaLink) > 0 {
+		builder = groups.NewItemCalendarEventsDeltaRequestBuilder(prevDeltaLink, c.Stable.Adapter())
+	} else {
+		builder = getGroupEventDeltaBuilder(c.Stable, groupID)
+	}
+
+	return &groupEventDeltaPager{c.Stable, groupID, builder, options}
+}
+
+func (p *groupEventDeltaPager) GetPage(
+	ctx context.Context,
+) (pagers.DeltaLinkValuer[models.Eventable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *groupEventDeltaPager) SetNextLink(nextLink string) {
+	p.builder = groups.NewItemCalendarEventsDeltaRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *groupEventDeltaPager) Reset(ctx context.Context) {
+	p.builder = getGroupEventDeltaBuilder(p.gs, p.groupID)
+}
+
+func (p *groupEventDeltaPager) ValidModTimes() bool {
+	return false
+}
+
+// GetAddedAndRemovedItemIDs enumerates the events in the group's calendar,
+// using the previous delta link to find only the changes where possible.
+// Like user calendars, the delta endpoint is retried with a reduced page
+// size if it fails to produce results.
+func (c GroupEvents) GetAddedAndRemovedItemIDs(
+	ctx context.Context,
+	groupID, prevDeltaLink string,
+	config CallConfig,
+) (pagers.AddedAndRemoved, error) {
+	ctx = clues.Add(ctx, "data_category", path.EventsCategory)
+
+	enumerate := func(pageSize int32, canMakeDeltaQueries bool) (pagers.AddedAndRemoved, error) {
+		// Get new pagers on each attempt to make sure we don't have
+		// partial state in them.
+		return pagers.GetAddedAndRemovedItemIDs[models.Eventable](
+			ctx,
+			c.NewGroupEventsPager(groupID, idAnd(lastModifiedDateTime)...),
+			c.NewGroupEventsDeltaPager(groupID, prevDeltaLink, pageSize, idAnd()...),
+			prevDeltaLink,
+			canMakeDeltaQueries,
+			config.LimitResults,
+			pagers.AddedAndRemovedByAddtlData[models.Eventable])
+	}
+
+	addedRemoved, err := enumerate(c.options.DeltaPageSize, config.CanMakeDeltaQueries)
+	if err == nil || !errors.Is(err, graph.ErrServiceUnavailableEmptyResp) {
+		return addedRemoved, clues.Stack(err).OrNil()
+	}
+
+	logger.Ctx(ctx).Infow(
+		"retrying list group event item query with reduced page size",
+		"delta_pager_effective_page_size", minEventsDeltaPageSize,
+		"delta_pager_default_page_size", c.options.DeltaPageSize)
+
+	addedRemoved, err = enumerate(minEventsDeltaPageSize, config.CanMakeDeltaQueries)
+	if err == nil || !errors.Is(err, graph.ErrServiceUnavailableEmptyResp) {
+		return addedRemoved, clues.Stack(err).OrNil()
+	}
+
+	logger.Ctx(ctx).Infow(
+		"retrying list group event item query with non-delta pager",
+		"effective_page_size", maxNonDeltaPageSize)
+
+	addedRemoved, err = enumerate(minEventsDeltaPageSize, false)
+
+	return addedRemoved, clues.Stack(err).OrNil()
+}
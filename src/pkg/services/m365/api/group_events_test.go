@@ -0,0 +1,115 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/tester/tconfig"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+type GroupEventsUnitSuite struct {
+	tester.Suite
+}
+
+func TestGroupEventsUnitSuite(t *testing.T) {
+	suite.Run(t, &GroupEventsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *GroupEventsUnitSuite) TestGetItem() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := tconfig.NewFakeM365Account(t)
+	creds, err := a.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+	require.NoError(t, err, clues.ToCore(err))
+
+	t.Cleanup(gock.Off)
+
+	gock.New(graphAPIHostURL).
+		Get("/beta/groups/gid/events/eid").
+		Reply(200).
+		JSON(map[string]any{
+			"id":             "eid",
+			"subject":        "standup",
+			"hasAttachments": false,
+			"body":           map[string]any{"contentType": "text", "content": "daily sync"},
+			"organizer": map[string]any{
+				"emailAddress": map[string]any{"address": "organizer@example.com"},
+			},
+			"start": map[string]any{"dateTime": "2024-01-02T03:00:00.0000000", "timeZone": "UTC"},
+			"end":   map[string]any{"dateTime": "2024-01-02T03:30:00.0000000", "timeZone": "UTC"},
+			"recurrence": map[string]any{
+				"pattern": map[string]any{"type": "daily", "interval": 1},
+				"range":   map[string]any{"type": "noEnd", "startDate": "2024-01-02"},
+			},
+		})
+
+	event, info, err := client.GroupEvents().GetItem(ctx, "gid", "eid")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.False(t, gock.HasUnmatchedRequest(), "unmatched graph calls")
+
+	assert.Equal(t, "standup", ptr.Val(event.GetSubject()))
+	assert.Equal(t, details.GroupsCalendarEvent, info.ItemType)
+	assert.Equal(t, "standup", info.Event.Subject)
+	assert.Equal(t, "organizer@example.com", info.Event.Organizer)
+	assert.True(t, info.Event.EventRecurs)
+	assert.False(t, info.Event.EventStart.IsZero())
+	assert.NotZero(t, info.Size)
+}
+
+func (suite *GroupEventsUnitSuite) TestGetAddedAndRemovedItemIDs() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := tconfig.NewFakeM365Account(t)
+	creds, err := a.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+	require.NoError(t, err, clues.ToCore(err))
+
+	t.Cleanup(gock.Off)
+
+	nextDelta := graphAPIHostURL + "/beta/groups/gid/calendar/events/delta?$deltatoken=next"
+
+	gock.New(graphAPIHostURL).
+		Get("/beta/groups/gid/calendar/events/delta").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{
+				{"id": "added"},
+				{"id": "removed", "@removed": map[string]any{"reason": "deleted"}},
+			},
+			"@odata.deltaLink": nextDelta,
+		})
+
+	aar, err := client.GroupEvents().GetAddedAndRemovedItemIDs(
+		ctx,
+		"gid",
+		"",
+		CallConfig{CanMakeDeltaQueries: true})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.False(t, gock.HasUnmatchedRequest(), "unmatched graph calls")
+
+	assert.Contains(t, aar.Added, "added")
+	assert.Equal(t, []string{"removed"}, aar.Removed)
+	assert.Equal(t, nextDelta, aar.DU.URL)
+	assert.False(t, aar.ValidModTimes)
+}
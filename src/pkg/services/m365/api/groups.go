@@ -17,6 +17,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/common/str"
 	"github.com/alcionai/corso/src/internal/common/tform"
+	"github.com/alcionai/corso/src/pkg/backup/membership"
 	"github.com/alcionai/corso/src/pkg/errs/core"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
@@ -26,6 +27,8 @@ import (
 const (
 	teamsAdditionalDataLabel    = "Team"
 	ResourceProvisioningOptions = "resourceProvisioningOptions"
+	guestUserType               = "Guest"
+	directoryObjectRefURLFmt    = "https://graph.microsoft.com/v1.0/directoryObjects/%s"
 )
 
 // ---------------------------------------------------------------------------
@@ -344,6 +347,97 @@ func (c Groups) GetRootSite(
 	return resp, clues.Stack(err).OrNil()
 }
 
+// ---------------------------------------------------------------------------
+// membership
+// ---------------------------------------------------------------------------
+
+// GetMembership retrieves the users who own and belong to the group.
+// Members whose user type is Guest are recorded with the guest role.
+func (c Groups) GetMembership(
+	ctx context.Context,
+	groupID string,
+) (membership.Membership, error) {
+	ctx = clues.Add(ctx, "group_id", groupID)
+
+	owners, err := c.GetOwners(ctx, groupID)
+	if err != nil {
+		return membership.Membership{}, clues.Wrap(err, "getting group owners")
+	}
+
+	members, err := c.GetMembers(ctx, groupID)
+	if err != nil {
+		return membership.Membership{}, clues.Wrap(err, "getting group members")
+	}
+
+	m := membership.Membership{
+		Owners:  make([]membership.Member, 0, len(owners)),
+		Members: make([]membership.Member, 0, len(members)),
+	}
+
+	for _, u := range owners {
+		m.Owners = append(m.Owners, userToMember(u, membership.OwnerRole))
+	}
+
+	for _, u := range members {
+		role := membership.MemberRole
+
+		if strings.EqualFold(ptr.Val(u.GetUserType()), guestUserType) {
+			role = membership.GuestRole
+		}
+
+		m.Members = append(m.Members, userToMember(u, role))
+	}
+
+	return m, nil
+}
+
+// PostMember adds the user as a member of the group.
+func (c Groups) PostMember(
+	ctx context.Context,
+	groupID, userID string,
+) error {
+	body := models.NewReferenceCreate()
+	body.SetOdataId(ptr.To(fmt.Sprintf(directoryObjectRefURLFmt, userID)))
+
+	err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Members().
+		Ref().
+		Post(ctx, body, nil)
+
+	return clues.Wrap(err, "adding group member").OrNil()
+}
+
+// PostOwner adds the user as an owner of the group.
+func (c Groups) PostOwner(
+	ctx context.Context,
+	groupID, userID string,
+) error {
+	body := models.NewReferenceCreate()
+	body.SetOdataId(ptr.To(fmt.Sprintf(directoryObjectRefURLFmt, userID)))
+
+	err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Owners().
+		Ref().
+		Post(ctx, body, nil)
+
+	return clues.Wrap(err, "adding group owner").OrNil()
+}
+
+func userToMember(u models.Userable, role membership.Role) membership.Member {
+	return membership.Member{
+		ID:          ptr.Val(u.GetId()),
+		UPN:         ptr.Val(u.GetUserPrincipalName()),
+		DisplayName: ptr.Val(u.GetDisplayName()),
+		Role:        role,
+	}
+}
+
 // ---------------------------------------------------------------------------
 // helpers
 // ---------------------------------------------------------------------------
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+var groupMemberSelectProps = []string{
+	"id",
+	"displayName",
+	"userPrincipalName",
+	"userType",
+}
+
+// ---------------------------------------------------------------------------
+// group members pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Userable] = &groupMembersPageCtrl{}
+
+type groupMembersPageCtrl struct {
+	gs      graph.Servicer
+	builder *groups.ItemMembersGraphUserRequestBuilder
+	options *groups.ItemMembersGraphUserRequestBuilderGetRequestConfiguration
+}
+
+func (p *groupMembersPageCtrl) SetNextLink(nextLink string) {
+	p.builder = groups.NewItemMembersGraphUserRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *groupMembersPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Userable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *groupMembersPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Groups) NewGroupMembersPager(
+	groupID string,
+) *groupMembersPageCtrl {
+	builder := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Members().
+		GraphUser()
+
+	options := &groups.ItemMembersGraphUserRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.ItemMembersGraphUserRequestBuilderGetQueryParameters{
+			Select: groupMemberSelectProps,
+		},
+	}
+
+	return &groupMembersPageCtrl{
+		builder: builder,
+		gs:      c.Stable,
+		options: options,
+	}
+}
+
+// GetMembers fetches all users who are members of the group.  Members
+// that aren't users, such as nested groups and devices, are not included.
+func (c Groups) GetMembers(
+	ctx context.Context,
+	groupID string,
+) ([]models.Userable, error) {
+	pager := c.NewGroupMembersPager(groupID)
+	items, err := pagers.BatchEnumerateItems[models.Userable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// group owners pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.Userable] = &groupOwnersPageCtrl{}
+
+type groupOwnersPageCtrl struct {
+	gs      graph.Servicer
+	builder *groups.ItemOwnersGraphUserRequestBuilder
+	options *groups.ItemOwnersGraphUserRequestBuilderGetRequestConfiguration
+}
+
+func (p *groupOwnersPageCtrl) SetNextLink(nextLink string) {
+	p.builder = groups.NewItemOwnersGraphUserRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *groupOwnersPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.Userable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *groupOwnersPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Groups) NewGroupOwnersPager(
+	groupID string,
+) *groupOwnersPageCtrl {
+	builder := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Owners().
+		GraphUser()
+
+	options := &groups.ItemOwnersGraphUserRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.ItemOwnersGraphUserRequestBuilderGetQueryParameters{
+			Select: groupMemberSelectProps,
+		},
+	}
+
+	return &groupOwnersPageCtrl{
+		builder: builder,
+		gs:      c.Stable,
+		options: options,
+	}
+}
+
+// GetOwners fetches all users who own the group.
+func (c Groups) GetOwners(
+	ctx context.Context,
+	groupID string,
+) ([]models.Userable, error) {
+	pager := c.NewGroupOwnersPager(groupID)
+	items, err := pagers.BatchEnumerateItems[models.Userable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
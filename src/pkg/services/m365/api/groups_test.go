@@ -15,6 +15,8 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/tester/tconfig"
+	"github.com/alcionai/corso/src/pkg/backup/membership"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/errs/core"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
@@ -79,6 +81,55 @@ func (suite *GroupUnitSuite) TestValidateGroup() {
 	}
 }
 
+func (suite *GroupUnitSuite) TestGetMembership() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := tconfig.NewFakeM365Account(t)
+	creds, err := a.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+	require.NoError(t, err, clues.ToCore(err))
+
+	t.Cleanup(gock.Off)
+
+	interceptV1Path("groups", "gid", "owners", "graph.user").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{
+				{"id": "o", "userPrincipalName": "o@contoso.com", "userType": "Member"},
+			},
+		})
+
+	interceptV1Path("groups", "gid", "members", "graph.user").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{
+				{"id": "m", "userPrincipalName": "m@contoso.com", "userType": "Member"},
+				{"id": "g", "userPrincipalName": "g_fabrikam.com#EXT#@contoso.com", "userType": "Guest"},
+			},
+		})
+
+	m, err := client.Groups().GetMembership(ctx, "gid")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.True(t, gock.IsDone(), "all graph calls made")
+
+	assert.Equal(
+		t,
+		[]membership.Member{{ID: "o", UPN: "o@contoso.com", Role: membership.OwnerRole}},
+		m.Owners)
+	assert.Equal(
+		t,
+		[]membership.Member{
+			{ID: "m", UPN: "m@contoso.com", Role: membership.MemberRole},
+			{ID: "g", UPN: "g_fabrikam.com#EXT#@contoso.com", Role: membership.GuestRole},
+		},
+		m.Members)
+}
+
 type GroupsIntgSuite struct {
 	tester.Suite
 	its intgTesterSetup
@@ -0,0 +1,503 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/sites"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+const (
+	// ListItemAttachmentsKey is the additionalData key that holds the files
+	// attached to a list item, each with its name and base64 encoded content.
+	ListItemAttachmentsKey = "@corso.attachments"
+
+	// listItemAttachmentsField is the list item field which flags whether
+	// the item has any attached files.
+	listItemAttachmentsField = "Attachments"
+
+	// graph doesn't expose list item attachments, so they're handled through
+	// the SharePoint REST api.  Lists and list items are addressed by the same
+	// ids in both apis.
+	// https://learn.microsoft.com/en-us/sharepoint/dev/sp-add-ins/working-with-lists-and-list-items-with-rest
+	listItemAttachmentsURLFmt = "%s/_api/web/lists(guid'%s')/items(%s)/AttachmentFiles"
+
+	// maxListItemAttachmentSize is the largest attached file that gets backed
+	// up along with its list item.
+	maxListItemAttachmentSize = 25 * 1024 * 1024
+	// maxListAttachmentsSize caps the attached files stored within a single
+	// list, across all of its items.
+	maxListAttachmentsSize = 100 * 1024 * 1024
+)
+
+var errListItemAttachmentTooLarge = clues.New("list item attachment exceeds size limit")
+
+var sharePointRESTHeaders = map[string]string{
+	"Accept": "application/json;odata=nometadata",
+}
+
+// ListItemAttachment is a file attached to a list item.
+type ListItemAttachment struct {
+	Name    string
+	Content []byte
+}
+
+// ---------------------------------------------------------------------------
+// backup
+// ---------------------------------------------------------------------------
+
+// listContentState is shared by the items of a list while their content
+// gets populated.
+type listContentState struct {
+	// siteURL is only looked up if any of the items has attached files.
+	siteURL string
+	budget  *contentBudget
+	// attachmentsDenied is set once SharePoint refuses access to the list's
+	// attachments, so that the remaining items don't retry.
+	attachmentsDenied bool
+}
+
+func newListContentState() *listContentState {
+	return &listContentState{budget: newContentBudget(maxListAttachmentsSize)}
+}
+
+// populateListItemContent adds the content which isn't returned along with
+// the list item's fields: its attached files, and, when the options ask for
+// it, its version history.  Attached files that can't be backed up get
+// reported to errs, and don't fail the list item.
+func (c Lists) populateListItemContent(
+	ctx context.Context,
+	siteID, listID string,
+	state *listContentState,
+	li models.ListItemable,
+	errs *fault.Bus,
+) error {
+	itemID := ptr.Val(li.GetId())
+
+	if hasListItemAttachments(li.GetFields()) {
+		c.populateListItemAttachments(ctx, siteID, listID, state, li, errs)
+	}
+
+	if c.options.ListItemVersions {
+		versions, err := c.GetListItemVersions(ctx, siteID, listID, itemID, CallConfig{})
+		if err != nil {
+			return clues.Wrap(err, "getting list item versions")
+		}
+
+		li.SetVersions(versions)
+	}
+
+	return nil
+}
+
+func (c Lists) populateListItemAttachments(
+	ctx context.Context,
+	siteID, listID string,
+	state *listContentState,
+	li models.ListItemable,
+	errs *fault.Bus,
+) {
+	itemID := ptr.Val(li.GetId())
+
+	// SharePoint only accepts app-only tokens that were granted through a
+	// certificate, and rejects the ones granted through a client secret.
+	// That won't change during the backup, so it's reported as a skip.
+	if state.attachmentsDenied {
+		errs.AddSkip(ctx, listItemAttachmentsSkip(fault.SkipListItemAttachmentsDenied, listID, itemID, "", 0))
+		return
+	}
+
+	if len(state.siteURL) == 0 {
+		u, err := c.getSiteWebURL(ctx, siteID)
+		if err != nil {
+			errs.AddRecoverable(ctx, clues.Wrap(err, "backing up list item attachments"))
+			return
+		}
+
+		state.siteURL = u
+	}
+
+	atts, err := c.GetListItemAttachments(ctx, state.siteURL, listID, itemID, state.budget, errs)
+	if err != nil {
+		if IsErrSharePointAccessDenied(err) {
+			logger.CtxErr(ctx, err).Info("sharepoint denied access to list item attachments")
+
+			state.attachmentsDenied = true
+
+			errs.AddSkip(ctx, listItemAttachmentsSkip(fault.SkipListItemAttachmentsDenied, listID, itemID, "", 0))
+
+			return
+		}
+
+		errs.AddRecoverable(ctx, clues.Wrap(err, "backing up list item attachments"))
+
+		return
+	}
+
+	SetListItemAttachments(li, atts)
+}
+
+func listItemAttachmentsSkip(
+	cause fault.SkipCause,
+	listID, itemID, fileName string,
+	size int64,
+) *fault.Skipped {
+	addtl := map[string]any{fault.AddtlContainerID: listID}
+
+	if size > 0 {
+		addtl["size"] = size
+	}
+
+	return fault.FileSkip(cause, listID, itemID, fileName, addtl)
+}
+
+func hasListItemAttachments(fields models.FieldValueSetable) bool {
+	if fields == nil {
+		return false
+	}
+
+	switch v := fields.GetAdditionalData()[listItemAttachmentsField].(type) {
+	case *bool:
+		return ptr.Val(v)
+	case bool:
+		return v
+	}
+
+	return false
+}
+
+func (c Client) getSiteWebURL(ctx context.Context, siteID string) (string, error) {
+	site, err := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		Get(ctx, &sites.SiteItemRequestBuilderGetRequestConfiguration{
+			QueryParameters: &sites.SiteItemRequestBuilderGetQueryParameters{
+				Select: idAnd("webUrl"),
+			},
+		})
+	if err != nil {
+		return "", clues.Wrap(err, "getting site url")
+	}
+
+	return strings.TrimSuffix(ptr.Val(site.GetWebUrl()), "/"), nil
+}
+
+// GetListItemAttachments downloads the files attached to the list item.
+// Files that exceed the size limits, either on their own or by running over
+// the budget, are left out and reported to errs as skips.  Files that fail
+// to download are left out and reported as recoverable errors.
+func (c Lists) GetListItemAttachments(
+	ctx context.Context,
+	siteURL, listID, itemID string,
+	budget *contentBudget,
+	errs *fault.Bus,
+) ([]ListItemAttachment, error) {
+	attURL := fmt.Sprintf(listItemAttachmentsURLFmt, siteURL, listID, itemID)
+
+	bs, err := c.sharePointRequest(ctx, http.MethodGet, attURL, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "listing list item attachments")
+	}
+
+	var resp struct {
+		Value []struct {
+			FileName string `json:"FileName"`
+		} `json:"value"`
+	}
+
+	if err := json.Unmarshal(bs, &resp); err != nil {
+		return nil, clues.Wrap(err, "parsing list item attachments")
+	}
+
+	atts := make([]ListItemAttachment, 0, len(resp.Value))
+
+	for _, v := range resp.Value {
+		ictx := clues.Add(ctx, "attachment_name", clues.Hide(v.FileName))
+
+		content, err := c.sharePointDownload(
+			ictx,
+			attURL+"("+odataString(v.FileName)+")/$value",
+			min(budget.remaining, maxListItemAttachmentSize))
+		if errors.Is(err, errListItemAttachmentTooLarge) {
+			errs.AddSkip(ictx, listItemAttachmentsSkip(
+				fault.SkipListItemAttachmentTooLarge,
+				listID,
+				itemID,
+				v.FileName,
+				0))
+
+			continue
+		}
+
+		if err != nil {
+			errs.AddRecoverable(ictx, clues.Wrap(err, "getting list item attachment content"))
+			continue
+		}
+
+		budget.take(int64(len(content)))
+
+		atts = append(atts, ListItemAttachment{
+			Name:    v.FileName,
+			Content: content,
+		})
+	}
+
+	return atts, nil
+}
+
+// ---------------------------------------------------------------------------
+// restore
+// ---------------------------------------------------------------------------
+
+// PostListItemAttachment attaches the file to the list item.
+func (c Lists) PostListItemAttachment(
+	ctx context.Context,
+	siteURL, listID, itemID string,
+	att ListItemAttachment,
+) error {
+	attURL := fmt.Sprintf(listItemAttachmentsURLFmt, siteURL, listID, itemID) +
+		"/add(FileName=" + odataString(att.Name) + ")"
+
+	_, err := c.sharePointRequest(ctx, http.MethodPost, attURL, bytes.NewReader(att.Content))
+
+	return clues.Wrap(err, "attaching file to list item").OrNil()
+}
+
+// PatchListItemFields overwrites the field values of the list item.
+func (c Lists) PatchListItemFields(
+	ctx context.Context,
+	siteID, listID, itemID string,
+	fields models.FieldValueSetable,
+) error {
+	_, err := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		Lists().
+		ByListId(listID).
+		Items().
+		ByListItemId(itemID).
+		Fields().
+		Patch(ctx, fields, nil)
+
+	return clues.Wrap(err, "patching list item fields").OrNil()
+}
+
+// priorListItemVersions returns the versions of the list item that precede
+// its current version, oldest first.
+func priorListItemVersions(li models.ListItemable) []models.ListItemVersionable {
+	versions := slices.Clone(li.GetVersions())
+
+	// graph lists the current version first.
+	if len(versions) > 0 {
+		versions = versions[1:]
+	}
+
+	slices.Reverse(versions)
+
+	return versions
+}
+
+func (c Client) sharePointRequest(
+	ctx context.Context,
+	method, reqURL string,
+	body io.Reader,
+) ([]byte, error) {
+	resp, err := c.sharePointResponse(ctx, method, reqURL, body, sharePointRESTHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+
+	return bs, clues.Wrap(err, "reading response body").OrNil()
+}
+
+// sharePointDownload gets the content at the url, and returns
+// errListItemAttachmentTooLarge, without reading any further, once the
+// content exceeds limit bytes.
+func (c Lists) sharePointDownload(
+	ctx context.Context,
+	reqURL string,
+	limit int64,
+) ([]byte, error) {
+	resp, err := c.sharePointResponse(ctx, http.MethodGet, reqURL, nil, sharePointRESTHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.ContentLength > limit {
+		return nil, clues.StackWC(ctx, errListItemAttachmentTooLarge)
+	}
+
+	bs, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, clues.Wrap(err, "reading response body")
+	}
+
+	if int64(len(bs)) > limit {
+		return nil, clues.StackWC(ctx, errListItemAttachmentTooLarge)
+	}
+
+	return bs, nil
+}
+
+func (c Client) sharePointResponse(
+	ctx context.Context,
+	method, reqURL string,
+	body io.Reader,
+	headers map[string]string,
+) (*http.Response, error) {
+	resp, err := c.SharePointRequester.Request(ctx, method, reqURL, body, headers, true)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	if (resp.StatusCode / 100) != 2 {
+		resp.Body.Close()
+
+		// upstream error checks can compare the status with
+		// clues.HasLabel(err, graph.LabelStatus(http.KnownStatusCode))
+		return nil, clues.
+			Wrap(clues.NewWC(ctx, resp.Status), "non-2xx http response").
+			Label(graph.LabelStatus(resp.StatusCode))
+	}
+
+	return resp, nil
+}
+
+// IsErrSharePointAccessDenied reports whether the SharePoint REST api
+// refused the request.  SharePoint only accepts app-only tokens that were
+// granted through a certificate.
+func IsErrSharePointAccessDenied(err error) bool {
+	return clues.HasLabel(err, graph.LabelStatus(http.StatusUnauthorized)) ||
+		clues.HasLabel(err, graph.LabelStatus(http.StatusForbidden))
+}
+
+// odataString quotes the value as an odata string literal for use within
+// the url path.
+func odataString(s string) string {
+	// quotes are escaped by doubling them, and are left readable in the url.
+	return "'" + strings.ReplaceAll(url.PathEscape(s), "%27", "''") + "'"
+}
+
+// ---------------------------------------------------------------------------
+// storage
+// ---------------------------------------------------------------------------
+
+// SetListItemAttachments stores the attached files within the list item.
+func SetListItemAttachments(li models.ListItemable, atts []ListItemAttachment) {
+	if len(atts) == 0 {
+		return
+	}
+
+	stored := make([]map[string]any, 0, len(atts))
+
+	for _, att := range atts {
+		stored = append(stored, map[string]any{
+			"name":         att.Name,
+			"contentBytes": base64.StdEncoding.EncodeToString(att.Content),
+		})
+	}
+
+	ad := li.GetAdditionalData()
+	if ad == nil {
+		ad = map[string]any{}
+	}
+
+	ad[ListItemAttachmentsKey] = stored
+
+	li.SetAdditionalData(ad)
+}
+
+// ListItemAttachments returns the attached files stored within the list
+// item, if the backup captured any.
+func ListItemAttachments(li models.ListItemable) ([]ListItemAttachment, error) {
+	var (
+		raw  = li.GetAdditionalData()[ListItemAttachmentsKey]
+		atts []ListItemAttachment
+	)
+
+	add := func(m map[string]any) error {
+		content, err := base64.StdEncoding.DecodeString(anyToString(m["contentBytes"]))
+		if err != nil {
+			return clues.Wrap(err, "decoding list item attachment content")
+		}
+
+		atts = append(atts, ListItemAttachment{
+			Name:    anyToString(m["name"]),
+			Content: content,
+		})
+
+		return nil
+	}
+
+	switch v := raw.(type) {
+	// as set during backup
+	case []map[string]any:
+		for _, m := range v {
+			if err := add(m); err != nil {
+				return nil, err
+			}
+		}
+	// as deserialized from the stored list
+	case []any:
+		for _, a := range v {
+			m, ok := a.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if err := add(m); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return atts, nil
+}
+
+// ListItemAttachmentCount counts the attached files stored within the list
+// item, without decoding their content.
+func ListItemAttachmentCount(li models.ListItemable) int {
+	switch v := li.GetAdditionalData()[ListItemAttachmentsKey].(type) {
+	case []map[string]any:
+		return len(v)
+	case []any:
+		return len(v)
+	}
+
+	return 0
+}
+
+func anyToString(a any) string {
+	switch v := a.(type) {
+	case *string:
+		return ptr.Val(v)
+	case string:
+		return v
+	}
+
+	return ""
+}
@@ -0,0 +1,360 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+type ListItemContentUnitSuite struct {
+	tester.Suite
+}
+
+func TestListItemContentUnitSuite(t *testing.T) {
+	suite.Run(t, &ListItemContentUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+type mockSharePointRequester struct {
+	// responses maps request urls to response bodies.
+	responses map[string]string
+	// statuses maps request urls to error statuses.
+	statuses map[string]int
+	requests []string
+	// bodies and headers hold the body and headers of each request.
+	bodies  []string
+	headers []map[string]string
+}
+
+func (m *mockSharePointRequester) Request(
+	_ context.Context,
+	method, url string,
+	reqBody io.Reader,
+	headers map[string]string,
+	_ bool,
+) (*http.Response, error) {
+	m.requests = append(m.requests, method+" "+url)
+	m.headers = append(m.headers, headers)
+
+	var bs []byte
+
+	if reqBody != nil {
+		bs, _ = io.ReadAll(reqBody)
+	}
+
+	m.bodies = append(m.bodies, string(bs))
+
+	if status, ok := m.statuses[url]; ok {
+		return &http.Response{
+			Status:     http.StatusText(status),
+			StatusCode: status,
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+		}, nil
+	}
+
+	body, ok := m.responses[url]
+	if !ok {
+		return &http.Response{
+			Status:     "404 Not Found",
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+		}, nil
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+	}, nil
+}
+
+func (suite *ListItemContentUnitSuite) TestGetListItemAttachments() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	attURL := "https://example.com/sites/s/_api/web/lists(guid'list')/items(1)/AttachmentFiles"
+
+	rqr := &mockSharePointRequester{
+		responses: map[string]string{
+			attURL:                              `{"value":[{"FileName":"a.txt"},{"FileName":"it's b.txt"}]}`,
+			attURL + "('a.txt')/$value":         "content a",
+			attURL + "('it''s%20b.txt')/$value": "content b",
+		},
+	}
+
+	c := Lists{Client{SharePointRequester: rqr}}
+	errs := fault.New(true)
+
+	atts, err := c.GetListItemAttachments(
+		ctx,
+		"https://example.com/sites/s",
+		"list",
+		"1",
+		newContentBudget(maxListAttachmentsSize),
+		errs)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Empty(t, errs.Skipped())
+
+	expect := []ListItemAttachment{
+		{Name: "a.txt", Content: []byte("content a")},
+		{Name: "it's b.txt", Content: []byte("content b")},
+	}
+	assert.Equal(t, expect, atts)
+
+	_, err = c.GetListItemAttachments(
+		ctx,
+		"https://example.com/sites/s",
+		"list",
+		"2",
+		newContentBudget(maxListAttachmentsSize),
+		errs)
+	require.Error(t, err)
+	assert.True(t, clues.HasLabel(err, graph.LabelStatus(http.StatusNotFound)), clues.ToCore(err))
+}
+
+func (suite *ListItemContentUnitSuite) TestGetListItemAttachments_limitsAndFailures() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	attURL := "https://example.com/sites/s/_api/web/lists(guid'list')/items(1)/AttachmentFiles"
+
+	rqr := &mockSharePointRequester{
+		responses: map[string]string{
+			attURL:                      `{"value":[{"FileName":"a.txt"},{"FileName":"b.txt"},{"FileName":"c.txt"}]}`,
+			attURL + "('a.txt')/$value": "content a",
+			attURL + "('b.txt')/$value": "content b",
+		},
+		statuses: map[string]int{
+			attURL + "('c.txt')/$value": http.StatusInternalServerError,
+		},
+	}
+
+	c := Lists{Client{SharePointRequester: rqr}}
+	errs := fault.New(false)
+
+	// only room for one of the files.
+	budget := newContentBudget(int64(len("content a") + 1))
+
+	atts, err := c.GetListItemAttachments(ctx, "https://example.com/sites/s", "list", "1", budget, errs)
+	require.NoError(t, err, clues.ToCore(err))
+	require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+
+	assert.Equal(t, []ListItemAttachment{{Name: "a.txt", Content: []byte("content a")}}, atts)
+	assert.Equal(t, int64(1), budget.remaining)
+
+	skipped := errs.Skipped()
+	require.Len(t, skipped, 1)
+	assert.True(t, skipped[0].HasCause(fault.SkipListItemAttachmentTooLarge), skipped[0].String())
+	assert.Equal(t, "b.txt", skipped[0].Item.Name)
+
+	assert.Len(t, errs.Recovered(), 1, "failed download is recoverable")
+}
+
+func (suite *ListItemContentUnitSuite) TestPopulateListItemAttachments_denied() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	attURL := "https://example.com/sites/s/_api/web/lists(guid'list')/items(1)/AttachmentFiles"
+
+	rqr := &mockSharePointRequester{
+		statuses: map[string]int{attURL: http.StatusUnauthorized},
+	}
+
+	var (
+		c     = Lists{Client{SharePointRequester: rqr}}
+		errs  = fault.New(true)
+		state = newListContentState()
+	)
+
+	state.siteURL = "https://example.com/sites/s"
+
+	for _, id := range []string{"1", "2"} {
+		li := models.NewListItem()
+		li.SetId(ptr.To(id))
+
+		c.populateListItemAttachments(ctx, "site", "list", state, li, errs)
+
+		assert.Empty(t, ListItemAttachmentCount(li))
+	}
+
+	require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+	assert.Empty(t, errs.Recovered())
+
+	skipped := errs.Skipped()
+	require.Len(t, skipped, 2)
+
+	for _, s := range skipped {
+		assert.True(t, s.HasCause(fault.SkipListItemAttachmentsDenied), s.String())
+	}
+
+	// the second item doesn't retry after the first was denied.
+	assert.Equal(t, []string{http.MethodGet + " " + attURL}, rqr.requests)
+}
+
+func (suite *ListItemContentUnitSuite) TestPostListItemAttachment() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	attURL := "https://example.com/sites/s/_api/web/lists(guid'list')/items(1)/AttachmentFiles"
+	addURL := attURL + "/add(FileName='a.txt')"
+
+	rqr := &mockSharePointRequester{
+		responses: map[string]string{addURL: "{}"},
+	}
+
+	c := Lists{Client{SharePointRequester: rqr}}
+
+	err := c.PostListItemAttachment(
+		ctx,
+		"https://example.com/sites/s",
+		"list",
+		"1",
+		ListItemAttachment{Name: "a.txt", Content: []byte("content")})
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, []string{http.MethodPost + " " + addURL}, rqr.requests)
+}
+
+func (suite *ListItemContentUnitSuite) TestListItemAttachments_roundTrip() {
+	t := suite.T()
+
+	atts := []ListItemAttachment{
+		{Name: "a.txt", Content: []byte("content a")},
+		{Name: "b.png", Content: []byte{0, 1, 2}},
+	}
+
+	li := models.NewListItem()
+	li.SetId(ptr.To("1"))
+	SetListItemAttachments(li, atts)
+
+	result, err := ListItemAttachments(li)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, atts, result)
+
+	list := models.NewList()
+	list.SetItems([]models.ListItemable{li})
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err = writer.WriteObjectValue("", list)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	stored, err := BytesToListable(bs)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, stored.GetItems(), 1)
+
+	storedItem := stored.GetItems()[0]
+	assert.Equal(t, 2, ListItemAttachmentCount(storedItem))
+
+	result, err = ListItemAttachments(storedItem)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, atts, result)
+
+	info := ListToSPInfo(stored)
+	assert.Equal(t, int64(2), info.List.AttachmentCount)
+
+	// attached files are uploaded separately from the list item.
+	clone := CloneListItem(storedItem, map[string]*columnDetails{})
+	assert.NotContains(t, clone.GetAdditionalData(), ListItemAttachmentsKey)
+	assert.Contains(t, storedItem.GetAdditionalData(), ListItemAttachmentsKey)
+}
+
+func (suite *ListItemContentUnitSuite) TestHasListItemAttachments() {
+	table := []struct {
+		name   string
+		fields map[string]any
+		expect assert.BoolAssertionFunc
+	}{
+		{
+			name:   "no fields",
+			expect: assert.False,
+		},
+		{
+			name:   "no attachments",
+			fields: map[string]any{listItemAttachmentsField: ptr.To(false)},
+			expect: assert.False,
+		},
+		{
+			name:   "attachments",
+			fields: map[string]any{listItemAttachmentsField: ptr.To(true)},
+			expect: assert.True,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			var fields models.FieldValueSetable
+
+			if test.fields != nil {
+				fields = models.NewFieldValueSet()
+				fields.SetAdditionalData(test.fields)
+			}
+
+			test.expect(suite.T(), hasListItemAttachments(fields))
+		})
+	}
+}
+
+func (suite *ListItemContentUnitSuite) TestPriorListItemVersions() {
+	t := suite.T()
+
+	newVersion := func(id string) models.ListItemVersionable {
+		v := models.NewListItemVersion()
+		v.SetId(ptr.To(id))
+
+		return v
+	}
+
+	li := models.NewListItem()
+	assert.Empty(t, priorListItemVersions(li))
+
+	li.SetVersions([]models.ListItemVersionable{newVersion("3.0")})
+	assert.Empty(t, priorListItemVersions(li))
+
+	li.SetVersions([]models.ListItemVersionable{
+		newVersion("3.0"),
+		newVersion("2.0"),
+		newVersion("1.0"),
+	})
+
+	result := priorListItemVersions(li)
+	require.Len(t, result, 2)
+	assert.Equal(t, "1.0", ptr.Val(result[0].GetId()))
+	assert.Equal(t, "2.0", ptr.Val(result[1].GetId()))
+
+	// the stored item is left unchanged.
+	assert.Equal(t, "3.0", ptr.Val(li.GetVersions()[0].GetId()))
+
+	info := ListToSPInfo(listWithItems(li))
+	assert.Equal(t, int64(3), info.List.VersionCount)
+}
+
+func listWithItems(items ...models.ListItemable) models.Listable {
+	list := models.NewList()
+	list.SetItems(items)
+
+	return list
+}
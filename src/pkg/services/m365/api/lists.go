@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
 	"strings"
 
@@ -87,9 +88,10 @@ func (c Lists) PostDrive(
 // Makes additional calls to retrieve the following relationships:
 // - Columns
 // - ContentTypes
-// - List Items
+// - List Items, with their attached files and, optionally, their versions
 func (c Lists) GetListByID(ctx context.Context,
 	siteID, listID string,
+	errs *fault.Bus,
 ) (models.Listable, *details.SharePointInfo, error) {
 	list, err := c.Stable.
 		Client().
@@ -102,7 +104,7 @@ func (c Lists) GetListByID(ctx context.Context,
 		return nil, nil, clues.Wrap(err, "fetching list")
 	}
 
-	cols, cTypes, lItems, err := c.getListContents(ctx, siteID, listID)
+	cols, cTypes, lItems, err := c.getListContents(ctx, siteID, listID, errs)
 	if err != nil {
 		return nil, nil, clues.Wrap(err, "getting list contents")
 	}
@@ -117,7 +119,11 @@ func (c Lists) GetListByID(ctx context.Context,
 // getListContents utility function to retrieve associated M365 relationships
 // which are not included with the standard List query:
 // - Columns, ContentTypes, ListItems
-func (c Lists) getListContents(ctx context.Context, siteID, listID string) (
+func (c Lists) getListContents(
+	ctx context.Context,
+	siteID, listID string,
+	errs *fault.Bus,
+) (
 	[]models.ColumnDefinitionable,
 	[]models.ContentTypeable,
 	[]models.ListItemable,
@@ -154,6 +160,8 @@ func (c Lists) getListContents(ctx context.Context, siteID, listID string) (
 		return nil, nil, nil, err
 	}
 
+	state := newListContentState()
+
 	for _, li := range lItems {
 		fields, err := c.getListItemFields(ctx, siteID, listID, ptr.Val(li.GetId()))
 		if err != nil {
@@ -161,6 +169,17 @@ func (c Lists) getListContents(ctx context.Context, siteID, listID string) (
 		}
 
 		li.SetFields(fields)
+
+		err = c.populateListItemContent(
+			clues.Add(ctx, "list_item_id", ptr.Val(li.GetId())),
+			siteID,
+			listID,
+			state,
+			li,
+			errs)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
 	return cols, cTypes, lItems, nil
@@ -194,21 +213,27 @@ func (c Lists) PostList(
 		return nil, clues.Wrap(err, "creating list")
 	}
 
-	listItems := make([]models.ListItemable, 0)
+	var (
+		listID    = ptr.Val(restoredList.GetId())
+		listItems = make([]models.ListItemable, 0, len(storedList.GetItems()))
+		// only looked up if any of the items has attached files.
+		siteURL string
+	)
 
 	for _, itm := range storedList.GetItems() {
-		temp := CloneListItem(itm, columnNames)
-		listItems = append(listItems, temp)
-	}
+		ictx := clues.Add(ctx, "list_item_id", ptr.Val(itm.GetId()))
 
-	err = c.PostListItems(
-		ctx,
-		siteID,
-		ptr.Val(restoredList.GetId()),
-		listItems)
-	if err != nil {
-		err = clues.Wrap(err, "creating list item")
-		el.AddRecoverable(ctx, err)
+		restoredItem, err := c.PostListItem(ictx, siteID, listID, itm, columnNames)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "creating list item"))
+			continue
+		}
+
+		listItems = append(listItems, restoredItem)
+
+		if err := c.postListItemAttachments(ictx, siteID, listID, &siteURL, itm, restoredItem); err != nil {
+			el.AddRecoverable(ictx, err)
+		}
 	}
 
 	restoredList.SetItems(listItems)
@@ -216,25 +241,87 @@ func (c Lists) PostList(
 	return restoredList, nil
 }
 
-func (c Lists) PostListItems(
+// PostListItem creates the stored list item in the list.  If the stored
+// item holds its version history, the item is created from its oldest
+// version, and each following version is replayed on top of it, ending with
+// the item's current field values.
+func (c Lists) PostListItem(
 	ctx context.Context,
 	siteID, listID string,
-	listItems []models.ListItemable,
+	storedItem models.ListItemable,
+	columnNames map[string]*columnDetails,
+) (models.ListItemable, error) {
+	var (
+		newItem = CloneListItem(storedItem, columnNames)
+		prior   = priorListItemVersions(storedItem)
+	)
+
+	if len(prior) > 0 {
+		newItem.SetFields(retrieveFieldData(prior[0].GetFields(), columnNames))
+	}
+
+	restoredItem, err := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		Lists().
+		ByListId(listID).
+		Items().
+		Post(ctx, newItem, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "creating item in list")
+	}
+
+	if len(prior) == 0 {
+		return restoredItem, nil
+	}
+
+	itemID := ptr.Val(restoredItem.GetId())
+
+	for _, v := range prior[1:] {
+		err := c.PatchListItemFields(ctx, siteID, listID, itemID, retrieveFieldData(v.GetFields(), columnNames))
+		if err != nil {
+			return nil, clues.Wrap(err, "restoring list item version").With("list_item_version", ptr.Val(v.GetId()))
+		}
+	}
+
+	err = c.PatchListItemFields(ctx, siteID, listID, itemID, retrieveFieldData(storedItem.GetFields(), columnNames))
+	if err != nil {
+		return nil, clues.Wrap(err, "restoring current list item version")
+	}
+
+	return restoredItem, nil
+}
+
+func (c Lists) postListItemAttachments(
+	ctx context.Context,
+	siteID, listID string,
+	siteURL *string,
+	storedItem, restoredItem models.ListItemable,
 ) error {
-	for _, lItem := range listItems {
-		_, err := c.Stable.
-			Client().
-			Sites().
-			BySiteId(siteID).
-			Lists().
-			ByListId(listID).
-			Items().
-			Post(ctx, lItem, nil)
+	atts, err := ListItemAttachments(storedItem)
+	if err != nil || len(atts) == 0 {
+		return clues.Stack(err).OrNil()
+	}
+
+	if len(ptr.Val(siteURL)) == 0 {
+		u, err := c.getSiteWebURL(ctx, siteID)
+		if err != nil {
+			return clues.Stack(err)
+		}
+
+		*siteURL = u
+	}
+
+	for _, att := range atts {
+		err := c.PostListItemAttachment(ctx, ptr.Val(siteURL), listID, ptr.Val(restoredItem.GetId()), att)
 		if err != nil {
-			return clues.Wrap(err, "creating item in list")
+			return clues.Stack(err)
 		}
 	}
 
+	SetListItemAttachments(restoredItem, atts)
+
 	return nil
 }
 
@@ -467,7 +554,12 @@ func CloneListItem(orig models.ListItemable, columnNames map[string]*columnDetai
 	newItem.SetFields(newFieldData)
 
 	// list item attributes
-	newItem.SetAdditionalData(orig.GetAdditionalData())
+	// attached files are stored alongside the item's attributes, but have
+	// to be uploaded separately.
+	additionalData := maps.Clone(orig.GetAdditionalData())
+	delete(additionalData, ListItemAttachmentsKey)
+
+	newItem.SetAdditionalData(additionalData)
 	newItem.SetDescription(orig.GetDescription())
 	newItem.SetCreatedBy(orig.GetCreatedBy())
 	newItem.SetCreatedDateTime(orig.GetCreatedDateTime())
@@ -476,7 +568,6 @@ func CloneListItem(orig models.ListItemable, columnNames map[string]*columnDetai
 	newItem.SetOdataType(orig.GetOdataType())
 	newItem.SetAnalytics(orig.GetAnalytics())
 	newItem.SetContentType(orig.GetContentType())
-	newItem.SetVersions(orig.GetVersions())
 
 	// Requires nil checks to avoid Graph error: 'Invalid request'
 	lastCreatedByUser := orig.GetCreatedByUser()
@@ -790,8 +881,15 @@ func ListToSPInfo(lst models.Listable) *details.SharePointInfo {
 		created  = ptr.Val(lst.GetCreatedDateTime())
 		modified = ptr.Val(lst.GetLastModifiedDateTime())
 		count    = len(lst.GetItems())
+		atts     int
+		versions int
 	)
 
+	for _, li := range lst.GetItems() {
+		atts += ListItemAttachmentCount(li)
+		versions += len(li.GetVersions())
+	}
+
 	template := ""
 	if lst.GetList() != nil {
 		template = ptr.Val(lst.GetList().GetTemplate())
@@ -803,9 +901,11 @@ func ListToSPInfo(lst models.Listable) *details.SharePointInfo {
 		Created:  created,
 		WebURL:   webURL,
 		List: &details.ListInfo{
-			Name:      name,
-			ItemCount: int64(count),
-			Template:  template,
+			Name:            name,
+			ItemCount:       int64(count),
+			Template:        template,
+			AttachmentCount: int64(atts),
+			VersionCount:    int64(versions),
 		},
 	}
 }
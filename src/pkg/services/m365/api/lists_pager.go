@@ -475,3 +475,86 @@ func (c Lists) GetColumnLinks(
 
 	return items, clues.Stack(err).OrNil()
 }
+
+// ---------------------------------------------------------------------------
+// list item versions pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.ListItemVersionable] = &listItemVersionsPageCtrl{}
+
+type listItemVersionsPageCtrl struct {
+	siteID  string
+	listID  string
+	itemID  string
+	gs      graph.Servicer
+	builder *sites.ItemListsItemItemsItemVersionsRequestBuilder
+	options *sites.ItemListsItemItemsItemVersionsRequestBuilderGetRequestConfiguration
+}
+
+func (p *listItemVersionsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = sites.NewItemListsItemItemsItemVersionsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *listItemVersionsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.ListItemVersionable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *listItemVersionsPageCtrl) ValidModTimes() bool {
+	return true
+}
+
+func (c Lists) NewListItemVersionsPager(
+	siteID string,
+	listID string,
+	itemID string,
+	cc CallConfig,
+) *listItemVersionsPageCtrl {
+	builder := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		Lists().
+		ByListId(listID).
+		Items().
+		ByListItemId(itemID).
+		Versions()
+
+	options := &sites.ItemListsItemItemsItemVersionsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &sites.ItemListsItemItemsItemVersionsRequestBuilderGetQueryParameters{
+			// the field values of each version are only returned when expanded.
+			Expand: []string{"fields"},
+		},
+		Headers: newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+	}
+
+	if len(cc.Select) > 0 {
+		options.QueryParameters.Select = cc.Select
+	}
+
+	return &listItemVersionsPageCtrl{
+		siteID:  siteID,
+		listID:  listID,
+		itemID:  itemID,
+		builder: builder,
+		gs:      c.Stable,
+		options: options,
+	}
+}
+
+// GetListItemVersions fetches the version history of the list item, most
+// recent version first.
+func (c Lists) GetListItemVersions(
+	ctx context.Context,
+	siteID string,
+	listID string,
+	itemID string,
+	cc CallConfig,
+) ([]models.ListItemVersionable, error) {
+	pager := c.NewListItemVersionsPager(siteID, listID, itemID, cc)
+	items, err := pagers.BatchEnumerateItems[models.ListItemVersionable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/control/testdata"
 	"github.com/alcionai/corso/src/pkg/errs/core"
 	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
 	graphTD "github.com/alcionai/corso/src/pkg/services/m365/api/graph/testdata"
 )
 
@@ -1301,7 +1303,7 @@ func (suite *ListsAPIIntgSuite) TestLists_GetListByID() {
 			defer gock.Off()
 			test.setupf()
 
-			list, info, err := suite.its.gockAC.Lists().GetListByID(ctx, siteID, listID)
+			list, info, err := suite.its.gockAC.Lists().GetListByID(ctx, siteID, listID, fault.New(true))
 			test.expect(t, err)
 			assert.Equal(t, listID, *list.GetId())
 
@@ -1377,6 +1379,59 @@ func (suite *ListsAPIIntgSuite) TestLists_PostList() {
 	require.NoError(t, err)
 }
 
+func (suite *ListsAPIIntgSuite) TestLists_ListItemAttachments() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		acl      = suite.its.ac.Lists()
+		siteID   = suite.its.site.id
+		listName = testdata.DefaultRestoreConfig("list_api_attachments").Location
+		att      = ListItemAttachment{Name: "attached.txt", Content: []byte("attached content")}
+	)
+
+	_, list := getFieldsDataAndList()
+	SetListItemAttachments(list.GetItems()[0], []ListItemAttachment{att})
+
+	restoreErrs := fault.New(false)
+
+	newList, err := acl.PostList(ctx, siteID, listName, list, restoreErrs)
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer func() {
+		err := acl.DeleteList(ctx, siteID, ptr.Val(newList.GetId()))
+		assert.NoError(t, err, clues.ToCore(err))
+	}()
+
+	for _, err := range restoreErrs.Recovered() {
+		// attachments go through the SharePoint REST api, which rejects
+		// app-only tokens unless they were granted through a certificate.
+		if clues.HasLabel(err, graph.LabelStatus(http.StatusUnauthorized)) ||
+			clues.HasLabel(err, graph.LabelStatus(http.StatusForbidden)) {
+			t.Skip("sharepoint denied access to list item attachments:", err)
+		}
+
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	errs := fault.New(true)
+
+	backedUp, _, err := acl.GetListByID(ctx, siteID, ptr.Val(newList.GetId()), errs)
+	require.NoError(t, err, clues.ToCore(err))
+	require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+	assert.Empty(t, errs.Recovered())
+	assert.Empty(t, errs.Skipped())
+
+	items := backedUp.GetItems()
+	require.Len(t, items, 1)
+
+	atts, err := ListItemAttachments(items[0])
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, []ListItemAttachment{att}, atts)
+}
+
 func (suite *ListsAPIIntgSuite) TestLists_PostList_invalidTemplate() {
 	t := suite.T()
 
@@ -1465,7 +1520,7 @@ func (suite *ListsAPIIntgSuite) TestLists_PatchList() {
 	require.NoError(t, err)
 	assert.Equal(t, newListName, ptr.Val(patchedList.GetDisplayName()))
 
-	patchedList, _, err = acl.GetListByID(ctx, siteID, listID)
+	patchedList, _, err = acl.GetListByID(ctx, siteID, listID, fault.New(true))
 	require.NoError(t, err)
 
 	newListItems := patchedList.GetItems()
@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/common/sanitize"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/errs/core"
 	"github.com/alcionai/corso/src/pkg/fault"
@@ -25,6 +27,11 @@ import (
 
 const (
 	mailFoldersBetaURLTemplate = "https://graph.microsoft.com/beta/users/%s/mailFolders"
+	mailFolderMessagesURLFmt   = "https://graph.microsoft.com/v1.0/users/%s/mailFolders/%s/messages"
+
+	// MailMIMEContentKey is the additionalData key that holds the base64
+	// encoded MIME content of a message within its serialized json.
+	MailMIMEContentKey = "@corso.mimeContent"
 )
 
 // ---------------------------------------------------------------------------
@@ -235,7 +242,8 @@ func (c Mail) GetContainerChildren(
 // ---------------------------------------------------------------------------
 
 // GetItem retrieves a Messageable item.  If the item contains an attachment, that
-// attachment is also downloaded.
+// attachment is also downloaded.  When the client options ask for the item's MIME
+// content, that content is downloaded as well and stored within the message.
 func (c Mail) GetItem(
 	ctx context.Context,
 	userID, mailID string,
@@ -261,6 +269,21 @@ func (c Mail) GetItem(
 		return nil, nil, clues.Stack(err)
 	}
 
+	if c.options.MailMIME == control.MailMIMEOnly {
+		// the MIME content holds the body and attachments, so we can skip
+		// fetching the attachments altogether.
+		mime, err := c.GetItemMIME(ctx, userID, mailID)
+		if err != nil {
+			return nil, nil, clues.Stack(err)
+		}
+
+		mail.SetBody(nil)
+		mail.SetAttachments(nil)
+		SetMailMIMEContent(mail, mime)
+
+		return mail, MailInfo(mail, int64(len(mime))), nil
+	}
+
 	mailBody = mail.GetBody()
 	if mailBody != nil {
 		content := ptr.Val(mailBody.GetContent())
@@ -269,10 +292,35 @@ func (c Mail) GetItem(
 		}
 	}
 
-	if !ptr.Val(mail.GetHasAttachments()) && !HasAttachments(mailBody) {
-		return mail, MailInfo(mail, size), nil
+	if ptr.Val(mail.GetHasAttachments()) || HasAttachments(mailBody) {
+		totalSize, err := c.setAttachments(ctx, userID, mailID, mail, errs)
+		if err != nil {
+			return nil, nil, clues.Stack(err)
+		}
+
+		size += totalSize
+	}
+
+	if c.options.MailMIME == control.MailMIMEInclude {
+		mime, err := c.GetItemMIME(ctx, userID, mailID)
+		if err != nil {
+			return nil, nil, clues.Stack(err)
+		}
+
+		SetMailMIMEContent(mail, mime)
 	}
 
+	return mail, MailInfo(mail, size), nil
+}
+
+// setAttachments downloads the message's attachments and adds them to the
+// message.  Returns the total size of the attachments.
+func (c Mail) setAttachments(
+	ctx context.Context,
+	userID, mailID string,
+	mail models.Messageable,
+	errs *fault.Bus,
+) (int64, error) {
 	attachments, totalSize, err := c.getAttachments(ctx, userID, mailID)
 	if err != nil {
 		// A failure can be caused by having a lot of attachments.
@@ -287,15 +335,33 @@ func (c Mail) GetItem(
 			mailID,
 			errs)
 		if err != nil {
-			return nil, nil, clues.Stack(err)
+			return 0, clues.Stack(err)
 		}
 	}
 
-	size += totalSize
+	mail.SetAttachments(c.getNestedAttachments(ctx, userID, mailID, attachments, errs))
 
-	mail.SetAttachments(attachments)
+	return totalSize, nil
+}
 
-	return mail, MailInfo(mail, size), nil
+// GetItemMIME retrieves the raw MIME content of the message.
+func (c Mail) GetItemMIME(
+	ctx context.Context,
+	userID, mailID string,
+) ([]byte, error) {
+	mime, err := c.LargeItem.
+		Client().
+		Users().
+		ByUserId(userID).
+		Messages().
+		ByMessageId(mailID).
+		Content().
+		Get(ctx, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting mail mime content")
+	}
+
+	return mime, nil
 }
 
 // getAttachments attempts to get all attachments, including their content, in a singe query.
@@ -308,7 +374,7 @@ func (c Mail) getAttachments(
 		totalSize int64
 		cfg       = &users.ItemMessagesItemAttachmentsRequestBuilderGetRequestConfiguration{
 			QueryParameters: &users.ItemMessagesItemAttachmentsRequestBuilderGetQueryParameters{
-				Expand: []string{"microsoft.graph.itemattachment/item"},
+				Expand: []string{itemAttachmentExpand(1)},
 			},
 			Headers: newPreferHeaders(
 				preferPageSize(maxNonDeltaPageSize),
@@ -408,22 +474,12 @@ func (c Mail) getAttachmentByID(
 	isItemAttachment bool,
 	errs *fault.Bus,
 ) (models.Attachmentable, error) {
-	cfg := &users.ItemMessagesItemAttachmentsAttachmentItemRequestBuilderGetRequestConfiguration{
-		QueryParameters: &users.ItemMessagesItemAttachmentsAttachmentItemRequestBuilderGetQueryParameters{
-			Expand: []string{"microsoft.graph.itemattachment/item"},
-		},
-		Headers: newPreferHeaders(preferImmutableIDs(c.options.ToggleFeatures.ExchangeImmutableIDs)),
-	}
-
-	attachment, err := c.Stable.
-		Client().
-		Users().
-		ByUserId(userID).
-		Messages().
-		ByMessageId(mailID).
-		Attachments().
-		ByAttachmentId(attachmentID).
-		Get(ctx, cfg)
+	attachment, err := c.getExpandedAttachment(
+		ctx,
+		userID,
+		mailID,
+		attachmentID,
+		itemAttachmentExpand(1))
 	if err != nil {
 		// CannotOpenFileAttachment errors are not transient and
 		// happens possibly from the original item somehow getting
@@ -453,6 +509,65 @@ func (c Mail) getAttachmentByID(
 	return attachment, nil
 }
 
+// getNestedAttachments re-fetches each item attachment whose item holds
+// attachments of its own, expanding those nested attachments down to
+// MaxNestedAttachmentDepth.  If the re-fetch fails, the attachment is kept
+// as originally retrieved, without its nested attachments, and the failure
+// gets reported as a recoverable error.
+func (c Mail) getNestedAttachments(
+	ctx context.Context,
+	userID, mailID string,
+	attachments []models.Attachmentable,
+	errs *fault.Bus,
+) []models.Attachmentable {
+	for i, a := range attachments {
+		if !hasUnfetchedNestedAttachments(a) {
+			continue
+		}
+
+		ictx := clues.Add(ctx, "attachment_id", ptr.Val(a.GetId()))
+
+		nested, err := c.getExpandedAttachment(
+			ictx,
+			userID,
+			mailID,
+			ptr.Val(a.GetId()),
+			itemAttachmentExpand(MaxNestedAttachmentDepth))
+		if err != nil {
+			errs.AddRecoverable(ictx, clues.Wrap(err, "fetching nested item attachments"))
+			continue
+		}
+
+		attachments[i] = nested
+	}
+
+	return attachments
+}
+
+func (c Mail) getExpandedAttachment(
+	ctx context.Context,
+	userID, mailID, attachmentID, expand string,
+) (models.Attachmentable, error) {
+	cfg := &users.ItemMessagesItemAttachmentsAttachmentItemRequestBuilderGetRequestConfiguration{
+		QueryParameters: &users.ItemMessagesItemAttachmentsAttachmentItemRequestBuilderGetQueryParameters{
+			Expand: []string{expand},
+		},
+		Headers: newPreferHeaders(preferImmutableIDs(c.options.ToggleFeatures.ExchangeImmutableIDs)),
+	}
+
+	attachment, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		Messages().
+		ByMessageId(mailID).
+		Attachments().
+		ByAttachmentId(attachmentID).
+		Get(ctx, cfg)
+
+	return attachment, graph.Stack(ctx, err).OrNil()
+}
+
 func (c Mail) PostItem(
 	ctx context.Context,
 	userID, containerID string,
@@ -477,6 +592,47 @@ func (c Mail) PostItem(
 	return itm, nil
 }
 
+// PostItemMIME creates a message in the container from its raw MIME
+// content.  Graph creates all messages posted as MIME in a draft state.
+func (c Mail) PostItemMIME(
+	ctx context.Context,
+	userID, containerID string,
+	mime []byte,
+) (models.Messageable, error) {
+	var (
+		rawURL  = fmt.Sprintf(mailFolderMessagesURLFmt, userID, containerID)
+		body    = strings.NewReader(base64.StdEncoding.EncodeToString(mime))
+		headers = map[string]string{
+			"Content-Type": "text/plain",
+		}
+	)
+
+	resp, err := c.Post(ctx, rawURL, headers, body, true)
+	if err != nil {
+		return nil, clues.Wrap(err, "creating mail message from mime")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return nil, clues.
+			Wrap(clues.NewWC(ctx, resp.Status), "non-2xx http response").
+			Label(graph.LabelStatus(resp.StatusCode))
+	}
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "reading mail message creation response")
+	}
+
+	itm, err := BytesToMessageable(bs)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "parsing mail message creation response")
+	}
+
+	return itm, nil
+}
+
 func (c Mail) MoveItem(
 	ctx context.Context,
 	userID, oldContainerID, newContainerID, itemID string,
@@ -667,6 +823,42 @@ func MailInfo(msg models.Messageable, size int64) *details.ExchangeInfo {
 	}
 }
 
+// SetMailMIMEContent stores the raw MIME content of the message within the
+// message's additional data, so that it gets serialized along with the rest
+// of the message.
+func SetMailMIMEContent(msg models.Messageable, mime []byte) {
+	ad := msg.GetAdditionalData()
+	if ad == nil {
+		ad = map[string]any{}
+	}
+
+	ad[MailMIMEContentKey] = ptr.To(base64.StdEncoding.EncodeToString(mime))
+
+	msg.SetAdditionalData(ad)
+}
+
+// MailMIMEContent returns the raw MIME content stored within the message,
+// if the backup captured it.
+func MailMIMEContent(msg models.Messageable) ([]byte, bool, error) {
+	var encoded string
+
+	switch v := msg.GetAdditionalData()[MailMIMEContentKey].(type) {
+	case *string:
+		encoded = ptr.Val(v)
+	case string:
+		encoded = v
+	default:
+		return nil, false, nil
+	}
+
+	mime, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false, clues.Wrap(err, "decoding mail mime content")
+	}
+
+	return mime, true, nil
+}
+
 func unwrapEmailAddress(contact models.Recipientable) string {
 	var empty string
 	if contact == nil || contact.GetEmailAddress() == nil {
@@ -160,9 +160,116 @@ func (suite *MailAPIUnitSuite) TestMailInfo() {
 	}
 }
 
+func (suite *MailAPIUnitSuite) TestItemAttachmentExpand() {
+	t := suite.T()
+
+	assert.Equal(t, "microsoft.graph.itemattachment/item", itemAttachmentExpand(1))
+	assert.Equal(
+		t,
+		"microsoft.graph.itemattachment/item($expand="+
+			"microsoft.graph.message/attachments($expand=microsoft.graph.itemattachment/item),"+
+			"microsoft.graph.event/attachments($expand=microsoft.graph.itemattachment/item))",
+		itemAttachmentExpand(2))
+}
+
+func (suite *MailAPIUnitSuite) TestHasUnfetchedNestedAttachments() {
+	itemAttachment := func(item models.OutlookItemable) models.Attachmentable {
+		ia := models.NewItemAttachment()
+		ia.SetItem(item)
+
+		return ia
+	}
+
+	msgWithAttachments := func(fetched bool) models.OutlookItemable {
+		msg := models.NewMessage()
+		msg.SetHasAttachments(ptr.To(true))
+
+		if fetched {
+			msg.SetAttachments([]models.Attachmentable{models.NewFileAttachment()})
+		}
+
+		return msg
+	}
+
+	eventWithAttachments := models.NewEvent()
+	eventWithAttachments.SetHasAttachments(ptr.To(true))
+
+	table := []struct {
+		name       string
+		attachment models.Attachmentable
+		expect     assert.BoolAssertionFunc
+	}{
+		{
+			name:       "file attachment",
+			attachment: models.NewFileAttachment(),
+			expect:     assert.False,
+		},
+		{
+			name:       "message without attachments",
+			attachment: itemAttachment(models.NewMessage()),
+			expect:     assert.False,
+		},
+		{
+			name:       "message with unfetched attachments",
+			attachment: itemAttachment(msgWithAttachments(false)),
+			expect:     assert.True,
+		},
+		{
+			name:       "message with fetched attachments",
+			attachment: itemAttachment(msgWithAttachments(true)),
+			expect:     assert.False,
+		},
+		{
+			name:       "event with unfetched attachments",
+			attachment: itemAttachment(eventWithAttachments),
+			expect:     assert.True,
+		},
+		{
+			name:       "contact",
+			attachment: itemAttachment(models.NewContact()),
+			expect:     assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), hasUnfetchedNestedAttachments(test.attachment))
+		})
+	}
+}
+
 // TestBytesToMessagable_InvalidError tests that the error message kiota returns
 // for invalid JSON matches what we check for. This helps keep things in sync
 // when kiota is updated.
+func (suite *MailAPIUnitSuite) TestMailMIMEContent() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	mime := []byte("Subject: mime\r\nContent-Type: text/plain\r\n\r\nbody\r\n")
+
+	msg, err := BytesToMessageable(exchMock.MessageBytes("mime"))
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, ok, err := MailMIMEContent(msg)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.False(t, ok, "mime content before setting it")
+
+	SetMailMIMEContent(msg, mime)
+
+	// the mime content must survive serialization
+	bs, err := Mail{}.Serialize(ctx, msg, "user", "id")
+	require.NoError(t, err, clues.ToCore(err))
+
+	msg, err = BytesToMessageable(bs)
+	require.NoError(t, err, clues.ToCore(err))
+
+	result, ok, err := MailMIMEContent(msg)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.True(t, ok, "mime content after serialization")
+	assert.Equal(t, mime, result)
+}
+
 func (suite *MailAPIUnitSuite) TestBytesToMessagable_InvalidError() {
 	t := suite.T()
 	input := exchMock.MessageWithSpecialCharacters("m365 mail support test")
@@ -324,6 +431,49 @@ func (suite *MailAPIIntgSuite) TestMail_attachmentListDownload() {
 			size:            200,
 			expect:          assert.NoError,
 		},
+		{
+			name: "fetch nested item attachments",
+			setupf: func() {
+				email := models.NewMessage()
+				email.SetId(&mid)
+				email.SetHasAttachments(ptr.To(true))
+
+				interceptV1Path("users", "user", "messages", mid).
+					Reply(200).
+					JSON(graphTD.ParseableToMap(suite.T(), email))
+
+				nestedMsg := models.NewMessage()
+				nestedMsg.SetHasAttachments(ptr.To(true))
+
+				attch := models.NewItemAttachment()
+				attch.SetId(&aid)
+				attch.SetSize(ptr.To(int32(100)))
+				attch.SetItem(nestedMsg)
+
+				atts := models.NewAttachmentCollectionResponse()
+				atts.SetValue([]models.Attachmentable{attch})
+
+				interceptV1Path("users", "user", "messages", mid, "attachments").
+					Reply(200).
+					JSON(graphTD.ParseableToMap(suite.T(), atts))
+
+				expandedMsg := models.NewMessage()
+				expandedMsg.SetHasAttachments(ptr.To(true))
+				expandedMsg.SetAttachments([]models.Attachmentable{models.NewFileAttachment()})
+
+				expanded := models.NewItemAttachment()
+				expanded.SetId(&aid)
+				expanded.SetSize(ptr.To(int32(100)))
+				expanded.SetItem(expandedMsg)
+
+				interceptV1Path("users", "user", "messages", mid, "attachments", aid).
+					Reply(200).
+					JSON(graphTD.ParseableToMap(suite.T(), expanded))
+			},
+			attachmentCount: 1,
+			size:            100,
+			expect:          assert.NoError,
+		},
 		{
 			name: "fetch multiple individual attachments",
 			setupf: func() {
@@ -0,0 +1,450 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) OneNote() OneNote {
+	return OneNote{c}
+}
+
+// OneNote is an interface-compliant provider of the client.
+type OneNote struct {
+	Client
+}
+
+const (
+	oneNoteRootURLFmt = "https://graph.microsoft.com/v1.0/%s/onenote"
+
+	// OneNotePageHTMLPartName is the name of the multipart form part that
+	// holds the html presentation of a page when creating pages.
+	OneNotePageHTMLPartName = "Presentation"
+)
+
+// UserOneNoteOwner produces the owner segment used to address the notebooks
+// belonging to the given user.
+func UserOneNoteOwner(userID string) string {
+	return "users/" + userID
+}
+
+// SiteOneNoteOwner produces the owner segment used to address the notebooks
+// belonging to the given site.
+func SiteOneNoteOwner(siteID string) string {
+	return "sites/" + siteID
+}
+
+func oneNoteRootURL(owner string) string {
+	return fmt.Sprintf(oneNoteRootURLFmt, owner)
+}
+
+// OneNoteNotebookContainer produces the segment used to address the
+// sections and section groups held directly by the notebook.
+func OneNoteNotebookContainer(notebookID string) string {
+	return "notebooks/" + notebookID
+}
+
+// OneNoteSectionGroupContainer produces the segment used to address the
+// sections and section groups held by the section group.
+func OneNoteSectionGroupContainer(sectionGroupID string) string {
+	return "sectionGroups/" + sectionGroupID
+}
+
+// ---------------------------------------------------------------------------
+// sections
+// ---------------------------------------------------------------------------
+
+var ErrOneNoteSectionNotFound = clues.New("onenote section not found")
+
+// OneNoteSectionID produces the id of the onenote section stored in the
+// .one drive item with the given sharepoint list item unique id.  Notebooks
+// hosted in onedrive for business and sharepoint address each section by
+// the unique id of its file.
+func OneNoteSectionID(listItemUniqueID string) string {
+	return "1-" + strings.ToLower(strings.Trim(listItemUniqueID, "{}"))
+}
+
+// GetSection retrieves the section, along with its parent notebook and
+// parent section group.
+func (c OneNote) GetSection(
+	ctx context.Context,
+	owner, sectionID string,
+) (models.OnenoteSectionable, error) {
+	var (
+		rawURL  = oneNoteRootURL(owner) + "/sections/" + sectionID
+		builder = users.NewItemOnenoteSectionsOnenoteSectionItemRequestBuilder(rawURL, c.Stable.Adapter())
+		options = &users.ItemOnenoteSectionsOnenoteSectionItemRequestBuilderGetRequestConfiguration{
+			QueryParameters: &users.ItemOnenoteSectionsOnenoteSectionItemRequestBuilderGetQueryParameters{
+				Expand: []string{"parentNotebook", "parentSectionGroup"},
+			},
+		}
+	)
+
+	sect, err := builder.Get(ctx, options)
+	if err != nil {
+		err = graph.Stack(ctx, err)
+
+		if errors.Is(err, core.ErrNotFound) {
+			err = clues.Stack(ErrOneNoteSectionNotFound, err)
+		}
+
+		return nil, clues.Wrap(err, "getting onenote section")
+	}
+
+	return sect, nil
+}
+
+// GetSectionByName looks up the section with the given name held directly
+// by the container, which is either a notebook or a section group.  OneNote
+// section names are unique within their container.
+func (c OneNote) GetSectionByName(
+	ctx context.Context,
+	owner, container, sectionName string,
+) (models.OnenoteSectionable, error) {
+	var (
+		rawURL  = oneNoteRootURL(owner) + "/" + container + "/sections"
+		builder = users.NewItemOnenoteSectionGroupsItemSectionsRequestBuilder(rawURL, c.Stable.Adapter())
+		filter  = fmt.Sprintf("displayName eq '%s'", escapeODataString(sectionName))
+		options = &users.ItemOnenoteSectionGroupsItemSectionsRequestBuilderGetRequestConfiguration{
+			QueryParameters: &users.ItemOnenoteSectionGroupsItemSectionsRequestBuilderGetQueryParameters{
+				Filter: &filter,
+			},
+		}
+	)
+
+	resp, err := builder.Get(ctx, options)
+	if err != nil {
+		return nil, clues.Wrap(graph.Stack(ctx, err), "getting onenote sections")
+	}
+
+	if len(resp.GetValue()) == 0 {
+		return nil, clues.StackWC(ctx, ErrOneNoteSectionNotFound)
+	}
+
+	return resp.GetValue()[0], nil
+}
+
+// PostSection creates a new section in the container, which is either a
+// notebook or a section group.
+func (c OneNote) PostSection(
+	ctx context.Context,
+	owner, container, sectionName string,
+) (models.OnenoteSectionable, error) {
+	rawURL := oneNoteRootURL(owner) + "/" + container + "/sections"
+	builder := users.NewItemOnenoteSectionGroupsItemSectionsRequestBuilder(rawURL, c.Stable.Adapter())
+
+	body := models.NewOnenoteSection()
+	body.SetDisplayName(ptr.To(sectionName))
+
+	section, err := builder.Post(ctx, body, nil)
+	if err != nil {
+		return nil, clues.Wrap(graph.Stack(ctx, err), "creating onenote section")
+	}
+
+	return section, nil
+}
+
+// ---------------------------------------------------------------------------
+// section groups
+// ---------------------------------------------------------------------------
+
+// GetSectionGroup retrieves the section group, along with its parent
+// section group.
+func (c OneNote) GetSectionGroup(
+	ctx context.Context,
+	owner, sectionGroupID string,
+) (models.SectionGroupable, error) {
+	var (
+		rawURL  = oneNoteRootURL(owner) + "/sectionGroups/" + sectionGroupID
+		builder = users.NewItemOnenoteSectionGroupsSectionGroupItemRequestBuilder(rawURL, c.Stable.Adapter())
+		options = &users.ItemOnenoteSectionGroupsSectionGroupItemRequestBuilderGetRequestConfiguration{
+			QueryParameters: &users.ItemOnenoteSectionGroupsSectionGroupItemRequestBuilderGetQueryParameters{
+				Expand: []string{"parentSectionGroup"},
+			},
+		}
+	)
+
+	sg, err := builder.Get(ctx, options)
+	if err != nil {
+		return nil, clues.Wrap(graph.Stack(ctx, err), "getting onenote section group")
+	}
+
+	return sg, nil
+}
+
+// GetOrPostSectionGroup returns the section group with the given name held
+// directly by the container, creating it if no such section group exists.
+func (c OneNote) GetOrPostSectionGroup(
+	ctx context.Context,
+	owner, container, sectionGroupName string,
+) (models.SectionGroupable, error) {
+	var (
+		rawURL  = oneNoteRootURL(owner) + "/" + container + "/sectionGroups"
+		builder = users.NewItemOnenoteNotebooksItemSectionGroupsRequestBuilder(rawURL, c.Stable.Adapter())
+		filter  = fmt.Sprintf("displayName eq '%s'", escapeODataString(sectionGroupName))
+		options = &users.ItemOnenoteNotebooksItemSectionGroupsRequestBuilderGetRequestConfiguration{
+			QueryParameters: &users.ItemOnenoteNotebooksItemSectionGroupsRequestBuilderGetQueryParameters{
+				Filter: &filter,
+			},
+		}
+	)
+
+	resp, err := builder.Get(ctx, options)
+	if err != nil {
+		return nil, clues.Wrap(graph.Stack(ctx, err), "getting onenote section groups")
+	}
+
+	if len(resp.GetValue()) > 0 {
+		return resp.GetValue()[0], nil
+	}
+
+	body := models.NewSectionGroup()
+	body.SetDisplayName(ptr.To(sectionGroupName))
+
+	sg, err := builder.Post(ctx, body, nil)
+	if err != nil {
+		return nil, clues.Wrap(graph.Stack(ctx, err), "creating onenote section group")
+	}
+
+	return sg, nil
+}
+
+// ---------------------------------------------------------------------------
+// notebooks
+// ---------------------------------------------------------------------------
+
+// GetOrPostNotebook returns the notebook with the given name, creating it
+// if no such notebook exists.
+func (c OneNote) GetOrPostNotebook(
+	ctx context.Context,
+	owner, notebookName string,
+) (models.Notebookable, error) {
+	var (
+		rawURL  = oneNoteRootURL(owner) + "/notebooks"
+		builder = users.NewItemOnenoteNotebooksRequestBuilder(rawURL, c.Stable.Adapter())
+		filter  = fmt.Sprintf("displayName eq '%s'", escapeODataString(notebookName))
+		options = &users.ItemOnenoteNotebooksRequestBuilderGetRequestConfiguration{
+			QueryParameters: &users.ItemOnenoteNotebooksRequestBuilderGetQueryParameters{
+				Filter: &filter,
+			},
+		}
+	)
+
+	resp, err := builder.Get(ctx, options)
+	if err != nil {
+		return nil, clues.Wrap(graph.Stack(ctx, err), "getting onenote notebooks")
+	}
+
+	if len(resp.GetValue()) > 0 {
+		return resp.GetValue()[0], nil
+	}
+
+	body := models.NewNotebook()
+	body.SetDisplayName(ptr.To(notebookName))
+
+	nb, err := builder.Post(ctx, body, nil)
+	if err != nil {
+		return nil, clues.Wrap(graph.Stack(ctx, err), "creating onenote notebook")
+	}
+
+	return nb, nil
+}
+
+// ---------------------------------------------------------------------------
+// pages
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.OnenotePageable] = &oneNotePagesPageCtrl{}
+
+type oneNotePagesPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemOnenoteSectionsItemPagesRequestBuilder
+	options *users.ItemOnenoteSectionsItemPagesRequestBuilderGetRequestConfiguration
+}
+
+func (p *oneNotePagesPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemOnenoteSectionsItemPagesRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *oneNotePagesPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.OnenotePageable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *oneNotePagesPageCtrl) ValidModTimes() bool {
+	return true
+}
+
+func (c OneNote) NewSectionPagesPager(
+	owner, sectionID string,
+) *oneNotePagesPageCtrl {
+	rawURL := oneNoteRootURL(owner) + "/sections/" + sectionID + "/pages"
+
+	return &oneNotePagesPageCtrl{
+		gs:      c.Stable,
+		builder: users.NewItemOnenoteSectionsItemPagesRequestBuilder(rawURL, c.Stable.Adapter()),
+		options: &users.ItemOnenoteSectionsItemPagesRequestBuilderGetRequestConfiguration{
+			QueryParameters: &users.ItemOnenoteSectionsItemPagesRequestBuilderGetQueryParameters{
+				// pages default to a 20 item page size, and cap out at 100.
+				Top: ptr.To[int32](100),
+			},
+		},
+	}
+}
+
+// GetSectionPages fetches the metadata for every page in the section.
+func (c OneNote) GetSectionPages(
+	ctx context.Context,
+	owner, sectionID string,
+) ([]models.OnenotePageable, error) {
+	pager := c.NewSectionPagesPager(owner, sectionID)
+	items, err := pagers.BatchEnumerateItems[models.OnenotePageable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
+
+// GetPageContent retrieves the html content of the page.  Images and other
+// binary resources are referenced by url within the content, and must be
+// retrieved separately with GetResourceContent.
+func (c OneNote) GetPageContent(
+	ctx context.Context,
+	owner, pageID string,
+) ([]byte, error) {
+	rawURL := oneNoteRootURL(owner) + "/pages/" + pageID + "/content"
+
+	bs, err := c.getBytes(ctx, rawURL)
+
+	return bs, clues.Wrap(graph.Stack(ctx, err), "getting onenote page content").OrNil()
+}
+
+// GetResourceContent retrieves the bytes of a page resource (images, file
+// attachments) by the url referenced in the page content.
+func (c OneNote) GetResourceContent(
+	ctx context.Context,
+	resourceURL string,
+) ([]byte, error) {
+	bs, err := c.getBytes(ctx, resourceURL)
+	return bs, clues.Wrap(graph.Stack(ctx, err), "getting onenote resource content").OrNil()
+}
+
+func (c OneNote) getBytes(ctx context.Context, rawURL string) ([]byte, error) {
+	resp, err := c.Get(ctx, rawURL, nil, true)
+	if err != nil {
+		return nil, graph.Stack(ctx, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return nil, clues.
+			Wrap(clues.NewWC(ctx, resp.Status), "non-2xx http response").
+			Label(graph.LabelStatus(resp.StatusCode))
+	}
+
+	bs, err := io.ReadAll(resp.Body)
+
+	return bs, clues.Stack(err).OrNil()
+}
+
+// OneNotePageResource is a binary part referenced by name from the html
+// content of a page during page creation.
+type OneNotePageResource struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// PostPage creates a new page in the section using the provided html content.
+// Resources are sent as additional multipart parts, and must be referenced in
+// the html using the `name:<resource name>` syntax.
+func (c OneNote) PostPage(
+	ctx context.Context,
+	owner, sectionID string,
+	html []byte,
+	resources []OneNotePageResource,
+) error {
+	var (
+		rawURL = oneNoteRootURL(owner) + "/sections/" + sectionID + "/pages"
+		body   = &bytes.Buffer{}
+		mpw    = multipart.NewWriter(body)
+	)
+
+	if err := writeMultipartPart(mpw, OneNotePageHTMLPartName, "text/html", html); err != nil {
+		return clues.WrapWC(ctx, err, "writing page html")
+	}
+
+	for _, r := range resources {
+		if err := writeMultipartPart(mpw, r.Name, r.ContentType, r.Data); err != nil {
+			return clues.WrapWC(ctx, err, "writing page resource")
+		}
+	}
+
+	if err := mpw.Close(); err != nil {
+		return clues.WrapWC(ctx, err, "closing multipart body")
+	}
+
+	headers := map[string]string{
+		"Content-Type": mpw.FormDataContentType(),
+	}
+
+	resp, err := c.Post(ctx, rawURL, headers, body, true)
+	if err != nil {
+		return clues.Wrap(graph.Stack(ctx, err), "creating onenote page")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return clues.
+			Wrap(clues.NewWC(ctx, resp.Status), "non-2xx http response").
+			Label(graph.LabelStatus(resp.StatusCode))
+	}
+
+	return nil
+}
+
+func writeMultipartPart(
+	mpw *multipart.Writer,
+	name, contentType string,
+	data []byte,
+) error {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, name))
+	header.Set("Content-Type", contentType)
+
+	w, err := mpw.CreatePart(header)
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	_, err = w.Write(data)
+
+	return clues.Stack(err).OrNil()
+}
+
+// escapeODataString escapes single quotes for use within an odata
+// string literal.
+func escapeODataString(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
@@ -600,9 +600,10 @@ func AddedAndRemovedAddAll[T any](
 
 // for added and removed by additionalData[@removed]
 
-type getIDModAndAddtler interface {
+// the last modified date time is optional here: directory objects such as
+// users and groups support delta queries without exposing a mod time.
+type getIDAndAddtler interface {
 	graph.GetIDer
-	graph.GetLastModifiedDateTimer
 	graph.GetAdditionalDataer
 }
 
@@ -624,9 +625,9 @@ func AddedAndRemovedByAddtlData[T any](
 			continue
 		}
 
-		giaa, ok := any(item).(getIDModAndAddtler)
+		giaa, ok := any(item).(getIDAndAddtler)
 		if !ok {
-			return nil, nil, clues.New("item does not provide id, modified date time, and additional data getters").
+			return nil, nil, clues.New("item does not provide id and additional data getters").
 				With("item_type", fmt.Sprintf("%T", item))
 		}
 
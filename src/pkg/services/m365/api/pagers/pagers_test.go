@@ -44,7 +44,7 @@ func (l deltaNextLink) GetOdataDeltaLink() *string {
 
 // mock item
 
-var _ getIDModAndAddtler = &testItem{}
+var _ getIDAndAddtler = &testItem{}
 
 func removedItem(id string) testItem {
 	return testItem{
@@ -0,0 +1,475 @@
+package api
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	abstractions "github.com/microsoft/kiota-abstractions-go"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/planner"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+const (
+	plannerETagKey   = "@odata.etag"
+	headerKeyIfMatch = "If-Match"
+
+	plannerAssignmentODataType    = "#microsoft.graph.plannerAssignment"
+	plannerChecklistItemODataType = "#microsoft.graph.plannerChecklistItem"
+	plannerReferenceODataType     = "#microsoft.graph.plannerExternalReference"
+
+	// plannerDefaultOrderHint asks planner to place the item ahead of
+	// any existing items.
+	plannerDefaultOrderHint = " !"
+)
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) Planner() Planner {
+	return Planner{c}
+}
+
+// Planner is an interface-compliant provider of the client.
+type Planner struct {
+	Client
+}
+
+// ---------------------------------------------------------------------------
+// Item (plan)
+// ---------------------------------------------------------------------------
+
+// GetPlan retrieves the plan along with its details, buckets, and tasks.
+// Each task is populated with its own details (description, checklist,
+// and references).  Assignments are already included in the task.
+func (c Planner) GetPlan(
+	ctx context.Context,
+	planID string,
+) (models.PlannerPlanable, *details.GroupsInfo, error) {
+	ctx = clues.Add(ctx, "plan_id", planID)
+
+	pb := c.Stable.
+		Client().
+		Planner().
+		Plans().
+		ByPlannerPlanId(planID)
+
+	plan, err := pb.Get(ctx, nil)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting plan")
+	}
+
+	pd, err := pb.Details().Get(ctx, nil)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting plan details")
+	}
+
+	plan.SetDetails(pd)
+
+	buckets, err := c.GetPlanBuckets(ctx, planID)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting plan buckets")
+	}
+
+	plan.SetBuckets(buckets)
+
+	tasks, err := c.GetPlanTasks(ctx, planID)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting plan tasks")
+	}
+
+	for _, task := range tasks {
+		taskID := ptr.Val(task.GetId())
+
+		td, err := c.Stable.
+			Client().
+			Planner().
+			Tasks().
+			ByPlannerTaskId(taskID).
+			Details().
+			Get(ctx, nil)
+		if err != nil {
+			return nil, nil, clues.Wrap(err, "getting task details").With("task_id", taskID)
+		}
+
+		task.SetDetails(td)
+	}
+
+	plan.SetTasks(tasks)
+
+	size, err := plannerPlanSize(plan)
+	if err != nil {
+		return nil, nil, clues.Stack(err)
+	}
+
+	return plan, PlannerPlanInfo(plan, size), nil
+}
+
+// ---------------------------------------------------------------------------
+// Restore
+// ---------------------------------------------------------------------------
+
+// PostPlan creates a new, empty plan owned by the group.
+func (c Planner) PostPlan(
+	ctx context.Context,
+	groupID, title string,
+) (models.PlannerPlanable, error) {
+	container := models.NewPlannerPlanContainer()
+	container.SetContainerId(ptr.To(groupID))
+	container.SetTypeEscaped(ptr.To(models.GROUP_PLANNERCONTAINERTYPE))
+
+	body := models.NewPlannerPlan()
+	body.SetTitle(ptr.To(title))
+	body.SetContainer(container)
+
+	plan, err := c.Stable.
+		Client().
+		Planner().
+		Plans().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "creating plan")
+	}
+
+	return plan, nil
+}
+
+// PatchPlanDetails replaces the category descriptions (labels) of the plan
+// with those in pd.
+func (c Planner) PatchPlanDetails(
+	ctx context.Context,
+	planID string,
+	pd models.PlannerPlanDetailsable,
+) error {
+	if pd == nil || pd.GetCategoryDescriptions() == nil {
+		return nil
+	}
+
+	db := c.Stable.
+		Client().
+		Planner().
+		Plans().
+		ByPlannerPlanId(planID).
+		Details()
+
+	current, err := db.Get(ctx, nil)
+	if err != nil {
+		return clues.Wrap(err, "getting plan details")
+	}
+
+	body := models.NewPlannerPlanDetails()
+	body.SetCategoryDescriptions(pd.GetCategoryDescriptions())
+
+	_, err = db.Patch(
+		ctx,
+		body,
+		&planner.PlansItemDetailsRequestBuilderPatchRequestConfiguration{
+			Headers: ifMatchHeaders(current.GetAdditionalData()),
+		})
+
+	return clues.Wrap(err, "updating plan details").OrNil()
+}
+
+// PostBucket creates a bucket in the plan.
+func (c Planner) PostBucket(
+	ctx context.Context,
+	planID string,
+	bucket models.PlannerBucketable,
+) (models.PlannerBucketable, error) {
+	body := models.NewPlannerBucket()
+	body.SetPlanId(ptr.To(planID))
+	body.SetName(bucket.GetName())
+	body.SetOrderHint(bucket.GetOrderHint())
+
+	created, err := c.Stable.
+		Client().
+		Planner().
+		Buckets().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "creating bucket")
+	}
+
+	return created, nil
+}
+
+// PostTask creates a task in the plan, within the given bucket.  Assignments
+// in the task are carried over to the new task.
+func (c Planner) PostTask(
+	ctx context.Context,
+	planID, bucketID string,
+	task models.PlannerTaskable,
+) (models.PlannerTaskable, error) {
+	body := models.NewPlannerTask()
+	body.SetPlanId(ptr.To(planID))
+	body.SetTitle(task.GetTitle())
+	body.SetOrderHint(task.GetOrderHint())
+	body.SetPercentComplete(task.GetPercentComplete())
+	body.SetPriority(task.GetPriority())
+	body.SetStartDateTime(task.GetStartDateTime())
+	body.SetDueDateTime(task.GetDueDateTime())
+	body.SetAppliedCategories(task.GetAppliedCategories())
+	body.SetPreviewType(task.GetPreviewType())
+
+	if len(bucketID) > 0 {
+		body.SetBucketId(ptr.To(bucketID))
+	}
+
+	if assignments := restorableAssignments(task.GetAssignments()); assignments != nil {
+		body.SetAssignments(assignments)
+	}
+
+	created, err := c.Stable.
+		Client().
+		Planner().
+		Tasks().
+		Post(ctx, body, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "creating task")
+	}
+
+	return created, nil
+}
+
+// PatchTaskDetails replaces the description, checklist, and references of
+// the task with those in td.
+func (c Planner) PatchTaskDetails(
+	ctx context.Context,
+	taskID string,
+	td models.PlannerTaskDetailsable,
+) error {
+	if td == nil {
+		return nil
+	}
+
+	body := models.NewPlannerTaskDetails()
+	body.SetDescription(td.GetDescription())
+	body.SetPreviewType(td.GetPreviewType())
+
+	if checklist := restorableChecklist(td.GetChecklist()); checklist != nil {
+		body.SetChecklist(checklist)
+	}
+
+	if refs := restorableReferences(td.GetReferences()); refs != nil {
+		body.SetReferences(refs)
+	}
+
+	db := c.Stable.
+		Client().
+		Planner().
+		Tasks().
+		ByPlannerTaskId(taskID).
+		Details()
+
+	current, err := db.Get(ctx, nil)
+	if err != nil {
+		return clues.Wrap(err, "getting task details")
+	}
+
+	_, err = db.Patch(
+		ctx,
+		body,
+		&planner.TasksItemDetailsRequestBuilderPatchRequestConfiguration{
+			Headers: ifMatchHeaders(current.GetAdditionalData()),
+		})
+
+	return clues.Wrap(err, "updating task details").OrNil()
+}
+
+// DeletePlan removes the plan, along with all of its buckets and tasks.
+func (c Planner) DeletePlan(
+	ctx context.Context,
+	planID string,
+) error {
+	// deletes require unique http clients
+	// https://github.com/alcionai/corso/issues/2707
+	srv, err := c.Service(c.counter)
+	if err != nil {
+		return clues.StackWC(ctx, err)
+	}
+
+	pb := srv.
+		Client().
+		Planner().
+		Plans().
+		ByPlannerPlanId(planID)
+
+	current, err := pb.Get(ctx, nil)
+	if err != nil {
+		return clues.Wrap(err, "getting plan")
+	}
+
+	err = pb.Delete(
+		ctx,
+		&planner.PlansPlannerPlanItemRequestBuilderDeleteRequestConfiguration{
+			Headers: ifMatchHeaders(current.GetAdditionalData()),
+		})
+
+	return clues.Wrap(err, "deleting plan").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func PlannerPlanInfo(plan models.PlannerPlanable, size int64) *details.GroupsInfo {
+	var (
+		created = ptr.Val(plan.GetCreatedDateTime())
+		creator string
+	)
+
+	if plan.GetCreatedBy() != nil && plan.GetCreatedBy().GetUser() != nil {
+		creator = ptr.Val(plan.GetCreatedBy().GetUser().GetDisplayName())
+		if len(creator) == 0 {
+			creator = ptr.Val(plan.GetCreatedBy().GetUser().GetId())
+		}
+	}
+
+	return &details.GroupsInfo{
+		ItemType: details.GroupsPlannerPlan,
+		// planner doesn't track a modification time for plans.
+		Modified: created,
+		Size:     size,
+		Plan: details.PlannerPlanInfo{
+			BucketCount: len(plan.GetBuckets()),
+			CreatedAt:   created,
+			Creator:     creator,
+			TaskCount:   len(plan.GetTasks()),
+			Title:       ptr.Val(plan.GetTitle()),
+		},
+	}
+}
+
+func plannerPlanSize(plan models.PlannerPlanable) (int64, error) {
+	bs, err := plannerPlanBytes(plan)
+	return int64(len(bs)), clues.Stack(err).OrNil()
+}
+
+func plannerPlanBytes(plan models.PlannerPlanable) ([]byte, error) {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", plan); err != nil {
+		return nil, clues.Wrap(err, "serializing plan")
+	}
+
+	bs, err := writer.GetSerializedContent()
+
+	return bs, clues.Wrap(err, "serializing plan").OrNil()
+}
+
+// BytesToPlannerPlanable deserializes the bytes into a plan.
+func BytesToPlannerPlanable(body []byte) (models.PlannerPlanable, error) {
+	v, err := CreateFromBytes(body, models.CreatePlannerPlanFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Wrap(err, "deserializing bytes to plan")
+	}
+
+	return v.(models.PlannerPlanable), nil
+}
+
+// planner updates require the etag of the current version of the object.
+func ifMatchHeaders(additionalData map[string]any) *abstractions.RequestHeaders {
+	headers := abstractions.NewRequestHeaders()
+
+	if etag, ok := additionalData[plannerETagKey]; ok {
+		headers.Add(headerKeyIfMatch, plannerString(etag))
+	}
+
+	return headers
+}
+
+// plannerString reads a string value out of additionalData, which holds
+// either plain or pointer strings depending on how the object was built.
+func plannerString(v any) string {
+	switch s := v.(type) {
+	case *string:
+		return ptr.Val(s)
+	case string:
+		return s
+	}
+
+	return ""
+}
+
+// plannerProperties copies the named properties of an open-typed planner
+// entry (ex: a single checklist item) into a new entry of the given type,
+// dropping read-only values like the last modified info.
+func plannerProperties(v any, odataType string, keys ...string) map[string]any {
+	props, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	result := map[string]any{"@odata.type": odataType}
+
+	for _, k := range keys {
+		if pv, ok := props[k]; ok && pv != nil {
+			result[k] = pv
+		}
+	}
+
+	return result
+}
+
+func restorableAssignments(pa models.PlannerAssignmentsable) models.PlannerAssignmentsable {
+	if pa == nil || len(pa.GetAdditionalData()) == 0 {
+		return nil
+	}
+
+	ad := map[string]any{}
+
+	for userID := range pa.GetAdditionalData() {
+		ad[userID] = map[string]any{
+			"@odata.type": plannerAssignmentODataType,
+			"orderHint":   plannerDefaultOrderHint,
+		}
+	}
+
+	result := models.NewPlannerAssignments()
+	result.SetAdditionalData(ad)
+
+	return result
+}
+
+func restorableChecklist(pc models.PlannerChecklistItemsable) models.PlannerChecklistItemsable {
+	if pc == nil || len(pc.GetAdditionalData()) == 0 {
+		return nil
+	}
+
+	ad := map[string]any{}
+
+	for id, v := range pc.GetAdditionalData() {
+		if item := plannerProperties(v, plannerChecklistItemODataType, "title", "isChecked", "orderHint"); item != nil {
+			ad[id] = item
+		}
+	}
+
+	result := models.NewPlannerChecklistItems()
+	result.SetAdditionalData(ad)
+
+	return result
+}
+
+func restorableReferences(pr models.PlannerExternalReferencesable) models.PlannerExternalReferencesable {
+	if pr == nil || len(pr.GetAdditionalData()) == 0 {
+		return nil
+	}
+
+	ad := map[string]any{}
+
+	for url, v := range pr.GetAdditionalData() {
+		if ref := plannerProperties(v, plannerReferenceODataType, "alias", "type", "previewPriority"); ref != nil {
+			ad[url] = ref
+		}
+	}
+
+	result := models.NewPlannerExternalReferences()
+	result.SetAdditionalData(ad)
+
+	return result
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/planner"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// ---------------------------------------------------------------------------
+// plans pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.PlannerPlanable] = &plansPageCtrl{}
+
+type plansPageCtrl struct {
+	resourceID string
+	gs         graph.Servicer
+	builder    *groups.ItemPlannerPlansRequestBuilder
+	options    *groups.ItemPlannerPlansRequestBuilderGetRequestConfiguration
+}
+
+func (p *plansPageCtrl) SetNextLink(nextLink string) {
+	p.builder = groups.NewItemPlannerPlansRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *plansPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.PlannerPlanable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *plansPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Planner) NewPlansPager(
+	groupID string,
+	cc CallConfig,
+) *plansPageCtrl {
+	builder := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Planner().
+		Plans()
+
+	options := &groups.ItemPlannerPlansRequestBuilderGetRequestConfiguration{
+		QueryParameters: &groups.ItemPlannerPlansRequestBuilderGetQueryParameters{},
+	}
+
+	if len(cc.Select) > 0 {
+		options.QueryParameters.Select = cc.Select
+	}
+
+	return &plansPageCtrl{
+		resourceID: groupID,
+		builder:    builder,
+		gs:         c.Stable,
+		options:    options,
+	}
+}
+
+// GetPlans fetches all planner plans owned by the group.
+func (c Planner) GetPlans(
+	ctx context.Context,
+	groupID string,
+	cc CallConfig,
+) ([]models.PlannerPlanable, error) {
+	pager := c.NewPlansPager(groupID, cc)
+	items, err := pagers.BatchEnumerateItems[models.PlannerPlanable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// plan buckets pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.PlannerBucketable] = &planBucketsPageCtrl{}
+
+type planBucketsPageCtrl struct {
+	planID  string
+	gs      graph.Servicer
+	builder *planner.PlansItemBucketsRequestBuilder
+}
+
+func (p *planBucketsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = planner.NewPlansItemBucketsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *planBucketsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.PlannerBucketable], error) {
+	resp, err := p.builder.Get(ctx, nil)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *planBucketsPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Planner) NewPlanBucketsPager(planID string) *planBucketsPageCtrl {
+	builder := c.Stable.
+		Client().
+		Planner().
+		Plans().
+		ByPlannerPlanId(planID).
+		Buckets()
+
+	return &planBucketsPageCtrl{
+		planID:  planID,
+		builder: builder,
+		gs:      c.Stable,
+	}
+}
+
+// GetPlanBuckets fetches all buckets in the plan.
+func (c Planner) GetPlanBuckets(
+	ctx context.Context,
+	planID string,
+) ([]models.PlannerBucketable, error) {
+	pager := c.NewPlanBucketsPager(planID)
+	items, err := pagers.BatchEnumerateItems[models.PlannerBucketable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// plan tasks pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.PlannerTaskable] = &planTasksPageCtrl{}
+
+type planTasksPageCtrl struct {
+	planID  string
+	gs      graph.Servicer
+	builder *planner.PlansItemTasksRequestBuilder
+}
+
+func (p *planTasksPageCtrl) SetNextLink(nextLink string) {
+	p.builder = planner.NewPlansItemTasksRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *planTasksPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.PlannerTaskable], error) {
+	resp, err := p.builder.Get(ctx, nil)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *planTasksPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Planner) NewPlanTasksPager(planID string) *planTasksPageCtrl {
+	builder := c.Stable.
+		Client().
+		Planner().
+		Plans().
+		ByPlannerPlanId(planID).
+		Tasks()
+
+	return &planTasksPageCtrl{
+		planID:  planID,
+		builder: builder,
+		gs:      c.Stable,
+	}
+}
+
+// GetPlanTasks fetches all tasks in the plan.
+func (c Planner) GetPlanTasks(
+	ctx context.Context,
+	planID string,
+) ([]models.PlannerTaskable, error) {
+	pager := c.NewPlanTasksPager(planID)
+	items, err := pagers.BatchEnumerateItems[models.PlannerTaskable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
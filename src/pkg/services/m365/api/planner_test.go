@@ -0,0 +1,238 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/h2non/gock"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/tester/tconfig"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+type PlannerUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlannerUnitSuite(t *testing.T) {
+	suite.Run(t, &PlannerUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlannerUnitSuite) TestGetPlan() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := tconfig.NewFakeM365Account(t)
+	creds, err := a.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+	require.NoError(t, err, clues.ToCore(err))
+
+	t.Cleanup(gock.Off)
+
+	// gock matches paths by regex, so the more specific paths are
+	// registered first.
+	interceptV1Path("planner", "plans", "pid", "details").
+		Reply(200).
+		JSON(map[string]any{
+			"id": "pid",
+			"categoryDescriptions": map[string]any{
+				"category1": "urgent",
+			},
+		})
+
+	interceptV1Path("planner", "plans", "pid", "buckets").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{
+				{"id": "bid", "name": "to do", "planId": "pid", "orderHint": "8585"},
+			},
+		})
+
+	interceptV1Path("planner", "plans", "pid", "tasks").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{
+				{
+					"id":       "tid",
+					"title":    "write the docs",
+					"planId":   "pid",
+					"bucketId": "bid",
+					"assignments": map[string]any{
+						"uid": map[string]any{
+							"@odata.type": plannerAssignmentODataType,
+							"orderHint":   "8585",
+						},
+					},
+				},
+			},
+		})
+
+	interceptV1Path("planner", "plans", "pid").
+		Reply(200).
+		JSON(map[string]any{
+			"id":              "pid",
+			"title":           "launch",
+			"createdDateTime": "2024-01-02T03:04:05Z",
+			"createdBy": map[string]any{
+				"user": map[string]any{"id": "uid", "displayName": "a user"},
+			},
+		})
+
+	interceptV1Path("planner", "tasks", "tid", "details").
+		Reply(200).
+		JSON(map[string]any{
+			"id":          "tid",
+			"description": "all of them",
+			"checklist": map[string]any{
+				"cid": map[string]any{
+					"@odata.type": plannerChecklistItemODataType,
+					"title":       "readme",
+					"isChecked":   true,
+				},
+			},
+		})
+
+	plan, info, err := client.Planner().GetPlan(ctx, "pid")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.False(t, gock.HasUnmatchedRequest(), "unmatched graph calls")
+
+	assert.Equal(t, "launch", ptr.Val(plan.GetTitle()))
+	require.NotNil(t, plan.GetDetails())
+	assert.Equal(t, "urgent", ptr.Val(plan.GetDetails().GetCategoryDescriptions().GetCategory1()))
+	require.Len(t, plan.GetBuckets(), 1)
+	require.Len(t, plan.GetTasks(), 1)
+
+	task := plan.GetTasks()[0]
+	require.NotNil(t, task.GetDetails())
+	assert.Equal(t, "all of them", ptr.Val(task.GetDetails().GetDescription()))
+	assert.Contains(t, task.GetDetails().GetChecklist().GetAdditionalData(), "cid")
+	assert.Contains(t, task.GetAssignments().GetAdditionalData(), "uid")
+
+	assert.Equal(t, details.GroupsPlannerPlan, info.ItemType)
+	assert.Equal(t, "launch", info.Plan.Title)
+	assert.Equal(t, "a user", info.Plan.Creator)
+	assert.Equal(t, 1, info.Plan.BucketCount)
+	assert.Equal(t, 1, info.Plan.TaskCount)
+	assert.NotZero(t, info.Size)
+
+	// the serialized plan must survive a round trip.
+	bs, err := plannerPlanBytes(plan)
+	require.NoError(t, err, clues.ToCore(err))
+
+	result, err := BytesToPlannerPlanable(bs)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, result.GetTasks(), 1)
+	assert.Equal(t, "all of them", ptr.Val(result.GetTasks()[0].GetDetails().GetDescription()))
+	assert.Contains(t, result.GetTasks()[0].GetDetails().GetChecklist().GetAdditionalData(), "cid")
+}
+
+func (suite *PlannerUnitSuite) TestRestorableTaskProperties() {
+	t := suite.T()
+
+	checklist := models.NewPlannerChecklistItems()
+	checklist.SetAdditionalData(map[string]any{
+		"cid": map[string]any{
+			"@odata.type":          plannerChecklistItemODataType,
+			"title":                ptr.To("readme"),
+			"isChecked":            ptr.To(true),
+			"orderHint":            ptr.To("8585"),
+			"lastModifiedDateTime": ptr.To("2024-01-02T03:04:05Z"),
+			"lastModifiedBy":       map[string]any{"user": map[string]any{"id": ptr.To("uid")}},
+		},
+	})
+
+	rc := restorableChecklist(checklist)
+	require.NotNil(t, rc)
+
+	item := rc.GetAdditionalData()["cid"].(map[string]any)
+	assert.Equal(t, plannerChecklistItemODataType, item["@odata.type"])
+	assert.Equal(t, "readme", ptr.Val(item["title"].(*string)))
+	assert.True(t, ptr.Val(item["isChecked"].(*bool)))
+	assert.NotContains(t, item, "lastModifiedDateTime")
+	assert.NotContains(t, item, "lastModifiedBy")
+
+	refs := models.NewPlannerExternalReferences()
+	refs.SetAdditionalData(map[string]any{
+		"https%3A//contoso%2Ecom": map[string]any{
+			"alias":          ptr.To("contoso"),
+			"type":           ptr.To("Other"),
+			"lastModifiedBy": map[string]any{},
+		},
+	})
+
+	rr := restorableReferences(refs)
+	require.NotNil(t, rr)
+
+	ref := rr.GetAdditionalData()["https%3A//contoso%2Ecom"].(map[string]any)
+	assert.Equal(t, plannerReferenceODataType, ref["@odata.type"])
+	assert.Equal(t, "contoso", ptr.Val(ref["alias"].(*string)))
+	assert.NotContains(t, ref, "lastModifiedBy")
+
+	assignments := models.NewPlannerAssignments()
+	assignments.SetAdditionalData(map[string]any{
+		"uid": map[string]any{
+			"assignedDateTime": ptr.To("2024-01-02T03:04:05Z"),
+			"orderHint":        ptr.To("8585"),
+		},
+	})
+
+	ra := restorableAssignments(assignments)
+	require.NotNil(t, ra)
+
+	assignment := ra.GetAdditionalData()["uid"].(map[string]any)
+	assert.Equal(t, plannerAssignmentODataType, assignment["@odata.type"])
+	assert.Equal(t, plannerDefaultOrderHint, assignment["orderHint"])
+	assert.NotContains(t, assignment, "assignedDateTime")
+
+	assert.Nil(t, restorableChecklist(models.NewPlannerChecklistItems()))
+	assert.Nil(t, restorableReferences(nil))
+	assert.Nil(t, restorableAssignments(nil))
+}
+
+func (suite *PlannerUnitSuite) TestPatchTaskDetails() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := tconfig.NewFakeM365Account(t)
+	creds, err := a.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+	require.NoError(t, err, clues.ToCore(err))
+
+	t.Cleanup(gock.Off)
+
+	interceptV1Path("planner", "tasks", "tid", "details").
+		Reply(200).
+		JSON(map[string]any{
+			"id":          "tid",
+			"@odata.etag": `W/"etag"`,
+		})
+
+	gock.New(graphAPIHostURL).
+		Patch(v1APIURLPath("planner", "tasks", "tid", "details")).
+		MatchHeader(headerKeyIfMatch, `W/"etag"`).
+		BodyString(`.*"description":"all of them".*`).
+		Reply(204)
+
+	td := models.NewPlannerTaskDetails()
+	td.SetDescription(ptr.To("all of them"))
+
+	err = client.Planner().PatchTaskDetails(ctx, "tid", td)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.True(t, gock.IsDone(), "all graph calls made")
+}
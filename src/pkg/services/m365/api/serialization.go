@@ -23,3 +23,27 @@ func CreateFromBytes(
 
 	return v, nil
 }
+
+// contentBudget tracks how much more file content can be stored within the
+// item that is currently being populated.  Content that gets embedded into
+// an item is held in memory, base64 encoded, until the item is persisted, so
+// the budget bounds the size of that item.
+type contentBudget struct {
+	remaining int64
+}
+
+func newContentBudget(limit int64) *contentBudget {
+	return &contentBudget{remaining: limit}
+}
+
+// take reserves size bytes of the budget.  Returns false, without reserving
+// anything, if the content doesn't fit.
+func (b *contentBudget) take(size int64) bool {
+	if size > b.remaining {
+		return false
+	}
+
+	b.remaining -= size
+
+	return true
+}
@@ -0,0 +1,549 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alcionai/clues"
+)
+
+// graph doesn't expose a site's navigation, SharePoint groups, features, or
+// theme, so they're handled through the SharePoint REST api.
+// https://learn.microsoft.com/en-us/sharepoint/dev/sp-add-ins/get-to-know-the-sharepoint-rest-service
+const (
+	navigationURLFmt        = "%s/_api/web/navigation/%s"
+	navigationNodeURLFmt    = "%s/_api/web/navigation/GetNodeById(%d)"
+	siteGroupsURLFmt        = "%s/_api/web/sitegroups"
+	siteGroupURLFmt         = "%s/_api/web/sitegroups/GetById(%d)"
+	siteFeaturesURLFmt      = "%s/_api/web/features"
+	siteFeatureAddURLFmt    = "%s/_api/web/features/add(featureId=guid'%s',force=false)"
+	siteThemeShadeURLFmt    = "%s/_api/web/ThemeInfo/GetThemeShadeByName(name='%s')"
+	siteThemeApplyURLFmt    = "%s/_api/ThemeManager/ApplyTheme"
+	restoredSiteThemeName   = "Corso Restored Theme"
+	sharingLinksGroupPrefix = "SharingLinks."
+	limitedAccessGroupTitle = "Limited Access System Group"
+)
+
+const (
+	// QuickLaunch is the navigation shown on the side of the site.
+	QuickLaunch = "QuickLaunch"
+	// TopNavigationBar is the navigation shown across the top of the site.
+	TopNavigationBar = "TopNavigationBar"
+)
+
+// siteThemeSlots are the palette slots of a modern SharePoint theme.
+var siteThemeSlots = []string{
+	"themePrimary",
+	"themeLighterAlt",
+	"themeLighter",
+	"themeLight",
+	"themeTertiary",
+	"themeSecondary",
+	"themeDarkAlt",
+	"themeDark",
+	"themeDarker",
+	"neutralLighterAlt",
+	"neutralLighter",
+	"neutralLight",
+	"neutralQuaternaryAlt",
+	"neutralQuaternary",
+	"neutralTertiaryAlt",
+	"neutralTertiary",
+	"neutralSecondary",
+	"neutralPrimaryAlt",
+	"neutralPrimary",
+	"neutralDark",
+	"black",
+	"white",
+}
+
+var sharePointRESTWriteHeaders = map[string]string{
+	"Accept":       "application/json;odata=nometadata",
+	"Content-Type": "application/json;odata=nometadata",
+}
+
+var sharePointRESTMergeHeaders = map[string]string{
+	"Accept":        "application/json;odata=nometadata",
+	"Content-Type":  "application/json;odata=nometadata",
+	"X-HTTP-Method": "MERGE",
+	"IF-MATCH":      "*",
+}
+
+// SiteWebConfig is the configuration of a site that's only available
+// through the SharePoint REST api.
+type SiteWebConfig struct {
+	QuickLaunch   []NavigationNode `json:"quickLaunch,omitempty"`
+	TopNavigation []NavigationNode `json:"topNavigation,omitempty"`
+	Groups        []SiteGroup      `json:"groups,omitempty"`
+	// FeatureIDs are the definition ids of the features activated in the
+	// site.
+	FeatureIDs []string   `json:"featureIds,omitempty"`
+	Theme      *SiteTheme `json:"theme,omitempty"`
+}
+
+// NavigationNode is a link in the site's navigation, along with the links
+// nested beneath it.
+type NavigationNode struct {
+	ID         int              `json:"Id"`
+	Title      string           `json:"Title"`
+	URL        string           `json:"Url"`
+	IsExternal bool             `json:"IsExternal"`
+	Children   []NavigationNode `json:"Children,omitempty"`
+}
+
+// SiteGroup is a SharePoint permission group of the site collection.
+type SiteGroup struct {
+	ID                             int    `json:"Id"`
+	Title                          string `json:"Title"`
+	Description                    string `json:"Description"`
+	AllowMembersEditMembership     bool   `json:"AllowMembersEditMembership"`
+	OnlyAllowMembersViewMembership bool   `json:"OnlyAllowMembersViewMembership"`
+	// Members holds the login names of the group's users.
+	Members []string `json:"Members,omitempty"`
+}
+
+// SiteTheme is the color palette applied to the site, keyed by slot name,
+// with each color as #rrggbb.
+type SiteTheme struct {
+	Palette map[string]string `json:"palette"`
+}
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) SiteWeb() SiteWeb {
+	return SiteWeb{c}
+}
+
+// SiteWeb is an interface-compliant provider of the client.
+type SiteWeb struct {
+	Client
+}
+
+// ---------------------------------------------------------------------------
+// backup
+// ---------------------------------------------------------------------------
+
+// GetSiteWebConfig fetches the site's navigation, SharePoint groups with
+// their members, activated features, and theme.  SharePoint manages the
+// groups that back sharing links and limited access, so those are left out.
+func (c SiteWeb) GetSiteWebConfig(
+	ctx context.Context,
+	siteID string,
+) (*SiteWebConfig, error) {
+	siteURL, err := c.GetSiteWebURL(ctx, siteID)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	ctx = clues.Add(ctx, "site_url", clues.Hide(siteURL))
+
+	quickLaunch, err := c.GetNavigation(ctx, siteURL, QuickLaunch)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting quick launch navigation")
+	}
+
+	topNav, err := c.GetNavigation(ctx, siteURL, TopNavigationBar)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting top navigation")
+	}
+
+	groups, err := c.GetSiteGroups(ctx, siteURL)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	features, err := c.GetSiteFeatureIDs(ctx, siteURL)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	theme, err := c.GetSiteTheme(ctx, siteURL)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	cfg := &SiteWebConfig{
+		QuickLaunch:   quickLaunch,
+		TopNavigation: topNav,
+		Groups:        groups,
+		FeatureIDs:    features,
+		Theme:         theme,
+	}
+
+	return cfg, nil
+}
+
+// GetSiteWebURL returns the url of the site's web, which addresses the
+// site in the SharePoint REST api.
+func (c SiteWeb) GetSiteWebURL(ctx context.Context, siteID string) (string, error) {
+	return c.getSiteWebURL(ctx, siteID)
+}
+
+// GetNavigation fetches the nodes of the site's navigation at the location,
+// either QuickLaunch or TopNavigationBar, with all of their descendants.
+func (c SiteWeb) GetNavigation(
+	ctx context.Context,
+	siteURL, location string,
+) ([]NavigationNode, error) {
+	return c.getNavigationNodes(ctx, fmt.Sprintf(navigationURLFmt, siteURL, location), siteURL)
+}
+
+func (c SiteWeb) getNavigationNodes(
+	ctx context.Context,
+	nodesURL, siteURL string,
+) ([]NavigationNode, error) {
+	var resp struct {
+		Value []NavigationNode `json:"value"`
+	}
+
+	if err := c.sharePointGet(ctx, nodesURL, &resp); err != nil {
+		return nil, clues.Wrap(err, "getting navigation nodes")
+	}
+
+	for i, node := range resp.Value {
+		nctx := clues.Add(ctx, "navigation_node_id", node.ID)
+
+		children, err := c.getNavigationNodes(
+			nctx,
+			fmt.Sprintf(navigationNodeURLFmt, siteURL, node.ID)+"/Children",
+			siteURL)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Value[i].Children = children
+	}
+
+	return resp.Value, nil
+}
+
+// GetSiteGroups fetches the site collection's SharePoint groups along with
+// the login names of their members.
+func (c SiteWeb) GetSiteGroups(
+	ctx context.Context,
+	siteURL string,
+) ([]SiteGroup, error) {
+	var resp struct {
+		Value []struct {
+			SiteGroup
+			Users []struct {
+				LoginName string `json:"LoginName"`
+			} `json:"Users"`
+		} `json:"value"`
+	}
+
+	err := c.sharePointGet(ctx, fmt.Sprintf(siteGroupsURLFmt, siteURL)+"?$expand=Users", &resp)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting site groups")
+	}
+
+	groups := make([]SiteGroup, 0, len(resp.Value))
+
+	for _, v := range resp.Value {
+		if strings.HasPrefix(v.Title, sharingLinksGroupPrefix) || v.Title == limitedAccessGroupTitle {
+			continue
+		}
+
+		group := v.SiteGroup
+		group.Members = make([]string, 0, len(v.Users))
+
+		for _, u := range v.Users {
+			group.Members = append(group.Members, u.LoginName)
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// GetSiteFeatureIDs fetches the definition ids of the features activated
+// in the site.
+func (c SiteWeb) GetSiteFeatureIDs(
+	ctx context.Context,
+	siteURL string,
+) ([]string, error) {
+	var resp struct {
+		Value []struct {
+			DefinitionID string `json:"DefinitionId"`
+		} `json:"value"`
+	}
+
+	err := c.sharePointGet(ctx, fmt.Sprintf(siteFeaturesURLFmt, siteURL)+"?$select=DefinitionId", &resp)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting site features")
+	}
+
+	ids := make([]string, 0, len(resp.Value))
+
+	for _, v := range resp.Value {
+		ids = append(ids, v.DefinitionID)
+	}
+
+	return ids, nil
+}
+
+// GetSiteTheme fetches the colors of the site's theme.  Returns nil if
+// the site has no theme colors.
+func (c SiteWeb) GetSiteTheme(
+	ctx context.Context,
+	siteURL string,
+) (*SiteTheme, error) {
+	palette := map[string]string{}
+
+	for _, slot := range siteThemeSlots {
+		var resp struct {
+			Value string `json:"value"`
+		}
+
+		err := c.sharePointGet(ctx, fmt.Sprintf(siteThemeShadeURLFmt, siteURL, slot), &resp)
+		if err != nil {
+			return nil, clues.Wrap(err, "getting site theme").With("theme_slot", slot)
+		}
+
+		if color := themeColor(resp.Value); len(color) > 0 {
+			palette[slot] = color
+		}
+	}
+
+	if len(palette) == 0 {
+		return nil, nil
+	}
+
+	return &SiteTheme{Palette: palette}, nil
+}
+
+// themeColor converts the shade, which SharePoint reports as hex ARGB
+// without a leading #, into #rrggbb.
+func themeColor(shade string) string {
+	shade = strings.TrimPrefix(shade, "#")
+
+	switch len(shade) {
+	case 8:
+		return "#" + strings.ToLower(shade[2:])
+	case 6:
+		return "#" + strings.ToLower(shade)
+	}
+
+	return ""
+}
+
+// ---------------------------------------------------------------------------
+// restore
+// ---------------------------------------------------------------------------
+
+// PostNavigationNode adds the node, without its children, to the site's
+// navigation.  The node is added beneath the parent node, or at the top
+// level of the location, either QuickLaunch or TopNavigationBar, if no
+// parent id is provided.  Returns the created node.
+func (c SiteWeb) PostNavigationNode(
+	ctx context.Context,
+	siteURL, location string,
+	parentID int,
+	node NavigationNode,
+) (NavigationNode, error) {
+	nodesURL := fmt.Sprintf(navigationURLFmt, siteURL, location)
+	if parentID != 0 {
+		nodesURL = fmt.Sprintf(navigationNodeURLFmt, siteURL, parentID) + "/Children"
+	}
+
+	body := map[string]any{
+		"Title":      node.Title,
+		"Url":        node.URL,
+		"IsExternal": node.IsExternal,
+		"AsLastNode": true,
+	}
+
+	var created NavigationNode
+
+	err := c.sharePointSend(ctx, nodesURL, sharePointRESTWriteHeaders, body, &created)
+
+	return created, clues.Wrap(err, "creating navigation node").OrNil()
+}
+
+// PatchNavigationNode points the site's navigation node at the node's url.
+func (c SiteWeb) PatchNavigationNode(
+	ctx context.Context,
+	siteURL string,
+	nodeID int,
+	node NavigationNode,
+) error {
+	body := map[string]any{"Url": node.URL}
+
+	err := c.sharePointSend(
+		ctx,
+		fmt.Sprintf(navigationNodeURLFmt, siteURL, nodeID),
+		sharePointRESTMergeHeaders,
+		body,
+		nil)
+
+	return clues.Wrap(err, "updating navigation node").OrNil()
+}
+
+// PostSiteGroup creates the group, without its members, in the site
+// collection.  Returns the created group.
+func (c SiteWeb) PostSiteGroup(
+	ctx context.Context,
+	siteURL string,
+	group SiteGroup,
+) (SiteGroup, error) {
+	var created SiteGroup
+
+	err := c.sharePointSend(
+		ctx,
+		fmt.Sprintf(siteGroupsURLFmt, siteURL),
+		sharePointRESTWriteHeaders,
+		restorableSiteGroup(group, true),
+		&created)
+
+	return created, clues.Wrap(err, "creating site group").OrNil()
+}
+
+// PatchSiteGroup updates the settings of the site group to match the group.
+func (c SiteWeb) PatchSiteGroup(
+	ctx context.Context,
+	siteURL string,
+	groupID int,
+	group SiteGroup,
+) error {
+	err := c.sharePointSend(
+		ctx,
+		fmt.Sprintf(siteGroupURLFmt, siteURL, groupID),
+		sharePointRESTMergeHeaders,
+		restorableSiteGroup(group, false),
+		nil)
+
+	return clues.Wrap(err, "updating site group").OrNil()
+}
+
+// PostSiteGroupMember adds the user with the login name to the site group.
+func (c SiteWeb) PostSiteGroupMember(
+	ctx context.Context,
+	siteURL string,
+	groupID int,
+	loginName string,
+) error {
+	err := c.sharePointSend(
+		ctx,
+		fmt.Sprintf(siteGroupURLFmt, siteURL, groupID)+"/users",
+		sharePointRESTWriteHeaders,
+		map[string]any{"LoginName": loginName},
+		nil)
+
+	return clues.Wrap(err, "adding site group member").OrNil()
+}
+
+// ActivateSiteFeature activates the feature in the site.
+func (c SiteWeb) ActivateSiteFeature(
+	ctx context.Context,
+	siteURL, featureID string,
+) error {
+	_, err := c.sharePointRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf(siteFeatureAddURLFmt, siteURL, featureID),
+		nil)
+
+	return clues.Wrap(err, "activating site feature").OrNil()
+}
+
+// ApplySiteTheme applies the theme's colors to the site.
+func (c SiteWeb) ApplySiteTheme(
+	ctx context.Context,
+	siteURL string,
+	theme SiteTheme,
+) error {
+	themeJSON, err := json.Marshal(map[string]any{"palette": theme.Palette})
+	if err != nil {
+		return clues.Wrap(err, "serializing site theme")
+	}
+
+	body := map[string]any{
+		"name":      restoredSiteThemeName,
+		"themeJson": string(themeJSON),
+	}
+
+	err = c.sharePointSend(
+		ctx,
+		fmt.Sprintf(siteThemeApplyURLFmt, siteURL),
+		sharePointRESTWriteHeaders,
+		body,
+		nil)
+
+	return clues.Wrap(err, "applying site theme").OrNil()
+}
+
+// restorableSiteGroup copies the settings of the group which can be written
+// back.  The title can only be provided on creation.
+func restorableSiteGroup(group SiteGroup, withTitle bool) map[string]any {
+	body := map[string]any{
+		"Description":                    group.Description,
+		"AllowMembersEditMembership":     group.AllowMembersEditMembership,
+		"OnlyAllowMembersViewMembership": group.OnlyAllowMembersViewMembership,
+	}
+
+	if withTitle {
+		body["Title"] = group.Title
+	}
+
+	return body
+}
+
+// ---------------------------------------------------------------------------
+// helpers
+// ---------------------------------------------------------------------------
+
+// sharePointGet gets the url and deserializes the response into resp.
+func (c Client) sharePointGet(ctx context.Context, reqURL string, resp any) error {
+	bs, err := c.sharePointRequest(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return err
+	}
+
+	return clues.Wrap(json.Unmarshal(bs, resp), "parsing response").OrNil()
+}
+
+// sharePointSend posts the body to the url as json, and deserializes the
+// response into resp, unless resp is nil.
+func (c Client) sharePointSend(
+	ctx context.Context,
+	reqURL string,
+	headers map[string]string,
+	body, resp any,
+) error {
+	bs, err := json.Marshal(body)
+	if err != nil {
+		return clues.Wrap(err, "serializing request body")
+	}
+
+	r, err := c.sharePointResponse(ctx, http.MethodPost, reqURL, bytes.NewReader(bs), headers)
+	if err != nil {
+		return err
+	}
+
+	defer r.Body.Close()
+
+	if resp == nil {
+		return nil
+	}
+
+	return clues.Wrap(json.NewDecoder(r.Body).Decode(resp), "parsing response").OrNil()
+}
+
+// NavigationNodeCount counts the nodes of the navigation, including all
+// nested nodes.
+func NavigationNodeCount(nodes []NavigationNode) int {
+	n := len(nodes)
+
+	for _, node := range nodes {
+		n += NavigationNodeCount(node.Children)
+	}
+
+	return n
+}
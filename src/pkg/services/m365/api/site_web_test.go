@@ -0,0 +1,313 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+const siteWebTestURL = "https://example.com/sites/s"
+
+type SiteWebUnitSuite struct {
+	tester.Suite
+}
+
+func TestSiteWebUnitSuite(t *testing.T) {
+	suite.Run(t, &SiteWebUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *SiteWebUnitSuite) TestGetNavigation() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	nodeURL := func(id int) string {
+		return fmt.Sprintf(navigationNodeURLFmt, siteWebTestURL, id) + "/Children"
+	}
+
+	rqr := &mockSharePointRequester{
+		responses: map[string]string{
+			siteWebTestURL + "/_api/web/navigation/QuickLaunch": `{"value":[
+				{"Id":1,"Title":"Home","Url":"/sites/s","IsExternal":false},
+				{"Id":2,"Title":"Bing","Url":"https://bing.com","IsExternal":true}]}`,
+			nodeURL(1): `{"value":[{"Id":3,"Title":"Docs","Url":"/sites/s/docs"}]}`,
+			nodeURL(2): `{"value":[]}`,
+			nodeURL(3): `{"value":[]}`,
+		},
+	}
+
+	c := SiteWeb{Client{SharePointRequester: rqr}}
+
+	nodes, err := c.GetNavigation(ctx, siteWebTestURL, QuickLaunch)
+	require.NoError(t, err, clues.ToCore(err))
+
+	expect := []NavigationNode{
+		{
+			ID:    1,
+			Title: "Home",
+			URL:   "/sites/s",
+			Children: []NavigationNode{
+				{ID: 3, Title: "Docs", URL: "/sites/s/docs", Children: []NavigationNode{}},
+			},
+		},
+		{ID: 2, Title: "Bing", URL: "https://bing.com", IsExternal: true, Children: []NavigationNode{}},
+	}
+
+	assert.Equal(t, expect, nodes)
+	assert.Equal(t, 3, NavigationNodeCount(nodes))
+}
+
+func (suite *SiteWebUnitSuite) TestGetSiteGroups() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	rqr := &mockSharePointRequester{
+		responses: map[string]string{
+			siteWebTestURL + "/_api/web/sitegroups?$expand=Users": `{"value":[
+				{"Id":5,"Title":"Owners","Description":"d","AllowMembersEditMembership":true,
+					"Users":[{"LoginName":"i:0#.f|membership|a@example.com"}]},
+				{"Id":6,"Title":"SharingLinks.abc.Flexible","Users":[]},
+				{"Id":7,"Title":"Limited Access System Group","Users":[]}]}`,
+		},
+	}
+
+	c := SiteWeb{Client{SharePointRequester: rqr}}
+
+	groups, err := c.GetSiteGroups(ctx, siteWebTestURL)
+	require.NoError(t, err, clues.ToCore(err))
+
+	expect := []SiteGroup{
+		{
+			ID:                         5,
+			Title:                      "Owners",
+			Description:                "d",
+			AllowMembersEditMembership: true,
+			Members:                    []string{"i:0#.f|membership|a@example.com"},
+		},
+	}
+
+	assert.Equal(t, expect, groups, "system managed groups are left out")
+}
+
+func (suite *SiteWebUnitSuite) TestGetSiteFeatureIDs() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	rqr := &mockSharePointRequester{
+		responses: map[string]string{
+			siteWebTestURL + "/_api/web/features?$select=DefinitionId": `{"value":[{"DefinitionId":"f1"},{"DefinitionId":"f2"}]}`,
+		},
+	}
+
+	c := SiteWeb{Client{SharePointRequester: rqr}}
+
+	ids, err := c.GetSiteFeatureIDs(ctx, siteWebTestURL)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, []string{"f1", "f2"}, ids)
+}
+
+func (suite *SiteWebUnitSuite) TestGetSiteTheme() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	rqr := &mockSharePointRequester{responses: map[string]string{}}
+	expect := map[string]string{}
+
+	for _, slot := range siteThemeSlots {
+		rqr.responses[fmt.Sprintf(siteThemeShadeURLFmt, siteWebTestURL, slot)] = `{"value":"FF0078D4"}`
+		expect[slot] = "#0078d4"
+	}
+
+	// unset slots are left out of the palette.
+	rqr.responses[fmt.Sprintf(siteThemeShadeURLFmt, siteWebTestURL, "black")] = `{"value":""}`
+	delete(expect, "black")
+
+	c := SiteWeb{Client{SharePointRequester: rqr}}
+
+	theme, err := c.GetSiteTheme(ctx, siteWebTestURL)
+	require.NoError(t, err, clues.ToCore(err))
+	require.NotNil(t, theme)
+	assert.Equal(t, expect, theme.Palette)
+}
+
+func (suite *SiteWebUnitSuite) TestGetSiteTheme_error() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	rqr := &mockSharePointRequester{
+		statuses: map[string]int{
+			fmt.Sprintf(siteThemeShadeURLFmt, siteWebTestURL, siteThemeSlots[0]): http.StatusForbidden,
+		},
+	}
+
+	c := SiteWeb{Client{SharePointRequester: rqr}}
+
+	_, err := c.GetSiteTheme(ctx, siteWebTestURL)
+	require.Error(t, err, clues.ToCore(err))
+	assert.True(t, IsErrSharePointAccessDenied(err), clues.ToCore(err))
+}
+
+func (suite *SiteWebUnitSuite) TestThemeColor() {
+	table := []struct {
+		shade  string
+		expect string
+	}{
+		{shade: "FF0078D4", expect: "#0078d4"},
+		{shade: "0078D4", expect: "#0078d4"},
+		{shade: "#0078d4", expect: "#0078d4"},
+		{shade: "", expect: ""},
+		{shade: "bad", expect: ""},
+	}
+	for _, test := range table {
+		suite.Run(test.shade, func() {
+			assert.Equal(suite.T(), test.expect, themeColor(test.shade))
+		})
+	}
+}
+
+func (suite *SiteWebUnitSuite) TestPostNavigationNode() {
+	table := []struct {
+		name      string
+		parentID  int
+		expectURL string
+	}{
+		{
+			name:      "top level",
+			expectURL: siteWebTestURL + "/_api/web/navigation/TopNavigationBar",
+		},
+		{
+			name:      "child",
+			parentID:  4,
+			expectURL: siteWebTestURL + "/_api/web/navigation/GetNodeById(4)/Children",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			rqr := &mockSharePointRequester{
+				responses: map[string]string{
+					test.expectURL: `{"Id":9,"Title":"Docs","Url":"/docs"}`,
+				},
+			}
+
+			c := SiteWeb{Client{SharePointRequester: rqr}}
+
+			created, err := c.PostNavigationNode(
+				ctx,
+				siteWebTestURL,
+				TopNavigationBar,
+				test.parentID,
+				NavigationNode{ID: 1, Title: "Docs", URL: "/docs"})
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, 9, created.ID)
+			assert.Equal(t, []string{http.MethodPost + " " + test.expectURL}, rqr.requests)
+
+			var body map[string]any
+
+			err = json.Unmarshal([]byte(rqr.bodies[0]), &body)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, "Docs", body["Title"])
+			assert.Equal(t, "/docs", body["Url"])
+			assert.NotContains(t, body, "Id")
+		})
+	}
+}
+
+func (suite *SiteWebUnitSuite) TestPatchSiteGroup() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	groupURL := siteWebTestURL + "/_api/web/sitegroups/GetById(5)"
+
+	rqr := &mockSharePointRequester{
+		responses: map[string]string{groupURL: ""},
+	}
+
+	c := SiteWeb{Client{SharePointRequester: rqr}}
+
+	err := c.PatchSiteGroup(ctx, siteWebTestURL, 5, SiteGroup{Title: "Owners", Description: "d"})
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, []string{http.MethodPost + " " + groupURL}, rqr.requests)
+	assert.Equal(t, "MERGE", rqr.headers[0]["X-HTTP-Method"])
+
+	var body map[string]any
+
+	err = json.Unmarshal([]byte(rqr.bodies[0]), &body)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "d", body["Description"])
+	assert.NotContains(t, body, "Title", "titles can't be updated")
+}
+
+func (suite *SiteWebUnitSuite) TestActivateSiteFeature() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	addURL := siteWebTestURL + "/_api/web/features/add(featureId=guid'f1',force=false)"
+
+	rqr := &mockSharePointRequester{
+		responses: map[string]string{addURL: "{}"},
+	}
+
+	c := SiteWeb{Client{SharePointRequester: rqr}}
+
+	err := c.ActivateSiteFeature(ctx, siteWebTestURL, "f1")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, []string{http.MethodPost + " " + addURL}, rqr.requests)
+}
+
+func (suite *SiteWebUnitSuite) TestApplySiteTheme() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	applyURL := siteWebTestURL + "/_api/ThemeManager/ApplyTheme"
+
+	rqr := &mockSharePointRequester{
+		responses: map[string]string{applyURL: "{}"},
+	}
+
+	c := SiteWeb{Client{SharePointRequester: rqr}}
+
+	err := c.ApplySiteTheme(ctx, siteWebTestURL, SiteTheme{Palette: map[string]string{"themePrimary": "#0078d4"}})
+	require.NoError(t, err, clues.ToCore(err))
+
+	var body struct {
+		Name      string `json:"name"`
+		ThemeJSON string `json:"themeJson"`
+	}
+
+	err = json.Unmarshal([]byte(rqr.bodies[0]), &body)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, restoredSiteThemeName, body.Name)
+	assert.JSONEq(t, `{"palette":{"themePrimary":"#0078d4"}}`, body.ThemeJSON)
+}
@@ -0,0 +1,416 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/teams"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+)
+
+const (
+	// moderation settings are only available in the beta api.
+	channelBetaURLTemplate = "https://graph.microsoft.com/beta/teams/%s/channels/%s"
+	teamsAppBindURLFmt     = "https://graph.microsoft.com/v1.0/appCatalogs/teamsApps/%s"
+	userBindURLFmt         = "https://graph.microsoft.com/v1.0/users('%s')"
+
+	ChannelModerationSettingsKey = "moderationSettings"
+
+	teamsAppBindKey                    = "teamsApp@odata.bind"
+	userBindKey                        = "user@odata.bind"
+	aadUserConversationMemberODataType = "#microsoft.graph.aadUserConversationMember"
+	channelOwnerRole                   = "owner"
+)
+
+// ---------------------------------------------------------------------------
+// Item (team structure)
+// ---------------------------------------------------------------------------
+
+// GetTeamStructure retrieves the team's settings, along with its channels
+// and installed apps.  Each channel is populated with its tabs, and either
+// its moderation settings (standard channels) or its owners (private and
+// shared channels).
+func (c Groups) GetTeamStructure(
+	ctx context.Context,
+	teamID string,
+) (models.Teamable, *details.GroupsInfo, error) {
+	ctx = clues.Add(ctx, "team_id", teamID)
+
+	team, err := c.GetTeamByID(ctx, teamID, CallConfig{})
+	if err != nil {
+		return nil, nil, clues.Stack(err)
+	}
+
+	chans, err := c.Channels().GetChannels(ctx, teamID)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting channels")
+	}
+
+	for _, ch := range chans {
+		channelID := ptr.Val(ch.GetId())
+		cctx := clues.Add(ctx, "channel_id", channelID)
+
+		tabs, err := c.Channels().GetChannelTabs(cctx, teamID, channelID)
+		if err != nil {
+			return nil, nil, clues.Wrap(err, "getting channel tabs")
+		}
+
+		ch.SetTabs(tabs)
+
+		if ptr.Val(ch.GetMembershipType()) == models.STANDARD_CHANNELMEMBERSHIPTYPE {
+			settings, err := c.Channels().GetChannelModerationSettings(cctx, teamID, channelID)
+			if err != nil {
+				return nil, nil, clues.Stack(err)
+			}
+
+			if settings != nil {
+				ad := ch.GetAdditionalData()
+				if ad == nil {
+					ad = map[string]any{}
+				}
+
+				ad[ChannelModerationSettingsKey] = settings
+				ch.SetAdditionalData(ad)
+			}
+
+			continue
+		}
+
+		members, err := c.Channels().GetChannelMembers(cctx, teamID, channelID)
+		if err != nil {
+			return nil, nil, clues.Wrap(err, "getting channel members")
+		}
+
+		// only the owners are kept; they're required to recreate the channel.
+		ch.SetMembers(channelOwners(members))
+	}
+
+	team.SetChannels(chans)
+
+	apps, err := c.GetInstalledApps(ctx, teamID)
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "getting installed apps")
+	}
+
+	team.SetInstalledApps(apps)
+
+	size, err := teamStructureSize(team)
+	if err != nil {
+		return nil, nil, clues.Stack(err)
+	}
+
+	return team, TeamStructureInfo(team, size), nil
+}
+
+// GetChannelModerationSettings returns the channel's moderation settings in
+// their untyped form, or nil if the channel has none.
+func (c Channels) GetChannelModerationSettings(
+	ctx context.Context,
+	teamID, channelID string,
+) (any, error) {
+	rawURL := fmt.Sprintf(channelBetaURLTemplate, teamID, channelID)
+
+	ch, err := teams.
+		NewItemChannelsChannelItemRequestBuilder(rawURL, c.Stable.Adapter()).
+		Get(ctx, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting channel moderation settings")
+	}
+
+	return ch.GetAdditionalData()[ChannelModerationSettingsKey], nil
+}
+
+// ---------------------------------------------------------------------------
+// Restore
+// ---------------------------------------------------------------------------
+
+// PutTeam creates a team for the group, using the settings of the provided
+// team.
+func (c Groups) PutTeam(
+	ctx context.Context,
+	groupID string,
+	team models.Teamable,
+) (models.Teamable, error) {
+	resp, err := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Team().
+		Put(ctx, restorableTeamSettings(team), nil)
+
+	return resp, clues.Wrap(err, "creating team").OrNil()
+}
+
+// PatchTeam updates the team's settings to match the provided team.
+func (c Groups) PatchTeam(
+	ctx context.Context,
+	teamID string,
+	team models.Teamable,
+) error {
+	_, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		Patch(ctx, restorableTeamSettings(team), nil)
+
+	return clues.Wrap(err, "updating team settings").OrNil()
+}
+
+// PostInstalledApp installs the app from the app catalog in the team.
+func (c Groups) PostInstalledApp(
+	ctx context.Context,
+	teamID, appID string,
+) error {
+	body := models.NewTeamsAppInstallation()
+	body.SetAdditionalData(map[string]any{
+		teamsAppBindKey: fmt.Sprintf(teamsAppBindURLFmt, appID),
+	})
+
+	_, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		InstalledApps().
+		Post(ctx, body, nil)
+
+	return clues.Wrap(err, "installing app").With("app_id", appID).OrNil()
+}
+
+// PostChannel creates a channel in the team with the same name, description,
+// and membership type as the provided channel.  Private and shared channels
+// are created with the provided channel's owners.
+func (c Channels) PostChannel(
+	ctx context.Context,
+	teamID string,
+	channel models.Channelable,
+) (models.Channelable, error) {
+	body := models.NewChannel()
+	body.SetDisplayName(channel.GetDisplayName())
+	body.SetDescription(channel.GetDescription())
+	body.SetMembershipType(channel.GetMembershipType())
+
+	if ptr.Val(channel.GetMembershipType()) == models.STANDARD_CHANNELMEMBERSHIPTYPE {
+		body.SetIsFavoriteByDefault(channel.GetIsFavoriteByDefault())
+	} else {
+		body.SetMembers(restorableChannelOwners(channel.GetMembers()))
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		Channels().
+		Post(ctx, body, nil)
+
+	return resp, clues.Wrap(err, "creating channel").OrNil()
+}
+
+// PatchChannelModerationSettings applies the untyped moderation settings
+// to the channel.
+func (c Channels) PatchChannelModerationSettings(
+	ctx context.Context,
+	teamID, channelID string,
+	settings any,
+) error {
+	rawURL := fmt.Sprintf(channelBetaURLTemplate, teamID, channelID)
+
+	body := models.NewChannel()
+	body.SetAdditionalData(map[string]any{
+		ChannelModerationSettingsKey: settings,
+	})
+
+	_, err := teams.
+		NewItemChannelsChannelItemRequestBuilder(rawURL, c.Stable.Adapter()).
+		Patch(ctx, body, nil)
+
+	return clues.Wrap(err, "updating channel moderation settings").OrNil()
+}
+
+// PostTab adds a tab to the channel with the same name, app, and
+// configuration as the provided tab.  The tab's app must already be
+// installed in the team.
+func (c Channels) PostTab(
+	ctx context.Context,
+	teamID, channelID string,
+	tab models.TeamsTabable,
+) (models.TeamsTabable, error) {
+	body := models.NewTeamsTab()
+	body.SetDisplayName(tab.GetDisplayName())
+	body.SetAdditionalData(map[string]any{
+		teamsAppBindKey: fmt.Sprintf(teamsAppBindURLFmt, TeamsTabAppID(tab)),
+	})
+
+	if cfg := tab.GetConfiguration(); cfg != nil {
+		rc := models.NewTeamsTabConfiguration()
+		rc.SetEntityId(cfg.GetEntityId())
+		rc.SetContentUrl(cfg.GetContentUrl())
+		rc.SetWebsiteUrl(cfg.GetWebsiteUrl())
+		rc.SetRemoveUrl(cfg.GetRemoveUrl())
+		body.SetConfiguration(rc)
+	}
+
+	resp, err := c.Stable.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		Channels().
+		ByChannelId(channelID).
+		Tabs().
+		Post(ctx, body, nil)
+
+	return resp, clues.Wrap(err, "creating tab").OrNil()
+}
+
+// DeleteTab removes the tab from the channel.
+func (c Channels) DeleteTab(
+	ctx context.Context,
+	teamID, channelID, tabID string,
+) error {
+	// deletes require unique http clients
+	// https://github.com/alcionai/corso/issues/2707
+	srv, err := c.Service(c.counter)
+	if err != nil {
+		return clues.StackWC(ctx, err)
+	}
+
+	err = srv.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		Channels().
+		ByChannelId(channelID).
+		Tabs().
+		ByTeamsTabId(tabID).
+		Delete(ctx, nil)
+
+	return clues.Wrap(err, "deleting tab").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func TeamStructureInfo(team models.Teamable, size int64) *details.GroupsInfo {
+	var tabs int
+
+	for _, ch := range team.GetChannels() {
+		tabs += len(ch.GetTabs())
+	}
+
+	return &details.GroupsInfo{
+		ItemType: details.GroupsTeamStructure,
+		// teams don't track a modification time.
+		Modified: ptr.Val(team.GetCreatedDateTime()),
+		Size:     size,
+		Team: details.TeamStructureInfo{
+			AppCount:     len(team.GetInstalledApps()),
+			ChannelCount: len(team.GetChannels()),
+			DisplayName:  ptr.Val(team.GetDisplayName()),
+			TabCount:     tabs,
+		},
+	}
+}
+
+// TeamsTabAppID returns the ID of the app that backs the tab.
+func TeamsTabAppID(tab models.TeamsTabable) string {
+	if tab.GetTeamsApp() == nil {
+		return ""
+	}
+
+	return ptr.Val(tab.GetTeamsApp().GetId())
+}
+
+// TeamsInstalledAppID returns the catalog ID of the installed app.
+func TeamsInstalledAppID(app models.TeamsAppInstallationable) string {
+	if app.GetTeamsApp() != nil {
+		return ptr.Val(app.GetTeamsApp().GetId())
+	}
+
+	if app.GetTeamsAppDefinition() != nil {
+		return ptr.Val(app.GetTeamsAppDefinition().GetTeamsAppId())
+	}
+
+	return ""
+}
+
+func teamStructureSize(team models.Teamable) (int64, error) {
+	bs, err := teamStructureBytes(team)
+	return int64(len(bs)), clues.Stack(err).OrNil()
+}
+
+func teamStructureBytes(team models.Teamable) ([]byte, error) {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", team); err != nil {
+		return nil, clues.Wrap(err, "serializing team")
+	}
+
+	bs, err := writer.GetSerializedContent()
+
+	return bs, clues.Wrap(err, "serializing team").OrNil()
+}
+
+// BytesToTeamable deserializes the bytes into a team.
+func BytesToTeamable(body []byte) (models.Teamable, error) {
+	v, err := CreateFromBytes(body, models.CreateTeamFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Wrap(err, "deserializing bytes to team")
+	}
+
+	return v.(models.Teamable), nil
+}
+
+func channelOwners(members []models.ConversationMemberable) []models.ConversationMemberable {
+	owners := []models.ConversationMemberable{}
+
+	for _, m := range members {
+		if slices.Contains(m.GetRoles(), channelOwnerRole) {
+			owners = append(owners, m)
+		}
+	}
+
+	return owners
+}
+
+// restorableTeamSettings copies the settings of the team which can be
+// written back, leaving out the read-only properties.
+func restorableTeamSettings(team models.Teamable) models.Teamable {
+	body := models.NewTeam()
+	body.SetMemberSettings(team.GetMemberSettings())
+	body.SetGuestSettings(team.GetGuestSettings())
+	body.SetMessagingSettings(team.GetMessagingSettings())
+	body.SetFunSettings(team.GetFunSettings())
+
+	return body
+}
+
+func restorableChannelOwners(
+	members []models.ConversationMemberable,
+) []models.ConversationMemberable {
+	owners := []models.ConversationMemberable{}
+
+	for _, m := range members {
+		am, ok := m.(models.AadUserConversationMemberable)
+		if !ok || len(ptr.Val(am.GetUserId())) == 0 {
+			continue
+		}
+
+		owner := models.NewAadUserConversationMember()
+		owner.SetOdataType(ptr.To(aadUserConversationMemberODataType))
+		owner.SetRoles([]string{channelOwnerRole})
+		owner.SetAdditionalData(map[string]any{
+			userBindKey: fmt.Sprintf(userBindURLFmt, ptr.Val(am.GetUserId())),
+		})
+
+		owners = append(owners, owner)
+	}
+
+	return owners
+}
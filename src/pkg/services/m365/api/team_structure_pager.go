@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/teams"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// ---------------------------------------------------------------------------
+// channel tabs pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.TeamsTabable] = &channelTabsPageCtrl{}
+
+type channelTabsPageCtrl struct {
+	gs      graph.Servicer
+	builder *teams.ItemChannelsItemTabsRequestBuilder
+	options *teams.ItemChannelsItemTabsRequestBuilderGetRequestConfiguration
+}
+
+func (p *channelTabsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = teams.NewItemChannelsItemTabsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *channelTabsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.TeamsTabable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *channelTabsPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Channels) NewChannelTabsPager(
+	teamID, channelID string,
+) *channelTabsPageCtrl {
+	builder := c.Stable.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		Channels().
+		ByChannelId(channelID).
+		Tabs()
+
+	options := &teams.ItemChannelsItemTabsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &teams.ItemChannelsItemTabsRequestBuilderGetQueryParameters{
+			// the app is needed to recreate the tab.
+			Expand: []string{"teamsApp"},
+		},
+	}
+
+	return &channelTabsPageCtrl{
+		builder: builder,
+		gs:      c.Stable,
+		options: options,
+	}
+}
+
+// GetChannelTabs fetches all tabs configured in the channel.
+func (c Channels) GetChannelTabs(
+	ctx context.Context,
+	teamID, channelID string,
+) ([]models.TeamsTabable, error) {
+	pager := c.NewChannelTabsPager(teamID, channelID)
+	items, err := pagers.BatchEnumerateItems[models.TeamsTabable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// channel members pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.ConversationMemberable] = &channelMembersPageCtrl{}
+
+type channelMembersPageCtrl struct {
+	gs      graph.Servicer
+	builder *teams.ItemChannelsItemMembersRequestBuilder
+	options *teams.ItemChannelsItemMembersRequestBuilderGetRequestConfiguration
+}
+
+func (p *channelMembersPageCtrl) SetNextLink(nextLink string) {
+	p.builder = teams.NewItemChannelsItemMembersRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *channelMembersPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.ConversationMemberable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *channelMembersPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Channels) NewChannelMembersPager(
+	teamID, channelID string,
+) *channelMembersPageCtrl {
+	builder := c.Stable.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		Channels().
+		ByChannelId(channelID).
+		Members()
+
+	return &channelMembersPageCtrl{
+		builder: builder,
+		gs:      c.Stable,
+		options: &teams.ItemChannelsItemMembersRequestBuilderGetRequestConfiguration{},
+	}
+}
+
+// GetChannelMembers fetches all members of the channel.
+func (c Channels) GetChannelMembers(
+	ctx context.Context,
+	teamID, channelID string,
+) ([]models.ConversationMemberable, error) {
+	pager := c.NewChannelMembersPager(teamID, channelID)
+	items, err := pagers.BatchEnumerateItems[models.ConversationMemberable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// installed apps pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.TeamsAppInstallationable] = &installedAppsPageCtrl{}
+
+type installedAppsPageCtrl struct {
+	gs      graph.Servicer
+	builder *teams.ItemInstalledAppsRequestBuilder
+	options *teams.ItemInstalledAppsRequestBuilderGetRequestConfiguration
+}
+
+func (p *installedAppsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = teams.NewItemInstalledAppsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *installedAppsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.TeamsAppInstallationable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *installedAppsPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Groups) NewInstalledAppsPager(
+	teamID string,
+) *installedAppsPageCtrl {
+	builder := c.Stable.
+		Client().
+		Teams().
+		ByTeamId(teamID).
+		InstalledApps()
+
+	options := &teams.ItemInstalledAppsRequestBuilderGetRequestConfiguration{
+		QueryParameters: &teams.ItemInstalledAppsRequestBuilderGetQueryParameters{
+			Expand: []string{"teamsApp", "teamsAppDefinition"},
+		},
+	}
+
+	return &installedAppsPageCtrl{
+		builder: builder,
+		gs:      c.Stable,
+		options: options,
+	}
+}
+
+// GetInstalledApps fetches all apps installed in the team.
+func (c Groups) GetInstalledApps(
+	ctx context.Context,
+	teamID string,
+) ([]models.TeamsAppInstallationable, error) {
+	pager := c.NewInstalledAppsPager(teamID)
+	items, err := pagers.BatchEnumerateItems[models.TeamsAppInstallationable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/h2non/gock"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/tester/tconfig"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+type TeamStructureUnitSuite struct {
+	tester.Suite
+}
+
+func TestTeamStructureUnitSuite(t *testing.T) {
+	suite.Run(t, &TeamStructureUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *TeamStructureUnitSuite) TestGetTeamStructure() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := tconfig.NewFakeM365Account(t)
+	creds, err := a.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+	require.NoError(t, err, clues.ToCore(err))
+
+	t.Cleanup(gock.Off)
+
+	// gock matches paths by regex, so the more specific paths are
+	// registered first.
+	interceptV1Path("teams", "tid", "channels", "cid", "tabs").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{
+				{
+					"id":          "tabid",
+					"displayName": "wiki",
+					"teamsApp":    map[string]any{"id": "appid"},
+					"configuration": map[string]any{
+						"entityId":   "eid",
+						"contentUrl": "https://contoso.com",
+					},
+				},
+			},
+		})
+
+	interceptV1Path("teams", "tid", "channels", "pcid", "tabs").
+		Reply(200).
+		JSON(map[string]any{"value": []map[string]any{}})
+
+	interceptV1Path("teams", "tid", "channels", "pcid", "members").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{
+				{
+					"@odata.type": aadUserConversationMemberODataType,
+					"id":          "mid1",
+					"userId":      "owner",
+					"roles":       []string{"owner"},
+				},
+				{
+					"@odata.type": aadUserConversationMemberODataType,
+					"id":          "mid2",
+					"userId":      "member",
+					"roles":       []string{},
+				},
+			},
+		})
+
+	gock.New(graphAPIHostURL).
+		Get("/beta/teams/tid/channels/cid").
+		Reply(200).
+		JSON(map[string]any{
+			"id": "cid",
+			"moderationSettings": map[string]any{
+				"userNewMessageRestriction": "moderators",
+				"allowNewMessageFromBots":   true,
+			},
+		})
+
+	interceptV1Path("teams", "tid", "channels").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{
+				{"id": "cid", "displayName": "General", "membershipType": "standard"},
+				{"id": "pcid", "displayName": "secrets", "membershipType": "private"},
+			},
+		})
+
+	interceptV1Path("teams", "tid", "installedApps").
+		Reply(200).
+		JSON(map[string]any{
+			"value": []map[string]any{
+				{
+					"id":                 "iid",
+					"teamsApp":           map[string]any{"id": "appid", "displayName": "wiki"},
+					"teamsAppDefinition": map[string]any{"teamsAppId": "appid"},
+				},
+			},
+		})
+
+	interceptV1Path("teams", "tid").
+		Reply(200).
+		JSON(map[string]any{
+			"id":          "tid",
+			"displayName": "marketing",
+			"funSettings": map[string]any{"allowGiphy": false},
+		})
+
+	team, info, err := client.Groups().GetTeamStructure(ctx, "tid")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.False(t, gock.HasUnmatchedRequest(), "unmatched graph calls")
+
+	require.Len(t, team.GetChannels(), 2)
+
+	general := team.GetChannels()[0]
+	require.Len(t, general.GetTabs(), 1)
+	assert.Equal(t, "appid", TeamsTabAppID(general.GetTabs()[0]))
+	assert.Contains(t, general.GetAdditionalData(), ChannelModerationSettingsKey)
+
+	private := team.GetChannels()[1]
+	require.Len(t, private.GetMembers(), 1, "only owners are kept")
+
+	require.Len(t, team.GetInstalledApps(), 1)
+	assert.Equal(t, "appid", TeamsInstalledAppID(team.GetInstalledApps()[0]))
+
+	assert.Equal(t, details.GroupsTeamStructure, info.ItemType)
+	assert.Equal(t, "marketing", info.Team.DisplayName)
+	assert.Equal(t, 2, info.Team.ChannelCount)
+	assert.Equal(t, 1, info.Team.TabCount)
+	assert.Equal(t, 1, info.Team.AppCount)
+	assert.NotZero(t, info.Size)
+
+	// the serialized team must survive a round trip.
+	bs, err := teamStructureBytes(team)
+	require.NoError(t, err, clues.ToCore(err))
+
+	result, err := BytesToTeamable(bs)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, result.GetChannels(), 2)
+	assert.False(t, ptr.Val(result.GetFunSettings().GetAllowGiphy()))
+	assert.Contains(t, result.GetChannels()[0].GetAdditionalData(), ChannelModerationSettingsKey)
+	assert.Equal(t, "appid", TeamsTabAppID(result.GetChannels()[0].GetTabs()[0]))
+	require.Len(t, result.GetChannels()[1].GetMembers(), 1)
+}
+
+func (suite *TeamStructureUnitSuite) TestPostChannel_private() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := tconfig.NewFakeM365Account(t)
+	creds, err := a.M365Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	client, err := gockClient(creds, count.New(), graph.MaxRetries(1))
+	require.NoError(t, err, clues.ToCore(err))
+
+	t.Cleanup(gock.Off)
+
+	gock.New(graphAPIHostURL).
+		Post(v1APIURLPath("teams", "tid", "channels")).
+		Reply(201).
+		JSON(map[string]any{"id": "newcid", "displayName": "secrets"})
+
+	owner := models.NewAadUserConversationMember()
+	owner.SetUserId(ptr.To("owner"))
+	owner.SetRoles([]string{"owner"})
+
+	mt := models.PRIVATE_CHANNELMEMBERSHIPTYPE
+
+	ch := models.NewChannel()
+	ch.SetDisplayName(ptr.To("secrets"))
+	ch.SetMembershipType(&mt)
+	ch.SetMembers([]models.ConversationMemberable{owner})
+
+	result, err := client.Channels().PostChannel(ctx, "tid", ch)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "newcid", ptr.Val(result.GetId()))
+	assert.True(t, gock.IsDone(), "all graph calls made")
+
+	owners := restorableChannelOwners(ch.GetMembers())
+	require.Len(t, owners, 1)
+	assert.Equal(t, []string{"owner"}, owners[0].GetRoles())
+	assert.Equal(
+		t,
+		"https://graph.microsoft.com/v1.0/users('owner')",
+		owners[0].GetAdditionalData()[userBindKey])
+}
@@ -3,11 +3,13 @@ package api
 import (
 	"context"
 
+	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/chats"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
 )
 
@@ -57,10 +59,82 @@ func (c Chats) GetChatByID(
 	return resp, TeamsChatInfo(resp), nil
 }
 
+// GetChatWithMessages retrieves the chat along with all of its messages.  The
+// hosted contents and attached files of each message are downloaded and stored
+// within the message.  Content that can't be stored is reported to errs.
+func (c Chats) GetChatWithMessages(
+	ctx context.Context,
+	chatID string,
+	errs *fault.Bus,
+) (models.Chatable, *details.TeamsChatsInfo, error) {
+	chat, info, err := c.GetChatByID(ctx, chatID, CallConfig{})
+	if err != nil {
+		return nil, nil, clues.Stack(err)
+	}
+
+	msgs, err := c.GetChatMessages(ctx, chatID, CallConfig{})
+	if err != nil {
+		return nil, nil, clues.Wrap(err, "retrieving chat messages")
+	}
+
+	budget := newContentBudget(maxChatItemContentSize)
+
+	for _, msg := range msgs {
+		c.populateChatMessageContent(
+			ctx,
+			msg,
+			chatMessageURL(chatID, ptr.Val(msg.GetId())),
+			budget,
+			errs)
+	}
+
+	chat.SetMessages(msgs)
+
+	info.Chat.MessageCount = len(msgs)
+	info.Size = chatMessagesSize(msgs)
+
+	if last := lastChatMessage(msgs); last != nil {
+		preview, _, err := getChatMessageContentPreview(last)
+		if err != nil {
+			preview = "malformed or unparseable html: " + preview
+		}
+
+		info.Chat.LastMessageAt = ptr.Val(last.GetCreatedDateTime())
+		info.Chat.LastMessagePreview = preview
+	}
+
+	return chat, info, nil
+}
+
 // ---------------------------------------------------------------------------
 // Helpers
 // ---------------------------------------------------------------------------
 
+func lastChatMessage(msgs []models.ChatMessageable) models.ChatMessageable {
+	var last models.ChatMessageable
+
+	for _, msg := range msgs {
+		if last == nil || ptr.Val(msg.GetCreatedDateTime()).After(ptr.Val(last.GetCreatedDateTime())) {
+			last = msg
+		}
+	}
+
+	return last
+}
+
+// chatMessagesSize sums the size of the messages' content, along with their
+// hosted contents and stored file attachments.
+func chatMessagesSize(msgs []models.ChatMessageable) int64 {
+	var size int64
+
+	for _, msg := range msgs {
+		_, contentLen, _ := getChatMessageContentPreview(msg)
+		size += contentLen + chatMessageContentSize(msg)
+	}
+
+	return size
+}
+
 func TeamsChatInfo(chat models.Chatable) *details.TeamsChatsInfo {
 	return &details.TeamsChatsInfo{
 		ItemType: details.TeamsChat,
@@ -0,0 +1,450 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/termstore"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+)
+
+// ListTermsKey is the additionalData key that holds the managed metadata
+// terms referenced by a list's items, resolved against the site's term
+// store at the time of backup.
+const ListTermsKey = "@corso.terms"
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) TermStore() TermStore {
+	return TermStore{c}
+}
+
+// TermStore is an interface-compliant provider of the client.
+type TermStore struct {
+	Client
+}
+
+// ---------------------------------------------------------------------------
+// backup
+// ---------------------------------------------------------------------------
+
+// GetTermStore fetches the term store as seen by the site: the tenant's
+// global term groups and the groups local to the site collection, each
+// with its term sets and their full hierarchy of terms.  System groups
+// are managed by SharePoint and are left out.
+func (c TermStore) GetTermStore(
+	ctx context.Context,
+	siteID string,
+) (termstore.Storeable, error) {
+	store, err := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		TermStore().
+		Get(ctx, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting term store")
+	}
+
+	groups, err := c.GetTermGroups(ctx, siteID)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting term groups")
+	}
+
+	kept := make([]termstore.Groupable, 0, len(groups))
+
+	for _, group := range groups {
+		if ptr.Val(group.GetScope()) == termstore.SYSTEM_TERMGROUPSCOPE {
+			continue
+		}
+
+		gctx := clues.Add(ctx, "term_group_id", ptr.Val(group.GetId()))
+
+		sets, err := c.GetTermSets(gctx, siteID, ptr.Val(group.GetId()))
+		if err != nil {
+			return nil, clues.Wrap(err, "getting term sets")
+		}
+
+		for _, set := range sets {
+			sctx := clues.Add(gctx, "term_set_id", ptr.Val(set.GetId()))
+
+			terms, err := c.getTermTree(sctx, siteID, ptr.Val(set.GetId()), "")
+			if err != nil {
+				return nil, clues.Wrap(err, "getting terms")
+			}
+
+			set.SetChildren(terms)
+		}
+
+		group.SetSets(sets)
+		kept = append(kept, group)
+	}
+
+	store.SetGroups(kept)
+
+	return store, nil
+}
+
+// getTermTree fetches the child terms of the parent term, or the top level
+// terms of the set if no parent is provided, along with all of their
+// descendants.
+func (c TermStore) getTermTree(
+	ctx context.Context,
+	siteID, setID, parentTermID string,
+) ([]termstore.Termable, error) {
+	terms, err := c.GetTerms(ctx, siteID, setID, parentTermID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, term := range terms {
+		children, err := c.getTermTree(ctx, siteID, setID, ptr.Val(term.GetId()))
+		if err != nil {
+			return nil, err
+		}
+
+		term.SetChildren(children)
+	}
+
+	return terms, nil
+}
+
+// ---------------------------------------------------------------------------
+// restore
+// ---------------------------------------------------------------------------
+
+// PostTermGroup creates a term group in the site's term store.  Only the
+// group's name and description are copied from the provided group.
+func (c TermStore) PostTermGroup(
+	ctx context.Context,
+	siteID string,
+	group termstore.Groupable,
+) (termstore.Groupable, error) {
+	body := termstore.NewGroup()
+	body.SetDisplayName(group.GetDisplayName())
+	body.SetDescription(group.GetDescription())
+
+	resp, err := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		TermStore().
+		Groups().
+		Post(ctx, body, nil)
+
+	return resp, clues.Wrap(err, "creating term group").OrNil()
+}
+
+// PostTermSet creates a term set within the term group.  Only the set's
+// names and description are copied from the provided set; its terms are
+// created separately.
+func (c TermStore) PostTermSet(
+	ctx context.Context,
+	siteID, groupID string,
+	set termstore.Setable,
+) (termstore.Setable, error) {
+	parent := termstore.NewGroup()
+	parent.SetId(ptr.To(groupID))
+
+	body := termstore.NewSet()
+	body.SetParentGroup(parent)
+	body.SetLocalizedNames(set.GetLocalizedNames())
+	body.SetDescription(set.GetDescription())
+
+	resp, err := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		TermStore().
+		Sets().
+		Post(ctx, body, nil)
+
+	return resp, clues.Wrap(err, "creating term set").OrNil()
+}
+
+// PostTerm creates a term under the parent term, or at the top level of the
+// set if no parent is provided.  Only the term's labels and descriptions are
+// copied from the provided term; its children are created separately.
+func (c TermStore) PostTerm(
+	ctx context.Context,
+	siteID, setID, parentTermID string,
+	term termstore.Termable,
+) (termstore.Termable, error) {
+	body := termstore.NewTerm()
+	body.SetLabels(term.GetLabels())
+	body.SetDescriptions(term.GetDescriptions())
+
+	setRB := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		TermStore().
+		Sets().
+		BySetId(setID)
+
+	var (
+		resp termstore.Termable
+		err  error
+	)
+
+	if len(parentTermID) == 0 {
+		resp, err = setRB.Children().Post(ctx, body, nil)
+	} else {
+		resp, err = setRB.Terms().ByTermId(parentTermID).Children().Post(ctx, body, nil)
+	}
+
+	return resp, clues.Wrap(err, "creating term").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// term references
+// ---------------------------------------------------------------------------
+
+// TermReference identifies a managed metadata term along with the term set
+// and group that hold it, so that the term can be located, or recreated,
+// in a term store that doesn't share its id.
+type TermReference struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	// Path holds the default labels of the term's ancestors within the term
+	// set, top level term first, followed by the term's own label.
+	Path      []string `json:"path"`
+	SetID     string   `json:"setId"`
+	SetName   string   `json:"setName"`
+	GroupID   string   `json:"groupId"`
+	GroupName string   `json:"groupName"`
+}
+
+// TermStoreReferences produces a reference for each term in the store,
+// keyed by the lowercased term id.
+func TermStoreReferences(store termstore.Storeable) map[string]TermReference {
+	refs := map[string]TermReference{}
+
+	if store == nil {
+		return refs
+	}
+
+	for _, group := range store.GetGroups() {
+		for _, set := range group.GetSets() {
+			base := TermReference{
+				SetID:     ptr.Val(set.GetId()),
+				SetName:   TermSetName(set),
+				GroupID:   ptr.Val(group.GetId()),
+				GroupName: ptr.Val(group.GetDisplayName()),
+			}
+
+			addTermReferences(refs, base, nil, set.GetChildren())
+		}
+	}
+
+	return refs
+}
+
+func addTermReferences(
+	refs map[string]TermReference,
+	base TermReference,
+	parentPath []string,
+	terms []termstore.Termable,
+) {
+	for _, term := range terms {
+		ref := base
+		ref.ID = ptr.Val(term.GetId())
+		ref.Label = TermLabel(term)
+		ref.Path = append(append([]string{}, parentPath...), ref.Label)
+
+		refs[strings.ToLower(ref.ID)] = ref
+
+		addTermReferences(refs, base, ref.Path, term.GetChildren())
+	}
+}
+
+// TermSetName returns the first of the set's localized names.
+func TermSetName(set termstore.Setable) string {
+	for _, ln := range set.GetLocalizedNames() {
+		if name := ptr.Val(ln.GetName()); len(name) > 0 {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// TermLabel returns the term's default label, or its first label if none
+// is marked as the default.
+func TermLabel(term termstore.Termable) string {
+	var label string
+
+	for _, l := range term.GetLabels() {
+		if ptr.Val(l.GetIsDefault()) {
+			return ptr.Val(l.GetName())
+		}
+
+		if len(label) == 0 {
+			label = ptr.Val(l.GetName())
+		}
+	}
+
+	return label
+}
+
+// ListTermIDs collects the lowercased ids of the managed metadata terms
+// referenced by the field values of the list's items.
+func ListTermIDs(list models.Listable) map[string]struct{} {
+	ids := map[string]struct{}{}
+
+	add := func(v any) {
+		md, ok := getMetadataFields(v)
+		if !ok {
+			return
+		}
+
+		if id := anyToString(md[MetadataTermGUIDKey]); len(id) > 0 {
+			ids[strings.ToLower(id)] = struct{}{}
+		}
+	}
+
+	for _, li := range list.GetItems() {
+		if li.GetFields() == nil {
+			continue
+		}
+
+		for _, v := range li.GetFields().GetAdditionalData() {
+			switch vt := v.(type) {
+			case map[string]any:
+				add(vt)
+			case []any:
+				for _, a := range vt {
+					add(a)
+				}
+			}
+		}
+	}
+
+	return ids
+}
+
+// SetListTermReferences stores the references within the list.
+func SetListTermReferences(list models.Listable, refs []TermReference) {
+	if len(refs) == 0 {
+		return
+	}
+
+	ad := list.GetAdditionalData()
+	if ad == nil {
+		ad = map[string]any{}
+	}
+
+	ad[ListTermsKey] = refs
+
+	list.SetAdditionalData(ad)
+}
+
+// ListTermReferences returns the term references stored within the list,
+// if the backup captured any.
+func ListTermReferences(list models.Listable) ([]TermReference, error) {
+	raw, ok := list.GetAdditionalData()[ListTermsKey]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	if refs, ok := raw.([]TermReference); ok {
+		return refs, nil
+	}
+
+	// deserialized lists hold the references in their untyped form.
+	bs, err := json.Marshal(raw)
+	if err != nil {
+		return nil, clues.Wrap(err, "marshalling list term references")
+	}
+
+	var refs []TermReference
+
+	err = json.Unmarshal(bs, &refs)
+
+	return refs, clues.Wrap(err, "unmarshalling list term references").OrNil()
+}
+
+// RemapListTermIDs replaces the term ids referenced by the field values of
+// the list's items, both within managed metadata values and within text
+// values such as the hidden fields that pair a label with its term id.
+// ids maps the lowercased backed up term ids to their replacements.
+func RemapListTermIDs(list models.Listable, ids map[string]string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	remap := func(s string) string {
+		for from, to := range ids {
+			if from == to {
+				continue
+			}
+
+			s = replaceFold(s, from, to)
+		}
+
+		return s
+	}
+
+	for _, li := range list.GetItems() {
+		fields := li.GetFields()
+		if fields == nil {
+			continue
+		}
+
+		for k, v := range fields.GetAdditionalData() {
+			switch vt := v.(type) {
+			case *string:
+				fields.GetAdditionalData()[k] = ptr.To(remap(ptr.Val(vt)))
+			case map[string]any:
+				remapMetadataTermID(vt, remap)
+			case []any:
+				for _, a := range vt {
+					if md, ok := a.(map[string]any); ok {
+						remapMetadataTermID(md, remap)
+					}
+				}
+			}
+		}
+	}
+}
+
+func remapMetadataTermID(md map[string]any, remap func(string) string) {
+	if _, ok := getMetadataFields(md); !ok {
+		return
+	}
+
+	md[MetadataTermGUIDKey] = ptr.To(remap(anyToString(md[MetadataTermGUIDKey])))
+}
+
+// replaceFold replaces each case-insensitive instance of old in s.
+func replaceFold(s, old, replacement string) string {
+	if len(old) == 0 {
+		return s
+	}
+
+	var (
+		sb    strings.Builder
+		lower = strings.ToLower(s)
+		lold  = strings.ToLower(old)
+	)
+
+	for {
+		i := strings.Index(lower, lold)
+		if i < 0 {
+			sb.WriteString(s)
+			return sb.String()
+		}
+
+		sb.WriteString(s[:i])
+		sb.WriteString(replacement)
+
+		s = s[i+len(old):]
+		lower = lower[i+len(old):]
+	}
+}
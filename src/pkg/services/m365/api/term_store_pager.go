@@ -0,0 +1,236 @@
+package api
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/termstore"
+	"github.com/microsoftgraph/msgraph-sdk-go/sites"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// ---------------------------------------------------------------------------
+// term groups pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[termstore.Groupable] = &termGroupsPageCtrl{}
+
+type termGroupsPageCtrl struct {
+	siteID  string
+	gs      graph.Servicer
+	builder *sites.ItemTermStoreGroupsRequestBuilder
+	options *sites.ItemTermStoreGroupsRequestBuilderGetRequestConfiguration
+}
+
+func (p *termGroupsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = sites.NewItemTermStoreGroupsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *termGroupsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[termstore.Groupable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *termGroupsPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c TermStore) NewTermGroupsPager(siteID string) *termGroupsPageCtrl {
+	builder := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		TermStore().
+		Groups()
+
+	options := &sites.ItemTermStoreGroupsRequestBuilderGetRequestConfiguration{
+		Headers: newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+	}
+
+	return &termGroupsPageCtrl{
+		siteID:  siteID,
+		builder: builder,
+		gs:      c.Stable,
+		options: options,
+	}
+}
+
+// GetTermGroups fetches the term groups visible to the site: the tenant's
+// global and system groups, and the groups local to the site collection.
+func (c TermStore) GetTermGroups(
+	ctx context.Context,
+	siteID string,
+) ([]termstore.Groupable, error) {
+	pager := c.NewTermGroupsPager(siteID)
+	items, err := pagers.BatchEnumerateItems[termstore.Groupable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// term sets pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[termstore.Setable] = &termSetsPageCtrl{}
+
+type termSetsPageCtrl struct {
+	siteID  string
+	groupID string
+	gs      graph.Servicer
+	builder *sites.ItemTermStoreGroupsItemSetsRequestBuilder
+	options *sites.ItemTermStoreGroupsItemSetsRequestBuilderGetRequestConfiguration
+}
+
+func (p *termSetsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = sites.NewItemTermStoreGroupsItemSetsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *termSetsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[termstore.Setable], error) {
+	resp, err := p.builder.Get(ctx, p.options)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *termSetsPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c TermStore) NewTermSetsPager(siteID, groupID string) *termSetsPageCtrl {
+	builder := c.Stable.
+		Client().
+		Sites().
+		BySiteId(siteID).
+		TermStore().
+		Groups().
+		ByGroupId(groupID).
+		Sets()
+
+	options := &sites.ItemTermStoreGroupsItemSetsRequestBuilderGetRequestConfiguration{
+		Headers: newPreferHeaders(preferPageSize(maxNonDeltaPageSize)),
+	}
+
+	return &termSetsPageCtrl{
+		siteID:  siteID,
+		groupID: groupID,
+		builder: builder,
+		gs:      c.Stable,
+		options: options,
+	}
+}
+
+// GetTermSets fetches the term sets within the group.  The sets' terms are
+// not included.
+func (c TermStore) GetTermSets(
+	ctx context.Context,
+	siteID, groupID string,
+) ([]termstore.Setable, error) {
+	pager := c.NewTermSetsPager(siteID, groupID)
+	items, err := pagers.BatchEnumerateItems[termstore.Setable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// terms pager
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[termstore.Termable] = &termsPageCtrl{}
+
+// termsPageCtrl pages through either the top level terms of a term set, or
+// the child terms of a term.  The two share a response type, but not a
+// request builder.
+type termsPageCtrl struct {
+	siteID       string
+	setID        string
+	parentTermID string
+	gs           graph.Servicer
+	setBuilder   *sites.ItemTermStoreSetsItemChildrenRequestBuilder
+	termBuilder  *sites.ItemTermStoreSetsItemTermsItemChildrenRequestBuilder
+	setOptions   *sites.ItemTermStoreSetsItemChildrenRequestBuilderGetRequestConfiguration
+	termOptions  *sites.ItemTermStoreSetsItemTermsItemChildrenRequestBuilderGetRequestConfiguration
+}
+
+func (p *termsPageCtrl) SetNextLink(nextLink string) {
+	if len(p.parentTermID) == 0 {
+		p.setBuilder = sites.NewItemTermStoreSetsItemChildrenRequestBuilder(nextLink, p.gs.Adapter())
+		return
+	}
+
+	p.termBuilder = sites.NewItemTermStoreSetsItemTermsItemChildrenRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *termsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[termstore.Termable], error) {
+	var (
+		resp termstore.TermCollectionResponseable
+		err  error
+	)
+
+	if len(p.parentTermID) == 0 {
+		resp, err = p.setBuilder.Get(ctx, p.setOptions)
+	} else {
+		resp, err = p.termBuilder.Get(ctx, p.termOptions)
+	}
+
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *termsPageCtrl) ValidModTimes() bool {
+	return true
+}
+
+// NewTermsPager pages through the child terms of the parent term, or the
+// top level terms of the set if no parent term is provided.
+func (c TermStore) NewTermsPager(siteID, setID, parentTermID string) *termsPageCtrl {
+	var (
+		headers = newPreferHeaders(preferPageSize(maxNonDeltaPageSize))
+		setRB   = c.Stable.
+			Client().
+			Sites().
+			BySiteId(siteID).
+			TermStore().
+			Sets().
+			BySetId(setID)
+		p = &termsPageCtrl{
+			siteID:       siteID,
+			setID:        setID,
+			parentTermID: parentTermID,
+			gs:           c.Stable,
+		}
+	)
+
+	if len(parentTermID) == 0 {
+		p.setBuilder = setRB.Children()
+		p.setOptions = &sites.ItemTermStoreSetsItemChildrenRequestBuilderGetRequestConfiguration{
+			Headers: headers,
+		}
+
+		return p
+	}
+
+	p.termBuilder = setRB.Terms().ByTermId(parentTermID).Children()
+	p.termOptions = &sites.ItemTermStoreSetsItemTermsItemChildrenRequestBuilderGetRequestConfiguration{
+		Headers: headers,
+	}
+
+	return p
+}
+
+// GetTerms fetches the child terms of the parent term, or the top level
+// terms of the set if no parent term is provided.  Grandchildren are not
+// included.
+func (c TermStore) GetTerms(
+	ctx context.Context,
+	siteID, setID, parentTermID string,
+) ([]termstore.Termable, error) {
+	pager := c.NewTermsPager(siteID, setID, parentTermID)
+	items, err := pagers.BatchEnumerateItems[termstore.Termable](ctx, pager)
+
+	return items, clues.Stack(err).OrNil()
+}
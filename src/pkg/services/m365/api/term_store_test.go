@@ -0,0 +1,216 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/models/termstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type TermStoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestTermStoreUnitSuite(t *testing.T) {
+	suite.Run(t, &TermStoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func newTestTerm(id, label string, children ...termstore.Termable) termstore.Termable {
+	other := termstore.NewLocalizedLabel()
+	other.SetName(ptr.To(label + "-fr"))
+	other.SetLanguageTag(ptr.To("fr-FR"))
+
+	def := termstore.NewLocalizedLabel()
+	def.SetName(ptr.To(label))
+	def.SetLanguageTag(ptr.To("en-US"))
+	def.SetIsDefault(ptr.To(true))
+
+	term := termstore.NewTerm()
+	term.SetId(ptr.To(id))
+	term.SetLabels([]termstore.LocalizedLabelable{other, def})
+	term.SetChildren(children)
+
+	return term
+}
+
+func newTestTermStore() termstore.Storeable {
+	name := termstore.NewLocalizedName()
+	name.SetName(ptr.To("Departments"))
+
+	set := termstore.NewSet()
+	set.SetId(ptr.To("set-id"))
+	set.SetLocalizedNames([]termstore.LocalizedNameable{name})
+	set.SetChildren([]termstore.Termable{
+		newTestTerm("A-ID", "Engineering", newTestTerm("b-id", "Platform")),
+		newTestTerm("c-id", "Sales"),
+	})
+
+	group := termstore.NewGroup()
+	group.SetId(ptr.To("group-id"))
+	group.SetDisplayName(ptr.To("Company"))
+	group.SetSets([]termstore.Setable{set})
+
+	store := termstore.NewStore()
+	store.SetGroups([]termstore.Groupable{group})
+
+	return store
+}
+
+func metadataValue(label, termID string) map[string]any {
+	return map[string]any{
+		MetadataLabelKey:    ptr.To(label),
+		MetadataTermGUIDKey: ptr.To(termID),
+		MetadataWssIDKey:    ptr.To("1"),
+	}
+}
+
+func (suite *TermStoreUnitSuite) TestTermStoreReferences() {
+	t := suite.T()
+
+	refs := TermStoreReferences(newTestTermStore())
+	require.Len(t, refs, 3)
+
+	base := TermReference{
+		SetID:     "set-id",
+		SetName:   "Departments",
+		GroupID:   "group-id",
+		GroupName: "Company",
+	}
+
+	expect := base
+	expect.ID = "A-ID"
+	expect.Label = "Engineering"
+	expect.Path = []string{"Engineering"}
+	assert.Equal(t, expect, refs["a-id"], "keyed by lowercased id")
+
+	expect = base
+	expect.ID = "b-id"
+	expect.Label = "Platform"
+	expect.Path = []string{"Engineering", "Platform"}
+	assert.Equal(t, expect, refs["b-id"])
+
+	assert.Empty(t, TermStoreReferences(nil))
+}
+
+func (suite *TermStoreUnitSuite) TestListTermReferences_roundTrip() {
+	t := suite.T()
+
+	fields := models.NewFieldValueSet()
+	fields.SetAdditionalData(map[string]any{
+		"Department": metadataValue("Platform", "B-ID"),
+		"Regions": []any{
+			metadataValue("Sales", "c-id"),
+		},
+		"Title": ptr.To("item"),
+	})
+
+	li := models.NewListItem()
+	li.SetFields(fields)
+
+	list := models.NewList()
+	list.SetItems([]models.ListItemable{li})
+
+	assert.Equal(
+		t,
+		map[string]struct{}{"b-id": {}, "c-id": {}},
+		ListTermIDs(list))
+
+	all := TermStoreReferences(newTestTermStore())
+	refs := []TermReference{all["b-id"], all["c-id"]}
+
+	SetListTermReferences(list, refs)
+
+	result, err := ListTermReferences(list)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, refs, result)
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err = writer.WriteObjectValue("", list)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	stored, err := BytesToListable(bs)
+	require.NoError(t, err, clues.ToCore(err))
+
+	result, err = ListTermReferences(stored)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, refs, result)
+
+	assert.Equal(
+		t,
+		map[string]struct{}{"b-id": {}, "c-id": {}},
+		ListTermIDs(stored))
+}
+
+func (suite *TermStoreUnitSuite) TestRemapListTermIDs() {
+	t := suite.T()
+
+	fields := models.NewFieldValueSet()
+	fields.SetAdditionalData(map[string]any{
+		"Department":   metadataValue("Platform", "B-ID"),
+		"Regions":      []any{metadataValue("Sales", "c-id")},
+		"Department_0": ptr.To("Platform|b-id;Other|x-id"),
+		"Title":        ptr.To("item"),
+	})
+
+	li := models.NewListItem()
+	li.SetFields(fields)
+
+	list := models.NewList()
+	list.SetItems([]models.ListItemable{li})
+
+	RemapListTermIDs(list, map[string]string{
+		"b-id": "new-b",
+		"c-id": "c-id",
+	})
+
+	ad := fields.GetAdditionalData()
+	assert.Equal(t, "new-b", anyToString(ad["Department"].(map[string]any)[MetadataTermGUIDKey]))
+	assert.Equal(t, "c-id", anyToString(ad["Regions"].([]any)[0].(map[string]any)[MetadataTermGUIDKey]))
+	assert.Equal(t, "Platform|new-b;Other|x-id", anyToString(ad["Department_0"]))
+	assert.Equal(t, "item", anyToString(ad["Title"]))
+}
+
+func (suite *TermStoreUnitSuite) TestReplaceFold() {
+	table := []struct {
+		name   string
+		input  string
+		old    string
+		expect string
+	}{
+		{
+			name:   "no match",
+			input:  "abc",
+			old:    "x",
+			expect: "abc",
+		},
+		{
+			name:   "mixed case",
+			input:  "a|ABC;b|abc",
+			old:    "abc",
+			expect: "a|new;b|new",
+		},
+		{
+			name:   "empty old",
+			input:  "abc",
+			expect: "abc",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, replaceFold(test.input, test.old, "new"))
+		})
+	}
+}
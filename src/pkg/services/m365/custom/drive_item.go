@@ -30,6 +30,7 @@ type DriveItem struct {
 	createdBy            *identitySet
 	createdByUser        *user
 	lastModifiedByUser   *user
+	listItemUniqueID     *string
 	additionalData       map[string]any
 }
 
@@ -112,6 +113,13 @@ func (c *DriveItem) GetLastModifiedByUser() *user {
 	return c.lastModifiedByUser
 }
 
+// GetListItemUniqueID returns the sharepoint list item unique id of the
+// item, which is only populated for items in onedrive for business and
+// sharepoint drives.
+func (c *DriveItem) GetListItemUniqueID() *string {
+	return c.listItemUniqueID
+}
+
 func (c *DriveItem) GetAdditionalData() map[string]any {
 	return c.additionalData
 }
@@ -356,6 +364,11 @@ func ToCustomDriveItem(item models.DriveItemable) *DriveItem {
 		di.lastModifiedByUser = lastModifiedByUser
 	}
 
+	if item.GetSharepointIds() != nil && item.GetSharepointIds().GetListItemUniqueId() != nil {
+		uniqueID := strings.Clone(ptr.Val(item.GetSharepointIds().GetListItemUniqueId()))
+		di.listItemUniqueID = &uniqueID
+	}
+
 	// We only use the download URL from additional data
 	aData := make(map[string]any)
 
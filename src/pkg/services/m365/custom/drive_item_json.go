@@ -0,0 +1,231 @@
+package custom
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/str"
+)
+
+// driveItemJSON is the serialized form of a DriveItem.
+type driveItemJSON struct {
+	ID                   *string            `json:"id,omitempty"`
+	Name                 *string            `json:"name,omitempty"`
+	Size                 *int64             `json:"size,omitempty"`
+	CreatedDateTime      *time.Time         `json:"createdDateTime,omitempty"`
+	LastModifiedDateTime *time.Time         `json:"lastModifiedDateTime,omitempty"`
+	Folder               bool               `json:"folder,omitempty"`
+	Package              bool               `json:"package,omitempty"`
+	Shared               bool               `json:"shared,omitempty"`
+	Deleted              bool               `json:"deleted,omitempty"`
+	Root                 bool               `json:"root,omitempty"`
+	Malware              *malwareJSON       `json:"malware,omitempty"`
+	File                 *fileItemJSON      `json:"file,omitempty"`
+	ParentReference      *itemReferenceJSON `json:"parentReference,omitempty"`
+	CreatedBy            *identitySetJSON   `json:"createdBy,omitempty"`
+	CreatedByUser        *userJSON          `json:"createdByUser,omitempty"`
+	LastModifiedByUser   *userJSON          `json:"lastModifiedByUser,omitempty"`
+	ListItemUniqueID     *string            `json:"listItemUniqueId,omitempty"`
+	AdditionalData       map[string]string  `json:"additionalData,omitempty"`
+}
+
+type malwareJSON struct {
+	Description *string `json:"description,omitempty"`
+}
+
+type fileItemJSON struct {
+	MimeType *string `json:"mimeType,omitempty"`
+}
+
+type itemReferenceJSON struct {
+	Path    *string `json:"path,omitempty"`
+	ID      *string `json:"id,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	DriveID *string `json:"driveId,omitempty"`
+}
+
+type identitySetJSON struct {
+	// User is nil when the identity set has no user.
+	User map[string]string `json:"user,omitempty"`
+}
+
+type userJSON struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// MarshalJSON serializes the item, so that the items found while enumerating
+// a drive can be saved and used again by a later backup.
+func (c *DriveItem) MarshalJSON() ([]byte, error) {
+	dij := driveItemJSON{
+		ID:                   c.id,
+		Name:                 c.name,
+		Size:                 c.size,
+		CreatedDateTime:      c.createdDateTime,
+		LastModifiedDateTime: c.lastModifiedDateTime,
+		Folder:               c.folder != nil,
+		Package:              c.pkg != nil,
+		Shared:               c.shared != nil,
+		Deleted:              c.deleted != nil,
+		Root:                 c.root != nil,
+		ListItemUniqueID:     c.listItemUniqueID,
+		AdditionalData:       toStringMap(c.additionalData),
+	}
+
+	if c.malware != nil {
+		dij.Malware = &malwareJSON{Description: c.malware.description}
+	}
+
+	if c.file != nil {
+		dij.File = &fileItemJSON{MimeType: c.file.mimeType}
+	}
+
+	if c.parentRef != nil {
+		dij.ParentReference = &itemReferenceJSON{
+			Path:    c.parentRef.path,
+			ID:      c.parentRef.id,
+			Name:    c.parentRef.name,
+			DriveID: c.parentRef.driveID,
+		}
+	}
+
+	if c.createdBy != nil {
+		dij.CreatedBy = &identitySetJSON{}
+
+		if c.createdBy.identity != nil {
+			dij.CreatedBy.User = toStringMap(c.createdBy.identity.additionalData)
+			if dij.CreatedBy.User == nil {
+				dij.CreatedBy.User = map[string]string{}
+			}
+		}
+	}
+
+	if c.createdByUser != nil {
+		dij.CreatedByUser = &userJSON{ID: c.createdByUser.id}
+	}
+
+	if c.lastModifiedByUser != nil {
+		dij.LastModifiedByUser = &userJSON{ID: c.lastModifiedByUser.id}
+	}
+
+	bs, err := json.Marshal(dij)
+
+	return bs, clues.Stack(err).OrNil()
+}
+
+// UnmarshalJSON deserializes an item serialized by MarshalJSON.
+func (c *DriveItem) UnmarshalJSON(bs []byte) error {
+	var dij driveItemJSON
+
+	if err := json.Unmarshal(bs, &dij); err != nil {
+		return clues.Wrap(err, "deserializing drive item")
+	}
+
+	*c = DriveItem{
+		id:                   dij.ID,
+		name:                 dij.Name,
+		size:                 dij.Size,
+		createdDateTime:      dij.CreatedDateTime,
+		lastModifiedDateTime: dij.LastModifiedDateTime,
+		folder:               presence(dij.Folder),
+		pkg:                  presence(dij.Package),
+		shared:               presence(dij.Shared),
+		deleted:              presence(dij.Deleted),
+		root:                 presence(dij.Root),
+		listItemUniqueID:     dij.ListItemUniqueID,
+		additionalData:       toAnyMap(dij.AdditionalData),
+	}
+
+	if dij.Malware != nil {
+		c.malware = &malware{description: dij.Malware.Description}
+	}
+
+	if dij.File != nil {
+		c.file = &fileItem{mimeType: dij.File.MimeType}
+	}
+
+	if dij.ParentReference != nil {
+		c.parentRef = &itemReference{
+			path:    dij.ParentReference.Path,
+			id:      dij.ParentReference.ID,
+			name:    dij.ParentReference.Name,
+			driveID: dij.ParentReference.DriveID,
+		}
+	}
+
+	if dij.CreatedBy != nil {
+		c.createdBy = &identitySet{}
+
+		if dij.CreatedBy.User != nil {
+			c.createdBy.identity = &identity{additionalData: toAnyMap(dij.CreatedBy.User)}
+		}
+	}
+
+	if dij.CreatedByUser != nil {
+		c.createdByUser = &user{id: dij.CreatedByUser.ID}
+	}
+
+	if dij.LastModifiedByUser != nil {
+		c.lastModifiedByUser = &user{id: dij.LastModifiedByUser.ID}
+	}
+
+	return nil
+}
+
+// Clone returns a copy of the item.  The copy doesn't share its parent
+// reference with the original, since backups rename the parent reference
+// of the items they stream.
+func (c *DriveItem) Clone() *DriveItem {
+	if c == nil {
+		return nil
+	}
+
+	clone := *c
+
+	if c.parentRef != nil {
+		ref := *c.parentRef
+		clone.parentRef = &ref
+	}
+
+	return &clone
+}
+
+func presence(present bool) *struct{} {
+	if !present {
+		return nil
+	}
+
+	return &struct{}{}
+}
+
+// toStringMap converts additional data, whose values are all strings, into
+// a map of strings.
+func toStringMap(m map[string]any) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+
+	sm := make(map[string]string, len(m))
+
+	for k := range m {
+		if v, err := str.AnyValueToString(k, m); err == nil {
+			sm[k] = v
+		}
+	}
+
+	return sm
+}
+
+// toAnyMap converts a map of strings into additional data, with values
+// typed the way ToCustomDriveItem produces them.
+func toAnyMap(sm map[string]string) map[string]any {
+	m := make(map[string]any, len(sm))
+
+	for k, v := range sm {
+		v := v
+		m[k] = &v
+	}
+
+	return m
+}
@@ -0,0 +1,155 @@
+package custom
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type driveItemJSONUnitSuite struct {
+	tester.Suite
+}
+
+func TestDriveItemJSONUnitSuite(t *testing.T) {
+	suite.Run(t, &driveItemJSONUnitSuite{
+		Suite: tester.NewUnitSuite(t),
+	})
+}
+
+func fullDriveItem() models.DriveItemable {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	di := models.NewDriveItem()
+	di.SetId(ptr.To("itemID"))
+	di.SetName(ptr.To("itemName"))
+	di.SetSize(ptr.To[int64](6))
+	di.SetCreatedDateTime(&now)
+	di.SetLastModifiedDateTime(&now)
+	di.SetPackageEscaped(models.NewPackageEscaped())
+	di.SetShared(models.NewShared())
+	di.SetDeleted(models.NewDeleted())
+
+	mw := models.NewMalware()
+	mw.SetDescription(ptr.To("malware"))
+	di.SetMalware(mw)
+
+	file := models.NewFile()
+	file.SetMimeType(ptr.To("text/plain"))
+	di.SetFile(file)
+
+	ref := models.NewItemReference()
+	ref.SetId(ptr.To("parentID"))
+	ref.SetPath(ptr.To("/drives/driveID/root:/folder"))
+	ref.SetName(ptr.To("folder"))
+	ref.SetDriveId(ptr.To("driveID"))
+	di.SetParentReference(ref)
+
+	idn := models.NewIdentity()
+	idn.SetAdditionalData(map[string]any{
+		"email":       ptr.To("user@example.com"),
+		"displayName": ptr.To("user"),
+	})
+
+	is := models.NewIdentitySet()
+	is.SetUser(idn)
+	di.SetCreatedBy(is)
+
+	cu := models.NewUser()
+	cu.SetId(ptr.To("creatorID"))
+	di.SetCreatedByUser(cu)
+
+	mu := models.NewUser()
+	mu.SetId(ptr.To("modifierID"))
+	di.SetLastModifiedByUser(mu)
+
+	spIDs := models.NewSharepointIds()
+	spIDs.SetListItemUniqueId(ptr.To("uniqueID"))
+	di.SetSharepointIds(spIDs)
+
+	di.SetAdditionalData(map[string]any{
+		"@microsoft.graph.downloadUrl": ptr.To("https://download"),
+	})
+
+	return di
+}
+
+func (suite *driveItemJSONUnitSuite) TestMarshalUnmarshal() {
+	folder := models.NewDriveItem()
+	folder.SetId(ptr.To("folderID"))
+	folder.SetName(ptr.To("folderName"))
+	folder.SetFolder(models.NewFolder())
+	folder.SetRoot(models.NewRoot())
+
+	table := []struct {
+		name string
+		item models.DriveItemable
+	}{
+		{
+			name: "file",
+			item: fullDriveItem(),
+		},
+		{
+			name: "root folder",
+			item: folder,
+		},
+		{
+			name: "created by without user",
+			item: func() models.DriveItemable {
+				di := models.NewDriveItem()
+				di.SetId(ptr.To("itemID"))
+				di.SetCreatedBy(models.NewIdentitySet())
+
+				return di
+			}(),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			expect := ToCustomDriveItem(test.item)
+
+			bs, err := json.Marshal(expect)
+			require.NoError(t, err, clues.ToCore(err))
+
+			var got DriveItem
+
+			err = json.Unmarshal(bs, &got)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, expect, &got)
+		})
+	}
+}
+
+func (suite *driveItemJSONUnitSuite) TestUnmarshal_badJSON() {
+	var got DriveItem
+
+	err := json.Unmarshal([]byte(`{"id": 1}`), &got)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *driveItemJSONUnitSuite) TestClone() {
+	t := suite.T()
+
+	orig := ToCustomDriveItem(fullDriveItem())
+	clone := orig.Clone()
+
+	assert.Equal(t, orig, clone)
+
+	clone.SetParentReference(SetParentName(clone.GetParentReference(), "drive"))
+
+	assert.Equal(t, "folder", ptr.Val(orig.GetParentReference().GetName()))
+	assert.Equal(t, "drive", ptr.Val(clone.GetParentReference().GetName()))
+
+	assert.Nil(t, (*DriveItem)(nil).Clone())
+}
@@ -81,6 +81,7 @@ func (suite *driveItemUnitSuite) TestToLiteDriveItemable() {
 				require.Nil(t, got.GetCreatedByUser())
 				require.Nil(t, got.GetLastModifiedByUser())
 				require.Nil(t, got.GetParentReference())
+				require.Nil(t, got.GetListItemUniqueID())
 				assert.Equal(t, len(got.GetAdditionalData()), 0)
 			},
 		},
@@ -339,6 +340,27 @@ func (suite *driveItemUnitSuite) TestToLiteDriveItemable() {
 				assert.Equal(t, ptr.Val(got.GetId()), ptr.Val(expected.GetId()))
 			},
 		},
+		{
+			name: "Sharepoint ids",
+			itemFunc: func() models.DriveItemable {
+				di := models.NewDriveItem()
+
+				spIDs := models.NewSharepointIds()
+				spIDs.SetListItemUniqueId(ptr.To("unique-id"))
+
+				di.SetId(&id)
+				di.SetSharepointIds(spIDs)
+
+				return di
+			},
+			validateFunc: func(
+				t *testing.T,
+				expected models.DriveItemable,
+				got *DriveItem,
+			) {
+				assert.Equal(t, "unique-id", ptr.Val(got.GetListItemUniqueID()))
+			},
+		},
 		{
 			name: "Get parent reference",
 			itemFunc: func() models.DriveItemable {
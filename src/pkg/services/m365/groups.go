@@ -8,6 +8,7 @@ import (
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/backup/membership"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -121,6 +122,92 @@ func (c client) SitesInGroup(
 	return result, nil
 }
 
+// ---------------------------------------------------------------------------
+// membership
+// ---------------------------------------------------------------------------
+
+type groupMembershipRestorer interface {
+	GetMembership(ctx context.Context, groupID string) (membership.Membership, error)
+	PostMember(ctx context.Context, groupID, userID string) error
+	PostOwner(ctx context.Context, groupID, userID string) error
+}
+
+// GroupMembership retrieves the group's current owners and members.
+func (c client) GroupMembership(
+	ctx context.Context,
+	groupID string,
+) (*membership.Membership, error) {
+	m, err := c.AC.Groups().GetMembership(ctx, groupID)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	return &m, nil
+}
+
+// RestoreGroupMembership re-adds the owners and members recorded in the
+// backup that are missing from the group.  Users are only ever added;
+// members of the group who weren't in the backup are left in place.
+// Returns the owners and members that were added, or with dryRun, the
+// ones that would be added.
+func (c client) RestoreGroupMembership(
+	ctx context.Context,
+	groupID string,
+	backedUp membership.Membership,
+	dryRun bool,
+	errs *fault.Bus,
+) ([]membership.Member, error) {
+	return restoreGroupMembership(ctx, c.AC.Groups(), groupID, backedUp, dryRun, errs)
+}
+
+func restoreGroupMembership(
+	ctx context.Context,
+	gmr groupMembershipRestorer,
+	groupID string,
+	backedUp membership.Membership,
+	dryRun bool,
+	errs *fault.Bus,
+) ([]membership.Member, error) {
+	current, err := gmr.GetMembership(ctx, groupID)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting current membership")
+	}
+
+	missing := membership.Missing(backedUp, current)
+
+	if dryRun {
+		return missing, nil
+	}
+
+	var (
+		added = make([]membership.Member, 0, len(missing))
+		el    = errs.Local()
+	)
+
+	for _, m := range missing {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx, "user_id", m.ID, "role", m.Role)
+
+		if m.Role == membership.OwnerRole {
+			err = gmr.PostOwner(ictx, groupID, m.ID)
+		} else {
+			err = gmr.PostMember(ictx, groupID, m.ID)
+		}
+
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Stack(err))
+			continue
+		}
+
+		added = append(added, m)
+	}
+
+	return added, el.Failure()
+}
+
 // ---------------------------------------------------------------------------
 // helpers
 // ---------------------------------------------------------------------------
@@ -1,6 +1,7 @@
 package m365
 
 import (
+	"context"
 	"testing"
 
 	"github.com/alcionai/clues"
@@ -14,6 +15,7 @@ import (
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/tester/its"
 	"github.com/alcionai/corso/src/internal/tester/tconfig"
+	"github.com/alcionai/corso/src/pkg/backup/membership"
 	"github.com/alcionai/corso/src/pkg/errs"
 	"github.com/alcionai/corso/src/pkg/errs/core"
 	"github.com/alcionai/corso/src/pkg/fault"
@@ -237,3 +239,121 @@ func (suite *GroupsUnitSuite) TestParseGroupFromTeamable() {
 		})
 	}
 }
+
+type mockMembershipRestorer struct {
+	current      membership.Membership
+	postMemberFn func(userID string) error
+	owners       []string
+	members      []string
+}
+
+func (m *mockMembershipRestorer) GetMembership(
+	context.Context,
+	string,
+) (membership.Membership, error) {
+	return m.current, nil
+}
+
+func (m *mockMembershipRestorer) PostMember(_ context.Context, _, userID string) error {
+	if m.postMemberFn != nil {
+		if err := m.postMemberFn(userID); err != nil {
+			return err
+		}
+	}
+
+	m.members = append(m.members, userID)
+
+	return nil
+}
+
+func (m *mockMembershipRestorer) PostOwner(_ context.Context, _, userID string) error {
+	m.owners = append(m.owners, userID)
+	return nil
+}
+
+func (suite *GroupsUnitSuite) TestRestoreGroupMembership() {
+	var (
+		owner  = membership.Member{ID: "o", UPN: "o@contoso.com", Role: membership.OwnerRole}
+		member = membership.Member{ID: "m", UPN: "m@contoso.com", Role: membership.MemberRole}
+		guest  = membership.Member{ID: "g", UPN: "g@fabrikam.com", Role: membership.GuestRole}
+		backup = membership.Membership{
+			Owners:  []membership.Member{owner},
+			Members: []membership.Member{member, guest},
+		}
+	)
+
+	table := []struct {
+		name          string
+		current       membership.Membership
+		dryRun        bool
+		postMemberFn  func(string) error
+		expectAdded   []membership.Member
+		expectOwners  []string
+		expectMembers []string
+		expectErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:          "empty group",
+			expectAdded:   []membership.Member{owner, member, guest},
+			expectOwners:  []string{"o"},
+			expectMembers: []string{"m", "g"},
+			expectErr:     assert.NoError,
+		},
+		{
+			name: "only missing members",
+			current: membership.Membership{
+				Owners:  []membership.Member{owner},
+				Members: []membership.Member{member},
+			},
+			expectAdded:   []membership.Member{guest},
+			expectMembers: []string{"g"},
+			expectErr:     assert.NoError,
+		},
+		{
+			name:        "dry run",
+			dryRun:      true,
+			expectAdded: []membership.Member{owner, member, guest},
+			expectErr:   assert.NoError,
+		},
+		{
+			name: "failed member is recoverable",
+			postMemberFn: func(id string) error {
+				if id == "g" {
+					return assert.AnError
+				}
+
+				return nil
+			},
+			expectAdded:   []membership.Member{owner, member},
+			expectOwners:  []string{"o"},
+			expectMembers: []string{"m"},
+			expectErr:     assert.NoError,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				errs = fault.New(false)
+				mock = &mockMembershipRestorer{
+					current:      test.current,
+					postMemberFn: test.postMemberFn,
+				}
+			)
+
+			added, err := restoreGroupMembership(ctx, mock, "gid", backup, test.dryRun, errs)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectAdded, added)
+			assert.Equal(t, test.expectOwners, mock.owners)
+			assert.Equal(t, test.expectMembers, mock.members)
+
+			if test.postMemberFn != nil {
+				assert.Len(t, errs.Recovered(), 1)
+			}
+		})
+	}
+}